@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/azure"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/gemini"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/notify/discord"
+	"github.com/egobogo/aiagents/internal/notify/slack"
+)
+
+// newBoardClient connects to the boards listed in the configuration, or to the single
+// Trello board named by TRELLO_BOARD_ID when none are configured. Trello credentials named by
+// identity replace the shared ones, so the board shows the agent's own member as the author.
+func newBoardClient(identity config.AgentIdentity) (board.BoardClient, error) {
+	boards := config.GetBoards()
+	if len(boards) == 0 {
+		keyEnv := credentialEnv(identity.TrelloKeyEnv, "", "TRELLO_API_KEY")
+		tokenEnv := credentialEnv(identity.TrelloTokenEnv, "", "TRELLO_TOKEN")
+		return trelloClient.NewTrelloClient(os.Getenv(keyEnv), os.Getenv(tokenEnv), os.Getenv("TRELLO_BOARD_ID")), nil
+	}
+	multi := board.NewMulti()
+	for _, b := range boards {
+		if b.Name == "" {
+			return nil, fmt.Errorf("board %q has no name", b.BoardID)
+		}
+		switch b.Provider {
+		case "", "trello":
+			keyEnv := credentialEnv(identity.TrelloKeyEnv, b.APIKeyEnv, "TRELLO_API_KEY")
+			tokenEnv := credentialEnv(identity.TrelloTokenEnv, b.TokenEnv, "TRELLO_TOKEN")
+			client := trelloClient.NewTrelloClient(os.Getenv(keyEnv), os.Getenv(tokenEnv), b.BoardID)
+			multi.Add(b.Name, board.WithColumns(client, b.Columns))
+		default:
+			return nil, fmt.Errorf("board %s: unsupported provider %q", b.Name, b.Provider)
+		}
+	}
+	return multi, nil
+}
+
+// credentialEnv picks the environment variable holding a credential: the agent's own, then the
+// board's, then def.
+func credentialEnv(agent, configured, def string) string {
+	if agent != "" {
+		return agent
+	}
+	if configured != "" {
+		return configured
+	}
+	return def
+}
+
+// newModelClient creates the chat model client for apiKey with rate-limit retries and the configured
+// providers, routing, cache and summarization. Requests take a slot of limiter, when set, which all
+// agents share.
+func newModelClient(apiKey string, limiter *model.Limiter) (model.ModelClient, error) {
+	openai := chatgpt.NewChatGPTClient(apiKey, "gpt-4o-mini", nil)
+	var client model.ModelClient = openai
+	// With "azure" or "gemini" configured, modelProvider and each role's "provider" choose among them.
+	providers := map[string]model.ModelClient{"openai": openai}
+	if config.GetAzureSettings() != nil || config.GetModelProvider("") == "azure" {
+		az, err := azure.FromConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Azure OpenAI: %w", err)
+		}
+		providers["azure"] = az
+	}
+	if config.GetGeminiSettings() != nil || config.GetModelProvider("") == "gemini" {
+		g, err := gemini.FromConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Gemini: %w", err)
+		}
+		providers["gemini"] = g
+	}
+	def, ok := providers[config.GetModelProvider("")]
+	if !ok {
+		return nil, fmt.Errorf("unknown model provider %q", config.GetModelProvider(""))
+	}
+	if len(providers) > 1 {
+		client = &model.ProviderClient{ModelClient: def, Providers: providers}
+	}
+	if limiter != nil {
+		client = model.NewLimitedClient(client, limiter)
+	}
+	// Rate-limited requests are retried outside the limiter so waiting does not hold a slot.
+	client = model.NewRetryingClient(client)
+	client = model.RouterFromConfig(client)
+	client, err := model.CacheFromConfig(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure model cache: %w", err)
+	}
+	return model.SummarizeFromConfig(client), nil
+}
+
+// newRepos opens the repositories listed in the configuration and returns the default one together
+// with the registry, or the single repository from GIT_REPO_URL/GIT_REPO_PATH and a nil registry.
+// Repositories authenticate with the credentials of their configuration entry, or with those
+// gitrepo.AuthFromEnv finds in the environment.
+func newRepos() (*gitrepo.GitClient, *gitrepo.Registry, error) {
+	repos := config.GetRepos()
+	if len(repos) == 0 {
+		g, err := gitrepo.NewGitClientWithAuth(os.Getenv("GIT_REPO_URL"), strings.TrimSpace(os.Getenv("GIT_REPO_PATH")), gitrepo.AuthFromEnv())
+		return g, nil, err
+	}
+	registry := gitrepo.NewRegistry()
+	for _, r := range repos {
+		auth := gitrepo.AuthFromEnv()
+		if r.Auth != nil {
+			auth = gitrepo.AuthConfigFrom(r.Auth)
+		}
+		g, err := gitrepo.NewGitClientWithOptions(r.URL, r.Path, auth, gitrepo.CloneOptionsFrom(r.Clone))
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository %s: %w", r.Name, err)
+		}
+		registry.Add(r.Name, g, r.Default)
+	}
+	def, err := registry.Get("")
+	if err != nil {
+		return nil, nil, err
+	}
+	return def, registry, nil
+}
+
+// newNotifier creates the team chat notifier from the environment: a Discord bot (DISCORD_TOKEN) or
+// webhook (DISCORD_WEBHOOK_URL), otherwise a Slack bot (SLACK_TOKEN) or webhook (SLACK_WEBHOOK_URL).
+// DISCORD_CHANNEL and SLACK_CHANNEL name the channel posted to. The Discord bot is also returned, as
+// only a bot can read chat commands. Both are nil when no chat is configured.
+func newNotifier() (notify.Notifier, *discord.DiscordClient) {
+	if token := os.Getenv("DISCORD_TOKEN"); token != "" {
+		bot := discord.NewDiscordClient(token, os.Getenv("DISCORD_CHANNEL"))
+		return bot, bot
+	}
+	if hook := os.Getenv("DISCORD_WEBHOOK_URL"); hook != "" {
+		return discord.NewDiscordWebhookClient(hook), nil
+	}
+	if token := os.Getenv("SLACK_TOKEN"); token != "" {
+		return slack.NewSlackClient(token, os.Getenv("SLACK_CHANNEL")), nil
+	}
+	if hook := os.Getenv("SLACK_WEBHOOK_URL"); hook != "" {
+		return slack.NewSlackWebhookClient(hook), nil
+	}
+	return nil, nil
+}
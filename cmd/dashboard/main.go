@@ -0,0 +1,511 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/config/filesys"
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+	"github.com/egobogo/aiagents/internal/dashboard"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/figma"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/intake/logs"
+	"github.com/egobogo/aiagents/internal/intake/sentry"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/memory"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/policy"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+	"github.com/egobogo/aiagents/internal/scheduler"
+	"github.com/egobogo/aiagents/internal/tracing"
+	"github.com/egobogo/aiagents/internal/transcript"
+	"github.com/egobogo/aiagents/internal/vcs"
+	"github.com/egobogo/aiagents/internal/vcs/github"
+)
+
+// scheduleFor returns the configured schedule of a recurring job, or def when none is configured.
+func scheduleFor(job, def string) string {
+	if spec := config.GetSchedule(job); spec != "" {
+		return spec
+	}
+	return def
+}
+
+// addJob registers a recurring job, exiting on an invalid schedule.
+func addJob(jobs *scheduler.Scheduler, name, spec string, run func() error) {
+	if err := jobs.Add(name, spec, run); err != nil {
+		log.Fatalf("Invalid schedule: %v", err)
+	}
+}
+
+// getenv returns the environment variable or def when it is unset.
+func getenv(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found; using system environment variables")
+	}
+
+	cfgPath := getenv("AIAGENTS_CONFIG", "cfg/main.cfg.yaml")
+	prov, err := filesys.NewFilesysConfigProvider(cfgPath)
+	if err != nil {
+		log.Fatalf("Could not create config provider: %v", err)
+	}
+	config.SetProvider(prov)
+	if err := config.Load(cfgPath); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	openaiAPIKey := os.Getenv("OPENAI_API_KEY")
+	// modelConcurrency in the configuration caps the model requests in flight across every agent.
+	modelLimiter := model.LimiterFromConfig()
+	modelClient, err := newModelClient(openaiAPIKey, modelLimiter)
+	if err != nil {
+		log.Fatalf("Failed to create model client: %v", err)
+	}
+
+	// In dry-run mode (AIAGENTS_DRY_RUN=1 or dryRun in the configuration) board writes, commits, pushes,
+	// pull requests and releases are printed instead of performed, by the agents and the orchestrator alike.
+	dryRun := config.IsDryRun()
+	gitClient, repos, err := newRepos()
+	if err != nil {
+		log.Fatalf("Failed to create GitClient: %v", err)
+	}
+	boardClient, err := newBoardClient(config.AgentIdentity{})
+	if err != nil {
+		log.Fatalf("Failed to configure boards: %v", err)
+	}
+	// Boards with a language other than English are read in English; questions are asked in their language.
+	translator := model.NewTranslator(modelClient)
+	boardClient = board.WithTranslation(boardClient, translator, config.GetBoardLanguages())
+	if dryRun {
+		boardClient = board.DryRun(boardClient)
+	}
+	docsClient := notion.NewNotionClient(os.Getenv("NOTION_TOKEN"), os.Getenv("NOTION_PARENT_PAGE"))
+
+	eventLog := events.NewJSONLLog(getenv("AIAGENTS_EVENT_LOG", "events.jsonl"))
+	// AIAGENTS_TRANSCRIPT_LOG keeps full model prompts and responses per ticket for transcript export.
+	var transcriptLog *events.JSONLLog
+	transcripts := &transcript.Exporter{Sources: []transcript.EventSource{eventLog}, Board: boardClient}
+	if path := os.Getenv("AIAGENTS_TRANSCRIPT_LOG"); path != "" {
+		transcriptLog = events.NewJSONLLog(path)
+		transcripts.Sources = append(transcripts.Sources, transcriptLog)
+	}
+	tracer := tracing.FromEnv()
+	gitClient.Events, gitClient.Tracer, gitClient.DryRun = eventLog, tracer, dryRun
+	// The policy from the configuration guards every repository and each agent's board writes.
+	rules := policy.FromConfig()
+	if rules != nil {
+		rules.Events = eventLog
+		gitClient.Guard = rules
+	}
+	// Commits in every repository are signed when the configuration has signing keys.
+	signing, err := gitrepo.SigningFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to configure commit signing: %v", err)
+	}
+	gitClient.Signing = signing
+	if repos != nil {
+		for _, name := range repos.Names() {
+			g, _ := repos.Get(name)
+			g.Events, g.Tracer, g.DryRun = eventLog, tracer, dryRun
+			if rules != nil {
+				g.Guard = rules
+			}
+			g.Signing = signing
+		}
+	}
+	// AIAGENTS_CHECKPOINT_DIR enables per-ticket checkpoints, so a restart resumes in-flight tickets.
+	checkpoints, err := checkpoint.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to open checkpoint store: %v", err)
+	}
+	// AIAGENTS_VECTOR_STORE keeps agent memories and knowledge chunks in a persistent vector store.
+	vectors, err := memory.FromEnv(1536)
+	if err != nil {
+		log.Fatalf("Failed to open vector store: %v", err)
+	}
+	// AIAGENTS_KNOWLEDGE_DIR indexes the repository's docs (markdown, ADRs, API specs) for every agent's prompts.
+	var kb *knowledge.Base
+	if dir := os.Getenv("AIAGENTS_KNOWLEDGE_DIR"); dir != "" {
+		kb = knowledge.New(openai.NewOpenAIEmbeddingProvider(openaiAPIKey, "text-embedding-ada-002"))
+		kb.Store = vectors
+		n, err := kb.IngestRepo(gitClient, dir)
+		if err != nil {
+			log.Fatalf("Failed to index %s: %v", dir, err)
+		}
+		log.Printf("Indexed %d documents from %s", n, dir)
+	}
+	// AIAGENTS_MEMORY_FILE keeps each agent's learnings across restarts.
+	var longTerm *memory.Journal
+	if path := os.Getenv("AIAGENTS_MEMORY_FILE"); path != "" {
+		if longTerm, err = memory.NewJournal(path); err != nil {
+			log.Fatalf("Failed to open memory journal: %v", err)
+		}
+		if n, err := longTerm.Expire(time.Now()); err != nil {
+			log.Printf("Warning: failed to expire memories: %v", err)
+		} else if n > 0 {
+			log.Printf("Expired %d memories", n)
+		}
+	}
+	// AIAGENTS_SELF_REVIEW sets how many critique rounds drafts go through before they reach the board.
+	selfReview := 0
+	if v := os.Getenv("AIAGENTS_SELF_REVIEW"); v != "" {
+		if selfReview, err = strconv.Atoi(v); err != nil {
+			log.Fatalf("Invalid AIAGENTS_SELF_REVIEW: %v", err)
+		}
+	}
+	// AIAGENTS_TEST_REPAIRS makes Backend agents run the tests before committing and sets how many
+	// rounds of fixes they may try when tests fail.
+	testRepairs := 0
+	if v := os.Getenv("AIAGENTS_TEST_REPAIRS"); v != "" {
+		if testRepairs, err = strconv.Atoi(v); err != nil {
+			log.Fatalf("Invalid AIAGENTS_TEST_REPAIRS: %v", err)
+		}
+	}
+	// AIAGENTS_MIN_COVERAGE sets the minimum statement coverage, in percent, of the packages a Backend
+	// agent changes; tickets below it get tests added or stay out of Review.
+	minCoverage := 0.0
+	if v := os.Getenv("AIAGENTS_MIN_COVERAGE"); v != "" {
+		if minCoverage, err = strconv.ParseFloat(v, 64); err != nil {
+			log.Fatalf("Invalid AIAGENTS_MIN_COVERAGE: %v", err)
+		}
+	}
+	// AIAGENTS_LINT_ROUNDS makes Backend agents run golangci-lint on changed packages and sets how many
+	// rounds of fixes they may try before committing with the remaining findings reported.
+	lintRounds := 0
+	if v := os.Getenv("AIAGENTS_LINT_ROUNDS"); v != "" {
+		if lintRounds, err = strconv.Atoi(v); err != nil {
+			log.Fatalf("Invalid AIAGENTS_LINT_ROUNDS: %v", err)
+		}
+	}
+	// GITHUB_REPOSITORY ("owner/repo") enables the CI gate: Backend agents push their branches, open
+	// pull requests against AIAGENTS_CI_TARGET and move tickets to review once the checks pass.
+	// The Release agent publishes its releases there too, attaching the AIAGENTS_RELEASE_ASSETS files.
+	var ciGate *agent.CIGate
+	var publisher vcs.ReleasePublisher
+	if repository := os.Getenv("GITHUB_REPOSITORY"); repository != "" {
+		gh, err := github.NewGitHubClient(os.Getenv("GITHUB_TOKEN"), repository, os.Getenv("GITHUB_API_URL"))
+		if err != nil {
+			log.Fatalf("Failed to create GitHub client: %v", err)
+		}
+		gh.DryRun = dryRun
+		ciGate = &agent.CIGate{Provider: gh, Hub: vcs.NewStatusHub(), TargetBranch: getenv("AIAGENTS_CI_TARGET", "main")}
+		publisher = gh
+	}
+	// Triage agents read application logs from the AIAGENTS_LOG_FILES files, from stdin with
+	// AIAGENTS_LOG_STDIN=1, and from Loki with LOKI_URL and LOKI_QUERY.
+	var logSources []logs.Source
+	for _, path := range strings.Split(os.Getenv("AIAGENTS_LOG_FILES"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			logSources = append(logSources, logs.NewFileSource(path))
+		}
+	}
+	if os.Getenv("AIAGENTS_LOG_STDIN") == "1" {
+		logSources = append(logSources, logs.NewReaderSource("stdin", os.Stdin))
+	}
+	if lokiURL := os.Getenv("LOKI_URL"); lokiURL != "" {
+		logSources = append(logSources, logs.NewLokiSource(lokiURL, os.Getenv("LOKI_QUERY"), time.Hour))
+	}
+	supervisor := orchestrator.NewSupervisor(os.Getenv("AIAGENTS_HUMAN"))
+	fleet := orchestrator.NewFleet()
+	// With a team chat configured, "notify" escalation steps post there, as do ticket phase changes
+	// and agent errors; a Discord bot also takes commands like "!decompose <card-url>".
+	var tracker agent.ProgressTracker = supervisor
+	notifier, discordBot := newNotifier()
+	if notifier != nil {
+		tracker = agent.Trackers{supervisor, notify.NewTicketUpdates(notifier, "")}
+		fleet.OnError = func(name string, err error) {
+			if err := notifier.Notify("", fmt.Sprintf(":warning: agent %s failed: %v", name, err)); err != nil {
+				log.Printf("Warning: failed to post agent error: %v", err)
+			}
+		}
+	}
+	chatCommands := notify.NewDispatcher()
+	if rules != nil {
+		rules.RegisterCommands(chatCommands)
+	}
+	// Rounds are skipped while the model provider answers with 429/503, until its Retry-After passes.
+	fleet.Degraded = model.Degraded
+	// Card comments like "/assign @backend" or "/retry" drive the agents; agents add the commands they serve.
+	cardCommands := orchestrator.NewCommandRule()
+	cardCommands.Trusted, cardCommands.Checkpoints = config.GetTrustedMembers(), checkpoints
+	var backends []*agent.BackendAgent
+	var managers []*agent.EngineeringManagerAgent
+	reporters := make(map[string]orchestrator.StandupReporter)
+
+	// AIAGENTS_ROLES lists the agents to run as "Role=name" pairs, e.g. "EngineeringManager=manager,Backend=backend".
+	for _, pair := range strings.Split(getenv("AIAGENTS_ROLES", "EngineeringManager=EngineeringManager"), ",") {
+		role, name, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			role, name = pair, pair
+		}
+		var searcher similarity.SimilaritySearcher = memory.NewSearcher(vectors, name)
+		if os.Getenv(memory.EnvBackend) == "" {
+			if searcher, err = hnsw.New(1536); err != nil {
+				log.Fatalf("Failed to create HNSW SimilaritySearcher: %v", err)
+			}
+		}
+		// Roles with clone settings work in their own shallow or sparse checkout next to the shared one.
+		agentRepo := gitClient
+		if c := config.GetRoleClone(role); c != nil {
+			if agentRepo, err = gitClient.CloneFor(gitClient.RepoPath+"-"+name, gitrepo.CloneOptionsFrom(c)); err != nil {
+				log.Fatalf("Failed to clone the repository for %s: %v", name, err)
+			}
+		}
+		// Agents listed under "agents" in the configuration commit, comment and call the model under their own identity.
+		identity := config.GetAgentIdentity(name)
+		agentKey, agentModel, agentBoard := openaiAPIKey, modelClient, boardClient
+		if identity.ModelAPIKeyEnv != "" {
+			agentKey = os.Getenv(identity.ModelAPIKeyEnv)
+			if agentModel, err = newModelClient(agentKey, modelLimiter); err != nil {
+				log.Fatalf("Failed to create model client for %s: %v", name, err)
+			}
+		}
+		if identity.TrelloKeyEnv != "" || identity.TrelloTokenEnv != "" {
+			if agentBoard, err = newBoardClient(identity); err != nil {
+				log.Fatalf("Failed to configure boards for %s: %v", name, err)
+			}
+			agentBoard = board.WithTranslation(agentBoard, translator, config.GetBoardLanguages())
+			if dryRun {
+				agentBoard = board.DryRun(agentBoard)
+			}
+		}
+		base := &agent.BaseAgent{
+			Name:           name,
+			AuthorName:     identity.AuthorName,
+			AuthorEmail:    identity.AuthorEmail,
+			ModelClient:    agentModel,
+			BoardClient:    events.WrapBoard(agentBoard, eventLog, name),
+			DocsClient:     docsClient,
+			GitClient:      agentRepo,
+			Repos:          repos,
+			Policy:         rules,
+			Context:        inmemory.NewInMemoryContextStorage(openai.NewOpenAIEmbeddingProvider(agentKey, "text-embedding-ada-002"), searcher),
+			PromptBuilder:  chatgptpromptbuilder.New(),
+			Notifier:       notifier,
+			Tracker:        tracker,
+			Tracer:         tracer,
+			Checkpoints:    checkpoints,
+			Knowledge:      kb,
+			LongTerm:       longTerm,
+			SelfReview:     selfReview,
+			TrustedMembers: config.GetTrustedMembers(),
+		}
+		// Model calls are logged with their token usage and latency against the ticket being worked on.
+		base.ModelClient = events.WrapModel(base.ModelClient, eventLog, name, func() string { return base.CurrentTicketID })
+		if transcriptLog != nil {
+			base.ModelClient = transcript.WrapModel(base.ModelClient, transcriptLog, name, func() string { return base.CurrentTicketID })
+		}
+		a, err := agent.DefaultRegistry.New(role, base)
+		if err != nil {
+			log.Fatalf("Failed to create agent %s: %v", name, err)
+		}
+		// Agents built on the Backend agent share its CI, test, lint, coverage and migration settings.
+		if coder, ok := a.(interface{ Backend() *agent.BackendAgent }); ok {
+			backend := coder.Backend()
+			backend.CI = ciGate
+			backend.TestRepairs = testRepairs
+			backend.LintRounds = lintRounds
+			backend.MinCoverage = minCoverage
+			backend.ScheduledBranches = config.GetSchedule("branches") != ""
+			if s := config.GetMigrationSettings(); s != nil {
+				backend.MigrationsDir, backend.MigrationCommand = s.Dir, s.Command
+				if backend.MigrationsDir == "" {
+					backend.MigrationsDir = "migrations"
+				}
+			}
+			backends = append(backends, backend)
+		}
+		if manager, ok := a.(*agent.EngineeringManagerAgent); ok {
+			// AIAGENTS_MANAGER_APPROVES_DEPENDENCIES=1 lets the manager answer dependency change requests instead of a human.
+			manager.ReviewDependencies = os.Getenv("AIAGENTS_MANAGER_APPROVES_DEPENDENCIES") == "1"
+			manager.RegisterCardCommands(cardCommands)
+			manager.RegisterCommands(chatCommands)
+			managers = append(managers, manager)
+		}
+		if designer, ok := a.(*agent.DesignerAgent); ok {
+			// With FIGMA_TOKEN the Designer exports the Figma frames linked in tickets into the repository.
+			if token := os.Getenv(figma.EnvToken); token != "" {
+				designer.Figma = figma.NewClient(token, os.Getenv("FIGMA_API_URL"))
+			}
+			if ciGate != nil {
+				designer.VCS = ciGate.Provider
+			}
+			if s := config.GetDesignSettings(); s != nil {
+				designer.AssetDir, designer.Brandbook = s.AssetDir, s.Brandbook
+				if s.TargetBranch != "" {
+					designer.TargetBranch = s.TargetBranch
+				}
+			}
+		}
+		if triage, ok := a.(*agent.TriageAgent); ok {
+			triage.Sources = logSources
+		}
+		if release, ok := a.(*agent.ReleaseAgent); ok {
+			release.Publisher = publisher
+			release.RegisterCommands(chatCommands)
+			if assets := os.Getenv("AIAGENTS_RELEASE_ASSETS"); assets != "" {
+				release.Assets = strings.Split(assets, ",")
+			}
+		}
+		if r, ok := a.(orchestrator.StandupReporter); ok {
+			reporters[name] = r
+		}
+		fleet.Add(name, role, a)
+	}
+
+	// Recurring jobs run on the cron expressions under "schedules" in the configuration. By default
+	// agents take turns every AIAGENTS_INTERVAL, starting right away.
+	interval, err := time.ParseDuration(getenv("AIAGENTS_INTERVAL", "1m"))
+	if err != nil {
+		log.Fatalf("Invalid AIAGENTS_INTERVAL: %v", err)
+	}
+	jobs := scheduler.New()
+	turns, err := scheduler.Parse(scheduleFor("agents", "@every "+interval.String()))
+	if err != nil {
+		log.Fatalf("Invalid schedule for agents: %v", err)
+	}
+	jobs.AddJob(scheduler.Job{Name: "agents", Schedule: turns, Immediate: config.GetSchedule("agents") == "", Run: func() error {
+		fleet.RunOnce()
+		return nil
+	}})
+
+	// Board-wide rules: a "KILL SWITCH" card pauses every agent, stuck tickets are escalated,
+	// tickets wait in Blocked until the tickets they depend on are done, card commands are run,
+	// parent tickets are completed or flagged from their technical tickets, and done tickets get a cost report.
+	orch := orchestrator.New(boardClient, orchestrator.NewKillSwitchCard(), supervisor, orchestrator.NewDependencyRule(), cardCommands,
+		orchestrator.NewParentRollupRule(), orchestrator.NewCostReportRule(eventLog))
+	addJob(jobs, "orchestrator", scheduleFor("orchestrator", "@every 30s"), orch.Tick)
+
+	// Sentry issues are filed as bug tickets: SENTRY_CLIENT_SECRET accepts webhooks on /webhooks/sentry,
+	// and SENTRY_TOKEN with SENTRY_ORG and SENTRY_PROJECT polls for unresolved issues every five minutes.
+	bugs := sentry.NewIntake(boardClient)
+	bugs.Repo = gitClient
+	if token := os.Getenv("SENTRY_TOKEN"); token != "" {
+		bugs.Client = sentry.NewClient(token, os.Getenv("SENTRY_ORG"), os.Getenv("SENTRY_PROJECT"), os.Getenv("SENTRY_URL"))
+		addJob(jobs, "sentry", scheduleFor("sentry", "@every 5m"), func() error {
+			_, err := bugs.Sync()
+			return err
+		})
+	}
+	// Standup reports, branch maintenance and backlog grooming only run when scheduled.
+	if spec := config.GetSchedule("standup"); spec != "" {
+		if err := orchestrator.NewStandupCoordinator(boardClient, reporters).Schedule(jobs, spec); err != nil {
+			log.Fatalf("Invalid schedule: %v", err)
+		}
+	}
+	if spec := config.GetSchedule("branches"); spec != "" {
+		addJob(jobs, "branches", spec, func() error {
+			var errs []error
+			for _, b := range backends {
+				errs = append(errs, b.MaintainBranches())
+			}
+			return errors.Join(errs...)
+		})
+	}
+	if spec := config.GetSchedule("grooming"); spec != "" {
+		addJob(jobs, "grooming", spec, func() error {
+			var errs []error
+			for _, m := range managers {
+				_, err := m.PrioritizeBacklog()
+				errs = append(errs, err)
+			}
+			return errors.Join(errs...)
+		})
+	}
+	stop := make(chan struct{})
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobs.Start(stop)
+	}()
+	if discordBot != nil {
+		// DISCORD_POLL_INTERVAL sets how often the bot reads new commands from its channel.
+		poll, err := time.ParseDuration(getenv("DISCORD_POLL_INTERVAL", "10s"))
+		if err != nil {
+			log.Fatalf("Invalid DISCORD_POLL_INTERVAL: %v", err)
+		}
+		go func() {
+			if err := discordBot.Listen("", chatCommands, poll, stop); err != nil {
+				log.Printf("Warning: Discord commands unavailable: %v", err)
+			}
+		}()
+	}
+
+	// The dashboard listens on the loopback interface unless DASHBOARD_ADDR says otherwise. Pausing
+	// agents, the kill switch and transcripts need DASHBOARD_TOKEN; without it they are refused.
+	addr := getenv("DASHBOARD_ADDR", "127.0.0.1:8080")
+	dash := dashboard.NewServer(fleet, supervisor, eventLog)
+	dash.Transcripts = transcripts
+	dash.ModelLimiter = modelLimiter
+	dash.Token = os.Getenv("DASHBOARD_TOKEN")
+	mux := http.NewServeMux()
+	mux.Handle("/", dash.Handler())
+	if ciGate != nil {
+		// Check deliveries from GitHub end pipeline waits early; GITHUB_WEBHOOK_SECRET verifies them.
+		mux.Handle("POST /webhooks/github", github.WebhookHandler(os.Getenv("GITHUB_WEBHOOK_SECRET"), ciGate.Hub))
+	}
+	if secret := os.Getenv("SENTRY_CLIENT_SECRET"); secret != "" {
+		mux.Handle("POST /webhooks/sentry", sentry.WebhookHandler(secret, bugs))
+	}
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		log.Printf("Dashboard listening on %s", addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	// On SIGINT/SIGTERM stop picking up work and give the running round a grace period to finish.
+	// Anything still in flight afterwards resumes from its checkpoint on the next start.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	grace, err := time.ParseDuration(getenv("AIAGENTS_SHUTDOWN_GRACE", "30s"))
+	if err != nil {
+		grace = 30 * time.Second
+	}
+	log.Printf("Shutting down; waiting up to %s for running agents", grace)
+	close(stop)
+	select {
+	case <-jobsDone:
+	case <-time.After(grace):
+		log.Println("Agents still busy; in-flight tickets will resume from their checkpoints")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Dashboard shutdown: %v", err)
+	}
+	if err := tracer.Flush(); err != nil {
+		log.Printf("Failed to flush traces: %v", err)
+	}
+}
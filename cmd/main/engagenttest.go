@@ -18,6 +18,7 @@ import (
 	"github.com/egobogo/aiagents/internal/gitrepo"
 	"github.com/egobogo/aiagents/internal/model/chatgpt"
 	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+	"github.com/egobogo/aiagents/internal/tracing"
 	// for ChatRequest and Message types
 )
 
@@ -94,16 +95,19 @@ func main() {
 	// Create a BaseAgent with the concrete dependencies.
 	baseAgent := &agent.BaseAgent{
 		Name:          "EngineeringManager",
-		Role:          "EngineeringManager",
 		ModelClient:   modelClient,
 		BoardClient:   boardClient,
 		DocsClient:    docsClient,
 		GitClient:     gitClient,
 		Context:       ctxStorage,
 		PromptBuilder: promptBuilder,
+		Tracer:        tracing.FromEnv(), // Enabled by OTEL_EXPORTER_OTLP_ENDPOINT.
 	}
 
 	// Create the Engineering Manager agent.
-	engAgent := agent.NewEngineeringManagerAgent(baseAgent)
-	log.Println(engAgent.Name)
+	engAgent, err := agent.DefaultRegistry.New(agent.RoleEngineeringManager, baseAgent)
+	if err != nil {
+		log.Fatalf("Failed to create agent: %v", err)
+	}
+	log.Println(engAgent.(*agent.EngineeringManagerAgent).Name)
 }
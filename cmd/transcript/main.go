@@ -0,0 +1,59 @@
+// Command transcript exports the interaction history of a ticket as markdown or JSON.
+//
+//	transcript -ticket <id> [-format md|json] [-o file]
+//
+// Events are read from AIAGENTS_EVENT_LOG and AIAGENTS_TRANSCRIPT_LOG; card comments are added
+// when TRELLO_BOARD_ID is set.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/transcript"
+)
+
+func main() {
+	ticket := flag.String("ticket", "", "ticket (card) ID to export")
+	format := flag.String("format", transcript.FormatMarkdown, "output format: md or json")
+	out := flag.String("o", "", "write to this file instead of stdout")
+	flag.Parse()
+	if *ticket == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	_ = godotenv.Load()
+
+	eventLog := os.Getenv("AIAGENTS_EVENT_LOG")
+	if eventLog == "" {
+		eventLog = "events.jsonl"
+	}
+	x := &transcript.Exporter{Sources: []transcript.EventSource{events.NewJSONLLog(eventLog)}}
+	if path := os.Getenv("AIAGENTS_TRANSCRIPT_LOG"); path != "" {
+		x.Sources = append(x.Sources, events.NewJSONLLog(path))
+	}
+	if boardID := os.Getenv("TRELLO_BOARD_ID"); boardID != "" {
+		x.Board = trelloClient.NewTrelloClient(os.Getenv("TRELLO_API_KEY"), os.Getenv("TRELLO_TOKEN"), boardID)
+	}
+
+	t, err := x.Export(*ticket)
+	if err != nil {
+		log.Fatalf("Failed to export transcript: %v", err)
+	}
+	data, err := t.Render(*format)
+	if err != nil {
+		log.Fatalf("Failed to render transcript: %v", err)
+	}
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
@@ -0,0 +1,205 @@
+// Package adr keeps architecture decision records: numbered markdown files in the repository, one
+// per significant technical decision, in the format popularised by Michael Nygard.
+package adr
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// DefaultDir is the repository directory records are kept in.
+const DefaultDir = "docs/adr"
+
+// Statuses of a record.
+const (
+	StatusProposed   = "Proposed"
+	StatusAccepted   = "Accepted"
+	StatusSuperseded = "Superseded"
+)
+
+// Record is one architecture decision record.
+type Record struct {
+	Number       int
+	Title        string
+	Status       string // StatusAccepted unless changed; superseded records name their successor.
+	Date         time.Time
+	Context      string
+	Decision     string
+	Consequences string
+	Alternatives []string
+	Links        []string // Markdown lines linking the tickets the decision was made for.
+	Path         string   // Repository path; set by List and Write.
+}
+
+// fileName matches record files, e.g. "0007-use-postgres-for-sessions.md".
+var fileName = regexp.MustCompile(`^(\d+)-.*\.md$`)
+
+// List returns the records in dir, ordered by number. Only the number, title, status and path are read.
+func List(repo *gitrepo.GitClient, dir string) ([]Record, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	files, err := repo.ListFiles(strings.TrimSuffix(dir, "/") + "/*.md")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	var records []Record
+	for _, f := range files {
+		m := fileName.FindStringSubmatch(path.Base(f))
+		if m == nil {
+			continue
+		}
+		text, err := repo.ReadText(f)
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", f, err)
+			continue
+		}
+		r := parse(text)
+		r.Number, _ = strconv.Atoi(m[1])
+		r.Path = f
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Number < records[j].Number })
+	return records, nil
+}
+
+// parse reads the title and status of a record.
+func parse(text string) Record {
+	var r Record
+	section := ""
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "# ") && r.Title == "":
+			title := strings.TrimPrefix(line, "# ")
+			if num, rest, ok := strings.Cut(title, ". "); ok && strings.Trim(num, "0123456789") == "" {
+				title = rest
+			}
+			r.Title = title
+		case strings.HasPrefix(line, "## "):
+			section = strings.ToLower(strings.TrimPrefix(line, "## "))
+		case section == "status" && line != "" && r.Status == "":
+			r.Status = line
+		}
+	}
+	return r
+}
+
+// Find returns the record titled title, compared case-insensitively, or nil.
+func Find(records []Record, title string) *Record {
+	for i := range records {
+		if strings.EqualFold(strings.TrimSpace(records[i].Title), strings.TrimSpace(title)) {
+			return &records[i]
+		}
+	}
+	return nil
+}
+
+// Next returns the number of the next record.
+func Next(records []Record) int {
+	n := 0
+	for _, r := range records {
+		n = max(n, r.Number)
+	}
+	return n + 1
+}
+
+// Slug turns a title into the file name part after the number.
+func Slug(title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	s := strings.TrimSuffix(sb.String(), "-")
+	if len(s) > 60 {
+		s = strings.TrimSuffix(s[:60], "-")
+	}
+	if s == "" {
+		return "decision"
+	}
+	return s
+}
+
+// Render returns the markdown of a record.
+func Render(r Record) string {
+	status := r.Status
+	if status == "" {
+		status = StatusAccepted
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %d. %s\n\nDate: %s\n\n## Status\n\n%s\n", r.Number, r.Title, r.Date.Format("2006-01-02"), status)
+	for _, s := range []struct{ heading, text string }{
+		{"Context", r.Context},
+		{"Decision", r.Decision},
+		{"Consequences", r.Consequences},
+	} {
+		if strings.TrimSpace(s.text) != "" {
+			fmt.Fprintf(&sb, "\n## %s\n\n%s\n", s.heading, strings.TrimSpace(s.text))
+		}
+	}
+	if len(r.Alternatives) > 0 {
+		sb.WriteString("\n## Alternatives considered\n\n")
+		for _, a := range r.Alternatives {
+			fmt.Fprintf(&sb, "- %s\n", a)
+		}
+	}
+	if len(r.Links) > 0 {
+		sb.WriteString("\n## Tickets\n\n")
+		for _, l := range r.Links {
+			fmt.Fprintf(&sb, "- %s\n", l)
+		}
+	}
+	return sb.String()
+}
+
+// Write stores r as the next record in dir of repo, setting its number and path. The caller commits.
+func Write(repo *gitrepo.GitClient, dir string, records []Record, r *Record) error {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	r.Number = Next(records)
+	r.Path = path.Join(dir, fmt.Sprintf("%04d-%s.md", r.Number, Slug(r.Title)))
+	if err := repo.WriteFile(r.Path, []byte(Render(*r))); err != nil {
+		return fmt.Errorf("failed to write %s: %w", r.Path, err)
+	}
+	return nil
+}
+
+// Supersede marks old as superseded by the record numbered by. The caller commits.
+func Supersede(repo *gitrepo.GitClient, old Record, by int) error {
+	text, err := repo.ReadText(old.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", old.Path, err)
+	}
+	lines := strings.Split(text, "\n")
+	section := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			section = strings.ToLower(strings.TrimPrefix(trimmed, "## "))
+			continue
+		}
+		if section == "status" && trimmed != "" {
+			lines[i] = fmt.Sprintf("%s by ADR %d", StatusSuperseded, by)
+			break
+		}
+	}
+	if err := repo.WriteFile(old.Path, []byte(strings.Join(lines, "\n"))); err != nil {
+		return fmt.Errorf("failed to write %s: %w", old.Path, err)
+	}
+	return nil
+}
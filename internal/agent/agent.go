@@ -3,15 +3,23 @@ package agent
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/config"
 	"github.com/egobogo/aiagents/internal/context"
 	"github.com/egobogo/aiagents/internal/docs"
 	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/memory"
 	"github.com/egobogo/aiagents/internal/model"
 	mclient "github.com/egobogo/aiagents/internal/model"
 	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/policy"
 	pb "github.com/egobogo/aiagents/internal/promptbuilder"
+	"github.com/egobogo/aiagents/internal/tracing"
 )
 
 // Agent defines the basic operations available to any agent.
@@ -29,14 +37,86 @@ type BaseAgent struct {
 	Name            string
 	CurrentTicketID string
 	Role            string
+	AuthorName      string // Optional; git author of the agent's commits and tags; defaults to Name.
+	AuthorEmail     string // Optional; defaults to "<Name>@aiagents.local".
 
 	ModelClient   mclient.ModelClient
 	BoardClient   board.BoardClient
 	DocsClient    docs.DocumentationClient
 	GitClient     *gitrepo.GitClient
+	Repos         *gitrepo.Registry // Optional; picks the repository per ticket. GitClient is used when nil.
 	Context       context.ContextStorage
 	PromptBuilder pb.PromptBuilder
 	VectorStorage *vectorstorage.Client
+	Tracker       ProgressTracker  // Optional; notified of phase changes and polls.
+	Notifier      notify.Notifier  // Optional; used by the "notify" escalation step.
+	Tracer        *tracing.Tracer  // Optional; traces ticket handling, model calls and board writes.
+	Checkpoints   checkpoint.Store // Optional; persists per-ticket progress so a restart resumes mid-ticket.
+	Policy        *policy.Engine   // Optional; refuses board writes the policy forbids.
+	Knowledge     *knowledge.Base  // Optional; project docs searched while building prompts.
+	LongTerm      *memory.Journal  // Optional; learnings kept across runs and sent as a system message.
+	SelfReview    int              // Optional; critique rounds run on clarifications and tickets before they reach the board.
+	ReplyInterval time.Duration    // Optional; time between polls while waiting for a reply; zero uses DefaultReplyPollInterval.
+
+	// TrustedMembers are the board member IDs whose comments WaitForReply accepts. When empty, a
+	// comment by anyone but the agent itself is a reply.
+	TrustedMembers []string
+
+	// Escalation overrides the configured escalation chain run when WaitForReply times out.
+	Escalation []config.EscalationStep
+}
+
+// RepoFor returns the repository a ticket should be worked on in: the one named by its repo hint,
+// the registry's default for tickets without one, or GitClient when no registry is configured.
+// The result is limited to the role's configured path scope and the ticket's "Scope:" line.
+func (a *BaseAgent) RepoFor(card board.Card) (*gitrepo.GitClient, error) {
+	repo := a.GitClient
+	if a.Repos != nil {
+		var err error
+		if repo, err = a.Repos.Get(board.RepoHint(card)); err != nil {
+			return nil, fmt.Errorf("failed to resolve repository for %q: %w", card.GetName(), err)
+		}
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("no repository configured")
+	}
+	return repo.Scoped(config.GetRoleScope(a.Role)...).Scoped(board.PathScope(card)...), nil
+}
+
+// CheckoutFor returns the ticket's own checkout of RepoFor(card), ready for new work. Changes
+// left there by an aborted run are stashed first, so they are never committed with unrelated
+// work; they stay recoverable with StashPop.
+func (a *BaseAgent) CheckoutFor(card board.Card) (*gitrepo.GitClient, error) {
+	base, err := a.RepoFor(card)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := base.WorktreeFor(card.GetID())
+	if err != nil {
+		return nil, err
+	}
+	clean, err := repo.StatusIsClean()
+	if err != nil || clean {
+		return repo, err
+	}
+	stash, err := repo.Stash("leftovers before resuming " + card.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean checkout for %q: %w", card.GetName(), err)
+	}
+	fmt.Printf("Warning: stashed uncommitted changes left in %s as %s\n", repo.RepoPath, stash)
+	return repo, nil
+}
+
+// Author returns the name and email the agent's commits and tags are made under.
+func (a *BaseAgent) Author() (name, email string) {
+	name, email = a.AuthorName, a.AuthorEmail
+	if name == "" {
+		name = a.Name
+	}
+	if email == "" {
+		email = a.Name + "@aiagents.local"
+	}
+	return name, email
 }
 
 // FindMyTickets retrieves board cards assigned to this agent.
@@ -44,6 +124,30 @@ func (a *BaseAgent) FindMyTickets() ([]board.Card, error) {
 	return a.BoardClient.GetCardsAssignedTo(a.Name)
 }
 
+// FindReadyTickets retrieves the tickets assigned to this agent whose dependencies are done.
+// When the board cannot be read to check dependencies, all assigned tickets are returned.
+func (a *BaseAgent) FindReadyTickets() ([]board.Card, error) {
+	cards, err := a.FindMyTickets()
+	if err != nil {
+		return nil, err
+	}
+	var all []board.Card
+	var ready []board.Card
+	for _, c := range cards {
+		if len(board.Dependencies(c.GetDescription())) > 0 && all == nil {
+			if all, err = a.BoardClient.GetCards(); err != nil {
+				fmt.Printf("Warning: failed to check dependencies: %v\n", err)
+				return cards, nil
+			}
+		}
+		if unmet := board.UnmetDependencies(all, c, board.ListDone); len(unmet) > 0 {
+			continue
+		}
+		ready = append(ready, c)
+	}
+	return ready, nil
+}
+
 // Think builds a request, obtains a response, and updates context.
 func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput interface{}) (mclient.Message, error) {
 	combinedInput := fmt.Sprintf("Context of the sender:\n%s\n\nThe query of the sender:\n%s", senderContext, userInput)
@@ -65,11 +169,12 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 	if err := a.RefreshMemories(relevantOldMemories, newMemories); err != nil {
 		fmt.Printf("Warning: RefreshMemories (first pass) failed: %v\n", err)
 	}
+	a.learn(newMemories)
 
 	chatReq, err := a.PromptBuilder.Build(
 		a.Role,
 		mode,
-		updatedContext,
+		a.withKnowledge(updatedContext, userInput),
 		userInput,
 		desiredOutput,
 		a.ModelClient.GetTemperature(),
@@ -78,6 +183,7 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 	if err != nil {
 		return mclient.Message{}, fmt.Errorf("failed to build task request: %w", err)
 	}
+	chatReq = a.withLongTerm(chatReq)
 
 	taskResponse, err := a.ModelClient.ChatAdvanced(chatReq)
 	if err != nil {
@@ -94,6 +200,7 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 	if err := a.RefreshMemories(relevantAdditional, additionalMemories); err != nil {
 		fmt.Printf("Warning: RefreshMemories (second pass) failed: %v\n", err)
 	}
+	a.learn(additionalMemories)
 
 	return mclient.Message{
 		Role:    "assistant",
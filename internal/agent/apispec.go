@@ -0,0 +1,244 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/openapi"
+)
+
+// apiSpecMarker prefixes the comment left on a ticket once its spec was synced, so the ticket is
+// not processed twice.
+const apiSpecMarker = "[api spec]"
+
+// apiSpecAttempts is how often the model may produce the spec before validation errors are given up on.
+const apiSpecAttempts = 3
+
+// APISpecUpdate is the model's answer to a spec sync request.
+type APISpecUpdate struct {
+	Spec    string `json:"spec"`    // Complete new OpenAPI document in YAML; empty when it is up to date.
+	Summary string `json:"summary"` // One line on what changed.
+}
+
+// APISpecAgent keeps the OpenAPI spec in sync with the HTTP handlers changed by completed technical tickets.
+type APISpecAgent struct {
+	*BackendAgent
+	BaseBranch string // Branch the ticket diff is taken against.
+	SpecPath   string // Repository path of the spec.
+}
+
+// NewAPISpecAgent creates a new APISpecAgent using the provided BaseAgent.
+func NewAPISpecAgent(base *BaseAgent) *APISpecAgent {
+	return &APISpecAgent{
+		BackendAgent: NewBackendAgent(base),
+		BaseBranch:   "main",
+		SpecPath:     openapi.DefaultPath,
+	}
+}
+
+// Act syncs the spec of every completed technical ticket that changed HTTP handlers and was not synced yet.
+func (a *APISpecAgent) Act() error {
+	cards, err := a.BoardClient.GetCardsFromList(board.ListDone)
+	if err != nil {
+		return fmt.Errorf("failed to get done tickets: %w", err)
+	}
+	for _, card := range cards {
+		if _, ok := board.ParentURL(card.GetDescription()); !ok {
+			continue // Only technical tickets change code.
+		}
+		if hasMarker(card, apiSpecMarker) {
+			continue
+		}
+		if err := a.SyncSpec(card); err != nil {
+			fmt.Printf("Warning: failed to sync the API spec for %q: %v\n", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// handlerFiles returns the Go files whose changes in diff touch HTTP handlers, in diff order.
+func handlerFiles(diff string) []string {
+	var files []string
+	path := ""
+	var section strings.Builder
+	flush := func() {
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") && openapi.IsHandlerCode(section.String()) {
+			files = append(files, path)
+		}
+		section.Reset()
+	}
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "diff --git "):
+			flush()
+			path = ""
+		case strings.HasPrefix(l, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+		case strings.HasPrefix(l, "+") || strings.HasPrefix(l, "-"):
+			section.WriteString(l[1:] + "\n")
+		}
+	}
+	flush()
+	return files
+}
+
+// SyncSpec updates the spec on the ticket branch when the ticket changed HTTP handlers. The model
+// rewrites the spec from the handler code; the result must be a valid OpenAPI document covering every
+// route registered in the repository, otherwise the errors are fed back. The spec is committed on the
+// ticket branch and the outcome is posted on the card.
+func (a *APISpecAgent) SyncSpec(card board.Card) error {
+	a.CurrentTicketID = card.GetID()
+	repo, err := a.CheckoutFor(card)
+	if err != nil {
+		return err
+	}
+	diff, err := repo.Diff(a.BaseBranch)
+	if err != nil {
+		return err
+	}
+	handlers := handlerFiles(diff)
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	current, err := repo.ReadText(a.SpecPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	routes, err := a.repoRoutes(repo)
+	if err != nil {
+		return err
+	}
+	var sources strings.Builder
+	for _, f := range handlers {
+		if content, err := repo.ReadText(f); err == nil {
+			sources.WriteString(fmt.Sprintf("=== %s ===\n%s\n", f, content))
+		}
+	}
+	var listed []string
+	for _, r := range routes {
+		listed = append(listed, "- "+r.String())
+	}
+	specText := current
+	if specText == "" {
+		specText = "(none yet)"
+	}
+	prompt := fmt.Sprintf(
+		"Ticket: %s\n\nThe following change was made:\n%s\n\nHandler code:\n%s\n\nRoutes registered in the repository:\n%s\n\n"+
+			"Current %s:\n%s\n\nReturn the complete OpenAPI 3 document in YAML describing every route with its parameters, "+
+			"request bodies and responses as implemented. Keep existing descriptions and schemas that are still correct. "+
+			"Return an empty spec if the document is up to date.",
+		card.GetName(), guard.Wrap("the repository diff", diff), guard.Wrap("the handler code", sources.String()),
+		strings.Join(listed, "\n"), a.SpecPath, guard.Wrap("the current spec", specText),
+	)
+
+	var update APISpecUpdate
+	var problems error
+	for attempt := 0; attempt < apiSpecAttempts; attempt++ {
+		input := prompt
+		if problems != nil {
+			input += fmt.Sprintf("\n\nYour previous spec was rejected:\n%v\nFix these problems.", problems)
+		}
+		if update, err = a.requestSpec(input); err != nil {
+			return err
+		}
+		if strings.TrimSpace(update.Spec) == "" {
+			if current == "" {
+				problems = errors.New("there is no spec yet; return the complete document")
+				continue
+			}
+			update.Spec = current
+		}
+		if problems = checkSpec([]byte(update.Spec), routes); problems == nil {
+			break
+		}
+	}
+	if problems != nil {
+		note := fmt.Sprintf("%s Could not produce a valid %s; please update it by hand:\n%v", apiSpecMarker, a.SpecPath, problems)
+		return card.WriteComment(note)
+	}
+	if update.Spec == current {
+		return card.WriteComment(fmt.Sprintf("%s %s is up to date.", apiSpecMarker, a.SpecPath))
+	}
+
+	if err := repo.WriteFile(a.SpecPath, []byte(update.Spec)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.SpecPath, err)
+	}
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		return err
+	}
+	msg := gitrepo.NewCommitMessage(changed, card.GetID(), "update OpenAPI spec for "+card.GetName())
+	msg.Type = "docs"
+	author, email := a.Author()
+	if err := a.CommitWork(repo, card, msg.String(), author, email); err != nil {
+		return err
+	}
+	note := fmt.Sprintf("%s Updated %s", apiSpecMarker, a.SpecPath)
+	if update.Summary != "" {
+		note += ": " + update.Summary
+	}
+	return card.WriteComment(note)
+}
+
+// checkSpec validates a spec and checks that it documents every route.
+func checkSpec(spec []byte, routes []openapi.Route) error {
+	if err := openapi.Validate(spec); err != nil {
+		return err
+	}
+	missing, err := openapi.Missing(spec, routes)
+	if err != nil {
+		return err
+	}
+	var problems []error
+	for _, r := range missing {
+		problems = append(problems, fmt.Errorf("route %s is not documented", r))
+	}
+	return errors.Join(problems...)
+}
+
+// repoRoutes returns the routes registered in the Go code of repo, tests excluded.
+func (a *APISpecAgent) repoRoutes(repo *gitrepo.GitClient) ([]openapi.Route, error) {
+	files, err := repo.ListFiles("**/*.go")
+	if err != nil {
+		return nil, err
+	}
+	var routes []openapi.Route
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		content, err := repo.ReadText(f)
+		if err != nil {
+			continue
+		}
+		routes = append(routes, openapi.FindRoutes(content)...)
+	}
+	return routes, nil
+}
+
+func (a *APISpecAgent) requestSpec(prompt string) (APISpecUpdate, error) {
+	chatReq, err := a.PromptBuilder.Build(
+		a.Role,
+		"SyncAPISpec",
+		a.Context.GetContext(),
+		prompt,
+		APISpecUpdate{},
+		a.ModelClient.GetTemperature(),
+		a.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return APISpecUpdate{}, fmt.Errorf("failed to build spec request: %w", err)
+	}
+	var update APISpecUpdate
+	if err := model.ChatStructured(a.ModelClient, chatReq, &update); err != nil {
+		return APISpecUpdate{}, fmt.Errorf("failed to parse spec response: %w", err)
+	}
+	return update, nil
+}
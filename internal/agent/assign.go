@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Assignment strategies understood by NewAssignStrategy.
+const (
+	AssignRoundRobin  = "round-robin"
+	AssignLeastLoaded = "least-loaded"
+	AssignCapability  = "capability"
+)
+
+// AssignStrategy picks the developer a technical ticket is assigned to.
+type AssignStrategy interface {
+	Pick(card board.Card, developers []config.DeveloperSettings) (string, error)
+}
+
+// NewAssignStrategy returns the named strategy; an empty name means round-robin.
+// Load is counted on b.
+func NewAssignStrategy(name string, b board.Board) (AssignStrategy, error) {
+	switch name {
+	case "", AssignRoundRobin:
+		return &RoundRobin{}, nil
+	case AssignLeastLoaded:
+		return &LeastLoaded{Board: b}, nil
+	case AssignCapability:
+		return &CapabilityMatch{Then: &LeastLoaded{Board: b}}, nil
+	}
+	return nil, fmt.Errorf("unknown assignment strategy %q", name)
+}
+
+// RoundRobin hands tickets to the developers in turn.
+type RoundRobin struct {
+	mu   sync.Mutex
+	next int
+}
+
+// Pick returns the developer after the one picked last.
+func (r *RoundRobin) Pick(_ board.Card, developers []config.DeveloperSettings) (string, error) {
+	if len(developers) == 0 {
+		return "", fmt.Errorf("no developers to assign to")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := developers[r.next%len(developers)]
+	r.next++
+	return d.Name, nil
+}
+
+// LeastLoaded picks the developer with the fewest open cards, preferring earlier developers on ties.
+type LeastLoaded struct {
+	Board board.Board
+}
+
+// Pick returns the developer with the fewest open cards.
+func (l *LeastLoaded) Pick(_ board.Card, developers []config.DeveloperSettings) (string, error) {
+	if len(developers) == 0 {
+		return "", fmt.Errorf("no developers to assign to")
+	}
+	best, bestLoad := "", -1
+	for _, d := range developers {
+		load, err := l.load(d.Name)
+		if err != nil {
+			return "", err
+		}
+		if bestLoad < 0 || load < bestLoad {
+			best, bestLoad = d.Name, load
+		}
+	}
+	return best, nil
+}
+
+// load counts the cards assigned to name that are not done.
+func (l *LeastLoaded) load(name string) (int, error) {
+	cards, err := l.Board.GetCardsAssignedTo(name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cards of %s: %w", name, err)
+	}
+	open := 0
+	for _, c := range cards {
+		if list, err := c.GetList(); err == nil && strings.EqualFold(list.GetName(), board.ListDone) {
+			continue
+		}
+		open++
+	}
+	return open, nil
+}
+
+// CapabilityMatch narrows the developers to those whose labels match the card's labels, component
+// or repository, then lets Then choose among them. All developers are candidates when none match.
+// A nil Then takes the first candidate.
+type CapabilityMatch struct {
+	Then AssignStrategy
+}
+
+// Pick returns a developer whose labels match the card.
+func (m *CapabilityMatch) Pick(card board.Card, developers []config.DeveloperSettings) (string, error) {
+	if len(developers) == 0 {
+		return "", fmt.Errorf("no developers to assign to")
+	}
+	labels := cardLabels(card)
+	var matched []config.DeveloperSettings
+	for _, d := range developers {
+		if hasLabel(d.Labels, labels) {
+			matched = append(matched, d)
+		}
+	}
+	if len(matched) == 0 {
+		matched = developers
+	}
+	if m.Then == nil {
+		return matched[0].Name, nil
+	}
+	return m.Then.Pick(card, matched)
+}
+
+// cardLabels returns the labels a card is matched on: its board labels, component and repository.
+func cardLabels(c board.Card) []string {
+	labels := board.Labels(c)
+	for _, l := range []string{board.Field(c, board.FieldComponent), board.RepoHint(c)} {
+		if l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func hasLabel(have, want []string) bool {
+	for _, h := range have {
+		for _, w := range want {
+			if strings.EqualFold(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// assignTicket assigns a newly created technical ticket to a developer chosen by the assignment strategy.
+// It returns the assignee, or "" when no developers are configured or the assignment failed.
+func (em *EngineeringManagerAgent) assignTicket(card board.Card) string {
+	if len(em.Developers) == 0 {
+		return ""
+	}
+	strategy := em.Assigner
+	if strategy == nil {
+		strategy = &RoundRobin{}
+		em.Assigner = strategy
+	}
+	name, err := strategy.Pick(card, em.Developers)
+	if err != nil {
+		fmt.Printf("Warning: failed to pick an assignee for %q: %v\n", card.GetName(), err)
+		return ""
+	}
+	if err := card.AssignTo(name); err != nil {
+		fmt.Printf("Warning: failed to assign %q to %s: %v\n", card.GetName(), name, err)
+		return ""
+	}
+	return name
+}
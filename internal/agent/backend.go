@@ -0,0 +1,828 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codecontext"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/policy"
+	"github.com/egobogo/aiagents/internal/testrunner"
+	"github.com/egobogo/aiagents/internal/tracing"
+)
+
+// DefaultRepairAttempts is how many times the model may try to fix a failing build before a human is asked.
+const DefaultRepairAttempts = 3
+
+// historyCommits is how many recent commits of each file the ticket mentions the implementation prompt shows.
+const historyCommits = 5
+
+// ErrBuildFailed is returned when the code still does not build after all repair attempts.
+var ErrBuildFailed = errors.New("build still failing after repair attempts")
+
+// ErrTestsFailed is returned when tests still fail after all test repair rounds.
+var ErrTestsFailed = errors.New("tests still failing after repair attempts")
+
+// DefaultCoverageRounds is how many times the model may add tests to reach MinCoverage before a human is asked.
+const DefaultCoverageRounds = 2
+
+// ErrCoverageTooLow is returned when the changed packages stay below MinCoverage.
+var ErrCoverageTooLow = errors.New("test coverage below the minimum")
+
+// BackendAgent writes and commits code for technical tickets.
+type BackendAgent struct {
+	*BaseAgent
+	MaxRepairAttempts int    // Zero uses DefaultRepairAttempts.
+	TestRepairs       int    // Rounds of fixes for failing tests before a human is asked; zero skips running tests.
+	LintRounds        int    // Rounds of fixes for golangci-lint findings on changed packages; zero skips linting.
+	Label             string // Only tickets carrying this label are picked up; empty accepts all.
+	ClarifyRounds     int    // Questions rounds allowed per ticket; zero uses DefaultInterviewRounds.
+	// MinCoverage is the minimum statement coverage, in percent, of the changed packages; zero skips the check.
+	MinCoverage float64
+	// AllowedPaths restricts writes to these repository-relative directories; empty allows the whole repository.
+	AllowedPaths []string
+	// ContextMode selects the repository code sent with each ticket (codecontext.ModeFull, ModeOutline or ModeRelated);
+	// empty sends none.
+	ContextMode string
+	// CI, when set, pushes each implemented ticket and moves it to review only once its checks pass.
+	CI *CIGate
+	// ScheduledBranches leaves MaintainBranches to a scheduled job instead of running it on every turn.
+	ScheduledBranches bool
+	// MigrationsDir is the repository directory of numbered SQL migrations; empty disables migration authoring.
+	MigrationsDir string
+	// MigrationCommand applies SQL read from stdin to a scratch database whose path replaces "{db}";
+	// empty uses codegen.DefaultMigrationCommand.
+	MigrationCommand []string
+}
+
+// NewBackendAgent creates a new BackendAgent using the provided BaseAgent.
+func NewBackendAgent(base *BaseAgent) *BackendAgent {
+	return &BackendAgent{
+		BaseAgent:         base,
+		MaxRepairAttempts: DefaultRepairAttempts,
+	}
+}
+
+// Backend returns the agent itself. Agents built on the Backend agent (DevOps, Frontend, Docs, APISpec)
+// promote it, so they can be configured like a Backend agent.
+func (b *BackendAgent) Backend() *BackendAgent {
+	return b
+}
+
+// createContext is a no-op; the Backend agent builds context per ticket.
+func (b *BackendAgent) createContext() error {
+	return nil
+}
+
+// Act implements every assigned ticket that carries the agent's label and waits in the sprint or in
+// Doing. Implemented tickets leave for Review, so they are not picked up again. Tickets in review with
+// an open pull request get their unresolved review threads addressed instead, after their branches are
+// brought up to date with the target branch.
+func (b *BackendAgent) Act() error {
+	if !b.ScheduledBranches {
+		if err := b.MaintainBranches(); err != nil {
+			return err
+		}
+	}
+	cards, err := b.FindReadyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if b.Label != "" && !board.HasLabel(card, b.Label) {
+			continue
+		}
+		list, err := card.GetList()
+		if err != nil {
+			fmt.Printf("Warning: failed to get the list of %q: %v\n", card.GetName(), err)
+			continue
+		}
+		if list.GetName() == board.ListReview {
+			if _, ok := pullRequestNumber(card); ok {
+				if _, err := b.AddressReviewThreads(card); err != nil {
+					return fmt.Errorf("failed to address review of %q: %w", card.GetName(), err)
+				}
+			}
+			continue
+		}
+		if !isIntake(list.GetName()) {
+			continue
+		}
+		if err := b.ImplementTicket(card); err != nil {
+			return fmt.Errorf("failed to implement %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// isIntake reports whether tickets in list are waiting to be implemented.
+func isIntake(list string) bool {
+	return list == board.ListSprint || list == board.ListDoing
+}
+
+// Implementation is the model's answer for a ticket: either open questions or the changes to make.
+type Implementation struct {
+	Questions []string   `json:"questions"`
+	Moves     []FileMove `json:"moves"`   // Applied before Files are written, e.g. to move a package.
+	Deletes   []string   `json:"deletes"` // Files or directories removed before Files are written.
+	// Patches are unified diffs for small edits of existing files, applied after Moves and Deletes.
+	Patches []string        `json:"patches"`
+	Files   []GeneratedFile `json:"files"`
+	Summary string          `json:"summary"`
+	// Migration is set when the ticket changes the database schema and MigrationsDir is configured.
+	Migration *MigrationDraft `json:"migration,omitempty"`
+}
+
+// ImplementTicket writes the code for a technical ticket on the ticket's feature branch.
+// Open questions are asked on the card and the model is consulted again with the answers;
+// the result is verified and committed with a generated Conventional Commit message, and the ticket
+// moves to review. With a CI gate the branch is pushed first and the ticket only moves once its checks
+// pass. Changes the policy blocks are reported on the card for a human.
+func (b *BackendAgent) ImplementTicket(card board.Card) (err error) {
+	b.CurrentTicketID = card.GetID()
+	span := b.Tracer.Start(b.Name, "ImplementTicket", tracing.AttrTicketID, card.GetID())
+	defer func() { span.Finish(err) }()
+	defer func() { reportViolation(card, err) }()
+	repo, err := b.CheckoutFor(card)
+	if err != nil {
+		return err
+	}
+	rounds := b.ClarifyRounds
+	if rounds <= 0 {
+		rounds = DefaultInterviewRounds
+	}
+
+	transcript := guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", card.GetName(), card.GetDescription()))
+	ticketText := card.GetName() + "\n" + card.GetDescription()
+	if history := describeHistory(repo, ticketText); history != "" {
+		transcript = "History of the files the ticket mentions:\n" + guard.Wrap("the commit history", history) + "\n\n" + transcript
+	}
+	code, err := codecontext.ForTicket(repo, b.ContextMode, ticketText)
+	if err != nil {
+		return fmt.Errorf("failed to build code context: %w", err)
+	}
+	if code != "" {
+		transcript = "Repository code:\n" + code + "\n" + transcript
+	}
+	if b.MigrationsDir != "" {
+		migrations, err := b.migrationPrompt(repo)
+		if err != nil {
+			return err
+		}
+		transcript += migrations
+	}
+	answered, err := b.ResumeQuestions(card)
+	if err != nil {
+		return err
+	}
+	for _, ex := range answered {
+		transcript += fmt.Sprintf("\n\n%s\nAnswer:\n%s", ex.Question, guard.Wrap("a card comment", ex.Answer))
+	}
+	state := b.withKnowledge(b.Context.GetContext(), ticketText)
+	var impl Implementation
+	for round := len(answered); ; round++ {
+		prompt := transcript
+		if round >= rounds {
+			prompt += "\n\nDo not ask further questions; implement the ticket now."
+		}
+		chatReq, err := b.PromptBuilder.Build(
+			b.Role,
+			"Implement",
+			state,
+			prompt,
+			Implementation{},
+			b.ModelClient.GetTemperature(),
+			b.ModelClient.GetModel(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to build implementation request: %w", err)
+		}
+		impl = Implementation{}
+		if err := b.ModelClient.ChatAdvancedParsed(b.withLongTerm(chatReq), &impl); err != nil {
+			return fmt.Errorf("failed to parse implementation response: %w", err)
+		}
+		if len(impl.Questions) == 0 || round >= rounds {
+			break
+		}
+		questions := selfReview(b.BaseAgent, "clarifying questions", guard.Wrap("the ticket", ticketText), impl.Questions)
+		question := "I have some questions before I start:\n- " + strings.Join(questions, "\n- ")
+		answer, err := b.Ask(card, question)
+		if err != nil {
+			return err
+		}
+		transcript += fmt.Sprintf("\n\n%s\nAnswer:\n%s", question, guard.Wrap("a card comment", answer))
+	}
+
+	if err := b.RearrangeFiles(repo, impl.Moves, impl.Deletes); err != nil {
+		return err
+	}
+	if err := b.ApplyPatches(repo, impl.Patches); err != nil {
+		return err
+	}
+	if err := b.WriteFiles(repo, impl.Files); err != nil {
+		return err
+	}
+	var migration codegen.Migration
+	verified := false
+	if impl.Migration != nil && b.MigrationsDir != "" {
+		if migration, verified, err = b.writeMigration(repo, card, impl.Migration); err != nil {
+			return err
+		}
+	}
+	message, err := b.ComposeCommitMessage(repo, card)
+	if err != nil {
+		return err
+	}
+	author, email := b.Author()
+	if err := b.CommitWork(repo, card, message, author, email); err != nil {
+		return err
+	}
+	b.ClearCheckpoint(card.GetID())
+	if migration.Up != "" {
+		postMigration(card, migration, impl.Migration, verified)
+	}
+	if impl.Summary != "" {
+		if err := card.WriteComment(impl.Summary); err != nil {
+			fmt.Printf("Warning: failed to post summary on %q: %v\n", card.GetName(), err)
+		}
+	}
+	if b.CI != nil {
+		return b.waitForGreen(repo, card)
+	}
+	return b.moveToReview(card, "implemented")
+}
+
+// describeHistory returns the recent commits and line ownership of the repository files ticket mentions,
+// so the model knows why they look the way they do. Files it cannot describe are left out.
+func describeHistory(repo *gitrepo.GitClient, ticket string) string {
+	files, err := repo.ListFiles("**/*")
+	if err != nil {
+		fmt.Printf("Warning: failed to list files for their history: %v\n", err)
+		return ""
+	}
+	var sb strings.Builder
+	for _, f := range codecontext.MentionedFiles(ticket, files) {
+		history, err := repo.DescribeHistory(f, historyCommits)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(history)
+	}
+	return sb.String()
+}
+
+// reportViolation asks a human on the card to handle a change the policy blocked, if err is one.
+func reportViolation(card board.Card, err error) {
+	var v *policy.ViolationError
+	if !errors.As(err, &v) {
+		return
+	}
+	text := fmt.Sprintf("The policy does not let me make this change: %s.", v.Violation)
+	switch v.Rule {
+	case policy.RuleApproval:
+		text += fmt.Sprintf(" Please review it and approve with \"%sapprove %s\", or make the change yourself.", notify.CommandPrefix, v.Target)
+	case policy.RuleDeletion:
+		text += fmt.Sprintf(" Please review it and approve with \"%sapprove-deletion %s\", or make the change yourself.", notify.CommandPrefix, v.Target)
+	default:
+		text += " Please make the change yourself if it is needed."
+	}
+	if err := card.WriteComment(text); err != nil {
+		fmt.Printf("Warning: failed to report policy violation on %q: %v\n", card.GetName(), err)
+	}
+}
+
+// GeneratedFile is a single file produced by the model.
+type GeneratedFile struct {
+	Path    string `json:"path"`    // Repository-relative path.
+	Content string `json:"content"` // Full file content.
+}
+
+// postProcess runs the codegen stage for a generated file before it is written.
+// Go files get their imports fixed and are gofmt-formatted; other files are left untouched.
+// Go files that do not parse are written as-is so the build step can report the error to the model.
+func (b *BackendAgent) postProcess(file GeneratedFile) []byte {
+	if !strings.EqualFold(filepath.Ext(file.Path), ".go") {
+		return []byte(file.Content)
+	}
+	formatted, err := codegen.FormatGo([]byte(file.Content))
+	if err != nil {
+		fmt.Printf("Warning: could not format %s: %v\n", file.Path, err)
+		return []byte(file.Content)
+	}
+	return formatted
+}
+
+// allowed reports whether path lies inside one of AllowedPaths.
+func (b *BackendAgent) allowed(path string) bool {
+	if len(b.AllowedPaths) == 0 {
+		return true
+	}
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, dir := range b.AllowedPaths {
+		dir = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(dir)), "/")
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// FileMove renames a file or a directory.
+type FileMove struct {
+	From string `json:"from"` // Repository-relative path.
+	To   string `json:"to"`   // Repository-relative path; must not exist yet.
+}
+
+// RearrangeFiles applies the moves, then the deletions, in repo. Paths outside AllowedPaths are
+// skipped with a warning.
+func (b *BackendAgent) RearrangeFiles(repo *gitrepo.GitClient, moves []FileMove, deletes []string) error {
+	for _, m := range moves {
+		if !b.allowed(m.From) || !b.allowed(m.To) {
+			fmt.Printf("Warning: moving %s to %s leaves the allowed paths %v; skipped\n", m.From, m.To, b.AllowedPaths)
+			continue
+		}
+		if err := repo.RenameFile(m.From, m.To); err != nil {
+			return fmt.Errorf("failed to move %s: %w", m.From, err)
+		}
+	}
+	for _, p := range deletes {
+		if !b.allowed(p) {
+			fmt.Printf("Warning: %s is outside the allowed paths %v; skipped\n", p, b.AllowedPaths)
+			continue
+		}
+		if err := repo.DeleteFile(p); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// WriteFiles post-processes and writes the generated files into repo.
+// Files outside AllowedPaths are skipped with a warning.
+func (b *BackendAgent) WriteFiles(repo *gitrepo.GitClient, files []GeneratedFile) error {
+	for _, f := range files {
+		if !b.allowed(f.Path) {
+			fmt.Printf("Warning: %s is outside the allowed paths %v; skipped\n", f.Path, b.AllowedPaths)
+			continue
+		}
+		if err := repo.WriteFile(f.Path, b.postProcess(f)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// ApplyPatches applies the unified diffs to repo in order. When a diff does not apply, the conflicting
+// hunks are sent back to the model for a corrected diff, up to MaxRepairAttempts times; diffs that touch
+// files outside AllowedPaths are skipped with a warning.
+func (b *BackendAgent) ApplyPatches(repo *gitrepo.GitClient, patches []string) error {
+	attempts := b.MaxRepairAttempts
+	if attempts <= 0 {
+		attempts = DefaultRepairAttempts
+	}
+	for _, diff := range patches {
+		for attempt := 0; ; attempt++ {
+			paths, err := gitrepo.PatchPaths(diff)
+			if err != nil {
+				return err
+			}
+			if p, ok := b.disallowed(paths); ok {
+				fmt.Printf("Warning: patch of %s is outside the allowed paths %v; skipped\n", p, b.AllowedPaths)
+				break
+			}
+			_, err = repo.ApplyPatch(diff)
+			var patchErr *gitrepo.PatchError
+			if err == nil {
+				break
+			}
+			if !errors.As(err, &patchErr) || attempt >= attempts {
+				return fmt.Errorf("failed to apply patch to %s: %w", strings.Join(paths, ", "), err)
+			}
+			if diff, err = b.repairPatch(repo, diff, paths, patchErr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// disallowed returns the first of paths outside AllowedPaths.
+func (b *BackendAgent) disallowed(paths []string) (string, bool) {
+	for _, p := range paths {
+		if !b.allowed(p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// PatchRepair is the model's corrected diff for a patch that did not apply.
+type PatchRepair struct {
+	Patch string `json:"patch"`
+}
+
+// repairPatch shows the model the conflicts of a diff and the current content of the files it touches
+// and returns the corrected diff.
+func (b *BackendAgent) repairPatch(repo *gitrepo.GitClient, diff string, paths []string, patchErr *gitrepo.PatchError) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("This patch does not apply. Return a corrected unified diff against the current files.\n\n")
+	sb.WriteString(board.CodeBlock(diff, "diff") + "\n" + patchErr.Error() + "\n")
+	for _, p := range paths {
+		if text, err := repo.ReadText(p); err == nil {
+			sb.WriteString(fmt.Sprintf("\nCurrent %s:\n%s", p, board.CodeBlock(text, "")))
+		}
+	}
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairPatch",
+		b.Context.GetContext(),
+		sb.String(),
+		PatchRepair{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build patch repair request: %w", err)
+	}
+	var repair PatchRepair
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &repair); err != nil {
+		return "", fmt.Errorf("failed to parse patch repair response: %w", err)
+	}
+	return repair.Patch, nil
+}
+
+// CommitWork validates the Conventional Commit message, verifies that the code in repo builds and passes vet, then commits it.
+// Compiler errors are fed back to the model, which may replace files up to MaxRepairAttempts times.
+// If the build still fails, the errors are posted on the card for a human and ErrBuildFailed is returned;
+// nothing is committed in that case. With TestRepairs set the tests must pass too (see verifyTests), with
+// MinCoverage set the changed packages must be covered well enough (see verifyCoverage), and with LintRounds
+// set lint findings are fixed before committing and the final lint report is posted on the card.
+// Changes to go.mod are tidied first, and requirement changes are asked for approval on the card before committing.
+func (b *BackendAgent) CommitWork(repo *gitrepo.GitClient, card board.Card, commitMessage, authorName, authorEmail string) error {
+	if err := gitrepo.ValidateCommitMessage(commitMessage); err != nil {
+		return fmt.Errorf("invalid commit message: %w", err)
+	}
+	attempts := b.MaxRepairAttempts
+	if attempts <= 0 {
+		attempts = DefaultRepairAttempts
+	}
+	deps, err := b.tidyDependencies(repo, card)
+	if err != nil {
+		return err
+	}
+
+	attempt, testRepairs, coverageRounds, lintRepairs := 0, 0, 0, 0
+	for {
+		err := codegen.VerifyGo(repo.RepoPath)
+		if err == nil {
+			repaired, err := b.verifyTests(repo, card, &testRepairs)
+			if err != nil {
+				return err
+			}
+			if !repaired {
+				if repaired, err = b.verifyCoverage(repo, card, &coverageRounds); err != nil {
+					return err
+				}
+			}
+			var lint *codegen.LintReport
+			if !repaired {
+				lint, repaired = b.lintChanges(repo, &lintRepairs)
+			}
+			if repaired {
+				continue // Fixes must build and pass the tests too.
+			}
+			if len(deps) > 0 {
+				if err := b.approveDependencies(repo, card, deps); err != nil {
+					return err
+				}
+			}
+			if err := repo.CommitChanges(commitMessage, authorName, authorEmail); err != nil {
+				return err
+			}
+			postLintReport(card, lint)
+			return nil
+		}
+		var buildErr *codegen.BuildError
+		if !errors.As(err, &buildErr) {
+			return fmt.Errorf("failed to verify build: %w", err)
+		}
+		if attempt >= attempts {
+			if card != nil {
+				comment := fmt.Sprintf("The build is still failing after %d repair attempt(s). Please take a look.\n\n%s", attempts, board.CodeBlock(buildErr.Error(), ""))
+				if err := card.WriteComment(comment); err != nil {
+					fmt.Printf("Warning: failed to escalate build failure: %v\n", err)
+				}
+			}
+			return fmt.Errorf("%w: %v", ErrBuildFailed, buildErr)
+		}
+		if err := b.repairBuild(repo, buildErr); err != nil {
+			return err
+		}
+		attempt++
+	}
+}
+
+// verifyTests runs the tests in repo. Failures are fed back to the model together with the
+// relevant source and test files, and only the failed packages are re-run after each fix, until
+// they pass or *repairs reaches TestRepairs; the count is shared across calls so CommitWork can
+// re-verify the build in between. It reports whether any fix was applied. When the tests still
+// fail, the failures are posted on the card and ErrTestsFailed is returned.
+func (b *BackendAgent) verifyTests(repo *gitrepo.GitClient, card board.Card, repairs *int) (bool, error) {
+	if b.TestRepairs <= 0 {
+		return false, nil
+	}
+	report, err := testrunner.Run(repo.RepoPath)
+	if err != nil {
+		return false, err
+	}
+	repaired := false
+	for report.Failed() {
+		if *repairs >= b.TestRepairs {
+			if card != nil {
+				comment := fmt.Sprintf("The tests are still failing after %d repair attempt(s). Please take a look.\n\n%s", b.TestRepairs, board.CodeBlock(report.String(), ""))
+				if err := card.WriteComment(comment); err != nil {
+					fmt.Printf("Warning: failed to escalate test failures: %v\n", err)
+				}
+			}
+			return repaired, fmt.Errorf("%w: %s", ErrTestsFailed, strings.Join(report.FailedPackages(), ", "))
+		}
+		if err := b.repairTests(repo, report); err != nil {
+			return repaired, err
+		}
+		*repairs++
+		repaired = true
+		if report, err = testrunner.Run(repo.RepoPath, report.FailedPackages()...); err != nil {
+			return repaired, err
+		}
+	}
+	return repaired, nil
+}
+
+// verifyCoverage measures the statement coverage of the changed packages. Below MinCoverage the
+// model is asked to add tests for the least covered functions, up to DefaultCoverageRounds times
+// across calls; it reports whether tests were added. When coverage stays too low, the uncovered
+// functions are posted on the card and ErrCoverageTooLow is returned, keeping the ticket out of Review.
+func (b *BackendAgent) verifyCoverage(repo *gitrepo.GitClient, card board.Card, rounds *int) (bool, error) {
+	if b.MinCoverage <= 0 {
+		return false, nil
+	}
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		return false, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	report, err := testrunner.Cover(repo.RepoPath, codegen.ChangedPackages(changed)...)
+	if err != nil {
+		return false, err
+	}
+	if report.Total >= b.MinCoverage {
+		return false, nil
+	}
+	if *rounds >= DefaultCoverageRounds {
+		if card != nil {
+			comment := fmt.Sprintf("Coverage of %s is %.1f%%, below the minimum of %.1f%%, after %d round(s) of added tests. Please take a look.\n\n%s",
+				strings.Join(report.Packages, ", "), report.Total, b.MinCoverage, DefaultCoverageRounds, board.CodeBlock(formatCoverage(report.Below(b.MinCoverage)), ""))
+			if err := card.WriteComment(comment); err != nil {
+				fmt.Printf("Warning: failed to escalate low coverage: %v\n", err)
+			}
+		}
+		return false, fmt.Errorf("%w: %.1f%% < %.1f%%", ErrCoverageTooLow, report.Total, b.MinCoverage)
+	}
+	*rounds++
+	if err := b.addTests(repo, report); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// formatCoverage lists functions with their coverage, one per line.
+func formatCoverage(funcs []testrunner.FuncCoverage) string {
+	lines := make([]string, len(funcs))
+	for i, f := range funcs {
+		lines[i] = fmt.Sprintf("%s:%d: %s %.1f%%", f.File, f.Line, f.Function, f.Percent)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// addTests asks the model for tests covering the functions below MinCoverage, sending their
+// source files and the matching test files where they exist.
+func (b *BackendAgent) addTests(repo *gitrepo.GitClient, report *testrunner.CoverageReport) error {
+	below := report.Below(b.MinCoverage)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Statement coverage of %s is %.1f%%; at least %.1f%% is required. ", strings.Join(report.Packages, ", "), report.Total, b.MinCoverage))
+	sb.WriteString("Add tests for these uncovered functions. Return the complete content of every test file you add or change.\n\n")
+	sb.WriteString(formatCoverage(below))
+	seen := make(map[string]bool)
+	for _, f := range below {
+		stem := strings.TrimSuffix(f.File, ".go")
+		for _, name := range []string{f.File, stem + "_test.go"} {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			content, err := repo.ReadText(name)
+			if err != nil {
+				continue // The test file may not exist yet.
+			}
+			sb.WriteString(fmt.Sprintf("\n\nFile: %s\n%s", name, board.CodeBlock(content, "go")))
+		}
+	}
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"AddTests",
+		b.Context.GetContext(),
+		sb.String(),
+		[]GeneratedFile{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build coverage request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse coverage response: %w", err)
+	}
+	return b.WriteFiles(repo, wrapper.Result)
+}
+
+// lintChanges lints the changed packages in repo and, while *repairs is below LintRounds, asks the
+// model to fix the findings. It returns the latest report and whether a fix was applied. A missing
+// linter or a failed run is logged and skips the stage.
+func (b *BackendAgent) lintChanges(repo *gitrepo.GitClient, repairs *int) (*codegen.LintReport, bool) {
+	if b.LintRounds <= 0 {
+		return nil, false
+	}
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		fmt.Printf("Warning: failed to list changed files for linting: %v\n", err)
+		return nil, false
+	}
+	report, err := codegen.LintGo(repo.RepoPath, codegen.ChangedPackages(changed))
+	if err != nil {
+		fmt.Printf("Warning: skipping lint: %v\n", err)
+		return nil, false
+	}
+	if len(report.Issues) == 0 || *repairs >= b.LintRounds {
+		return report, false
+	}
+	*repairs++
+	if err := b.repairLint(repo, report); err != nil {
+		fmt.Printf("Warning: failed to fix lint findings: %v\n", err)
+		return report, false
+	}
+	return report, true
+}
+
+// repairLint asks the model for corrected versions of the files with lint findings.
+func (b *BackendAgent) repairLint(repo *gitrepo.GitClient, report *codegen.LintReport) error {
+	var sb strings.Builder
+	sb.WriteString("golangci-lint reports these findings. Return the complete corrected content of every file that needs to change.\n\n")
+	sb.WriteString(report.String())
+	for _, f := range report.Files() {
+		content, err := repo.ReadText(f)
+		if err != nil {
+			fmt.Printf("Warning: failed to read %s: %v\n", f, err)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n\nFile: %s\n%s", f, board.CodeBlock(content, "go")))
+	}
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairLint",
+		b.Context.GetContext(),
+		sb.String(),
+		[]GeneratedFile{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build lint repair request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse lint repair response: %w", err)
+	}
+	return b.WriteFiles(repo, wrapper.Result)
+}
+
+// postLintReport posts the final lint report of a commit on the card.
+func postLintReport(card board.Card, report *codegen.LintReport) {
+	if card == nil || report == nil || len(report.Packages) == 0 {
+		return
+	}
+	text := fmt.Sprintf("Lint report for %s:\n\n%s", strings.Join(report.Packages, ", "), board.CodeBlock(report.String(), ""))
+	if err := card.WriteComment(text); err != nil {
+		fmt.Printf("Warning: failed to post lint report on %q: %v\n", card.GetName(), err)
+	}
+}
+
+// repairTests asks the model for corrected versions of the files behind the failing tests.
+func (b *BackendAgent) repairTests(repo *gitrepo.GitClient, report *testrunner.Report) error {
+	var sb strings.Builder
+	sb.WriteString("The tests fail. Return the complete corrected content of every file that needs to change.\n\n")
+	sb.WriteString(report.String())
+	files, err := testrunner.RelevantFiles(repo.RepoPath, report.Failures)
+	if err != nil {
+		fmt.Printf("Warning: failed to find files for the failing tests: %v\n", err)
+	}
+	for _, f := range files {
+		content, err := repo.ReadText(f)
+		if err != nil {
+			fmt.Printf("Warning: failed to read %s: %v\n", f, err)
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n\nFile: %s\n%s", f, board.CodeBlock(content, "go")))
+	}
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairTests",
+		b.Context.GetContext(),
+		sb.String(),
+		[]GeneratedFile{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build test repair request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse test repair response: %w", err)
+	}
+	return b.WriteFiles(repo, wrapper.Result)
+}
+
+// repairBuild asks the model for corrected versions of the files named in the build output.
+func (b *BackendAgent) repairBuild(repo *gitrepo.GitClient, buildErr *codegen.BuildError) error {
+	prompt := fmt.Sprintf("The code does not compile. Return the complete corrected content of every file that needs to change.\n\n%s", buildErr.Error())
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairBuild",
+		b.Context.GetContext(),
+		prompt,
+		[]GeneratedFile{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build repair request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse repair response: %w", err)
+	}
+	return b.WriteFiles(repo, wrapper.Result)
+}
+
+// ComposeCommitMessage asks the model for a Conventional Commit message describing the uncommitted
+// changes in repo for the given card. If the model's answer does not validate, a message derived
+// from the changed paths and the card name is used instead.
+func (b *BackendAgent) ComposeCommitMessage(repo *gitrepo.GitClient, card board.Card) (string, error) {
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		return "", err
+	}
+	fallback := gitrepo.NewCommitMessage(changed, card.GetID(), card.GetName()).String()
+
+	prompt := fmt.Sprintf(
+		"Write a Conventional Commit message for ticket %s (%s).\nAllowed types: %s.\nChanged files:\n%s",
+		card.GetID(), card.GetName(), strings.Join(gitrepo.CommitTypes, ", "), strings.Join(changed, "\n"),
+	)
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"CommitMessage",
+		b.Context.GetContext(),
+		prompt,
+		gitrepo.CommitMessage{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build commit message request: %w", err)
+	}
+	var msg gitrepo.CommitMessage
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &msg); err != nil {
+		fmt.Printf("Warning: failed to generate commit message, using fallback: %v\n", err)
+		return fallback, nil
+	}
+	msg.TicketID = card.GetID()
+	if err := gitrepo.ValidateCommitMessage(msg.String()); err != nil {
+		fmt.Printf("Warning: generated commit message rejected, using fallback: %v\n", err)
+		return fallback, nil
+	}
+	return msg.String(), nil
+}
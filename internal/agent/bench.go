@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/testrunner"
+)
+
+// ErrBenchmarkRegression is returned when a ticket slowed a benchmark down beyond the threshold and was blocked.
+var ErrBenchmarkRegression = errors.New("benchmark regression")
+
+// DefaultRegressionThreshold is the significant slowdown, in percent of time/op, that blocks a ticket.
+const DefaultRegressionThreshold = 20.0
+
+// benchAlpha is the significance level below which a change is not considered noise.
+const benchAlpha = 0.05
+
+// benchMarker prefixes benchmark reports, followed by the commit they measured, so every commit
+// is benchmarked once.
+const benchMarker = "[benchmarks]"
+
+// BenchmarkAgent benchmarks the packages a ticket changes before and after the change and blocks
+// tickets that make them significantly slower.
+type BenchmarkAgent struct {
+	*BaseAgent
+	BaseBranch string                  // Branch the ticket forked from is measured at the fork point.
+	Bench      testrunner.BenchOptions // Runs per benchmark and bench time.
+	// Threshold is the significant slowdown of time/op, in percent, that counts as a big regression;
+	// zero uses DefaultRegressionThreshold.
+	Threshold         float64
+	BlockOnRegression bool // Move the card to the blocked list on big regressions, keeping it out of review.
+}
+
+// NewBenchmarkAgent creates a new BenchmarkAgent using the provided BaseAgent.
+func NewBenchmarkAgent(base *BaseAgent) *BenchmarkAgent {
+	return &BenchmarkAgent{
+		BaseAgent:         base,
+		BaseBranch:        "main",
+		BlockOnRegression: true,
+	}
+}
+
+// createContext is a no-op; benchmarks need no model context.
+func (a *BenchmarkAgent) createContext() error {
+	return nil
+}
+
+// Act benchmarks the latest commit of every technical ticket in progress or in review.
+func (a *BenchmarkAgent) Act() error {
+	for _, list := range []string{board.ListDoing, board.ListReview} {
+		cards, err := a.BoardClient.GetCardsFromList(list)
+		if err != nil {
+			return fmt.Errorf("failed to get tickets in %s: %w", list, err)
+		}
+		for _, card := range cards {
+			if _, ok := board.ParentURL(card.GetDescription()); !ok {
+				continue // Only technical tickets change code.
+			}
+			if _, err := a.Benchmark(card); err != nil && !errors.Is(err, ErrBenchmarkRegression) {
+				fmt.Printf("Warning: failed to benchmark %q: %v\n", card.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// changedGoFiles returns the Go files a diff adds, changes or removes.
+func changedGoFiles(diff string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, l := range strings.Split(diff, "\n") {
+		var p string
+		switch {
+		case strings.HasPrefix(l, "+++ b/"):
+			p = strings.TrimPrefix(l, "+++ b/")
+		case strings.HasPrefix(l, "--- a/"):
+			p = strings.TrimPrefix(l, "--- a/")
+		default:
+			continue
+		}
+		if strings.HasSuffix(p, ".go") && !seen[p] {
+			seen[p] = true
+			files = append(files, p)
+		}
+	}
+	return files
+}
+
+// Benchmark runs the benchmarks of the packages the ticket branch changes, at the commit the branch
+// forked from and at its head, and posts a benchstat-style report on the card. A significant slowdown
+// of time/op above Threshold blocks the ticket and returns ErrBenchmarkRegression. Each commit is
+// benchmarked once; it returns nil deltas when there is nothing to measure.
+func (a *BenchmarkAgent) Benchmark(card board.Card) ([]testrunner.BenchDelta, error) {
+	a.CurrentTicketID = card.GetID()
+	base, err := a.RepoFor(card)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := base.WorktreeFor(card.GetID())
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.HeadCommit()
+	if err != nil {
+		return nil, err
+	}
+	marker := fmt.Sprintf("%s %.7s", benchMarker, head)
+	if hasMarker(card, marker) {
+		return nil, nil
+	}
+	diff, err := repo.Diff(a.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	packages := codegen.ChangedPackages(changedGoFiles(diff))
+	if len(packages) == 0 {
+		return nil, nil
+	}
+
+	after, err := testrunner.Bench(repo.RepoPath, packages, a.Bench)
+	if err != nil {
+		return nil, err
+	}
+	if len(after) == 0 {
+		return nil, card.WriteComment(marker + " No benchmarks cover the changed packages " + strings.Join(packages, ", ") + ".")
+	}
+	before, err := a.benchBaseline(repo, packages)
+	if err != nil {
+		fmt.Printf("Warning: no baseline for %q: %v\n", card.GetName(), err)
+	}
+	deltas := testrunner.CompareBench(before, after)
+
+	threshold := a.Threshold
+	if threshold <= 0 {
+		threshold = DefaultRegressionThreshold
+	}
+	var regressions []string
+	for _, d := range deltas {
+		if d.Unit == "ns/op" && d.Significant(benchAlpha) && d.Change >= threshold {
+			regressions = append(regressions, fmt.Sprintf("%s %+.1f%%", d.Name, d.Change))
+		}
+	}
+	var added []string
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+
+	report := marker + " "
+	switch {
+	case len(regressions) > 0:
+		report += fmt.Sprintf("Regressions above %.0f%%: %s.", threshold, strings.Join(regressions, ", "))
+	case len(deltas) > 0:
+		report += "No significant regressions."
+	default:
+		report += "No baseline to compare with."
+	}
+	if len(deltas) > 0 {
+		report += "\n\n" + board.CodeBlock(testrunner.FormatBench(deltas, benchAlpha), "")
+	}
+	if len(added) > 0 {
+		report += "\n\nNew benchmarks: " + strings.Join(added, ", ")
+	}
+	if err := card.WriteComment(report); err != nil {
+		fmt.Printf("Warning: failed to post benchmark report on %q: %v\n", card.GetName(), err)
+	}
+	if len(regressions) == 0 {
+		return deltas, nil
+	}
+	if a.BlockOnRegression {
+		if err := board.TransitionTicket(card, board.ListBlocked, a.Name, "benchmark regression: "+strings.Join(regressions, ", ")); err != nil {
+			return deltas, fmt.Errorf("failed to block ticket: %w", err)
+		}
+	}
+	return deltas, ErrBenchmarkRegression
+}
+
+// benchBaseline runs the benchmarks on a copy of the commit the ticket branch forked from.
+func (a *BenchmarkAgent) benchBaseline(repo *gitrepo.GitClient, packages []string) (testrunner.BenchResults, error) {
+	forkPoint, err := repo.MergeBase(a.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "bench-base-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create baseline checkout: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := repo.ExportTree(forkPoint, dir); err != nil {
+		return nil, err
+	}
+	return testrunner.Bench(dir, packages, a.Bench)
+}
@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/testrunner"
+)
+
+// ErrBranchConflicts is returned when a ticket branch conflicts with its base branch in ways the
+// model could not resolve.
+var ErrBranchConflicts = errors.New("branch conflicts with its base branch")
+
+// targetBranch returns the branch pull requests target and ticket branches are kept up to date with.
+func (b *BackendAgent) targetBranch() string {
+	if b.CI == nil || b.CI.TargetBranch == "" {
+		return "main"
+	}
+	return b.CI.TargetBranch
+}
+
+// MaintainBranches keeps the branches of the agent's tickets in review up to date with the target
+// branch. Conflicts that need a human are reported on the ticket and do not stop the other tickets.
+func (b *BackendAgent) MaintainBranches() error {
+	if b.CI == nil {
+		return nil
+	}
+	cards, err := b.FindMyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if b.Label != "" && !board.HasLabel(card, b.Label) {
+			continue
+		}
+		if list, err := card.GetList(); err != nil || list.GetName() != board.ListReview {
+			continue
+		}
+		if _, err := b.UpdateBranch(card); err != nil && !errors.Is(err, ErrBranchConflicts) {
+			return fmt.Errorf("failed to update the branch of %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// UpdateBranch merges the target branch into the ticket's branch when it has fallen behind.
+// Conflicts are handed to the model; if any hunk cannot be resolved confidently the merge is
+// abandoned, the conflicts are posted on the card and ErrBranchConflicts is returned. The merged
+// branch must still build and pass the tests, with the usual repairs, before it is pushed.
+// It reports whether the branch was updated.
+func (b *BackendAgent) UpdateBranch(card board.Card) (bool, error) {
+	b.CurrentTicketID = card.GetID()
+	repo, err := b.CheckoutFor(card)
+	if err != nil {
+		return false, err
+	}
+	target := b.targetBranch()
+	behind, err := repo.BehindRemote("", "", target)
+	if err != nil || behind == 0 {
+		return false, err
+	}
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return false, err
+	}
+
+	author, email := b.Author()
+	conflicted, err := repo.MergeRemote("", "", target, author, email)
+	if errors.Is(err, gitrepo.ErrConflicts) {
+		resolved, err := b.ResolveConflicts(repo, card, DefaultConflictConfidence)
+		if err != nil {
+			return false, errors.Join(err, repo.AbortMerge())
+		}
+		if !resolved {
+			if err := repo.AbortMerge(); err != nil {
+				return false, err
+			}
+			comment := fmt.Sprintf("%s is %d commit(s) behind %s and merging it conflicts in %s. Please resolve the conflicts on the branch.",
+				branch, behind, target, strings.Join(conflicted, ", "))
+			if err := card.WriteComment(comment); err != nil {
+				fmt.Printf("Warning: failed to report conflicts on %q: %v\n", card.GetName(), err)
+			}
+			return false, fmt.Errorf("%w: %s", ErrBranchConflicts, strings.Join(conflicted, ", "))
+		}
+		err = repo.CompleteMerge(fmt.Sprintf("Merge %s into %s", target, branch), author, email)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := b.verifyMerge(repo, card, target); err != nil {
+		return false, err
+	}
+	if err := repo.PushChanges("", ""); err != nil {
+		return false, err
+	}
+	comment := fmt.Sprintf("Merged %d new commit(s) from %s into %s", behind, target, branch)
+	if len(conflicted) > 0 {
+		comment += fmt.Sprintf(", resolving conflicts in %s", strings.Join(conflicted, ", "))
+	}
+	if err := card.WriteComment(comment + "."); err != nil {
+		fmt.Printf("Warning: failed to report the branch update on %q: %v\n", card.GetName(), err)
+	}
+	return true, nil
+}
+
+// verifyMerge runs the build and, with TestRepairs, the tests on a merged branch. When they fail,
+// the first repair is made here and CommitWork verifies and commits it, repairing further as needed.
+func (b *BackendAgent) verifyMerge(repo *gitrepo.GitClient, card board.Card, target string) error {
+	err := codegen.VerifyGo(repo.RepoPath)
+	if err == nil {
+		if b.TestRepairs <= 0 {
+			return nil
+		}
+		report, err := testrunner.Run(repo.RepoPath)
+		if err != nil {
+			return err
+		}
+		if !report.Failed() {
+			return nil
+		}
+		if err := b.repairTests(repo, report); err != nil {
+			return err
+		}
+	} else {
+		var buildErr *codegen.BuildError
+		if !errors.As(err, &buildErr) {
+			return fmt.Errorf("failed to verify build: %w", err)
+		}
+		if err := b.repairBuild(repo, buildErr); err != nil {
+			return err
+		}
+	}
+	author, email := b.Author()
+	return b.CommitWork(repo, card, "fix: adapt to changes on "+target, author, email)
+}
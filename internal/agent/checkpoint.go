@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+)
+
+// LoadCheckpoint returns the saved progress of the agent on a ticket, or an empty checkpoint
+// when there is none or Checkpoints is not configured.
+func (a *BaseAgent) LoadCheckpoint(ticketID string) *checkpoint.Checkpoint {
+	if a.Checkpoints == nil {
+		return checkpoint.New(a.Name, ticketID)
+	}
+	cp, err := a.Checkpoints.Load(a.Name, ticketID)
+	if err != nil {
+		fmt.Printf("Warning: failed to load checkpoint for %s: %v\n", ticketID, err)
+	}
+	if cp == nil {
+		return checkpoint.New(a.Name, ticketID)
+	}
+	return cp
+}
+
+// SaveCheckpoint persists progress on a ticket. Failures are logged; the work itself continues.
+// Checkpoints without a ticket (e.g. ideas given on the command line) are not saved.
+func (a *BaseAgent) SaveCheckpoint(cp *checkpoint.Checkpoint) {
+	if a.Checkpoints == nil || cp.TicketID == "" {
+		return
+	}
+	if err := a.Checkpoints.Save(cp); err != nil {
+		fmt.Printf("Warning: failed to save checkpoint for %s: %v\n", cp.TicketID, err)
+	}
+}
+
+// ClearCheckpoint drops the saved progress once a ticket is finished.
+func (a *BaseAgent) ClearCheckpoint(ticketID string) {
+	if a.Checkpoints == nil || ticketID == "" {
+		return
+	}
+	if err := a.Checkpoints.Clear(a.Name, ticketID); err != nil {
+		fmt.Printf("Warning: failed to clear checkpoint for %s: %v\n", ticketID, err)
+	}
+}
+
+// savePlan records the decided output for a ticket so a resume reuses it instead of asking the model again.
+func (a *BaseAgent) savePlan(cp *checkpoint.Checkpoint, plan interface{}) {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode plan for %s: %v\n", cp.TicketID, err)
+		return
+	}
+	cp.Plan, cp.Phase = data, checkpoint.PhasePlanned
+	a.SaveCheckpoint(cp)
+}
+
+// restorePlan decodes a previously saved plan into out and reports whether there was one.
+func (a *BaseAgent) restorePlan(cp *checkpoint.Checkpoint, out interface{}) bool {
+	if len(cp.Plan) == 0 {
+		return false
+	}
+	if err := json.Unmarshal(cp.Plan, out); err != nil {
+		fmt.Printf("Warning: discarding unreadable plan for %s: %v\n", cp.TicketID, err)
+		return false
+	}
+	fmt.Printf("Resuming %s from its saved plan (%d card(s) already created)\n", cp.TicketID, len(cp.Created))
+	return true
+}
+
+// createdCard returns the card created for title before a restart, if it still exists.
+func (a *BaseAgent) createdCard(cp *checkpoint.Checkpoint, title string) (board.Card, bool) {
+	id, ok := cp.Created[title]
+	if !ok {
+		return nil, false
+	}
+	cards, err := a.BoardClient.GetCards()
+	if err != nil {
+		fmt.Printf("Warning: failed to look up %q created earlier: %v\n", title, err)
+		return nil, false
+	}
+	for _, c := range cards {
+		if c.GetID() == id {
+			return c, true
+		}
+	}
+	return nil, false
+}
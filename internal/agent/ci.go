@@ -0,0 +1,189 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// DefaultCIFixAttempts is how many times failing checks are fed back to the model before a human is asked.
+const DefaultCIFixAttempts = 3
+
+// ErrChecksFailed is returned when the CI checks still fail after all fix attempts.
+var ErrChecksFailed = errors.New("checks still failing after fix attempts")
+
+// CIGate makes the Backend agent push its work, open a pull request and wait for green checks
+// before the ticket moves to review.
+type CIGate struct {
+	Provider     vcs.VCSProvider
+	Hub          *vcs.StatusHub // Optional; webhook deliveries trigger an immediate status check.
+	TargetBranch string         // Pull requests target this branch; empty uses "main".
+	FixAttempts  int            // Zero uses DefaultCIFixAttempts.
+	PollInterval time.Duration  // Zero uses vcs.DefaultPollInterval.
+	Timeout      time.Duration  // Per pipeline; zero uses vcs.DefaultPipelineTimeout.
+}
+
+// waitForGreen pushes the ticket's branch, opens a pull request and waits for its checks. Failed
+// job logs are fed back to the model, whose fixes are committed and pushed up to FixAttempts times.
+// Once the checks pass the ticket moves to review; otherwise the failure is posted on the card.
+func (b *BackendAgent) waitForGreen(repo *gitrepo.GitClient, card board.Card) error {
+	ci := b.CI
+	attempts := ci.FixAttempts
+	if attempts <= 0 {
+		attempts = DefaultCIFixAttempts
+	}
+	branch, err := repo.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	if err := repo.PushTo("", "", gitrepo.PushOptions{SetUpstream: true}); err != nil {
+		return err
+	}
+	b.openPullRequest(card, branch)
+
+	for attempt := 0; ; attempt++ {
+		sha, err := repo.HeadCommit()
+		if err != nil {
+			return err
+		}
+		status, err := vcs.WaitForPipeline(ci.Provider, sha, vcs.WaitOptions{Interval: ci.PollInterval, Timeout: ci.Timeout, Hub: ci.Hub})
+		if err != nil {
+			b.reportChecks(card, fmt.Sprintf("The checks on %s did not finish: %v", branch, err), nil)
+			return err
+		}
+		switch status {
+		case vcs.PipelineSuccess:
+			return b.moveToReview(card, fmt.Sprintf("checks passed on %s", branch))
+		case vcs.PipelineCanceled:
+			b.reportChecks(card, fmt.Sprintf("The checks on %s were canceled. Please take a look.", branch), nil)
+			return fmt.Errorf("checks on %s were canceled", branch)
+		case vcs.PipelineManual:
+			b.reportChecks(card, fmt.Sprintf("The checks on %s wait for a manual job. Please start it or take a look.", branch), nil)
+			return fmt.Errorf("checks on %s wait for a manual job", branch)
+		}
+
+		logs := b.failedJobLogs(sha)
+		if attempt >= attempts || !hasLogs(logs) {
+			b.reportChecks(card, fmt.Sprintf("The checks on %s are still failing after %d fix attempt(s). Please take a look.", branch, attempt), logs)
+			return fmt.Errorf("%w on %s", ErrChecksFailed, branch)
+		}
+		if err := b.repairChecks(repo, logs); err != nil {
+			return err
+		}
+		if changed, err := repo.ChangedFiles(); err != nil || len(changed) == 0 {
+			b.reportChecks(card, fmt.Sprintf("The checks on %s are failing and no fix was found. Please take a look.", branch), logs)
+			return fmt.Errorf("%w on %s", ErrChecksFailed, branch)
+		}
+		message, err := b.ComposeCommitMessage(repo, card)
+		if err != nil {
+			return err
+		}
+		author, email := b.Author()
+		if err := b.CommitWork(repo, card, message, author, email); err != nil {
+			return err
+		}
+		if err := repo.PushChanges("", ""); err != nil {
+			return err
+		}
+	}
+}
+
+// openPullRequest opens a pull request for branch and links it on the card. Failures, e.g. because
+// one is already open, are only logged.
+func (b *BackendAgent) openPullRequest(card board.Card, branch string) {
+	pr, err := b.CI.Provider.CreatePullRequest(card.GetName(), fmt.Sprintf("Ticket: %s\n\n%s", card.GetURL(), card.GetDescription()), branch, b.targetBranch())
+	if err != nil {
+		fmt.Printf("Warning: failed to open pull request for %s: %v\n", branch, err)
+		return
+	}
+	if err := card.AddAttachment(board.Attachment{Name: fmt.Sprintf("Pull request #%d", pr.Number), URL: pr.URL}); err != nil {
+		fmt.Printf("Warning: failed to link pull request on %q: %v\n", card.GetName(), err)
+	}
+}
+
+// failedJobLogs returns the logs of the failed jobs of sha when the provider offers them.
+func (b *BackendAgent) failedJobLogs(sha string) []vcs.JobLog {
+	p, ok := b.CI.Provider.(vcs.CILogs)
+	if !ok {
+		return nil
+	}
+	logs, err := p.FailedJobLogs(sha)
+	if err != nil {
+		fmt.Printf("Warning: failed to get job logs of %s: %v\n", sha, err)
+	}
+	return logs
+}
+
+// hasLogs reports whether any job log has output the model could work with.
+func hasLogs(logs []vcs.JobLog) bool {
+	for _, l := range logs {
+		if strings.TrimSpace(l.Log) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// repairChecks asks the model for corrected versions of the files behind the failed jobs.
+func (b *BackendAgent) repairChecks(repo *gitrepo.GitClient, logs []vcs.JobLog) error {
+	var sb strings.Builder
+	for _, l := range logs {
+		if strings.TrimSpace(l.Log) != "" {
+			sb.WriteString(fmt.Sprintf("Job: %s\n%s\n\n", l.Name, l.Log))
+		}
+	}
+	prompt := "The CI checks failed. Return the complete corrected content of every file that needs to change.\n\n" +
+		guard.Wrap("the CI logs", sb.String())
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairChecks",
+		b.Context.GetContext(),
+		prompt,
+		[]GeneratedFile{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build check repair request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse check repair response: %w", err)
+	}
+	return b.WriteFiles(repo, wrapper.Result)
+}
+
+// reportChecks posts a check failure on the card, followed by the failed jobs.
+func (b *BackendAgent) reportChecks(card board.Card, text string, logs []vcs.JobLog) {
+	for _, l := range logs {
+		text += "\n\n" + l.Name
+		if l.URL != "" {
+			text += " " + l.URL
+		}
+		if l.Log != "" {
+			text += "\n" + board.CodeBlock(vcs.TailLog(l.Log, 30), "")
+		}
+	}
+	if err := card.WriteComment(text); err != nil {
+		fmt.Printf("Warning: failed to report checks on %q: %v\n", card.GetName(), err)
+	}
+}
+
+// moveToReview moves the ticket to review for reason, passing through Doing when it has not been moved there yet.
+func (b *BackendAgent) moveToReview(card board.Card, reason string) error {
+	if list, err := card.GetList(); err == nil && list.GetName() != board.ListDoing &&
+		board.DefaultStateMachine.CanTransition(list.GetName(), board.ListDoing) {
+		if err := board.TransitionTicket(card, board.ListDoing, b.Name, "implemented"); err != nil {
+			return err
+		}
+	}
+	return board.TransitionTicket(card, board.ListReview, b.Name, reason)
+}
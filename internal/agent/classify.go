@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// ModeClassify is the prompt mode of short yes/no and labelling checks. Requests built with it
+// carry model.ClassClassification, so modelRouting can send them to a cheaper model.
+const ModeClassify = "Classify"
+
+// Classification is the model's yes/no answer to a question about some text.
+type Classification struct {
+	Answer bool   `json:"answer"`
+	Reason string `json:"reason"`
+}
+
+// classify asks the model a yes/no question about text, which comes from source and is
+// framed as untrusted.
+func (a *BaseAgent) classify(question, source, text string) (Classification, error) {
+	prompt := fmt.Sprintf("Answer the question below about the content that follows with true or false.\n\nQuestion: %s\n\n%s",
+		question, guard.Wrap(source, text))
+	var result Classification
+	if err := a.chatStructured(ModeClassify, prompt, &result); err != nil {
+		return Classification{}, err
+	}
+	return result, nil
+}
+
+// chatStructured builds a request in mode from the agent's context and parses the answer into target,
+// a pointer whose element type is the requested output.
+func (a *BaseAgent) chatStructured(mode, prompt string, target interface{}) error {
+	chatReq, err := a.PromptBuilder.Build(
+		a.Role,
+		mode,
+		a.Context.GetContext(),
+		prompt,
+		reflect.ValueOf(target).Elem().Interface(),
+		a.ModelClient.GetTemperature(),
+		a.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build %s request: %w", mode, err)
+	}
+	if err := model.ChatStructured(a.ModelClient, chatReq, target); err != nil {
+		return fmt.Errorf("%s request failed: %w", mode, err)
+	}
+	return nil
+}
+
+// Judged matches comments the model judges to fit description, e.g. "approves the release",
+// for replies no keyword or pattern catches. Comments it fails to judge do not match.
+// Combine it with cheaper matchers, e.g. AllOf(FromMember(owner), a.Judged(...)), so only
+// candidate replies reach the model.
+func (a *BaseAgent) Judged(description string) ReplyMatcher {
+	return func(c board.Comment) bool {
+		result, err := a.classify("Does this comment "+description+"?", "a board comment", c.Text)
+		if err != nil {
+			fmt.Printf("Warning: failed to judge comment on %q: %v\n", description, err)
+			return false
+		}
+		return result.Answer
+	}
+}
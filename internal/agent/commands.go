@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+)
+
+// findCardByURL returns the card on b whose URL matches url.
+func findCardByURL(b board.Board, url string) (board.Card, error) {
+	cards, err := b.GetCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	url = strings.TrimSuffix(strings.Trim(url, "<>"), "/")
+	for _, c := range cards {
+		if strings.TrimSuffix(c.GetURL(), "/") == url {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no card with URL %s", url)
+}
+
+// RegisterCommands adds the Engineering Manager's chat commands to d:
+// "!decompose <card-url>" splits a card into technical tickets and "!prioritize" scores and reorders the backlog.
+func (em *EngineeringManagerAgent) RegisterCommands(d *notify.Dispatcher) {
+	d.Register("decompose", func(cmd notify.Command) (string, error) {
+		if len(cmd.Args) != 1 {
+			return "", fmt.Errorf("usage: %sdecompose <card-url>", notify.CommandPrefix)
+		}
+		card, err := findCardByURL(em.BoardClient, cmd.Args[0])
+		if err != nil {
+			return "", err
+		}
+		created, err := em.HandleTicket(card)
+		if err != nil {
+			return "", err
+		}
+		lines := []string{fmt.Sprintf("Decomposed %q into %d ticket(s):", card.GetName(), len(created))}
+		for _, c := range created {
+			lines = append(lines, "- "+c.GetName()+" "+c.GetURL())
+		}
+		return strings.Join(lines, "\n"), nil
+	})
+	d.Register("prioritize", func(cmd notify.Command) (string, error) {
+		ordered, err := em.PrioritizeBacklog()
+		if err != nil {
+			return "", err
+		}
+		lines := []string{fmt.Sprintf("Prioritized %d backlog ticket(s):", len(ordered))}
+		for i, c := range ordered {
+			line := fmt.Sprintf("%d. %s", i+1, c.GetName())
+			if score, ok := board.CardScore(c); ok {
+				line += " (" + formatPoints(score) + ")"
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n"), nil
+	})
+}
+
+// RegisterCommands adds the Release agent's chat command to d: "!release <version>" writes the
+// release notes for the work since the last release, tags it and publishes it when a Publisher is set.
+func (r *ReleaseAgent) RegisterCommands(d *notify.Dispatcher) {
+	d.Register("release", func(cmd notify.Command) (string, error) {
+		if len(cmd.Args) != 1 {
+			return "", fmt.Errorf("usage: %srelease <version>", notify.CommandPrefix)
+		}
+		card, err := r.Release(cmd.Args[0])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Released %s: %s", cmd.Args[0], card.GetURL()), nil
+	})
+}
+
+// RegisterCardCommands adds the Engineering Manager's card comment commands to r: "/decompose"
+// splits the card into technical tickets and "/estimate" stores the card's story points.
+func (em *EngineeringManagerAgent) RegisterCardCommands(r *orchestrator.CommandRule) {
+	r.Register("decompose", func(cmd orchestrator.CardCommand) (string, error) {
+		created, err := em.HandleTicket(cmd.Card)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Decomposed into %d ticket(s).", len(created)), nil
+	})
+	r.Register("estimate", func(cmd orchestrator.CardCommand) (string, error) {
+		estimate, err := em.EstimateTicket(cmd.Card)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Estimated at %s story points: %s", formatPoints(estimate.StoryPoints), strings.TrimSpace(estimate.Reason)), nil
+	})
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// DefaultConflictConfidence is the minimum confidence required to apply a model-proposed resolution.
+const DefaultConflictConfidence = 0.7
+
+// ConflictResolution is the structured answer expected from the model for a single conflict hunk.
+type ConflictResolution struct {
+	Resolution string  `json:"resolution"` // Final text replacing the whole conflict hunk.
+	Confidence float64 `json:"confidence"` // 0..1 self-assessed confidence in the resolution.
+	Rationale  string  `json:"rationale"`  // Short explanation of how both sides were combined.
+}
+
+// ResolveConflicts asks the model to resolve every conflict hunk in the repository.
+// Resolutions with confidence below minConfidence are not applied; instead the hunk is posted
+// as a comment on the card for a human to resolve. It returns true when all hunks were resolved,
+// in which case the caller can finish the merge with GitClient.CompleteMerge.
+func (a *BaseAgent) ResolveConflicts(repo *gitrepo.GitClient, card board.Card, minConfidence float64) (bool, error) {
+	hunks, err := repo.FindConflicts()
+	if err != nil {
+		return false, fmt.Errorf("failed to find conflicts: %w", err)
+	}
+
+	// Resolve from the last hunk backwards so earlier hunk indices stay valid after each edit.
+	allResolved := true
+	for i := len(hunks) - 1; i >= 0; i-- {
+		h := hunks[i]
+		prompt := fmt.Sprintf(
+			"Resolve the merge conflict in %s.\nContext before:\n%s\n\nOur version:\n%s\n\nTheir version:\n%s\n\nContext after:\n%s",
+			h.Path, h.Before, h.Ours, h.Theirs, h.After,
+		)
+		chatReq, err := a.PromptBuilder.Build(
+			a.Role,
+			"ResolveConflict",
+			a.Context.GetContext(),
+			prompt,
+			ConflictResolution{},
+			a.ModelClient.GetTemperature(),
+			a.ModelClient.GetModel(),
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to build conflict resolution request: %w", err)
+		}
+		var res ConflictResolution
+		if err := a.ModelClient.ChatAdvancedParsed(chatReq, &res); err != nil {
+			return false, fmt.Errorf("failed to parse conflict resolution: %w", err)
+		}
+
+		if res.Confidence < minConfidence {
+			allResolved = false
+			if card != nil {
+				comment := fmt.Sprintf(
+					"I could not confidently resolve a merge conflict in %s (confidence %.2f).\n\nOurs:\n%s\nTheirs:\n%s\nProposed:\n%s\n%s",
+					h.Path, res.Confidence, board.CodeBlock(h.Ours, ""), board.CodeBlock(h.Theirs, ""), board.CodeBlock(res.Resolution, ""), res.Rationale,
+				)
+				if err := card.WriteComment(comment); err != nil {
+					fmt.Printf("Warning: failed to escalate conflict in %s: %v\n", h.Path, err)
+				}
+			}
+			continue
+		}
+		if err := repo.ResolveConflict(h.Path, h.Index, res.Resolution); err != nil {
+			return false, fmt.Errorf("failed to apply resolution to %s: %w", h.Path, err)
+		}
+	}
+	return allResolved, nil
+}
@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/adr"
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// ArchitectureDecision is a significant technical decision a ticket introduces, such as a new
+// architectural pattern or a new dependency. It is recorded as an architecture decision record.
+type ArchitectureDecision struct {
+	Title        string   `json:"title"` // Short imperative title, e.g. "Use Redis for session storage".
+	Context      string   `json:"context"`
+	Decision     string   `json:"decision"`
+	Consequences string   `json:"consequences"`
+	Alternatives []string `json:"alternatives,omitempty"`
+	Supersedes   int      `json:"supersedes,omitempty"` // Number of an earlier record this decision replaces.
+}
+
+// decisionPrompt tells the model when to set a ticket's decision and lists the decisions already
+// recorded, so they are not recorded twice.
+func (em *EngineeringManagerAgent) decisionPrompt(card board.Card) string {
+	prompt := "\n\nSet \"decision\" on a ticket only when it introduces a significant technical decision, such as a new " +
+		"architectural pattern or a new dependency; tickets implementing the same decision share it. Leave it empty otherwise."
+	repo, err := em.RepoFor(card)
+	if err != nil {
+		return prompt
+	}
+	records, err := adr.List(repo, em.ADRDir)
+	if err != nil || len(records) == 0 {
+		return prompt
+	}
+	prompt += " Decisions already recorded, which a new decision may supersede by number:"
+	for _, r := range records {
+		prompt += fmt.Sprintf("\n- ADR %d: %s (%s)", r.Number, r.Title, r.Status)
+	}
+	return prompt
+}
+
+// decisionKey identifies a decision across the tickets of a plan.
+func decisionKey(d *ArchitectureDecision) string {
+	if d == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(d.Title))
+}
+
+// recordDecisions writes an architecture decision record for every decision in plan that is not
+// recorded yet, commits them and adds them to the knowledge base. It returns the records by
+// decision key, including ones recorded before. Failures are logged; tickets are created anyway.
+func (em *EngineeringManagerAgent) recordDecisions(parent board.Card, plan []TechnicalTicket) map[string]adr.Record {
+	var decisions []*ArchitectureDecision
+	tickets := make(map[string][]string)
+	for _, t := range plan {
+		key := decisionKey(t.Decision)
+		if key == "" {
+			continue
+		}
+		if _, ok := tickets[key]; !ok {
+			decisions = append(decisions, t.Decision)
+		}
+		tickets[key] = append(tickets[key], t.Title)
+	}
+	if len(decisions) == 0 {
+		return nil
+	}
+	repo, err := em.RepoFor(parent)
+	if err != nil {
+		fmt.Printf("Warning: failed to record architecture decisions: %v\n", err)
+		return nil
+	}
+	records, err := adr.List(repo, em.ADRDir)
+	if err != nil {
+		fmt.Printf("Warning: failed to read architecture decisions: %v\n", err)
+		return nil
+	}
+
+	result := make(map[string]adr.Record)
+	var changed []string
+	for _, d := range decisions {
+		key := decisionKey(d)
+		if existing := adr.Find(records, d.Title); existing != nil {
+			result[key] = *existing
+			continue
+		}
+		r := adr.Record{
+			Title:        strings.TrimSpace(d.Title),
+			Status:       adr.StatusAccepted,
+			Date:         time.Now(),
+			Context:      d.Context,
+			Decision:     d.Decision,
+			Consequences: d.Consequences,
+			Alternatives: d.Alternatives,
+			Links:        []string{fmt.Sprintf("Parent: [%s](%s)", parent.GetName(), parent.GetURL())},
+		}
+		for _, title := range tickets[key] {
+			r.Links = append(r.Links, title)
+		}
+		if err := adr.Write(repo, em.ADRDir, records, &r); err != nil {
+			fmt.Printf("Warning: failed to record %q: %v\n", d.Title, err)
+			continue
+		}
+		changed = append(changed, r.Path)
+		for _, old := range records {
+			if d.Supersedes > 0 && old.Number == d.Supersedes {
+				if err := adr.Supersede(repo, old, r.Number); err != nil {
+					fmt.Printf("Warning: failed to supersede ADR %d: %v\n", old.Number, err)
+				} else {
+					changed = append(changed, old.Path)
+				}
+			}
+		}
+		records = append(records, r)
+		result[key] = r
+	}
+	if len(changed) == 0 {
+		return result
+	}
+	author, email := em.Author()
+	if err := repo.CommitChanges("docs(adr): record decisions for "+parent.GetName(), author, email); err != nil {
+		fmt.Printf("Warning: failed to commit architecture decisions: %v\n", err)
+	}
+	if em.Knowledge != nil {
+		for _, p := range changed {
+			text, err := repo.ReadText(p)
+			if err == nil {
+				err = em.Knowledge.Add(p, text)
+			}
+			if err != nil {
+				fmt.Printf("Warning: failed to index %s: %v\n", p, err)
+			}
+		}
+	}
+	return result
+}
+
+// decisionLine references a record from a ticket description.
+func decisionLine(r adr.Record) string {
+	return fmt.Sprintf("Architecture decision: ADR %d, %s (%s)", r.Number, r.Title, r.Path)
+}
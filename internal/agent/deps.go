@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/policy"
+)
+
+// dependencyRequestPrefix starts the comment asking for approval of a dependency change.
+const dependencyRequestPrefix = "Dependency change requested"
+
+// ErrDependenciesRejected is returned when a dependency change was not approved.
+var ErrDependenciesRejected = errors.New("dependency change not approved")
+
+// approvalWords start a reply approving a request.
+var approvalWords = map[string]bool{"approve": true, "approved": true, "lgtm": true, "yes": true, "ok": true}
+
+// approves reports whether a reply approves a request.
+func approves(reply string) bool {
+	fields := strings.Fields(strings.ToLower(reply))
+	return len(fields) > 0 && approvalWords[strings.Trim(fields[0], ".,:;!")]
+}
+
+// dependencyFiles returns the go.mod and go.sum files with pending changes in repo.
+func dependencyFiles(repo *gitrepo.GitClient) ([]string, error) {
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, p := range changed {
+		if codegen.IsDependencyFile(p) {
+			files = append(files, p)
+		}
+	}
+	return files, nil
+}
+
+// tidyDependencies runs "go mod tidy" in every module whose go.mod has pending changes and returns
+// the dependency files changed afterwards. Tidy failures are posted on the card.
+func (b *BackendAgent) tidyDependencies(repo *gitrepo.GitClient, card board.Card) ([]string, error) {
+	files, err := dependencyFiles(repo)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	for _, f := range files {
+		if path.Base(f) != "go.mod" {
+			continue
+		}
+		if err := codegen.TidyGo(filepath.Join(repo.RepoPath, path.Dir(f))); err != nil {
+			if card != nil {
+				comment := fmt.Sprintf("The dependency change in %s does not resolve. Please take a look.\n\n%s", f, board.CodeBlock(err.Error(), ""))
+				if err := card.WriteComment(comment); err != nil {
+					fmt.Printf("Warning: failed to escalate dependency failure: %v\n", err)
+				}
+			}
+			return nil, fmt.Errorf("failed to tidy %s: %w", f, err)
+		}
+	}
+	return dependencyFiles(repo)
+}
+
+// requirementChanges lists the requirement changes of the pending go.mod files.
+func requirementChanges(repo *gitrepo.GitClient, files []string) ([]string, error) {
+	var lines []string
+	for _, f := range files {
+		if path.Base(f) != "go.mod" {
+			continue
+		}
+		before, err := repo.ReadFileAt("HEAD", f)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		after, err := repo.ReadText(f)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for _, c := range codegen.DiffRequirements(string(before), after) {
+			lines = append(lines, fmt.Sprintf("- %s (%s)", c, f))
+		}
+	}
+	return lines, nil
+}
+
+// approveDependencies asks on the card for approval of the pending requirement changes, from a human
+// or the Engineering Manager, and lets the policy guarding repo commit the dependency files once
+// approved. Edits that leave the requirements alone need no approval here.
+func (b *BackendAgent) approveDependencies(repo *gitrepo.GitClient, card board.Card, files []string) error {
+	changes, err := requirementChanges(repo, files)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+	if card == nil {
+		return fmt.Errorf("%w: no ticket to ask on", ErrDependenciesRejected)
+	}
+	question := fmt.Sprintf("%s for %q:\n%s\n\nReply \"approve\" to commit it, or \"reject\" with a reason.", dependencyRequestPrefix, card.GetName(), strings.Join(changes, "\n"))
+	answer, err := b.Ask(card, question)
+	if err != nil {
+		return err
+	}
+	if !approves(answer) {
+		return fmt.Errorf("%w: %s", ErrDependenciesRejected, strings.TrimSpace(answer))
+	}
+	if engine, ok := repo.Guard.(*policy.Engine); ok {
+		for _, f := range files {
+			engine.ApproveWrite(f)
+		}
+	}
+	return nil
+}
+
+// DependencyReview is the model's decision on a dependency change request.
+type DependencyReview struct {
+	Approve bool   `json:"approve"`
+	Reason  string `json:"reason"`
+}
+
+// pendingDependencyRequest returns the dependency change request on the card nobody answered yet.
+func pendingDependencyRequest(card board.Card) (string, bool) {
+	comments, err := card.ReadComments()
+	if err != nil || len(comments) == 0 {
+		return "", false
+	}
+	latest := comments[len(comments)-1]
+	for _, c := range comments {
+		if c.Created.After(latest.Created) {
+			latest = c
+		}
+	}
+	return latest.Text, strings.HasPrefix(latest.Text, dependencyRequestPrefix)
+}
+
+// ReviewDependencyRequests answers the unanswered dependency change requests on the board,
+// approving or rejecting each with the model's reasoning.
+func (em *EngineeringManagerAgent) ReviewDependencyRequests() error {
+	cards, err := em.BoardClient.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, card := range cards {
+		request, ok := pendingDependencyRequest(card)
+		if !ok {
+			continue
+		}
+		prompt := fmt.Sprintf(
+			"A developer asks to change the project's Go dependencies for this ticket. Approve the change only if the "+
+				"ticket needs it and the modules are well-known and maintained; otherwise reject it and say what to do instead.\n\n%s\n\n%s",
+			guard.Wrap("the ticket", card.GetName()+"\n"+card.GetDescription()), guard.Wrap("the request", request),
+		)
+		chatReq, err := em.PromptBuilder.Build(
+			em.Role,
+			"ReviewDependencies",
+			em.Context.GetContext(),
+			prompt,
+			DependencyReview{},
+			em.ModelClient.GetTemperature(),
+			em.ModelClient.GetModel(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to build dependency review request: %w", err)
+		}
+		var review DependencyReview
+		if err := em.ModelClient.ChatAdvancedParsed(chatReq, &review); err != nil {
+			return fmt.Errorf("failed to parse dependency review: %w", err)
+		}
+		verdict := "reject"
+		if review.Approve {
+			verdict = "approve"
+		}
+		if err := card.WriteComment(verdict + ": " + strings.TrimSpace(review.Reason)); err != nil {
+			return fmt.Errorf("failed to answer the dependency request on %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,528 @@
+package agent
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/figma"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// designReviewMarker starts every design review comment, so screens are reviewed only once.
+const designReviewMarker = "[design review]"
+
+// DefaultBrandbook is the repository path of the brandbook designs are checked against.
+const DefaultBrandbook = "docs/brandbook.md"
+
+// DefaultAssetDir is the repository directory design assets are written to.
+const DefaultAssetDir = "assets/design"
+
+// maxDesignImages caps the images sent with one review.
+const maxDesignImages = 8
+
+// assetTypes are the file types the Designer may write: vector assets and design tokens.
+var assetTypes = map[string]bool{".svg": true, ".css": true, ".scss": true, ".json": true}
+
+// DesignIssue is a problem found in a screen.
+type DesignIssue struct {
+	Screen         string `json:"screen"`   // Attachment or Figma node name the issue was found in.
+	Severity       string `json:"severity"` // high, medium or low.
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+}
+
+// DesignAsset is a file the Designer proposes, e.g. an SVG icon or CSS custom properties holding
+// design tokens.
+type DesignAsset struct {
+	Path        string `json:"path"` // Relative to the asset directory, e.g. "icons/search.svg" or "tokens.css".
+	Content     string `json:"content"`
+	Description string `json:"description"`
+}
+
+// DesignReview is the Designer's critique of a ticket's screens and the UI spec derived from them.
+type DesignReview struct {
+	Summary string        `json:"summary"`
+	Issues  []DesignIssue `json:"issues"`
+	// Spec describes the UI for the frontend: layout, components, colors, typography, spacing and states.
+	Spec string `json:"spec"`
+	// Assets are the SVG and design token files the ticket needs; empty when it needs none.
+	Assets []DesignAsset `json:"assets"`
+}
+
+// Screen is an image the Designer reviews: a card attachment or a Figma frame or component.
+type Screen struct {
+	Name  string
+	Link  string // Figma node link; empty for attachments.
+	Asset string // Repository path the Figma export was committed to; empty for attachments.
+	image model.ContentPart
+	data  []byte // PNG export of a Figma node.
+}
+
+// DesignerAgent reviews screenshots and design exports attached to its tickets, and the Figma
+// frames and components the tickets link to. It critiques them against the brandbook, answers
+// with a UI spec the Frontend agent implements from, and answers asset requests. Files it writes
+// go to a design branch of their own and are proposed in a pull request for a human to approve.
+type DesignerAgent struct {
+	*BaseAgent
+	Brandbook    string          // Repository path of the brandbook; empty uses DefaultBrandbook.
+	Figma        *figma.Client   // Optional; exports the Figma nodes linked in tickets.
+	AssetDir     string          // Repository directory for assets; empty uses DefaultAssetDir.
+	VCS          vcs.VCSProvider // Optional; opens the pull request for the design branch.
+	TargetBranch string          // Branch design pull requests target.
+}
+
+// NewDesignerAgent creates a new DesignerAgent using the provided BaseAgent.
+func NewDesignerAgent(base *BaseAgent) *DesignerAgent {
+	return &DesignerAgent{BaseAgent: base, Brandbook: DefaultBrandbook, AssetDir: DefaultAssetDir, TargetBranch: "main"}
+}
+
+// createContext is a no-op; reviews only look at the ticket, its images and the brandbook.
+func (d *DesignerAgent) createContext() error {
+	return nil
+}
+
+// Act reviews the new screens and answers the asset requests on every assigned ticket that is not done yet.
+func (d *DesignerAgent) Act() error {
+	cards, err := d.FindReadyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if list, err := card.GetList(); err == nil && list.GetName() == board.ListDone {
+			continue
+		}
+		if _, err := d.Review(card); err != nil {
+			return fmt.Errorf("failed to review the design of %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Review critiques the screens of card not covered by an earlier review, and answers a request
+// for assets addressed to the Designer since then. The critique, UI spec and proposed assets are
+// posted as a comment linking Figma nodes and the design pull request. It returns nil when there
+// is nothing new to review.
+func (d *DesignerAgent) Review(card board.Card) (*DesignReview, error) {
+	d.CurrentTicketID = card.GetID()
+	comments, err := card.ReadComments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+	reviewed := reviewedScreens(comments)
+	screens, err := d.attachedScreens(card, reviewed)
+	if err != nil {
+		return nil, err
+	}
+	screens = append(screens, d.figmaScreens(card, reviewed, maxDesignImages-len(screens))...)
+	request := d.pendingRequest(comments)
+	if len(screens) == 0 && request == "" {
+		return nil, nil
+	}
+	if len(screens) > maxDesignImages {
+		screens = screens[:maxDesignImages]
+	}
+
+	var names []string
+	var images []model.ContentPart
+	for _, s := range screens {
+		names = append(names, s.Name)
+		images = append(images, s.image)
+	}
+	prompt := guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", card.GetName(), card.GetDescription())) +
+		"\n\n" + d.brandbook(card)
+	if len(screens) > 0 {
+		prompt += fmt.Sprintf("\n\nThe attached images are, in order: %s. Critique them against the brandbook: color, typography, "+
+			"spacing, component consistency and accessibility (contrast, target sizes, readable text). Then write the "+
+			"UI spec the frontend developer implements from: layout, components with their states, colors, "+
+			"typography and spacing, using brandbook tokens where they exist.", strings.Join(names, ", "))
+	}
+	if request != "" {
+		prompt += "\n\n" + guard.Wrap("the asset request", request) + "\n\nProvide the requested assets."
+	}
+	prompt += "\n\nAdd any SVG icons or illustrations and any CSS, SCSS or JSON design token files the ticket " +
+		"needs as assets, with paths relative to the asset directory. Leave assets empty when none are needed."
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"DesignReview",
+		d.Context.GetContext(),
+		prompt,
+		DesignReview{},
+		d.ModelClient.GetTemperature(),
+		d.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build design review request: %w", err)
+	}
+	model.AttachImages(&chatReq, images...)
+	var review DesignReview
+	if err := model.ChatStructured(d.ModelClient, chatReq, &review); err != nil {
+		return nil, fmt.Errorf("failed to parse design review: %w", err)
+	}
+	review.Assets = d.validAssets(review.Assets)
+	proposal := d.proposeAssets(card, screens, review.Assets)
+	if err := card.WriteComment(FormatDesignReview(review, screens, proposal)); err != nil {
+		return nil, fmt.Errorf("failed to post design review: %w", err)
+	}
+	return &review, nil
+}
+
+// reviewedScreens returns the names of the screens earlier design reviews covered.
+func reviewedScreens(comments []board.Comment) map[string]bool {
+	reviewed := make(map[string]bool)
+	for _, c := range comments {
+		if rest, ok := strings.CutPrefix(c.Text, designReviewMarker); ok {
+			line, _, _ := strings.Cut(rest, "\n")
+			for _, name := range strings.Split(line, ", ") {
+				reviewed[strings.TrimSpace(name)] = true
+			}
+		}
+	}
+	return reviewed
+}
+
+// pendingRequest returns the latest comment mentioning the Designer that no design review
+// answered yet, e.g. the Frontend agent asking for assets, or "" when there is none.
+func (d *DesignerAgent) pendingRequest(comments []board.Comment) string {
+	mention := "@" + strings.ToLower(d.Name)
+	request := ""
+	for _, c := range comments {
+		switch {
+		case strings.HasPrefix(c.Text, designReviewMarker):
+			request = ""
+		case strings.Contains(strings.ToLower(c.Text), mention):
+			request = c.Text
+		}
+	}
+	return request
+}
+
+// attachedScreens returns the card's image attachments that were not reviewed yet.
+func (d *DesignerAgent) attachedScreens(card board.Card, reviewed map[string]bool) ([]Screen, error) {
+	attachments, err := card.GetAttachments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	var screens []Screen
+	for _, a := range attachments {
+		if reviewed[a.Name] || (model.ImageType(a.Name) == "" && model.ImageType(a.URL) == "") {
+			continue
+		}
+		screens = append(screens, Screen{Name: a.Name, image: d.image(card, a)})
+	}
+	return screens, nil
+}
+
+// image returns the content part for an attachment: its data when the card can download it, so
+// files behind the board's login reach the model, and its URL otherwise.
+func (d *DesignerAgent) image(card board.Card, a board.Attachment) model.ContentPart {
+	if strings.HasPrefix(a.URL, "data:") {
+		return model.ImageURLPart(a.URL)
+	}
+	data, err := board.ReadAttachment(card, a)
+	if errors.Is(err, board.ErrNotDownloadable) {
+		return model.ImageURLPart(a.URL)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to download %q, sending its link: %v\n", a.Name, err)
+		return model.ImageURLPart(a.URL)
+	}
+	mimeType := model.ImageType(a.Name)
+	if mimeType == "" {
+		mimeType = model.ImageType(a.URL)
+	}
+	return model.ImageDataPart(mimeType, data)
+}
+
+// figmaScreens exports up to limit frames and components linked in the card description that were
+// not reviewed yet. Figma failures are logged, so the attachments are still reviewed.
+func (d *DesignerAgent) figmaScreens(card board.Card, reviewed map[string]bool, limit int) []Screen {
+	if d.Figma == nil || limit <= 0 {
+		return nil
+	}
+	var screens []Screen
+	for _, link := range figma.FindLinks(card.GetDescription()) {
+		nodes, err := d.Figma.Screens(link)
+		if err != nil {
+			fmt.Printf("Warning: failed to read Figma file %s: %v\n", link.FileKey, err)
+			continue
+		}
+		var ids []string
+		byID := make(map[string]figma.Node)
+		for _, n := range nodes {
+			if !reviewed[n.Name] && len(screens)+len(ids) < limit {
+				ids = append(ids, n.ID)
+				byID[n.ID] = n
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		urls, err := d.Figma.ExportImages(link.FileKey, "png", 2, ids...)
+		if err != nil {
+			fmt.Printf("Warning: failed to export Figma nodes from %s: %v\n", link.FileKey, err)
+			continue
+		}
+		for _, id := range ids {
+			if urls[id] == "" {
+				fmt.Printf("Warning: Figma rendered nothing for node %s\n", id)
+				continue
+			}
+			data, err := d.Figma.Download(urls[id])
+			if err != nil {
+				fmt.Printf("Warning: failed to download Figma node %s: %v\n", id, err)
+				continue
+			}
+			screens = append(screens, Screen{
+				Name:  byID[id].Name,
+				Link:  figma.NodeURL(link.FileKey, id),
+				Asset: d.exportPath(byID[id], screens),
+				image: model.ImageDataPart("image/png", data),
+				data:  data,
+			})
+			reviewed[byID[id].Name] = true
+		}
+	}
+	return screens
+}
+
+// assetDir returns the repository directory assets are written to.
+func (d *DesignerAgent) assetDir() string {
+	if d.AssetDir == "" {
+		return DefaultAssetDir
+	}
+	return d.AssetDir
+}
+
+// exportPath returns the repository path for a node's export, unique among screens.
+func (d *DesignerAgent) exportPath(n figma.Node, screens []Screen) string {
+	name := assetSlug(n.Name)
+	p := path.Join(d.assetDir(), "figma", name+".png")
+	for _, s := range screens {
+		if s.Asset == p {
+			return path.Join(d.assetDir(), "figma", name+"-"+assetSlug(n.ID)+".png")
+		}
+	}
+	return p
+}
+
+// assetSlug turns a node name into a lowercase file name.
+func assetSlug(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	if s := strings.TrimSuffix(sb.String(), "-"); s != "" {
+		return s
+	}
+	return "screen"
+}
+
+// validAssets returns the assets that stay inside the asset directory and are well-formed SVG,
+// CSS or JSON, with their paths made relative to the repository. Others are logged and dropped.
+func (d *DesignerAgent) validAssets(assets []DesignAsset) []DesignAsset {
+	var valid []DesignAsset
+	for _, a := range assets {
+		if err := checkAsset(a); err != nil {
+			fmt.Printf("Warning: dropping design asset %q: %v\n", a.Path, err)
+			continue
+		}
+		a.Path = path.Join(d.assetDir(), path.Clean("/" + a.Path)[1:])
+		valid = append(valid, a)
+	}
+	return valid
+}
+
+// checkAsset rejects asset paths leaving the asset directory, file types other than vector assets
+// and design tokens, and SVG or JSON that does not parse.
+func checkAsset(a DesignAsset) error {
+	clean := path.Clean(a.Path)
+	if a.Path == "" || path.IsAbs(a.Path) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("path must be relative to the asset directory")
+	}
+	ext := strings.ToLower(path.Ext(clean))
+	if !assetTypes[ext] {
+		return fmt.Errorf("file type %q is not an asset type", ext)
+	}
+	switch ext {
+	case ".svg":
+		dec := xml.NewDecoder(strings.NewReader(a.Content))
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return fmt.Errorf("invalid SVG: %w", err)
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				if start.Name.Local != "svg" {
+					return fmt.Errorf("invalid SVG: root element is <%s>", start.Name.Local)
+				}
+				return nil
+			}
+		}
+	case ".json":
+		if !json.Valid([]byte(a.Content)) {
+			return fmt.Errorf("invalid JSON")
+		}
+	}
+	return nil
+}
+
+// designBranchID is the ticket ID of a card's design branch, kept apart from the ticket branch so
+// a human approves the design before the Frontend agent builds on it.
+func designBranchID(card board.Card) string {
+	return "design-" + card.GetID()
+}
+
+// proposeAssets commits the Figma exports and generated assets to the card's design branch and,
+// with a VCS provider, pushes it and opens a pull request for design approval, linked on the card.
+// It returns the line telling the team where to review them, or "" when nothing was committed.
+// Failures are logged and leave the screens without an export path.
+func (d *DesignerAgent) proposeAssets(card board.Card, screens []Screen, assets []DesignAsset) string {
+	files := make(map[string][]byte)
+	for _, s := range screens {
+		if s.Asset != "" {
+			files[s.Asset] = s.data
+		}
+	}
+	for _, a := range assets {
+		files[a.Path] = []byte(a.Content)
+	}
+	if len(files) == 0 {
+		return ""
+	}
+	proposal, err := d.commitDesign(card, files)
+	if err != nil {
+		fmt.Printf("Warning: failed to propose design assets for %q: %v\n", card.GetName(), err)
+		for i := range screens {
+			screens[i].Asset = ""
+		}
+	}
+	return proposal
+}
+
+// commitDesign writes files to the card's design branch, commits them and opens the pull request.
+func (d *DesignerAgent) commitDesign(card board.Card, files map[string][]byte) (string, error) {
+	base, err := d.RepoFor(card)
+	if err != nil {
+		return "", err
+	}
+	repo, err := base.WorktreeFor(designBranchID(card))
+	if err != nil {
+		return "", err
+	}
+	for p, content := range files {
+		if err := repo.WriteFile(p, content); err != nil {
+			return "", err
+		}
+	}
+	if changed, err := repo.ChangedFiles(); err != nil || len(changed) == 0 {
+		return "", err
+	}
+	name, email := d.Author()
+	if err := repo.CommitChanges("feat(design): add assets for "+card.GetName(), name, email); err != nil {
+		return "", err
+	}
+	branch := gitrepo.BranchForTicket(designBranchID(card))
+	if d.VCS == nil {
+		return fmt.Sprintf("The assets are committed on branch %s for design approval.", branch), nil
+	}
+	if err := repo.PushChanges("", ""); err != nil {
+		return "", err
+	}
+	pr, err := d.VCS.CreatePullRequest("Design: "+card.GetName(),
+		fmt.Sprintf("Ticket: %s\n\nAssets and design tokens proposed by the Designer. Merge to approve the design.", card.GetURL()),
+		branch, d.TargetBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to open design pull request: %w", err)
+	}
+	if err := card.AddAttachment(board.Attachment{Name: fmt.Sprintf("Design pull request #%d", pr.Number), URL: pr.URL}); err != nil {
+		fmt.Printf("Warning: failed to link design pull request on %q: %v\n", card.GetName(), err)
+	}
+	return fmt.Sprintf("The assets await design approval in pull request #%d: %s", pr.Number, pr.URL), nil
+}
+
+// brandbook returns the prompt section holding the brandbook of the card's repository.
+func (d *DesignerAgent) brandbook(card board.Card) string {
+	p := d.Brandbook
+	if p == "" {
+		p = DefaultBrandbook
+	}
+	if repo, err := d.RepoFor(card); err == nil {
+		if text, err := repo.ReadText(p); err == nil && strings.TrimSpace(text) != "" {
+			return guard.Wrap("the brandbook", text)
+		}
+	}
+	return "There is no brandbook yet; judge the screens against common UI practice and their own consistency."
+}
+
+// FormatDesignReview renders a review as a card comment. The first line names the reviewed screens;
+// Figma screens are listed with links to their nodes and the paths of their exports, followed by
+// the proposed assets and where to approve them.
+func FormatDesignReview(review DesignReview, screens []Screen, proposal string) string {
+	var names []string
+	links := make(map[string]string)
+	for _, s := range screens {
+		names = append(names, s.Name)
+		if s.Link != "" {
+			links[s.Name] = s.Link
+		}
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s\n", designReviewMarker, strings.Join(names, ", "))
+	if review.Summary != "" {
+		fmt.Fprintf(&sb, "\n%s\n", review.Summary)
+	}
+	if len(links) > 0 {
+		sb.WriteString("\nFigma:\n")
+		for _, s := range screens {
+			if s.Link == "" {
+				continue
+			}
+			fmt.Fprintf(&sb, "- %s: %s", s.Name, s.Link)
+			if s.Asset != "" {
+				fmt.Fprintf(&sb, " (exported to %s)", s.Asset)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if len(review.Issues) > 0 {
+		sb.WriteString("\nIssues:\n")
+		for _, issue := range review.Issues {
+			fmt.Fprintf(&sb, "- [%s] %s: %s", issue.Severity, issue.Screen, issue.Description)
+			if issue.Recommendation != "" {
+				fmt.Fprintf(&sb, " Recommendation: %s", issue.Recommendation)
+			}
+			if link := links[issue.Screen]; link != "" {
+				fmt.Fprintf(&sb, " (%s)", link)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if review.Spec != "" {
+		fmt.Fprintf(&sb, "\nUI spec:\n%s\n", review.Spec)
+	}
+	if len(review.Assets) > 0 {
+		sb.WriteString("\nAssets:\n")
+		for _, a := range review.Assets {
+			fmt.Fprintf(&sb, "- %s: %s\n", a.Path, a.Description)
+		}
+	}
+	if proposal != "" {
+		fmt.Fprintf(&sb, "\n%s\n", proposal)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,17 @@
+package agent
+
+// LabelInfra marks technical tickets handled by the DevOps agent.
+const LabelInfra = "infra"
+
+// DevOpsAgent implements infrastructure tickets: Dockerfiles, CI workflows and deployment manifests.
+// It shares the Backend agent's clarification, verification and commit flow.
+type DevOpsAgent struct {
+	*BackendAgent
+}
+
+// NewDevOpsAgent creates a new DevOpsAgent using the provided BaseAgent.
+func NewDevOpsAgent(base *BaseAgent) *DevOpsAgent {
+	backend := NewBackendAgent(base)
+	backend.Label = LabelInfra
+	return &DevOpsAgent{BackendAgent: backend}
+}
@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// docsMarker prefixes the comment left on a ticket once its documentation was updated,
+// so the ticket is not processed twice.
+const docsMarker = "[docs]"
+
+// DocsAgent keeps README, docs/ and godoc comments in sync with completed technical tickets.
+type DocsAgent struct {
+	*BackendAgent
+	BaseBranch   string   // Branch the ticket diff is taken against.
+	DocsPatterns []string // Glob patterns of documentation files offered to the model.
+}
+
+// NewDocsAgent creates a new DocsAgent using the provided BaseAgent.
+func NewDocsAgent(base *BaseAgent) *DocsAgent {
+	return &DocsAgent{
+		BackendAgent: NewBackendAgent(base),
+		BaseBranch:   "main",
+		DocsPatterns: []string{"README.md", "docs/**/*.md", "**/*.md"},
+	}
+}
+
+// Act updates the documentation of every completed technical ticket that has not been documented yet.
+func (d *DocsAgent) Act() error {
+	cards, err := d.BoardClient.GetCardsFromList(board.ListDone)
+	if err != nil {
+		return fmt.Errorf("failed to get done tickets: %w", err)
+	}
+	for _, card := range cards {
+		if _, ok := board.ParentURL(card.GetDescription()); !ok {
+			continue // Only technical tickets change code.
+		}
+		if hasMarker(card, docsMarker) {
+			continue
+		}
+		if err := d.UpdateDocs(card); err != nil {
+			fmt.Printf("Warning: failed to update docs for %q: %v\n", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// hasMarker reports whether a comment on the card starts with marker.
+func hasMarker(card board.Card, marker string) bool {
+	comments, err := card.ReadComments()
+	if err != nil {
+		return false
+	}
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateDocs asks the model which documentation is affected by the ticket's changes and commits
+// the updated files on the ticket branch.
+func (d *DocsAgent) UpdateDocs(card board.Card) error {
+	d.CurrentTicketID = card.GetID()
+	repo, err := d.CheckoutFor(card)
+	if err != nil {
+		return err
+	}
+	diff, err := repo.Diff(d.BaseBranch)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	docs, err := d.collectDocs(repo)
+	if err != nil {
+		return err
+	}
+	prompt := fmt.Sprintf(
+		"Ticket: %s\n\nThe following change was made:\n%s\n\nCurrent documentation:\n%s\n\n"+
+			"Return the complete new content of every README, docs page or Go file whose documentation "+
+			"(including godoc comments) is now outdated. Do not change code behaviour. Return nothing if the docs are up to date.",
+		card.GetName(), guard.Wrap("the repository diff", diff), guard.Wrap("the repository documentation", docs),
+	)
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"UpdateDocs",
+		d.Context.GetContext(),
+		prompt,
+		[]GeneratedFile{},
+		d.ModelClient.GetTemperature(),
+		d.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build docs request: %w", err)
+	}
+	var wrapper struct {
+		Result []GeneratedFile `json:"result"`
+	}
+	if err := d.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse docs response: %w", err)
+	}
+
+	note := docsMarker + " Documentation is up to date."
+	if len(wrapper.Result) > 0 {
+		if err := d.WriteFiles(repo, wrapper.Result); err != nil {
+			return err
+		}
+		changed, err := repo.ChangedFiles()
+		if err != nil {
+			return err
+		}
+		msg := gitrepo.NewCommitMessage(changed, card.GetID(), "update documentation for "+card.GetName())
+		msg.Type = "docs"
+		author, email := d.Author()
+		if err := d.CommitWork(repo, card, msg.String(), author, email); err != nil {
+			return err
+		}
+		var paths []string
+		for _, f := range wrapper.Result {
+			paths = append(paths, f.Path)
+		}
+		note = fmt.Sprintf("%s Updated documentation: %s", docsMarker, strings.Join(paths, ", "))
+	}
+	return card.WriteComment(note)
+}
+
+// collectDocs returns the documentation files matching DocsPatterns, concatenated for the prompt.
+func (d *DocsAgent) collectDocs(repo *gitrepo.GitClient) (string, error) {
+	seen := make(map[string]bool)
+	var sb strings.Builder
+	for _, pattern := range d.DocsPatterns {
+		files, err := repo.ListFiles(pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range files {
+			if seen[f] {
+				continue
+			}
+			seen[f] = true
+			content, err := repo.ReadText(f)
+			if err != nil {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("=== %s ===\n%s\n", f, content))
+		}
+	}
+	return sb.String(), nil
+}
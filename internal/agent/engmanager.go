@@ -3,23 +3,45 @@ package agent
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
 	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/guard"
 	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/tracing"
 )
 
 // EngineeringManagerAgent implements the Agent interface.
 type EngineeringManagerAgent struct {
 	*BaseAgent
+	Developers []config.DeveloperSettings // Optional; developers technical tickets are assigned to.
+	Assigner   AssignStrategy             // Optional; picks among Developers. Round-robin when nil.
+	Rules      *config.TicketRules        // Optional; overrides the configured ticket quality rules.
+	Template   *config.TicketTemplate     // Optional; overrides the configured ticket template.
+	ADRDir     string                     // Directory of architecture decision records; empty uses adr.DefaultDir.
+
+	// ReviewDependencies makes the manager answer the developers' dependency change requests.
+	ReviewDependencies bool
 }
 
-// NewEngineeringManagerAgent creates a new EngineeringManagerAgent.
+// NewEngineeringManagerAgent creates a new EngineeringManagerAgent, assigning tickets as configured.
 func NewEngineeringManagerAgent(base *BaseAgent) *EngineeringManagerAgent {
 	engManagerAgent := &EngineeringManagerAgent{
 		BaseAgent: base,
 	}
+	if settings := config.GetAssignment(); settings != nil {
+		assigner, err := NewAssignStrategy(settings.Strategy, base.BoardClient)
+		if err != nil {
+			fmt.Printf("Warning: %v; using round-robin\n", err)
+			assigner = &RoundRobin{}
+		}
+		engManagerAgent.Developers = settings.Developers
+		engManagerAgent.Assigner = assigner
+	}
 	if err := engManagerAgent.createContext(); err != nil {
 		fmt.Printf("Failed to create context: %v\n", err)
 	}
@@ -177,3 +199,271 @@ func (em *EngineeringManagerAgent) createContext() error {
 
 	return nil
 }
+
+// TechnicalTicket is a single unit of work produced by decomposing a high-level ticket.
+type TechnicalTicket struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	StoryPoints float64  `json:"storyPoints"`         // Effort estimate on a Fibonacci scale (1, 2, 3, 5, 8, 13).
+	Repo        string   `json:"repo,omitempty"`      // Repository the ticket changes; empty keeps the parent's.
+	Component   string   `json:"component,omitempty"` // Component or service the ticket touches.
+	Priority    string   `json:"priority,omitempty"`  // e.g. "high", "medium" or "low".
+	DependsOn   []string `json:"dependsOn,omitempty"` // Titles of tickets in the same plan that must be done first.
+	// Decision is set when the ticket introduces a significant technical decision; it is recorded as an ADR.
+	Decision *ArchitectureDecision `json:"decision,omitempty"`
+}
+
+// setTicketFields stores a created ticket's estimate and routing metadata in the card's custom fields,
+// falling back to description lines for the estimate and repository on boards without them.
+func setTicketFields(c board.Card, t TechnicalTicket, repo string) {
+	if err := board.SetEstimate(c, t.StoryPoints); err != nil {
+		fmt.Printf("Warning: failed to set estimate on %q: %v\n", t.Title, err)
+	}
+	if err := board.SetRepo(c, repo); err != nil {
+		fmt.Printf("Warning: failed to set repository on %q: %v\n", t.Title, err)
+	}
+	for name, value := range map[string]string{board.FieldComponent: t.Component, board.FieldPriority: t.Priority} {
+		if value == "" {
+			continue
+		}
+		if _, err := board.SetField(c, name, value); err != nil {
+			fmt.Printf("Warning: failed to set %s on %q: %v\n", name, t.Title, err)
+		}
+	}
+}
+
+// HandleTicket decomposes a high-level card into estimated technical tickets and creates them in the backlog,
+// each linked back to the originating card and assigned to a developer when Developers are set. The total estimate is posted on the originating card.
+func (em *EngineeringManagerAgent) HandleTicket(card board.Card) (created []board.Card, err error) {
+	em.CurrentTicketID = card.GetID()
+	span := em.Tracer.Start(em.Name, "HandleTicket", tracing.AttrTicketID, card.GetID())
+	defer func() { span.Finish(err) }()
+
+	cp := em.LoadCheckpoint(card.GetID())
+	var plan []TechnicalTicket
+	if !em.restorePlan(cp, &plan) {
+		if plan, err = em.decompose(card); err != nil {
+			return nil, err
+		}
+		em.savePlan(cp, plan)
+	}
+	decisions := em.recordDecisions(card, plan)
+
+	var total float64
+	assignees := make(map[string]string)
+	for _, t := range plan {
+		total += t.StoryPoints
+		if child, ok := em.createdCard(cp, t.Title); ok {
+			created = append(created, child)
+			continue
+		}
+		repo := t.Repo
+		if repo == "" {
+			repo = board.RepoHint(card)
+		}
+		var decision string
+		if r, ok := decisions[decisionKey(t.Decision)]; ok {
+			decision = decisionLine(r)
+		}
+		child, err := em.BoardClient.CreateCard(t.Title, em.ticketBody(t, card, decision), board.ListOn(card, board.ListBacklog))
+		if err != nil {
+			return created, fmt.Errorf("failed to create technical ticket %q: %w", t.Title, err)
+		}
+		cp.MarkCreated(t.Title, child.GetID())
+		em.SaveCheckpoint(cp)
+		setTicketFields(child, t, repo)
+		assignees[child.GetID()] = em.assignTicket(child)
+		if err := child.AddAttachment(board.Attachment{Name: "Parent: " + card.GetName(), URL: card.GetURL()}); err != nil {
+			fmt.Printf("Warning: failed to attach parent link to %q: %v\n", t.Title, err)
+		}
+		created = append(created, child)
+	}
+	linkDependencies(plan, created)
+
+	summary := fmt.Sprintf("Decomposed into %d technical ticket(s), estimated at %s story points in total:", len(created), formatPoints(total))
+	for i, c := range created {
+		summary += fmt.Sprintf("\n- %s (%s)", c.GetName(), formatPoints(plan[i].StoryPoints))
+		if name := assignees[c.GetID()]; name != "" {
+			summary += ", assigned to " + name
+		}
+	}
+	if len(decisions) > 0 {
+		summary += "\n\nArchitecture decisions:"
+		for _, r := range decisions {
+			summary += fmt.Sprintf("\n- ADR %d: %s (%s)", r.Number, r.Title, r.Path)
+		}
+	}
+	if err := card.WriteComment(summary); err != nil {
+		fmt.Printf("Warning: failed to post estimate on %q: %v\n", card.GetName(), err)
+	}
+	em.ClearCheckpoint(card.GetID())
+	return created, nil
+}
+
+// linkDependencies records the dependencies between the created tickets; created[i] belongs to plan[i].
+func linkDependencies(plan []TechnicalTicket, created []board.Card) {
+	byTitle := make(map[string]board.Card, len(created))
+	for i, c := range created {
+		byTitle[strings.ToLower(strings.TrimSpace(plan[i].Title))] = c
+	}
+	for i, t := range plan {
+		for _, title := range t.DependsOn {
+			dep, ok := byTitle[strings.ToLower(strings.TrimSpace(title))]
+			if !ok || dep == created[i] {
+				fmt.Printf("Warning: %q depends on unknown ticket %q\n", t.Title, title)
+				continue
+			}
+			if err := board.AddDependency(created[i], dep); err != nil {
+				fmt.Printf("Warning: failed to link %q to %q: %v\n", t.Title, title, err)
+			}
+		}
+	}
+}
+
+// decompose asks the model to split a high-level card into estimated technical tickets,
+// has it review them when SelfReview is set, splits tickets estimated above the configured
+// maximum and checks the result against the ticket rules.
+// Rejected tickets are regenerated with the problems as feedback; when every attempt is
+// rejected no tickets are returned.
+func (em *EngineeringManagerAgent) decompose(card board.Card) ([]TechnicalTicket, error) {
+	prompt := guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", card.GetName(), card.GetDescription()))
+	if em.Repos != nil && len(em.Repos.Names()) > 1 {
+		prompt += fmt.Sprintf("\n\nRepositories: %s. Set \"repo\" on each ticket to the repository it changes.", strings.Join(em.Repos.Names(), ", "))
+	}
+	rules := em.ticketRules()
+	sections := slices.Clone(rules.RequiredSections)
+	if tmpl := em.ticketTemplate(); tmpl != nil {
+		for _, name := range tmpl.Sections {
+			if !slices.ContainsFunc(sections, func(s string) bool { return strings.EqualFold(s, name) }) {
+				sections = append(sections, name)
+			}
+		}
+	}
+	var format string
+	if len(sections) > 0 {
+		format = fmt.Sprintf("\n\nEvery ticket description must contain these sections, each on its own heading line: %s.", strings.Join(sections, ", "))
+	}
+	prompt += format
+	prompt += em.decisionPrompt(card)
+	if rules.MaxStoryPoints > 0 {
+		prompt += fmt.Sprintf("\n\nKeep every ticket at or below %s story points.", formatPoints(rules.MaxStoryPoints))
+	}
+	attempts := rules.Attempts
+	if attempts <= 0 {
+		attempts = DefaultDecomposeAttempts
+	}
+
+	var problems []string
+	for attempt := 1; ; attempt++ {
+		input := prompt
+		if len(problems) > 0 {
+			input += "\n\nYour previous tickets were rejected:\n- " + strings.Join(problems, "\n- ") + "\nFix these problems."
+		}
+		tickets, err := em.requestTickets(input)
+		if err != nil {
+			return nil, err
+		}
+		tickets = selfReview(em.BaseAgent, "technical tickets", prompt, tickets)
+		tickets = em.splitOversized(tickets, rules, format)
+		if problems = CheckTickets(tickets, rules); len(problems) == 0 {
+			return tickets, nil
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("%w after %d attempt(s): %s", ErrTicketRules, attempts, strings.Join(problems, "; "))
+		}
+		fmt.Printf("Warning: decomposition of %q rejected (%s); regenerating\n", card.GetName(), strings.Join(problems, "; "))
+	}
+}
+
+// requestTickets sends one decomposition request.
+func (em *EngineeringManagerAgent) requestTickets(prompt string) ([]TechnicalTicket, error) {
+	chatReq, err := em.PromptBuilder.Build(
+		em.Role,
+		"Decompose",
+		em.Context.GetContext(),
+		prompt,
+		[]TechnicalTicket{},
+		em.ModelClient.GetTemperature(),
+		em.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build decomposition request: %w", err)
+	}
+
+	var wrapper struct {
+		Result []TechnicalTicket `json:"result"`
+	}
+	if err := model.ChatStructured(em.ModelClient, chatReq, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse decomposition response: %w", err)
+	}
+	return wrapper.Result, nil
+}
+
+// TicketEstimate is the model's estimate of a single ticket.
+type TicketEstimate struct {
+	StoryPoints float64 `json:"storyPoints"` // Fibonacci scale (1, 2, 3, 5, 8, 13).
+	Reason      string  `json:"reason"`
+}
+
+// EstimateTicket asks the model for the story points of a card and stores them on it.
+func (em *EngineeringManagerAgent) EstimateTicket(card board.Card) (TicketEstimate, error) {
+	prompt := "Estimate the effort of this ticket in story points on a Fibonacci scale (1, 2, 3, 5, 8, 13) and explain the estimate briefly.\n\n" +
+		guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", card.GetName(), card.GetDescription()))
+	chatReq, err := em.PromptBuilder.Build(
+		em.Role,
+		"Estimate",
+		em.Context.GetContext(),
+		prompt,
+		TicketEstimate{},
+		em.ModelClient.GetTemperature(),
+		em.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return TicketEstimate{}, fmt.Errorf("failed to build estimate request: %w", err)
+	}
+	var estimate TicketEstimate
+	if err := model.ChatStructured(em.ModelClient, chatReq, &estimate); err != nil {
+		return TicketEstimate{}, fmt.Errorf("failed to parse estimate: %w", err)
+	}
+	if err := board.SetEstimate(card, estimate.StoryPoints); err != nil {
+		return estimate, fmt.Errorf("failed to set estimate on %q: %w", card.GetName(), err)
+	}
+	return estimate, nil
+}
+
+// Act answers pending dependency change requests when ReviewDependencies is set, then decomposes
+// every ticket currently assigned to the Engineering Manager that has not been decomposed yet.
+func (em *EngineeringManagerAgent) Act() error {
+	if em.ReviewDependencies {
+		if err := em.ReviewDependencyRequests(); err != nil {
+			return err
+		}
+	}
+	cards, err := em.FindMyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if em.decomposed(card) {
+			continue
+		}
+		if _, err := em.HandleTicket(card); err != nil {
+			return fmt.Errorf("failed to handle ticket %s: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// decomposed reports whether card already has technical tickets linked to it. A decomposition
+// interrupted halfway still has its checkpoint and is resumed instead.
+func (em *EngineeringManagerAgent) decomposed(card board.Card) bool {
+	if cp := em.LoadCheckpoint(card.GetID()); len(cp.Plan) > 0 {
+		return false
+	}
+	children, err := board.GetChildren(em.BoardClient, card)
+	if err != nil {
+		fmt.Printf("Warning: failed to look up tickets of %q: %v\n", card.GetName(), err)
+		return true // Decomposing again could duplicate them.
+	}
+	return len(children) > 0
+}
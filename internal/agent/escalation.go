@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Escalation actions understood by Escalate.
+const (
+	EscalateComment  = "comment"
+	EscalateNotify   = "notify"
+	EscalateReassign = "reassign"
+	EscalateMove     = "move"
+)
+
+// DefaultEscalation is used when neither the agent nor the configuration defines a chain:
+// remind on the card and park it in the blocked list.
+var DefaultEscalation = []config.EscalationStep{
+	{Action: EscalateComment, Message: "Reminder: still waiting for a reply on {card}. Moving it to Blocked until someone answers."},
+	{Action: EscalateMove, List: board.ListBlocked},
+}
+
+// escalationSteps returns the chain that applies to this agent.
+func (a *BaseAgent) escalationSteps() []config.EscalationStep {
+	if a.Escalation != nil {
+		return a.Escalation
+	}
+	if steps := config.GetEscalationSteps(); steps != nil {
+		return steps
+	}
+	return DefaultEscalation
+}
+
+// Escalate runs every step of the escalation chain for card. All steps are attempted;
+// the first error is returned.
+func (a *BaseAgent) Escalate(card board.Card) error {
+	var firstErr error
+	for _, step := range a.escalationSteps() {
+		if err := a.escalateStep(card, step); err != nil {
+			fmt.Printf("Warning: escalation step %s on %q failed: %v\n", step.Action, card.GetName(), err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("escalation step %s failed: %w", step.Action, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+func (a *BaseAgent) escalateStep(card board.Card, step config.EscalationStep) error {
+	message := strings.NewReplacer("{card}", card.GetName(), "{url}", card.GetURL()).Replace(step.Message)
+	switch step.Action {
+	case EscalateComment:
+		return card.WriteComment(message)
+	case EscalateNotify:
+		if a.Notifier == nil {
+			return fmt.Errorf("no notifier configured")
+		}
+		if message == "" {
+			message = fmt.Sprintf("%s is waiting for a reply on %s: %s", a.Name, card.GetName(), card.GetURL())
+		}
+		return a.Notifier.Notify(step.Channel, message)
+	case EscalateReassign:
+		if step.Assignee == "" {
+			return fmt.Errorf("no assignee configured")
+		}
+		if err := card.AssignTo(step.Assignee); err != nil {
+			return err
+		}
+		if a.Name != "" {
+			// The agent may not be a card member; failing to unassign is not fatal.
+			_ = card.UnassignFrom(a.Name)
+		}
+		return nil
+	case EscalateMove:
+		list := step.List
+		if list == "" {
+			list = board.ListBlocked
+		}
+		return board.TransitionTicket(card, list, a.Name, "no reply received")
+	default:
+		return fmt.Errorf("unknown escalation action %q", step.Action)
+	}
+}
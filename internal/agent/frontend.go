@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// LabelUI marks technical tickets handled by the Frontend agent.
+const LabelUI = "ui"
+
+// AssetRequest describes a design asset the frontend needs.
+type AssetRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// FrontendAgent implements UI tickets inside the configured frontend paths and asks the Designer for assets.
+type FrontendAgent struct {
+	*BackendAgent
+	Designer string // Board member asked for assets; empty skips coordination.
+}
+
+// NewFrontendAgent creates a new FrontendAgent using the provided BaseAgent.
+// Writes are limited to paths; an empty list allows the whole repository.
+func NewFrontendAgent(base *BaseAgent, paths ...string) *FrontendAgent {
+	backend := NewBackendAgent(base)
+	backend.Label = LabelUI
+	backend.AllowedPaths = paths
+	return &FrontendAgent{BackendAgent: backend, Designer: RoleDesigner}
+}
+
+// Act implements every assigned UI ticket waiting in the sprint or in Doing, requesting assets first when needed.
+func (f *FrontendAgent) Act() error {
+	cards, err := f.FindReadyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if !board.HasLabel(card, f.Label) {
+			continue
+		}
+		if list, err := card.GetList(); err != nil || !isIntake(list.GetName()) {
+			continue
+		}
+		if err := f.RequestAssets(card); err != nil {
+			return fmt.Errorf("failed to get assets for %q: %w", card.GetName(), err)
+		}
+		if err := f.ImplementTicket(card); err != nil {
+			return fmt.Errorf("failed to implement %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// RequestAssets asks the model which design assets the ticket needs and, if any, asks the Designer
+// for them on the card and waits until they are delivered. The Designer's answer is appended to the
+// card description so ImplementTicket sees it.
+func (f *FrontendAgent) RequestAssets(card board.Card) error {
+	if f.Designer == "" {
+		return nil
+	}
+	prompt := guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", card.GetName(), card.GetDescription())) +
+		"\n\nList the design assets (icons, images, mock-ups) this ticket needs that do not exist yet."
+	chatReq, err := f.PromptBuilder.Build(
+		f.Role,
+		"PlanAssets",
+		f.Context.GetContext(),
+		prompt,
+		[]AssetRequest{},
+		f.ModelClient.GetTemperature(),
+		f.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build asset request: %w", err)
+	}
+	var wrapper struct {
+		Result []AssetRequest `json:"result"`
+	}
+	if err := f.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
+		return fmt.Errorf("failed to parse asset request: %w", err)
+	}
+	if len(wrapper.Result) == 0 {
+		return nil
+	}
+
+	lines := []string{fmt.Sprintf("@%s I need the following assets for this ticket:", f.Designer)}
+	for _, a := range wrapper.Result {
+		lines = append(lines, fmt.Sprintf("- %s: %s", a.Name, a.Description))
+	}
+	if err := card.AssignTo(f.Designer); err != nil {
+		fmt.Printf("Warning: failed to assign %q to %s: %v\n", card.GetName(), f.Designer, err)
+	}
+	answer, err := f.Ask(card, strings.Join(lines, "\n"))
+	if err != nil {
+		return err
+	}
+	return card.ChangeDescription(card.GetDescription() + "\n\nAssets from the Designer:\n" + answer)
+}
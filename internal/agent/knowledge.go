@@ -0,0 +1,26 @@
+package agent
+
+import "fmt"
+
+// KnowledgeResults is the number of knowledge base chunks added to a prompt.
+const KnowledgeResults = 4
+
+// withKnowledge prefixes state with the project knowledge relevant to query.
+// It returns state unchanged when no knowledge base is configured or nothing matches.
+func (a *BaseAgent) withKnowledge(state, query string) string {
+	if a.Knowledge == nil {
+		return state
+	}
+	kb, err := a.Knowledge.Context(query, KnowledgeResults)
+	if err != nil {
+		fmt.Printf("Warning: knowledge base search failed: %v\n", err)
+		return state
+	}
+	if kb == "" {
+		return state
+	}
+	if state == "" {
+		return kb
+	}
+	return kb + "\n" + state
+}
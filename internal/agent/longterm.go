@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/context"
+	mclient "github.com/egobogo/aiagents/internal/model"
+)
+
+// Long-term memory settings.
+const (
+	LongTermLimit      = 20 // Learnings included in the system message.
+	LearningImportance = 8  // Minimum importance of a thought worth keeping across runs.
+)
+
+// withLongTerm inserts the agent's long-term learnings as a system message after the builder's own.
+func (a *BaseAgent) withLongTerm(req mclient.ChatRequest) mclient.ChatRequest {
+	if a.LongTerm == nil {
+		return req
+	}
+	text := a.LongTerm.SystemMessage(a.Name, LongTermLimit)
+	if text == "" {
+		return req
+	}
+	at := 0
+	for at < len(req.Input) && req.Input[at].Role == "system" {
+		at++
+	}
+	input := make([]mclient.Message, 0, len(req.Input)+1)
+	input = append(input, req.Input[:at]...)
+	input = append(input, mclient.Message{Role: "system", Content: text})
+	req.Input = append(input, req.Input[at:]...)
+	return req
+}
+
+// learn keeps the important thoughts in long-term memory.
+func (a *BaseAgent) learn(thoughts []context.EasyMemory) {
+	if a.LongTerm == nil {
+		return
+	}
+	for _, t := range thoughts {
+		if t.Importance < LearningImportance {
+			continue
+		}
+		if _, err := a.LongTerm.Add(a.Name, t.Category, t.Content, 0); err != nil {
+			fmt.Printf("Warning: failed to keep learning: %v\n", err)
+		}
+	}
+}
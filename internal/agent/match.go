@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// ReplyMatcher decides whether a new comment is the reply an agent is waiting for.
+type ReplyMatcher func(board.Comment) bool
+
+// Tagged matches comments containing any of tags, e.g. "@backend" or "/approve", ignoring case.
+func Tagged(tags ...string) ReplyMatcher {
+	return func(c board.Comment) bool {
+		text := strings.ToLower(c.Text)
+		for _, tag := range tags {
+			if strings.Contains(text, strings.ToLower(tag)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Matching matches comments re finds a match in, e.g. `(?i)^\s*(approve|lgtm)\b`.
+func Matching(re *regexp.Regexp) ReplyMatcher {
+	return func(c board.Comment) bool { return re.MatchString(c.Text) }
+}
+
+// NewerThan matches comments created after t. Boards that do not report creation times
+// leave Created zero; such comments match, as the wait already skips comments seen before.
+func NewerThan(t time.Time) ReplyMatcher {
+	return func(c board.Comment) bool { return c.Created.IsZero() || c.Created.After(t) }
+}
+
+// FromMember matches comments by any of the members, given by ID, name or username.
+func FromMember(members ...string) ReplyMatcher {
+	return func(c board.Comment) bool {
+		if c.Member == nil {
+			return false
+		}
+		return slices.ContainsFunc(members, func(m string) bool {
+			return m != "" && (m == c.Member.ID || m == c.Member.Name || m == c.Member.Username)
+		})
+	}
+}
+
+// AllOf matches comments every matcher accepts.
+func AllOf(matchers ...ReplyMatcher) ReplyMatcher {
+	return func(c board.Comment) bool {
+		for _, m := range matchers {
+			if !m(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf matches comments at least one matcher accepts.
+func AnyOf(matchers ...ReplyMatcher) ReplyMatcher {
+	return func(c board.Comment) bool {
+		for _, m := range matchers {
+			if m(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// ErrMigrationFailed is returned when a generated migration still does not apply after all repair attempts.
+var ErrMigrationFailed = errors.New("migration still failing after repair attempts")
+
+// migrationSchemaFiles is how many of the latest migrations are shown to the model as the current schema.
+const migrationSchemaFiles = 5
+
+// MigrationDraft is a schema change the model asks for. The agent numbers and writes the files.
+type MigrationDraft struct {
+	Name     string `json:"name"`     // Snake case, e.g. "add_users_email".
+	Up       string `json:"up"`       // SQL applying the change.
+	Down     string `json:"down"`     // SQL reverting it.
+	Rollback string `json:"rollback"` // Steps to roll back in production, e.g. data to back up first.
+}
+
+// migrationPrompt tells the model how to ask for a migration and shows the latest existing ones.
+func (b *BackendAgent) migrationPrompt(repo *gitrepo.GitClient) (string, error) {
+	existing, err := codegen.ListMigrations(repo.RepoPath, b.MigrationsDir)
+	if err != nil {
+		return "", err
+	}
+	prompt := fmt.Sprintf("\n\nDatabase migrations live in %s. If the ticket changes the database schema, set \"migration\" "+
+		"with the up and down SQL and the rollback steps instead of writing migration files; it is numbered and validated "+
+		"for you. Leave it empty otherwise.", b.MigrationsDir)
+	if len(existing) == 0 {
+		return prompt + " There are no migrations yet.", nil
+	}
+	var sb strings.Builder
+	for i, m := range existing {
+		if i < len(existing)-migrationSchemaFiles {
+			sb.WriteString(fmt.Sprintf("=== %s ===\n(omitted)\n", m.Up))
+			continue
+		}
+		content, err := repo.ReadText(m.Up)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf("=== %s ===\n%s\n", m.Up, content))
+	}
+	return prompt + " Existing migrations:\n" + guard.Wrap("the existing migrations", sb.String()), nil
+}
+
+// writeMigration numbers the drafted migration after the existing ones, writes its files and verifies
+// them against a scratch database. Failures are fed back to the model up to MaxRepairAttempts times;
+// when the migration still does not apply the error is posted on the card and ErrMigrationFailed is
+// returned. It reports whether the migration was verified, which it is not when the migration command
+// is not installed.
+func (b *BackendAgent) writeMigration(repo *gitrepo.GitClient, card board.Card, draft *MigrationDraft) (codegen.Migration, bool, error) {
+	existing, err := codegen.ListMigrations(repo.RepoPath, b.MigrationsDir)
+	if err != nil {
+		return codegen.Migration{}, false, err
+	}
+	migration := codegen.NextMigration(existing, b.MigrationsDir, codegen.MigrationName(draft.Name))
+	attempts := b.MaxRepairAttempts
+	if attempts <= 0 {
+		attempts = DefaultRepairAttempts
+	}
+	for attempt := 0; ; attempt++ {
+		files := []GeneratedFile{{Path: migration.Up, Content: draft.Up}, {Path: migration.Down, Content: draft.Down}}
+		if err := b.WriteFiles(repo, files); err != nil {
+			return migration, false, err
+		}
+		err := codegen.VerifyMigration(repo.RepoPath, append(existing, migration), b.MigrationCommand)
+		if errors.Is(err, codegen.ErrNoDatabase) {
+			fmt.Printf("Warning: skipping migration check: %v\n", err)
+			return migration, false, nil
+		}
+		var migErr *codegen.MigrationError
+		if !errors.As(err, &migErr) {
+			return migration, err == nil, err
+		}
+		if attempt >= attempts {
+			comment := fmt.Sprintf("Migration %s still does not apply after %d repair attempt(s). Please take a look.\n\n%s",
+				migration.ID(), attempts, board.CodeBlock(migErr.Error(), ""))
+			if err := card.WriteComment(comment); err != nil {
+				fmt.Printf("Warning: failed to escalate migration failure: %v\n", err)
+			}
+			return migration, false, fmt.Errorf("%w: %v", ErrMigrationFailed, migErr)
+		}
+		repaired, err := b.repairMigration(*draft, migErr)
+		if err != nil {
+			return migration, false, err
+		}
+		draft.Up, draft.Down = repaired.Up, repaired.Down
+		if repaired.Rollback != "" {
+			draft.Rollback = repaired.Rollback
+		}
+	}
+}
+
+// repairMigration asks the model for corrected SQL of a migration that did not apply.
+func (b *BackendAgent) repairMigration(draft MigrationDraft, migErr *codegen.MigrationError) (MigrationDraft, error) {
+	prompt := fmt.Sprintf("The migration %q does not apply to a scratch database with all earlier migrations. "+
+		"Return the corrected migration.\n\n%s\n\nUp:\n%s\nDown:\n%s",
+		draft.Name, board.CodeBlock(migErr.Error(), ""), board.CodeBlock(draft.Up, "sql"), board.CodeBlock(draft.Down, "sql"))
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"RepairMigration",
+		b.Context.GetContext(),
+		prompt,
+		MigrationDraft{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return MigrationDraft{}, fmt.Errorf("failed to build migration repair request: %w", err)
+	}
+	var repaired MigrationDraft
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &repaired); err != nil {
+		return MigrationDraft{}, fmt.Errorf("failed to parse migration repair response: %w", err)
+	}
+	return repaired, nil
+}
+
+// postMigration documents a committed migration and how to roll it back on the card.
+func postMigration(card board.Card, m codegen.Migration, draft *MigrationDraft, verified bool) {
+	text := fmt.Sprintf("Database migration %s:\n- Apply: %s\n- Roll back: %s", m.ID(), m.Up, m.Down)
+	if rollback := strings.TrimSpace(draft.Rollback); rollback != "" {
+		text += "\n\nRollback steps:\n" + rollback
+	}
+	if verified {
+		text += "\n\nChecked against a scratch database: all migrations apply, and this one rolls back and applies again."
+	} else {
+		text += "\n\nNot checked against a database; the migration command is not installed."
+	}
+	if err := card.WriteComment(text); err != nil {
+		fmt.Printf("Warning: failed to post migration notes on %q: %v\n", card.GetName(), err)
+	}
+}
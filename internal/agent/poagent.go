@@ -25,3 +25,8 @@ func NewProductManagerAgent(base *BaseAgent) *ProductManagerAgent {
 func (pm *ProductManagerAgent) createContext() error {
 	return nil
 }
+
+// Act is a no-op until the Product Manager has automated duties.
+func (pm *ProductManagerAgent) Act() error {
+	return nil
+}
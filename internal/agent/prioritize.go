@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// PriorityScore is the model's assessment of one backlog ticket, each dimension on a 1–10 scale.
+type PriorityScore struct {
+	ID        string  `json:"id"`
+	Value     float64 `json:"value"`  // Benefit to users or the business.
+	Effort    float64 `json:"effort"` // Work needed to deliver it.
+	Risk      float64 `json:"risk"`   // Uncertainty and potential for breakage.
+	Rationale string  `json:"rationale"`
+}
+
+// Score combines the dimensions into one number; higher scores come first.
+// Value is divided by effort plus risk and rounded to two decimals.
+func (s PriorityScore) Score() float64 {
+	return math.Round(clampScale(s.Value)/(clampScale(s.Effort)+clampScale(s.Risk))*100) / 100
+}
+
+// clampScale limits v to the 1–10 scale.
+func clampScale(v float64) float64 {
+	return math.Min(math.Max(v, 1), 10)
+}
+
+// BacklogPriorities is the model's assessment of the backlog.
+type BacklogPriorities struct {
+	Scores []PriorityScore `json:"scores"`
+}
+
+// PrioritizeBacklog asks the model to score every backlog ticket on value, effort and risk, stores
+// each score on its card and reorders the backlog by descending score. Tickets the model skipped
+// keep their relative order below the scored ones. It returns the backlog in its new order.
+func (em *EngineeringManagerAgent) PrioritizeBacklog() ([]board.Card, error) {
+	backlog, err := em.BoardClient.GetCardsFromList(board.ListBacklog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backlog: %w", err)
+	}
+	if len(backlog) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	for _, c := range backlog {
+		sb.WriteString(fmt.Sprintf("- id=%s points=%s%s title=%s\n  %s\n", c.GetID(), formatPoints(cardPoints(c)), fieldTags(c), c.GetName(), strings.ReplaceAll(c.GetDescription(), "\n", "\n  ")))
+	}
+	prompt := "Score every backlog ticket from 1 to 10 on value, effort and risk, referring to tickets by id.\n\n" +
+		guard.Wrap("the backlog", sb.String())
+
+	chatReq, err := em.PromptBuilder.Build(
+		em.Role,
+		"PrioritizeBacklog",
+		em.Context.GetContext(),
+		prompt,
+		BacklogPriorities{},
+		em.ModelClient.GetTemperature(),
+		em.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prioritization request: %w", err)
+	}
+	var priorities BacklogPriorities
+	if err := em.ModelClient.ChatAdvancedParsed(chatReq, &priorities); err != nil {
+		return nil, fmt.Errorf("failed to parse backlog priorities: %w", err)
+	}
+
+	scores := make(map[string]float64)
+	for _, s := range priorities.Scores {
+		scores[s.ID] = s.Score()
+	}
+	var ordered []board.Card
+	for _, c := range backlog {
+		score, ok := scores[c.GetID()]
+		if !ok {
+			continue // Unknown ID or a ticket the model skipped.
+		}
+		if err := board.SetScore(c, score); err != nil {
+			fmt.Printf("Warning: failed to store the score of %q: %v\n", c.GetName(), err)
+		}
+		ordered = append(ordered, c)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return scores[ordered[i].GetID()] > scores[ordered[j].GetID()]
+	})
+	for _, c := range backlog {
+		if _, ok := scores[c.GetID()]; !ok {
+			ordered = append(ordered, c)
+		}
+	}
+
+	ok, err := board.Reorder(ordered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder backlog: %w", err)
+	}
+	if !ok {
+		fmt.Printf("Warning: the board does not order cards; backlog scores were stored without reordering\n")
+	}
+	return ordered, nil
+}
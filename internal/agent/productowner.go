@@ -0,0 +1,193 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// DefaultInterviewRounds limits how many rounds of questions the Product Owner asks about one idea.
+const DefaultInterviewRounds = 3
+
+// HighLevelTicket is a business-level ticket handed to the Engineering Manager.
+type HighLevelTicket struct {
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	AcceptanceCriteria []string `json:"acceptanceCriteria"`
+}
+
+// TicketDraft is the Product Owner's answer for an idea: either open questions or finished tickets.
+type TicketDraft struct {
+	Questions []string          `json:"questions"`
+	Tickets   []HighLevelTicket `json:"tickets"`
+}
+
+// ProductOwnerAgent turns free-form feature ideas into high-level tickets.
+type ProductOwnerAgent struct {
+	*BaseAgent
+	InboxList       string // List holding raw ideas.
+	Assignee        string // Member the finished tickets are assigned to, usually the Engineering Manager.
+	InterviewRounds int    // Zero uses DefaultInterviewRounds.
+}
+
+// NewProductOwnerAgent creates a new ProductOwnerAgent using the provided BaseAgent.
+func NewProductOwnerAgent(base *BaseAgent) *ProductOwnerAgent {
+	return &ProductOwnerAgent{
+		BaseAgent:       base,
+		InboxList:       board.ListInbox,
+		Assignee:        RoleEngineeringManager,
+		InterviewRounds: DefaultInterviewRounds,
+	}
+}
+
+// createContext is a no-op; the Product Owner works from the idea and the interview only.
+func (po *ProductOwnerAgent) createContext() error {
+	return nil
+}
+
+// Act drafts tickets for every idea in the inbox list and moves the idea to Done.
+func (po *ProductOwnerAgent) Act() error {
+	ideas, err := po.BoardClient.GetCardsFromList(po.InboxList)
+	if err != nil {
+		return fmt.Errorf("failed to read inbox: %w", err)
+	}
+	for _, idea := range ideas {
+		text := idea.GetName()
+		if d := strings.TrimSpace(idea.GetDescription()); d != "" {
+			text += "\n\n" + d
+		}
+		created, err := po.Draft(text, idea)
+		if err != nil {
+			return fmt.Errorf("failed to draft tickets for %q: %w", idea.GetName(), err)
+		}
+		lines := []string{fmt.Sprintf("Drafted %d ticket(s):", len(created))}
+		for _, c := range created {
+			lines = append(lines, "- "+c.GetURL())
+		}
+		if err := idea.WriteComment(strings.Join(lines, "\n")); err != nil {
+			fmt.Printf("Warning: failed to comment on idea %q: %v\n", idea.GetName(), err)
+		}
+		// Ideas are not tickets: the inbox is outside the ticket flow, so the idea is moved directly.
+		if err := idea.Move(board.ListDone); err != nil {
+			fmt.Printf("Warning: failed to move idea %q: %v\n", idea.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// Draft turns a feature idea into high-level tickets in the backlog. When the model needs more
+// business context it asks on the idea card and waits for the answer; with a nil card
+// (e.g. CLI input) it drafts with what it has.
+func (po *ProductOwnerAgent) Draft(idea string, card board.Card) ([]board.Card, error) {
+	if card != nil {
+		po.CurrentTicketID = card.GetID()
+	}
+	rounds := po.InterviewRounds
+	if rounds <= 0 {
+		rounds = DefaultInterviewRounds
+	}
+
+	transcript := "Feature idea:\n" + guard.Wrap("the idea card", idea)
+	cp := checkpoint.New(po.Name, "")
+	round := 0
+	if card != nil {
+		answered, err := po.ResumeQuestions(card)
+		if err != nil {
+			return nil, err
+		}
+		for _, ex := range answered {
+			transcript += fmt.Sprintf("\n\n%s\nAnswer:\n%s", ex.Question, guard.Wrap("a card comment", ex.Answer))
+		}
+		round = len(answered)
+		cp = po.LoadCheckpoint(card.GetID())
+	}
+	var draft TicketDraft
+	if !po.restorePlan(cp, &draft) {
+		if err := po.draftTickets(transcript, card, round, rounds, &draft); err != nil {
+			return nil, err
+		}
+		po.savePlan(cp, draft)
+	}
+
+	list := board.ListBacklog
+	if card != nil {
+		list = board.ListOn(card, list)
+	}
+	var created []board.Card
+	for _, t := range draft.Tickets {
+		if c, ok := po.createdCard(cp, t.Title); ok {
+			created = append(created, c)
+			continue
+		}
+		c, err := po.BoardClient.CreateCard(t.Title, formatHighLevelTicket(t), list)
+		if err != nil {
+			return created, fmt.Errorf("failed to create ticket %q: %w", t.Title, err)
+		}
+		cp.MarkCreated(t.Title, c.GetID())
+		po.SaveCheckpoint(cp)
+		if po.Assignee != "" {
+			if err := c.AssignTo(po.Assignee); err != nil {
+				fmt.Printf("Warning: failed to assign %q to %s: %v\n", t.Title, po.Assignee, err)
+			}
+		}
+		created = append(created, c)
+	}
+	po.ClearCheckpoint(cp.TicketID)
+	return created, nil
+}
+
+// draftTickets runs the clarification interview, starting at round, until the model returns a draft.
+func (po *ProductOwnerAgent) draftTickets(transcript string, card board.Card, round, rounds int, draft *TicketDraft) error {
+	for ; ; round++ {
+		prompt := transcript
+		if card == nil || round >= rounds {
+			prompt += "\n\nDo not ask further questions; draft the tickets now."
+		}
+		chatReq, err := po.PromptBuilder.Build(
+			po.Role,
+			"DraftTickets",
+			po.Context.GetContext(),
+			prompt,
+			TicketDraft{},
+			po.ModelClient.GetTemperature(),
+			po.ModelClient.GetModel(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to build draft request: %w", err)
+		}
+		*draft = TicketDraft{}
+		if err := po.ModelClient.ChatAdvancedParsed(chatReq, draft); err != nil {
+			return fmt.Errorf("failed to parse draft response: %w", err)
+		}
+		if len(draft.Questions) == 0 || card == nil || round >= rounds {
+			if len(draft.Tickets) > 0 {
+				draft.Tickets = selfReview(po.BaseAgent, "tickets", transcript, draft.Tickets)
+			}
+			return nil
+		}
+
+		questions := selfReview(po.BaseAgent, "clarifying questions", transcript, draft.Questions)
+		question := "Before I write the tickets I need a few answers:\n- " + strings.Join(questions, "\n- ")
+		answer, err := po.Ask(card, question)
+		if err != nil {
+			return err
+		}
+		transcript += fmt.Sprintf("\n\n%s\nAnswer:\n%s", question, guard.Wrap("a card comment", answer))
+	}
+}
+
+// formatHighLevelTicket renders the description of a high-level ticket card.
+func formatHighLevelTicket(t HighLevelTicket) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(t.Description))
+	if len(t.AcceptanceCriteria) > 0 {
+		sb.WriteString("\n\nAcceptance criteria:\n")
+		for _, ac := range t.AcceptanceCriteria {
+			sb.WriteString("- [ ] " + ac + "\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
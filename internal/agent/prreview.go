@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// pullRequestPrefix starts the name of the attachment linking a ticket to its pull request.
+const pullRequestPrefix = "Pull request #"
+
+// ReviewReply is the model's answer to one review thread.
+type ReviewReply struct {
+	Files []GeneratedFile `json:"files"` // Code changes addressing the thread; empty when a reply is enough.
+	Reply string          `json:"reply"` // Posted on the thread.
+}
+
+// pullRequestNumber returns the number of the pull request linked on the card by openPullRequest.
+func pullRequestNumber(card board.Card) (int, bool) {
+	attachments, err := card.GetAttachments()
+	if err != nil {
+		return 0, false
+	}
+	for _, a := range attachments {
+		if rest, ok := strings.CutPrefix(a.Name, pullRequestPrefix); ok {
+			if n, err := strconv.Atoi(strings.TrimSpace(rest)); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// reviewThreads returns the CI gate's provider when it supports review threads.
+func (b *BackendAgent) reviewThreads() (vcs.ReviewThreads, bool) {
+	if b.CI == nil {
+		return nil, false
+	}
+	p, ok := b.CI.Provider.(vcs.ReviewThreads)
+	return p, ok
+}
+
+// AddressReviewThreads answers the unresolved review threads on the ticket's pull request. The model
+// either changes code or replies for each thread; changes are verified, committed and pushed on the
+// ticket branch before every thread gets its reply and is resolved. It returns how many threads
+// were addressed.
+func (b *BackendAgent) AddressReviewThreads(card board.Card) (int, error) {
+	provider, ok := b.reviewThreads()
+	if !ok {
+		return 0, nil
+	}
+	number, ok := pullRequestNumber(card)
+	if !ok {
+		return 0, nil
+	}
+	threads, err := provider.ReadReviewThreads(number)
+	if err != nil {
+		return 0, err
+	}
+	var open []vcs.ReviewThread
+	for _, t := range threads {
+		if !t.Resolved && len(t.Comments) > 0 {
+			open = append(open, t)
+		}
+	}
+	if len(open) == 0 {
+		return 0, nil
+	}
+
+	b.CurrentTicketID = card.GetID()
+	repo, err := b.CheckoutFor(card)
+	if err != nil {
+		return 0, err
+	}
+	replies := make([]string, len(open))
+	for i, t := range open {
+		reply, err := b.answerThread(repo, card, t)
+		if err != nil {
+			return 0, err
+		}
+		if err := b.WriteFiles(repo, reply.Files); err != nil {
+			return 0, err
+		}
+		replies[i] = strings.TrimSpace(reply.Reply)
+	}
+
+	changed, err := repo.ChangedFiles()
+	if err != nil {
+		return 0, err
+	}
+	if len(changed) > 0 {
+		message, err := b.ComposeCommitMessage(repo, card)
+		if err != nil {
+			return 0, err
+		}
+		author, email := b.Author()
+		if err := b.CommitWork(repo, card, message, author, email); err != nil {
+			return 0, err
+		}
+		if err := repo.PushChanges("", ""); err != nil {
+			return 0, err
+		}
+	}
+	for i, t := range open {
+		if replies[i] == "" {
+			replies[i] = "Addressed."
+		}
+		if err := provider.ReplyToThread(number, t.ID, replies[i]); err != nil {
+			return i, err
+		}
+		if err := provider.ResolveThread(number, t.ID); err != nil {
+			return i, err
+		}
+	}
+	if err := card.WriteComment(fmt.Sprintf("Addressed %d review thread(s) on pull request #%d.", len(open), number)); err != nil {
+		fmt.Printf("Warning: failed to report review threads on %q: %v\n", card.GetName(), err)
+	}
+	return len(open), nil
+}
+
+// answerThread asks the model how to address a review thread, sending the file it refers to.
+func (b *BackendAgent) answerThread(repo *gitrepo.GitClient, card board.Card, t vcs.ReviewThread) (ReviewReply, error) {
+	var conversation strings.Builder
+	for _, c := range t.Comments {
+		conversation.WriteString(fmt.Sprintf("%s: %s\n", c.Author, c.Body))
+	}
+	prompt := fmt.Sprintf("A reviewer left this thread on the pull request for ticket %q", card.GetName())
+	if t.Path != "" {
+		prompt += fmt.Sprintf(" at %s:%d", t.Path, t.Line)
+	}
+	prompt += ". Address it: return the complete new content of every file you change, and a short reply for the " +
+		"thread explaining what you changed, or why no change is needed.\n\n" + guard.Wrap("the review thread", conversation.String())
+	if t.Path != "" {
+		if content, err := repo.ReadText(t.Path); err == nil {
+			prompt += fmt.Sprintf("\n\nFile: %s\n%s", t.Path, board.CodeBlock(content, strings.TrimPrefix(path.Ext(t.Path), ".")))
+		}
+	}
+	chatReq, err := b.PromptBuilder.Build(
+		b.Role,
+		"AddressReview",
+		b.Context.GetContext(),
+		prompt,
+		ReviewReply{},
+		b.ModelClient.GetTemperature(),
+		b.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return ReviewReply{}, fmt.Errorf("failed to build review request: %w", err)
+	}
+	var reply ReviewReply
+	if err := b.ModelClient.ChatAdvancedParsed(chatReq, &reply); err != nil {
+		return ReviewReply{}, fmt.Errorf("failed to parse review response: %w", err)
+	}
+	return reply, nil
+}
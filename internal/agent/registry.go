@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/policy"
+	"github.com/egobogo/aiagents/internal/tracing"
+)
+
+// Role names of the built-in agents.
+const (
+	RoleEngineeringManager = "EngineeringManager"
+	RoleProductManager     = "ProductManager"
+	RoleProductOwner       = "ProductOwner"
+	RoleBackend            = "Backend"
+	RoleDevOps             = "DevOps"
+	RoleSecurity           = "Security"
+	RoleBenchmark          = "Benchmark"
+	RoleDocs               = "TechnicalWriter"
+	RoleAPISpec            = "APISpec"
+	RoleFrontend           = "Frontend"
+	RoleDesigner           = "Designer"
+	RoleRetro              = "Retrospective"
+	RoleRelease            = "Release"
+	RoleTriage             = "Triage"
+)
+
+// Factory builds an agent for a role from its shared dependencies.
+type Factory func(deps *BaseAgent) (Agent, error)
+
+// Registry maps role names to agent factories.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// Register adds or replaces the factory for role.
+func (r *Registry) Register(role string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[role] = factory
+}
+
+// New creates an agent for role. deps.Role is set to role when empty, and a model client that
+// supports per-role settings is replaced by one configured for the role.
+// A role with a configured path scope only sees that part of the repository.
+// Board writes forbidden by deps.Policy are refused, writes wait while the kill switch is engaged,
+// in dry-run mode they are only printed, and with a Tracer set, model calls and board writes are traced.
+func (r *Registry) New(role string, deps *BaseAgent) (Agent, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[role]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no agent registered for role %q (known roles: %v)", role, r.Roles())
+	}
+	if deps == nil {
+		return nil, fmt.Errorf("dependencies for role %q are nil", role)
+	}
+	if deps.Role == "" {
+		deps.Role = role
+	}
+	if scoped, ok := deps.ModelClient.(model.RoleScoped); ok {
+		deps.ModelClient = scoped.ForRole(deps.Role)
+	}
+	if deps.GitClient != nil {
+		deps.GitClient = deps.GitClient.Scoped(config.GetRoleScope(deps.Role)...)
+	}
+	if deps.BoardClient != nil {
+		deps.BoardClient = board.Pausable(policy.WrapBoard(deps.BoardClient, deps.Policy, deps.Name))
+	}
+	if deps.Tracer != nil {
+		deps.ModelClient = tracing.WrapModel(deps.ModelClient, deps.Tracer, deps.Name)
+		deps.BoardClient = tracing.WrapBoard(deps.BoardClient, deps.Tracer, deps.Name)
+	}
+	return factory(deps)
+}
+
+// Roles returns the registered role names in sorted order.
+func (r *Registry) Roles() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	roles := make([]string, 0, len(r.factories))
+	for role := range r.factories {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// DefaultRegistry holds the built-in roles. Additional roles can register themselves from an init function.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.Register(RoleEngineeringManager, func(deps *BaseAgent) (Agent, error) {
+		return NewEngineeringManagerAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleProductManager, func(deps *BaseAgent) (Agent, error) {
+		return NewProductManagerAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleProductOwner, func(deps *BaseAgent) (Agent, error) {
+		return NewProductOwnerAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleBackend, func(deps *BaseAgent) (Agent, error) {
+		return NewBackendAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleDevOps, func(deps *BaseAgent) (Agent, error) {
+		return NewDevOpsAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleSecurity, func(deps *BaseAgent) (Agent, error) {
+		return NewSecurityAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleBenchmark, func(deps *BaseAgent) (Agent, error) {
+		return NewBenchmarkAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleDocs, func(deps *BaseAgent) (Agent, error) {
+		return NewDocsAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleAPISpec, func(deps *BaseAgent) (Agent, error) {
+		return NewAPISpecAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleFrontend, func(deps *BaseAgent) (Agent, error) {
+		return NewFrontendAgent(deps, "web", "frontend"), nil
+	})
+	DefaultRegistry.Register(RoleDesigner, func(deps *BaseAgent) (Agent, error) {
+		return NewDesignerAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleRetro, func(deps *BaseAgent) (Agent, error) {
+		return NewRetroAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleRelease, func(deps *BaseAgent) (Agent, error) {
+		return NewReleaseAgent(deps), nil
+	})
+	DefaultRegistry.Register(RoleTriage, func(deps *BaseAgent) (Agent, error) {
+		return NewTriageAgent(deps), nil
+	})
+}
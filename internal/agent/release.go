@@ -0,0 +1,267 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// DefaultChangelog is the changelog file the Release agent maintains.
+const DefaultChangelog = "CHANGELOG.md"
+
+// releaseCommitPrefix starts the Release agent's own commits, which are left out of later releases.
+const releaseCommitPrefix = "docs(release): "
+
+// ReleaseNotes is the model's write-up of a release.
+type ReleaseNotes struct {
+	Changelog string `json:"changelog"` // Markdown body of the changelog section, grouped by kind of change.
+	Notes     string `json:"notes"`     // Release notes for readers outside the team.
+}
+
+// ReleaseAgent writes the changelog and release notes for each release from the tickets and commits
+// since the previous one, commits them, posts them on a release card and, with a Publisher, publishes the release.
+type ReleaseAgent struct {
+	*BaseAgent
+	ReleaseList string // List the release cards are created in.
+	Changelog   string // Repository-relative path of the changelog.
+	// Publisher, when set, gets each release published after the release commit and tags are pushed.
+	Publisher vcs.ReleasePublisher
+	Assets    []string // Optional; repository-relative files attached to published releases.
+}
+
+// NewReleaseAgent creates a new ReleaseAgent using the provided BaseAgent.
+func NewReleaseAgent(base *BaseAgent) *ReleaseAgent {
+	return &ReleaseAgent{
+		BaseAgent:   base,
+		ReleaseList: board.ListRelease,
+		Changelog:   DefaultChangelog,
+	}
+}
+
+// createContext is a no-op; releases are built from board and repository data only.
+func (r *ReleaseAgent) createContext() error {
+	return nil
+}
+
+// Act writes the release for the newest tag when it has no release card yet.
+func (r *ReleaseAgent) Act() error {
+	tags, err := r.GitClient.Tags()
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	latest := tags[len(tags)-1]
+	if card, err := r.findRelease(latest.Name); err != nil || card != nil {
+		return err
+	}
+	var prev *gitrepo.TagInfo
+	if len(tags) > 1 {
+		prev = &tags[len(tags)-2]
+	}
+	card, err := r.writeRelease(latest.Name, prev, latest.Name, latest.When)
+	if err != nil || card == nil {
+		return err
+	}
+	return r.publish(card, latest.Name)
+}
+
+// Release writes the release of version from the work since the newest tag and tags HEAD with version.
+func (r *ReleaseAgent) Release(version string) (board.Card, error) {
+	if card, err := r.findRelease(version); err != nil {
+		return nil, err
+	} else if card != nil {
+		return nil, fmt.Errorf("%s was already released: %s", version, card.GetURL())
+	}
+	tags, err := r.GitClient.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var prev *gitrepo.TagInfo
+	if len(tags) > 0 {
+		prev = &tags[len(tags)-1]
+	}
+	card, err := r.writeRelease(version, prev, "", time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, fmt.Errorf("nothing to release since %s", prev.Name)
+	}
+	author, email := r.Author()
+	if err := r.GitClient.CreateTag(version, "Release "+version, author, email); err != nil {
+		return card, err
+	}
+	return card, r.publish(card, version)
+}
+
+// publish pushes the release commit and the tags, then publishes the release of version with the
+// card's notes and the Assets, and links it on the card.
+func (r *ReleaseAgent) publish(card board.Card, version string) error {
+	if r.Publisher == nil {
+		return nil
+	}
+	var assets []vcs.ReleaseAsset
+	for _, p := range r.Assets {
+		data, err := r.GitClient.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read release asset: %w", err)
+		}
+		assets = append(assets, vcs.ReleaseAsset{Name: path.Base(p), ContentType: mime.TypeByExtension(path.Ext(p)), Data: data})
+	}
+	if err := r.GitClient.PushChanges("", ""); err != nil {
+		return err
+	}
+	if err := r.GitClient.PushTags("", ""); err != nil {
+		return err
+	}
+	release, err := r.Publisher.CreateRelease(version, "Release "+version, card.GetDescription(), assets)
+	if err != nil {
+		return err
+	}
+	return card.WriteComment("Published: " + release.URL)
+}
+
+// findRelease returns the release card of version, or nil when there is none.
+func (r *ReleaseAgent) findRelease(version string) (board.Card, error) {
+	cards, err := r.BoardClient.GetCardsFromList(r.ReleaseList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release cards: %w", err)
+	}
+	for _, c := range cards {
+		if c.GetName() == "Release "+version {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// releasedTickets returns the done tickets that reached Done after since and no later than until.
+// Tickets without history only count towards the first release.
+func (r *ReleaseAgent) releasedTickets(since, until time.Time) ([]board.Card, error) {
+	cards, err := r.BoardClient.GetCardsFromList(board.ListDone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get done tickets: %w", err)
+	}
+	var released []board.Card
+	for _, c := range cards {
+		var moves []board.Move
+		if h, ok := c.(board.CardHistory); ok {
+			moves, _ = h.GetMoves()
+		}
+		if len(moves) == 0 {
+			if since.IsZero() {
+				released = append(released, c)
+			}
+			continue
+		}
+		if done := moves[len(moves)-1].When; done.After(since) && !done.After(until) {
+			released = append(released, c)
+		}
+	}
+	return released, nil
+}
+
+// writeRelease collects the tickets and commits between prev and to (HEAD when empty), has the
+// model write the changelog section and release notes, commits the changelog and creates the
+// release card. It returns nil when there is nothing to release.
+func (r *ReleaseAgent) writeRelease(version string, prev *gitrepo.TagInfo, to string, until time.Time) (board.Card, error) {
+	var from string
+	var since time.Time
+	if prev != nil {
+		from, since = prev.Name, prev.When
+	}
+	commits, err := r.GitClient.CommitsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := r.releasedTickets(since, until)
+	if err != nil {
+		return nil, err
+	}
+	var log strings.Builder
+	for _, c := range commits {
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		if strings.HasPrefix(subject, releaseCommitPrefix) {
+			continue
+		}
+		log.WriteString(fmt.Sprintf("- %s %s\n", c.Hash[:7], subject))
+	}
+	if log.Len() == 0 && len(tickets) == 0 {
+		return nil, nil
+	}
+	var done strings.Builder
+	for _, c := range tickets {
+		done.WriteString(fmt.Sprintf("- %s (%s)\n", c.GetName(), c.GetURL()))
+	}
+
+	prompt := fmt.Sprintf(
+		"Write the release of %s. The changelog section lists the changes grouped under \"### Added\", \"### Changed\" "+
+			"and \"### Fixed\" (leave out empty groups), one line per change, without a version heading. The release "+
+			"notes explain the highlights to users in a few short paragraphs.\n\nCompleted tickets:\n%s\nCommits:\n%s",
+		version, guard.Wrap("the completed tickets", done.String()), guard.Wrap("the commit log", log.String()),
+	)
+	chatReq, err := r.PromptBuilder.Build(
+		r.Role,
+		"ReleaseNotes",
+		r.Context.GetContext(),
+		prompt,
+		ReleaseNotes{},
+		r.ModelClient.GetTemperature(),
+		r.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build release notes request: %w", err)
+	}
+	var notes ReleaseNotes
+	if err := r.ModelClient.ChatAdvancedParsed(chatReq, &notes); err != nil {
+		return nil, fmt.Errorf("failed to parse release notes: %w", err)
+	}
+
+	section := fmt.Sprintf("## %s - %s\n\n%s\n", version, until.Format("2006-01-02"), strings.TrimSpace(notes.Changelog))
+	existing, err := r.GitClient.ReadText(r.Changelog)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := r.GitClient.WriteFile(r.Changelog, []byte(insertChangelogSection(existing, section))); err != nil {
+		return nil, err
+	}
+	author, email := r.Author()
+	if err := r.GitClient.CommitChanges(releaseCommitPrefix+"add release notes for "+version, author, email); err != nil {
+		return nil, err
+	}
+
+	card, err := r.BoardClient.CreateCard("Release "+version, strings.TrimSpace(notes.Notes), r.ReleaseList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release card: %w", err)
+	}
+	if err := card.WriteComment("Changelog:\n\n" + section); err != nil {
+		fmt.Printf("Warning: failed to post the changelog of %s: %v\n", version, err)
+	}
+	return card, nil
+}
+
+// insertChangelogSection puts section above the newest release in a changelog, creating the
+// changelog when it is empty.
+func insertChangelogSection(changelog, section string) string {
+	if strings.TrimSpace(changelog) == "" {
+		return "# Changelog\n\n" + section
+	}
+	lines := strings.SplitAfter(changelog, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			return strings.Join(lines[:i], "") + section + "\n" + strings.Join(lines[i:], "")
+		}
+	}
+	return strings.TrimRight(changelog, "\n") + "\n\n" + section
+}
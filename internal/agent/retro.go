@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// DefaultRetroCadence is how often the Retrospective agent reports.
+const DefaultRetroCadence = 14 * 24 * time.Hour
+
+// Retrospective is the model's analysis of a period of completed work.
+type Retrospective struct {
+	Summary     string   `json:"summary"`
+	WentWell    []string `json:"wentWell"`
+	Problems    []string `json:"problems"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// RetroAgent periodically reviews done tickets and posts a retrospective card.
+type RetroAgent struct {
+	*BaseAgent
+	Cadence   time.Duration // Minimum time between two retrospectives.
+	RetroList string        // List the retrospective cards are created in.
+	LastRun   time.Time
+}
+
+// NewRetroAgent creates a new RetroAgent using the provided BaseAgent.
+func NewRetroAgent(base *BaseAgent) *RetroAgent {
+	return &RetroAgent{
+		BaseAgent: base,
+		Cadence:   DefaultRetroCadence,
+		RetroList: board.ListRetro,
+	}
+}
+
+// createContext is a no-op; retrospectives are built from board data only.
+func (r *RetroAgent) createContext() error {
+	return nil
+}
+
+// Act runs a retrospective when the cadence has elapsed since the last one.
+func (r *RetroAgent) Act() error {
+	if !r.LastRun.IsZero() && time.Since(r.LastRun) < r.Cadence {
+		return nil
+	}
+	if _, err := r.RunRetrospective(); err != nil {
+		return err
+	}
+	r.LastRun = time.Now()
+	return nil
+}
+
+// ticketFacts describes a done ticket for the retrospective prompt.
+func ticketFacts(c board.Card, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Ticket: %s\n", c.GetName()))
+	if v, ok := board.CardEstimate(c); ok {
+		sb.WriteString(fmt.Sprintf("  Estimate: %s points\n", formatPoints(v)))
+	}
+	if h, ok := c.(board.CardHistory); ok {
+		if moves, err := h.GetMoves(); err == nil && len(moves) > 0 {
+			sb.WriteString(fmt.Sprintf("  Rework loops: %d\n", board.Reworks(moves)))
+			durations := board.TimeInLists(moves, now)
+			lists := make([]string, 0, len(durations))
+			for l := range durations {
+				lists = append(lists, l)
+			}
+			sort.Strings(lists)
+			for _, l := range lists {
+				if l == board.ListDone {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("  Time in %s: %s\n", l, durations[l].Round(time.Minute)))
+			}
+		}
+	}
+	if comments, err := c.ReadComments(); err == nil {
+		sb.WriteString(fmt.Sprintf("  Comments (%d):\n", len(comments)))
+		for _, cm := range comments {
+			sb.WriteString("  > " + strings.ReplaceAll(strings.TrimSpace(cm.Text), "\n", " ") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// finishedSinceLastRun reports whether the card reached Done after the previous retrospective.
+// Cards without history are always included.
+func (r *RetroAgent) finishedSinceLastRun(c board.Card) bool {
+	h, ok := c.(board.CardHistory)
+	if !ok || r.LastRun.IsZero() {
+		return true
+	}
+	moves, err := h.GetMoves()
+	if err != nil || len(moves) == 0 {
+		return true
+	}
+	return moves[len(moves)-1].When.After(r.LastRun)
+}
+
+// RunRetrospective analyses the tickets done since the last run and creates a retrospective card in RetroList.
+func (r *RetroAgent) RunRetrospective() (board.Card, error) {
+	cards, err := r.BoardClient.GetCardsFromList(board.ListDone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get done tickets: %w", err)
+	}
+	now := time.Now()
+	var facts strings.Builder
+	reviewed := 0
+	for _, c := range cards {
+		if !r.finishedSinceLastRun(c) {
+			continue
+		}
+		facts.WriteString(ticketFacts(c, now))
+		reviewed++
+	}
+	if reviewed == 0 {
+		return nil, nil
+	}
+
+	prompt := "Run a retrospective over the completed tickets below. Look at rework loops, where time was spent, " +
+		"and recurring questions or failures in the comments. Suggest concrete process improvements.\n\n" + facts.String()
+	chatReq, err := r.PromptBuilder.Build(
+		r.Role,
+		"Retrospective",
+		r.Context.GetContext(),
+		prompt,
+		Retrospective{},
+		r.ModelClient.GetTemperature(),
+		r.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build retrospective request: %w", err)
+	}
+	var retro Retrospective
+	if err := r.ModelClient.ChatAdvancedParsed(chatReq, &retro); err != nil {
+		return nil, fmt.Errorf("failed to parse retrospective: %w", err)
+	}
+
+	card, err := r.BoardClient.CreateCard("Retrospective "+now.Format("2006-01-02"), formatRetrospective(retro, reviewed), r.RetroList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retrospective card: %w", err)
+	}
+	return card, nil
+}
+
+func formatRetrospective(r Retrospective, tickets int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s\n\nTickets reviewed: %d\n", strings.TrimSpace(r.Summary), tickets))
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString("\n" + title + ":\n")
+		for _, it := range items {
+			sb.WriteString("- " + it + "\n")
+		}
+	}
+	section("What went well", r.WentWell)
+	section("What went wrong", r.Problems)
+	section("Suggestions", r.Suggestions)
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkInstruction tells the model how to critique a draft.
+const checkInstruction = "Check the draft below as a critical reviewer before it is posted. " +
+	"Find ambiguities, missing tasks and contradictions and list them as issues. " +
+	"When the draft needs no changes, return no issues."
+
+// reviseInstruction tells the model how to revise a draft once its check found issues.
+const reviseInstruction = "Revise the draft below so that every issue listed is fixed, and return the whole revised draft."
+
+// DraftCheck is the model's critique of one of its own drafts.
+type DraftCheck struct {
+	Issues []string `json:"issues"`
+}
+
+// SelfReview is the model's revision of a draft that fixes the issues its check found.
+type SelfReview[T any] struct {
+	Revised T `json:"revised"`
+}
+
+// selfReview runs up to a.SelfReview critique rounds on draft and returns the revised draft.
+// input, the request the draft answers, is sent as is; callers wrap untrusted text.
+// Each round first checks the draft in the Classify mode and only asks for a revision, in the
+// SelfReview mode, when the check found issues; it stops early once a check finds none.
+// A failed round, or one that returns an empty draft, leaves the draft as it was.
+func selfReview[T any](a *BaseAgent, what, input string, draft T) T {
+	for round := 0; round < a.SelfReview; round++ {
+		data, err := json.MarshalIndent(draft, "", "  ")
+		if err != nil {
+			fmt.Printf("Warning: failed to encode %s for review: %v\n", what, err)
+			return draft
+		}
+		request := fmt.Sprintf("The %s were written for:\n%s\n\nDraft %s:\n%s", what, input, what, data)
+		var check DraftCheck
+		if err := a.chatStructured(ModeClassify, checkInstruction+"\n\n"+request, &check); err != nil {
+			fmt.Printf("Warning: review of %s failed: %v\n", what, err)
+			return draft
+		}
+		if len(check.Issues) == 0 {
+			return draft
+		}
+		issues := "Issues:\n- " + strings.Join(check.Issues, "\n- ")
+		var review SelfReview[T]
+		if err := a.chatStructured("SelfReview", reviseInstruction+"\n\n"+issues+"\n\n"+request, &review); err != nil {
+			fmt.Printf("Warning: revision of %s failed: %v\n", what, err)
+			return draft
+		}
+		if isEmptyDraft(review.Revised) {
+			fmt.Printf("Warning: review of %s returned an empty draft; keeping the original\n", what)
+			return draft
+		}
+		fmt.Printf("Self-review of %s found %d issue(s); revising\n", what, len(check.Issues))
+		draft = review.Revised
+	}
+	return draft
+}
+
+// isEmptyDraft reports whether a revised draft carries nothing, e.g. an empty list of tickets.
+func isEmptyDraft(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
@@ -0,0 +1,238 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// Severity levels of security findings, from most to least severe.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// ErrCriticalFindings is returned when a review found critical issues and the ticket was blocked.
+var ErrCriticalFindings = errors.New("critical security findings")
+
+// securityMarker prefixes security reports, followed by the commit they reviewed, so every commit
+// is reviewed once.
+const securityMarker = "[security]"
+
+// SecurityFinding is a single issue found in a diff.
+type SecurityFinding struct {
+	Severity       string `json:"severity"` // critical, high, medium or low.
+	Category       string `json:"category"` // e.g. injection, secrets, crypto, dependency.
+	Path           string `json:"path"`
+	Line           int    `json:"line"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+}
+
+// secretPatterns catch credentials that must never reach the repository, independent of the model.
+var secretPatterns = map[string]*regexp.Regexp{
+	"AWS access key":      regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+	"private key":         regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	"GitHub token":        regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36}`),
+	"Slack token":         regexp.MustCompile(`xox[baprs]-[A-Za-z0-9-]{10,}`),
+	"OpenAI API key":      regexp.MustCompile(`sk-[A-Za-z0-9_-]{20,}`),
+	"hard-coded password": regexp.MustCompile(`(?i)(password|passwd|secret)\s*[:=]\s*"[^"]{6,}"`),
+}
+
+// SecurityAgent reviews branch diffs for vulnerabilities and blocks tickets with critical findings.
+type SecurityAgent struct {
+	*BaseAgent
+	VCS             vcs.VCSProvider // Optional; findings are also posted on the pull request.
+	BaseBranch      string          // Branch diffs are taken against.
+	BlockOnCritical bool            // Move the card to the blocked list on critical findings.
+}
+
+// NewSecurityAgent creates a new SecurityAgent using the provided BaseAgent.
+func NewSecurityAgent(base *BaseAgent) *SecurityAgent {
+	return &SecurityAgent{
+		BaseAgent:       base,
+		BaseBranch:      "main",
+		BlockOnCritical: true,
+	}
+}
+
+// createContext is a no-op; reviews only look at the diff.
+func (s *SecurityAgent) createContext() error {
+	return nil
+}
+
+// Act reviews every assigned ticket that is not done yet.
+func (s *SecurityAgent) Act() error {
+	cards, err := s.FindReadyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find tickets: %w", err)
+	}
+	for _, card := range cards {
+		if list, err := card.GetList(); err == nil && (list.GetName() == board.ListDone || list.GetName() == board.ListBlocked) {
+			continue
+		}
+		if _, err := s.Review(card, nil); err != nil && !errors.Is(err, ErrCriticalFindings) {
+			return fmt.Errorf("failed to review %q: %w", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// scanSecrets reports added diff lines that match a known credential pattern.
+func scanSecrets(diff string) []SecurityFinding {
+	var findings []SecurityFinding
+	path, line := "", 0
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+		case strings.HasPrefix(l, "@@"):
+			var newStart int
+			if i := strings.Index(l, "+"); i >= 0 {
+				fmt.Sscanf(l[i:], "+%d", &newStart)
+			}
+			line = newStart - 1
+		case strings.HasPrefix(l, "+"):
+			line++
+			for name, re := range secretPatterns {
+				if re.MatchString(l) {
+					findings = append(findings, SecurityFinding{
+						Severity:       SeverityCritical,
+						Category:       "secrets",
+						Path:           path,
+						Line:           line,
+						Description:    fmt.Sprintf("Possible %s committed to the repository.", name),
+						Recommendation: "Remove it from the code, rotate the credential and load it from the environment or a secret store.",
+					})
+				}
+			}
+		case strings.HasPrefix(l, " "):
+			line++
+		}
+	}
+	return findings
+}
+
+// Review scans the ticket branch for vulnerabilities: a pattern scan for secrets plus a model review
+// for injection, unsafe crypto and dependency issues. Findings are posted on the card and, when pr is
+// given and a VCS provider is configured, on the pull request. Critical findings block the ticket
+// and return ErrCriticalFindings. Each commit is reviewed once; it returns nil findings when the
+// branch head was already reviewed.
+func (s *SecurityAgent) Review(card board.Card, pr *vcs.PullRequest) ([]SecurityFinding, error) {
+	s.CurrentTicketID = card.GetID()
+	base, err := s.RepoFor(card)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := base.WorktreeFor(card.GetID())
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.HeadCommit()
+	if err != nil {
+		return nil, err
+	}
+	marker := fmt.Sprintf("%s %.7s", securityMarker, head)
+	if hasMarker(card, marker) {
+		return nil, nil
+	}
+	diff, err := repo.Diff(s.BaseBranch)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return nil, nil
+	}
+
+	findings := scanSecrets(diff)
+	modelFindings, err := s.reviewWithModel(diff)
+	if err != nil {
+		fmt.Printf("Warning: model security review failed, using pattern scan only: %v\n", err)
+	}
+	findings = append(findings, modelFindings...)
+
+	report := marker + " " + FormatSecurityReport(findings)
+	if err := card.WriteComment(report); err != nil {
+		fmt.Printf("Warning: failed to post security report on %q: %v\n", card.GetName(), err)
+	}
+	if pr != nil && s.VCS != nil {
+		if err := s.VCS.CommentOnPullRequest(pr.Number, report); err != nil {
+			fmt.Printf("Warning: failed to post security report on PR #%d: %v\n", pr.Number, err)
+		}
+	}
+
+	for _, f := range findings {
+		if f.Severity != SeverityCritical {
+			continue
+		}
+		if s.BlockOnCritical {
+			if err := board.TransitionTicket(card, board.ListBlocked, s.Name, "critical security findings"); err != nil {
+				return findings, fmt.Errorf("failed to block ticket: %w", err)
+			}
+		}
+		return findings, ErrCriticalFindings
+	}
+	return findings, nil
+}
+
+func (s *SecurityAgent) reviewWithModel(diff string) ([]SecurityFinding, error) {
+	prompt := "Review the following diff for security vulnerabilities: injection (SQL, command, path, template), " +
+		"leaked secrets, unsafe or outdated cryptography, and risky dependency changes. Report only real issues.\n\n" +
+		guard.Wrap("the diff under review", diff)
+	chatReq, err := s.PromptBuilder.Build(
+		s.Role,
+		"SecurityReview",
+		s.Context.GetContext(),
+		prompt,
+		[]SecurityFinding{},
+		s.ModelClient.GetTemperature(),
+		s.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build security review request: %w", err)
+	}
+	var wrapper struct {
+		Result []SecurityFinding `json:"result"`
+	}
+	if err := model.ChatStructured(s.ModelClient, chatReq, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to parse security review: %w", err)
+	}
+	for i := range wrapper.Result {
+		switch sev := strings.ToLower(wrapper.Result[i].Severity); sev {
+		case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow:
+			wrapper.Result[i].Severity = sev
+		default:
+			wrapper.Result[i].Severity = SeverityMedium
+		}
+	}
+	return wrapper.Result, nil
+}
+
+// FormatSecurityReport renders findings as a markdown comment, most severe first.
+func FormatSecurityReport(findings []SecurityFinding) string {
+	if len(findings) == 0 {
+		return "Security review: no issues found."
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Security review: %d finding(s).\n", len(findings)))
+	for _, sev := range []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow} {
+		for _, f := range findings {
+			if f.Severity != sev {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n- **%s** [%s] %s:%d — %s", strings.ToUpper(f.Severity), f.Category, f.Path, f.Line, f.Description))
+			if f.Recommendation != "" {
+				sb.WriteString("\n  Fix: " + f.Recommendation)
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// DefaultSprintCapacity is the number of story points planned when no capacity is given.
+const DefaultSprintCapacity = 20
+
+// SprintItem is one backlog ticket selected by the model, in priority order.
+type SprintItem struct {
+	ID        string `json:"id"`
+	Rationale string `json:"rationale"`
+}
+
+// SprintPlan is the model's prioritized selection for the next sprint.
+type SprintPlan struct {
+	Items   []SprintItem `json:"items"`
+	Summary string       `json:"summary"`
+}
+
+// PlanSprint asks the model to prioritize the backlog, selects tickets in that order until capacity
+// (in story points) is used up and moves them into the sprint list in priority order.
+// A "Sprint plan" card with the planning summary is added to the sprint list.
+// Tickets without an estimate count as one point.
+func (em *EngineeringManagerAgent) PlanSprint(capacity float64) ([]board.Card, error) {
+	if capacity <= 0 {
+		capacity = DefaultSprintCapacity
+	}
+	backlog, err := em.BoardClient.GetCardsFromList(board.ListBacklog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backlog: %w", err)
+	}
+	if len(backlog) == 0 {
+		return nil, nil
+	}
+
+	byID := make(map[string]board.Card)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Plan the next sprint with a capacity of %s story points. Order the tickets you select by priority.\n\nBacklog:\n", formatPoints(capacity)))
+	for _, c := range backlog {
+		byID[c.GetID()] = c
+		sb.WriteString(fmt.Sprintf("- id=%s points=%s%s title=%s\n  %s\n", c.GetID(), formatPoints(cardPoints(c)), fieldTags(c), c.GetName(), strings.ReplaceAll(c.GetDescription(), "\n", "\n  ")))
+	}
+
+	chatReq, err := em.PromptBuilder.Build(
+		em.Role,
+		"PlanSprint",
+		em.Context.GetContext(),
+		sb.String(),
+		SprintPlan{},
+		em.ModelClient.GetTemperature(),
+		em.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sprint planning request: %w", err)
+	}
+	var plan SprintPlan
+	if err := em.ModelClient.ChatAdvancedParsed(chatReq, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse sprint plan: %w", err)
+	}
+
+	var selected []board.Card
+	var used float64
+	var lines []string
+	for _, item := range plan.Items {
+		card, ok := byID[item.ID]
+		if !ok {
+			continue // Unknown or duplicate ID.
+		}
+		delete(byID, item.ID)
+		points := cardPoints(card)
+		if used+points > capacity {
+			continue
+		}
+		if err := board.TransitionTicket(card, board.ListSprint, em.Name, "planned for the sprint"); err != nil {
+			return selected, fmt.Errorf("failed to move %q into the sprint: %w", card.GetName(), err)
+		}
+		used += points
+		selected = append(selected, card)
+		lines = append(lines, fmt.Sprintf("%d. %s (%s) — %s", len(selected), card.GetName(), formatPoints(points), item.Rationale))
+	}
+
+	summary := fmt.Sprintf("%s\n\nPlanned %s of %s story points:\n%s", plan.Summary, formatPoints(used), formatPoints(capacity), strings.Join(lines, "\n"))
+	planCard, err := em.BoardClient.CreateCard("Sprint plan "+time.Now().Format("2006-01-02"), "", board.ListSprint)
+	if err != nil {
+		fmt.Printf("Warning: failed to create sprint plan card: %v\n", err)
+		return selected, nil
+	}
+	if err := planCard.WriteComment(summary); err != nil {
+		fmt.Printf("Warning: failed to post sprint plan: %v\n", err)
+	}
+	return selected, nil
+}
+
+// cardPoints returns the card's estimate, or one point when it has none.
+func cardPoints(c board.Card) float64 {
+	if v, ok := board.CardEstimate(c); ok {
+		return v
+	}
+	return 1
+}
+
+// fieldTags renders the card's priority and component fields as " priority=high component=api".
+func fieldTags(c board.Card) string {
+	var tags string
+	for _, name := range []string{board.FieldPriority, board.FieldComponent} {
+		if v := board.Field(c, name); v != "" {
+			tags += fmt.Sprintf(" %s=%s", name, v)
+		}
+	}
+	return tags
+}
+
+func formatPoints(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
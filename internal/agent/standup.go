@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// standupLogDepth bounds how many recent commits are inspected for a standup.
+const standupLogDepth = 200
+
+// Standup summarizes what the agent did since the given time: its commits, the tickets it holds
+// and where they are, and tickets still waiting on an answer.
+func (a *BaseAgent) Standup(since time.Time) (string, error) {
+	var facts strings.Builder
+
+	if a.GitClient != nil {
+		commits, err := a.GitClient.Log("", standupLogDepth)
+		if err != nil {
+			fmt.Printf("Warning: failed to read commits for standup: %v\n", err)
+		}
+		facts.WriteString("Commits:\n")
+		for _, c := range commits {
+			if c.AuthorName == a.Name && c.When.After(since) {
+				facts.WriteString("- " + strings.SplitN(c.Message, "\n", 2)[0] + "\n")
+			}
+		}
+	}
+
+	cards, err := a.FindMyTickets()
+	if err != nil {
+		return "", fmt.Errorf("failed to find tickets: %w", err)
+	}
+	facts.WriteString("Tickets:\n")
+	var waiting []string
+	for _, c := range cards {
+		list := "unknown"
+		if l, err := c.GetList(); err == nil {
+			list = l.GetName()
+		}
+		facts.WriteString(fmt.Sprintf("- %s [%s]\n", c.GetName(), list))
+		if comments, err := c.ReadComments(); err == nil && len(comments) > 0 {
+			last := comments[len(comments)-1]
+			if strings.HasSuffix(strings.TrimSpace(last.Text), "?") {
+				waiting = append(waiting, c.GetName())
+			}
+		}
+	}
+	if len(waiting) > 0 {
+		facts.WriteString("Open questions on: " + strings.Join(waiting, ", ") + "\n")
+	}
+
+	prompt := fmt.Sprintf("Write your standup update for the last %s: what you did, what you are doing next and what blocks you.\n\n%s",
+		time.Since(since).Round(time.Hour), facts.String())
+	chatReq, err := a.PromptBuilder.Build(
+		a.Role,
+		"Standup",
+		a.Context.GetContext(),
+		prompt,
+		nil,
+		a.ModelClient.GetTemperature(),
+		a.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build standup request: %w", err)
+	}
+	update, err := a.ModelClient.ChatAdvanced(chatReq)
+	if err != nil {
+		// The raw facts are still a useful standup.
+		fmt.Printf("Warning: failed to summarize standup, posting raw activity: %v\n", err)
+		return facts.String(), nil
+	}
+	return update, nil
+}
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// DefaultDecomposeAttempts is how often a rejected decomposition is regenerated before giving up.
+const DefaultDecomposeAttempts = 3
+
+// ErrTicketRules is returned when generated tickets keep failing the ticket rules.
+var ErrTicketRules = errors.New("technical tickets failed the quality rules")
+
+// ticketRules returns the rules that apply to this Engineering Manager.
+func (em *EngineeringManagerAgent) ticketRules() config.TicketRules {
+	if em.Rules != nil {
+		return *em.Rules
+	}
+	if rules := config.GetTicketRules(); rules != nil {
+		return *rules
+	}
+	return config.TicketRules{}
+}
+
+// CheckTickets returns the problems that keep tickets from passing rules, or nil when they pass.
+func CheckTickets(tickets []TechnicalTicket, rules config.TicketRules) []string {
+	var problems []string
+	if rules.MinTickets > 0 && len(tickets) < rules.MinTickets {
+		problems = append(problems, fmt.Sprintf("expected at least %d tickets, got %d", rules.MinTickets, len(tickets)))
+	}
+	if rules.MaxTickets > 0 && len(tickets) > rules.MaxTickets {
+		problems = append(problems, fmt.Sprintf("expected at most %d tickets, got %d", rules.MaxTickets, len(tickets)))
+	}
+	seen := make(map[string]bool)
+	for i, t := range tickets {
+		title := strings.TrimSpace(t.Title)
+		switch {
+		case title == "":
+			problems = append(problems, fmt.Sprintf("ticket %d has no title", i+1))
+			continue
+		case rules.MinTitleLength > 0 && len([]rune(title)) < rules.MinTitleLength:
+			problems = append(problems, fmt.Sprintf("title %q is shorter than %d characters", title, rules.MinTitleLength))
+		case rules.MaxTitleLength > 0 && len([]rune(title)) > rules.MaxTitleLength:
+			problems = append(problems, fmt.Sprintf("title %q is longer than %d characters", title, rules.MaxTitleLength))
+		}
+		key := strings.ToLower(title)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("title %q is used more than once", title))
+		}
+		seen[key] = true
+		for _, section := range rules.RequiredSections {
+			if !hasSection(t.Description, section) {
+				problems = append(problems, fmt.Sprintf("ticket %q lacks a %q section", title, section))
+			}
+		}
+	}
+	return append(problems, dependencyProblems(tickets)...)
+}
+
+// dependencyProblems reports dependencies on tickets outside the plan and dependency cycles.
+func dependencyProblems(tickets []TechnicalTicket) []string {
+	var problems []string
+	index := make(map[string]int, len(tickets))
+	for i, t := range tickets {
+		index[strings.ToLower(strings.TrimSpace(t.Title))] = i
+	}
+	deps := make([][]int, len(tickets))
+	for i, t := range tickets {
+		for _, title := range t.DependsOn {
+			j, ok := index[strings.ToLower(strings.TrimSpace(title))]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("ticket %q depends on unknown ticket %q", t.Title, title))
+				continue
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+	// Depth-first search; state 1 is on the current path, 2 is finished.
+	state := make([]int, len(tickets))
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		state[i] = 1
+		for _, j := range deps[i] {
+			if state[j] == 1 || (state[j] == 0 && visit(j)) {
+				return true
+			}
+		}
+		state[i] = 2
+		return false
+	}
+	for i := range tickets {
+		if state[i] == 0 && visit(i) {
+			problems = append(problems, fmt.Sprintf("dependencies of ticket %q form a cycle", tickets[i].Title))
+			break
+		}
+	}
+	return problems
+}
+
+// hasSection reports whether description has a line naming the section, as a markdown heading,
+// a bold label or a "Name:" prefix.
+func hasSection(description, section string) bool {
+	for _, line := range strings.Split(description, "\n") {
+		if _, ok := sectionLine(line, section); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionLine reports whether line opens the section and returns any text following the label.
+func sectionLine(line, section string) (string, bool) {
+	line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#*_ "))
+	if len(line) < len(section) || !strings.EqualFold(line[:len(section)], section) {
+		return "", false
+	}
+	rest := strings.TrimLeft(line[len(section):], "*_ ")
+	if rest == "" {
+		return "", true
+	}
+	if strings.HasPrefix(rest, ":") {
+		return strings.TrimSpace(strings.TrimLeft(rest[1:], "*_ ")), true
+	}
+	return "", false
+}
@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/guard"
+)
+
+// DefaultSplitDepth is how often an oversized ticket is split again when its parts are still too large.
+const DefaultSplitDepth = 3
+
+// splitOversized replaces every ticket estimated above rules.MaxStoryPoints with smaller tickets
+// from the model, recursing until all parts fit or the split depth is exhausted. format is appended
+// to every split request. Dependencies on a split ticket are moved to all of its parts.
+func (em *EngineeringManagerAgent) splitOversized(tickets []TechnicalTicket, rules config.TicketRules, format string) []TechnicalTicket {
+	if rules.MaxStoryPoints <= 0 {
+		return tickets
+	}
+	depth := rules.SplitDepth
+	if depth <= 0 {
+		depth = DefaultSplitDepth
+	}
+	return em.splitTickets(tickets, rules.MaxStoryPoints, depth, format)
+}
+
+func (em *EngineeringManagerAgent) splitTickets(tickets []TechnicalTicket, limit float64, depth int, format string) []TechnicalTicket {
+	var result []TechnicalTicket
+	replaced := make(map[string][]string)
+	for _, t := range tickets {
+		if t.StoryPoints <= limit {
+			result = append(result, t)
+			continue
+		}
+		if depth <= 0 {
+			fmt.Printf("Warning: %q is still estimated at %s story points; creating it as is\n", t.Title, formatPoints(t.StoryPoints))
+			result = append(result, t)
+			continue
+		}
+		parts, err := em.splitTicket(t, limit, format)
+		if err != nil || len(parts) < 2 {
+			if err == nil {
+				err = fmt.Errorf("got %d part(s)", len(parts))
+			}
+			fmt.Printf("Warning: failed to split %q: %v\n", t.Title, err)
+			result = append(result, t)
+			continue
+		}
+		parts = em.splitTickets(parts, limit, depth-1, format)
+		titles := make([]string, len(parts))
+		for i := range parts {
+			titles[i] = parts[i].Title
+		}
+		replaced[strings.ToLower(strings.TrimSpace(t.Title))] = titles
+		result = append(result, parts...)
+	}
+	if len(replaced) == 0 {
+		return result
+	}
+	for i := range result {
+		var deps []string
+		for _, title := range result[i].DependsOn {
+			if titles, ok := replaced[strings.ToLower(strings.TrimSpace(title))]; ok {
+				deps = append(deps, titles...)
+			} else {
+				deps = append(deps, title)
+			}
+		}
+		result[i].DependsOn = deps
+	}
+	return result
+}
+
+// splitTicket asks the model to split one ticket into tickets of at most limit story points.
+// The parts inherit the ticket's routing metadata and dependencies.
+func (em *EngineeringManagerAgent) splitTicket(t TechnicalTicket, limit float64, format string) ([]TechnicalTicket, error) {
+	prompt := guard.Wrap("the ticket", fmt.Sprintf("Ticket: %s\n\n%s", t.Title, t.Description))
+	prompt += fmt.Sprintf("\n\nThis technical ticket is estimated at %s story points, more than the limit of %s. "+
+		"Split it into smaller technical tickets estimated at no more than %s story points each.",
+		formatPoints(t.StoryPoints), formatPoints(limit), formatPoints(limit))
+	prompt += format
+	parts, err := em.requestTickets(prompt)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parts {
+		p := &parts[i]
+		if p.Repo == "" {
+			p.Repo = t.Repo
+		}
+		if p.Component == "" {
+			p.Component = t.Component
+		}
+		if p.Priority == "" {
+			p.Priority = t.Priority
+		}
+		p.DependsOn = append(p.DependsOn, t.DependsOn...)
+	}
+	return parts, nil
+}
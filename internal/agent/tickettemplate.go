@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// missingSection is written under a template section the generated description does not cover.
+const missingSection = "_Not specified._"
+
+// TicketData is what a ticket template's footer can refer to, e.g. "{{.Ticket.StoryPoints}}" or "{{.Parent.URL}}".
+type TicketData struct {
+	Ticket    TechnicalTicket
+	Parent    struct{ Name, URL string }
+	Agent     string
+	Model     string
+	Generated time.Time
+}
+
+// ticketTemplate returns the template that applies to this Engineering Manager, or nil.
+func (em *EngineeringManagerAgent) ticketTemplate() *config.TicketTemplate {
+	if em.Template != nil {
+		return em.Template
+	}
+	return config.GetTicketTemplate()
+}
+
+// ticketBody renders the description of a technical ticket created from parent, followed by the
+// decision line when the ticket implements an architecture decision, and ending in the parent link.
+// Without a template the generated description is used as is.
+func (em *EngineeringManagerAgent) ticketBody(t TechnicalTicket, parent board.Card, decision string) string {
+	body := t.Description
+	if tmpl := em.ticketTemplate(); tmpl != nil {
+		data := TicketData{Ticket: t, Agent: em.Name, Model: em.ModelClient.GetModel(), Generated: time.Now()}
+		data.Parent.Name, data.Parent.URL = parent.GetName(), parent.GetURL()
+		rendered, err := RenderTicket(*tmpl, data)
+		if err != nil {
+			fmt.Printf("Warning: failed to render ticket template for %q: %v\n", t.Title, err)
+		} else {
+			body = rendered
+		}
+	}
+	if decision != "" {
+		body = strings.TrimRight(body, "\n") + "\n\n" + decision
+	}
+	return board.WithParentLink(body, parent)
+}
+
+// RenderTicket renders a ticket description: the template's sections in order, each filled from the
+// matching section of the generated description, then any text outside them, then the footer.
+func RenderTicket(tmpl config.TicketTemplate, data TicketData) (string, error) {
+	var sb strings.Builder
+	if len(tmpl.Sections) == 0 {
+		sb.WriteString(strings.TrimSpace(data.Ticket.Description))
+	} else {
+		other, sections := splitSections(data.Ticket.Description, tmpl.Sections)
+		for i, name := range tmpl.Sections {
+			if i > 0 {
+				sb.WriteString("\n\n")
+			}
+			content := sections[name]
+			if content == "" {
+				content = missingSection
+			}
+			fmt.Fprintf(&sb, "## %s\n%s", name, content)
+		}
+		if other != "" {
+			fmt.Fprintf(&sb, "\n\n%s", other)
+		}
+	}
+	if strings.TrimSpace(tmpl.Footer) != "" {
+		footer, err := template.New("footer").Parse(tmpl.Footer)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse ticket footer: %w", err)
+		}
+		var fb strings.Builder
+		if err := footer.Execute(&fb, data); err != nil {
+			return "", fmt.Errorf("failed to render ticket footer: %w", err)
+		}
+		fmt.Fprintf(&sb, "\n\n%s", strings.TrimSpace(fb.String()))
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// splitSections cuts description at the lines opening one of names. It returns the text before the
+// first of them and each section's content.
+func splitSections(description string, names []string) (string, map[string]string) {
+	sections := make(map[string][]string)
+	var other []string
+	current := ""
+	for _, line := range strings.Split(description, "\n") {
+		opened := false
+		for _, name := range names {
+			if rest, ok := sectionLine(line, name); ok {
+				current, opened = name, true
+				if rest != "" {
+					sections[name] = append(sections[name], rest)
+				}
+				break
+			}
+		}
+		if opened {
+			continue
+		}
+		if current == "" {
+			other = append(other, line)
+		} else {
+			sections[current] = append(sections[current], line)
+		}
+	}
+	result := make(map[string]string, len(sections))
+	for name, lines := range sections {
+		result[name] = strings.TrimSpace(strings.Join(lines, "\n"))
+	}
+	return strings.TrimSpace(strings.Join(other, "\n")), result
+}
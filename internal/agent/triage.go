@@ -0,0 +1,219 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/guard"
+	"github.com/egobogo/aiagents/internal/intake/logs"
+)
+
+// DefaultMinOccurrences is how often an error must recur before the Triage agent files a ticket for it.
+const DefaultMinOccurrences = 3
+
+// DefaultCommitWindow is how many recent commits the Triage agent correlates errors with.
+const DefaultCommitWindow = 20
+
+// errorSignaturePrefix marks the description line naming the error a bug ticket was filed for.
+const errorSignaturePrefix = "Error signature: "
+
+// BugReport is the model's triage of one recurring error.
+type BugReport struct {
+	Signature        string   `json:"signature"` // Signature of the error cluster the report is about.
+	Title            string   `json:"title"`
+	Priority         string   `json:"priority"` // "high", "medium" or "low".
+	Summary          string   `json:"summary"`
+	SuspectedCommits []string `json:"suspectedCommits,omitempty"` // Short hashes of commits that likely introduced the error.
+	Reproduction     string   `json:"reproduction"`               // Hints on how to reproduce the error.
+}
+
+// BugTriage is the model's answer for a batch of recurring errors.
+type BugTriage struct {
+	Bugs []BugReport `json:"bugs"`
+}
+
+// priorityRank orders priorities for filing, highest first.
+var priorityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// TriageAgent reads application logs, clusters recurring errors, correlates them with recent
+// commits and files prioritized bug tickets.
+type TriageAgent struct {
+	*BaseAgent
+	Sources        []logs.Source
+	MinOccurrences int    // Zero uses DefaultMinOccurrences.
+	CommitWindow   int    // Zero uses DefaultCommitWindow.
+	BugList        string // List bug tickets are created in.
+
+	clusters logs.Clusters
+	filed    map[string]bool // Signatures with a ticket.
+}
+
+// NewTriageAgent creates a new TriageAgent using the provided BaseAgent.
+func NewTriageAgent(base *BaseAgent) *TriageAgent {
+	return &TriageAgent{
+		BaseAgent: base,
+		BugList:   board.ListBugs,
+		clusters:  make(logs.Clusters),
+		filed:     make(map[string]bool),
+	}
+}
+
+// createContext is a no-op; triage is built from logs and the commit history only.
+func (t *TriageAgent) createContext() error {
+	return nil
+}
+
+// Act reads new log entries from every source and files tickets for errors that now recur often enough.
+func (t *TriageAgent) Act() error {
+	for _, s := range t.Sources {
+		entries, err := s.Read()
+		if err != nil {
+			fmt.Printf("Warning: failed to read logs: %v\n", err)
+		}
+		t.clusters.Add(entries)
+	}
+	_, err := t.Triage()
+	return err
+}
+
+// Ingest clusters entries as if a source had returned them.
+func (t *TriageAgent) Ingest(entries []logs.Entry) {
+	t.clusters.Add(entries)
+}
+
+// markFiled records the signatures of the bug tickets already on the board.
+func (t *TriageAgent) markFiled() error {
+	cards, err := t.BoardClient.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, c := range cards {
+		for _, line := range strings.Split(c.GetDescription(), "\n") {
+			if sig, ok := strings.CutPrefix(strings.TrimSpace(line), errorSignaturePrefix); ok {
+				t.filed[strings.TrimSpace(sig)] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Triage asks the model to triage the recurring errors without a ticket and files a bug ticket
+// for each, highest priority first.
+func (t *TriageAgent) Triage() ([]board.Card, error) {
+	min := t.MinOccurrences
+	if min <= 0 {
+		min = DefaultMinOccurrences
+	}
+	if err := t.markFiled(); err != nil {
+		return nil, err
+	}
+	var pending []*logs.Cluster
+	for _, cl := range t.clusters.Recurring(min) {
+		if !t.filed[cl.Signature] {
+			pending = append(pending, cl)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	window := t.CommitWindow
+	if window <= 0 {
+		window = DefaultCommitWindow
+	}
+	var history strings.Builder
+	if commits, err := t.GitClient.Log("", window); err != nil {
+		fmt.Printf("Warning: failed to read recent commits: %v\n", err)
+	} else {
+		for _, c := range commits {
+			subject, _, _ := strings.Cut(c.Message, "\n")
+			history.WriteString(fmt.Sprintf("- %s %s %s: %s\n", c.Hash[:7], c.When.Format(time.RFC3339), c.AuthorName, subject))
+		}
+	}
+	byID := make(map[string]*logs.Cluster, len(pending))
+	var errs strings.Builder
+	for _, cl := range pending {
+		byID[cl.Signature] = cl
+		errs.WriteString(fmt.Sprintf("Signature %s: %d occurrence(s) at level %s between %s and %s\n",
+			cl.Signature, cl.Count, cl.Level, cl.First.Format(time.RFC3339), cl.Last.Format(time.RFC3339)))
+		for _, s := range cl.Samples {
+			errs.WriteString("  > " + s + "\n")
+		}
+	}
+	prompt := fmt.Sprintf(
+		"Triage these recurring errors from the application logs. For each signature, write a bug ticket title and "+
+			"summary, rate its priority (high, medium or low) by frequency and impact, name the recent commits that "+
+			"likely introduced it, and give hints on how to reproduce it.\n\nErrors:\n%s\nRecent commits:\n%s",
+		guard.Wrap("the application logs", errs.String()), history.String(),
+	)
+	chatReq, err := t.PromptBuilder.Build(
+		t.Role,
+		"TriageErrors",
+		t.Context.GetContext(),
+		prompt,
+		BugTriage{},
+		t.ModelClient.GetTemperature(),
+		t.ModelClient.GetModel(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build triage request: %w", err)
+	}
+	var triage BugTriage
+	if err := t.ModelClient.ChatAdvancedParsed(chatReq, &triage); err != nil {
+		return nil, fmt.Errorf("failed to parse triage: %w", err)
+	}
+
+	bugs := triage.Bugs
+	sort.SliceStable(bugs, func(i, j int) bool {
+		return rank(bugs[i].Priority) < rank(bugs[j].Priority)
+	})
+	var created []board.Card
+	for _, bug := range bugs {
+		cl, ok := byID[bug.Signature]
+		if !ok || t.filed[bug.Signature] {
+			continue // Unknown or repeated signature.
+		}
+		card, err := t.BoardClient.CreateCard("[bug] "+bug.Title, formatBugReport(bug, cl), t.BugList)
+		if err != nil {
+			return created, fmt.Errorf("failed to create bug ticket %q: %w", bug.Title, err)
+		}
+		if bug.Priority != "" {
+			if _, err := board.SetField(card, board.FieldPriority, bug.Priority); err != nil {
+				fmt.Printf("Warning: failed to set priority on %q: %v\n", bug.Title, err)
+			}
+		}
+		t.filed[bug.Signature] = true
+		created = append(created, card)
+	}
+	return created, nil
+}
+
+// rank returns the filing order of a priority; unknown priorities come last.
+func rank(priority string) int {
+	if r, ok := priorityRank[strings.ToLower(priority)]; ok {
+		return r
+	}
+	return len(priorityRank)
+}
+
+// formatBugReport writes the description of a bug ticket.
+func formatBugReport(bug BugReport, cl *logs.Cluster) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(bug.Summary) + "\n\n")
+	if bug.Priority != "" {
+		sb.WriteString(fmt.Sprintf("Priority: %s\n", bug.Priority))
+	}
+	sb.WriteString(fmt.Sprintf("Occurrences: %d between %s and %s\n", cl.Count, cl.First.Format(time.RFC3339), cl.Last.Format(time.RFC3339)))
+	sb.WriteString("\nLog samples:\n" + board.CodeBlock(strings.Join(cl.Samples, "\n"), "") + "\n")
+	if len(bug.SuspectedCommits) > 0 {
+		sb.WriteString("\nSuspected commits: " + strings.Join(bug.SuspectedCommits, ", ") + "\n")
+	}
+	if r := strings.TrimSpace(bug.Reproduction); r != "" {
+		sb.WriteString("\nReproduction hints:\n" + r + "\n")
+	}
+	sb.WriteString("\n---\n" + errorSignaturePrefix + cl.Signature)
+	return sb.String()
+}
@@ -0,0 +1,229 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+)
+
+// Defaults for WaitForReply.
+const (
+	DefaultReplyPolls        = 100
+	DefaultReplyPollInterval = 30 * time.Second
+)
+
+// ErrNoReply is returned by WaitForReply when nobody answered within the allowed number of polls.
+var ErrNoReply = errors.New("no reply received")
+
+// ProgressTracker receives progress signals from agents so stuck tickets can be detected.
+type ProgressTracker interface {
+	// Track records that agent entered phase on the ticket.
+	Track(ticketID, agent, phase string)
+	// Poll records one unsuccessful poll while waiting on the ticket.
+	Poll(ticketID string)
+	// Done records that the ticket no longer needs supervision.
+	Done(ticketID string)
+}
+
+// Trackers passes every progress signal on to each of its trackers, e.g. the supervisor and the
+// chat ticket updates.
+type Trackers []ProgressTracker
+
+// Track implements ProgressTracker.
+func (ts Trackers) Track(ticketID, agent, phase string) {
+	for _, t := range ts {
+		t.Track(ticketID, agent, phase)
+	}
+}
+
+// Poll implements ProgressTracker.
+func (ts Trackers) Poll(ticketID string) {
+	for _, t := range ts {
+		t.Poll(ticketID)
+	}
+}
+
+// Done implements ProgressTracker.
+func (ts Trackers) Done(ticketID string) {
+	for _, t := range ts {
+		t.Done(ticketID)
+	}
+}
+
+// WaitForReply polls the card until someone other than the agent adds a comment and returns that comment.
+// It gives up after DefaultReplyPolls polls spaced ReplyInterval apart, runs the
+// escalation chain on the card and returns ErrNoReply.
+func (a *BaseAgent) WaitForReply(card board.Card) (board.Comment, error) {
+	return a.waitForReply(card, nil, DefaultReplyPolls, a.replyInterval())
+}
+
+// WaitForReplyMatching is WaitForReply for the first new comment match accepts, e.g. an approval
+// keyword or a command. Other new comments are skipped. A nil match accepts every reply.
+func (a *BaseAgent) WaitForReplyMatching(card board.Card, match ReplyMatcher) (board.Comment, error) {
+	return a.waitForReply(card, match, DefaultReplyPolls, a.replyInterval())
+}
+
+// replyInterval returns the time between polls while waiting for a reply.
+func (a *BaseAgent) replyInterval() time.Duration {
+	if a.ReplyInterval > 0 {
+		return a.ReplyInterval
+	}
+	return DefaultReplyPollInterval
+}
+
+func (a *BaseAgent) waitForReply(card board.Card, match ReplyMatcher, polls int, interval time.Duration) (board.Comment, error) {
+	seen, err := commentCounts(card)
+	if err != nil {
+		return board.Comment{}, err
+	}
+	return a.waitForReplySince(card, seen, match, polls, interval)
+}
+
+// commentCounts counts the card's comment texts.
+// Boards disagree on comment order, so new comments are found by counting texts rather than by index.
+func commentCounts(card board.Card) (map[string]int, error) {
+	existing, err := card.ReadComments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+	seen := make(map[string]int)
+	for _, c := range existing {
+		seen[c.Text]++
+	}
+	return seen, nil
+}
+
+// trusts reports whether c may answer the agent: it is not the agent's own comment and, with
+// TrustedMembers set, its author is one of them. Comments without a known author are only trusted
+// when no list is configured.
+func (a *BaseAgent) trusts(c board.Comment) bool {
+	if c.Member != nil && c.Member.Name == a.Name {
+		return false
+	}
+	if len(a.TrustedMembers) == 0 {
+		return true
+	}
+	return c.Member != nil && slices.Contains(a.TrustedMembers, c.Member.ID)
+}
+
+// commentAuthor names the member who wrote c, or "unknown" when the board does not say.
+func commentAuthor(c board.Comment) string {
+	if c.Member == nil {
+		return "unknown"
+	}
+	if c.Member.ID != "" {
+		return c.Member.ID
+	}
+	return c.Member.Name
+}
+
+// waitForReplySince polls until a comment by a trusted member that match accepts appears beyond the
+// counts in seen. A reply already present is returned after the first poll. Once the board reports
+// creation times, later polls only fetch the comments added since the newest one read. Comments by
+// untrusted members are ignored, so nobody can answer in the approver's place. The Tracker is told
+// when the wait ends, answered or not, so the Supervisor stops counting the ticket as waiting.
+func (a *BaseAgent) waitForReplySince(card board.Card, seen map[string]int, match ReplyMatcher, polls int, interval time.Duration) (board.Comment, error) {
+	if a.Tracker != nil {
+		a.Tracker.Track(card.GetID(), a.Name, "WaitForReply")
+		defer a.Tracker.Done(card.GetID())
+	}
+
+	counts := make(map[string]int)
+	ignored := make(map[string]bool)
+	var since time.Time
+	for i := 0; i < polls; i++ {
+		time.Sleep(interval)
+		comments, err := board.ReadCommentsSince(card, since)
+		if err != nil {
+			fmt.Printf("Warning: failed to read comments on %q: %v\n", card.GetName(), err)
+			continue
+		}
+		if since.IsZero() {
+			counts = make(map[string]int) // A full read; count from scratch.
+		}
+		for _, c := range comments {
+			if c.Created.After(since) {
+				since = c.Created
+			}
+		}
+		for _, c := range comments {
+			counts[c.Text]++
+			if counts[c.Text] <= seen[c.Text] {
+				continue
+			}
+			if a.trusts(c) {
+				if match == nil || match(c) {
+					return c, nil
+				}
+				continue
+			}
+			if own := c.Member != nil && c.Member.Name == a.Name; !own && !ignored[c.Text] {
+				ignored[c.Text] = true
+				fmt.Printf("Warning: ignoring reply on %q from untrusted member %s\n", card.GetName(), commentAuthor(c))
+			}
+		}
+		if a.Tracker != nil {
+			a.Tracker.Poll(card.GetID())
+		}
+	}
+	if err := a.Escalate(card); err != nil {
+		fmt.Printf("Warning: escalation for %q incomplete: %v\n", card.GetName(), err)
+	}
+	return board.Comment{}, fmt.Errorf("%w on %q after %d polls", ErrNoReply, card.GetName(), polls)
+}
+
+// Ask posts question on the card and waits for the answer.
+// On boards written in another language the question is posted translated and the answer read in English.
+// With Checkpoints set, the posted question and its answer are saved, so after a restart
+// ResumeQuestions picks up the wait instead of asking again.
+func (a *BaseAgent) Ask(card board.Card, question string) (string, error) {
+	return a.ask(card, question, DefaultReplyPolls, a.replyInterval())
+}
+
+func (a *BaseAgent) ask(card board.Card, question string, polls int, interval time.Duration) (string, error) {
+	if err := board.WriteQuestion(card, question); err != nil {
+		return "", fmt.Errorf("failed to post question: %w", err)
+	}
+	seen, err := commentCounts(card)
+	if err != nil {
+		return "", err
+	}
+	cp := a.LoadCheckpoint(card.GetID())
+	cp.Phase, cp.Pending, cp.Seen = checkpoint.PhaseQuestionPosted, question, seen
+	a.SaveCheckpoint(cp)
+	return a.awaitAnswer(card, cp, polls, interval)
+}
+
+// awaitAnswer waits for the reply to the checkpoint's pending question and records the exchange.
+func (a *BaseAgent) awaitAnswer(card board.Card, cp *checkpoint.Checkpoint, polls int, interval time.Duration) (string, error) {
+	reply, err := a.waitForReplySince(card, cp.Seen, nil, polls, interval)
+	if err != nil {
+		return "", err
+	}
+	cp.Exchanges = append(cp.Exchanges, checkpoint.Exchange{Question: cp.Pending, Answer: reply.Text})
+	cp.Phase, cp.Pending, cp.Seen = checkpoint.PhaseReplyReceived, "", nil
+	a.SaveCheckpoint(cp)
+	return reply.Text, nil
+}
+
+// ResumeQuestions returns the clarification exchanges already completed on the card before a restart.
+// A question that was posted but not yet answered is waited for rather than asked again.
+// Without Checkpoints it returns nothing.
+func (a *BaseAgent) ResumeQuestions(card board.Card) ([]checkpoint.Exchange, error) {
+	return a.resumeQuestions(card, DefaultReplyPolls, a.replyInterval())
+}
+
+func (a *BaseAgent) resumeQuestions(card board.Card, polls int, interval time.Duration) ([]checkpoint.Exchange, error) {
+	cp := a.LoadCheckpoint(card.GetID())
+	if cp.Pending != "" {
+		fmt.Printf("Resuming %q: waiting for the answer to an earlier question\n", card.GetName())
+		if _, err := a.awaitAnswer(card, cp, polls, interval); err != nil {
+			return nil, err
+		}
+	}
+	return cp.Exchanges, nil
+}
@@ -0,0 +1,24 @@
+package board
+
+import "errors"
+
+// ErrNotDownloadable is returned by ReadAttachment when the card cannot download the attachment
+// itself; callers should use the attachment's URL instead.
+var ErrNotDownloadable = errors.New("attachment cannot be downloaded through the board")
+
+// CardAttachmentReader is implemented by cards whose attachments can only be downloaded with the
+// board's credentials, e.g. files uploaded to Trello.
+type CardAttachmentReader interface {
+	// ReadAttachment downloads the content of one of the card's attachments.
+	ReadAttachment(a Attachment) ([]byte, error)
+}
+
+// ReadAttachment downloads an attachment through the card when it supports that, and returns
+// ErrNotDownloadable otherwise. Card wrappers forward to it so the wrapped card's support shows through.
+func ReadAttachment(c Card, a Attachment) ([]byte, error) {
+	r, ok := c.(CardAttachmentReader)
+	if !ok {
+		return nil, ErrNotDownloadable
+	}
+	return r.ReadAttachment(a)
+}
@@ -1,15 +1,19 @@
 package board
 
+import "time"
+
 // Member represents a board member.
 type Member struct {
-	ID   string
-	Name string
+	ID       string
+	Name     string
+	Username string // Login handle, when the board has one besides the display name.
 }
 
 // Comment represents a comment on a card.
 type Comment struct {
-	Text   string
-	Member *Member
+	Text    string
+	Member  *Member
+	Created time.Time // Zero when the board does not report it.
 }
 
 // Attachment represents an attachment on a card.
@@ -21,10 +25,16 @@ type Attachment struct {
 
 // Card defines the operations available on a card.
 type Card interface {
+	// GetID returns the unique identifier of the card.
+	GetID() string
 	// GetName returns the name of the card.
 	GetName() string
 	// ChangeName sets a new name for the card.
 	ChangeName(newName string) error
+	// GetDescription returns the description of the card.
+	GetDescription() string
+	// ChangeDescription sets a new description for the card.
+	ChangeDescription(newDescription string) error
 	// GetURL returns the URL of the card on the board.
 	GetURL() string
 	// GetList returns the current list (column) that the card is in.
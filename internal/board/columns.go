@@ -0,0 +1,163 @@
+package board
+
+import (
+	"strings"
+	"time"
+)
+
+// columnBoard translates the standard list names used by agents to a board's own column names.
+type columnBoard struct {
+	BoardClient
+	columns map[string]string // standard list → board column
+	reverse map[string]string // lower-cased board column → standard list
+}
+
+// WithColumns returns a BoardClient on which agents keep using the standard list names
+// (ListBacklog, ListDoing, ...) while the board itself uses the mapped column names,
+// e.g. {"Doing": "In Progress"}. Lists without a mapping keep their name.
+func WithColumns(b BoardClient, columns map[string]string) BoardClient {
+	if len(columns) == 0 {
+		return b
+	}
+	cb := &columnBoard{BoardClient: b, columns: columns, reverse: make(map[string]string)}
+	for list, column := range columns {
+		cb.reverse[strings.ToLower(column)] = list
+	}
+	return cb
+}
+
+func (b *columnBoard) toColumn(list string) string {
+	for l, column := range b.columns {
+		if strings.EqualFold(l, list) {
+			return column
+		}
+	}
+	return list
+}
+
+func (b *columnBoard) toList(column string) string {
+	if list, ok := b.reverse[strings.ToLower(column)]; ok {
+		return list
+	}
+	return column
+}
+
+func (b *columnBoard) wrapAll(cards []Card, err error) ([]Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = &columnCard{Card: c, board: b}
+	}
+	return wrapped, nil
+}
+
+func (b *columnBoard) GetCards() ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *columnBoard) GetCardsAssignedTo(userName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *columnBoard) GetCardsFromList(listName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(b.toColumn(listName)))
+}
+
+func (b *columnBoard) CreateCard(name, description, listName string) (Card, error) {
+	c, err := b.BoardClient.CreateCard(name, description, b.toColumn(listName))
+	if c == nil {
+		return nil, err
+	}
+	return &columnCard{Card: c, board: b}, err
+}
+
+func (b *columnBoard) GetLists() ([]List, error) {
+	lists, err := b.BoardClient.GetLists()
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]List, len(lists))
+	for i, l := range lists {
+		mapped[i] = &columnList{List: l, name: b.toList(l.GetName())}
+	}
+	return mapped, nil
+}
+
+// columnList reports the standard name of a mapped column.
+type columnList struct {
+	List
+	name string
+}
+
+func (l *columnList) GetName() string { return l.name }
+
+// columnCard translates list names on card operations.
+type columnCard struct {
+	Card
+	board *columnBoard
+}
+
+func (c *columnCard) GetList() (List, error) {
+	l, err := c.Card.GetList()
+	if err != nil || l == nil {
+		return l, err
+	}
+	return &columnList{List: l, name: c.board.toList(l.GetName())}, nil
+}
+
+func (c *columnCard) Move(newListName string) error {
+	return c.Card.Move(c.board.toColumn(newListName))
+}
+
+// GetMoves forwards to the wrapped card's history when it has one, with standard list names.
+func (c *columnCard) GetMoves() ([]Move, error) {
+	h, ok := c.Card.(CardHistory)
+	if !ok {
+		return nil, nil
+	}
+	moves, err := h.GetMoves()
+	for i := range moves {
+		if moves[i].From != "" {
+			moves[i].From = c.board.toList(moves[i].From)
+		}
+		moves[i].To = c.board.toList(moves[i].To)
+	}
+	return moves, err
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *columnCard) ReadAttachment(a Attachment) ([]byte, error) {
+	return ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *columnCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *columnCard) SetField(name, value string) error {
+	f, ok := c.Card.(CardFields)
+	if !ok {
+		return ErrNoField
+	}
+	return f.SetField(name, value)
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *columnCard) ReadCommentsSince(since time.Time) ([]Comment, error) {
+	return ReadCommentsSince(c.Card, since)
+}
+
+// MoveToBottom forwards to the wrapped card's ordering when it has one.
+func (c *columnCard) MoveToBottom() error {
+	o, ok := c.Card.(CardOrdering)
+	if !ok {
+		return ErrNoOrdering
+	}
+	return o.MoveToBottom()
+}
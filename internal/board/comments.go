@@ -0,0 +1,30 @@
+package board
+
+import "time"
+
+// CardCommentFeed is implemented by cards that can fetch only the comments created after a time,
+// sparing a full read of long discussions on every poll.
+type CardCommentFeed interface {
+	// ReadCommentsSince returns the comments created after since; a zero since returns all of them.
+	ReadCommentsSince(since time.Time) ([]Comment, error)
+}
+
+// ReadCommentsSince returns the card's comments created after since, using the card's feed when it
+// has one. Otherwise all comments are read and those with a known creation time at or before since
+// are dropped.
+func ReadCommentsSince(c Card, since time.Time) ([]Comment, error) {
+	if f, ok := c.(CardCommentFeed); ok {
+		return f.ReadCommentsSince(since)
+	}
+	comments, err := c.ReadComments()
+	if err != nil || since.IsZero() {
+		return comments, err
+	}
+	var result []Comment
+	for _, cm := range comments {
+		if cm.Created.IsZero() || cm.Created.After(since) {
+			result = append(result, cm)
+		}
+	}
+	return result, nil
+}
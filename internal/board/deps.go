@@ -0,0 +1,68 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dependsOnPrefix marks a description line naming a card that must be done first.
+const dependsOnPrefix = "Depends on: "
+
+// Dependencies returns the URLs of the cards a description depends on.
+func Dependencies(description string) []string {
+	var urls []string
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, dependsOnPrefix) {
+			urls = append(urls, strings.TrimSpace(strings.TrimPrefix(line, dependsOnPrefix)))
+		}
+	}
+	return urls
+}
+
+// AddDependency records that card can only start once dep is done.
+// The description line is used for lookups; the attachment makes the link clickable on the board.
+func AddDependency(card, dep Card) error {
+	for _, url := range Dependencies(card.GetDescription()) {
+		if url == dep.GetURL() {
+			return nil
+		}
+	}
+	line := dependsOnPrefix + dep.GetURL()
+	description := strings.TrimRight(card.GetDescription(), "\n")
+	if description != "" {
+		description += "\n"
+	}
+	if err := card.ChangeDescription(description + line); err != nil {
+		return fmt.Errorf("failed to write dependency: %w", err)
+	}
+	if err := card.AddAttachment(Attachment{Name: "Depends on: " + dep.GetName(), URL: dep.GetURL()}); err != nil {
+		return fmt.Errorf("failed to attach dependency: %w", err)
+	}
+	return nil
+}
+
+// UnmetDependencies returns the cards among cards that c depends on and that are not in doneList.
+// Dependencies on cards that are no longer on the board count as met.
+func UnmetDependencies(cards []Card, c Card, doneList string) []Card {
+	urls := Dependencies(c.GetDescription())
+	if len(urls) == 0 {
+		return nil
+	}
+	byURL := make(map[string]Card, len(cards))
+	for _, other := range cards {
+		byURL[other.GetURL()] = other
+	}
+	var unmet []Card
+	for _, url := range urls {
+		dep, ok := byURL[url]
+		if !ok {
+			continue
+		}
+		if list, err := dep.GetList(); err == nil && strings.EqualFold(list.GetName(), doneList) {
+			continue
+		}
+		unmet = append(unmet, dep)
+	}
+	return unmet
+}
@@ -0,0 +1,168 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dryRunBoard prints write operations instead of performing them. Reads go to the real board.
+type dryRunBoard struct {
+	BoardClient
+	created int
+}
+
+// DryRun returns a BoardClient that prints every write instead of performing it.
+// Cards created in dry-run mode exist only in memory.
+func DryRun(b BoardClient) BoardClient {
+	return &dryRunBoard{BoardClient: b}
+}
+
+func dryRunPrint(format string, args ...interface{}) {
+	fmt.Printf("[dry-run] "+format+"\n", args...)
+}
+
+func (b *dryRunBoard) wrapAll(cards []Card, err error) ([]Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = &dryRunCard{Card: c}
+	}
+	return wrapped, nil
+}
+
+func (b *dryRunBoard) GetCards() ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *dryRunBoard) GetCardsAssignedTo(userName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *dryRunBoard) GetCardsFromList(listName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *dryRunBoard) CreateCard(name, description, listName string) (Card, error) {
+	b.created++
+	dryRunPrint("create card %q in %s:\n%s", name, listName, description)
+	return &dryRunCard{Card: &draftCard{id: fmt.Sprintf("dry-run-%d", b.created), name: name, description: description, list: listName}}, nil
+}
+
+// dryRunCard prints write operations on an existing card.
+type dryRunCard struct {
+	Card
+}
+
+func (c *dryRunCard) ChangeName(newName string) error {
+	dryRunPrint("rename %q to %q", c.GetName(), newName)
+	return nil
+}
+
+func (c *dryRunCard) ChangeDescription(newDescription string) error {
+	dryRunPrint("update description of %q:\n%s", c.GetName(), newDescription)
+	return nil
+}
+
+func (c *dryRunCard) Move(newListName string) error {
+	dryRunPrint("move %q to %s", c.GetName(), newListName)
+	return nil
+}
+
+func (c *dryRunCard) AssignTo(userName string) error {
+	dryRunPrint("assign %q to %s", c.GetName(), userName)
+	return nil
+}
+
+func (c *dryRunCard) UnassignFrom(userName string) error {
+	dryRunPrint("unassign %s from %q", userName, c.GetName())
+	return nil
+}
+
+func (c *dryRunCard) WriteComment(comment string) error {
+	dryRunPrint("comment on %q:\n%s", c.GetName(), comment)
+	return nil
+}
+
+func (c *dryRunCard) WriteQuestion(question string) error {
+	dryRunPrint("ask on %q:\n%s", c.GetName(), question)
+	return nil
+}
+
+func (c *dryRunCard) AddAttachment(attachment Attachment) error {
+	dryRunPrint("attach %s (%s) to %q", attachment.Name, attachment.URL, c.GetName())
+	return nil
+}
+
+// draftCard is an in-memory card returned by CreateCard in dry-run mode.
+type draftCard struct {
+	id, name, description, list string
+}
+
+func (c *draftCard) GetID() string                             { return c.id }
+func (c *draftCard) GetName() string                           { return c.name }
+func (c *draftCard) ChangeName(newName string) error           { c.name = newName; return nil }
+func (c *draftCard) GetDescription() string                    { return c.description }
+func (c *draftCard) ChangeDescription(d string) error          { c.description = d; return nil }
+func (c *draftCard) GetURL() string                            { return "dry-run://" + strings.ToLower(c.id) }
+func (c *draftCard) GetList() (List, error)                    { return draftList(c.list), nil }
+func (c *draftCard) Move(newListName string) error             { c.list = newListName; return nil }
+func (c *draftCard) GetAssignedMembers() ([]Member, error)     { return nil, nil }
+func (c *draftCard) AssignTo(userName string) error            { return nil }
+func (c *draftCard) UnassignFrom(userName string) error        { return nil }
+func (c *draftCard) ReadComments() ([]Comment, error)          { return nil, nil }
+func (c *draftCard) WriteComment(comment string) error         { return nil }
+func (c *draftCard) GetAttachments() ([]Attachment, error)     { return nil, nil }
+func (c *draftCard) AddAttachment(attachment Attachment) error { return nil }
+
+// draftList is the list of a draft card.
+type draftList string
+
+func (l draftList) GetName() string { return string(l) }
+func (l draftList) GetID() string   { return string(l) }
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *dryRunCard) GetMoves() ([]Move, error) {
+	if h, ok := c.Card.(CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *dryRunCard) ReadAttachment(a Attachment) ([]byte, error) {
+	return ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *dryRunCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+// SetField prints the change instead of performing it; a real board without the field still reports ErrNoField.
+func (c *dryRunCard) SetField(name, value string) error {
+	if _, ok := c.Card.(CardFields); !ok {
+		return ErrNoField
+	}
+	dryRunPrint("set %s of %q to %q", name, c.GetName(), value)
+	return nil
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *dryRunCard) ReadCommentsSince(since time.Time) ([]Comment, error) {
+	return ReadCommentsSince(c.Card, since)
+}
+
+// MoveToBottom prints the change instead of performing it; a real board without ordering still reports ErrNoOrdering.
+func (c *dryRunCard) MoveToBottom() error {
+	if _, ok := c.Card.(CardOrdering); !ok {
+		return ErrNoOrdering
+	}
+	dryRunPrint("move %q to the bottom of its list", c.GetName())
+	return nil
+}
@@ -0,0 +1,49 @@
+package board
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// estimatePrefix marks the description line holding a ticket's effort estimate.
+const estimatePrefix = "Estimate: "
+
+// estimateUnit is the unit written after the estimate.
+const estimateUnit = "story points"
+
+// WithEstimate adds or replaces the estimate section of a description.
+func WithEstimate(description string, points float64) string {
+	line := fmt.Sprintf("%s%s %s", estimatePrefix, strconv.FormatFloat(points, 'f', -1, 64), estimateUnit)
+	lines := strings.Split(description, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), estimatePrefix) {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	if strings.TrimSpace(description) == "" {
+		return line
+	}
+	return strings.TrimRight(description, "\n") + "\n\n" + line
+}
+
+// Estimate extracts the estimate written by WithEstimate.
+func Estimate(description string) (float64, bool) {
+	for _, l := range strings.Split(description, "\n") {
+		l = strings.TrimSpace(l)
+		if !strings.HasPrefix(l, estimatePrefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(l, estimatePrefix))
+		if len(fields) == 0 {
+			return 0, false
+		}
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, false
+		}
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package board
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Standard custom fields.
+const (
+	FieldPoints    = "points"    // Story point estimate.
+	FieldRepo      = "repo"      // Repository the ticket belongs to.
+	FieldComponent = "component" // Component or service the ticket touches.
+	FieldPriority  = "priority"  // e.g. "high".
+)
+
+// ErrNoField is returned by SetField when the board does not define the field.
+var ErrNoField = errors.New("custom field not defined on the board")
+
+// CardFields is implemented by cards whose board supports custom fields.
+type CardFields interface {
+	// GetFields returns the card's custom field values keyed by lower-cased field name.
+	GetFields() (map[string]string, error)
+	// SetField sets a custom field by name; an empty value clears it.
+	SetField(name, value string) error
+}
+
+// Field returns a custom field of the card, or "" when it is unset or the card has no custom fields.
+func Field(c Card, name string) string {
+	f, ok := c.(CardFields)
+	if !ok {
+		return ""
+	}
+	fields, err := f.GetFields()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(fields[strings.ToLower(name)])
+}
+
+// SetField sets a custom field when the card supports it and the board defines it. It reports
+// false, without error, when the caller should fall back to a description convention instead.
+func SetField(c Card, name, value string) (bool, error) {
+	f, ok := c.(CardFields)
+	if !ok {
+		return false, nil
+	}
+	if err := f.SetField(name, value); err != nil {
+		if errors.Is(err, ErrNoField) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CardEstimate returns the card's estimate from its points field, falling back to the "Estimate:" line.
+func CardEstimate(c Card) (float64, bool) {
+	if v := Field(c, FieldPoints); v != "" {
+		if points, err := strconv.ParseFloat(v, 64); err == nil {
+			return points, true
+		}
+	}
+	return Estimate(c.GetDescription())
+}
+
+// SetEstimate stores the estimate in the card's points field, or in its description when the
+// board has no such field.
+func SetEstimate(c Card, points float64) error {
+	ok, err := SetField(c, FieldPoints, strconv.FormatFloat(points, 'f', -1, 64))
+	if ok || err != nil {
+		return err
+	}
+	return c.ChangeDescription(WithEstimate(c.GetDescription(), points))
+}
+
+// SetRepo stores the repository in the card's repo field, or in its description when the board
+// has no such field. An empty repo leaves the card unchanged.
+func SetRepo(c Card, repo string) error {
+	if repo == "" {
+		return nil
+	}
+	ok, err := SetField(c, FieldRepo, repo)
+	if ok || err != nil {
+		return err
+	}
+	return c.ChangeDescription(WithRepo(c.GetDescription(), repo))
+}
@@ -0,0 +1,481 @@
+// internal/board/gitlab/gitlabClient.go
+package gitlabClient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bc "github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/gitlabapi"
+)
+
+// -------------------------
+// Concrete GitLabBoardClient
+// -------------------------
+
+// GitLabClient implements the bc.BoardClient interface on top of GitLab issues.
+// Columns are modelled as scoped labels: an issue is in the list whose label it carries.
+type GitLabClient struct {
+	Token      string
+	ProjectID  string
+	BaseURL    string
+	Columns    []string // Ordered list of labels that act as board columns.
+	HTTPClient *http.Client
+
+	membersMu sync.Mutex
+	members   *bc.MemberDirectory
+}
+
+// NewGitLabClient constructs a new GitLabClient. An empty baseURL defaults to gitlab.com.
+func NewGitLabClient(token, projectID, baseURL string, columns []string) *GitLabClient {
+	if baseURL == "" {
+		baseURL = gitlabapi.DefaultBaseURL
+	}
+	return &GitLabClient{
+		Token:      token,
+		ProjectID:  projectID,
+		BaseURL:    baseURL,
+		Columns:    columns,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// issue mirrors the fields of a GitLab issue we care about.
+type issue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	WebURL      string   `json:"web_url"`
+	Labels      []string `json:"labels"`
+	Assignees   []struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"assignees"`
+}
+
+// projectPath returns the API path prefix for the configured project.
+func (gc *GitLabClient) projectPath() string {
+	return "/projects/" + url.PathEscape(gc.ProjectID)
+}
+
+// do performs an authenticated API request and decodes the JSON response into out (if non-nil).
+func (gc *GitLabClient) do(method, path string, payload interface{}, out interface{}) error {
+	return gitlabapi.Do(gc.HTTPClient, gc.BaseURL, gc.Token, method, path, payload, out)
+}
+
+// issues returns the open issues matching query, e.g. "labels=Doing", from every page.
+func (gc *GitLabClient) issues(query string) ([]bc.Card, error) {
+	path := gc.projectPath() + "/issues?state=opened"
+	if query != "" {
+		path += "&" + query
+	}
+	issues, err := gitlabapi.GetAll[issue](gc.HTTPClient, gc.BaseURL, gc.Token, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	var result []bc.Card
+	for _, i := range issues {
+		result = append(result, gc.toCard(i))
+	}
+	return result, nil
+}
+
+// column returns the configured column label matching name, ignoring case.
+func (gc *GitLabClient) column(name string) (string, bool) {
+	for _, c := range gc.Columns {
+		if strings.EqualFold(c, name) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// toCard wraps a GitLab issue in a GitLabCard.
+func (gc *GitLabClient) toCard(i issue) *GitLabCard {
+	card := &GitLabCard{
+		IID:         i.IID,
+		Title:       i.Title,
+		Description: i.Description,
+		URL:         i.WebURL,
+		Labels:      i.Labels,
+		BoardClient: gc,
+	}
+	for _, l := range i.Labels {
+		if col, ok := gc.column(l); ok {
+			card.List = &GitLabList{Name: col}
+			break
+		}
+	}
+	return card
+}
+
+func (gc *GitLabClient) GetName() string {
+	var project struct {
+		Name string `json:"name_with_namespace"`
+	}
+	if err := gc.do("GET", gc.projectPath(), nil, &project); err != nil {
+		return ""
+	}
+	return project.Name
+}
+
+func (gc *GitLabClient) GetURL() string {
+	var project struct {
+		WebURL string `json:"web_url"`
+	}
+	if err := gc.do("GET", gc.projectPath(), nil, &project); err != nil {
+		return ""
+	}
+	return project.WebURL + "/-/boards"
+}
+
+// GetMembers returns the project members, cached for bc.DefaultMemberTTL.
+func (gc *GitLabClient) GetMembers() ([]bc.Member, error) {
+	return gc.directory().Members()
+}
+
+// fetchMembers reads the project members from the API.
+func (gc *GitLabClient) fetchMembers() ([]bc.Member, error) {
+	type member struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	}
+	members, err := gitlabapi.GetAll[member](gc.HTTPClient, gc.BaseURL, gc.Token, gc.projectPath()+"/members/all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project members: %w", err)
+	}
+	var result []bc.Member
+	for _, m := range members {
+		result = append(result, bc.Member{
+			ID:       strconv.Itoa(m.ID),
+			Name:     m.Username,
+			Username: m.Username,
+		})
+	}
+	return result, nil
+}
+
+// directory returns the client's member directory, created on first use with the configured aliases.
+func (gc *GitLabClient) directory() *bc.MemberDirectory {
+	gc.membersMu.Lock()
+	defer gc.membersMu.Unlock()
+	if gc.members == nil {
+		gc.members = bc.NewMemberDirectory(gc.fetchMembers, config.GetMemberAliases())
+	}
+	return gc.members
+}
+
+func (gc *GitLabClient) GetLists() ([]bc.List, error) {
+	var result []bc.List
+	for _, c := range gc.Columns {
+		result = append(result, &GitLabList{Name: c})
+	}
+	return result, nil
+}
+
+// CreateCard creates a new issue labelled with the given column.
+func (gc *GitLabClient) CreateCard(name, description, listName string) (bc.Card, error) {
+	col, ok := gc.column(listName)
+	if !ok {
+		return nil, fmt.Errorf("list %s not found", listName)
+	}
+	payload := map[string]string{
+		"title":       name,
+		"description": description,
+		"labels":      col,
+	}
+	var created issue
+	if err := gc.do("POST", gc.projectPath()+"/issues", payload, &created); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	return gc.toCard(created), nil
+}
+
+func (gc *GitLabClient) GetCards() ([]bc.Card, error) {
+	return gc.issues("")
+}
+
+func (gc *GitLabClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
+	return gc.issues("assignee_username=" + url.QueryEscape(userName))
+}
+
+func (gc *GitLabClient) GetCardsFromList(listName string) ([]bc.Card, error) {
+	col, ok := gc.column(listName)
+	if !ok {
+		return nil, fmt.Errorf("list %s not found", listName)
+	}
+	return gc.issues("labels=" + url.QueryEscape(col))
+}
+
+// memberID resolves a username or display name to a GitLab user ID.
+func (gc *GitLabClient) memberID(userName string) (int, error) {
+	m, err := gc.directory().Lookup(userName)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(m.ID)
+}
+
+// -------------------------
+// Concrete GitLabList Implementation
+// -------------------------
+
+type GitLabList struct {
+	Name string
+}
+
+func (gl *GitLabList) GetName() string {
+	return gl.Name
+}
+
+// GetID returns the label name, which uniquely identifies a column.
+func (gl *GitLabList) GetID() string {
+	return gl.Name
+}
+
+// -------------------------
+// Concrete GitLabCard Implementation
+// -------------------------
+
+type GitLabCard struct {
+	IID         int
+	Title       string
+	Description string
+	URL         string
+	Labels      []string
+	// The list (column label) the issue belongs to.
+	List bc.List
+	// Reference to the board client.
+	BoardClient *GitLabClient
+}
+
+// attachmentPattern matches the markdown links posted by AddAttachment.
+var attachmentPattern = regexp.MustCompile(`^Attachment: \[(.*)\]\((.*)\)$`)
+
+// issuePath returns the API path of this issue.
+func (c *GitLabCard) issuePath() string {
+	return fmt.Sprintf("%s/issues/%d", c.BoardClient.projectPath(), c.IID)
+}
+
+func (c *GitLabCard) GetID() string {
+	return strconv.Itoa(c.IID)
+}
+
+func (c *GitLabCard) GetName() string {
+	return c.Title
+}
+
+func (c *GitLabCard) ChangeName(newName string) error {
+	if err := c.BoardClient.do("PUT", c.issuePath(), map[string]string{"title": newName}, nil); err != nil {
+		return fmt.Errorf("failed to rename issue: %w", err)
+	}
+	c.Title = newName
+	return nil
+}
+
+func (c *GitLabCard) GetDescription() string {
+	return c.Description
+}
+
+func (c *GitLabCard) ChangeDescription(newDescription string) error {
+	if err := c.BoardClient.do("PUT", c.issuePath(), map[string]string{"description": newDescription}, nil); err != nil {
+		return fmt.Errorf("failed to update issue description: %w", err)
+	}
+	c.Description = newDescription
+	return nil
+}
+
+func (c *GitLabCard) GetURL() string {
+	return c.URL
+}
+
+func (c *GitLabCard) GetList() (bc.List, error) {
+	if c.List == nil {
+		return nil, fmt.Errorf("list not set for card")
+	}
+	return c.List, nil
+}
+
+// Move swaps the current column label for the label of the target column.
+func (c *GitLabCard) Move(newListName string) error {
+	col, ok := c.BoardClient.column(newListName)
+	if !ok {
+		return fmt.Errorf("list %s not found", newListName)
+	}
+	var remove []string
+	for _, l := range c.Labels {
+		if other, isCol := c.BoardClient.column(l); isCol && other != col {
+			remove = append(remove, l)
+		}
+	}
+	payload := map[string]string{
+		"add_labels":    col,
+		"remove_labels": strings.Join(remove, ","),
+	}
+	var updated issue
+	if err := c.BoardClient.do("PUT", c.issuePath(), payload, &updated); err != nil {
+		return fmt.Errorf("failed to move issue: %w", err)
+	}
+	c.Labels = updated.Labels
+	c.List = &GitLabList{Name: col}
+	return nil
+}
+
+func (c *GitLabCard) GetAssignedMembers() ([]bc.Member, error) {
+	var current issue
+	if err := c.BoardClient.do("GET", c.issuePath(), nil, &current); err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	var members []bc.Member
+	for _, a := range current.Assignees {
+		members = append(members, bc.Member{
+			ID:   strconv.Itoa(a.ID),
+			Name: a.Username,
+		})
+	}
+	return members, nil
+}
+
+// assigneeIDs returns the IDs of the users currently assigned to the issue.
+func (c *GitLabCard) assigneeIDs() ([]int, error) {
+	var current issue
+	if err := c.BoardClient.do("GET", c.issuePath(), nil, &current); err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	var ids []int
+	for _, a := range current.Assignees {
+		ids = append(ids, a.ID)
+	}
+	return ids, nil
+}
+
+func (c *GitLabCard) AssignTo(userName string) error {
+	targetID, err := c.BoardClient.memberID(userName)
+	if err != nil {
+		return err
+	}
+	ids, err := c.assigneeIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id == targetID {
+			return nil
+		}
+	}
+	ids = append(ids, targetID)
+	return c.BoardClient.do("PUT", c.issuePath(), map[string][]int{"assignee_ids": ids}, nil)
+}
+
+func (c *GitLabCard) UnassignFrom(userName string) error {
+	targetID, err := c.BoardClient.memberID(userName)
+	if err != nil {
+		return err
+	}
+	ids, err := c.assigneeIDs()
+	if err != nil {
+		return err
+	}
+	kept := []int{}
+	for _, id := range ids {
+		if id != targetID {
+			kept = append(kept, id)
+		}
+	}
+	return c.BoardClient.do("PUT", c.issuePath(), map[string][]int{"assignee_ids": kept}, nil)
+}
+
+// notes returns all non-system notes on the issue in chronological order.
+func (c *GitLabCard) notes() ([]bc.Comment, error) {
+	return c.notesSince(time.Time{})
+}
+
+// notesSince returns the non-system notes created after since in chronological order.
+// Pages are read newest first so a poll stops as soon as it reaches notes it has seen.
+func (c *GitLabCard) notesSince(since time.Time) ([]bc.Comment, error) {
+	var comments []bc.Comment
+	for page := 1; ; page++ {
+		var notes []struct {
+			Body      string    `json:"body"`
+			System    bool      `json:"system"`
+			CreatedAt time.Time `json:"created_at"`
+			Author    struct {
+				ID       int    `json:"id"`
+				Username string `json:"username"`
+			} `json:"author"`
+		}
+		path := fmt.Sprintf("%s/notes?order_by=created_at&sort=desc&per_page=%d&page=%d", c.issuePath(), gitlabapi.PageSize, page)
+		if err := c.BoardClient.do("GET", path, nil, &notes); err != nil {
+			return nil, fmt.Errorf("failed to get comments: %w", err)
+		}
+		for _, n := range notes {
+			if !since.IsZero() && !n.CreatedAt.After(since) {
+				slices.Reverse(comments)
+				return comments, nil
+			}
+			if n.System || n.Body == "" {
+				continue
+			}
+			comments = append(comments, bc.Comment{
+				Text:    n.Body,
+				Member:  &bc.Member{ID: strconv.Itoa(n.Author.ID), Name: n.Author.Username},
+				Created: n.CreatedAt,
+			})
+		}
+		if len(notes) < gitlabapi.PageSize {
+			slices.Reverse(comments)
+			return comments, nil
+		}
+	}
+}
+
+func (c *GitLabCard) ReadComments() ([]bc.Comment, error) {
+	return c.notes()
+}
+
+// ReadCommentsSince returns the comments created after since in chronological order.
+func (c *GitLabCard) ReadCommentsSince(since time.Time) ([]bc.Comment, error) {
+	return c.notesSince(since)
+}
+
+// MaxNoteLength is the longest note GitLab accepts; longer comments are split into numbered notes.
+const MaxNoteLength = 1000000
+
+func (c *GitLabCard) WriteComment(comment string) error {
+	for _, part := range bc.SplitComment(bc.CloseFences(comment), MaxNoteLength) {
+		if err := c.BoardClient.do("POST", c.issuePath()+"/notes", map[string]string{"body": part}, nil); err != nil {
+			return fmt.Errorf("failed to post comment: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetAttachments returns the links previously attached via AddAttachment.
+// GitLab issues have no native link attachments, so they are stored as notes.
+func (c *GitLabCard) GetAttachments() ([]bc.Attachment, error) {
+	comments, err := c.notes()
+	if err != nil {
+		return nil, err
+	}
+	var result []bc.Attachment
+	for _, cm := range comments {
+		if m := attachmentPattern.FindStringSubmatch(strings.TrimSpace(cm.Text)); m != nil {
+			result = append(result, bc.Attachment{Name: m[1], URL: m[2]})
+		}
+	}
+	return result, nil
+}
+
+func (c *GitLabCard) AddAttachment(attachment bc.Attachment) error {
+	return c.WriteComment(fmt.Sprintf("Attachment: [%s](%s)", attachment.Name, attachment.URL))
+}
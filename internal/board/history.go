@@ -0,0 +1,42 @@
+package board
+
+import "time"
+
+// Move records a card moving between lists.
+type Move struct {
+	From string // Empty for the list the card was created in.
+	To   string
+	When time.Time
+}
+
+// CardHistory is implemented by cards whose board exposes list-change history.
+type CardHistory interface {
+	// GetMoves returns the card's list changes, oldest first, starting with its creation.
+	GetMoves() ([]Move, error)
+}
+
+// TimeInLists sums how long a card spent in each list, up to now for the current list.
+func TimeInLists(moves []Move, now time.Time) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for i, m := range moves {
+		end := now
+		if i+1 < len(moves) {
+			end = moves[i+1].When
+		}
+		durations[m.To] += end.Sub(m.When)
+	}
+	return durations
+}
+
+// Reworks counts how often a card re-entered a list it had already left, e.g. Review back to In Progress.
+func Reworks(moves []Move) int {
+	visited := make(map[string]bool)
+	count := 0
+	for _, m := range moves {
+		if visited[m.To] {
+			count++
+		}
+		visited[m.To] = true
+	}
+	return count
+}
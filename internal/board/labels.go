@@ -0,0 +1,49 @@
+package board
+
+import (
+	"regexp"
+	"strings"
+)
+
+// labelsLinePrefix marks the description line listing a card's labels, e.g. "Labels: infra, ui".
+const labelsLinePrefix = "Labels:"
+
+var titleLabel = regexp.MustCompile(`^\s*\[([^\]]+)\]`)
+
+// Labels returns the lower-cased labels of a card. Boards without native labels mark them
+// with "[label]" prefixes in the title or a "Labels: a, b" line in the description.
+func Labels(c Card) []string {
+	var labels []string
+	name := c.GetName()
+	for {
+		m := titleLabel.FindStringSubmatchIndex(name)
+		if m == nil {
+			break
+		}
+		labels = append(labels, strings.ToLower(strings.TrimSpace(name[m[2]:m[3]])))
+		name = name[m[1]:]
+	}
+	for _, line := range strings.Split(c.GetDescription(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, labelsLinePrefix) {
+			continue
+		}
+		for _, l := range strings.Split(strings.TrimPrefix(line, labelsLinePrefix), ",") {
+			if l = strings.ToLower(strings.TrimSpace(l)); l != "" {
+				labels = append(labels, l)
+			}
+		}
+	}
+	return labels
+}
+
+// HasLabel reports whether the card carries label (case-insensitive).
+func HasLabel(c Card, label string) bool {
+	label = strings.ToLower(label)
+	for _, l := range Labels(c) {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
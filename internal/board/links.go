@@ -0,0 +1,109 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Standard list (column) names used by the agents.
+const (
+	ListInbox   = "Inbox"
+	ListBacklog = "Backlog"
+	ListSprint  = "Sprint"
+	ListDoing   = "Doing"
+	ListReview  = "Review"
+	ListQA      = "QA"
+	ListDone    = "Done"
+	ListBlocked = "Blocked"
+	ListRetro   = "Retro"
+	ListRelease = "Releases"
+	ListBugs    = "Bugs"
+)
+
+// parentFooterPrefix marks the line in a card description that references its parent card.
+const parentFooterPrefix = "Parent ticket: "
+
+// WithParentLink appends a footer referencing the parent card to the given description.
+func WithParentLink(description string, parent Card) string {
+	footer := fmt.Sprintf("%s%s", parentFooterPrefix, parent.GetURL())
+	if strings.TrimSpace(description) == "" {
+		return footer
+	}
+	return fmt.Sprintf("%s\n\n---\n%s", strings.TrimRight(description, "\n"), footer)
+}
+
+// ParentURL extracts the parent card URL from a description footer, if present.
+func ParentURL(description string) (string, bool) {
+	lines := strings.Split(description, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, parentFooterPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, parentFooterPrefix)), true
+		}
+	}
+	return "", false
+}
+
+// LinkToParent records the parent reference on an existing child card.
+// The description footer is used for lookups; the attachment makes the link clickable on the board.
+func LinkToParent(child, parent Card) error {
+	if url, ok := ParentURL(child.GetDescription()); ok && url == parent.GetURL() {
+		return nil
+	}
+	if err := child.ChangeDescription(WithParentLink(child.GetDescription(), parent)); err != nil {
+		return fmt.Errorf("failed to write parent link: %w", err)
+	}
+	if err := child.AddAttachment(Attachment{Name: "Parent: " + parent.GetName(), URL: parent.GetURL()}); err != nil {
+		return fmt.Errorf("failed to attach parent link: %w", err)
+	}
+	return nil
+}
+
+// GetChildren returns all cards on the board whose description references the given parent card.
+func GetChildren(b Board, parent Card) ([]Card, error) {
+	cards, err := b.GetCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	var children []Card
+	for _, c := range cards {
+		if url, ok := ParentURL(c.GetDescription()); ok && url == parent.GetURL() {
+			children = append(children, c)
+		}
+	}
+	return children, nil
+}
+
+// Rollup summarizes the status of a parent's child cards.
+type Rollup struct {
+	Total  int            // Number of child cards.
+	Done   int            // Number of child cards in the done list.
+	ByList map[string]int // Number of child cards per list name.
+}
+
+// Complete reports whether the parent has children and all of them are done.
+func (r Rollup) Complete() bool {
+	return r.Total > 0 && r.Done == r.Total
+}
+
+// RollupStatus counts the children of a parent card per list, treating doneList as completed.
+func RollupStatus(b Board, parent Card, doneList string) (Rollup, error) {
+	children, err := GetChildren(b, parent)
+	if err != nil {
+		return Rollup{}, err
+	}
+	rollup := Rollup{ByList: make(map[string]int)}
+	for _, c := range children {
+		rollup.Total++
+		list, err := c.GetList()
+		if err != nil {
+			rollup.ByList["Unknown"]++
+			continue
+		}
+		rollup.ByList[list.GetName()]++
+		if strings.EqualFold(list.GetName(), doneList) {
+			rollup.Done++
+		}
+	}
+	return rollup, nil
+}
@@ -0,0 +1,160 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// partHeaderReserve is the room kept in every part for its "(i/n)" header.
+const partHeaderReserve = 16
+
+// CodeBlock fences code for a markdown comment. The fence is longer than any backtick run in
+// the code, so code that itself contains fences still renders as one block.
+func CodeBlock(code, lang string) string {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	return fence + lang + "\n" + strings.TrimRight(code, "\n") + "\n" + fence
+}
+
+// CloseFences appends the closing fence of a code block left open at the end of text, which
+// would otherwise swallow everything posted after it.
+func CloseFences(text string) string {
+	var f fenceState
+	for _, line := range strings.SplitAfter(text, "\n") {
+		f.update(line)
+	}
+	if f.marker == "" {
+		return text
+	}
+	return strings.TrimRight(text, "\n") + "\n" + f.marker
+}
+
+// SplitComment splits text into parts of at most limit bytes, breaking between lines where it can.
+// A code block cut between parts is closed at the end of one part and reopened, with its language,
+// at the start of the next. With more than one part, each starts with an "(i/n)" header.
+func SplitComment(text string, limit int) []string {
+	if limit <= 0 || len(text) <= limit {
+		return []string{text}
+	}
+	budget := limit - partHeaderReserve
+	if budget < 64 {
+		budget = limit
+	}
+	var parts []string
+	var cur strings.Builder
+	var f fenceState
+	reopened := 0 // Length of the fence opener repeated at the start of cur.
+	flush := func() {
+		part := cur.String()
+		if f.marker != "" {
+			part = strings.TrimRight(part, "\n") + "\n" + f.marker
+		}
+		parts = append(parts, strings.TrimRight(part, "\n"))
+		cur.Reset()
+		reopened = 0
+		if f.marker != "" {
+			cur.WriteString(f.opener)
+			reopened = len(f.opener)
+		}
+	}
+	for _, line := range splitLines(text, budget/3) {
+		closing := 0
+		if f.marker != "" {
+			closing = len(f.marker) + 1
+		}
+		if cur.Len() > reopened && cur.Len()+len(line)+closing > budget {
+			flush()
+		}
+		cur.WriteString(line)
+		f.update(line)
+	}
+	if strings.TrimSpace(cur.String()) != "" {
+		parts = append(parts, strings.TrimRight(cur.String(), "\n"))
+	}
+	if len(parts) > 1 {
+		for i, p := range parts {
+			parts[i] = fmt.Sprintf("(%d/%d)\n%s", i+1, len(parts), p)
+		}
+	}
+	return parts
+}
+
+// TruncateComment shortens text to at most limit bytes at a line break, closing any code block it
+// cuts and appending suffix.
+func TruncateComment(text string, limit int, suffix string) string {
+	if len(text) <= limit {
+		return text
+	}
+	parts := SplitComment(text, limit-len(suffix))
+	head := parts[0]
+	if len(parts) > 1 {
+		head = strings.SplitN(head, "\n", 2)[1] // Drop the "(1/n)" header.
+	}
+	return head + suffix
+}
+
+// fenceState tracks whether a markdown code block is open.
+type fenceState struct {
+	marker string // Fence of the open block, e.g. "```"; empty outside code.
+	opener string // Line that opened the block, e.g. "```go\n".
+}
+
+func (f *fenceState) update(line string) {
+	trimmed := strings.TrimSpace(line)
+	if f.marker == "" {
+		for _, c := range []byte{'`', '~'} {
+			if n := longestPrefix(trimmed, c); n >= 3 {
+				f.marker = strings.Repeat(string(c), n)
+				f.opener = strings.TrimRight(line, "\n") + "\n"
+				return
+			}
+		}
+		return
+	}
+	if longestPrefix(trimmed, f.marker[0]) >= len(f.marker) && strings.Trim(trimmed, f.marker[:1]) == "" {
+		f.marker, f.opener = "", ""
+	}
+}
+
+// splitLines splits text after each newline, cutting lines longer than limit at rune boundaries.
+func splitLines(text string, limit int) []string {
+	var lines []string
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for len(line) > limit {
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				cut = limit
+			}
+			lines = append(lines, line[:cut]+"\n")
+			line = line[cut:]
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func longestPrefix(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+func longestRun(s string, c byte) int {
+	best, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			best = max(best, run)
+		} else {
+			run = 0
+		}
+	}
+	return best
+}
@@ -0,0 +1,170 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultMemberTTL is how long a MemberDirectory trusts its member list.
+const DefaultMemberTTL = 10 * time.Minute
+
+// maxMemberDistance is the largest edit distance accepted as a typo of a member's name.
+const maxMemberDistance = 2
+
+// MemberDirectory caches a board's members and resolves the names agents use for them.
+// Lookups go through configured aliases first, then case-insensitive exact matches on ID,
+// username and name, then punctuation-insensitive, substring and typo-tolerant matches, as long
+// as exactly one member qualifies. A miss refetches the members once before failing.
+type MemberDirectory struct {
+	Fetch   func() ([]Member, error)
+	Aliases map[string]string // e.g. "backend" -> "egobogobackendagent".
+	TTL     time.Duration     // Zero uses DefaultMemberTTL.
+
+	mu      sync.Mutex
+	members []Member
+	fetched time.Time
+}
+
+// NewMemberDirectory creates a directory over fetch with the given aliases.
+func NewMemberDirectory(fetch func() ([]Member, error), aliases map[string]string) *MemberDirectory {
+	return &MemberDirectory{Fetch: fetch, Aliases: aliases}
+}
+
+// Members returns the cached members, fetching them when the cache is empty or stale.
+func (d *MemberDirectory) Members() ([]Member, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.load(false)
+}
+
+// Invalidate drops the cached members so the next lookup refetches them.
+func (d *MemberDirectory) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.members = nil
+}
+
+func (d *MemberDirectory) load(force bool) ([]Member, error) {
+	ttl := d.TTL
+	if ttl <= 0 {
+		ttl = DefaultMemberTTL
+	}
+	if !force && d.members != nil && time.Since(d.fetched) < ttl {
+		return d.members, nil
+	}
+	members, err := d.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []Member{}
+	}
+	d.members, d.fetched = members, time.Now()
+	return members, nil
+}
+
+// Lookup resolves name to a member.
+func (d *MemberDirectory) Lookup(name string) (Member, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if alias, ok := d.alias(name); ok {
+		name = alias
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		members, err := d.load(attempt > 0)
+		if err != nil {
+			return Member{}, err
+		}
+		if m, ok := MatchMember(members, name); ok {
+			return m, nil
+		}
+	}
+	return Member{}, fmt.Errorf("member %s not found", name)
+}
+
+func (d *MemberDirectory) alias(name string) (string, bool) {
+	for k, v := range d.Aliases {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// MatchMember finds the member name refers to, trying progressively looser matches and
+// accepting a loose match only when it is unambiguous.
+func MatchMember(members []Member, name string) (Member, bool) {
+	name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+	if name == "" {
+		return Member{}, false
+	}
+	matchers := []func(Member) bool{
+		func(m Member) bool {
+			return strings.EqualFold(m.ID, name) || strings.EqualFold(m.Username, name) || strings.EqualFold(m.Name, name)
+		},
+		func(m Member) bool {
+			n := normalizeName(name)
+			return n != "" && (normalizeName(m.Username) == n || normalizeName(m.Name) == n)
+		},
+		func(m Member) bool {
+			n := normalizeName(name)
+			return len(n) >= 3 && (strings.Contains(normalizeName(m.Username), n) || strings.Contains(normalizeName(m.Name), n))
+		},
+		func(m Member) bool {
+			n := normalizeName(name)
+			return len(n) > maxMemberDistance*2 &&
+				(editDistance(normalizeName(m.Username), n) <= maxMemberDistance || editDistance(normalizeName(m.Name), n) <= maxMemberDistance)
+		},
+	}
+	for _, match := range matchers {
+		var found []Member
+		for _, m := range members {
+			if match(m) {
+				found = append(found, m)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], true
+		}
+		if len(found) > 1 {
+			return Member{}, false // Ambiguous; looser matches would only add candidates.
+		}
+	}
+	return Member{}, false
+}
+
+// normalizeName lower-cases s and drops everything but letters and digits.
+func normalizeName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,213 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BoardSeparator joins a board name and a card ID or list name, e.g. "web:5f2a..." or "web:Backlog".
+const BoardSeparator = ":"
+
+// TicketID returns the identity of a card on a named board.
+func TicketID(boardName, cardID string) string {
+	return boardName + BoardSeparator + cardID
+}
+
+// SplitTicketID splits a ticket identity into board name and card ID.
+// IDs of single-board setups have no board and are returned unchanged.
+func SplitTicketID(id string) (boardName, cardID string) {
+	if b, c, ok := strings.Cut(id, BoardSeparator); ok {
+		return b, c
+	}
+	return "", id
+}
+
+// ListOn returns the list name addressing list on the board card belongs to, so cards created
+// next to an existing ticket (child tickets, sub-tasks) land on the same board.
+// For cards of a single-board setup it returns list unchanged.
+func ListOn(card Card, list string) string {
+	if boardName, _ := SplitTicketID(card.GetID()); boardName != "" {
+		return boardName + BoardSeparator + list
+	}
+	return list
+}
+
+// Multi watches several boards as one BoardClient. Card IDs are prefixed with the board name,
+// and list names may be too ("web:Backlog") to address a single board; unprefixed lists
+// read from every board and create on the first one added.
+type Multi struct {
+	names  []string
+	boards map[string]BoardClient
+}
+
+// NewMulti returns an empty multi-board client.
+func NewMulti() *Multi {
+	return &Multi{boards: make(map[string]BoardClient)}
+}
+
+// Add registers a board under name. Wrap it with WithColumns first if its columns differ from the standard lists.
+func (m *Multi) Add(name string, b BoardClient) {
+	if _, ok := m.boards[name]; !ok {
+		m.names = append(m.names, name)
+	}
+	m.boards[name] = b
+}
+
+// Boards returns the board names in the order they were added.
+func (m *Multi) Boards() []string {
+	return append([]string(nil), m.names...)
+}
+
+// Board returns the board registered under name.
+func (m *Multi) Board(name string) (BoardClient, bool) {
+	b, ok := m.boards[name]
+	return b, ok
+}
+
+// target resolves a possibly prefixed list name to the boards it addresses.
+func (m *Multi) target(listName string) ([]string, string, error) {
+	if boardName, list, ok := strings.Cut(listName, BoardSeparator); ok {
+		if _, known := m.boards[boardName]; !known {
+			return nil, "", fmt.Errorf("unknown board %q", boardName)
+		}
+		return []string{boardName}, list, nil
+	}
+	return m.names, listName, nil
+}
+
+func (m *Multi) GetName() string {
+	return strings.Join(m.names, ", ")
+}
+
+func (m *Multi) GetURL() string {
+	if len(m.names) == 0 {
+		return ""
+	}
+	return m.boards[m.names[0]].GetURL()
+}
+
+func (m *Multi) GetMembers() ([]Member, error) {
+	seen := make(map[string]bool)
+	var result []Member
+	for _, name := range m.names {
+		members, err := m.boards[name].GetMembers()
+		if err != nil {
+			return nil, fmt.Errorf("board %s: %w", name, err)
+		}
+		for _, mem := range members {
+			if !seen[mem.ID] {
+				seen[mem.ID] = true
+				result = append(result, mem)
+			}
+		}
+	}
+	return result, nil
+}
+
+// collect gathers cards from the named boards, scoping each one to its board.
+func (m *Multi) collect(names []string, get func(b BoardClient) ([]Card, error)) ([]Card, error) {
+	var result []Card
+	for _, name := range names {
+		cards, err := get(m.boards[name])
+		if err != nil {
+			return nil, fmt.Errorf("board %s: %w", name, err)
+		}
+		for _, c := range cards {
+			result = append(result, &scopedCard{Card: c, board: name})
+		}
+	}
+	return result, nil
+}
+
+func (m *Multi) GetCards() ([]Card, error) {
+	return m.collect(m.names, func(b BoardClient) ([]Card, error) { return b.GetCards() })
+}
+
+func (m *Multi) GetCardsAssignedTo(userName string) ([]Card, error) {
+	return m.collect(m.names, func(b BoardClient) ([]Card, error) { return b.GetCardsAssignedTo(userName) })
+}
+
+func (m *Multi) GetCardsFromList(listName string) ([]Card, error) {
+	names, list, err := m.target(listName)
+	if err != nil {
+		return nil, err
+	}
+	return m.collect(names, func(b BoardClient) ([]Card, error) { return b.GetCardsFromList(list) })
+}
+
+func (m *Multi) CreateCard(name, description, listName string) (Card, error) {
+	names, list, err := m.target(listName)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no boards configured")
+	}
+	c, err := m.boards[names[0]].CreateCard(name, description, list)
+	if c == nil {
+		return nil, err
+	}
+	return &scopedCard{Card: c, board: names[0]}, err
+}
+
+// GetLists returns the lists of the first board; use Board for the others.
+func (m *Multi) GetLists() ([]List, error) {
+	if len(m.names) == 0 {
+		return nil, nil
+	}
+	return m.boards[m.names[0]].GetLists()
+}
+
+// scopedCard carries the name of its board in its ID.
+type scopedCard struct {
+	Card
+	board string
+}
+
+func (c *scopedCard) GetID() string {
+	return TicketID(c.board, c.Card.GetID())
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *scopedCard) GetMoves() ([]Move, error) {
+	if h, ok := c.Card.(CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *scopedCard) ReadAttachment(a Attachment) ([]byte, error) {
+	return ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *scopedCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *scopedCard) SetField(name, value string) error {
+	f, ok := c.Card.(CardFields)
+	if !ok {
+		return ErrNoField
+	}
+	return f.SetField(name, value)
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *scopedCard) ReadCommentsSince(since time.Time) ([]Comment, error) {
+	return ReadCommentsSince(c.Card, since)
+}
+
+// MoveToBottom forwards to the wrapped card's ordering when it has one.
+func (c *scopedCard) MoveToBottom() error {
+	o, ok := c.Card.(CardOrdering)
+	if !ok {
+		return ErrNoOrdering
+	}
+	return o.MoveToBottom()
+}
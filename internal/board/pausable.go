@@ -0,0 +1,138 @@
+package board
+
+import (
+	"time"
+
+	"github.com/egobogo/aiagents/internal/killswitch"
+)
+
+// pausableBoard holds write operations while the kill switch is engaged. Reads are never blocked.
+type pausableBoard struct {
+	BoardClient
+}
+
+// Pausable returns a BoardClient whose writes wait for the kill switch to be released.
+func Pausable(b BoardClient) BoardClient {
+	return &pausableBoard{BoardClient: b}
+}
+
+func (b *pausableBoard) wrapAll(cards []Card, err error) ([]Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = &pausableCard{Card: c}
+	}
+	return wrapped, nil
+}
+
+func (b *pausableBoard) GetCards() ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *pausableBoard) GetCardsAssignedTo(userName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *pausableBoard) GetCardsFromList(listName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *pausableBoard) CreateCard(name, description, listName string) (Card, error) {
+	killswitch.Wait("creating card " + name)
+	c, err := b.BoardClient.CreateCard(name, description, listName)
+	if c == nil {
+		return nil, err
+	}
+	return &pausableCard{Card: c}, err
+}
+
+// pausableCard holds write operations on a card while the kill switch is engaged.
+type pausableCard struct {
+	Card
+}
+
+func (c *pausableCard) ChangeName(newName string) error {
+	killswitch.Wait("renaming " + c.GetName())
+	return c.Card.ChangeName(newName)
+}
+
+func (c *pausableCard) ChangeDescription(newDescription string) error {
+	killswitch.Wait("editing " + c.GetName())
+	return c.Card.ChangeDescription(newDescription)
+}
+
+func (c *pausableCard) Move(newListName string) error {
+	killswitch.Wait("moving " + c.GetName())
+	return c.Card.Move(newListName)
+}
+
+func (c *pausableCard) AssignTo(userName string) error {
+	killswitch.Wait("assigning " + c.GetName())
+	return c.Card.AssignTo(userName)
+}
+
+func (c *pausableCard) UnassignFrom(userName string) error {
+	killswitch.Wait("unassigning " + c.GetName())
+	return c.Card.UnassignFrom(userName)
+}
+
+func (c *pausableCard) WriteComment(comment string) error {
+	killswitch.Wait("commenting on " + c.GetName())
+	return c.Card.WriteComment(comment)
+}
+
+func (c *pausableCard) WriteQuestion(question string) error {
+	killswitch.Wait("commenting on " + c.GetName())
+	return WriteQuestion(c.Card, question)
+}
+
+func (c *pausableCard) AddAttachment(attachment Attachment) error {
+	killswitch.Wait("attaching to " + c.GetName())
+	return c.Card.AddAttachment(attachment)
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *pausableCard) GetMoves() ([]Move, error) {
+	if h, ok := c.Card.(CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *pausableCard) ReadAttachment(a Attachment) ([]byte, error) {
+	return ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *pausableCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *pausableCard) SetField(name, value string) error {
+	f, ok := c.Card.(CardFields)
+	if !ok {
+		return ErrNoField
+	}
+	killswitch.Wait("editing " + c.GetName())
+	return f.SetField(name, value)
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *pausableCard) ReadCommentsSince(since time.Time) ([]Comment, error) {
+	return ReadCommentsSince(c.Card, since)
+}
+
+func (c *pausableCard) MoveToBottom() error {
+	o, ok := c.Card.(CardOrdering)
+	if !ok {
+		return ErrNoOrdering
+	}
+	killswitch.Wait("reordering " + c.GetName())
+	return o.MoveToBottom()
+}
@@ -0,0 +1,85 @@
+package board
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// FieldScore is the custom field holding a card's prioritization score.
+const FieldScore = "score"
+
+// ErrNoOrdering is returned by MoveToBottom when the board does not order cards.
+var ErrNoOrdering = errors.New("board does not order cards")
+
+// scorePrefix marks the description line holding the score on boards without the field.
+const scorePrefix = "Priority score: "
+
+// CardOrdering is implemented by cards whose board keeps the order of cards within a list.
+type CardOrdering interface {
+	// MoveToBottom moves the card to the end of its list.
+	MoveToBottom() error
+}
+
+// Reorder moves the cards to the bottom of their list one after another, so a list holding
+// exactly these cards ends up in the given order. It reports false, without error, when the
+// board does not order cards.
+func Reorder(cards []Card) (bool, error) {
+	for _, c := range cards {
+		o, ok := c.(CardOrdering)
+		if !ok {
+			return false, nil
+		}
+		if err := o.MoveToBottom(); err != nil {
+			if errors.Is(err, ErrNoOrdering) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// WithScore adds or replaces the score line of a description.
+func WithScore(description string, score float64) string {
+	line := scorePrefix + strconv.FormatFloat(score, 'f', -1, 64)
+	lines := strings.Split(description, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), scorePrefix) {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	if strings.TrimSpace(description) == "" {
+		return line
+	}
+	return strings.TrimRight(description, "\n") + "\n\n" + line
+}
+
+// CardScore returns the card's score from its score field, falling back to the "Priority score:" line.
+func CardScore(c Card) (float64, bool) {
+	v := Field(c, FieldScore)
+	if v == "" {
+		for _, l := range strings.Split(c.GetDescription(), "\n") {
+			if l = strings.TrimSpace(l); strings.HasPrefix(l, scorePrefix) {
+				v = strings.TrimSpace(strings.TrimPrefix(l, scorePrefix))
+				break
+			}
+		}
+	}
+	score, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return score, true
+}
+
+// SetScore stores the score in the card's score field, or in its description when the board
+// has no such field.
+func SetScore(c Card, score float64) error {
+	ok, err := SetField(c, FieldScore, strconv.FormatFloat(score, 'f', -1, 64))
+	if ok || err != nil {
+		return err
+	}
+	return c.ChangeDescription(WithScore(c.GetDescription(), score))
+}
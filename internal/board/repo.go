@@ -0,0 +1,69 @@
+package board
+
+import "strings"
+
+// repoPrefix marks the description line naming the repository a ticket belongs to, e.g. "Repo: frontend".
+const repoPrefix = "Repo: "
+
+// scopePrefix marks the description line restricting a ticket to repository paths, e.g. "Scope: services/payments/**".
+const scopePrefix = "Scope:"
+
+// repoLabelPrefix marks a label naming the repository, e.g. "[repo:frontend]".
+const repoLabelPrefix = "repo:"
+
+// RepoHint returns the repository a ticket targets, taken from its repo custom field, a "repo:<name>"
+// label or a "Repo: <name>" description line, or "" when the ticket does not say.
+func RepoHint(c Card) string {
+	if repo := Field(c, FieldRepo); repo != "" {
+		return strings.ToLower(repo)
+	}
+	for _, l := range Labels(c) {
+		if strings.HasPrefix(l, repoLabelPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(l, repoLabelPrefix))
+		}
+	}
+	for _, l := range strings.Split(c.GetDescription(), "\n") {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, repoPrefix) {
+			return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(l, repoPrefix)))
+		}
+	}
+	return ""
+}
+
+// WithRepo adds or replaces the repository line of a description. An empty repo leaves it unchanged.
+func WithRepo(description, repo string) string {
+	if repo == "" {
+		return description
+	}
+	line := repoPrefix + repo
+	lines := strings.Split(description, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), repoPrefix) {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	if strings.TrimSpace(description) == "" {
+		return line
+	}
+	return strings.TrimRight(description, "\n") + "\n\n" + line
+}
+
+// PathScope returns the repository path patterns listed on a "Scope: a, b" description line, or nil.
+func PathScope(c Card) []string {
+	for _, l := range strings.Split(c.GetDescription(), "\n") {
+		l = strings.TrimSpace(l)
+		if !strings.HasPrefix(l, scopePrefix) {
+			continue
+		}
+		var patterns []string
+		for _, p := range strings.Split(strings.TrimPrefix(l, scopePrefix), ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				patterns = append(patterns, p)
+			}
+		}
+		return patterns
+	}
+	return nil
+}
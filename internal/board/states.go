@@ -0,0 +1,109 @@
+package board
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIllegalTransition is returned when a ticket is moved along a transition the state machine does not allow.
+var ErrIllegalTransition = errors.New("illegal ticket transition")
+
+// transitionMarker prefixes the comment recording a transition.
+const transitionMarker = "[transition]"
+
+// StateMachine defines the lists a ticket may move between.
+type StateMachine struct {
+	// Transitions maps a list to the lists a ticket may move to from it.
+	Transitions map[string][]string
+	// SideStates can be entered from any state except Done, e.g. Blocked.
+	SideStates []string
+}
+
+// DefaultStateMachine is the standard ticket flow:
+// Backlog → Sprint → Doing → Review → QA → Done, with review and QA able to send work back
+// to Doing, and Blocked reachable from every open state.
+var DefaultStateMachine = &StateMachine{
+	Transitions: map[string][]string{
+		ListBacklog: {ListSprint, ListDoing},
+		ListSprint:  {ListDoing, ListBacklog},
+		ListDoing:   {ListReview},
+		ListReview:  {ListQA, ListDoing},
+		ListQA:      {ListDone, ListDoing},
+		ListBlocked: {ListBacklog, ListSprint, ListDoing, ListReview, ListQA},
+		ListDone:    {},
+	},
+	SideStates: []string{ListBlocked},
+}
+
+// CanTransition reports whether a ticket may move from one list to another.
+func (sm *StateMachine) CanTransition(from, to string) bool {
+	if _, ok := sm.Transitions[from]; !ok {
+		return false
+	}
+	if from == ListDone {
+		return false
+	}
+	for _, side := range sm.SideStates {
+		if to == side {
+			return from != side
+		}
+	}
+	for _, next := range sm.Transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTicket moves the card to list to after validating the transition, and records who moved it
+// and why as a card comment. Illegal moves are rejected with ErrIllegalTransition and leave the card untouched.
+func (sm *StateMachine) TransitionTicket(card Card, to, actor, reason string) error {
+	list, err := card.GetList()
+	if err != nil {
+		return fmt.Errorf("failed to get current list: %w", err)
+	}
+	from := list.GetName()
+	if from == to {
+		return nil
+	}
+	if !sm.CanTransition(from, to) {
+		return fmt.Errorf("%w: %s → %s for %q", ErrIllegalTransition, from, to, card.GetName())
+	}
+	return moveAndRecord(card, from, to, actor, reason)
+}
+
+// TransitionTicket validates and performs a transition using DefaultStateMachine.
+func TransitionTicket(card Card, to, actor, reason string) error {
+	return DefaultStateMachine.TransitionTicket(card, to, actor, reason)
+}
+
+// ForceTransition moves the card to list to and records the move like TransitionTicket, without
+// validating it. It is meant for cards that do not go through the ticket flow themselves, e.g. a
+// parent ticket completed from the backlog once all its technical tickets are done.
+func ForceTransition(card Card, to, actor, reason string) error {
+	list, err := card.GetList()
+	if err != nil {
+		return fmt.Errorf("failed to get current list: %w", err)
+	}
+	if list.GetName() == to {
+		return nil
+	}
+	return moveAndRecord(card, list.GetName(), to, actor, reason)
+}
+
+// moveAndRecord moves the card and records the transition as a card comment.
+func moveAndRecord(card Card, from, to, actor, reason string) error {
+	if err := card.Move(to); err != nil {
+		return fmt.Errorf("failed to move %q to %s: %w", card.GetName(), to, err)
+	}
+	note := fmt.Sprintf("%s %s → %s by %s", transitionMarker, from, to, actor)
+	if reason = strings.TrimSpace(reason); reason != "" {
+		note += ": " + reason
+	}
+	if err := card.WriteComment(note); err != nil {
+		fmt.Printf("Warning: failed to record transition of %q: %v\n", card.GetName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Translator translates text into a language named in English, e.g. "German". Text already in that
+// language is returned unchanged.
+type Translator interface {
+	Translate(text, language string) (string, error)
+}
+
+// CardQuestions is implemented by cards that post questions for people differently from other comments.
+type CardQuestions interface {
+	// WriteQuestion posts a question that a person is expected to answer.
+	WriteQuestion(question string) error
+}
+
+// WriteQuestion posts question on the card, through the card's own question handling when it has one.
+func WriteQuestion(c Card, question string) error {
+	if q, ok := c.(CardQuestions); ok {
+		return q.WriteQuestion(question)
+	}
+	return c.WriteComment(question)
+}
+
+// maxTranslations bounds the translations a translated board keeps; the oldest are dropped first.
+const maxTranslations = 4096
+
+// translatedBoard shows agents the cards of boards written in another language in English.
+type translatedBoard struct {
+	BoardClient
+	translator Translator
+	languages  map[string]string // board name ("" for a single board) → language people write in
+
+	mu      sync.Mutex
+	english map[string]string // text on the board → its English version
+	order   []string          // keys of english, oldest first
+}
+
+// WithTranslation returns a BoardClient on which agents read card names, descriptions and comments in
+// English while people on the board write in another language, given per board name ("" for a single
+// board), e.g. {"web": "German"}. Questions posted with WriteQuestion are translated into the board's
+// language. Boards without a language, or with English, are left alone. Text agents write themselves
+// is never translated, and every translation is done once while it is among the most recent ones.
+// Descriptions agents change keep the people's original text; only the agents' edits are applied to it.
+func WithTranslation(b BoardClient, t Translator, languages map[string]string) BoardClient {
+	foreign := make(map[string]string)
+	for name, language := range languages {
+		if language != "" && !strings.EqualFold(language, "English") && !strings.EqualFold(language, "en") {
+			foreign[name] = language
+		}
+	}
+	if t == nil || len(foreign) == 0 {
+		return b
+	}
+	return &translatedBoard{BoardClient: b, translator: t, languages: foreign, english: make(map[string]string)}
+}
+
+// remember records the English version of text posted on the board, dropping the oldest
+// translation once maxTranslations are kept.
+func (b *translatedBoard) remember(text, english string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.english[text]; !ok {
+		if len(b.order) >= maxTranslations {
+			delete(b.english, b.order[0])
+			b.order = b.order[1:]
+		}
+		b.order = append(b.order, text)
+	}
+	b.english[text] = english
+}
+
+// toEnglish returns the English version of text. When the translation fails the text is kept.
+func (b *translatedBoard) toEnglish(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return text
+	}
+	b.mu.Lock()
+	english, ok := b.english[text]
+	b.mu.Unlock()
+	if ok {
+		return english
+	}
+	english, err := b.translator.Translate(text, "English")
+	if err != nil {
+		fmt.Printf("Warning: failed to translate board text into English: %v\n", err)
+		return text
+	}
+	b.remember(text, english)
+	return english
+}
+
+func (b *translatedBoard) wrap(c Card) Card {
+	boardName, _ := SplitTicketID(c.GetID())
+	language, ok := b.languages[boardName]
+	if !ok {
+		return c
+	}
+	return &translatedCard{Card: c, board: b, language: language}
+}
+
+func (b *translatedBoard) wrapAll(cards []Card, err error) ([]Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = b.wrap(c)
+	}
+	return wrapped, nil
+}
+
+func (b *translatedBoard) GetCards() ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *translatedBoard) GetCardsAssignedTo(userName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *translatedBoard) GetCardsFromList(listName string) ([]Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *translatedBoard) CreateCard(name, description, listName string) (Card, error) {
+	b.remember(name, name)
+	b.remember(description, description)
+	c, err := b.BoardClient.CreateCard(name, description, listName)
+	if c == nil {
+		return nil, err
+	}
+	return b.wrap(c), err
+}
+
+// translatedCard reads a card in English and asks questions in the board's language.
+type translatedCard struct {
+	Card
+	board    *translatedBoard
+	language string
+}
+
+func (c *translatedCard) GetName() string {
+	return c.board.toEnglish(c.Card.GetName())
+}
+
+func (c *translatedCard) ChangeName(newName string) error {
+	c.board.remember(newName, newName)
+	return c.Card.ChangeName(newName)
+}
+
+func (c *translatedCard) GetDescription() string {
+	return c.board.toEnglish(c.Card.GetDescription())
+}
+
+// ChangeDescription applies the agent's edit of the English description to the original text, so
+// people keep reading the description in their language with the agent's additions.
+func (c *translatedCard) ChangeDescription(newDescription string) error {
+	original := c.Card.GetDescription()
+	english := c.board.toEnglish(original)
+	updated := newDescription
+	if english != original {
+		updated = patchOriginal(original, english, newDescription)
+	}
+	c.board.remember(updated, newDescription)
+	return c.Card.ChangeDescription(updated)
+}
+
+// patchOriginal applies the lines that changed from english to edited to original, the text english
+// was translated from. Lines added at the start or end, or in place of lines original contains
+// verbatim such as metadata lines, go to the same place; other changes are appended to original.
+func patchOriginal(original, english, edited string) string {
+	from, to := strings.Split(english, "\n"), strings.Split(edited, "\n")
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	removed, added := from[prefix:len(from)-suffix], to[prefix:len(to)-suffix]
+	lines := strings.Split(original, "\n")
+	switch {
+	case len(removed) == 0 && prefix == len(from):
+		lines = append(lines, added...)
+	case len(removed) == 0 && prefix == 0:
+		lines = append(append([]string(nil), added...), lines...)
+	case len(removed) > 0 && indexLines(lines, removed) >= 0:
+		i := indexLines(lines, removed)
+		lines = append(append(append([]string(nil), lines[:i]...), added...), lines[i+len(removed):]...)
+	case len(added) > 0:
+		lines = append(append(lines, ""), added...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indexLines returns the index of the first run of lines equal to block, or -1.
+func indexLines(lines, block []string) int {
+	for i := 0; i+len(block) <= len(lines); i++ {
+		if slices.Equal(lines[i:i+len(block)], block) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *translatedCard) translateAll(comments []Comment, err error) ([]Comment, error) {
+	if err != nil {
+		return comments, err
+	}
+	translated := make([]Comment, len(comments))
+	for i, cm := range comments {
+		cm.Text = c.board.toEnglish(cm.Text)
+		translated[i] = cm
+	}
+	return translated, nil
+}
+
+func (c *translatedCard) ReadComments() ([]Comment, error) {
+	return c.translateAll(c.Card.ReadComments())
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *translatedCard) ReadCommentsSince(since time.Time) ([]Comment, error) {
+	return c.translateAll(ReadCommentsSince(c.Card, since))
+}
+
+func (c *translatedCard) WriteComment(comment string) error {
+	c.board.remember(comment, comment)
+	return c.Card.WriteComment(comment)
+}
+
+// WriteQuestion posts the question in the board's language; agents reading it back see the original.
+// When the translation fails the question is posted in English.
+func (c *translatedCard) WriteQuestion(question string) error {
+	translated, err := c.board.translator.Translate(question, c.language)
+	if err != nil {
+		fmt.Printf("Warning: failed to translate question into %s: %v\n", c.language, err)
+		translated = question
+	}
+	c.board.remember(translated, question)
+	return WriteQuestion(c.Card, translated)
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *translatedCard) GetMoves() ([]Move, error) {
+	if h, ok := c.Card.(CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *translatedCard) ReadAttachment(a Attachment) ([]byte, error) {
+	return ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *translatedCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *translatedCard) SetField(name, value string) error {
+	f, ok := c.Card.(CardFields)
+	if !ok {
+		return ErrNoField
+	}
+	return f.SetField(name, value)
+}
+
+// MoveToBottom forwards to the wrapped card's ordering when it has one.
+func (c *translatedCard) MoveToBottom() error {
+	o, ok := c.Card.(CardOrdering)
+	if !ok {
+		return ErrNoOrdering
+	}
+	return o.MoveToBottom()
+}
@@ -0,0 +1,132 @@
+package trelloClient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/adlio/trello"
+	bc "github.com/egobogo/aiagents/internal/board"
+)
+
+// commentPageSize is the number of comment actions requested per page, Trello's maximum.
+const commentPageSize = 1000
+
+// Comment size limits.
+const (
+	MaxCommentLength = 16384 // Trello rejects longer comments.
+	MaxCommentParts  = 5     // Longer texts are attached as a file instead of split.
+)
+
+// ReadComments returns all comments on the card, newest first.
+func (tc *TrelloCard) ReadComments() ([]bc.Comment, error) {
+	return tc.ReadCommentsSince(time.Time{})
+}
+
+// ReadCommentsSince returns the comments created after since, newest first, paging through
+// long discussions. A zero since returns all comments.
+func (tc *TrelloCard) ReadCommentsSince(since time.Time) ([]bc.Comment, error) {
+	args := trello.Arguments{"filter": "commentCard", "limit": strconv.Itoa(commentPageSize)}
+	if !since.IsZero() {
+		args["since"] = since.UTC().Format(time.RFC3339Nano)
+	}
+	var comments []bc.Comment
+	for {
+		var actions []*trello.Action
+		if err := tc.Client.Get("cards/"+tc.ID+"/actions", args, &actions); err != nil {
+			return nil, fmt.Errorf("failed to get comments: %w", err)
+		}
+		for _, a := range actions {
+			if a.Data == nil || a.Data.Text == "" || (!since.IsZero() && !a.Date.After(since)) {
+				continue
+			}
+			comment := bc.Comment{Text: a.Data.Text, Created: a.Date}
+			if a.MemberCreator != nil {
+				comment.Member = &bc.Member{ID: a.MemberCreator.ID, Name: a.MemberCreator.FullName}
+			}
+			comments = append(comments, comment)
+		}
+		if len(actions) < commentPageSize {
+			return comments, nil
+		}
+		args["before"] = actions[len(actions)-1].ID
+	}
+}
+
+// WriteComment posts comment, closing a code block it leaves open. A comment over MaxCommentLength
+// is split into numbered parts; one that would need more than MaxCommentParts is attached to the
+// card as a markdown file, with its beginning posted as the comment.
+func (tc *TrelloCard) WriteComment(comment string) error {
+	comment = bc.CloseFences(comment)
+	parts := bc.SplitComment(comment, MaxCommentLength)
+	if len(parts) > MaxCommentParts {
+		name := fmt.Sprintf("comment-%s.md", time.Now().UTC().Format("20060102-150405"))
+		if err := tc.attachFile(name, []byte(comment)); err != nil {
+			return err
+		}
+		return tc.postComment(bc.TruncateComment(comment, MaxCommentLength, fmt.Sprintf("\n\n… (truncated; the full text is attached as %s)", name)))
+	}
+	for _, p := range parts {
+		if err := tc.postComment(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postComment posts a single comment.
+func (tc *TrelloCard) postComment(comment string) error {
+	endpoint := fmt.Sprintf("%s/cards/%s/actions/comments", tc.Client.BaseURL, tc.ID)
+	values := url.Values{}
+	values.Set("text", comment)
+	values.Set("key", tc.BoardClient.APIKey)
+	values.Set("token", tc.BoardClient.Token)
+
+	resp, err := http.PostForm(endpoint, values)
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to post comment, status: %d, response: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// attachFile uploads data to the card as a file attachment.
+func (tc *TrelloCard) attachFile(name string, data []byte) error {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if err := w.WriteField("name", name); err != nil {
+		return fmt.Errorf("failed to build attachment: %w", err)
+	}
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		return fmt.Errorf("failed to build attachment: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("failed to build attachment: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to build attachment: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/cards/%s/attachments?key=%s&token=%s", tc.Client.BaseURL, tc.ID,
+		url.QueryEscape(tc.BoardClient.APIKey), url.QueryEscape(tc.BoardClient.Token))
+	resp, err := http.Post(endpoint, w.FormDataContentType(), body)
+	if err != nil {
+		return fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to upload attachment, status: %d, response: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
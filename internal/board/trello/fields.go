@@ -0,0 +1,158 @@
+package trelloClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adlio/trello"
+	bc "github.com/egobogo/aiagents/internal/board"
+)
+
+// customFields returns the board's custom field definitions, fetched once per client.
+func (tc *TrelloClient) customFields() ([]*trello.CustomField, error) {
+	tc.fieldsMu.Lock()
+	defer tc.fieldsMu.Unlock()
+	if tc.fields != nil {
+		return tc.fields, nil
+	}
+	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	fields, err := b.GetCustomFields(trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom fields: %w", err)
+	}
+	if fields == nil {
+		fields = []*trello.CustomField{}
+	}
+	tc.fields = fields
+	return fields, nil
+}
+
+// GetFields returns the card's custom field values keyed by lower-cased field name.
+func (tc *TrelloCard) GetFields() (map[string]string, error) {
+	defs, err := tc.BoardClient.customFields()
+	if err != nil {
+		return nil, err
+	}
+	tCard, err := tc.Client.GetCard(tc.ID, trello.Arguments{"customFieldItems": "true"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	fields := make(map[string]string)
+	for name, v := range tCard.CustomFields(defs) {
+		fields[strings.ToLower(name)] = fieldString(v)
+	}
+	return fields, nil
+}
+
+// SetField sets a custom field by name (case-insensitive); an empty value clears it.
+// Dropdown fields take the text of one of their options.
+func (tc *TrelloCard) SetField(name, value string) error {
+	defs, err := tc.BoardClient.customFields()
+	if err != nil {
+		return err
+	}
+	var def *trello.CustomField
+	for _, d := range defs {
+		if strings.EqualFold(d.Name, name) {
+			def = d
+			break
+		}
+	}
+	if def == nil {
+		return fmt.Errorf("%w: %s", bc.ErrNoField, name)
+	}
+	body, err := fieldItem(def, value)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode custom field: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/cards/%s/customField/%s/item?key=%s&token=%s", tc.Client.BaseURL, tc.ID, def.ID,
+		url.QueryEscape(tc.BoardClient.APIKey), url.QueryEscape(tc.BoardClient.Token))
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := tc.Client.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set custom field %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to set custom field %s, status: %d: %s", name, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// fieldItem builds the request body setting def to value.
+func fieldItem(def *trello.CustomField, value string) (map[string]interface{}, error) {
+	if def.Type == "list" {
+		if value == "" {
+			return map[string]interface{}{"idValue": ""}, nil
+		}
+		for _, o := range def.Options {
+			if strings.EqualFold(o.Value.Text, value) {
+				return map[string]interface{}{"idValue": o.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not an option of custom field %s", value, def.Name)
+	}
+	if value == "" {
+		return map[string]interface{}{"value": ""}, nil
+	}
+	switch def.Type {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("custom field %s needs a number, got %q", def.Name, value)
+		}
+		return map[string]interface{}{"value": map[string]string{"number": value}}, nil
+	case "checkbox":
+		checked, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("custom field %s needs true or false, got %q", def.Name, value)
+		}
+		return map[string]interface{}{"value": map[string]string{"checked": strconv.FormatBool(checked)}}, nil
+	case "date":
+		return map[string]interface{}{"value": map[string]string{"date": value}}, nil
+	default:
+		return map[string]interface{}{"value": map[string]string{"text": value}}, nil
+	}
+}
+
+// fieldString formats a decoded custom field value.
+func fieldString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case bool:
+		return strconv.FormatBool(x)
+	case time.Time:
+		return x.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(x)
+	}
+}
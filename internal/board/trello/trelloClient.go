@@ -3,15 +3,19 @@ package trelloClient
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
-	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/adlio/trello"
 	bc "github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
 )
 
+// maxAttachmentSize caps downloaded attachments; Trello accepts uploads up to 10 MB on free boards.
+const maxAttachmentSize = 10 << 20
+
 // -------------------------
 // Concrete TrelloBoardClient
 // -------------------------
@@ -22,6 +26,12 @@ type TrelloClient struct {
 	BoardID string
 	APIKey  string
 	Token   string
+
+	fieldsMu sync.Mutex
+	fields   []*trello.CustomField // Custom field definitions, loaded on first use.
+
+	membersMu sync.Mutex
+	members   *bc.MemberDirectory
 }
 
 // NewTrelloClient constructs a new TrelloClient.
@@ -35,6 +45,11 @@ func NewTrelloClient(apiKey, token, boardID string) *TrelloClient {
 	}
 }
 
+// SetHTTPClient replaces the HTTP client used for Trello API calls, e.g. with a recording transport.
+func (tc *TrelloClient) SetHTTPClient(c *http.Client) {
+	tc.Client.Client = c
+}
+
 func (tc *TrelloClient) GetName() string {
 	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
 	if err != nil {
@@ -51,7 +66,13 @@ func (tc *TrelloClient) GetURL() string {
 	return b.ShortURL
 }
 
+// GetMembers returns the board members, cached for bc.DefaultMemberTTL.
 func (tc *TrelloClient) GetMembers() ([]bc.Member, error) {
+	return tc.directory().Members()
+}
+
+// fetchMembers reads the board members from the API.
+func (tc *TrelloClient) fetchMembers() ([]bc.Member, error) {
 	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get board: %w", err)
@@ -63,13 +84,24 @@ func (tc *TrelloClient) GetMembers() ([]bc.Member, error) {
 	var result []bc.Member
 	for _, m := range members {
 		result = append(result, bc.Member{
-			ID:   m.ID,
-			Name: m.FullName,
+			ID:       m.ID,
+			Name:     m.FullName,
+			Username: m.Username,
 		})
 	}
 	return result, nil
 }
 
+// directory returns the client's member directory, created on first use with the configured aliases.
+func (tc *TrelloClient) directory() *bc.MemberDirectory {
+	tc.membersMu.Lock()
+	defer tc.membersMu.Unlock()
+	if tc.members == nil {
+		tc.members = bc.NewMemberDirectory(tc.fetchMembers, config.GetMemberAliases())
+	}
+	return tc.members
+}
+
 func (tc *TrelloClient) GetLists() ([]bc.List, error) {
 	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
 	if err != nil {
@@ -141,6 +173,14 @@ func (tc *TrelloClient) GetCards() ([]bc.Card, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cards: %w", err)
 	}
+	lists, err := tc.GetLists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+	listsByID := make(map[string]bc.List)
+	for _, l := range lists {
+		listsByID[l.GetID()] = l
+	}
 	var result []bc.Card
 	for _, c := range cards {
 		tcCard := &TrelloCard{
@@ -148,7 +188,9 @@ func (tc *TrelloClient) GetCards() ([]bc.Card, error) {
 			CardName:    c.Name,
 			Description: c.Desc,
 			URL:         c.ShortURL,
-			// List, BoardClient and Client should be set appropriately if needed.
+			List:        listsByID[c.IDList],
+			BoardClient: tc,
+			Client:      tc.Client,
 		}
 		result = append(result, tcCard)
 	}
@@ -160,6 +202,10 @@ func (tc *TrelloClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
 	if err != nil {
 		return nil, err
 	}
+	target, err := tc.directory().Lookup(userName)
+	if err != nil {
+		return nil, nil // Nobody by that name has cards.
+	}
 	var result []bc.Card
 	for _, card := range allCards {
 		members, err := card.GetAssignedMembers()
@@ -167,7 +213,7 @@ func (tc *TrelloClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
 			continue
 		}
 		for _, m := range members {
-			if strings.EqualFold(m.Name, userName) {
+			if m.ID == target.ID {
 				result = append(result, card)
 				break
 			}
@@ -227,6 +273,10 @@ type TrelloCard struct {
 	Client      *trello.Client
 }
 
+func (tc *TrelloCard) GetID() string {
+	return tc.ID
+}
+
 func (tc *TrelloCard) GetName() string {
 	return tc.CardName
 }
@@ -244,6 +294,23 @@ func (tc *TrelloCard) ChangeName(newName string) error {
 	return nil
 }
 
+func (tc *TrelloCard) GetDescription() string {
+	return tc.Description
+}
+
+func (tc *TrelloCard) ChangeDescription(newDescription string) error {
+	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
+	if err != nil {
+		return fmt.Errorf("failed to get card: %w", err)
+	}
+	args := trello.Arguments{"desc": newDescription}
+	if err := tCard.Update(args); err != nil {
+		return err
+	}
+	tc.Description = newDescription
+	return nil
+}
+
 func (tc *TrelloCard) GetURL() string {
 	return tc.URL
 }
@@ -278,49 +345,55 @@ func (tc *TrelloCard) Move(newListName string) error {
 	return tCard.Update(args)
 }
 
+// MoveToBottom moves the card to the end of its list.
+func (tc *TrelloCard) MoveToBottom() error {
+	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
+	if err != nil {
+		return fmt.Errorf("failed to get card: %w", err)
+	}
+	return tCard.MoveToBottomOfList()
+}
+
 func (tc *TrelloCard) GetAssignedMembers() ([]bc.Member, error) {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get card: %w", err)
 	}
+	known := make(map[string]bc.Member)
+	if all, err := tc.BoardClient.GetMembers(); err == nil {
+		for _, m := range all {
+			known[m.ID] = m
+		}
+	}
 	var members []bc.Member
 	for _, mID := range tCard.IDMembers {
+		if m, ok := known[mID]; ok {
+			members = append(members, m)
+			continue
+		}
 		member, err := tc.Client.GetMember(mID, trello.Defaults())
 		if err != nil {
 			continue
 		}
 		members = append(members, bc.Member{
-			ID:   member.ID,
-			Name: member.FullName,
+			ID:       member.ID,
+			Name:     member.FullName,
+			Username: member.Username,
 		})
 	}
 	return members, nil
 }
 
 func (tc *TrelloCard) AssignTo(userName string) error {
-	b, err := tc.Client.GetBoard(tc.BoardClient.BoardID, trello.Defaults())
+	member, err := tc.BoardClient.directory().Lookup(userName)
 	if err != nil {
-		return fmt.Errorf("failed to get board: %w", err)
-	}
-	members, err := b.GetMembers(trello.Defaults())
-	if err != nil {
-		return fmt.Errorf("failed to get board members: %w", err)
-	}
-	var targetID string
-	for _, m := range members {
-		if strings.EqualFold(m.Username, userName) || strings.EqualFold(m.FullName, userName) {
-			targetID = m.ID
-			break
-		}
-	}
-	if targetID == "" {
-		return fmt.Errorf("member %s not found", userName)
+		return err
 	}
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
 		return fmt.Errorf("failed to get card: %w", err)
 	}
-	args := trello.Arguments{"idMembers": targetID}
+	args := trello.Arguments{"idMembers": member.ID}
 	return tCard.Update(args)
 }
 
@@ -330,27 +403,13 @@ func (tc *TrelloCard) UnassignFrom(userName string) error {
 		return fmt.Errorf("failed to get card: %w", err)
 	}
 	current := tCard.IDMembers
-	b, err := tc.Client.GetBoard(tc.BoardClient.BoardID, trello.Defaults())
+	member, err := tc.BoardClient.directory().Lookup(userName)
 	if err != nil {
-		return fmt.Errorf("failed to get board: %w", err)
-	}
-	members, err := b.GetMembers(trello.Defaults())
-	if err != nil {
-		return fmt.Errorf("failed to get members: %w", err)
-	}
-	var targetID string
-	for _, m := range members {
-		if strings.EqualFold(m.Username, userName) || strings.EqualFold(m.FullName, userName) {
-			targetID = m.ID
-			break
-		}
-	}
-	if targetID == "" {
-		return fmt.Errorf("member %s not found", userName)
+		return err
 	}
 	var newMembers []string
 	for _, id := range current {
-		if id != targetID {
+		if id != member.ID {
 			newMembers = append(newMembers, id)
 		}
 	}
@@ -358,49 +417,6 @@ func (tc *TrelloCard) UnassignFrom(userName string) error {
 	return tCard.Update(args)
 }
 
-func (tc *TrelloCard) ReadComments() ([]bc.Comment, error) {
-	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get card: %w", err)
-	}
-	actions, err := tCard.GetActions(map[string]string{"filter": "commentCard"})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get comments: %w", err)
-	}
-	var comments []bc.Comment
-	for _, a := range actions {
-		// Use a.Data.Text instead of indexing a.Data.
-		text := a.Data.Text
-		if text == "" {
-			continue
-		}
-		comments = append(comments, bc.Comment{
-			Text: text,
-		})
-	}
-	return comments, nil
-}
-
-func (tc *TrelloCard) WriteComment(comment string) error {
-	endpoint := fmt.Sprintf("https://api.trello.com/1/cards/%s/actions/comments", tc.ID)
-	values := url.Values{}
-	values.Set("text", comment)
-	values.Set("key", tc.BoardClient.APIKey)
-	values.Set("token", tc.BoardClient.Token)
-
-	resp, err := http.PostForm(endpoint, values)
-	if err != nil {
-		return fmt.Errorf("failed to post comment: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to post comment, status: %d, response: %s", resp.StatusCode, string(body))
-	}
-	return nil
-}
-
 func (tc *TrelloCard) GetAttachments() ([]bc.Attachment, error) {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
@@ -436,3 +452,67 @@ func (tc *TrelloCard) AddAttachment(attachment bc.Attachment) error {
 	}
 	return nil
 }
+
+// ReadAttachment downloads an attachment. Files uploaded to Trello need the board's credentials,
+// which are only sent to Trello itself; links to other sites are fetched without them.
+func (tc *TrelloCard) ReadAttachment(attachment bc.Attachment) ([]byte, error) {
+	req, err := http.NewRequest("GET", attachment.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment request: %w", err)
+	}
+	if req.URL.Host == "trello.com" || strings.HasSuffix(req.URL.Host, ".trello.com") {
+		req.Header.Set("Authorization", fmt.Sprintf("OAuth oauth_consumer_key=%q, oauth_token=%q", tc.BoardClient.APIKey, tc.BoardClient.Token))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download attachment, status: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(data) > maxAttachmentSize {
+		return nil, fmt.Errorf("attachment %q is larger than %d bytes", attachment.Name, maxAttachmentSize)
+	}
+	return data, nil
+}
+
+// GetMoves returns the card's creation and list changes, oldest first.
+func (tc *TrelloCard) GetMoves() ([]bc.Move, error) {
+	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	actions, err := tCard.GetListChangeActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list changes: %w", err)
+	}
+	var moves []bc.Move
+	for _, a := range actions {
+		m := bc.Move{When: a.Date}
+		if a.Data == nil {
+			continue
+		}
+		if a.Data.ListBefore != nil {
+			m.From = a.Data.ListBefore.Name
+		}
+		if a.Data.ListAfter != nil {
+			m.To = a.Data.ListAfter.Name
+		} else if a.Data.List != nil {
+			m.To = a.Data.List.Name
+		}
+		if m.To == "" {
+			continue // Archiving, not a list change.
+		}
+		moves = append(moves, m)
+	}
+	// Trello returns newest first.
+	for i, j := 0, len(moves)-1; i < j; i, j = i+1, j-1 {
+		moves[i], moves[j] = moves[j], moves[i]
+	}
+	return moves, nil
+}
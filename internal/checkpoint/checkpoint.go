@@ -0,0 +1,157 @@
+// Package checkpoint persists per-ticket progress so an agent restarted mid-ticket
+// resumes from the last completed step instead of repeating board actions.
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Phases recorded while handling a ticket.
+const (
+	PhaseQuestionPosted = "question-posted"
+	PhaseReplyReceived  = "reply-received"
+	PhasePlanned        = "planned"
+	PhaseTicketsCreated = "tickets-created"
+)
+
+// Exchange is a clarification question together with the answer it received.
+type Exchange struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// Checkpoint is the saved progress of one agent on one ticket.
+type Checkpoint struct {
+	TicketID string    `json:"ticketId"`
+	Agent    string    `json:"agent"`
+	Phase    string    `json:"phase"`
+	Updated  time.Time `json:"updated"`
+
+	// Pending is a question already posted on the card that has not been answered yet.
+	Pending string `json:"pending,omitempty"`
+	// Seen counts the card's comment texts at the time Pending was posted, so a reply
+	// that arrived while the process was down is still recognised as new.
+	Seen map[string]int `json:"seen,omitempty"`
+	// Exchanges are the answered clarification questions, oldest first.
+	Exchanges []Exchange `json:"exchanges,omitempty"`
+
+	// Plan is the agent's decided output (e.g. a decomposition) so a resume does not ask the model again.
+	Plan json.RawMessage `json:"plan,omitempty"`
+	// Created maps the title of every card already created for the ticket to its ID.
+	Created map[string]string `json:"created,omitempty"`
+}
+
+// New returns an empty checkpoint for agent on ticketID.
+func New(agent, ticketID string) *Checkpoint {
+	return &Checkpoint{TicketID: ticketID, Agent: agent}
+}
+
+// MarkCreated records that a card was created for the ticket.
+func (c *Checkpoint) MarkCreated(title, cardID string) {
+	if c.Created == nil {
+		c.Created = make(map[string]string)
+	}
+	c.Created[title] = cardID
+	c.Phase = PhaseTicketsCreated
+}
+
+// Store persists checkpoints.
+type Store interface {
+	// Load returns the checkpoint of agent on ticketID, or nil when there is none.
+	Load(agent, ticketID string) (*Checkpoint, error)
+	// Save writes the checkpoint, replacing any previous one for the same agent and ticket.
+	Save(c *Checkpoint) error
+	// Clear removes the checkpoint once the ticket is finished.
+	Clear(agent, ticketID string) error
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FileStore keeps one JSON file per agent and ticket in a directory.
+type FileStore struct {
+	Dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates the directory if needed and returns a store backed by it.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (s *FileStore) path(agent, ticketID string) string {
+	name := unsafeNameChars.ReplaceAllString(agent, "-") + "_" + unsafeNameChars.ReplaceAllString(ticketID, "-") + ".json"
+	return filepath.Join(s.Dir, name)
+}
+
+// Load implements Store.
+func (s *FileStore) Load(agent, ticketID string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path(agent, ticketID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	return &c, nil
+}
+
+// Save implements Store. The file is replaced atomically so a crash never leaves a torn checkpoint.
+func (s *FileStore) Save(c *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.Updated = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	target := s.path(c.Agent, c.TicketID)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Clear implements Store.
+func (s *FileStore) Clear(agent, ticketID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(agent, ticketID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// EnvDir names the environment variable holding the checkpoint directory.
+const EnvDir = "AIAGENTS_CHECKPOINT_DIR"
+
+// FromEnv returns a FileStore in the directory named by AIAGENTS_CHECKPOINT_DIR, or nil when it is unset.
+func FromEnv() (Store, error) {
+	dir := os.Getenv(EnvDir)
+	if dir == "" {
+		return nil, nil
+	}
+	s, err := NewFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,117 @@
+package codecontext
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// Context modes.
+const (
+	ModeNone    = ""        // No repository context.
+	ModeFull    = "full"    // Every Go file in full.
+	ModeOutline = "outline" // Signatures only, full bodies for the touched files.
+	ModeRelated = "related" // Touched files in full plus outlines of their importers and imports only.
+)
+
+// MentionedFiles returns the files whose repository path or base name appears in text,
+// e.g. the paths a ticket description refers to.
+func MentionedFiles(text string, files []string) []string {
+	var mentioned []string
+	for _, f := range files {
+		if strings.Contains(text, f) || containsWord(text, path.Base(f)) {
+			mentioned = append(mentioned, f)
+		}
+	}
+	return mentioned
+}
+
+// containsWord reports whether name appears in text delimited by non-path characters.
+func containsWord(text, name string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], name)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(name)
+		if (start == 0 || !isPathChar(text[start-1])) && (end == len(text) || !isPathChar(text[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isPathChar(c byte) bool {
+	return c == '/' || c == '.' || c == '_' || c == '-' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// Build renders the Go files of the repository for a prompt. In ModeOutline the touched files are
+// included in full and every other file as an Outline; in ModeFull every file is included in full.
+// ModeRelated behaves like ModeOutline but drops the files unrelated to the touched ones in the
+// import graph; with nothing touched it covers the whole tree.
+// Files that fail to parse fall back to their full content.
+func Build(repo *gitrepo.GitClient, mode string, touched []string) (string, error) {
+	if mode == ModeNone {
+		return "", nil
+	}
+	files, err := repo.ListFiles("**/*.go")
+	if err != nil {
+		return "", err
+	}
+	if mode == ModeRelated && len(touched) > 0 {
+		files = newGraph(repo, files).Related(touched)
+	}
+	return build(repo, mode, files, touched)
+}
+
+// ForTicket builds the context for a ticket, treating the files its text mentions as touched.
+func ForTicket(repo *gitrepo.GitClient, mode, ticket string) (string, error) {
+	if mode == ModeNone {
+		return "", nil
+	}
+	files, err := repo.ListFiles("**/*.go")
+	if err != nil {
+		return "", err
+	}
+	touched := MentionedFiles(ticket, files)
+	if mode == ModeRelated && len(touched) > 0 {
+		files = newGraph(repo, files).Related(touched)
+	}
+	return build(repo, mode, files, touched)
+}
+
+func build(repo *gitrepo.GitClient, mode string, files, touched []string) (string, error) {
+	if mode != ModeFull && mode != ModeOutline && mode != ModeRelated {
+		return "", fmt.Errorf("unknown context mode %q", mode)
+	}
+	full := make(map[string]bool)
+	for _, f := range touched {
+		full[f] = true
+	}
+	return render(repo, files, func(f string) bool { return mode == ModeFull || full[f] })
+}
+
+// render writes the listed files, in full when inFull reports so and as outlines otherwise.
+func render(repo *gitrepo.GitClient, files []string, inFull func(string) bool) (string, error) {
+	sort.Strings(files)
+	var sb strings.Builder
+	for _, f := range files {
+		src, err := repo.ReadText(f)
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", f, err)
+			continue
+		}
+		kind, body := "full", src
+		if !inFull(f) {
+			if outline, err := Outline(f, []byte(src)); err == nil {
+				kind, body = "outline", outline
+			}
+		}
+		fmt.Fprintf(&sb, "### %s (%s)\n```go\n%s\n```\n\n", f, kind, strings.TrimRight(body, "\n"))
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,126 @@
+package codecontext
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// Graph is the package import graph of a Go repository, keyed by package directory.
+type Graph struct {
+	Module  string              // Module path from go.mod; empty when there is none.
+	Files   map[string][]string // Package directory -> its Go files.
+	Imports map[string][]string // Package directory -> directories of the repository packages it imports.
+}
+
+// BuildGraph parses the imports of every Go file in the repository. Imports outside the
+// repository are ignored; files that fail to parse only contribute their directory.
+func BuildGraph(repo *gitrepo.GitClient) (*Graph, error) {
+	files, err := repo.ListFiles("**/*.go")
+	if err != nil {
+		return nil, err
+	}
+	return newGraph(repo, files), nil
+}
+
+func newGraph(repo *gitrepo.GitClient, files []string) *Graph {
+	g := &Graph{Module: modulePath(repo), Files: make(map[string][]string), Imports: make(map[string][]string)}
+	for _, f := range files {
+		g.Files[path.Dir(f)] = append(g.Files[path.Dir(f)], f)
+	}
+	fset := token.NewFileSet()
+	for _, f := range files {
+		src, err := repo.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, f, src, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(f)
+		for _, imp := range parsed.Imports {
+			p, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			if dep, ok := g.resolve(p); ok && dep != dir && !contains(g.Imports[dir], dep) {
+				g.Imports[dir] = append(g.Imports[dir], dep)
+			}
+		}
+	}
+	return g
+}
+
+// resolve maps an import path to a package directory of the repository.
+func (g *Graph) resolve(importPath string) (string, bool) {
+	if g.Module != "" {
+		if importPath == g.Module {
+			_, ok := g.Files["."]
+			return ".", ok
+		}
+		dir := strings.TrimPrefix(importPath, g.Module+"/")
+		_, ok := g.Files[dir]
+		return dir, ok && dir != importPath
+	}
+	// Without go.mod, fall back to the longest directory the import path ends with.
+	best := ""
+	for dir := range g.Files {
+		if dir != "." && (importPath == dir || strings.HasSuffix(importPath, "/"+dir)) && len(dir) > len(best) {
+			best = dir
+		}
+	}
+	return best, best != ""
+}
+
+// Related returns the given files together with the files of their own packages, of the packages
+// they import directly and of the packages importing them, sorted.
+func (g *Graph) Related(files []string) []string {
+	dirs := make(map[string]bool)
+	for _, f := range files {
+		dir := path.Dir(f)
+		dirs[dir] = true
+		for _, dep := range g.Imports[dir] {
+			dirs[dep] = true
+		}
+		for importer, deps := range g.Imports {
+			if contains(deps, dir) {
+				dirs[importer] = true
+			}
+		}
+	}
+	var related []string
+	for dir := range dirs {
+		related = append(related, g.Files[dir]...)
+	}
+	sort.Strings(related)
+	return related
+}
+
+// modulePath reads the module path from the repository's go.mod, if any.
+func modulePath(repo *gitrepo.GitClient) string {
+	mod, err := repo.ReadText("go.mod")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(mod, "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module"); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
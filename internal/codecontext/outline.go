@@ -0,0 +1,139 @@
+// Package codecontext builds compact repository context for prompts: Go files are reduced to their
+// API surface, with full bodies kept only for the files a ticket touches.
+package codecontext
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+)
+
+// Outline reduces Go source to its package doc, imports, exported declarations with their doc comments,
+// full struct and interface definitions, and function signatures without bodies.
+func Outline(filename string, src []byte) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	var decls []ast.Decl
+	for _, d := range file.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() || (d.Recv != nil && !exportedReceiver(d.Recv)) {
+				continue
+			}
+			d.Body = nil
+			decls = append(decls, d)
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				decls = append(decls, d)
+				continue
+			}
+			var specs []ast.Spec
+			for _, s := range d.Specs {
+				switch s := s.(type) {
+				case *ast.TypeSpec:
+					if s.Name.IsExported() {
+						specs = append(specs, s)
+					}
+				case *ast.ValueSpec:
+					if anyExported(s.Names) {
+						specs = append(specs, s)
+					}
+				}
+			}
+			if len(specs) > 0 {
+				d.Specs = specs
+				decls = append(decls, d)
+			}
+		}
+	}
+	file.Decls = decls
+	// Only doc comments attached to kept declarations survive; free-floating comments are dropped.
+	file.Comments = keptComments(file)
+
+	var buf bytes.Buffer
+	if err := (&printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}).Fprint(&buf, fset, file); err != nil {
+		return "", fmt.Errorf("failed to print outline of %s: %w", filename, err)
+	}
+	return buf.String(), nil
+}
+
+// exportedReceiver reports whether a method's receiver type is exported.
+func exportedReceiver(recv *ast.FieldList) bool {
+	if len(recv.List) == 0 {
+		return false
+	}
+	t := recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.Ident:
+			return x.IsExported()
+		default:
+			return false
+		}
+	}
+}
+
+func anyExported(names []*ast.Ident) bool {
+	for _, n := range names {
+		if n.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// keptComments returns the comment groups still reachable from the pruned file.
+func keptComments(file *ast.File) []*ast.CommentGroup {
+	var groups []*ast.CommentGroup
+	if file.Doc != nil {
+		groups = append(groups, file.Doc)
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Doc != nil {
+				groups = append(groups, n.Doc)
+			}
+		case *ast.GenDecl:
+			if n.Doc != nil {
+				groups = append(groups, n.Doc)
+			}
+		case *ast.TypeSpec:
+			if n.Doc != nil {
+				groups = append(groups, n.Doc)
+			}
+			if n.Comment != nil {
+				groups = append(groups, n.Comment)
+			}
+		case *ast.ValueSpec:
+			if n.Doc != nil {
+				groups = append(groups, n.Doc)
+			}
+			if n.Comment != nil {
+				groups = append(groups, n.Comment)
+			}
+		case *ast.Field:
+			if n.Doc != nil {
+				groups = append(groups, n.Doc)
+			}
+			if n.Comment != nil {
+				groups = append(groups, n.Comment)
+			}
+		}
+		return true
+	})
+	return groups
+}
@@ -0,0 +1,102 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IsDependencyFile reports whether a repository-relative path is a Go module's go.mod or go.sum.
+func IsDependencyFile(p string) bool {
+	base := path.Base(p)
+	return base == "go.mod" || base == "go.sum"
+}
+
+// TidyGo runs "go mod tidy" in dir. It returns a *BuildError with the output when tidying fails;
+// directories without a go.mod are skipped.
+func TidyGo(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultVerifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", "mod", "tidy")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(out) == 0 {
+			return fmt.Errorf("failed to run go mod tidy: %w", err)
+		}
+		return &BuildError{Step: "go mod tidy", Output: strings.TrimSpace(string(out))}
+	}
+	return nil
+}
+
+// Requirements returns the modules a go.mod requires, mapped to their versions.
+func Requirements(gomod string) map[string]string {
+	reqs := make(map[string]string)
+	inBlock := false
+	for _, line := range strings.Split(gomod, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) >= 2 {
+			reqs[fields[0]] = fields[1]
+		}
+	}
+	return reqs
+}
+
+// DependencyChange is a module requirement added, removed or changed between two go.mod files.
+type DependencyChange struct {
+	Module string
+	From   string // Empty when the module was added.
+	To     string // Empty when the module was removed.
+}
+
+func (c DependencyChange) String() string {
+	switch {
+	case c.From == "":
+		return fmt.Sprintf("add %s %s", c.Module, c.To)
+	case c.To == "":
+		return fmt.Sprintf("remove %s %s", c.Module, c.From)
+	default:
+		return fmt.Sprintf("change %s %s => %s", c.Module, c.From, c.To)
+	}
+}
+
+// DiffRequirements compares the requirements of two go.mod files, ordered by module path.
+func DiffRequirements(before, after string) []DependencyChange {
+	old, updated := Requirements(before), Requirements(after)
+	var changes []DependencyChange
+	for mod, to := range updated {
+		if from := old[mod]; from != to {
+			changes = append(changes, DependencyChange{Module: mod, From: from, To: to})
+		}
+	}
+	for mod, from := range old {
+		if _, ok := updated[mod]; !ok {
+			changes = append(changes, DependencyChange{Module: mod, From: from})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Module < changes[j].Module })
+	return changes
+}
@@ -0,0 +1,208 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// stdlibImports maps package names commonly referenced by generated code to their import paths.
+// Only unambiguous standard library packages are listed; anything else must be imported explicitly.
+var stdlibImports = map[string]string{
+	"bufio":     "bufio",
+	"bytes":     "bytes",
+	"context":   "context",
+	"csv":       "encoding/csv",
+	"errors":    "errors",
+	"filepath":  "path/filepath",
+	"fmt":       "fmt",
+	"hex":       "encoding/hex",
+	"http":      "net/http",
+	"io":        "io",
+	"json":      "encoding/json",
+	"log":       "log",
+	"math":      "math",
+	"os":        "os",
+	"path":      "path",
+	"reflect":   "reflect",
+	"regexp":    "regexp",
+	"sort":      "sort",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"sync":      "sync",
+	"atomic":    "sync/atomic",
+	"time":      "time",
+	"url":       "net/url",
+	"utf8":      "unicode/utf8",
+	"unicode":   "unicode",
+	"exec":      "os/exec",
+	"base64":    "encoding/base64",
+	"sha256":    "crypto/sha256",
+	"rand":      "math/rand",
+	"httptest":  "net/http/httptest",
+	"testing":   "testing",
+	"slices":    "slices",
+	"maps":      "maps",
+	"embed":     "embed",
+	"signal":    "os/signal",
+	"template":  "text/template",
+	"xml":       "encoding/xml",
+	"ioutil":    "io/ioutil",
+	"net":       "net",
+	"runtime":   "runtime",
+	"tabwriter": "text/tabwriter",
+}
+
+var versionSuffix = regexp.MustCompile(`[./]v[0-9]+$`)
+
+// importName guesses the package name an import path is referred to by. The guess is only reliable
+// for the standard library; other packages may declare any name.
+func importName(importPath string) string {
+	base := path.Base(versionSuffix.ReplaceAllString(importPath, ""))
+	base = strings.TrimPrefix(base, "go-")
+	return strings.ReplaceAll(base, "-", "")
+}
+
+// isStdlib reports whether importPath belongs to the standard library, whose first path element has no dot.
+func isStdlib(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// FormatGo fixes the imports of a Go source file and formats it like gofmt.
+// Unused standard library and named imports are removed and missing standard library imports are added.
+// Source that does not parse is returned with the parse error so callers can feed it back to the model.
+func FormatGo(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return src, fmt.Errorf("failed to parse Go source: %w", err)
+	}
+
+	fixImports(file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return src, fmt.Errorf("failed to print Go source: %w", err)
+	}
+	// A second pass sorts and groups the rewritten import block.
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return src, fmt.Errorf("failed to format Go source: %w", err)
+	}
+	return out, nil
+}
+
+// fixImports drops imports that are never referenced and adds standard library imports
+// for package selectors that have no matching import. Only imports whose name is certain, named ones
+// and standard library packages, are dropped; the name of any other package cannot be told from its path.
+func fixImports(file *ast.File) {
+	// Identifiers used as the left side of a selector that did not resolve to a local declaration.
+	referenced := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+			referenced[id.Name] = true
+		}
+		return true
+	})
+
+	imported := make(map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		kept := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			p, _ := strconv.Unquote(imp.Path.Value)
+			name := importName(p)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			guessed := imp.Name == nil && !isStdlib(p)
+			if guessed || name == "_" || name == "." || p == "C" || referenced[name] {
+				kept = append(kept, spec)
+				imported[name] = true
+			}
+		}
+		gen.Specs = kept
+	}
+
+	var missing []string
+	for name := range referenced {
+		if imported[name] {
+			continue
+		}
+		if p, ok := stdlibImports[name]; ok {
+			missing = append(missing, p)
+		}
+	}
+
+	// Drop import declarations left empty, then add the missing ones in a fresh block.
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && len(gen.Specs) == 0 {
+			continue
+		}
+		decls = append(decls, decl)
+	}
+	file.Decls = decls
+
+	if len(missing) == 0 {
+		rebuildImportList(file)
+		return
+	}
+	var block *ast.GenDecl
+	if len(file.Decls) > 0 {
+		if gen, ok := file.Decls[0].(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			block = gen
+		}
+	}
+	if block == nil {
+		// Place the new block right after the package clause so no comments end up inside it.
+		pos := file.Name.End()
+		block = &ast.GenDecl{TokPos: pos, Tok: token.IMPORT, Lparen: pos, Rparen: pos}
+		file.Decls = append([]ast.Decl{block}, file.Decls...)
+	}
+	if !block.Lparen.IsValid() {
+		block.Lparen = block.Specs[0].Pos()
+		block.Rparen = block.Specs[0].End()
+	}
+	// New imports join the first group, which by convention holds the standard library.
+	pos := block.Rparen
+	if len(block.Specs) > 0 {
+		pos = block.Specs[0].Pos()
+	}
+	var added []ast.Spec
+	for _, p := range missing {
+		added = append(added, &ast.ImportSpec{Path: &ast.BasicLit{ValuePos: pos, Kind: token.STRING, Value: strconv.Quote(p)}})
+	}
+	block.Specs = append(added, block.Specs...)
+	rebuildImportList(file)
+}
+
+// rebuildImportList keeps file.Imports consistent with the import declarations.
+func rebuildImportList(file *ast.File) {
+	file.Imports = nil
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			file.Imports = append(file.Imports, spec.(*ast.ImportSpec))
+		}
+	}
+}
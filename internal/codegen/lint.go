@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LintCommand is the linter binary run by LintGo.
+const LintCommand = "golangci-lint"
+
+// ErrNoLinter is returned by LintGo when golangci-lint is not installed.
+var ErrNoLinter = errors.New("golangci-lint not found")
+
+// lintLine matches a finding in golangci-lint's text output: "path.go:12:5: message (linter)".
+var lintLine = regexp.MustCompile(`^(\S+\.go):(\d+)(?::(\d+))?: (.+) \(([\w-]+)\)$`)
+
+// LintIssue is a single linter finding.
+type LintIssue struct {
+	File    string // Repository-relative path.
+	Line    int
+	Column  int
+	Linter  string // e.g. "errcheck".
+	Message string
+}
+
+func (i LintIssue) String() string {
+	pos := i.File + ":" + strconv.Itoa(i.Line)
+	if i.Column > 0 {
+		pos += ":" + strconv.Itoa(i.Column)
+	}
+	return fmt.Sprintf("%s: %s (%s)", pos, i.Message, i.Linter)
+}
+
+// LintReport is the outcome of a lint run.
+type LintReport struct {
+	Packages []string // Linted package patterns, e.g. "./store".
+	Issues   []LintIssue
+}
+
+// String lists the findings one per line, or states that there are none.
+func (r *LintReport) String() string {
+	if len(r.Issues) == 0 {
+		return "No lint findings."
+	}
+	lines := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Files returns the files with findings in order.
+func (r *LintReport) Files() []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, issue := range r.Issues {
+		if !seen[issue.File] {
+			seen[issue.File] = true
+			files = append(files, issue.File)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ChangedPackages returns the package patterns ("./dir") of the Go files among paths.
+func ChangedPackages(paths []string) []string {
+	seen := make(map[string]bool)
+	var pkgs []string
+	for _, p := range paths {
+		if !strings.HasSuffix(p, ".go") {
+			continue
+		}
+		pkg := "./" + path.Dir(strings.TrimPrefix(p, "./"))
+		if pkg == "./." {
+			pkg = "."
+		}
+		if !seen[pkg] {
+			seen[pkg] = true
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// LintGo runs golangci-lint on packages in dir and parses its findings. Findings are reported in
+// the LintReport, not as an error; no packages yield an empty report without running the linter.
+func LintGo(dir string, packages []string) (*LintReport, error) {
+	report := &LintReport{Packages: packages}
+	if len(packages) == 0 {
+		return report, nil
+	}
+	bin, err := exec.LookPath(LintCommand)
+	if err != nil {
+		return nil, ErrNoLinter
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultVerifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, append([]string{"run", "--color=never"}, packages...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	for _, line := range strings.Split(string(out), "\n") {
+		m := lintLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		lineNo, _ := strconv.Atoi(m[2])
+		col, _ := strconv.Atoi(m[3])
+		report.Issues = append(report.Issues, LintIssue{
+			File:    path.Clean(strings.TrimPrefix(m[1], "./")),
+			Line:    lineNo,
+			Column:  col,
+			Message: m[4],
+			Linter:  m[5],
+		})
+	}
+	if err != nil && len(report.Issues) == 0 {
+		return nil, fmt.Errorf("failed to run %s: %w: %s", LintCommand, err, strings.TrimSpace(string(out)))
+	}
+	return report, nil
+}
@@ -0,0 +1,184 @@
+package codegen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultMigrationCommand applies SQL read from stdin to the scratch database named by "{db}".
+var DefaultMigrationCommand = []string{"sqlite3", "-bail", "{db}"}
+
+// ErrNoDatabase is returned by VerifyMigration when the migration command is not installed.
+var ErrNoDatabase = errors.New("migration command not found")
+
+// migrationFile matches numbered migration files, e.g. "0003_add_users.up.sql".
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a numbered pair of SQL files in a migrations directory.
+type Migration struct {
+	Version int
+	Name    string // Snake case, e.g. "add_users".
+	Up      string // Repository path of the file applying the change.
+	Down    string // Repository path of the file reverting it; empty when there is none.
+}
+
+// ID returns the file name prefix, e.g. "0003_add_users".
+func (m Migration) ID() string {
+	return strings.TrimSuffix(path.Base(m.Up), ".up.sql")
+}
+
+// MigrationError carries the output of a migration that did not apply.
+type MigrationError struct {
+	Step   string // e.g. "apply 0003_add_users.up.sql".
+	Output string
+}
+
+func (e *MigrationError) Error() string {
+	return fmt.Sprintf("%s failed:\n%s", e.Step, e.Output)
+}
+
+// ListMigrations returns the migrations in dir, relative to the repository at root, ordered by
+// version. A missing directory has no migrations.
+func ListMigrations(root, dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	byID := make(map[string]*Migration)
+	var migrations []*Migration
+	for _, e := range entries {
+		m := migrationFile.FindStringSubmatch(e.Name())
+		if m == nil || e.IsDir() {
+			continue
+		}
+		id := m[1] + "_" + m[2]
+		mig := byID[id]
+		if mig == nil {
+			version, _ := strconv.Atoi(m[1])
+			mig = &Migration{Version: version, Name: m[2]}
+			byID[id] = mig
+			migrations = append(migrations, mig)
+		}
+		if m[3] == "up" {
+			mig.Up = path.Join(dir, e.Name())
+		} else {
+			mig.Down = path.Join(dir, e.Name())
+		}
+	}
+	var result []Migration
+	for _, m := range migrations {
+		if m.Up != "" {
+			result = append(result, *m)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// NextMigration returns the migration following existing, named name, with file names padded
+// like the existing ones (four digits by default).
+func NextMigration(existing []Migration, dir, name string) Migration {
+	version, width := 1, 4
+	if len(existing) > 0 {
+		last := existing[len(existing)-1]
+		version = last.Version + 1
+		width = len(strings.SplitN(path.Base(last.Up), "_", 2)[0])
+	}
+	id := fmt.Sprintf("%0*d_%s", width, version, name)
+	return Migration{
+		Version: version,
+		Name:    name,
+		Up:      path.Join(dir, id+".up.sql"),
+		Down:    path.Join(dir, id+".down.sql"),
+	}
+}
+
+// MigrationName turns a description into a migration name, e.g. "Add users.email" into "add_users_email".
+func MigrationName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_"):
+			sb.WriteByte('_')
+		}
+	}
+	name := strings.TrimSuffix(sb.String(), "_")
+	if name == "" {
+		return "migration"
+	}
+	return name
+}
+
+// VerifyMigration applies every migration in order to a fresh scratch database, then rolls back
+// the last one and applies it again, proving that it applies cleanly and can be reverted. command
+// reads SQL on stdin; "{db}" in its arguments is replaced by the scratch database path. An empty
+// command uses DefaultMigrationCommand. It returns a *MigrationError for the first failing step.
+func VerifyMigration(root string, migrations []Migration, command []string) error {
+	if len(migrations) == 0 {
+		return nil
+	}
+	if len(command) == 0 {
+		command = DefaultMigrationCommand
+	}
+	bin, err := exec.LookPath(command[0])
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNoDatabase, command[0])
+	}
+	scratch, err := os.MkdirTemp("", "migrations-")
+	if err != nil {
+		return fmt.Errorf("failed to create scratch database: %w", err)
+	}
+	defer os.RemoveAll(scratch)
+	db := filepath.Join(scratch, "scratch.db")
+	var args []string
+	for _, a := range command[1:] {
+		args = append(args, strings.ReplaceAll(a, "{db}", db))
+	}
+
+	apply := func(step, file string) error {
+		sql, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultVerifyTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, bin, args...)
+		cmd.Dir = scratch
+		cmd.Stdin = strings.NewReader(string(sql))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			output := strings.TrimSpace(string(out))
+			if output == "" {
+				output = err.Error()
+			}
+			return &MigrationError{Step: step + " " + path.Base(file), Output: output}
+		}
+		return nil
+	}
+	for _, m := range migrations {
+		if err := apply("apply", m.Up); err != nil {
+			return err
+		}
+	}
+	last := migrations[len(migrations)-1]
+	if last.Down == "" {
+		return &MigrationError{Step: "roll back " + last.ID(), Output: "there is no down migration"}
+	}
+	if err := apply("roll back", last.Down); err != nil {
+		return err
+	}
+	return apply("re-apply", last.Up)
+}
@@ -0,0 +1,48 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultVerifyTimeout bounds a single build or vet run.
+const DefaultVerifyTimeout = 5 * time.Minute
+
+// BuildError carries the compiler or vet output of a failed verification step.
+type BuildError struct {
+	Step   string // Command that failed, e.g. "go build ./...".
+	Output string
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("%s failed:\n%s", e.Step, e.Output)
+}
+
+// VerifyGo runs "go build ./..." followed by "go vet ./..." in dir.
+// It returns a *BuildError describing the first failing step, or nil when both pass.
+// Directories without a go.mod are not Go modules and are skipped.
+func VerifyGo(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return nil
+	}
+	for _, args := range [][]string{{"build", "./..."}, {"vet", "./..."}} {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultVerifyTimeout)
+		cmd := exec.CommandContext(ctx, "go", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		cancel()
+		if err != nil {
+			step := "go " + strings.Join(args, " ")
+			if len(out) == 0 {
+				return fmt.Errorf("failed to run %s: %w", step, err)
+			}
+			return &BuildError{Step: step, Output: strings.TrimSpace(string(out))}
+		}
+	}
+	return nil
+}
@@ -1,20 +1,15 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
 
 // Config represents the entire YAML configuration.
 type Config struct {
-	Roles map[string]struct {
-		Name          string `yaml:"name" json:"name"`
-		Prompt        string `yaml:"prompt" json:"prompt"`
-		DefaultAction string `yaml:"defaultAction" json:"defaultAction"`
-		Actions       []struct {
-			ID     string `yaml:"id" json:"id"`
-			Name   string `yaml:"name" json:"name"`
-			Mode   string `yaml:"mode" json:"mode"`
-			Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
-		} `yaml:"actions" json:"actions"`
-	} `yaml:"roles" json:"roles"`
+	Roles map[string]RoleConfig `yaml:"roles" json:"roles"`
 
 	GlobalModes map[string]string `yaml:"globalModes" json:"globalModes"`
 
@@ -27,6 +22,294 @@ type Config struct {
 		CurrentStep string   `yaml:"currentStep" json:"currentStep"`
 		StepsOrder  []string `yaml:"stepsOrder" json:"stepsOrder"`
 	} `yaml:"workflowControl" json:"workflowControl"`
+
+	// DryRun makes agents print board writes, commits, pushes, pull requests and releases instead of performing them.
+	DryRun bool `yaml:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// ModelRouting maps request classes ("classification", "generation", "summarization") to model names.
+	// A route overrides the model of every role for its class.
+	ModelRouting map[string]string `yaml:"modelRouting,omitempty" json:"modelRouting,omitempty"`
+
+	// ModelProvider selects the model API agents use: "openai" (the default), "azure" or "gemini".
+	// Roles may override it with their own "provider".
+	ModelProvider string `yaml:"modelProvider,omitempty" json:"modelProvider,omitempty"`
+
+	// Azure configures the Azure OpenAI provider.
+	Azure *AzureSettings `yaml:"azure,omitempty" json:"azure,omitempty"`
+
+	// Gemini configures the Google Gemini provider.
+	Gemini *GeminiSettings `yaml:"gemini,omitempty" json:"gemini,omitempty"`
+
+	// Design configures where the Designer agent writes assets and design tokens.
+	Design *DesignSettings `yaml:"design,omitempty" json:"design,omitempty"`
+
+	// Migrations lets the Backend agent author numbered SQL migrations and check them against a scratch database.
+	Migrations *MigrationSettings `yaml:"migrations,omitempty" json:"migrations,omitempty"`
+
+	// ModelConcurrency caps the model requests in flight across all agents of the process; interactive
+	// requests are served before background context refreshes. Zero leaves requests unlimited.
+	ModelConcurrency int `yaml:"modelConcurrency,omitempty" json:"modelConcurrency,omitempty"`
+
+	// ModelPrices sets USD prices per million tokens by model name or name prefix, e.g. "gpt-4o",
+	// overriding the built-in prices used for cost reports.
+	ModelPrices map[string]ModelPrice `yaml:"modelPrices,omitempty" json:"modelPrices,omitempty"`
+
+	// ModelCache enables caching of model responses.
+	ModelCache *CacheSettings `yaml:"modelCache,omitempty" json:"modelCache,omitempty"`
+
+	// ContextLimit summarizes older conversation turns when a request nears the model's context window.
+	ContextLimit *ContextLimitSettings `yaml:"contextLimit,omitempty" json:"contextLimit,omitempty"`
+
+	// Escalation is the chain of actions taken when an agent gives up waiting for a reply.
+	Escalation []EscalationStep `yaml:"escalation,omitempty" json:"escalation,omitempty"`
+
+	// Boards lists the boards watched by one process. When empty, the single board from the environment is used.
+	Boards []BoardSettings `yaml:"boards,omitempty" json:"boards,omitempty"`
+
+	// BoardLanguage is the language people write the single board from the environment in, e.g. "German".
+	// Agents read it in English and ask their questions in it. Configured boards set their own Language.
+	BoardLanguage string `yaml:"boardLanguage,omitempty" json:"boardLanguage,omitempty"`
+
+	// Repos lists the repositories agents work on. Tickets pick one with a "repo:<name>" label
+	// or a "Repo: <name>" description line; when empty, the single repository from the environment is used.
+	Repos []RepoSettings `yaml:"repos,omitempty" json:"repos,omitempty"`
+
+	// MemberAliases maps names agents use to board member names, e.g. "backend": "egobogobackendagent".
+	MemberAliases map[string]string `yaml:"memberAliases,omitempty" json:"memberAliases,omitempty"`
+
+	// TicketRules are checked on generated technical tickets before any card is created.
+	TicketRules *TicketRules `yaml:"ticketRules,omitempty" json:"ticketRules,omitempty"`
+
+	// TicketTemplate shapes the descriptions of generated technical tickets.
+	TicketTemplate *TicketTemplate `yaml:"ticketTemplate,omitempty" json:"ticketTemplate,omitempty"`
+
+	// Assignment distributes the Engineering Manager's technical tickets among developer agents.
+	Assignment *AssignmentSettings `yaml:"assignment,omitempty" json:"assignment,omitempty"`
+
+	// Policy restricts what agents may modify.
+	Policy *PolicySettings `yaml:"policy,omitempty" json:"policy,omitempty"`
+
+	// Signing signs agents' commits, e.g. for branches that require signed commits.
+	Signing *SigningSettings `yaml:"signing,omitempty" json:"signing,omitempty"`
+
+	// Agents gives individual agents their own identity, keyed by agent name, e.g. "backend".
+	Agents map[string]AgentIdentity `yaml:"agents,omitempty" json:"agents,omitempty"`
+
+	// Schedules sets when recurring jobs run, keyed by job name ("agents", "orchestrator", "sentry",
+	// "standup", "branches" or "grooming"), as cron expressions, e.g. "0 9 * * mon-fri" or "@every 30s".
+	// Jobs without an entry keep their defaults; "standup", "branches" and "grooming" only run when set.
+	Schedules map[string]string `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+
+	// TrustedMembers lists the board member IDs whose comments agents accept as replies, e.g. the
+	// human approver and agents that answer each other. When empty, any other member's comment counts.
+	TrustedMembers []string `yaml:"trustedMembers,omitempty" json:"trustedMembers,omitempty"`
+}
+
+// AgentIdentity attributes one agent's commits and board actions to it. Credentials are read from the
+// named environment variables so secrets stay out of the config file; unset fields use the shared ones.
+type AgentIdentity struct {
+	AuthorName     string `yaml:"authorName,omitempty" json:"authorName,omitempty"`         // Git author; defaults to the agent name. Signing keys are looked up by it.
+	AuthorEmail    string `yaml:"authorEmail,omitempty" json:"authorEmail,omitempty"`       // Defaults to "<agent>@aiagents.local".
+	TrelloKeyEnv   string `yaml:"trelloKeyEnv,omitempty" json:"trelloKeyEnv,omitempty"`     // Env variable holding the agent's Trello API key.
+	TrelloTokenEnv string `yaml:"trelloTokenEnv,omitempty" json:"trelloTokenEnv,omitempty"` // Env variable holding the agent's Trello token.
+	ModelAPIKeyEnv string `yaml:"modelApiKeyEnv,omitempty" json:"modelApiKeyEnv,omitempty"` // Env variable holding the agent's OpenAI API key.
+}
+
+// SigningSettings selects the keys commits are signed with. A key in KeyringDir named after the
+// committing agent takes precedence over KeyFile.
+type SigningSettings struct {
+	Format        string `yaml:"format,omitempty" json:"format,omitempty"`               // "openpgp" or "ssh"; empty detects it from the key.
+	KeyFile       string `yaml:"keyFile,omitempty" json:"keyFile,omitempty"`             // Private key for agents without their own.
+	PassphraseEnv string `yaml:"passphraseEnv,omitempty" json:"passphraseEnv,omitempty"` // Env variable holding the key passphrase.
+	KeyringDir    string `yaml:"keyringDir,omitempty" json:"keyringDir,omitempty"`       // Keys named "<agent>.asc" or "<agent>.key".
+}
+
+// PolicySettings declares the limits enforced on agents' repository and board changes.
+type PolicySettings struct {
+	ProtectedPaths    []string `yaml:"protectedPaths,omitempty" json:"protectedPaths,omitempty"`       // e.g. [".github/**"]; unset uses the defaults.
+	MaxFilesPerCommit int      `yaml:"maxFilesPerCommit,omitempty" json:"maxFilesPerCommit,omitempty"` // Zero means unlimited.
+	Forbidden         []string `yaml:"forbidden,omitempty" json:"forbidden,omitempty"`                 // e.g. ["force-push", "move-card"].
+	ApproveDeletions  bool     `yaml:"approveDeletions,omitempty" json:"approveDeletions,omitempty"`   // File deletions need approval.
+	ApprovalPaths     []string `yaml:"approvalPaths,omitempty" json:"approvalPaths,omitempty"`         // Commits need approval; unset uses the defaults.
+}
+
+// RepoSettings describes one repository of the registry.
+type RepoSettings struct {
+	Name    string `yaml:"name" json:"name"` // e.g. "backend" or "frontend".
+	URL     string `yaml:"url,omitempty" json:"url,omitempty"`
+	Path    string `yaml:"path" json:"path"`                           // Local checkout; cloned from URL when missing.
+	Default bool   `yaml:"default,omitempty" json:"default,omitempty"` // Serves tickets without a repo hint.
+
+	// Clone limits the history and paths fetched when the checkout is created.
+	Clone *CloneSettings `yaml:"clone,omitempty" json:"clone,omitempty"`
+	// Auth holds the credentials for the remote; without it the GIT_* environment variables are used.
+	Auth *GitAuthSettings `yaml:"auth,omitempty" json:"auth,omitempty"`
+}
+
+// GitAuthSettings name the credentials used to clone, pull and push a repository. Secrets are read
+// from environment variables rather than stored in the configuration.
+type GitAuthSettings struct {
+	Method                string `yaml:"method" json:"method"`                                                   // "token-env" or "ssh"; empty is anonymous.
+	Username              string `yaml:"username,omitempty" json:"username,omitempty"`                           // Token auth; defaults to "git".
+	TokenEnv              string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"`                           // Variable holding the token.
+	SSHUser               string `yaml:"sshUser,omitempty" json:"sshUser,omitempty"`                             // Defaults to "git".
+	SSHKeyPath            string `yaml:"sshKeyPath,omitempty" json:"sshKeyPath,omitempty"`                       // e.g. a deploy key.
+	SSHKeyPassphraseEnv   string `yaml:"sshKeyPassphraseEnv,omitempty" json:"sshKeyPassphraseEnv,omitempty"`     // Variable holding the key's passphrase.
+	KnownHostsPath        string `yaml:"knownHostsPath,omitempty" json:"knownHostsPath,omitempty"`               // Defaults to ~/.ssh/known_hosts.
+	InsecureIgnoreHostKey bool   `yaml:"insecureIgnoreHostKey,omitempty" json:"insecureIgnoreHostKey,omitempty"` // Skip host key checks.
+}
+
+// CloneSettings keep clones of large repositories small.
+type CloneSettings struct {
+	Depth        int      `yaml:"depth,omitempty" json:"depth,omitempty"`               // e.g. 1 for a shallow clone; zero fetches all history.
+	SingleBranch bool     `yaml:"singleBranch,omitempty" json:"singleBranch,omitempty"` // Fetch only Branch or the default branch.
+	Branch       string   `yaml:"branch,omitempty" json:"branch,omitempty"`             // Empty uses the remote's default branch.
+	SparsePaths  []string `yaml:"sparsePaths,omitempty" json:"sparsePaths,omitempty"`   // Directories checked out; empty checks out everything.
+}
+
+// BoardSettings describes one board (project) and how to reach it.
+type BoardSettings struct {
+	Name     string `yaml:"name" json:"name"`                             // Short name used in ticket IDs, e.g. "web".
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"` // "trello" (default).
+	BoardID  string `yaml:"boardId" json:"boardId"`
+	// Credentials are read from the named environment variables so secrets stay out of the config file.
+	APIKeyEnv string `yaml:"apiKeyEnv,omitempty" json:"apiKeyEnv,omitempty"`
+	TokenEnv  string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"`
+	// Columns maps the standard lists ("Backlog", "Doing", ...) to this board's column names.
+	Columns map[string]string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	// Language people write tickets and comments in, e.g. "German"; empty means English.
+	Language string `yaml:"language,omitempty" json:"language,omitempty"`
+}
+
+// RoleConfig describes a single agent role.
+type RoleConfig struct {
+	Name          string `yaml:"name" json:"name"`
+	Prompt        string `yaml:"prompt" json:"prompt"`
+	DefaultAction string `yaml:"defaultAction" json:"defaultAction"`
+	Actions       []struct {
+		ID     string `yaml:"id" json:"id"`
+		Name   string `yaml:"name" json:"name"`
+		Mode   string `yaml:"mode" json:"mode"`
+		Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+	} `yaml:"actions" json:"actions"`
+
+	// Model settings for this role; unset fields keep the model client's defaults.
+	ModelSettings `yaml:",inline"`
+
+	// Scope restricts the repository paths the role may read and write, e.g. ["services/payments/**"].
+	Scope []string `yaml:"scope,omitempty" json:"scope,omitempty"`
+
+	// Clone gives each agent of the role its own shallow or sparse checkout of the repository.
+	Clone *CloneSettings `yaml:"clone,omitempty" json:"clone,omitempty"`
+}
+
+// ModelSettings selects the model and sampling parameters used by a role. Model applies to the
+// request classes ModelRouting has no route for.
+type ModelSettings struct {
+	Model           string   `yaml:"model,omitempty" json:"model,omitempty"`
+	Temperature     *float64 `yaml:"temperature,omitempty" json:"temperature,omitempty"`
+	TopP            *float64 `yaml:"topP,omitempty" json:"topP,omitempty"`
+	MaxTokens       int      `yaml:"maxTokens,omitempty" json:"maxTokens,omitempty"`
+	ReasoningEffort string   `yaml:"reasoningEffort,omitempty" json:"reasoningEffort,omitempty"` // "low", "medium" or "high".
+	Provider        string   `yaml:"provider,omitempty" json:"provider,omitempty"`               // "openai", "azure" or "gemini"; empty uses modelProvider.
+}
+
+// AzureSettings configures Azure OpenAI. Requests authenticate with an API key, or with Azure AD
+// (Entra ID) client credentials when TenantID and ClientID are set.
+type AzureSettings struct {
+	Endpoint        string            `yaml:"endpoint" json:"endpoint"`                                   // e.g. "https://contoso.openai.azure.com".
+	APIVersion      string            `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`           // Empty uses the provider's default.
+	APIKeyEnv       string            `yaml:"apiKeyEnv,omitempty" json:"apiKeyEnv,omitempty"`             // Defaults to AZURE_OPENAI_API_KEY.
+	TenantID        string            `yaml:"tenantId,omitempty" json:"tenantId,omitempty"`               // Azure AD tenant.
+	ClientID        string            `yaml:"clientId,omitempty" json:"clientId,omitempty"`               // Azure AD application.
+	ClientSecretEnv string            `yaml:"clientSecretEnv,omitempty" json:"clientSecretEnv,omitempty"` // Defaults to AZURE_CLIENT_SECRET.
+	Deployments     map[string]string `yaml:"deployments,omitempty" json:"deployments,omitempty"`         // Model name to deployment name; unlisted models are deployed under their own name.
+}
+
+// CacheSettings configures the model response cache.
+type CacheSettings struct {
+	Dir string        `yaml:"dir" json:"dir"`                     // Directory holding cached responses.
+	TTL time.Duration `yaml:"ttl,omitempty" json:"ttl,omitempty"` // e.g. "24h"; zero uses the default.
+}
+
+// GeminiSettings configures Google Gemini.
+type GeminiSettings struct {
+	APIKeyEnv string            `yaml:"apiKeyEnv,omitempty" json:"apiKeyEnv,omitempty"` // Defaults to GEMINI_API_KEY.
+	Model     string            `yaml:"model,omitempty" json:"model,omitempty"`         // Used for requests naming no Gemini model; empty uses the provider's default.
+	Models    map[string]string `yaml:"models,omitempty" json:"models,omitempty"`       // Model names used by roles and routing to Gemini models, e.g. "gpt-4o": "gemini-2.5-pro".
+	// SafetySettings maps harm categories to block thresholds, e.g.
+	// "HARM_CATEGORY_DANGEROUS_CONTENT": "BLOCK_ONLY_HIGH".
+	SafetySettings map[string]string `yaml:"safetySettings,omitempty" json:"safetySettings,omitempty"`
+}
+
+// DesignSettings configures the Designer agent. Generated assets are committed on a branch of
+// their own and proposed in a pull request for a human to approve.
+type DesignSettings struct {
+	AssetDir     string `yaml:"assetDir,omitempty" json:"assetDir,omitempty"`         // Repository directory for SVG assets and design tokens; defaults to "assets/design".
+	Brandbook    string `yaml:"brandbook,omitempty" json:"brandbook,omitempty"`       // Repository path of the brandbook; defaults to "docs/brandbook.md".
+	TargetBranch string `yaml:"targetBranch,omitempty" json:"targetBranch,omitempty"` // Branch design pull requests target; defaults to "main".
+}
+
+// MigrationSettings configures database migration authoring. Migrations are pairs of
+// "NNNN_name.up.sql" and "NNNN_name.down.sql" files.
+type MigrationSettings struct {
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"` // Repository directory of the migrations; defaults to "migrations".
+	// Command applies SQL read from stdin to a scratch database; "{db}" is replaced by its path.
+	// Defaults to ["sqlite3", "-bail", "{db}"].
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+// ModelPrice is what a model charges, in USD per million tokens.
+type ModelPrice struct {
+	Prompt     float64 `yaml:"prompt" json:"prompt"`
+	Completion float64 `yaml:"completion" json:"completion"`
+}
+
+// ContextLimitSettings controls when requests are summarized; zero values use the defaults.
+type ContextLimitSettings struct {
+	Threshold  float64 `yaml:"threshold,omitempty" json:"threshold,omitempty"`   // Fraction of the window, e.g. 0.8.
+	KeepRecent int     `yaml:"keepRecent,omitempty" json:"keepRecent,omitempty"` // Latest messages never summarized.
+	Window     int     `yaml:"window,omitempty" json:"window,omitempty"`         // Overrides the model's window in tokens.
+}
+
+// TicketRules is the quality gate for decomposed technical tickets. Zero values disable a rule;
+// duplicate and empty titles are always rejected.
+type TicketRules struct {
+	MinTickets       int      `yaml:"minTickets,omitempty" json:"minTickets,omitempty"`
+	MaxTickets       int      `yaml:"maxTickets,omitempty" json:"maxTickets,omitempty"`
+	MinTitleLength   int      `yaml:"minTitleLength,omitempty" json:"minTitleLength,omitempty"`
+	MaxTitleLength   int      `yaml:"maxTitleLength,omitempty" json:"maxTitleLength,omitempty"`
+	RequiredSections []string `yaml:"requiredSections,omitempty" json:"requiredSections,omitempty"` // e.g. ["Goal", "Spec", "Acceptance Criteria"].
+	Attempts         int      `yaml:"attempts,omitempty" json:"attempts,omitempty"`                 // Decompositions tried before giving up; zero uses the default.
+	MaxStoryPoints   float64  `yaml:"maxStoryPoints,omitempty" json:"maxStoryPoints,omitempty"`     // Larger tickets are split further before creation.
+	SplitDepth       int      `yaml:"splitDepth,omitempty" json:"splitDepth,omitempty"`             // How often a ticket may be split again; zero uses the default.
+}
+
+// TicketTemplate defines the body of generated technical tickets.
+type TicketTemplate struct {
+	Sections []string `yaml:"sections,omitempty" json:"sections,omitempty"` // Headings in order, e.g. ["Goal", "Spec", "Acceptance Criteria"].
+	Footer   string   `yaml:"footer,omitempty" json:"footer,omitempty"`     // Go template rendered below the sections, e.g. "Generated by {{.Agent}}".
+}
+
+// AssignmentSettings selects who receives newly created technical tickets.
+type AssignmentSettings struct {
+	Strategy   string              `yaml:"strategy,omitempty" json:"strategy,omitempty"` // "round-robin" (default), "least-loaded" or "capability".
+	Developers []DeveloperSettings `yaml:"developers" json:"developers"`
+}
+
+// DeveloperSettings describes a developer agent tickets can be assigned to.
+type DeveloperSettings struct {
+	Name   string   `yaml:"name" json:"name"`                         // Board member name of the agent.
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"` // Components or repositories it handles, e.g. ["api", "backend"].
+}
+
+// EscalationStep is a single action of the escalation chain.
+type EscalationStep struct {
+	Action   string `yaml:"action" json:"action"`                         // "comment", "notify", "reassign" or "move".
+	Message  string `yaml:"message,omitempty" json:"message,omitempty"`   // Text for "comment" and "notify"; "{card}" and "{url}" are replaced.
+	Channel  string `yaml:"channel,omitempty" json:"channel,omitempty"`   // Chat channel for "notify".
+	Assignee string `yaml:"assignee,omitempty" json:"assignee,omitempty"` // Board member for "reassign".
+	List     string `yaml:"list,omitempty" json:"list,omitempty"`         // Target list for "move".
 }
 
 // Step represents an individual step in the workflow.
@@ -50,8 +333,27 @@ var (
 	provider     ConfigProvider
 	loadedConfig *Config
 	ErrNotLoaded = fmt.Errorf("configuration not loaded")
+	dryRun       bool
 )
 
+// SetDryRun enables or disables dry-run mode regardless of the loaded configuration.
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// IsDryRun reports whether dry-run mode is enabled, either by SetDryRun, the loaded configuration
+// or the AIAGENTS_DRY_RUN environment variable.
+func IsDryRun() bool {
+	if dryRun {
+		return true
+	}
+	if loadedConfig != nil && loadedConfig.DryRun {
+		return true
+	}
+	v := os.Getenv("AIAGENTS_DRY_RUN")
+	return v == "1" || strings.EqualFold(v, "true")
+}
+
 // SetProvider sets the configuration provider.
 func SetProvider(p ConfigProvider) {
 	provider = p
@@ -74,12 +376,16 @@ func GetLoadedConfig() *Config {
 	return loadedConfig
 }
 
+// GetRoleInstruction returns the system prompt of a role, falling back to DefaultRolePrompts.
 func GetRoleInstruction(role string) (string, error) {
 	if loadedConfig == nil {
 		return "", ErrNotLoaded
 	}
 	r, ok := loadedConfig.Roles[role]
 	if !ok {
+		if prompt, ok := DefaultRolePrompts[role]; ok {
+			return prompt, nil
+		}
 		return "", fmt.Errorf("role %q not found", role)
 	}
 	return r.Prompt, nil
@@ -106,3 +412,229 @@ func GetRoleMode(role, mode string) (string, error) {
 	}
 	return "", fmt.Errorf("mode %q not found for role %q and no global mode available", mode, role)
 }
+
+// GetEscalationSteps returns the configured escalation chain, or nil when none is configured.
+func GetEscalationSteps() []EscalationStep {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Escalation
+}
+
+// GetTicketRules returns the configured ticket quality rules, or nil when none are configured.
+func GetTicketRules() *TicketRules {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.TicketRules
+}
+
+// GetTicketTemplate returns the configured ticket template, or nil when none is configured.
+func GetTicketTemplate() *TicketTemplate {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.TicketTemplate
+}
+
+// GetAssignment returns the ticket assignment settings, or nil when none are configured.
+func GetAssignment() *AssignmentSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Assignment
+}
+
+// GetRoleModelSettings returns the model settings configured for a role, or zero settings when none are configured.
+func GetRoleModelSettings(role string) ModelSettings {
+	if loadedConfig == nil {
+		return ModelSettings{}
+	}
+	return loadedConfig.Roles[role].ModelSettings
+}
+
+// GetRoleScope returns the repository path scope configured for a role, or nil for the whole repository.
+func GetRoleScope(role string) []string {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Roles[role].Scope
+}
+
+// GetRoleClone returns the clone settings of a role's own checkout, or nil when the role shares the repository.
+func GetRoleClone(role string) *CloneSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Roles[role].Clone
+}
+
+// GetAgentIdentity returns the identity configured for the named agent, or a zero identity that
+// uses the shared credentials.
+func GetAgentIdentity(name string) AgentIdentity {
+	if loadedConfig == nil {
+		return AgentIdentity{}
+	}
+	return loadedConfig.Agents[name]
+}
+
+// GetSchedule returns the cron expression configured for a recurring job, or "" when the job keeps its default.
+func GetSchedule(job string) string {
+	if loadedConfig == nil {
+		return ""
+	}
+	return loadedConfig.Schedules[job]
+}
+
+// GetTrustedMembers returns the board member IDs whose comments count as replies, or nil when any member's do.
+func GetTrustedMembers() []string {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.TrustedMembers
+}
+
+// GetModelRouting returns the configured model per request class, or nil when routing is not configured.
+func GetModelRouting() map[string]string {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.ModelRouting
+}
+
+// GetModelConcurrency returns the cap on concurrent model requests, or zero when they are unlimited.
+func GetModelConcurrency() int {
+	if loadedConfig == nil {
+		return 0
+	}
+	return loadedConfig.ModelConcurrency
+}
+
+// GetModelPrices returns the configured model prices, or nil when none are configured.
+func GetModelPrices() map[string]ModelPrice {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.ModelPrices
+}
+
+// GetModelProvider returns the model provider of a role: its own "provider", the configured
+// modelProvider, or "openai".
+func GetModelProvider(role string) string {
+	if p := GetRoleModelSettings(role).Provider; p != "" {
+		return p
+	}
+	if loadedConfig != nil && loadedConfig.ModelProvider != "" {
+		return loadedConfig.ModelProvider
+	}
+	return "openai"
+}
+
+// GetAzureSettings returns the Azure OpenAI settings, or nil when Azure is not configured.
+func GetAzureSettings() *AzureSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Azure
+}
+
+// GetGeminiSettings returns the Gemini settings, or nil when Gemini is not configured.
+func GetGeminiSettings() *GeminiSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Gemini
+}
+
+// GetDesignSettings returns the Designer settings, or nil when none are configured.
+func GetDesignSettings() *DesignSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Design
+}
+
+// GetMigrationSettings returns the migration settings, or nil when migration authoring is not configured.
+func GetMigrationSettings() *MigrationSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Migrations
+}
+
+// GetModelCache returns the model cache settings, or nil when caching is not configured.
+func GetModelCache() *CacheSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.ModelCache
+}
+
+// GetContextLimit returns the context limit settings, or nil when summarization is not configured.
+func GetContextLimit() *ContextLimitSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.ContextLimit
+}
+
+// GetMemberAliases returns the configured member aliases, or nil when none are configured.
+func GetMemberAliases() map[string]string {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.MemberAliases
+}
+
+// GetBoards returns the configured boards, or nil when a single board is used.
+func GetBoards() []BoardSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Boards
+}
+
+// GetBoardLanguages returns the language of each board by name ("" for the single board from the
+// environment), or nil when every board is written in English.
+func GetBoardLanguages() map[string]string {
+	if loadedConfig == nil {
+		return nil
+	}
+	languages := make(map[string]string)
+	for _, b := range loadedConfig.Boards {
+		if b.Language != "" {
+			languages[b.Name] = b.Language
+		}
+	}
+	if len(loadedConfig.Boards) == 0 && loadedConfig.BoardLanguage != "" {
+		languages[""] = loadedConfig.BoardLanguage
+	}
+	if len(languages) == 0 {
+		return nil
+	}
+	return languages
+}
+
+// GetRepos returns the configured repositories, or nil when a single repository is used.
+func GetRepos() []RepoSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Repos
+}
+
+// GetPolicy returns the configured policy, or nil when none is configured.
+func GetPolicy() *PolicySettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Policy
+}
+
+// GetSigning returns the commit signing settings, or nil when commits are not signed.
+func GetSigning() *SigningSettings {
+	if loadedConfig == nil {
+		return nil
+	}
+	return loadedConfig.Signing
+}
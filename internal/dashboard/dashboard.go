@@ -0,0 +1,379 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/killswitch"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/transcript"
+)
+
+// Defaults for the dashboard views.
+const (
+	DefaultRecentEvents = 50
+	DefaultWindow       = 24 * time.Hour
+)
+
+// EventSource provides the event log shown on the dashboard; *events.JSONLLog implements it.
+type EventSource interface {
+	Read(filter events.Filter) ([]events.Event, error)
+}
+
+// AgentView combines an agent's fleet status with its supervised ticket and usage.
+type AgentView struct {
+	orchestrator.AgentStatus
+	TicketID   string    `json:"ticketId,omitempty"`
+	Phase      string    `json:"phase,omitempty"`
+	PhaseSince time.Time `json:"phaseSince,omitempty"`
+	Stuck      bool      `json:"stuck"`
+	ModelCalls int       `json:"modelCalls"`
+	Tokens     int       `json:"tokens"` // Prompt tokens within the window.
+	Completion int       `json:"completionTokens"`
+	Errors     int       `json:"errors"` // Failed actions within the window.
+}
+
+// Status is everything the dashboard shows.
+type Status struct {
+	Generated   time.Time      `json:"generated"`
+	Killed      bool           `json:"killed"` // Kill switch engaged; all side-effectful actions are paused.
+	KillReason  string         `json:"killReason,omitempty"`
+	Agents      []AgentView    `json:"agents"`
+	TotalTokens int            `json:"totalTokens"` // Prompt tokens within the window.
+	Recent      []events.Event `json:"recent"`      // Newest first.
+	Failures    []events.Event `json:"failures"`
+	// Usage breaks the window's model calls down by agent, ticket and model.
+	Usage events.UsageReport `json:"usage"`
+	// Degraded is set while the model provider rate limits or is overloaded; agents wait until DegradedUntil.
+	Degraded       bool      `json:"degraded"`
+	DegradedUntil  time.Time `json:"degradedUntil,omitempty"`
+	DegradedReason string    `json:"degradedReason,omitempty"`
+	// ModelQueue is the state of the shared model request limiter, when one is configured.
+	ModelQueue *model.LimiterStats `json:"modelQueue,omitempty"`
+}
+
+// Server serves the fleet dashboard. Supervisor and Events are optional.
+type Server struct {
+	Fleet      *orchestrator.Fleet
+	Supervisor *orchestrator.Supervisor
+	Events     EventSource
+	Window     time.Duration // How far back usage and errors are counted; zero uses DefaultWindow.
+	// Transcripts, when set, serves per-ticket interaction histories.
+	Transcripts *transcript.Exporter
+	// ModelLimiter, when set, reports how many model requests are in flight and queued.
+	ModelLimiter *model.Limiter
+	// Token guards the control and transcript routes. Requests must send it as "Authorization: Bearer
+	// <token>" or as the token form value; the page shows its controls when opened with ?token=<token>.
+	// Without a token those routes are refused.
+	Token string
+}
+
+// NewServer creates a dashboard for fleet.
+func NewServer(fleet *orchestrator.Fleet, sup *orchestrator.Supervisor, log EventSource) *Server {
+	return &Server{Fleet: fleet, Supervisor: sup, Events: log, Window: DefaultWindow}
+}
+
+// Status collects the current dashboard state.
+func (s *Server) Status() (Status, error) {
+	st := Status{Generated: time.Now()}
+	st.Killed, st.KillReason = killswitch.Engaged()
+	st.Degraded, st.DegradedUntil, st.DegradedReason = model.Degraded()
+	if s.ModelLimiter != nil {
+		q := s.ModelLimiter.Stats()
+		st.ModelQueue = &q
+	}
+	views := make(map[string]*AgentView)
+	for _, a := range s.Fleet.Status() {
+		st.Agents = append(st.Agents, AgentView{AgentStatus: a})
+	}
+	for i := range st.Agents {
+		views[st.Agents[i].Name] = &st.Agents[i]
+	}
+
+	if s.Supervisor != nil {
+		stuck := make(map[string]bool)
+		for _, p := range s.Supervisor.Stuck() {
+			stuck[p.TicketID] = true
+		}
+		for _, p := range s.Supervisor.Snapshot() {
+			// The most recent phase wins when an agent has several tickets in flight.
+			if v, ok := views[p.Agent]; ok && !p.PhaseStarted.Before(v.PhaseSince) {
+				v.TicketID, v.Phase, v.PhaseSince, v.Stuck = p.TicketID, p.Phase, p.PhaseStarted, stuck[p.TicketID]
+			}
+		}
+	}
+
+	if s.Events != nil {
+		window := s.Window
+		if window <= 0 {
+			window = DefaultWindow
+		}
+		evs, err := s.Events.Read(events.Filter{Since: st.Generated.Add(-window)})
+		if err != nil {
+			return st, err
+		}
+		for i := len(evs) - 1; i >= 0; i-- {
+			e := evs[i]
+			if len(st.Recent) < DefaultRecentEvents {
+				st.Recent = append(st.Recent, e)
+			}
+			if e.Error != "" && len(st.Failures) < DefaultRecentEvents {
+				st.Failures = append(st.Failures, e)
+			}
+			if v := views[e.Agent]; e.Error != "" && v != nil {
+				v.Errors++
+			}
+		}
+		st.Usage = events.AggregateUsage(evs)
+		st.TotalTokens = st.Usage.Total.PromptTokens
+		for name, u := range st.Usage.ByAgent {
+			if v := views[name]; v != nil {
+				v.ModelCalls, v.Tokens, v.Completion = u.Calls, u.PromptTokens, u.CompletionTokens
+			}
+		}
+	}
+	return st, nil
+}
+
+// Handler returns the dashboard routes; those marked * require the Token:
+//
+//	GET  /                    HTML dashboard
+//	GET  /api/status          Status as JSON
+//	GET  /api/usage           Model usage as JSON; ?agent=, ?ticket= and ?since=<duration> narrow it
+//	POST /agents/{name}/pause *
+//	POST /agents/{name}/resume *
+//	POST /killswitch/engage   * Pause every side-effectful action
+//	POST /killswitch/release  *
+//	GET  /api/tickets/{id}/transcript?format=md|json *
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.page)
+	mux.HandleFunc("GET /api/status", s.apiStatus)
+	mux.HandleFunc("GET /api/usage", s.apiUsage)
+	mux.HandleFunc("POST /agents/{name}/pause", s.requireToken(s.control(s.Fleet.Pause)))
+	mux.HandleFunc("POST /agents/{name}/resume", s.requireToken(s.control(s.Fleet.Resume)))
+	mux.HandleFunc("POST /killswitch/engage", s.requireToken(s.control(func(string) error {
+		killswitch.Engage("engaged from the dashboard")
+		return nil
+	})))
+	mux.HandleFunc("POST /killswitch/release", s.requireToken(s.control(func(string) error {
+		killswitch.Release()
+		return nil
+	})))
+	mux.HandleFunc("GET /api/tickets/{id}/transcript", s.requireToken(s.transcript))
+	return mux
+}
+
+// requestToken returns the token sent with the request, from the Authorization header or the token form value.
+func requestToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return r.FormValue("token")
+}
+
+// authorized reports whether token matches the configured Token.
+func (s *Server) authorized(token string) bool {
+	return s.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+// requireToken refuses requests without the configured Token. Because the token is never sent
+// implicitly like a cookie, other sites cannot make a browser trigger these routes either.
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.Token == "" {
+			http.Error(w, "set a dashboard token to use this route", http.StatusForbidden)
+			return
+		}
+		if !s.authorized(requestToken(r)) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) transcript(w http.ResponseWriter, r *http.Request) {
+	if s.Transcripts == nil {
+		http.Error(w, "transcripts are not enabled", http.StatusNotFound)
+		return
+	}
+	t, err := s.Transcripts.Export(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	data, err := t.Render(format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format == transcript.FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	}
+	w.Write(data)
+}
+
+func (s *Server) apiStatus(w http.ResponseWriter, r *http.Request) {
+	st, err := s.Status()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(st)
+}
+
+// apiUsage aggregates the model calls matching the query. Without since, the dashboard window applies.
+func (s *Server) apiUsage(w http.ResponseWriter, r *http.Request) {
+	if s.Events == nil {
+		http.Error(w, "no event log configured", http.StatusNotFound)
+		return
+	}
+	window := s.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	if since := r.URL.Query().Get("since"); since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil {
+			http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		window = d
+	}
+	filter := events.Filter{Agent: r.URL.Query().Get("agent"), TicketID: r.URL.Query().Get("ticket"), Since: time.Now().Add(-window)}
+	report, err := events.ReadUsage(s.Events, filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func (s *Server) control(action func(name string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := action(r.PathValue("name")); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, orchestrator.ErrUnknownAgent) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		if r.Header.Get("Accept") == "application/json" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		// Forms send the token as a form value; keep it so the page still shows its controls.
+		http.Redirect(w, r, "/?token="+url.QueryEscape(r.FormValue("token")), http.StatusSeeOther)
+	}
+}
+
+// pageData is the HTML page's view of the status; Token is set when the page may show its controls.
+type pageData struct {
+	Status
+	Token string
+}
+
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	st, err := s.Status()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := pageData{Status: st}
+	if token := r.URL.Query().Get("token"); s.authorized(token) {
+		data.Token = token
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var pageTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"ago": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return time.Since(t).Round(time.Second).String() + " ago"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="15">
+<title>aiagents fleet</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.error { color: #b00; }
+.stuck { background: #fee; }
+.paused { color: #888; }
+</style>
+</head>
+<body>
+<h1>Fleet</h1>
+{{if .Killed}}
+<p class="error"><strong>Kill switch engaged:</strong> {{.KillReason}}</p>
+{{if .Token}}<form method="post" action="/killswitch/release"><input type="hidden" name="token" value="{{.Token}}"><button>Release kill switch</button></form>{{end}}
+{{else if .Token}}
+<form method="post" action="/killswitch/engage"><input type="hidden" name="token" value="{{.Token}}"><button>Engage kill switch</button></form>
+{{end}}
+{{if .Degraded}}
+<p class="error"><strong>Model provider degraded</strong> until {{.DegradedUntil.Format "15:04:05"}}: {{.DegradedReason}}</p>
+{{end}}
+<p>{{.TotalTokens}} prompt and {{.Usage.Total.CompletionTokens}} completion tokens in {{.Usage.Total.Calls}} model calls in the window. Updated {{.Generated.Format "15:04:05"}}.</p>
+{{with .ModelQueue}}<p>Model requests: {{.InFlight}}/{{.Max}} in flight, {{.Interactive}} interactive and {{.Background}} background queued.</p>{{end}}
+<table>
+<tr><th>Agent</th><th>Role</th><th>State</th><th>Ticket</th><th>Phase</th><th>Last run</th><th>Model calls</th><th>Tokens (prompt/completion)</th><th>Errors</th><th></th></tr>
+{{range .Agents}}
+<tr class="{{if .Stuck}}stuck{{end}} {{if .Paused}}paused{{end}}">
+<td>{{.Name}}</td>
+<td>{{.Role}}</td>
+<td>{{if .Paused}}paused{{else if .Running}}running{{else}}idle{{end}}</td>
+<td>{{.TicketID}}</td>
+<td>{{.Phase}}{{if .Stuck}} (stuck){{end}}</td>
+<td>{{ago .LastRun}}{{if .LastError}}<div class="error">{{.LastError}}</div>{{end}}</td>
+<td>{{.ModelCalls}}</td>
+<td>{{.Tokens}}/{{.Completion}}</td>
+<td>{{.Errors}}</td>
+<td>
+{{if $.Token}}{{if .Paused}}<form method="post" action="/agents/{{.Name}}/resume"><input type="hidden" name="token" value="{{$.Token}}"><button>Resume</button></form>
+{{else}}<form method="post" action="/agents/{{.Name}}/pause"><input type="hidden" name="token" value="{{$.Token}}"><button>Pause</button></form>{{end}}{{end}}
+</td>
+</tr>
+{{end}}
+</table>
+{{if .Failures}}
+<h2>Errors</h2>
+<table>
+<tr><th>Time</th><th>Agent</th><th>Action</th><th>Target</th><th>Error</th></tr>
+{{range .Failures}}<tr><td>{{.Time.Format "15:04:05"}}</td><td>{{.Agent}}</td><td>{{.Type}}</td><td>{{.Target}}</td><td class="error">{{.Error}}</td></tr>
+{{end}}
+</table>
+{{end}}
+<h2>Recent actions</h2>
+<table>
+<tr><th>Time</th><th>Agent</th><th>Ticket</th><th>Action</th><th>Target</th></tr>
+{{range .Recent}}<tr><td>{{.Time.Format "15:04:05"}}</td><td>{{.Agent}}</td><td>{{.TicketID}}</td><td>{{.Type}}</td><td>{{.Target}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
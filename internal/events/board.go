@@ -0,0 +1,177 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// recordingBoard records the write operations of a board on behalf of an agent.
+type recordingBoard struct {
+	board.BoardClient
+	rec   Recorder
+	agent string
+}
+
+// WrapBoard returns a BoardClient that records every card it creates or changes.
+func WrapBoard(b board.BoardClient, rec Recorder, agent string) board.BoardClient {
+	return &recordingBoard{BoardClient: b, rec: rec, agent: agent}
+}
+
+func (b *recordingBoard) wrap(c board.Card) board.Card {
+	if c == nil {
+		return nil
+	}
+	return &recordingCard{Card: c, board: b}
+}
+
+func (b *recordingBoard) wrapAll(cards []board.Card, err error) ([]board.Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]board.Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = b.wrap(c)
+	}
+	return wrapped, nil
+}
+
+func (b *recordingBoard) GetCards() ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *recordingBoard) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *recordingBoard) GetCardsFromList(listName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *recordingBoard) CreateCard(name, description, listName string) (board.Card, error) {
+	c, err := b.BoardClient.CreateCard(name, description, listName)
+	e := Event{Agent: b.agent, Type: CardCreated, Details: map[string]string{"name": name, "list": listName}, Error: errString(err)}
+	if c != nil {
+		e.Target = c.GetID()
+		e.TicketID = c.GetID()
+	}
+	b.rec.Record(e)
+	return b.wrap(c), err
+}
+
+// recordingCard records the write operations of a card.
+type recordingCard struct {
+	board.Card
+	board *recordingBoard
+}
+
+func (c *recordingCard) record(t Type, details map[string]string, err error) {
+	c.board.rec.Record(Event{
+		Agent:    c.board.agent,
+		TicketID: c.GetID(),
+		Type:     t,
+		Target:   c.GetID(),
+		Details:  details,
+		Error:    errString(err),
+	})
+}
+
+func (c *recordingCard) ChangeName(newName string) error {
+	err := c.Card.ChangeName(newName)
+	c.record(CardUpdated, map[string]string{"name": newName}, err)
+	return err
+}
+
+func (c *recordingCard) ChangeDescription(newDescription string) error {
+	err := c.Card.ChangeDescription(newDescription)
+	c.record(CardUpdated, map[string]string{"descriptionLength": strconv.Itoa(len(newDescription))}, err)
+	return err
+}
+
+func (c *recordingCard) Move(newListName string) error {
+	from := ""
+	if l, err := c.Card.GetList(); err == nil {
+		from = l.GetName()
+	}
+	err := c.Card.Move(newListName)
+	c.record(CardMoved, map[string]string{"from": from, "to": newListName}, err)
+	return err
+}
+
+func (c *recordingCard) AssignTo(userName string) error {
+	err := c.Card.AssignTo(userName)
+	c.record(CardAssigned, map[string]string{"assign": userName}, err)
+	return err
+}
+
+func (c *recordingCard) UnassignFrom(userName string) error {
+	err := c.Card.UnassignFrom(userName)
+	c.record(CardAssigned, map[string]string{"unassign": userName}, err)
+	return err
+}
+
+func (c *recordingCard) WriteComment(comment string) error {
+	err := c.Card.WriteComment(comment)
+	c.record(CommentPosted, map[string]string{"text": comment}, err)
+	return err
+}
+
+// WriteQuestion forwards to the wrapped card's question handling and records the question as a comment.
+func (c *recordingCard) WriteQuestion(question string) error {
+	err := board.WriteQuestion(c.Card, question)
+	c.record(CommentPosted, map[string]string{"text": question}, err)
+	return err
+}
+
+func (c *recordingCard) AddAttachment(attachment board.Attachment) error {
+	err := c.Card.AddAttachment(attachment)
+	c.record(CardUpdated, map[string]string{"attachment": attachment.URL}, err)
+	return err
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *recordingCard) GetMoves() ([]board.Move, error) {
+	if h, ok := c.Card.(board.CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *recordingCard) ReadAttachment(a board.Attachment) ([]byte, error) {
+	return board.ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *recordingCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(board.CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *recordingCard) SetField(name, value string) error {
+	f, ok := c.Card.(board.CardFields)
+	if !ok {
+		return board.ErrNoField
+	}
+	err := f.SetField(name, value)
+	c.record(CardUpdated, map[string]string{"field": name, "value": value}, err)
+	return err
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *recordingCard) ReadCommentsSince(since time.Time) ([]board.Comment, error) {
+	return board.ReadCommentsSince(c.Card, since)
+}
+
+func (c *recordingCard) MoveToBottom() error {
+	o, ok := c.Card.(board.CardOrdering)
+	if !ok {
+		return board.ErrNoOrdering
+	}
+	err := o.MoveToBottom()
+	c.record(CardUpdated, map[string]string{"position": "bottom"}, err)
+	return err
+}
@@ -0,0 +1,146 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of action recorded.
+type Type string
+
+const (
+	CommentPosted Type = "comment_posted"
+	CardCreated   Type = "card_created"
+	CardMoved     Type = "card_moved"
+	CardUpdated   Type = "card_updated"
+	CardAssigned  Type = "card_assigned"
+	FileWritten   Type = "file_written"
+	FileDeleted   Type = "file_deleted"
+	FileMoved     Type = "file_moved"
+	Commit        Type = "commit"
+	Push          Type = "push"
+	ModelCall     Type = "model_call"
+)
+
+// Event is a single externally visible action taken by an agent.
+type Event struct {
+	Time     time.Time         `json:"time"`
+	Agent    string            `json:"agent,omitempty"`
+	TicketID string            `json:"ticketId,omitempty"`
+	Type     Type              `json:"type"`
+	Target   string            `json:"target,omitempty"` // Card ID, file path, branch, model name, ...
+	Details  map[string]string `json:"details,omitempty"`
+	Error    string            `json:"error,omitempty"` // Set when the action failed.
+}
+
+// Recorder receives events. Implementations must be safe for concurrent use.
+type Recorder interface {
+	Record(e Event)
+}
+
+// Filter selects events when reading the log. Zero fields match everything.
+type Filter struct {
+	Agent    string
+	TicketID string
+	Types    []Type
+	Since    time.Time
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Event) bool {
+	if f.Agent != "" && e.Agent != f.Agent {
+		return false
+	}
+	if f.TicketID != "" && e.TicketID != f.TicketID {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONLLog is an append-only event log stored as one JSON object per line.
+type JSONLLog struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewJSONLLog creates a log writing to path. The file is created on first write.
+func NewJSONLLog(path string) *JSONLLog {
+	return &JSONLLog{Path: path}
+}
+
+// Record appends e to the log. Failures are reported as warnings so auditing never breaks an agent.
+func (l *JSONLLog) Record(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode event: %v\n", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("Warning: failed to open event log: %v\n", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		fmt.Printf("Warning: failed to write event: %v\n", err)
+	}
+}
+
+// Read returns the events matching filter in the order they were recorded.
+func (l *JSONLLog) Read(filter Filter) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	var result []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // Skip a torn last line after a crash.
+		}
+		if filter.Match(e) {
+			result = append(result, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return result, nil
+}
+
+// errString returns err's message, or "" for nil.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// recordingModel records a summary of every model call made by an agent.
+type recordingModel struct {
+	model.ModelClient
+	rec    Recorder
+	agent  string
+	ticket func() string
+}
+
+// WrapModel returns a ModelClient that records a summary of each chat call: model, latency, request
+// size and prompt and completion tokens, attributed to agent and to the ticket reported by ticket,
+// e.g. func() string { return base.CurrentTicketID }; ticket may be nil. Token counts come from the
+// provider when it reports them and are zero for cache hits. Otherwise they are counted with
+// model.CountTokens and marked "counted"; when the encoding's ranks cannot be loaded only estimates are
+// stored, apart from the token counts, so usage and cost never mix in guesses. Prompt and response text
+// are not stored.
+func WrapModel(m model.ModelClient, rec Recorder, agent string, ticket func() string) model.ModelClient {
+	return &recordingModel{ModelClient: m, rec: rec, agent: agent, ticket: ticket}
+}
+
+func (m *recordingModel) record(modelName string, messages []model.Message, usage *model.Usage, start time.Time, response string, err error) {
+	details := map[string]string{
+		"messages":   strconv.Itoa(len(messages)),
+		"durationMs": strconv.FormatInt(time.Since(start).Milliseconds(), 10),
+		"response":   strconv.Itoa(len(response)),
+	}
+	if usage != nil && usage.Model != "" {
+		modelName = usage.Model
+	}
+	switch {
+	case usage != nil && usage.Cached:
+		details["tokens"], details["completionTokens"], details["cached"] = "0", "0", "true"
+	case usage != nil && usage.PromptTokens+usage.CompletionTokens > 0:
+		details["tokens"] = strconv.Itoa(usage.PromptTokens)
+		details["completionTokens"] = strconv.Itoa(usage.CompletionTokens)
+	default:
+		if prompt, err := model.CountMessageTokens(messages, modelName); err == nil {
+			if completion, err := model.CountTokens(response, modelName); err == nil {
+				details["tokens"], details["completionTokens"] = strconv.Itoa(prompt), strconv.Itoa(completion)
+				details["counted"] = "true"
+				break
+			}
+		}
+		details["estimatedTokens"] = strconv.Itoa(model.EstimateMessageTokens(messages, modelName))
+		details["estimatedCompletionTokens"] = strconv.Itoa(model.EstimateTokens(response, modelName))
+		details["estimated"] = "true"
+	}
+	var ticketID string
+	if m.ticket != nil {
+		ticketID = m.ticket()
+	}
+	m.rec.Record(Event{
+		Agent:    m.agent,
+		TicketID: ticketID,
+		Type:     ModelCall,
+		Target:   modelName,
+		Details:  details,
+		Error:    errString(err),
+	})
+}
+
+func (m *recordingModel) Chat(prompt string) (string, error) {
+	start := time.Now()
+	resp, err := m.ModelClient.Chat(prompt)
+	m.record(m.GetModel(), []model.Message{{Role: "user", Content: prompt}}, nil, start, resp, err)
+	return resp, err
+}
+
+func (m *recordingModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	start := time.Now()
+	if req.Usage == nil {
+		req.Usage = &model.Usage{}
+	}
+	resp, err := m.ModelClient.ChatAdvanced(req)
+	m.record(req.Model, req.Input, req.Usage, start, resp, err)
+	return resp, err
+}
+
+func (m *recordingModel) ChatAdvancedParsed(req model.ChatRequest, target interface{}) error {
+	start := time.Now()
+	if req.Usage == nil {
+		req.Usage = &model.Usage{}
+	}
+	err := m.ModelClient.ChatAdvancedParsed(req, target)
+	var resp string
+	if err == nil {
+		if data, mErr := json.Marshal(target); mErr == nil {
+			resp = string(data)
+		}
+	}
+	m.record(req.Model, req.Input, req.Usage, start, resp, err)
+	return err
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps recording.
+func (m *recordingModel) ForRole(role string) model.ModelClient {
+	scoped, ok := m.ModelClient.(model.RoleScoped)
+	if !ok {
+		return m
+	}
+	return &recordingModel{ModelClient: scoped.ForRole(role), rec: m.rec, agent: m.agent, ticket: m.ticket}
+}
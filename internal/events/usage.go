@@ -0,0 +1,113 @@
+package events
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// Usage totals the model calls of one agent, ticket or model.
+type Usage struct {
+	Calls            int `json:"calls"`
+	Errors           int `json:"errors"`
+	Cached           int `json:"cached"` // Calls answered from the model cache.
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	// Unreported counts calls the provider reported no token usage for and whose tokens could not be
+	// counted locally; their tokens and cost are not included.
+	Unreported int           `json:"unreported"`
+	Latency    time.Duration `json:"latency"` // Total time spent waiting for answers.
+}
+
+// Tokens returns prompt and completion tokens together.
+func (u Usage) Tokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// AverageLatency returns the mean latency of a call, or zero without calls.
+func (u Usage) AverageLatency() time.Duration {
+	if u.Calls == 0 {
+		return 0
+	}
+	return u.Latency / time.Duration(u.Calls)
+}
+
+func (u *Usage) add(e Event) {
+	ms, _ := strconv.ParseInt(e.Details["durationMs"], 10, 64)
+	u.Calls++
+	// Older logs stored estimates as token counts; they are left out like calls without counts.
+	if _, ok := e.Details["tokens"]; ok && e.Details["estimated"] != "true" {
+		prompt, _ := strconv.Atoi(e.Details["tokens"])
+		completion, _ := strconv.Atoi(e.Details["completionTokens"])
+		u.PromptTokens += prompt
+		u.CompletionTokens += completion
+	} else {
+		u.Unreported++
+	}
+	u.Latency += time.Duration(ms) * time.Millisecond
+	if e.Error != "" {
+		u.Errors++
+	}
+	if e.Details["cached"] == "true" {
+		u.Cached++
+	}
+}
+
+// UsageReport aggregates model calls overall and per agent, ticket and model. Calls made outside
+// any ticket are counted under the empty ticket ID.
+type UsageReport struct {
+	Total    Usage            `json:"total"`
+	ByAgent  map[string]Usage `json:"byAgent"`
+	ByTicket map[string]Usage `json:"byTicket"`
+	ByModel  map[string]Usage `json:"byModel"`
+}
+
+// AggregateUsage totals the model_call events among evs.
+func AggregateUsage(evs []Event) UsageReport {
+	r := UsageReport{ByAgent: map[string]Usage{}, ByTicket: map[string]Usage{}, ByModel: map[string]Usage{}}
+	for _, e := range evs {
+		if e.Type != ModelCall {
+			continue
+		}
+		r.Total.add(e)
+		for _, group := range []struct {
+			m   map[string]Usage
+			key string
+		}{{r.ByAgent, e.Agent}, {r.ByTicket, e.TicketID}, {r.ByModel, e.Target}} {
+			u := group.m[group.key]
+			u.add(e)
+			group.m[group.key] = u
+		}
+	}
+	return r
+}
+
+// Cost estimates the USD cost of the report from model prices. Models without a known price are
+// left out of the sum and returned, sorted, so reports can say the estimate is incomplete.
+func (r UsageReport) Cost() (usd float64, unpriced []string) {
+	for name, u := range r.ByModel {
+		if u.Tokens() == 0 {
+			continue
+		}
+		price, ok := model.PriceFor(name)
+		if !ok {
+			unpriced = append(unpriced, name)
+			continue
+		}
+		usd += price.Cost(u.PromptTokens, u.CompletionTokens)
+	}
+	sort.Strings(unpriced)
+	return usd, unpriced
+}
+
+// ReadUsage reads the model calls matching filter from log and aggregates them.
+func ReadUsage(log interface{ Read(Filter) ([]Event, error) }, filter Filter) (UsageReport, error) {
+	filter.Types = []Type{ModelCall}
+	evs, err := log.Read(filter)
+	if err != nil {
+		return UsageReport{}, err
+	}
+	return AggregateUsage(evs), nil
+}
@@ -0,0 +1,217 @@
+// Package figma reads frames and components from the Figma REST API and exports them as images.
+package figma
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultBaseURL is the Figma REST API.
+const DefaultBaseURL = "https://api.figma.com"
+
+// EnvToken is the environment variable holding the personal access token.
+const EnvToken = "FIGMA_TOKEN"
+
+// maxImageSize caps downloaded exports.
+const maxImageSize = 20 << 20
+
+// Node types that are exported as screens or assets.
+const (
+	TypeFrame        = "FRAME"
+	TypeComponent    = "COMPONENT"
+	TypeComponentSet = "COMPONENT_SET"
+	TypeInstance     = "INSTANCE"
+	TypeCanvas       = "CANVAS"
+	TypeSection      = "SECTION"
+)
+
+// Node is a frame, component or other node of a Figma file.
+type Node struct {
+	ID       string `json:"id"` // e.g. "12:34".
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Children []Node `json:"children,omitempty"`
+}
+
+// Link is a reference to a Figma file, or to a node in it, found in a ticket.
+type Link struct {
+	FileKey string
+	NodeID  string // Empty when the link points at the whole file.
+}
+
+// linkPattern matches Figma file links, e.g. "https://www.figma.com/design/KEY/Name?node-id=12-34".
+var linkPattern = regexp.MustCompile(`https://(?:www\.)?figma\.com/(?:file|design|proto)/([A-Za-z0-9]+)[^\s)>\]]*`)
+
+// FindLinks returns the distinct Figma links in text, in order of appearance.
+func FindLinks(text string) []Link {
+	var links []Link
+	seen := make(map[Link]bool)
+	for _, m := range linkPattern.FindAllStringSubmatch(text, -1) {
+		l := Link{FileKey: m[1]}
+		if u, err := url.Parse(m[0]); err == nil {
+			// Links copied from the editor write "12-34"; the API expects "12:34".
+			l.NodeID = strings.ReplaceAll(u.Query().Get("node-id"), "-", ":")
+		}
+		if !seen[l] {
+			seen[l] = true
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// NodeURL returns the link opening a node in the Figma editor.
+func NodeURL(fileKey, nodeID string) string {
+	return "https://www.figma.com/design/" + fileKey + "?node-id=" + url.QueryEscape(strings.ReplaceAll(nodeID, ":", "-"))
+}
+
+// Client calls the Figma REST API.
+type Client struct {
+	Token      string // Personal access token with file read scope.
+	BaseURL    string // e.g. "https://api.figma.com".
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client. An empty baseURL defaults to DefaultBaseURL.
+func NewClient(token, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{Token: token, BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: &http.Client{}}
+}
+
+// get performs an authenticated GET request and decodes the JSON response into out.
+func (c *Client) get(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Figma-Token", c.Token)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("figma API returned status %d: %s", resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// Nodes returns the nodes with the given IDs and their direct children. Unknown IDs are left out.
+func (c *Client) Nodes(fileKey string, ids ...string) ([]Node, error) {
+	var resp struct {
+		Nodes map[string]*struct {
+			Document Node `json:"document"`
+		} `json:"nodes"`
+	}
+	path := fmt.Sprintf("/v1/files/%s/nodes?ids=%s&depth=1", url.PathEscape(fileKey), url.QueryEscape(strings.Join(ids, ",")))
+	if err := c.get(path, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+	var nodes []Node
+	for _, id := range ids {
+		if n := resp.Nodes[id]; n != nil {
+			nodes = append(nodes, n.Document)
+		}
+	}
+	return nodes, nil
+}
+
+// Pages returns the pages of a file with their top-level nodes.
+func (c *Client) Pages(fileKey string) ([]Node, error) {
+	var resp struct {
+		Document Node `json:"document"`
+	}
+	if err := c.get("/v1/files/"+url.PathEscape(fileKey)+"?depth=2", &resp); err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return resp.Document.Children, nil
+}
+
+// ExportImages renders nodes and returns the download URL of each image by node ID. format is
+// "png", "jpg", "svg" or "pdf"; scale applies to raster formats.
+func (c *Client) ExportImages(fileKey, format string, scale float64, ids ...string) (map[string]string, error) {
+	var resp struct {
+		Err    *string           `json:"err"`
+		Images map[string]string `json:"images"`
+	}
+	path := fmt.Sprintf("/v1/images/%s?ids=%s&format=%s&scale=%g", url.PathEscape(fileKey), url.QueryEscape(strings.Join(ids, ",")), url.QueryEscape(format), scale)
+	if err := c.get(path, &resp); err != nil {
+		return nil, fmt.Errorf("failed to export images: %w", err)
+	}
+	if resp.Err != nil && *resp.Err != "" {
+		return nil, fmt.Errorf("failed to export images: %s", *resp.Err)
+	}
+	return resp.Images, nil
+}
+
+// Download fetches an exported image. Export URLs are pre-signed and need no token.
+func (c *Client) Download(imageURL string) ([]byte, error) {
+	resp, err := c.HTTPClient.Get(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image, status: %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	}
+	return data, nil
+}
+
+// Screens returns the frames and components a link refers to: the node itself, or the top-level
+// frames and components of a page, section or, for links without a node, of the whole file.
+func (c *Client) Screens(l Link) ([]Node, error) {
+	var nodes []Node
+	var err error
+	if l.NodeID == "" {
+		nodes, err = c.Pages(l.FileKey)
+	} else {
+		nodes, err = c.Nodes(l.FileKey, l.NodeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var screens []Node
+	for _, n := range nodes {
+		switch n.Type {
+		case TypeCanvas, TypeSection:
+			for _, child := range n.Children {
+				if IsScreen(child) {
+					screens = append(screens, child)
+				}
+			}
+		default:
+			screens = append(screens, n)
+		}
+	}
+	return screens, nil
+}
+
+// IsScreen reports whether n is a frame or component worth exporting on its own.
+func IsScreen(n Node) bool {
+	switch n.Type {
+	case TypeFrame, TypeComponent, TypeComponentSet, TypeInstance:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Package gitlabapi holds the request helper shared by the GitLab board and VCS clients.
+package gitlabapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the REST API root of gitlab.com.
+const DefaultBaseURL = "https://gitlab.com/api/v4"
+
+// PageSize is the number of items list requests ask for per page, GitLab's maximum.
+const PageSize = 100
+
+// Do performs a request against the GitLab REST API at baseURL+path, authenticated with token,
+// and decodes the JSON response into out (if non-nil). payload, if non-nil, is sent as JSON.
+func Do(client *http.Client, baseURL, token, method, path string, payload, out interface{}) error {
+	body := &bytes.Buffer{}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewBuffer(data)
+	}
+	req, err := http.NewRequest(method, baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("gitlab API returned status %d: %s", resp.StatusCode, string(respBytes))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBytes, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// GetAll reads every page of the list endpoint at path, which may carry a query, and returns the
+// items of all pages. Pages are requested until one comes back short.
+func GetAll[T any](client *http.Client, baseURL, token, path string) ([]T, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := Do(client, baseURL, token, "GET", fmt.Sprintf("%s%sper_page=%d&page=%d", path, sep, PageSize, page), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < PageSize {
+			return all, nil
+		}
+	}
+}
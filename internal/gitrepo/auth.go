@@ -0,0 +1,132 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	cryptossh "golang.org/x/crypto/ssh"
+)
+
+// AuthMethod selects how the GitClient authenticates against the remote.
+type AuthMethod string
+
+const (
+	AuthNone     AuthMethod = ""          // Anonymous access (public repositories).
+	AuthBasic    AuthMethod = "basic"     // HTTP basic auth with a username and password/token.
+	AuthTokenEnv AuthMethod = "token-env" // HTTP basic auth with a token read from an environment variable.
+	AuthSSH      AuthMethod = "ssh"       // SSH public key (e.g. a deploy key).
+)
+
+// AuthConfig holds the credentials used for clone, pull and push.
+type AuthConfig struct {
+	Method AuthMethod `yaml:"method" json:"method"`
+
+	// Basic / token auth.
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	TokenEnv string `yaml:"tokenEnv,omitempty" json:"tokenEnv,omitempty"` // Name of the env variable holding the token.
+
+	// SSH auth.
+	SSHUser               string `yaml:"sshUser,omitempty" json:"sshUser,omitempty"` // Defaults to "git".
+	SSHKeyPath            string `yaml:"sshKeyPath,omitempty" json:"sshKeyPath,omitempty"`
+	SSHKeyPassphrase      string `yaml:"sshKeyPassphrase,omitempty" json:"sshKeyPassphrase,omitempty"`
+	KnownHostsPath        string `yaml:"knownHostsPath,omitempty" json:"knownHostsPath,omitempty"` // Defaults to ~/.ssh/known_hosts.
+	InsecureIgnoreHostKey bool   `yaml:"insecureIgnoreHostKey,omitempty" json:"insecureIgnoreHostKey,omitempty"`
+}
+
+// AuthConfigFrom converts repository credentials from the configuration; nil is anonymous.
+func AuthConfigFrom(c *config.GitAuthSettings) AuthConfig {
+	if c == nil {
+		return AuthConfig{}
+	}
+	auth := AuthConfig{
+		Method:                AuthMethod(c.Method),
+		Username:              c.Username,
+		TokenEnv:              c.TokenEnv,
+		SSHUser:               c.SSHUser,
+		SSHKeyPath:            c.SSHKeyPath,
+		KnownHostsPath:        c.KnownHostsPath,
+		InsecureIgnoreHostKey: c.InsecureIgnoreHostKey,
+	}
+	if c.SSHKeyPassphraseEnv != "" {
+		auth.SSHKeyPassphrase = os.Getenv(c.SSHKeyPassphraseEnv)
+	}
+	return auth
+}
+
+// AuthFromEnv returns the credentials named by the environment: an SSH key at GIT_SSH_KEY_PATH
+// (with GIT_SSH_USER, GIT_SSH_KEY_PASSPHRASE and GIT_KNOWN_HOSTS), otherwise a token in GIT_TOKEN
+// sent as GIT_USERNAME. Without either access is anonymous.
+func AuthFromEnv() AuthConfig {
+	if key := os.Getenv("GIT_SSH_KEY_PATH"); key != "" {
+		return AuthConfig{
+			Method:           AuthSSH,
+			SSHUser:          os.Getenv("GIT_SSH_USER"),
+			SSHKeyPath:       key,
+			SSHKeyPassphrase: os.Getenv("GIT_SSH_KEY_PASSPHRASE"),
+			KnownHostsPath:   os.Getenv("GIT_KNOWN_HOSTS"),
+		}
+	}
+	if os.Getenv("GIT_TOKEN") != "" {
+		return AuthConfig{Method: AuthTokenEnv, Username: os.Getenv("GIT_USERNAME"), TokenEnv: "GIT_TOKEN"}
+	}
+	return AuthConfig{}
+}
+
+// transportAuth converts the configuration into a go-git transport.AuthMethod.
+// It returns nil for anonymous access.
+func (c AuthConfig) transportAuth() (transport.AuthMethod, error) {
+	switch c.Method {
+	case AuthNone:
+		return nil, nil
+	case AuthBasic:
+		return &http.BasicAuth{Username: c.Username, Password: c.Password}, nil
+	case AuthTokenEnv:
+		token := os.Getenv(c.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("environment variable %s is empty", c.TokenEnv)
+		}
+		username := c.Username
+		if username == "" {
+			username = "git" // For GitHub/GitLab any non-empty username works with a token.
+		}
+		return &http.BasicAuth{Username: username, Password: token}, nil
+	case AuthSSH:
+		user := c.SSHUser
+		if user == "" {
+			user = "git"
+		}
+		keys, err := ssh.NewPublicKeysFromFile(user, c.SSHKeyPath, c.SSHKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SSH key %s: %w", c.SSHKeyPath, err)
+		}
+		if c.InsecureIgnoreHostKey {
+			keys.HostKeyCallback = cryptossh.InsecureIgnoreHostKey()
+			return keys, nil
+		}
+		var files []string
+		if c.KnownHostsPath != "" {
+			files = append(files, c.KnownHostsPath)
+		}
+		callback, err := ssh.NewKnownHostsCallback(files...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load known_hosts: %w", err)
+		}
+		keys.HostKeyCallback = callback
+		return keys, nil
+	default:
+		return nil, fmt.Errorf("unsupported auth method %q", c.Method)
+	}
+}
+
+// authFor returns explicit basic credentials when provided, otherwise the client's configured auth.
+func (g *GitClient) authFor(username, token string) (transport.AuthMethod, error) {
+	if username != "" || token != "" {
+		return &http.BasicAuth{Username: username, Password: token}, nil
+	}
+	return g.Auth.transportAuth()
+}
@@ -0,0 +1,195 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// CloneOptions limit what is fetched and checked out of large repositories. The zero value clones
+// everything.
+type CloneOptions struct {
+	Depth        int      // Optional; commits of history to fetch, e.g. 1 for a shallow clone.
+	SingleBranch bool     // Optional; fetch only Branch, or the remote's default branch.
+	Branch       string   // Optional; branch to check out instead of the remote's default.
+	SparsePaths  []string // Optional; directories to check out, e.g. ["services/payments"].
+}
+
+// CloneOptionsFrom converts clone settings from the configuration; nil clones everything.
+func CloneOptionsFrom(c *config.CloneSettings) CloneOptions {
+	if c == nil {
+		return CloneOptions{}
+	}
+	return CloneOptions{Depth: c.Depth, SingleBranch: c.SingleBranch, Branch: c.Branch, SparsePaths: c.SparsePaths}
+}
+
+// NewGitClientWithOptions creates a new GitClient like NewGitClientWithAuth, cloning only what opts
+// allow when the repository does not exist at repoPath yet. Ticket worktrees use the same options.
+func NewGitClientWithOptions(repoURL, repoPath string, auth AuthConfig, opts CloneOptions) (*GitClient, error) {
+	repo, err := openOrClone(repoURL, repoPath, auth, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &GitClient{
+		RepoURL:  repoURL,
+		RepoPath: repoPath,
+		Repo:     repo,
+		Auth:     auth,
+		Clone:    opts,
+	}, nil
+}
+
+// CloneFor returns a client on a separate checkout of the same remote at dir, e.g. an agent's own
+// shallow or sparse clone, cloning it first when dir does not exist. Without a remote the repository
+// itself is cloned. Guards, signing and event recording carry over.
+func (g *GitClient) CloneFor(dir string, opts CloneOptions) (*GitClient, error) {
+	url := g.RepoURL
+	if url == "" {
+		url = g.RepoPath
+	}
+	repo, err := openOrClone(url, dir, g.Auth, opts)
+	if err != nil {
+		return nil, err
+	}
+	c := g.withRepo(dir, repo)
+	c.Clone = opts
+	return c, nil
+}
+
+// openOrClone opens the repository at repoPath, or clones repoURL there with opts when it is missing.
+func openOrClone(repoURL, repoPath string, auth AuthConfig, opts CloneOptions) (*git.Repository, error) {
+	if _, err := os.Stat(repoPath); !os.IsNotExist(err) {
+		repo, err := git.PlainOpen(repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repository: %w", err)
+		}
+		return repo, nil
+	}
+	authMethod, err := auth.transportAuth()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure auth: %w", err)
+	}
+	cloneOpts := &git.CloneOptions{
+		URL:          repoURL,
+		Auth:         authMethod,
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+		NoCheckout:   len(opts.SparsePaths) > 0, // Checked out sparsely below.
+	}
+	if opts.Branch != "" {
+		cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(opts.Branch)
+	}
+	repo, err := git.PlainClone(repoPath, false, cloneOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+	if len(opts.SparsePaths) > 0 {
+		if err := checkoutSparse(repo, opts); err != nil {
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+// inSparse reports whether a repository-relative path is checked out under opts.
+func (o CloneOptions) inSparse(p string) bool {
+	if len(o.SparsePaths) == 0 {
+		return true
+	}
+	for _, dir := range o.SparsePaths {
+		dir = strings.Trim(filepath.ToSlash(dir), "/")
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkoutSparse fills the index from HEAD but writes only the files under opts.SparsePaths.
+// Files outside the sparse set stay in the index, so commits keep them; status and staging
+// ignore their absence (see status).
+func checkoutSparse(repo *git.Repository, opts CloneOptions) error {
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	var sparse []string
+	if err := files.ForEach(func(f *object.File) error {
+		if opts.inSparse(f.Name) {
+			sparse = append(sparse, f.Name)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if err := worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.MixedReset}); err != nil {
+		return fmt.Errorf("failed to fill the index: %w", err)
+	}
+	if len(sparse) == 0 {
+		return nil
+	}
+	if err := worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset, Files: sparse}); err != nil {
+		return fmt.Errorf("failed to check out %v: %w", opts.SparsePaths, err)
+	}
+	return nil
+}
+
+// status returns the worktree status. In a sparse checkout the files outside the sparse set are
+// missing on purpose, so their absence is not reported as a deletion.
+func (g *GitClient) status(worktree *git.Worktree) (git.Status, error) {
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+	for p, s := range status {
+		if !g.Clone.inSparse(p) && s.Worktree == git.Deleted && s.Staging == git.Unmodified {
+			delete(status, p)
+		}
+	}
+	return status, nil
+}
+
+// stageAll stages every change reported by status, like "git add --all" restricted to the
+// sparse set in sparse checkouts.
+func (g *GitClient) stageAll(worktree *git.Worktree) error {
+	if len(g.Clone.SparsePaths) == 0 {
+		return worktree.AddWithOptions(&git.AddOptions{All: true})
+	}
+	status, err := g.status(worktree)
+	if err != nil {
+		return err
+	}
+	for p, s := range status {
+		if s.Worktree == git.Unmodified {
+			continue
+		}
+		if s.Worktree == git.Deleted {
+			_, err = worktree.Remove(p)
+		} else {
+			_, err = worktree.Add(p)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stage %s: %w", p, err)
+		}
+	}
+	return nil
+}
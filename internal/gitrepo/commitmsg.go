@@ -0,0 +1,176 @@
+package gitrepo
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// CommitTypes lists the Conventional Commit types accepted by ValidateCommitMessage.
+var CommitTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// maxSubjectLength is the longest accepted commit subject line.
+const maxSubjectLength = 72
+
+var conventionalSubject = regexp.MustCompile(`^([a-z]+)(\(([a-z0-9._/-]+)\))?(!)?: (\S.*)$`)
+
+// CommitMessage is a Conventional Commit message: "type(scope): summary", a body and a "Refs:" footer.
+type CommitMessage struct {
+	Type     string `json:"type"`
+	Scope    string `json:"scope"`
+	Summary  string `json:"summary"`
+	Body     string `json:"body"`
+	TicketID string `json:"ticketId"`
+	Breaking bool   `json:"breaking"`
+}
+
+// String renders the message in Conventional Commit form.
+func (m CommitMessage) String() string {
+	var sb strings.Builder
+	sb.WriteString(m.Type)
+	if m.Scope != "" {
+		sb.WriteString("(" + m.Scope + ")")
+	}
+	if m.Breaking {
+		sb.WriteString("!")
+	}
+	sb.WriteString(": " + strings.TrimSpace(m.Summary))
+	if body := strings.TrimSpace(m.Body); body != "" {
+		sb.WriteString("\n\n" + body)
+	}
+	if m.TicketID != "" {
+		sb.WriteString("\n\nRefs: " + m.TicketID)
+	}
+	return sb.String()
+}
+
+// ValidateCommitMessage returns an error describing why msg is not a valid Conventional Commit.
+func ValidateCommitMessage(msg string) error {
+	lines := strings.Split(strings.TrimRight(msg, "\n"), "\n")
+	subject := lines[0]
+	m := conventionalSubject.FindStringSubmatch(subject)
+	if m == nil {
+		return fmt.Errorf("commit subject %q does not match \"type(scope): summary\"", subject)
+	}
+	known := false
+	for _, t := range CommitTypes {
+		if m[1] == t {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown commit type %q; use one of %s", m[1], strings.Join(CommitTypes, ", "))
+	}
+	if len(subject) > maxSubjectLength {
+		return fmt.Errorf("commit subject is %d characters long; keep it under %d", len(subject), maxSubjectLength)
+	}
+	if strings.HasSuffix(subject, ".") {
+		return fmt.Errorf("commit subject must not end with a period")
+	}
+	if len(lines) > 1 && lines[1] != "" {
+		return fmt.Errorf("commit subject must be followed by a blank line")
+	}
+	return nil
+}
+
+// NewCommitMessage derives a Conventional Commit message from the changed paths and the ticket.
+// The type is "test" or "docs" when only tests or docs changed, otherwise "feat";
+// the scope is the directory shared by all changes, if any. The summary is shortened to fit the subject line.
+func NewCommitMessage(changed []string, ticketID, summary string) CommitMessage {
+	msg := CommitMessage{Type: "feat", TicketID: ticketID, Summary: lowerFirst(strings.TrimSuffix(strings.TrimSpace(summary), "."))}
+	if len(changed) == 0 {
+		msg.Type = "chore"
+		msg.Summary = truncateSummary(msg.Summary, maxSubjectLength-len(msg.Type)-2)
+		return msg
+	}
+	allTests, allDocs := true, true
+	for _, p := range changed {
+		if !strings.HasSuffix(p, "_test.go") && !strings.HasPrefix(p, "test/") {
+			allTests = false
+		}
+		if ext := path.Ext(p); ext != ".md" && ext != ".txt" && !strings.HasPrefix(p, "docs/") {
+			allDocs = false
+		}
+	}
+	switch {
+	case allTests:
+		msg.Type = "test"
+	case allDocs:
+		msg.Type = "docs"
+	}
+	msg.Scope = commonScope(changed)
+	overhead := len(msg.Type) + 2 // ": "
+	if msg.Scope != "" {
+		overhead += len(msg.Scope) + 2 // "()"
+	}
+	msg.Summary = truncateSummary(msg.Summary, maxSubjectLength-overhead)
+	return msg
+}
+
+// truncateSummary shortens s to at most max bytes, cutting at a word boundary, or at a rune
+// boundary when the first word is already too long.
+func truncateSummary(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	short := s[:cut]
+	if i := strings.LastIndex(short, " "); i > 0 {
+		short = short[:i]
+	}
+	return strings.TrimRight(short, " .,;:")
+}
+
+// commonScope returns the last element of the deepest directory containing every path.
+func commonScope(paths []string) string {
+	dir := path.Dir(paths[0])
+	for _, p := range paths[1:] {
+		for dir != "." && !strings.HasPrefix(path.Dir(p)+"/", dir+"/") {
+			dir = path.Dir(dir)
+		}
+	}
+	if dir == "." {
+		return ""
+	}
+	return strings.ToLower(path.Base(dir))
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	first, size := utf8.DecodeRuneInString(s)
+	if second, _ := utf8.DecodeRuneInString(s[size:]); size < len(s) && unicode.ToUpper(first) == first && unicode.ToUpper(second) == second {
+		return s // Keep acronyms such as "API".
+	}
+	return string(unicode.ToLower(first)) + s[size:]
+}
+
+// ChangedFiles returns the repository-relative paths with uncommitted changes.
+func (g *GitClient) ChangedFiles() ([]string, error) {
+	defer g.rlock()()
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := g.status(worktree)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for p, s := range status {
+		if s.Worktree != git.Unmodified || s.Staging != git.Unmodified {
+			files = append(files, p)
+		}
+	}
+	return files, nil
+}
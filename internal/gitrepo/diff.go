@@ -0,0 +1,122 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitAt resolves a revision such as a branch name, tag or hash to a commit.
+func (g *GitClient) commitAt(rev string) (*object.Commit, error) {
+	hash, err := g.Repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+	commit, err := g.Repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit %s: %w", rev, err)
+	}
+	return commit, nil
+}
+
+// forkPoint returns the commit HEAD forked from base, or base itself when they share no history.
+func (g *GitClient) forkPoint(base string) (baseCommit, headCommit *object.Commit, err error) {
+	if baseCommit, err = g.commitAt(base); err != nil {
+		return nil, nil, err
+	}
+	if headCommit, err = g.commitAt("HEAD"); err != nil {
+		return nil, nil, err
+	}
+	bases, err := baseCommit.MergeBase(headCommit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	if len(bases) > 0 {
+		baseCommit = bases[0]
+	}
+	return baseCommit, headCommit, nil
+}
+
+// MergeBase returns the SHA of the commit HEAD forked from base, i.e. the equivalent of
+// "git merge-base base HEAD".
+func (g *GitClient) MergeBase(base string) (string, error) {
+	defer g.rlock()()
+	baseCommit, _, err := g.forkPoint(base)
+	if err != nil {
+		return "", err
+	}
+	return baseCommit.Hash.String(), nil
+}
+
+// Diff returns the unified diff of the committed changes on HEAD since it forked from base,
+// i.e. the equivalent of "git diff base...HEAD".
+func (g *GitClient) Diff(base string) (string, error) {
+	defer g.rlock()()
+	baseCommit, headCommit, err := g.forkPoint(base)
+	if err != nil {
+		return "", err
+	}
+	patch, err := baseCommit.Patch(headCommit)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute diff: %w", err)
+	}
+	return patch.String(), nil
+}
+
+// ReadFileAt returns the content of a file as committed in rev. A file missing from rev yields an
+// error wrapping os.ErrNotExist.
+func (g *GitClient) ReadFileAt(rev, path string) ([]byte, error) {
+	commit, err := g.commitAt(rev)
+	if err != nil {
+		return nil, err
+	}
+	file, err := commit.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, fmt.Errorf("%s at %s: %w", path, rev, os.ErrNotExist)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	return []byte(content), nil
+}
+
+// ExportTree writes the files committed in rev into dir, like "git archive", without touching the
+// checkout. dir is created when missing.
+func (g *GitClient) ExportTree(rev, dir string) error {
+	defer g.rlock()()
+	commit, err := g.commitAt(rev)
+	if err != nil {
+		return err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return fmt.Errorf("failed to list files of %s: %w", rev, err)
+	}
+	return files.ForEach(func(f *object.File) error {
+		target := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Name, err)
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read %s at %s: %w", f.Name, rev, err)
+		}
+		perm := os.FileMode(0644)
+		if f.Mode == filemode.Executable {
+			perm = 0755
+		}
+		if err := os.WriteFile(target, []byte(content), perm); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+package gitrepo
+
+import "strings"
+
+// maxDiffCells bounds the size of the table used to match the lines of two files. Files whose
+// changed regions are larger than that are not merged line by line: the whole changed region
+// becomes a single conflict.
+const maxDiffCells = 4 << 20
+
+// merge3 performs a line-based three-way merge of ours and theirs against their common base.
+// Regions only one side changed take that side's lines; regions both sides changed identically are
+// kept once; regions both sides changed differently are wrapped in conflict markers and reported.
+func merge3(base, ours, theirs string) (merged string, conflicted bool) {
+	o := strings.Split(base, "\n")
+	a := strings.Split(ours, "\n")
+	b := strings.Split(theirs, "\n")
+	matchA := matchLines(o, a)
+	matchB := matchLines(o, b)
+
+	var out []string
+	i, ia, ib := 0, 0, 0
+	for {
+		// The next base line both sides kept is a stable point; everything before it is a change.
+		k := i
+		for k < len(o) && (matchA[k] < 0 || matchB[k] < 0) {
+			k++
+		}
+		endA, endB := len(a), len(b)
+		if k < len(o) {
+			endA, endB = matchA[k], matchB[k]
+		}
+		lines, conflict := mergeChunk(o[i:k], a[ia:endA], b[ib:endB])
+		out = append(out, lines...)
+		conflicted = conflicted || conflict
+		if k == len(o) {
+			break
+		}
+		out = append(out, o[k])
+		i, ia, ib = k+1, endA+1, endB+1
+	}
+	return strings.Join(out, "\n"), conflicted
+}
+
+// mergeChunk merges a region between two stable points.
+func mergeChunk(base, ours, theirs []string) ([]string, bool) {
+	switch {
+	case equalLines(ours, base):
+		return theirs, false
+	case equalLines(theirs, base), equalLines(ours, theirs):
+		return ours, false
+	}
+	out := []string{markerOurs}
+	out = append(out, ours...)
+	out = append(out, markerSep)
+	out = append(out, theirs...)
+	out = append(out, markerTheirs)
+	return out, true
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLines returns, for every line of x, the index of the line of y it is paired with in a longest
+// common subsequence of the two, or -1 when it was removed.
+func matchLines(x, y []string) []int {
+	match := make([]int, len(x))
+	for i := range match {
+		match[i] = -1
+	}
+	// Lines shared at the start and end are matched directly; only the middle needs the table.
+	pre := 0
+	for pre < len(x) && pre < len(y) && x[pre] == y[pre] {
+		match[pre] = pre
+		pre++
+	}
+	suf := 0
+	for suf < len(x)-pre && suf < len(y)-pre && x[len(x)-1-suf] == y[len(y)-1-suf] {
+		match[len(x)-1-suf] = len(y) - 1 - suf
+		suf++
+	}
+	xs, ys := x[pre:len(x)-suf], y[pre:len(y)-suf]
+	n, m := len(xs), len(ys)
+	if n == 0 || m == 0 || n*m > maxDiffCells {
+		return match
+	}
+	// lcs[i*(m+1)+j] is the length of the longest common subsequence of xs[i:] and ys[j:].
+	lcs := make([]int32, (n+1)*(m+1))
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case xs[i] == ys[j]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+			case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+				lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j]
+			default:
+				lcs[i*(m+1)+j] = lcs[i*(m+1)+j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case xs[i] == ys[j]:
+			match[pre+i] = pre + j
+			i++
+			j++
+		case lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
@@ -0,0 +1,162 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+
+	"github.com/egobogo/aiagents/internal/events"
+)
+
+// keepFile holds an otherwise empty directory in git, which only tracks files.
+const keepFile = ".gitkeep"
+
+// checkChange runs the scope and guard checks for changing a repository-relative path.
+func (g *GitClient) checkChange(t events.Type, fileName string) error {
+	err := g.checkScope(fileName)
+	if err == nil && g.Guard != nil {
+		err = g.Guard.CheckWrite(fileName)
+	}
+	if err != nil {
+		g.record(t, fileName, nil, err)
+	}
+	return err
+}
+
+// filesUnder returns the repository-relative paths of the files at rel: rel itself when it is a
+// file, or every file below it when it is a directory.
+func (g *GitClient) filesUnder(rel string) ([]string, error) {
+	full := filepath.Join(g.RepoPath, rel)
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{filepath.ToSlash(filepath.Clean(rel))}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(full, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, g.relPath(p))
+		return nil
+	})
+	return files, err
+}
+
+// DeleteFile removes a file, or a directory with everything in it, and stages the deletion.
+// Every removed path passes the same scope and guard checks as WriteFile.
+func (g *GitClient) DeleteFile(fileName string) error {
+	defer g.lock()()
+	files, err := g.filesUnder(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", fileName, err)
+	}
+	for _, f := range files {
+		if err := g.checkChange(events.FileDeleted, f); err != nil {
+			return err
+		}
+	}
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	for _, f := range files {
+		_, err := worktree.Remove(f)
+		if errors.Is(err, index.ErrEntryNotFound) {
+			err = os.Remove(filepath.Join(g.RepoPath, f)) // Never committed.
+		}
+		g.record(events.FileDeleted, f, nil, err)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", f, err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(g.RepoPath, fileName)); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// RenameFile moves a file, or a directory with everything in it, and stages the move so git
+// records it as a rename, e.g. when a package moves. The target must not exist yet. Both the old
+// and the new paths pass the same scope and guard checks as WriteFile.
+func (g *GitClient) RenameFile(from, to string) error {
+	defer g.lock()()
+	files, err := g.filesUnder(from)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s: %w", from, err)
+	}
+	if _, err := os.Stat(filepath.Join(g.RepoPath, to)); err == nil {
+		return fmt.Errorf("failed to rename %s: %s already exists", from, to)
+	}
+	base := filepath.ToSlash(filepath.Clean(from))
+	target := func(f string) string {
+		return path.Join(filepath.ToSlash(filepath.Clean(to)), f[len(base):])
+	}
+	for _, f := range files {
+		if err := g.checkChange(events.FileMoved, f); err != nil {
+			return err
+		}
+		if err := g.checkChange(events.FileMoved, target(f)); err != nil {
+			return err
+		}
+	}
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	for _, f := range files {
+		dest := target(f)
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(g.RepoPath, dest)), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+		}
+		_, err := worktree.Move(f, dest)
+		if errors.Is(err, index.ErrEntryNotFound) {
+			err = os.Rename(filepath.Join(g.RepoPath, f), filepath.Join(g.RepoPath, dest)) // Never committed.
+		}
+		g.record(events.FileMoved, f, map[string]string{"to": dest}, err)
+		if err != nil {
+			return fmt.Errorf("failed to rename %s to %s: %w", f, dest, err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(g.RepoPath, from)); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", from, err)
+	}
+	return nil
+}
+
+// MkdirAll creates a directory and its parents. Git does not track empty directories, so a new
+// empty one gets a .gitkeep file to be committed.
+func (g *GitClient) MkdirAll(dir string) error {
+	defer g.lock()()
+	keep := path.Join(filepath.ToSlash(filepath.Clean(dir)), keepFile)
+	if err := g.checkChange(events.FileWritten, keep); err != nil {
+		return err
+	}
+	full := filepath.Join(g.RepoPath, dir)
+	if err := os.MkdirAll(full, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	entries, err := os.ReadDir(full)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return nil
+	}
+	err = os.WriteFile(filepath.Join(full, keepFile), nil, 0644)
+	g.record(events.FileWritten, keep, map[string]string{"bytes": "0"}, err)
+	return err
+}
@@ -2,23 +2,59 @@ package gitrepo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-git/go-git/v5"                         // go-git library
-	"github.com/go-git/go-git/v5/plumbing/object"         // for commit signatures
-	"github.com/go-git/go-git/v5/plumbing/transport/http" // for basic auth
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/killswitch"
+	"github.com/egobogo/aiagents/internal/tracing"
+	"github.com/go-git/go-git/v5" // go-git library
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object" // for commit signatures
 )
 
-// GitClient defines basic Git operations.
+// GitClient defines basic Git operations. It is safe for concurrent use; lock.go describes what that covers.
 type GitClient struct {
 	RepoURL  string
 	RepoPath string
 	Repo     *git.Repository
+	Auth     AuthConfig  // Credentials used when no explicit username/token is passed.
+	Limits   ReadOptions // Size cap and binary handling for reads.
+	// ConventionalCommits makes CommitChanges reject messages that fail ValidateCommitMessage.
+	ConventionalCommits bool
+	// Events, when set, receives an audit event for every file write, commit and push.
+	Events events.Recorder
+	// Tracer, when set, records a span for every commit and push.
+	Tracer *tracing.Tracer
+	// Guard, when set, can refuse writes, commits and pushes.
+	Guard Guard
+	// Signing, when set, signs every commit with the key of its author.
+	Signing *Signing
+	// Clone limits the history and paths of the checkout and of ticket worktrees.
+	Clone CloneOptions
+	// TicketID is the ticket a worktree checkout belongs to; it is attached to recorded events.
+	TicketID string
+	// DryRun makes commits, tags and pushes print what they would do instead of doing it.
+	DryRun bool
+
+	scopes [][]string // Path scopes added with Scoped; a path must match every one.
+}
+
+// record sends an audit event when an event recorder is configured.
+func (g *GitClient) record(t events.Type, target string, details map[string]string, err error) {
+	if g.Events == nil {
+		return
+	}
+	e := events.Event{TicketID: g.TicketID, Type: t, Target: target, Details: details}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	g.Events.Record(e)
 }
 
 // RepoFile represents a single file within the repository in JSON form.
@@ -35,56 +71,77 @@ type RepoSnapshot struct {
 // NewGitClient creates a new GitClient.
 // If the repository does not exist at repoPath, it clones from repoURL; otherwise, it opens the existing repo.
 func NewGitClient(repoURL, repoPath string) (*GitClient, error) {
-	var repo *git.Repository
-	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
-		// Clone repository if it doesn't exist.
-		repo, err = git.PlainClone(repoPath, false, &git.CloneOptions{
-			URL: repoURL,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to clone repository: %w", err)
-		}
-	} else {
-		// Open existing repository.
-		var err error
-		repo, err = git.PlainOpen(repoPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open repository: %w", err)
-		}
-	}
-	return &GitClient{
-		RepoURL:  repoURL,
-		RepoPath: repoPath,
-		Repo:     repo,
-	}, nil
+	return NewGitClientWithAuth(repoURL, repoPath, AuthConfig{})
+}
+
+// NewGitClientWithAuth creates a new GitClient that uses the given credentials for clone, pull and push.
+func NewGitClientWithAuth(repoURL, repoPath string, auth AuthConfig) (*GitClient, error) {
+	return NewGitClientWithOptions(repoURL, repoPath, auth, CloneOptions{})
 }
 
-// WriteFile writes content to a file relative to the repository path.
+// WriteFile writes content to a file relative to the repository path, creating parent directories as needed.
 func (g *GitClient) WriteFile(fileName string, content []byte) error {
+	defer g.lock()()
+	if err := g.checkScope(fileName); err != nil {
+		g.record(events.FileWritten, fileName, nil, err)
+		return err
+	}
+	if g.Guard != nil {
+		if err := g.Guard.CheckWrite(fileName); err != nil {
+			g.record(events.FileWritten, fileName, nil, err)
+			return err
+		}
+	}
 	fullPath := filepath.Join(g.RepoPath, fileName)
-	return os.WriteFile(fullPath, content, 0644)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", fileName, err)
+	}
+	err := os.WriteFile(fullPath, content, 0644)
+	g.record(events.FileWritten, fileName, map[string]string{"bytes": strconv.Itoa(len(content))}, err)
+	return err
 }
 
 // CommitChanges stages all changes in the repository and commits them with the provided commit message and author info.
-func (g *GitClient) CommitChanges(commitMessage, authorName, authorEmail string) error {
+func (g *GitClient) CommitChanges(commitMessage, authorName, authorEmail string) (err error) {
+	defer g.lock()()
+	span := g.Tracer.Start(authorName, "Commit", "git.repo", g.RepoPath)
+	defer func() { span.Finish(err) }()
+	if g.ConventionalCommits {
+		if err := ValidateCommitMessage(commitMessage); err != nil {
+			return fmt.Errorf("invalid commit message: %w", err)
+		}
+	}
+	if err := g.admitCommit(authorName, "committing"); err != nil {
+		return err
+	}
+	signer, err := g.signerFor(authorName)
+	if err != nil {
+		return err
+	}
+	if g.DryRun {
+		fmt.Printf("[dry-run] commit in %s as %s <%s>:\n%s\n", g.RepoPath, authorName, authorEmail, commitMessage)
+		return nil
+	}
 	worktree, err := g.Repo.Worktree()
 	if err != nil {
 		return fmt.Errorf("failed to get worktree: %w", err)
 	}
 
 	// Stage all changes.
-	if err := worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+	if err := g.stageAll(worktree); err != nil {
 		return fmt.Errorf("failed to add changes: %w", err)
 	}
 
 	// Create a commit.
-	_, err = worktree.Commit(commitMessage, &git.CommitOptions{
+	hash, err := worktree.Commit(commitMessage, &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  authorName,
 			Email: authorEmail,
 			When:  time.Now(),
 		},
+		Signer: signer,
 	})
+	g.record(events.Commit, hash.String(), map[string]string{"author": authorName, "message": commitMessage}, err)
 	if err != nil {
 		return fmt.Errorf("failed to commit changes: %w", err)
 	}
@@ -92,20 +149,22 @@ func (g *GitClient) CommitChanges(commitMessage, authorName, authorEmail string)
 	return nil
 }
 
-// PushChanges pushes commits to the remote repository using basic authentication.
-func (g *GitClient) PushChanges(username, token string) error {
-	err := g.Repo.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{
-			Username: username, // For GitHub, this is usually "git" when using a token.
-			Password: token,
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to push changes: %w", err)
+// admitCommit applies the checks every change of HEAD goes through, merges included: the author's
+// commit policy and the kill switch. Callers still honor DryRun themselves.
+func (g *GitClient) admitCommit(authorName, action string) error {
+	if err := g.checkCommit(authorName); err != nil {
+		return err
 	}
+	killswitch.Wait(action + " in " + g.RepoPath)
 	return nil
 }
 
+// PushChanges pushes the checked-out branch to its upstream, or to the branch of the same name on origin.
+// If username and token are empty, the client's configured AuthConfig is used instead of basic auth.
+func (g *GitClient) PushChanges(username, token string) error {
+	return g.PushTo(username, token, PushOptions{})
+}
+
 // GatherRepoInfo walks the repository path and gathers code file information.
 // It returns a JSON string of the repository snapshot, a schema describing its structure, and an error.
 func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
@@ -125,10 +184,13 @@ func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
 		if err != nil {
 			return err
 		}
-		// Skip .git folder.
-		if info.IsDir() && info.Name() == ".git" {
+		// Skip .git folder and directories outside the path scope.
+		if info.IsDir() && (info.Name() == ".git" || !g.mayContainScoped(g.relPath(path))) {
 			return filepath.SkipDir
 		}
+		if !info.IsDir() && !g.InScope(g.relPath(path)) {
+			return nil
+		}
 		// Filter: only process code files.
 		if !info.IsDir() && (strings.HasSuffix(info.Name(), ".go") ||
 			strings.HasSuffix(info.Name(), ".py") ||
@@ -140,13 +202,17 @@ func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
 			strings.HasSuffix(info.Name(), ".cpp") ||
 			strings.HasSuffix(info.Name(), ".c")) {
 			relativePath, _ := filepath.Rel(g.RepoPath, path)
-			content, err := ioutil.ReadFile(path)
+			content, err := g.readText(path)
+			if errors.Is(err, ErrFileTooLarge) || errors.Is(err, ErrBinaryFile) {
+				fmt.Printf("Warning: skipping %s: %v\n", relativePath, err)
+				return nil
+			}
 			if err != nil {
 				return fmt.Errorf("failed to read file %s: %w", relativePath, err)
 			}
 			snapshot.Files = append(snapshot.Files, RepoFile{
 				Path:    relativePath,
-				Content: string(content),
+				Content: content,
 			})
 		}
 		return nil
@@ -174,23 +240,33 @@ func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
 	return string(repoJSONBytes), schema, nil
 }
 
-// PullChanges pulls the latest changes from the remote repository.
+// PullChanges pulls the latest changes of the checked-out branch from its upstream, or from origin.
+// If username and token are empty, the client's configured AuthConfig is used.
 func (g *GitClient) PullChanges(username, token string) error {
+	defer g.lock()()
 	worktree, err := g.Repo.Worktree()
 	if err != nil {
 		return fmt.Errorf("failed to get worktree: %w", err)
 	}
-	err = worktree.Pull(&git.PullOptions{
-		RemoteName: "origin",
-		Auth: &http.BasicAuth{
-			Username: username,
-			Password: token,
-		},
-	})
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return fmt.Errorf("failed to configure auth: %w", err)
+	}
+	opts := &git.PullOptions{RemoteName: "origin", Auth: auth}
+	if head, err := g.Repo.Head(); err == nil {
+		if remote, branch, ok := g.upstream(head.Name().Short()); ok {
+			opts.RemoteName, opts.ReferenceName = remote, plumbing.NewBranchReferenceName(branch)
+		}
+	}
+	err = worktree.Pull(opts)
 	// If there are no changes to pull, go-git returns an error message "already up-to-date"
 	if err != nil && err.Error() == "already up-to-date" {
 		return nil
 	}
+	// go-git only fast-forwards; diverged histories must be merged explicitly.
+	if errors.Is(err, git.ErrNonFastForwardUpdate) {
+		return fmt.Errorf("failed to pull changes: %w (local and remote diverged; use MergeRemote to merge and resolve conflicts)", err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to pull changes: %w", err)
 	}
@@ -206,16 +282,23 @@ func (g *GitClient) ListCodeFiles() ([]string, error) {
 		if err != nil {
 			return err
 		}
-		// Skip .git and vendor directories.
+		// Skip .git and vendor directories, and anything outside the path scope.
 		if info.IsDir() {
-			if info.Name() == ".git" || info.Name() == "vendor" {
+			if info.Name() == ".git" || info.Name() == "vendor" || !g.mayContainScoped(g.relPath(path)) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if !g.InScope(g.relPath(path)) {
+			return nil
+		}
 		ext := filepath.Ext(info.Name())
 		for _, allowed := range allowedExtensions {
 			if strings.EqualFold(ext, allowed) {
+				if info.Size() > g.Limits.maxSize() {
+					fmt.Printf("Warning: skipping %s: %s exceeds size limit\n", path, humanSize(info.Size()))
+					break
+				}
 				files = append(files, path)
 				break
 			}
@@ -236,10 +319,13 @@ func (g *GitClient) PrintTree() (string, error) {
 			return err
 		}
 
-		// Skip vendor and .git directories
-		if info.IsDir() && (info.Name() == ".git" || info.Name() == "vendor") {
+		// Skip vendor and .git directories, and anything outside the path scope
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == "vendor" || !g.mayContainScoped(g.relPath(path))) {
 			return filepath.SkipDir
 		}
+		if !info.IsDir() && !g.InScope(g.relPath(path)) {
+			return nil
+		}
 
 		// Get relative path from repository root
 		relPath, err := filepath.Rel(g.RepoPath, path)
@@ -0,0 +1,52 @@
+package gitrepo
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// Guard vets writes, commits and pushes before they happen, e.g. a policy engine.
+// Returning an error blocks the action.
+type Guard interface {
+	CheckWrite(path string) error
+	CheckCommit(author string, changed, deleted []string) error
+	CheckPush(branch string, force bool) error
+}
+
+// pendingChanges returns the paths a commit of the current worktree would change, and which of them are deletions.
+func (g *GitClient) pendingChanges() (changed, deleted []string, err error) {
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := g.status(worktree)
+	if err != nil {
+		return nil, nil, err
+	}
+	for p, s := range status {
+		if s.Worktree == git.Unmodified && s.Staging == git.Unmodified {
+			continue
+		}
+		changed = append(changed, p)
+		if s.Worktree == git.Deleted || s.Staging == git.Deleted {
+			deleted = append(deleted, p)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(deleted)
+	return changed, deleted, nil
+}
+
+// checkCommit asks the guard whether the pending changes may be committed.
+func (g *GitClient) checkCommit(author string) error {
+	if g.Guard == nil {
+		return nil
+	}
+	changed, deleted, err := g.pendingChanges()
+	if err != nil {
+		return err
+	}
+	return g.Guard.CheckCommit(author, changed, deleted)
+}
@@ -0,0 +1,151 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// CommitInfo is a summary of a single commit.
+type CommitInfo struct {
+	Hash        string    `json:"hash"`
+	AuthorName  string    `json:"authorName"`
+	AuthorEmail string    `json:"authorEmail"`
+	When        time.Time `json:"when"`
+	Message     string    `json:"message"`
+}
+
+// BlameLine attributes a single line of a file to the commit that last changed it.
+type BlameLine struct {
+	Line       int       `json:"line"` // 1-based line number.
+	Text       string    `json:"text"`
+	Hash       string    `json:"hash"`
+	AuthorName string    `json:"authorName"`
+	When       time.Time `json:"when"`
+}
+
+// Log returns up to n most recent commits touching path (or the whole repository when path is empty).
+// A non-positive n returns the full history. Paths outside the client's scope return ErrOutOfScope.
+func (g *GitClient) Log(path string, n int) ([]CommitInfo, error) {
+	if path != "" {
+		if err := g.checkScope(path); err != nil {
+			return nil, err
+		}
+	}
+	opts := &git.LogOptions{Order: git.LogOrderCommitterTime}
+	if g.isShallow() {
+		opts.Order = git.LogOrderDFS // Ordering by time loads parents a shallow clone does not have.
+	}
+	if path != "" {
+		p := filepath.ToSlash(path)
+		opts.FileName = &p
+	}
+	iter, err := g.Repo.Log(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []CommitInfo
+	err = iter.ForEach(func(c *object.Commit) error {
+		if n > 0 && len(commits) >= n {
+			return storer.ErrStop
+		}
+		commits = append(commits, CommitInfo{
+			Hash:        c.Hash.String(),
+			AuthorName:  c.Author.Name,
+			AuthorEmail: c.Author.Email,
+			When:        c.Author.When,
+			Message:     strings.TrimSpace(c.Message),
+		})
+		return nil
+	})
+	if errors.Is(err, plumbing.ErrObjectNotFound) && g.isShallow() {
+		err = nil // The history of a shallow clone ends early.
+	}
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, fmt.Errorf("failed to iterate log: %w", err)
+	}
+	return commits, nil
+}
+
+// isShallow reports whether the repository was cloned with limited history.
+func (g *GitClient) isShallow() bool {
+	shallow, err := g.Repo.Storer.Shallow()
+	return err == nil && len(shallow) > 0
+}
+
+// Blame attributes every line of path at HEAD to the commit that last modified it.
+// Paths outside the client's scope return ErrOutOfScope.
+func (g *GitClient) Blame(path string) ([]BlameLine, error) {
+	if err := g.checkScope(path); err != nil {
+		return nil, err
+	}
+	head, err := g.Repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	commit, err := g.Repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HEAD commit: %w", err)
+	}
+	result, err := git.Blame(commit, filepath.ToSlash(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %w", path, err)
+	}
+	lines := make([]BlameLine, 0, len(result.Lines))
+	for i, l := range result.Lines {
+		lines = append(lines, BlameLine{
+			Line:       i + 1,
+			Text:       l.Text,
+			Hash:       l.Hash.String(),
+			AuthorName: l.AuthorName,
+			When:       l.Date,
+		})
+	}
+	return lines, nil
+}
+
+// DescribeHistory returns a compact, prompt-friendly summary of why a file looks the way it does:
+// its n most recent commits and how many lines each author last touched.
+func (g *GitClient) DescribeHistory(path string, n int) (string, error) {
+	commits, err := g.Log(path, n)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Recent commits for %s:\n", path))
+	if len(commits) == 0 {
+		sb.WriteString("  (no history)\n")
+	}
+	for _, c := range commits {
+		subject := strings.SplitN(c.Message, "\n", 2)[0]
+		sb.WriteString(fmt.Sprintf("  %s %s (%s, %s)\n", c.Hash[:7], subject, c.AuthorName, c.When.Format("2006-01-02")))
+	}
+
+	blame, err := g.Blame(path)
+	if err != nil {
+		// New or untracked files have no blame; the log is still useful.
+		return sb.String(), nil
+	}
+	counts := make(map[string]int)
+	var authors []string
+	for _, l := range blame {
+		if counts[l.AuthorName] == 0 {
+			authors = append(authors, l.AuthorName)
+		}
+		counts[l.AuthorName]++
+	}
+	sb.WriteString("Line ownership:\n")
+	for _, a := range authors {
+		sb.WriteString(fmt.Sprintf("  %s: %d line(s)\n", a, counts[a]))
+	}
+	return sb.String(), nil
+}
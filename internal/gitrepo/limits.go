@@ -0,0 +1,123 @@
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// DefaultMaxFileSize is the size cap applied when ReadOptions.MaxFileSize is zero.
+const DefaultMaxFileSize int64 = 1 << 20 // 1 MiB
+
+// sniffLen is the number of leading bytes inspected to decide whether a file is binary.
+const sniffLen = 8000
+
+var (
+	// ErrFileTooLarge is returned when a file exceeds the configured size cap.
+	ErrFileTooLarge = errors.New("file exceeds size limit")
+	// ErrBinaryFile is returned when a text read hits a non-text file.
+	ErrBinaryFile = errors.New("file is binary")
+)
+
+// ReadOptions controls how file contents are loaded from the working tree.
+type ReadOptions struct {
+	MaxFileSize  int64 `yaml:"maxFileSize" json:"maxFileSize"`   // Files larger than this are not loaded; 0 uses DefaultMaxFileSize.
+	Placeholders bool  `yaml:"placeholders" json:"placeholders"` // Return a summary such as "[binary, 12.0MB]" instead of skipping.
+}
+
+// maxSize returns the effective size cap.
+func (o ReadOptions) maxSize() int64 {
+	if o.MaxFileSize <= 0 {
+		return DefaultMaxFileSize
+	}
+	return o.MaxFileSize
+}
+
+// isBinary reports whether the sample looks like binary content.
+func isBinary(sample []byte) bool {
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	// Ignore a multi-byte rune cut off at the end of the sample.
+	for i := 0; i < utf8.UTFMax && len(sample) > 0 && !utf8.Valid(sample); i++ {
+		sample = sample[:len(sample)-1]
+	}
+	return !utf8.Valid(sample)
+}
+
+// humanSize formats a byte count for placeholders.
+func humanSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
+
+// readText loads a file as text, enforcing the client's size cap and binary detection.
+// With placeholders enabled, oversized and binary files yield a short summary instead of an error.
+func (g *GitClient) readText(full string) (string, error) {
+	info, err := os.Stat(full)
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > g.Limits.maxSize() {
+		if g.Limits.Placeholders {
+			return fmt.Sprintf("[file omitted: %s exceeds %s limit]", humanSize(info.Size()), humanSize(g.Limits.maxSize())), nil
+		}
+		return "", fmt.Errorf("%w: %s", ErrFileTooLarge, humanSize(info.Size()))
+	}
+
+	f, err := os.Open(full)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	sample := data
+	if len(sample) > sniffLen {
+		sample = sample[:sniffLen]
+	}
+	if isBinary(sample) {
+		if g.Limits.Placeholders {
+			return fmt.Sprintf("[binary, %s]", humanSize(info.Size())), nil
+		}
+		return "", ErrBinaryFile
+	}
+	return string(data), nil
+}
+
+// ReadText returns the content of a text file relative to the repository path.
+// Oversized and binary files return ErrFileTooLarge / ErrBinaryFile, or a placeholder
+// summary when ReadOptions.Placeholders is enabled.
+func (g *GitClient) ReadText(fileName string) (string, error) {
+	full, err := g.resolvePath(fileName)
+	if err != nil {
+		return "", err
+	}
+	if err := g.checkScope(fileName); err != nil {
+		return "", err
+	}
+	content, err := g.readText(full)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", fileName, err)
+	}
+	return content, nil
+}
+
+// withinSizeLimit reports whether the file at full is small enough to be loaded.
+func (g *GitClient) withinSizeLimit(full string) bool {
+	info, err := os.Stat(full)
+	return err == nil && info.Size() <= g.Limits.maxSize()
+}
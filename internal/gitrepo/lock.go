@@ -0,0 +1,39 @@
+package gitrepo
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// Concurrency model: a GitClient may be shared between goroutines. Operations that change a
+// checkout (writes, staging, commits, merges, pulls, pushes and tags) hold an exclusive lock on the
+// checkout directory, shared by every client of that directory in the process; ChangedFiles and
+// Diff hold it shared. The lock makes single operations safe, not sequences of them: committing in a
+// shared checkout also commits what others wrote there in the meantime. Agents working concurrently
+// should each use their own checkout, which WorktreeFor provides per ticket.
+
+// checkoutLocks maps cleaned absolute checkout paths to their *sync.RWMutex.
+var checkoutLocks sync.Map
+
+// lockFor returns the lock of the checkout at dir.
+func lockFor(dir string) *sync.RWMutex {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	l, _ := checkoutLocks.LoadOrStore(filepath.Clean(dir), &sync.RWMutex{})
+	return l.(*sync.RWMutex)
+}
+
+// lock takes the exclusive lock of the checkout and returns its release.
+func (g *GitClient) lock() func() {
+	l := lockFor(g.RepoPath)
+	l.Lock()
+	return l.Unlock
+}
+
+// rlock takes the shared lock of the checkout and returns its release.
+func (g *GitClient) rlock() func() {
+	l := lockFor(g.RepoPath)
+	l.RLock()
+	return l.RUnlock
+}
@@ -0,0 +1,450 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ErrConflicts is returned when merging remote changes left conflict markers in the working tree.
+var ErrConflicts = errors.New("merge produced conflicts")
+
+// ErrUncommittedChanges is returned when a merge would overwrite changes that are not committed yet.
+var ErrUncommittedChanges = errors.New("working tree has uncommitted changes")
+
+// Conflict markers written into conflicted files.
+const (
+	markerOurs   = "<<<<<<< ours"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>> theirs"
+)
+
+// mergeHeadFile stores the commit being merged while conflicts are pending.
+const mergeHeadFile = "MERGE_HEAD"
+
+// ConflictHunk is a conflicted region of a working tree file delimited by merge markers.
+type ConflictHunk struct {
+	Path   string `json:"path"`
+	Index  int    `json:"index"`  // 0-based index of the hunk within the file.
+	Ours   string `json:"ours"`   // Local side of the conflict.
+	Theirs string `json:"theirs"` // Remote side of the conflict.
+	Before string `json:"before"` // Up to a few lines of context preceding the hunk.
+	After  string `json:"after"`  // Up to a few lines of context following the hunk.
+}
+
+// conflictContextLines is the number of surrounding lines included with each hunk.
+const conflictContextLines = 5
+
+// MergeRemote fetches the given remote branch and merges it into the current branch.
+// Fast-forwards are applied directly. Diverged histories are merged file by file with a line-based
+// three-way merge against the merge base; where both sides changed the same lines differently,
+// conflict markers are written and ErrConflicts is returned together with the conflicted paths. Resolve them with ResolveConflict and finish with CompleteMerge.
+// Clean merges are committed immediately using the given author. Merges go through the same policy,
+// kill switch and dry-run checks as CommitChanges, and are refused with ErrUncommittedChanges while
+// the working tree has changes they could overwrite.
+func (g *GitClient) MergeRemote(username, token, branch, authorName, authorEmail string) ([]string, error) {
+	defer g.lock()()
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure auth: %w", err)
+	}
+	err = g.Repo.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("failed to fetch: %w", err)
+	}
+	remoteRef, err := g.Repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve origin/%s: %w", branch, err)
+	}
+	return g.mergeCommit(remoteRef.Hash(), authorName, authorEmail)
+}
+
+// mergeCommit merges the given commit into HEAD.
+func (g *GitClient) mergeCommit(theirsHash plumbing.Hash, authorName, authorEmail string) ([]string, error) {
+	head, err := g.Repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	ours, err := g.Repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HEAD commit: %w", err)
+	}
+	theirs, err := g.Repo.CommitObject(theirsHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load remote commit: %w", err)
+	}
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if upToDate, _ := theirs.IsAncestor(ours); upToDate || ours.Hash == theirs.Hash {
+		return nil, nil
+	}
+	pending, _, err := g.pendingChanges()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		sort.Strings(pending)
+		return nil, fmt.Errorf("%w: %s", ErrUncommittedChanges, strings.Join(pending, ", "))
+	}
+	if g.DryRun {
+		fmt.Printf("[dry-run] merge %s into %s as %s <%s>\n", theirs.Hash.String()[:7], head.Name().Short(), authorName, authorEmail)
+		return nil, nil
+	}
+	if fastForward, _ := ours.IsAncestor(theirs); fastForward {
+		if err := g.admitCommit(authorName, "fast-forwarding"); err != nil {
+			return nil, err
+		}
+		err := worktree.Reset(&git.ResetOptions{Commit: theirs.Hash, Mode: git.HardReset})
+		g.record(events.Commit, theirs.Hash.String(), map[string]string{"author": authorName, "fastForward": "true"}, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fast-forward: %w", err)
+		}
+		return nil, nil
+	}
+
+	bases, err := ours.MergeBase(theirs)
+	if err != nil || len(bases) == 0 {
+		return nil, fmt.Errorf("failed to find merge base: %v", err)
+	}
+	baseFiles, err := commitFiles(bases[0])
+	if err != nil {
+		return nil, err
+	}
+	ourFiles, err := commitFiles(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirFiles, err := commitFiles(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]struct{})
+	for _, files := range []map[string]string{baseFiles, ourFiles, theirFiles} {
+		for p := range files {
+			paths[p] = struct{}{}
+		}
+	}
+
+	var conflicted []string
+	for p := range paths {
+		base, inBase := baseFiles[p]
+		our, inOurs := ourFiles[p]
+		their, inTheirs := theirFiles[p]
+		full, err := g.resolvePath(p)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case inOurs == inTheirs && our == their:
+			continue
+		case inBase == inTheirs && base == their:
+			continue // Only we changed it.
+		case inBase == inOurs && base == our:
+			// Only they changed it.
+			if !inTheirs {
+				if err := os.Remove(full); err != nil && !os.IsNotExist(err) {
+					return nil, fmt.Errorf("failed to remove %s: %w", p, err)
+				}
+				continue
+			}
+			if err := writeWorktreeFile(full, their); err != nil {
+				return nil, err
+			}
+		default:
+			// Both changed it, or one side deleted what the other changed: merge line by line.
+			merged, conflict := merge3(base, our, their)
+			if err := writeWorktreeFile(full, merged); err != nil {
+				return nil, err
+			}
+			if conflict {
+				conflicted = append(conflicted, p)
+			}
+		}
+	}
+
+	mergeHead := filepath.Join(g.RepoPath, ".git", mergeHeadFile)
+	if err := os.WriteFile(mergeHead, []byte(theirs.Hash.String()+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to record merge head: %w", err)
+	}
+	if len(conflicted) > 0 {
+		return conflicted, ErrConflicts
+	}
+	return nil, g.completeMerge(fmt.Sprintf("Merge %s", theirs.Hash.String()[:7]), authorName, authorEmail)
+}
+
+// commitFiles returns the contents of all files in a commit keyed by path.
+func commitFiles(c *object.Commit) (map[string]string, error) {
+	files, err := c.Files()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", c.Hash, err)
+	}
+	result := make(map[string]string)
+	err = files.ForEach(func(f *object.File) error {
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		result[f.Name] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files of %s: %w", c.Hash, err)
+	}
+	return result, nil
+}
+
+// writeWorktreeFile writes a file, creating parent directories as needed.
+func writeWorktreeFile(full, content string) error {
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", full, err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", full, err)
+	}
+	return nil
+}
+
+// conflictSpan locates one conflict hunk by line numbers within a file.
+type conflictSpan struct {
+	start, sep, end int
+}
+
+// findSpans returns the conflict hunks of a file's lines.
+func findSpans(lines []string) []conflictSpan {
+	var spans []conflictSpan
+	start, sep := -1, -1
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "<<<<<<<"):
+			start, sep = i, -1
+		case strings.HasPrefix(l, markerSep) && strings.TrimSpace(l) == markerSep && start >= 0:
+			sep = i
+		case strings.HasPrefix(l, ">>>>>>>") && start >= 0 && sep >= 0:
+			spans = append(spans, conflictSpan{start: start, sep: sep, end: i})
+			start, sep = -1, -1
+		}
+	}
+	return spans
+}
+
+// FindConflicts scans the given files (or the whole working tree when none are given)
+// for conflict markers and returns every conflicted hunk with surrounding context.
+func (g *GitClient) FindConflicts(paths ...string) ([]ConflictHunk, error) {
+	if len(paths) == 0 {
+		err := filepath.Walk(g.RepoPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			rel, _ := filepath.Rel(g.RepoPath, path)
+			paths = append(paths, rel)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error walking repo path: %w", err)
+		}
+	}
+
+	var hunks []ConflictHunk
+	for _, p := range paths {
+		full, err := g.resolvePath(p)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		lines := strings.Split(string(data), "\n")
+		for idx, s := range findSpans(lines) {
+			before := s.start - conflictContextLines
+			if before < 0 {
+				before = 0
+			}
+			after := s.end + 1 + conflictContextLines
+			if after > len(lines) {
+				after = len(lines)
+			}
+			ours := lines[s.start+1 : s.sep]
+			// Drop an optional diff3 base section.
+			for i, l := range ours {
+				if strings.HasPrefix(l, "|||||||") {
+					ours = ours[:i]
+					break
+				}
+			}
+			hunks = append(hunks, ConflictHunk{
+				Path:   filepath.ToSlash(p),
+				Index:  idx,
+				Ours:   strings.Join(ours, "\n"),
+				Theirs: strings.Join(lines[s.sep+1:s.end], "\n"),
+				Before: strings.Join(lines[before:s.start], "\n"),
+				After:  strings.Join(lines[s.end+1:after], "\n"),
+			})
+		}
+	}
+	return hunks, nil
+}
+
+// ResolveConflict replaces the conflict hunk with the given index in path by the resolution text.
+func (g *GitClient) ResolveConflict(path string, index int, resolution string) error {
+	defer g.lock()()
+	full, err := g.resolvePath(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	spans := findSpans(lines)
+	if index < 0 || index >= len(spans) {
+		return fmt.Errorf("conflict %d not found in %s", index, path)
+	}
+	s := spans[index]
+	var out []string
+	out = append(out, lines[:s.start]...)
+	if resolution != "" {
+		out = append(out, strings.Split(strings.TrimSuffix(resolution, "\n"), "\n")...)
+	}
+	out = append(out, lines[s.end+1:]...)
+	return os.WriteFile(full, []byte(strings.Join(out, "\n")), 0644)
+}
+
+// MergeInProgress reports whether a merge started by MergeRemote is awaiting completion.
+func (g *GitClient) MergeInProgress() bool {
+	_, err := os.Stat(filepath.Join(g.RepoPath, ".git", mergeHeadFile))
+	return err == nil
+}
+
+// CompleteMerge commits a pending merge with HEAD and the merged commit as parents.
+// It refuses to commit while conflict markers remain in the working tree.
+func (g *GitClient) CompleteMerge(commitMessage, authorName, authorEmail string) error {
+	defer g.lock()()
+	return g.completeMerge(commitMessage, authorName, authorEmail)
+}
+
+// completeMerge is CompleteMerge for callers holding the lock.
+func (g *GitClient) completeMerge(commitMessage, authorName, authorEmail string) error {
+	mergeHeadPath := filepath.Join(g.RepoPath, ".git", mergeHeadFile)
+	data, err := os.ReadFile(mergeHeadPath)
+	if err != nil {
+		return fmt.Errorf("no merge in progress: %w", err)
+	}
+	remaining, err := g.FindConflicts()
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("%w: %d unresolved hunk(s)", ErrConflicts, len(remaining))
+	}
+
+	if err := g.admitCommit(authorName, "merging"); err != nil {
+		return err
+	}
+	signer, err := g.signerFor(authorName)
+	if err != nil {
+		return err
+	}
+	if g.DryRun {
+		fmt.Printf("[dry-run] merge commit in %s as %s <%s>:\n%s\n", g.RepoPath, authorName, authorEmail, commitMessage)
+		return nil
+	}
+	head, err := g.Repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if err := g.stageAll(worktree); err != nil {
+		return fmt.Errorf("failed to add changes: %w", err)
+	}
+	hash, err := worktree.Commit(commitMessage, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  authorName,
+			Email: authorEmail,
+			When:  time.Now(),
+		},
+		Parents:           []plumbing.Hash{head.Hash(), plumbing.NewHash(strings.TrimSpace(string(data)))},
+		AllowEmptyCommits: true,
+		Signer:            signer,
+	})
+	g.record(events.Commit, hash.String(), map[string]string{"author": authorName, "message": commitMessage, "merge": "true"}, err)
+	if err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return os.Remove(mergeHeadPath)
+}
+
+// BehindRemote fetches the given remote branch and returns how many of its commits are not yet in HEAD.
+func (g *GitClient) BehindRemote(username, token, branch string) (int, error) {
+	defer g.lock()()
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return 0, fmt.Errorf("failed to configure auth: %w", err)
+	}
+	err = g.Repo.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return 0, fmt.Errorf("failed to fetch: %w", err)
+	}
+	remoteRef, err := g.Repo.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve origin/%s: %w", branch, err)
+	}
+	head, err := g.Repo.Head()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	ours := make(map[plumbing.Hash]bool)
+	iter, err := g.Repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history: %w", err)
+	}
+	if err := iter.ForEach(func(c *object.Commit) error {
+		ours[c.Hash] = true
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to read history: %w", err)
+	}
+	iter, err = g.Repo.Log(&git.LogOptions{From: remoteRef.Hash()})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history of origin/%s: %w", branch, err)
+	}
+	behind := 0
+	err = iter.ForEach(func(c *object.Commit) error {
+		if !ours[c.Hash] {
+			behind++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read history of origin/%s: %w", branch, err)
+	}
+	return behind, nil
+}
+
+// AbortMerge discards a pending merge, restoring the working tree to HEAD.
+func (g *GitClient) AbortMerge() error {
+	return g.ResetHard()
+}
@@ -0,0 +1,377 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PatchConflict describes a hunk that could not be applied to the current file content.
+type PatchConflict struct {
+	Path     string   `json:"path"`
+	Hunk     int      `json:"hunk"` // 1-based index of the hunk within the file.
+	Line     int      `json:"line"` // Line the hunk expected to start at.
+	Reason   string   `json:"reason"`
+	Expected []string `json:"expected,omitempty"` // Context and removed lines from the hunk.
+	Actual   []string `json:"actual,omitempty"`   // Lines currently found at that position.
+}
+
+// PatchError is returned by ApplyPatch when the patch cannot be applied cleanly.
+// Its message is meant to be fed back to the model so it can regenerate the diff.
+type PatchError struct {
+	Conflicts []PatchConflict
+}
+
+func (e *PatchError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("patch does not apply (%d conflict(s)):", len(e.Conflicts)))
+	for _, c := range e.Conflicts {
+		sb.WriteString(fmt.Sprintf("\n- %s hunk #%d at line %d: %s", c.Path, c.Hunk, c.Line, c.Reason))
+		if len(c.Expected) > 0 {
+			sb.WriteString("\n  expected:\n    " + strings.Join(c.Expected, "\n    "))
+		}
+		if len(c.Actual) > 0 {
+			sb.WriteString("\n  found:\n    " + strings.Join(c.Actual, "\n    "))
+		}
+	}
+	return sb.String()
+}
+
+// hunk is a single "@@ -a,b +c,d @@" section of a unified diff.
+type hunk struct {
+	oldStart int
+	oldCount int      // Remaining old-side lines while parsing.
+	newCount int      // Remaining new-side lines while parsing.
+	lines    []string // Raw hunk lines including their ' ', '-' or '+' prefix.
+}
+
+// open reports whether the hunk still expects more lines.
+func (h *hunk) open() bool {
+	return h.oldCount > 0 || h.newCount > 0
+}
+
+// add records a hunk line and decrements the remaining line counts.
+func (h *hunk) add(line string) {
+	switch line[0] {
+	case ' ':
+		h.oldCount--
+		h.newCount--
+	case '-':
+		h.oldCount--
+	case '+':
+		h.newCount--
+	}
+	h.lines = append(h.lines, line)
+}
+
+// filePatch holds all hunks for a single file of a unified diff.
+type filePatch struct {
+	oldPath string
+	newPath string
+	hunks   []hunk
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseUnifiedDiff splits a unified diff into per-file patches. While a hunk still expects lines,
+// lines are read as its content, so a removed line starting with "-- " is not taken for a file header.
+func parseUnifiedDiff(diff string) ([]filePatch, error) {
+	var patches []filePatch
+	var current *filePatch
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if h := openHunk(current); h != nil && !strings.HasPrefix(line, "@@") {
+			if line == "" {
+				// Some generators drop the leading space of empty context lines.
+				line = " "
+			}
+			if strings.HasPrefix(line, "\\") {
+				continue
+			}
+			if line[0] != ' ' && line[0] != '-' && line[0] != '+' {
+				return nil, fmt.Errorf("line %d: unexpected line in hunk %q", i+1, line)
+			}
+			h.add(line)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			if i+1 >= len(lines) || !strings.HasPrefix(lines[i+1], "+++ ") {
+				return nil, fmt.Errorf("line %d: missing +++ header after ---", i+1)
+			}
+			patches = append(patches, filePatch{
+				oldPath: stripDiffPrefix(line[4:]),
+				newPath: stripDiffPrefix(lines[i+1][4:]),
+			})
+			current = &patches[len(patches)-1]
+			i++
+		case strings.HasPrefix(line, "@@"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: hunk without file header", i+1)
+			}
+			m := hunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: malformed hunk header %q", i+1, line)
+			}
+			start, _ := strconv.Atoi(m[1])
+			current.hunks = append(current.hunks, hunk{
+				oldStart: start,
+				oldCount: hunkCount(m[2]),
+				newCount: hunkCount(m[4]),
+			})
+		default:
+			// "diff --git", "index", "\ No newline at end of file" and similar lines carry no content.
+		}
+	}
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no file headers found in diff")
+	}
+	return patches, nil
+}
+
+// openHunk returns the last hunk of the file patch if it still expects lines, or nil.
+func openHunk(p *filePatch) *hunk {
+	if p == nil || len(p.hunks) == 0 {
+		return nil
+	}
+	if h := &p.hunks[len(p.hunks)-1]; h.open() {
+		return h
+	}
+	return nil
+}
+
+// hunkCount parses the optional line count of a hunk header, which defaults to 1.
+func hunkCount(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// stripDiffPrefix removes timestamps and the conventional a/ or b/ prefix from a diff path.
+func stripDiffPrefix(p string) string {
+	if idx := strings.Index(p, "\t"); idx >= 0 {
+		p = p[:idx]
+	}
+	p = strings.TrimSpace(p)
+	if p == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(p, "a/") || strings.HasPrefix(p, "b/") {
+		return p[2:]
+	}
+	return p
+}
+
+// applyHunks applies the hunks of a file patch to the given lines.
+func applyHunks(path string, lines []string, hunks []hunk) ([]string, []PatchConflict) {
+	var conflicts []PatchConflict
+	result := append([]string(nil), lines...)
+	offset := 0
+	for idx, h := range hunks {
+		var oldLines, newLines []string
+		for _, l := range h.lines {
+			switch l[0] {
+			case ' ':
+				oldLines = append(oldLines, l[1:])
+				newLines = append(newLines, l[1:])
+			case '-':
+				oldLines = append(oldLines, l[1:])
+			case '+':
+				newLines = append(newLines, l[1:])
+			}
+		}
+		expected := h.oldStart - 1 + offset
+		if h.oldStart == 0 {
+			expected = 0
+		}
+		pos := findBlock(result, oldLines, expected)
+		if pos < 0 {
+			var actual []string
+			if expected >= 0 && expected < len(result) {
+				end := expected + len(oldLines)
+				if end > len(result) {
+					end = len(result)
+				}
+				actual = result[expected:end]
+			}
+			conflicts = append(conflicts, PatchConflict{
+				Path:     path,
+				Hunk:     idx + 1,
+				Line:     h.oldStart,
+				Reason:   "context does not match current content",
+				Expected: oldLines,
+				Actual:   actual,
+			})
+			continue
+		}
+		updated := append([]string(nil), result[:pos]...)
+		updated = append(updated, newLines...)
+		updated = append(updated, result[pos+len(oldLines):]...)
+		result = updated
+		offset += len(newLines) - len(oldLines)
+	}
+	return result, conflicts
+}
+
+// findBlock returns the position of block in lines closest to the expected position, or -1.
+func findBlock(lines, block []string, expected int) int {
+	matches := func(pos int) bool {
+		if pos < 0 || pos+len(block) > len(lines) {
+			return false
+		}
+		for i, b := range block {
+			if lines[pos+i] != b {
+				return false
+			}
+		}
+		return true
+	}
+	if matches(expected) {
+		return expected
+	}
+	for delta := 1; delta <= len(lines); delta++ {
+		if matches(expected - delta) {
+			return expected - delta
+		}
+		if matches(expected + delta) {
+			return expected + delta
+		}
+	}
+	return -1
+}
+
+// resolvePath joins a repository-relative path with the repository root, refusing paths that escape it.
+func (g *GitClient) resolvePath(rel string) (string, error) {
+	full := filepath.Join(g.RepoPath, rel)
+	relToRoot, err := filepath.Rel(g.RepoPath, full)
+	if err != nil || relToRoot == ".." || strings.HasPrefix(relToRoot, ".."+string(os.PathSeparator)) {
+		return "", fmt.Errorf("path %s is outside the repository", rel)
+	}
+	return full, nil
+}
+
+// PatchPaths returns the repository-relative paths a unified diff changes, in the order they appear;
+// a renamed file contributes both its old and its new path.
+func PatchPaths(diff string) ([]string, error) {
+	patches, err := parseUnifiedDiff(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+	var paths []string
+	for _, p := range patches {
+		if p.oldPath != "" {
+			paths = append(paths, p.oldPath)
+		}
+		if p.newPath != "" && p.newPath != p.oldPath {
+			paths = append(paths, p.newPath)
+		}
+	}
+	return paths, nil
+}
+
+// ApplyPatch applies a unified diff to the working tree.
+// All hunks are validated against the current file contents first; if any hunk conflicts,
+// nothing is written and a *PatchError describing every conflict is returned.
+// It returns the repository-relative paths of the files that were changed.
+func (g *GitClient) ApplyPatch(diff string) ([]string, error) {
+	defer g.lock()()
+	patches, err := parseUnifiedDiff(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+
+	type pendingWrite struct {
+		path    string
+		content []byte
+		remove  bool
+	}
+	var writes []pendingWrite
+	var conflicts []PatchConflict
+
+	for _, p := range patches {
+		path := p.newPath
+		if path == "" {
+			path = p.oldPath
+		}
+		full, err := g.resolvePath(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, touched := range []string{p.oldPath, p.newPath} {
+			if touched == "" {
+				continue
+			}
+			if err := g.checkScope(touched); err != nil {
+				return nil, err
+			}
+			if g.Guard != nil {
+				if err := g.Guard.CheckWrite(touched); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		var lines []string
+		trailingNewline := true
+		if p.oldPath != "" {
+			data, err := os.ReadFile(full)
+			if err != nil {
+				conflicts = append(conflicts, PatchConflict{Path: path, Reason: fmt.Sprintf("cannot read file: %v", err)})
+				continue
+			}
+			content := string(data)
+			trailingNewline = strings.HasSuffix(content, "\n")
+			content = strings.TrimSuffix(content, "\n")
+			if content != "" {
+				lines = strings.Split(content, "\n")
+			}
+		} else if _, err := os.Stat(full); err == nil {
+			conflicts = append(conflicts, PatchConflict{Path: path, Reason: "file already exists"})
+			continue
+		}
+
+		updated, fileConflicts := applyHunks(path, lines, p.hunks)
+		if len(fileConflicts) > 0 {
+			conflicts = append(conflicts, fileConflicts...)
+			continue
+		}
+		if p.newPath == "" {
+			writes = append(writes, pendingWrite{path: path, remove: true})
+			continue
+		}
+		content := strings.Join(updated, "\n")
+		if trailingNewline && len(updated) > 0 {
+			content += "\n"
+		}
+		writes = append(writes, pendingWrite{path: path, content: []byte(content)})
+	}
+
+	if len(conflicts) > 0 {
+		return nil, &PatchError{Conflicts: conflicts}
+	}
+
+	var changed []string
+	for _, w := range writes {
+		full, _ := g.resolvePath(w.path)
+		if w.remove {
+			if err := os.Remove(full); err != nil {
+				return changed, fmt.Errorf("failed to remove %s: %w", w.path, err)
+			}
+		} else {
+			if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+				return changed, fmt.Errorf("failed to create directory for %s: %w", w.path, err)
+			}
+			if err := os.WriteFile(full, w.content, 0644); err != nil {
+				return changed, fmt.Errorf("failed to write %s: %w", w.path, err)
+			}
+		}
+		changed = append(changed, w.path)
+	}
+	return changed, nil
+}
@@ -0,0 +1,137 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DirEntry describes a single entry returned by ReadDir.
+type DirEntry struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"` // Repository-relative, slash-separated path.
+	IsDir bool   `json:"isDir"`
+	Size  int64  `json:"size"`
+}
+
+// MatchGlob reports whether a slash-separated repository path matches a glob pattern.
+// In addition to the path.Match syntax, a "**" segment matches any number of directories.
+func MatchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// ReadFile returns the raw content of a single file relative to the repository path.
+// Files above the configured size cap return ErrFileTooLarge; use ReadText for text-only reads.
+func (g *GitClient) ReadFile(fileName string) ([]byte, error) {
+	full, err := g.resolvePath(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkScope(fileName); err != nil {
+		return nil, err
+	}
+	if !g.withinSizeLimit(full) {
+		if _, err := os.Stat(full); err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", fileName, err)
+		}
+		return nil, fmt.Errorf("failed to read file %s: %w", fileName, ErrFileTooLarge)
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", fileName, err)
+	}
+	return data, nil
+}
+
+// ListFiles returns the repository-relative paths of all files matching the glob pattern,
+// e.g. "internal/**/*.go" or "cmd/*/main.go". The .git directory is never included.
+func (g *GitClient) ListFiles(pattern string) ([]string, error) {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	var files []string
+	err := filepath.Walk(g.RepoPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" || !g.mayContainScoped(g.relPath(p)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(g.RepoPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if MatchGlob(pattern, rel) && g.InScope(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking repo path: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadDir lists the direct children of a directory relative to the repository path.
+func (g *GitClient) ReadDir(dir string) ([]DirEntry, error) {
+	full, err := g.resolvePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	var result []DirEntry
+	for _, e := range entries {
+		if e.Name() == ".git" {
+			continue
+		}
+		rel := path.Join(filepath.ToSlash(dir), e.Name())
+		if (e.IsDir() && !g.mayContainScoped(rel)) || (!e.IsDir() && !g.InScope(rel)) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		result = append(result, DirEntry{
+			Name:  e.Name(),
+			Path:  rel,
+			IsDir: e.IsDir(),
+			Size:  info.Size(),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package gitrepo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Registry holds the repositories an agent may work on, keyed by a short name such as "backend".
+type Registry struct {
+	clients  map[string]*GitClient
+	fallback string
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{clients: make(map[string]*GitClient)}
+}
+
+// Add registers a repository. The first one added, or any added with isDefault, serves tickets without a repo hint.
+func (r *Registry) Add(name string, client *GitClient, isDefault bool) {
+	name = strings.ToLower(name)
+	r.clients[name] = client
+	if isDefault || r.fallback == "" {
+		r.fallback = name
+	}
+}
+
+// Names returns the registered repository names, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.clients))
+	for name := range r.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the repository registered under name; an empty name selects the default.
+func (r *Registry) Get(name string) (*GitClient, error) {
+	if name == "" {
+		name = r.fallback
+	}
+	c, ok := r.clients[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown repository %q (known: %s)", name, strings.Join(r.Names(), ", "))
+	}
+	return c, nil
+}
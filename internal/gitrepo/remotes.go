@@ -0,0 +1,200 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/killswitch"
+)
+
+// defaultRemote is pushed to and pulled from when a branch has no upstream.
+const defaultRemote = "origin"
+
+// Remote is a named remote of the repository.
+type Remote struct {
+	Name string
+	URLs []string
+}
+
+// Remotes lists the configured remotes by name.
+func (g *GitClient) Remotes() ([]Remote, error) {
+	remotes, err := g.Repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+	var out []Remote
+	for _, r := range remotes {
+		out = append(out, Remote{Name: r.Config().Name, URLs: r.Config().URLs})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// SetRemote adds the named remote, or points an existing one at url.
+func (g *GitClient) SetRemote(name, url string) error {
+	defer g.lock()()
+	if err := g.Repo.DeleteRemote(name); err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+		return fmt.Errorf("failed to replace remote %s: %w", name, err)
+	}
+	if _, err := g.Repo.CreateRemote(&gitconfig.RemoteConfig{Name: name, URLs: []string{url}}); err != nil {
+		return fmt.Errorf("failed to add remote %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveRemote deletes the named remote.
+func (g *GitClient) RemoveRemote(name string) error {
+	defer g.lock()()
+	if err := g.Repo.DeleteRemote(name); err != nil {
+		return fmt.Errorf("failed to remove remote %s: %w", name, err)
+	}
+	return nil
+}
+
+// Fetch updates the remote-tracking branches of remote ("origin" when empty). With prune, the
+// remote-tracking branches of branches deleted on the remote are removed as well.
+// If username and token are empty, the client's configured AuthConfig is used.
+func (g *GitClient) Fetch(username, token, remote string, prune bool) error {
+	defer g.lock()()
+	if remote == "" {
+		remote = defaultRemote
+	}
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return fmt.Errorf("failed to configure auth: %w", err)
+	}
+	err = g.Repo.Fetch(&git.FetchOptions{
+		RemoteName: remote,
+		Auth:       auth,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", remote))},
+		Prune:      prune,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch %s: %w", remote, err)
+	}
+	return nil
+}
+
+// SetUpstream makes the local branch track remoteBranch on remote, so pulls and pushes of the branch
+// go there.
+func (g *GitClient) SetUpstream(branch, remote, remoteBranch string) error {
+	defer g.lock()()
+	return g.setUpstream(branch, remote, remoteBranch)
+}
+
+func (g *GitClient) setUpstream(branch, remote, remoteBranch string) error {
+	cfg, err := g.Repo.Config()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	cfg.Branches[branch] = &gitconfig.Branch{Name: branch, Remote: remote, Merge: plumbing.NewBranchReferenceName(remoteBranch)}
+	if err := g.Repo.SetConfig(cfg); err != nil {
+		return fmt.Errorf("failed to set upstream of %s: %w", branch, err)
+	}
+	return nil
+}
+
+// Upstream returns the remote and remote branch the local branch tracks; ok is false when it tracks none.
+func (g *GitClient) Upstream(branch string) (remote, remoteBranch string, ok bool) {
+	return g.upstream(branch)
+}
+
+func (g *GitClient) upstream(branch string) (remote, remoteBranch string, ok bool) {
+	cfg, err := g.Repo.Config()
+	if err != nil {
+		return "", "", false
+	}
+	b, found := cfg.Branches[branch]
+	if !found || b.Remote == "" || b.Merge == "" {
+		return "", "", false
+	}
+	return b.Remote, b.Merge.Short(), true
+}
+
+// PushOptions select where PushTo sends the checked-out branch.
+type PushOptions struct {
+	Remote         string // Optional; defaults to the branch's upstream remote, then "origin".
+	Branch         string // Optional; remote branch, defaults to the upstream branch, then the local name.
+	ForceWithLease bool   // Overwrite diverged history only if the remote branch is still where it was last fetched.
+	SetUpstream    bool   // Record the pushed branch as the upstream of the local one.
+}
+
+// PushTo pushes the checked-out branch as opts select. Other local branches are never pushed;
+// they may be stale, e.g. the base branch of a ticket checkout.
+// If username and token are empty, the client's configured AuthConfig is used instead of basic auth.
+func (g *GitClient) PushTo(username, token string, opts PushOptions) (err error) {
+	defer g.lock()()
+	head, err := g.Repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	local := head.Name().Short()
+	if remote, branch, ok := g.upstream(local); ok {
+		if opts.Remote == "" {
+			opts.Remote = remote
+		}
+		if opts.Branch == "" {
+			opts.Branch = branch
+		}
+	}
+	if opts.Remote == "" {
+		opts.Remote = defaultRemote
+	}
+	if opts.Branch == "" {
+		opts.Branch = local
+	}
+	target := opts.Remote + "/" + opts.Branch
+
+	span := g.Tracer.Start("", "Push", "git.repo", g.RepoPath, "git.remote", target)
+	defer func() { span.Finish(err) }()
+	if g.Guard != nil {
+		if err := g.Guard.CheckPush(opts.Branch, opts.ForceWithLease); err != nil {
+			return err
+		}
+	}
+	killswitch.Wait("pushing " + g.RepoPath)
+	if g.DryRun {
+		fmt.Printf("[dry-run] push %s to %s\n", local, target)
+		return nil
+	}
+	// For GitHub, username is usually "git" when using a token.
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return fmt.Errorf("failed to configure auth: %w", err)
+	}
+	remoteRef := plumbing.NewBranchReferenceName(opts.Branch)
+	trackingRef := plumbing.NewRemoteReferenceName(opts.Remote, opts.Branch)
+	refSpec := gitconfig.RefSpec(head.Name().String() + ":" + remoteRef.String())
+	push := &git.PushOptions{RemoteName: opts.Remote, Auth: auth}
+	if opts.ForceWithLease {
+		// The lease is the remote-tracking branch, i.e. where the remote branch was last seen.
+		tracking, err := g.Repo.Reference(trackingRef, true)
+		if err != nil {
+			return fmt.Errorf("failed to push changes: no lease for %s, fetch it first: %w", target, err)
+		}
+		refSpec = "+" + refSpec
+		push.RequireRemoteRefs = []gitconfig.RefSpec{gitconfig.RefSpec(tracking.Hash().String() + ":" + remoteRef.String())}
+	}
+	push.RefSpecs = []gitconfig.RefSpec{refSpec}
+	err = g.Repo.Push(push)
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		err = nil
+	}
+	g.record(events.Push, local, map[string]string{"target": target}, err)
+	if err != nil {
+		return fmt.Errorf("failed to push changes: %w", err)
+	}
+	if err := g.Repo.Storer.SetReference(plumbing.NewHashReference(trackingRef, head.Hash())); err != nil {
+		return fmt.Errorf("failed to update %s: %w", trackingRef.Short(), err)
+	}
+	if opts.SetUpstream {
+		return g.setUpstream(local, opts.Remote, opts.Branch)
+	}
+	return nil
+}
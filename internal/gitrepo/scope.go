@@ -0,0 +1,103 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutOfScope is returned when a read or write targets a path outside the client's path scope.
+var ErrOutOfScope = errors.New("path is outside the allowed scope")
+
+// Scoped returns a copy of the client restricted to paths matching at least one of the glob patterns,
+// e.g. "services/payments/**". Scopes stack: on an already scoped client a path must match
+// every scope. Reads, listings, context snapshots and writes all honour the scope; no patterns
+// returns the client unchanged.
+func (g *GitClient) Scoped(patterns ...string) *GitClient {
+	var cleaned []string
+	for _, p := range patterns {
+		if p = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(p)), "./"); p != "" {
+			cleaned = append(cleaned, p)
+		}
+	}
+	if len(cleaned) == 0 {
+		return g
+	}
+	c := *g
+	c.scopes = append(append([][]string(nil), g.scopes...), cleaned)
+	return &c
+}
+
+// InScope reports whether a repository-relative path may be read and written through this client.
+func (g *GitClient) InScope(rel string) bool {
+	rel = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(rel)), "./")
+	for _, scope := range g.scopes {
+		matched := false
+		for _, pattern := range scope {
+			if MatchGlob(pattern, rel) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// mayContainScoped reports whether a directory could hold in-scope files, so listings can descend into it.
+func (g *GitClient) mayContainScoped(dir string) bool {
+	dir = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(dir)), "./")
+	if dir == "." || dir == "" {
+		return true
+	}
+	parts := strings.Split(dir, "/")
+	for _, scope := range g.scopes {
+		possible := false
+		for _, pattern := range scope {
+			if prefixMatches(strings.Split(pattern, "/"), parts) {
+				possible = true
+				break
+			}
+		}
+		if !possible {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixMatches reports whether the directory segments can be the start of a path matching pattern.
+func prefixMatches(pattern, dir []string) bool {
+	for i, seg := range dir {
+		if i >= len(pattern) {
+			return false
+		}
+		if pattern[i] == "**" {
+			return true
+		}
+		if ok, err := filepath.Match(pattern[i], seg); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkScope returns ErrOutOfScope for paths outside the client's scope.
+func (g *GitClient) checkScope(rel string) error {
+	if !g.InScope(rel) {
+		return fmt.Errorf("%w: %s", ErrOutOfScope, rel)
+	}
+	return nil
+}
+
+// relPath returns the repository-relative, slash-separated form of an absolute path inside the repository.
+func (g *GitClient) relPath(full string) string {
+	rel, err := filepath.Rel(g.RepoPath, full)
+	if err != nil {
+		return full
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,205 @@
+package gitrepo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Signature formats understood by NewSigner.
+const (
+	SignOpenPGP = "openpgp"
+	SignSSH     = "ssh"
+)
+
+// sshNamespace is the namespace git uses for SSH commit signatures.
+const sshNamespace = "git"
+
+// ErrNoSigningKey is returned when signing is configured but there is no key for a commit's author.
+var ErrNoSigningKey = errors.New("no signing key")
+
+// NewSigner loads a private key and returns a signer for commits. format is SignOpenPGP or SignSSH;
+// when empty it is detected from the key. passphrase unlocks an encrypted key.
+func NewSigner(format string, key []byte, passphrase string) (git.Signer, error) {
+	if format == "" {
+		format = SignSSH
+		if bytes.Contains(key, []byte("PGP PRIVATE KEY BLOCK")) {
+			format = SignOpenPGP
+		}
+	}
+	switch format {
+	case SignOpenPGP:
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenPGP key: %w", err)
+		}
+		if len(entities) == 0 || entities[0].PrivateKey == nil {
+			return nil, fmt.Errorf("no OpenPGP private key found")
+		}
+		entity := entities[0]
+		if entity.PrivateKey.Encrypted {
+			if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("failed to decrypt OpenPGP key: %w", err)
+			}
+		}
+		return openPGPSigner{entity: entity}, nil
+	case SignSSH:
+		var signer cryptossh.Signer
+		var err error
+		if passphrase != "" {
+			signer, err = cryptossh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+		} else {
+			signer, err = cryptossh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SSH key: %w", err)
+		}
+		return sshSigner{signer: signer}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature format %q", format)
+	}
+}
+
+// openPGPSigner makes armored detached OpenPGP signatures, as "gpg --detach-sign --armor" does.
+type openPGPSigner struct {
+	entity *openpgp.Entity
+}
+
+func (s openPGPSigner) Sign(message io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, message, nil); err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sshSigner makes armored SSH signatures in the SSHSIG format, as "ssh-keygen -Y sign -n git" does.
+type sshSigner struct {
+	signer cryptossh.Signer
+}
+
+func (s sshSigner) Sign(message io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	hash := sha512.Sum512(data)
+	signed := append([]byte("SSHSIG"), cryptossh.Marshal(struct {
+		Namespace, Reserved, HashAlgorithm string
+		Hash                               []byte
+	}{sshNamespace, "", "sha512", hash[:]})...)
+
+	var sig *cryptossh.Signature
+	if as, ok := s.signer.(cryptossh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == cryptossh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signed, cryptossh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	blob := append([]byte("SSHSIG"), cryptossh.Marshal(struct {
+		Version                            uint32
+		PublicKey                          []byte
+		Namespace, Reserved, HashAlgorithm string
+		Signature                          []byte
+	}{1, s.signer.PublicKey().Marshal(), sshNamespace, "", "sha512", cryptossh.Marshal(sig)})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var out strings.Builder
+	out.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		out.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	out.WriteString(encoded + "\n-----END SSH SIGNATURE-----\n")
+	return []byte(out.String()), nil
+}
+
+// Signing picks the key each commit is signed with: the author's own key from Dir when there is
+// one, otherwise Default.
+type Signing struct {
+	Default    git.Signer // Optional; signs the commits of authors without their own key.
+	Dir        string     // Optional; per-author keys named "<author>.asc" (OpenPGP) or "<author>.key" (SSH).
+	Passphrase string     // Optional; unlocks the keys in Dir.
+
+	mu      sync.Mutex
+	signers map[string]git.Signer // Keys loaded from Dir by author.
+}
+
+// SignerFor returns the signer for commits by author, or ErrNoSigningKey when there is none.
+func (s *Signing) SignerFor(author string) (git.Signer, error) {
+	if s.Dir != "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if signer, ok := s.signers[author]; ok {
+			return signer, nil
+		}
+		for _, ext := range []string{".asc", ".key"} {
+			key, err := os.ReadFile(filepath.Join(s.Dir, sanitizeTicketID(author)+ext))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read signing key of %s: %w", author, err)
+			}
+			signer, err := NewSigner("", key, s.Passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("signing key of %s: %w", author, err)
+			}
+			if s.signers == nil {
+				s.signers = make(map[string]git.Signer)
+			}
+			s.signers[author] = signer
+			return signer, nil
+		}
+	}
+	if s.Default == nil {
+		return nil, fmt.Errorf("%w for %s", ErrNoSigningKey, author)
+	}
+	return s.Default, nil
+}
+
+// SigningFromConfig builds the commit signing from the configuration, or returns nil when it is not configured.
+func SigningFromConfig() (*Signing, error) {
+	c := config.GetSigning()
+	if c == nil {
+		return nil, nil
+	}
+	s := &Signing{Dir: c.KeyringDir}
+	if c.PassphraseEnv != "" {
+		s.Passphrase = os.Getenv(c.PassphraseEnv)
+	}
+	if c.KeyFile != "" {
+		key, err := os.ReadFile(c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key: %w", err)
+		}
+		if s.Default, err = NewSigner(c.Format, key, s.Passphrase); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// signerFor returns the signer for commits by author, or nil when commits are not signed.
+func (g *GitClient) signerFor(author string) (git.Signer, error) {
+	if g.Signing == nil {
+		return nil, nil
+	}
+	return g.Signing.SignerFor(author)
+}
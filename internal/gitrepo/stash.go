@@ -0,0 +1,165 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+// stashRef points at the latest stash. Each stash commit has HEAD at stashing time as its first
+// parent and the previous stash, if any, as its second, so the stashes form a stack.
+const stashRef = plumbing.ReferenceName("refs/stash")
+
+// ErrNoStash is returned by StashPop when nothing is stashed.
+var ErrNoStash = errors.New("no stash")
+
+// StatusIsClean reports whether the checkout has no uncommitted changes, untracked files included.
+func (g *GitClient) StatusIsClean() (bool, error) {
+	files, err := g.ChangedFiles()
+	return len(files) == 0, err
+}
+
+// ResetHard discards every uncommitted change and untracked file, and any merge in progress,
+// restoring the checkout to HEAD.
+func (g *GitClient) ResetHard() error {
+	defer g.lock()()
+	head, err := g.Repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return g.resetHard(head.Hash())
+}
+
+// resetHard points the checked-out branch at commit and restores the checkout to it.
+func (g *GitClient) resetHard(commit plumbing.Hash) error {
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if len(g.Clone.SparsePaths) > 0 {
+		err = worktree.Reset(&git.ResetOptions{Commit: commit, Mode: git.MixedReset})
+		if err == nil {
+			err = checkoutSparse(g.Repo, g.Clone)
+		}
+	} else {
+		err = worktree.Reset(&git.ResetOptions{Commit: commit, Mode: git.HardReset})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset worktree: %w", err)
+	}
+	if err := worktree.Clean(&git.CleanOptions{Dir: true}); err != nil {
+		return fmt.Errorf("failed to clean worktree: %w", err)
+	}
+	if err := os.Remove(filepath.Join(g.RepoPath, ".git", mergeHeadFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove merge head: %w", err)
+	}
+	return nil
+}
+
+// Stash saves the uncommitted changes, untracked files included, and resets the checkout to HEAD.
+// It returns the stash commit, or "" when there was nothing to stash. StashPop brings the changes back.
+func (g *GitClient) Stash(message string) (string, error) {
+	defer g.lock()()
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := g.status(worktree)
+	if err != nil || len(status) == 0 {
+		return "", err
+	}
+	head, err := g.Repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	parents := []plumbing.Hash{head.Hash()}
+	if prev, err := g.Repo.Reference(stashRef, true); err == nil {
+		parents = append(parents, prev.Hash())
+	}
+	if err := g.stageAll(worktree); err != nil {
+		return "", fmt.Errorf("failed to add changes: %w", err)
+	}
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author:  &object.Signature{Name: "aiagents", Email: "aiagents@aiagents.local", When: time.Now()},
+		Parents: parents,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to stash: %w", err)
+	}
+	if err := g.Repo.Storer.SetReference(plumbing.NewHashReference(stashRef, hash)); err != nil {
+		return "", fmt.Errorf("failed to save stash: %w", err)
+	}
+	// The commit moved the branch; put it back.
+	if err := g.resetHard(head.Hash()); err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// StashPop applies the latest stash to the checkout and drops it. It returns ErrNoStash when
+// nothing is stashed.
+func (g *GitClient) StashPop() error {
+	defer g.lock()()
+	ref, err := g.Repo.Reference(stashRef, true)
+	if err != nil {
+		return ErrNoStash
+	}
+	stash, err := g.Repo.CommitObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read stash: %w", err)
+	}
+	base, err := stash.Parent(0)
+	if err != nil {
+		return fmt.Errorf("failed to read stash base: %w", err)
+	}
+	from, err := base.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to read stash base: %w", err)
+	}
+	to, err := stash.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to read stash: %w", err)
+	}
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to diff stash: %w", err)
+	}
+	for _, c := range changes {
+		action, err := c.Action()
+		if err != nil {
+			return fmt.Errorf("failed to apply stash: %w", err)
+		}
+		if action == merkletrie.Delete {
+			if err := os.Remove(filepath.Join(g.RepoPath, c.From.Name)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to apply stash: %w", err)
+			}
+			continue
+		}
+		file, err := to.TreeEntryFile(&c.To.TreeEntry)
+		if err != nil {
+			return fmt.Errorf("failed to apply stash: %w", err)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to apply stash: %w", err)
+		}
+		full := filepath.Join(g.RepoPath, c.To.Name)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return fmt.Errorf("failed to apply stash: %w", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to apply stash: %w", err)
+		}
+	}
+	if stash.NumParents() > 1 {
+		return g.Repo.Storer.SetReference(plumbing.NewHashReference(stashRef, stash.ParentHashes[1]))
+	}
+	return g.Repo.Storer.RemoveReference(stashRef)
+}
@@ -0,0 +1,169 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/killswitch"
+)
+
+// TagInfo is a tag and the commit it points to.
+type TagInfo struct {
+	Name string    `json:"name"`
+	Hash string    `json:"hash"` // Commit hash; annotated tags are peeled.
+	When time.Time `json:"when"` // Commit time.
+}
+
+// Tags returns the repository's tags, oldest commit first.
+func (g *GitClient) Tags() ([]TagInfo, error) {
+	iter, err := g.Repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer iter.Close()
+	var tags []TagInfo
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		commit, err := g.tagCommit(ref.Hash())
+		if err != nil {
+			return nil // Tags of trees or blobs are not releases.
+		}
+		tags = append(tags, TagInfo{Name: ref.Name().Short(), Hash: commit.Hash.String(), When: commit.Committer.When})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].When.Before(tags[j].When) })
+	return tags, nil
+}
+
+// tagCommit returns the commit a tag reference points to, peeling annotated tags.
+func (g *GitClient) tagCommit(hash plumbing.Hash) (*object.Commit, error) {
+	if tag, err := g.Repo.TagObject(hash); err == nil {
+		return tag.Commit()
+	}
+	return g.Repo.CommitObject(hash)
+}
+
+// CreateTag creates a tag named name on HEAD: an annotated tag with message, or a lightweight one when message is empty.
+func (g *GitClient) CreateTag(name, message, authorName, authorEmail string) error {
+	defer g.lock()()
+	killswitch.Wait("tagging in " + g.RepoPath)
+	if g.DryRun {
+		fmt.Printf("[dry-run] tag %s in %s as %s <%s>\n", name, g.RepoPath, authorName, authorEmail)
+		return nil
+	}
+	head, err := g.Repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	var opts *git.CreateTagOptions
+	if message != "" {
+		opts = &git.CreateTagOptions{
+			Tagger:  &object.Signature{Name: authorName, Email: authorEmail, When: time.Now()},
+			Message: message,
+		}
+	}
+	if _, err := g.Repo.CreateTag(name, head.Hash(), opts); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// PushTags pushes all tags to the remote. Tags already on the remote are not an error.
+// If username and token are empty, the client's configured AuthConfig is used instead of basic auth.
+func (g *GitClient) PushTags(username, token string) (err error) {
+	defer g.lock()()
+	span := g.Tracer.Start("", "PushTags", "git.repo", g.RepoPath, "git.remote", g.RepoURL)
+	defer func() { span.Finish(err) }()
+	if g.Guard != nil {
+		if err := g.Guard.CheckPush("refs/tags", false); err != nil {
+			return err
+		}
+	}
+	killswitch.Wait("pushing tags of " + g.RepoPath)
+	if g.DryRun {
+		fmt.Printf("[dry-run] push tags to %s\n", g.RepoURL)
+		return nil
+	}
+	auth, err := g.authFor(username, token)
+	if err != nil {
+		return fmt.Errorf("failed to configure auth: %w", err)
+	}
+	err = g.Repo.Push(&git.PushOptions{
+		Auth:     auth,
+		RefSpecs: []gitconfig.RefSpec{"refs/tags/*:refs/tags/*"},
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		err = nil
+	}
+	g.record(events.Push, "refs/tags", nil, err)
+	if err != nil {
+		return fmt.Errorf("failed to push tags: %w", err)
+	}
+	return nil
+}
+
+// CommitsBetween returns the commits reachable from to but not from from, newest first. Both are
+// revisions such as a tag, branch or hash; an empty from returns the whole history of to, and an
+// empty to means HEAD.
+func (g *GitClient) CommitsBetween(from, to string) ([]CommitInfo, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	toHash, err := g.Repo.ResolveRevision(plumbing.Revision(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", to, err)
+	}
+	excluded := make(map[plumbing.Hash]bool)
+	if from != "" {
+		fromHash, err := g.Repo.ResolveRevision(plumbing.Revision(from))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", from, err)
+		}
+		iter, err := g.Repo.Log(&git.LogOptions{From: *fromHash})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log: %w", err)
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate log: %w", err)
+		}
+	}
+	iter, err := g.Repo.Log(&git.LogOptions{From: *toHash, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+	var commits []CommitInfo
+	err = iter.ForEach(func(c *object.Commit) error {
+		if excluded[c.Hash] {
+			return nil
+		}
+		commits = append(commits, CommitInfo{
+			Hash:        c.Hash.String(),
+			AuthorName:  c.Author.Name,
+			AuthorEmail: c.Author.Email,
+			When:        c.Author.When,
+			Message:     strings.TrimSpace(c.Message),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate log: %w", err)
+	}
+	return commits, nil
+}
@@ -0,0 +1,141 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var unsafeRefChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// sanitizeTicketID turns a ticket identifier into something usable as a branch and directory name.
+func sanitizeTicketID(ticketID string) string {
+	return unsafeRefChars.ReplaceAllString(ticketID, "-")
+}
+
+// BranchForTicket returns the name of the feature branch used for a ticket.
+func BranchForTicket(ticketID string) string {
+	return "ticket/" + sanitizeTicketID(ticketID)
+}
+
+// worktreeDir returns the directory holding the isolated checkout for a ticket.
+// Checkouts live next to the main repository in "<repo>-worktrees/<ticket>".
+func (g *GitClient) worktreeDir(ticketID string) string {
+	root := filepath.Clean(g.RepoPath) + "-worktrees"
+	return filepath.Join(root, sanitizeTicketID(ticketID))
+}
+
+// withRepo returns a copy of the client settings bound to another checkout.
+func (g *GitClient) withRepo(dir string, repo *git.Repository) *GitClient {
+	c := *g
+	c.RepoPath = dir
+	c.Repo = repo
+	return &c
+}
+
+// WorktreeFor returns a GitClient operating on an isolated checkout of the ticket's feature branch.
+// The checkout is a shared clone of the main repository (objects are borrowed, index and HEAD are not),
+// so several agents can stage and commit concurrently without touching each other's files (see the
+// concurrency model in lock.go). Calling it again for the same ticket reopens the existing checkout.
+func (g *GitClient) WorktreeFor(ticketID string) (*GitClient, error) {
+	if sanitizeTicketID(ticketID) == "" {
+		return nil, fmt.Errorf("ticket ID is empty")
+	}
+	dir := g.worktreeDir(ticketID)
+	branch := BranchForTicket(ticketID)
+	l := lockFor(dir) // Concurrent callers for one ticket wait for the first to create the checkout.
+	l.Lock()
+	defer l.Unlock()
+
+	if _, err := os.Stat(dir); err == nil {
+		repo, err := git.PlainOpen(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open worktree for %s: %w", ticketID, err)
+		}
+		return g.forTicket(ticketID, dir, repo), nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create worktree root: %w", err)
+	}
+	repo, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:        g.RepoPath,
+		Shared:     true,
+		Depth:      g.Clone.Depth,
+		NoCheckout: len(g.Clone.SparsePaths) > 0, // Checked out sparsely below.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worktree for %s: %w", ticketID, err)
+	}
+
+	// Point origin at the real upstream so pushes from the worktree go to the shared remote.
+	if g.RepoURL != "" {
+		if err := repo.DeleteRemote("origin"); err != nil {
+			return nil, fmt.Errorf("failed to reset origin remote: %w", err)
+		}
+		if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{g.RepoURL}}); err != nil {
+			return nil, fmt.Errorf("failed to configure origin remote: %w", err)
+		}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(branch),
+		Create: true,
+		Keep:   len(g.Clone.SparsePaths) > 0,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s: %w", branch, err)
+	}
+	if len(g.Clone.SparsePaths) > 0 {
+		if err := checkoutSparse(repo, g.Clone); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.forTicket(ticketID, dir, repo), nil
+}
+
+// forTicket binds a copy of the client to a ticket's checkout.
+func (g *GitClient) forTicket(ticketID, dir string, repo *git.Repository) *GitClient {
+	c := g.withRepo(dir, repo)
+	c.TicketID = ticketID
+	return c
+}
+
+// RemoveWorktree deletes the isolated checkout of a ticket. Unpushed commits are lost.
+func (g *GitClient) RemoveWorktree(ticketID string) error {
+	dir := g.worktreeDir(ticketID)
+	l := lockFor(dir)
+	l.Lock()
+	defer l.Unlock()
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove worktree for %s: %w", ticketID, err)
+	}
+	return nil
+}
+
+// CurrentBranch returns the short name of the branch checked out in this repository.
+func (g *GitClient) CurrentBranch() (string, error) {
+	head, err := g.Repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return head.Name().Short(), nil
+}
+
+// HeadCommit returns the SHA of the commit checked out in this repository.
+func (g *GitClient) HeadCommit() (string, error) {
+	head, err := g.Repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
@@ -0,0 +1,174 @@
+// Package logs reads application logs from files, streams or Loki and groups recurring errors.
+package logs
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry is a single log line.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"` // Lower-cased, e.g. "error"; empty when unknown.
+	Message string    `json:"message"`
+	Source  string    `json:"source"` // e.g. the file or Loki query the line came from.
+}
+
+// Source yields the log entries written since its previous Read.
+type Source interface {
+	Read() ([]Entry, error)
+}
+
+// errorLevels are the levels clustered as errors.
+var errorLevels = map[string]bool{"error": true, "err": true, "fatal": true, "panic": true, "critical": true, "crit": true}
+
+// IsError reports whether the entry is an error.
+func (e Entry) IsError() bool {
+	return errorLevels[e.Level]
+}
+
+// textLevel finds the level of plain-text lines, e.g. "ERROR ...", "level=error" or "[error]".
+var textLevel = regexp.MustCompile(`(?i)(?:^|[\s\[(]|level=)(debug|info|warn|warning|error|err|fatal|panic|critical|crit)(?:$|[\s\]):])`)
+
+// Parse turns a raw line into an Entry. JSON lines are read by their common level, message and
+// time keys; other lines get their level from the first level word, and "panic:" lines are panics.
+func Parse(line, source string) Entry {
+	line = strings.TrimRight(line, "\r\n")
+	e := Entry{Message: line, Source: source}
+	var fields map[string]interface{}
+	if strings.HasPrefix(strings.TrimSpace(line), "{") && json.Unmarshal([]byte(line), &fields) == nil {
+		for _, k := range []string{"level", "lvl", "severity"} {
+			if v, ok := fields[k].(string); ok {
+				e.Level = strings.ToLower(v)
+				break
+			}
+		}
+		for _, k := range []string{"msg", "message", "error"} {
+			if v, ok := fields[k].(string); ok {
+				e.Message = v
+				break
+			}
+		}
+		if err, ok := fields["error"].(string); ok && e.Message != err {
+			e.Message += ": " + err
+		}
+		for _, k := range []string{"time", "ts", "timestamp"} {
+			if v, ok := fields[k].(string); ok {
+				if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+					e.Time = t
+				}
+				break
+			}
+		}
+		return e
+	}
+	if strings.HasPrefix(line, "panic: ") {
+		e.Level = "panic"
+	} else if m := textLevel.FindStringSubmatch(line); m != nil {
+		e.Level = strings.ToLower(m[1])
+	}
+	return e
+}
+
+// Patterns replaced by placeholders when computing signatures, most specific first.
+var (
+	uuidPattern   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	timePattern   = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+	hexPattern    = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-f]{12,}\b`)
+	quotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// Normalize strips the variable parts of a message (IDs, times, addresses, quoted values and
+// numbers) so occurrences of the same error compare equal.
+func Normalize(message string) string {
+	s := uuidPattern.ReplaceAllString(message, "<id>")
+	s = timePattern.ReplaceAllString(s, "<time>")
+	s = hexPattern.ReplaceAllString(s, "<hex>")
+	s = quotedPattern.ReplaceAllString(s, "<str>")
+	s = numberPattern.ReplaceAllString(s, "<n>")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Signature returns a short stable identifier of a normalized message.
+func Signature(message string) string {
+	sum := sha1.Sum([]byte(Normalize(message)))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Cluster is a group of occurrences of the same error.
+type Cluster struct {
+	Signature string    `json:"signature"`
+	Pattern   string    `json:"pattern"` // Normalized message.
+	Samples   []string  `json:"samples"` // First distinct raw messages, up to MaxSamples.
+	Level     string    `json:"level"`
+	Count     int       `json:"count"`
+	First     time.Time `json:"first"`
+	Last      time.Time `json:"last"`
+}
+
+// MaxSamples bounds the raw messages kept per cluster.
+const MaxSamples = 3
+
+// Clusters accumulates error entries into clusters across reads.
+type Clusters map[string]*Cluster
+
+// Add clusters the error entries among entries. Entries without a time count as seen now.
+func (c Clusters) Add(entries []Entry) {
+	for _, e := range entries {
+		if !e.IsError() {
+			continue
+		}
+		when := e.Time
+		if when.IsZero() {
+			when = time.Now()
+		}
+		sig := Signature(e.Message)
+		cl, ok := c[sig]
+		if !ok {
+			cl = &Cluster{Signature: sig, Pattern: Normalize(e.Message), Level: e.Level, First: when}
+			c[sig] = cl
+		}
+		cl.Count++
+		if when.After(cl.Last) {
+			cl.Last = when
+		}
+		if when.Before(cl.First) {
+			cl.First = when
+		}
+		if len(cl.Samples) < MaxSamples && !contains(cl.Samples, e.Message) {
+			cl.Samples = append(cl.Samples, e.Message)
+		}
+	}
+}
+
+// Recurring returns the clusters seen at least min times, most frequent first.
+func (c Clusters) Recurring(min int) []*Cluster {
+	var out []*Cluster
+	for _, cl := range c {
+		if cl.Count >= min {
+			out = append(out, cl)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Signature < out[j].Signature
+	})
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSource tails a log file. The first Read starts at the end of the file unless FromStart is
+// set; a file that shrank, e.g. after rotation, is read again from the start.
+type FileSource struct {
+	Path      string
+	FromStart bool
+
+	offset  int64
+	started bool
+}
+
+// NewFileSource creates a FileSource for path.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+// Read returns the complete lines appended since the previous Read.
+func (f *FileSource) Read() ([]Entry, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	if !f.started {
+		f.started = true
+		if !f.FromStart {
+			f.offset = info.Size()
+		}
+	}
+	if info.Size() < f.offset {
+		f.offset = 0
+	}
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek log file: %w", err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	complete := strings.LastIndexByte(string(data), '\n') + 1 // A partial last line is read next time.
+	f.offset += int64(complete)
+	var entries []Entry
+	for _, line := range strings.Split(string(data[:complete]), "\n") {
+		if strings.TrimSpace(line) != "" {
+			entries = append(entries, Parse(line, f.Path))
+		}
+	}
+	return entries, nil
+}
+
+// ReaderSource buffers the lines of a stream, e.g. stdin, in the background.
+type ReaderSource struct {
+	Name string
+
+	mu      sync.Mutex
+	pending []Entry
+	err     error
+}
+
+// NewReaderSource starts reading r and returns a ReaderSource named name.
+func NewReaderSource(name string, r io.Reader) *ReaderSource {
+	s := &ReaderSource{Name: name}
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+			e := Parse(scanner.Text(), name)
+			s.mu.Lock()
+			s.pending = append(s.pending, e)
+			s.mu.Unlock()
+		}
+		s.mu.Lock()
+		s.err = scanner.Err()
+		s.mu.Unlock()
+	}()
+	return s
+}
+
+// Read returns the lines received since the previous Read, and any error that ended the stream.
+func (s *ReaderSource) Read() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.pending
+	s.pending = nil
+	if s.err != nil {
+		err := s.err
+		s.err = nil
+		return entries, fmt.Errorf("failed to read %s: %w", s.Name, err)
+	}
+	return entries, nil
+}
+
+// DefaultLokiLimit bounds the lines fetched from Loki per Read.
+const DefaultLokiLimit = 1000
+
+// LokiSource pulls log lines matching a LogQL query from Loki.
+type LokiSource struct {
+	URL        string // e.g. "http://loki:3100".
+	Query      string // LogQL, e.g. `{app="shop"} |= "error"`.
+	Limit      int    // Zero uses DefaultLokiLimit.
+	HTTPClient *http.Client
+
+	since time.Time
+}
+
+// NewLokiSource creates a LokiSource that starts with the lines of the last lookback.
+func NewLokiSource(baseURL, query string, lookback time.Duration) *LokiSource {
+	return &LokiSource{
+		URL:        strings.TrimSuffix(baseURL, "/"),
+		Query:      query,
+		HTTPClient: &http.Client{},
+		since:      time.Now().Add(-lookback),
+	}
+}
+
+// Read returns the lines logged since the previous Read, oldest first.
+func (l *LokiSource) Read() ([]Entry, error) {
+	limit := l.Limit
+	if limit <= 0 {
+		limit = DefaultLokiLimit
+	}
+	q := url.Values{}
+	q.Set("query", l.Query)
+	q.Set("start", strconv.FormatInt(l.since.UnixNano(), 10))
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("direction", "forward")
+	resp, err := l.HTTPClient.Get(l.URL + "/loki/api/v1/query_range?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Loki: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("loki returned status %d: %s", resp.StatusCode, string(body))
+	}
+	var result struct {
+		Data struct {
+			Result []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"` // [unix nanoseconds, line]
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode Loki response: %w", err)
+	}
+	var entries []Entry
+	for _, stream := range result.Data.Result {
+		for _, v := range stream.Values {
+			ns, err := strconv.ParseInt(v[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			e := Parse(v[1], l.Query)
+			if e.Time.IsZero() {
+				e.Time = time.Unix(0, ns)
+			}
+			if e.Level == "" {
+				e.Level = strings.ToLower(stream.Stream["level"])
+			}
+			entries = append(entries, e)
+			if t := time.Unix(0, ns+1); t.After(l.since) {
+				l.since = t
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
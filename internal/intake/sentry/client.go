@@ -0,0 +1,127 @@
+package sentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client reads issues and events from the Sentry API.
+type Client struct {
+	Token      string // Auth token with event:read scope.
+	Org        string // Organization slug.
+	Project    string // Project slug.
+	BaseURL    string // e.g. "https://sentry.io".
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client for a project. An empty baseURL defaults to sentry.io.
+func NewClient(token, org, project, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = "https://sentry.io"
+	}
+	return &Client{Token: token, Org: org, Project: project, BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: &http.Client{}}
+}
+
+// get performs an authenticated GET request and decodes the JSON response into out.
+func (c *Client) get(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry API returned status %d: %s", resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// UnresolvedIssues returns the project's unresolved issues.
+func (c *Client) UnresolvedIssues() ([]Issue, error) {
+	var issues []Issue
+	path := fmt.Sprintf("/api/0/projects/%s/%s/issues/?query=%s", url.PathEscape(c.Org), url.PathEscape(c.Project), url.QueryEscape("is:unresolved"))
+	if err := c.get(path, &issues); err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	return issues, nil
+}
+
+// LatestExceptions returns the exceptions of the issue's latest event.
+func (c *Client) LatestExceptions(issueID string) ([]Exception, error) {
+	var e event
+	if err := c.get("/api/0/issues/"+url.PathEscape(issueID)+"/events/latest/", &e); err != nil {
+		return nil, fmt.Errorf("failed to get latest event: %w", err)
+	}
+	return e.exceptions(), nil
+}
+
+// event mirrors the fields of a Sentry event we care about. Webhooks carry the exceptions at the
+// top level, the API in an "exception" entry.
+type event struct {
+	IssueID   string        `json:"issue_id"`
+	Title     string        `json:"title"`
+	Culprit   string        `json:"culprit"`
+	Level     string        `json:"level"`
+	WebURL    string        `json:"web_url"`
+	Exception exceptionList `json:"exception"`
+	Entries   []struct {
+		Type string        `json:"type"`
+		Data exceptionList `json:"data"`
+	} `json:"entries"`
+}
+
+type exceptionList struct {
+	Values []struct {
+		Type       string `json:"type"`
+		Value      string `json:"value"`
+		Stacktrace struct {
+			Frames []struct {
+				Filename string `json:"filename"`
+				AbsPath  string `json:"abs_path"`
+				Lineno   int    `json:"lineno"`
+				Function string `json:"function"`
+				InApp    bool   `json:"in_app"`
+			} `json:"frames"`
+		} `json:"stacktrace"`
+	} `json:"values"`
+}
+
+// exceptions converts the event's exceptions, preferring frame filenames relative to the project.
+func (e event) exceptions() []Exception {
+	lists := []exceptionList{e.Exception}
+	for _, entry := range e.Entries {
+		if entry.Type == "exception" {
+			lists = append(lists, entry.Data)
+		}
+	}
+	var exceptions []Exception
+	for _, l := range lists {
+		for _, v := range l.Values {
+			ex := Exception{Type: v.Type, Value: v.Value}
+			for _, f := range v.Stacktrace.Frames {
+				file := f.Filename
+				if file == "" {
+					file = f.AbsPath
+				}
+				ex.Frames = append(ex.Frames, Frame{File: file, Line: f.Lineno, Function: f.Function, InApp: f.InApp})
+			}
+			exceptions = append(exceptions, ex)
+		}
+	}
+	return exceptions
+}
@@ -0,0 +1,213 @@
+// Package sentry files bug tickets for Sentry issues, received by webhook or polled from the API.
+package sentry
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// issueMarker prefixes the description line naming the Sentry issue a ticket was filed for.
+const issueMarker = "Sentry issue: "
+
+// maxFrames bounds the stack frames written into a ticket.
+const maxFrames = 30
+
+// Frame is one stack frame of an error.
+type Frame struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+	InApp    bool   `json:"inApp"` // Whether the frame is in the application's own code.
+}
+
+// Exception is one error of an event with its stack trace, outermost frame first as Sentry sends it.
+type Exception struct {
+	Type   string  `json:"type"`
+	Value  string  `json:"value"`
+	Frames []Frame `json:"frames"`
+}
+
+// Issue is a Sentry issue, a group of events with the same error.
+type Issue struct {
+	ID         string      `json:"id"`
+	ShortID    string      `json:"shortId"` // e.g. "SHOP-1A".
+	Title      string      `json:"title"`
+	Culprit    string      `json:"culprit"` // Function or transaction the error is attributed to.
+	Level      string      `json:"level"`
+	Count      string      `json:"count"` // Number of events.
+	Permalink  string      `json:"permalink"`
+	Exceptions []Exception `json:"exceptions"` // Of the latest event; may be empty.
+}
+
+// Intake files a bug ticket in List for every Sentry issue that has none yet.
+type Intake struct {
+	Board board.Board
+	List  string // List bug tickets are created in.
+	// Repo, when set, is searched for the files of in-app stack frames to name the suspected files.
+	Repo *gitrepo.GitClient
+	// Client, when set, fetches the stack trace of issues delivered without one.
+	Client *Client
+
+	mu sync.Mutex // Serializes filing so concurrent deliveries of one issue create one ticket.
+}
+
+// NewIntake creates an Intake filing tickets on b in board.ListBugs.
+func NewIntake(b board.Board) *Intake {
+	return &Intake{Board: b, List: board.ListBugs}
+}
+
+// IssueID returns the Sentry issue a ticket was filed for, if any.
+func IssueID(c board.Card) (string, bool) {
+	for _, line := range strings.Split(c.GetDescription(), "\n") {
+		if id, ok := strings.CutPrefix(strings.TrimSpace(line), issueMarker); ok {
+			return strings.TrimSpace(id), true
+		}
+	}
+	return "", false
+}
+
+// find returns the ticket already filed for the issue, or nil.
+func (in *Intake) find(id string) (board.Card, error) {
+	cards, err := in.Board.GetCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, c := range cards {
+		if filed, ok := IssueID(c); ok && filed == id {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// File creates a bug ticket for issue unless one was filed before, and reports whether it created one.
+func (in *Intake) File(issue Issue) (board.Card, bool, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if existing, err := in.find(issue.ID); err != nil || existing != nil {
+		return existing, false, err
+	}
+	if len(issue.Exceptions) == 0 && in.Client != nil {
+		if exceptions, err := in.Client.LatestExceptions(issue.ID); err == nil {
+			issue.Exceptions = exceptions
+		} else {
+			fmt.Printf("Warning: failed to get the stack trace of Sentry issue %s: %v\n", issue.ID, err)
+		}
+	}
+	card, err := in.Board.CreateCard("[bug] "+issue.Title, in.describe(issue), in.List)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create bug ticket: %w", err)
+	}
+	return card, true, nil
+}
+
+// Sync files a ticket for every unresolved issue of the client's project and returns how many it created.
+func (in *Intake) Sync() (int, error) {
+	if in.Client == nil {
+		return 0, fmt.Errorf("no Sentry client configured")
+	}
+	issues, err := in.Client.UnresolvedIssues()
+	if err != nil {
+		return 0, err
+	}
+	created := 0
+	for _, issue := range issues {
+		_, ok, err := in.File(issue)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// describe writes the ticket description of an issue.
+func (in *Intake) describe(issue Issue) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Reported by Sentry: %s\n\n", issue.Permalink))
+	if issue.Level != "" {
+		sb.WriteString(fmt.Sprintf("Level: %s\n", issue.Level))
+	}
+	if issue.Culprit != "" {
+		sb.WriteString(fmt.Sprintf("Culprit: %s\n", issue.Culprit))
+	}
+	if issue.Count != "" {
+		sb.WriteString(fmt.Sprintf("Events: %s\n", issue.Count))
+	}
+	if trace := stackTrace(issue.Exceptions); trace != "" {
+		sb.WriteString("\nStack trace:\n" + board.CodeBlock(trace, "") + "\n")
+	}
+	if files := in.suspectedFiles(issue.Exceptions); len(files) > 0 {
+		sb.WriteString("\nSuspected files:\n")
+		for _, f := range files {
+			sb.WriteString("- " + f + "\n")
+		}
+	}
+	sb.WriteString("\n---\n" + issueMarker + issue.ID)
+	return sb.String()
+}
+
+// stackTrace renders exceptions with their innermost frames first.
+func stackTrace(exceptions []Exception) string {
+	var sb strings.Builder
+	for _, e := range exceptions {
+		sb.WriteString(strings.TrimSpace(e.Type+": "+e.Value) + "\n")
+		for i := len(e.Frames) - 1; i >= 0 && len(e.Frames)-i <= maxFrames; i-- {
+			f := e.Frames[i]
+			sb.WriteString(fmt.Sprintf("  at %s (%s:%d)\n", f.Function, f.File, f.Line))
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// suspectedFiles returns the files of the in-app frames, innermost first. With a Repo they are
+// matched to repository files by path suffix, and frames outside the repository are dropped.
+func (in *Intake) suspectedFiles(exceptions []Exception) []string {
+	var repoFiles []string
+	if in.Repo != nil {
+		var err error
+		if repoFiles, err = in.Repo.ListFiles("**/*"); err != nil {
+			fmt.Printf("Warning: failed to list repository files: %v\n", err)
+			repoFiles = nil
+		}
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for _, e := range exceptions {
+		for i := len(e.Frames) - 1; i >= 0; i-- {
+			f := e.Frames[i]
+			if !f.InApp || f.File == "" {
+				continue
+			}
+			file := f.File
+			if in.Repo != nil {
+				if file = matchFile(file, repoFiles); file == "" {
+					continue
+				}
+			}
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// matchFile returns the repository file whose path is the longest suffix of frame, or "".
+func matchFile(frame string, repoFiles []string) string {
+	frame = strings.TrimPrefix(frame, "./")
+	best := ""
+	for _, f := range repoFiles {
+		if (frame == f || strings.HasSuffix(frame, "/"+f)) && len(f) > len(best) {
+			best = f
+		}
+	}
+	return best
+}
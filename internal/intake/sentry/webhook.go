@@ -0,0 +1,86 @@
+package sentry
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxWebhookBody bounds the size of accepted webhook deliveries.
+const maxWebhookBody = 5 << 20
+
+// WebhookHandler receives Sentry integration webhooks and files a ticket for each new issue:
+// "issue" deliveries with action "created", and "event_alert" and "error" deliveries, which carry
+// the event's stack trace. With a non-empty secret (the integration's client secret), deliveries
+// must carry a valid Sentry-Hook-Signature header. Other deliveries are acknowledged and ignored.
+func WebhookHandler(secret string, in *Intake) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if secret != "" && !validSignature(secret, body, r.Header.Get("Sentry-Hook-Signature")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var payload struct {
+			Action string `json:"action"`
+			Data   struct {
+				Issue *Issue `json:"issue"`
+				Event *event `json:"event"`
+				Error *event `json:"error"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+		var issue *Issue
+		switch resource := r.Header.Get("Sentry-Hook-Resource"); {
+		case resource == "issue" && payload.Action == "created" && payload.Data.Issue != nil:
+			issue = payload.Data.Issue
+		case resource == "event_alert" && payload.Data.Event != nil:
+			issue = payload.Data.Event.issue()
+		case resource == "error" && payload.Data.Error != nil:
+			issue = payload.Data.Error.issue()
+		}
+		if issue != nil && issue.ID != "" {
+			if _, _, err := in.File(*issue); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// issue returns the issue an event belongs to, with the event's stack trace.
+func (e event) issue() *Issue {
+	return &Issue{
+		ID:         e.IssueID,
+		Title:      e.Title,
+		Culprit:    e.Culprit,
+		Level:      e.Level,
+		Permalink:  e.WebURL,
+		Exceptions: e.exceptions(),
+	}
+}
+
+// validSignature checks the hex HMAC-SHA256 of body against signature.
+func validSignature(secret string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
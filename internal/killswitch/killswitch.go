@@ -0,0 +1,116 @@
+package killswitch
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is how often Wait re-checks a paused switch.
+const DefaultPollInterval = 2 * time.Second
+
+// EnvFlagFile names the environment variable holding the path of the flag file.
+// While that file exists every agent is paused; its content, if any, is used as the reason.
+const EnvFlagFile = "AIAGENTS_KILL_FILE"
+
+// SourceManual is the source of Engage and Release, e.g. an operator on the dashboard.
+const SourceManual = "manual"
+
+var (
+	mu       sync.Mutex
+	holds    = make(map[string]string) // Reason per source that engaged the switch.
+	interval = DefaultPollInterval
+)
+
+// Engage pauses all side-effectful actions (comments, card changes, commits and pushes) in this process.
+// It is EngageFor with SourceManual.
+func Engage(why string) {
+	EngageFor(SourceManual, why)
+}
+
+// Release drops the hold taken by Engage. Holds of other sources and a present flag file keep the switch
+// engaged.
+func Release() {
+	ReleaseFor(SourceManual)
+}
+
+// EngageFor pauses actions on behalf of source, e.g. a kill switch card. The switch stays engaged until
+// every source has released its hold.
+func EngageFor(source, why string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(holds) == 0 {
+		fmt.Printf("Kill switch engaged: %s\n", why)
+	}
+	holds[source] = why
+}
+
+// ReleaseFor drops the hold of source; releasing a source without a hold does nothing.
+func ReleaseFor(source string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := holds[source]; !ok {
+		return
+	}
+	delete(holds, source)
+	if len(holds) == 0 {
+		fmt.Println("Kill switch released")
+	}
+}
+
+// SetPollInterval changes how often Wait re-checks the switch, e.g. to speed up tests.
+func SetPollInterval(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	interval = d
+}
+
+// Engaged reports whether actions are paused, either by a hold or by the flag file, and why. The reasons
+// of several holds are joined in source order.
+func Engaged() (bool, string) {
+	mu.Lock()
+	sources := make([]string, 0, len(holds))
+	for source := range holds {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	reasons := make([]string, 0, len(sources))
+	for _, source := range sources {
+		reasons = append(reasons, holds[source])
+	}
+	mu.Unlock()
+	if len(reasons) > 0 {
+		return true, strings.Join(reasons, "; ")
+	}
+	if path := os.Getenv(EnvFlagFile); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			why := strings.TrimSpace(string(data))
+			if why == "" {
+				why = "flag file " + path + " present"
+			}
+			return true, why
+		}
+	}
+	return false, ""
+}
+
+// Wait blocks while the switch is engaged, so the action resumes as soon as it is released.
+// action describes what is waiting and is printed once.
+func Wait(action string) {
+	on, why := Engaged()
+	if !on {
+		return
+	}
+	fmt.Printf("Paused before %s: %s\n", action, why)
+	for on {
+		mu.Lock()
+		d := interval
+		mu.Unlock()
+		time.Sleep(d)
+		on, _ = Engaged()
+	}
+	fmt.Printf("Resuming %s\n", action)
+}
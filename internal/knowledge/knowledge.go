@@ -0,0 +1,351 @@
+// Package knowledge indexes the project's own documentation (markdown docs, ADRs and API specs)
+// so agents can ground their prompts in the project's conventions.
+package knowledge
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/context/embedding"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/memory"
+)
+
+// Document kinds.
+const (
+	KindDoc = "doc" // Markdown documentation.
+	KindADR = "adr" // Architecture decision record.
+	KindAPI = "api" // API specification (OpenAPI/Swagger YAML or JSON).
+)
+
+// DefaultDir is the repository directory ingested when none is configured.
+const DefaultDir = "docs"
+
+// namespace marks knowledge base records in a shared vector store.
+const namespace = "knowledge"
+
+// DefaultChunkSize is the approximate maximum chunk length in characters.
+const DefaultChunkSize = 1500
+
+// Chunk is one searchable piece of a document.
+type Chunk struct {
+	Source    string    `json:"source"`            // Repository path of the document.
+	Kind      string    `json:"kind"`              // KindDoc, KindADR or KindAPI.
+	Heading   string    `json:"heading,omitempty"` // Nearest markdown heading.
+	Text      string    `json:"text"`
+	Embedding []float64 `json:"-"`
+}
+
+// Base is a knowledge base. Without an Embedder, Search ranks chunks by keyword overlap; with one and a
+// Store, embedded chunks are kept in (and searched from) the vector store, so they survive restarts.
+type Base struct {
+	Embedder  embedding.EmbeddingProvider // Optional; enables semantic search.
+	Store     memory.VectorStore          // Optional; persists embedded chunks. Requires Embedder.
+	ChunkSize int                         // Zero uses DefaultChunkSize.
+
+	mu     sync.RWMutex
+	chunks []Chunk
+}
+
+// New creates an empty knowledge base.
+func New(embedder embedding.EmbeddingProvider) *Base {
+	return &Base{Embedder: embedder}
+}
+
+// Len returns the number of indexed chunks.
+func (b *Base) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.chunks)
+}
+
+// IngestRepo indexes every markdown, YAML and JSON file below dir in the repository and
+// returns the number of documents indexed. Files that fail to read are skipped with a warning.
+func (b *Base) IngestRepo(repo *gitrepo.GitClient, dir string) (int, error) {
+	if dir == "" {
+		dir = DefaultDir
+	}
+	files, err := repo.ListFiles(strings.TrimSuffix(dir, "/") + "/**/*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	n := 0
+	for _, f := range files {
+		if kindOf(f) == "" {
+			continue
+		}
+		text, err := repo.ReadText(f)
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", f, err)
+			continue
+		}
+		if err := b.Add(f, text); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Add chunks, embeds and indexes a document, replacing any earlier version of the same source.
+func (b *Base) Add(source, text string) error {
+	kind := kindOf(source)
+	if kind == "" {
+		kind = KindDoc
+	}
+	size := b.ChunkSize
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	var chunks []Chunk
+	for _, s := range split(text, kind, size) {
+		c := Chunk{Source: source, Kind: kind, Heading: s.heading, Text: s.text}
+		if b.Embedder != nil {
+			emb, err := b.Embedder.ComputeEmbedding(embedText(c))
+			if err != nil {
+				return fmt.Errorf("failed to embed %s: %w", source, err)
+			}
+			c.Embedding = emb
+		}
+		chunks = append(chunks, c)
+	}
+	if b.Embedder != nil && b.Store != nil {
+		if err := b.store(source, chunks); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	kept := b.chunks[:0]
+	for _, c := range b.chunks {
+		if c.Source != source {
+			kept = append(kept, c)
+		}
+	}
+	b.chunks = append(kept, chunks...)
+	return nil
+}
+
+// Search returns up to k chunks most relevant to the query, best first.
+func (b *Base) Search(query string, k int) ([]Chunk, error) {
+	if k <= 0 || strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	var q []float64
+	if b.Embedder != nil {
+		var err error
+		if q, err = b.Embedder.ComputeEmbedding(query); err != nil {
+			return nil, fmt.Errorf("failed to embed query: %w", err)
+		}
+		if b.Store != nil {
+			return b.query(q, k)
+		}
+	}
+	b.mu.RLock()
+	chunks := append([]Chunk(nil), b.chunks...)
+	b.mu.RUnlock()
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+
+	score := keywordScorer(query)
+	if q != nil {
+		score = func(c Chunk) float64 { return memory.Cosine(q, c.Embedding) }
+	}
+	type scored struct {
+		chunk Chunk
+		score float64
+	}
+	var ranked []scored
+	for _, c := range chunks {
+		if s := score(c); s > 0 {
+			ranked = append(ranked, scored{c, s})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	var result []Chunk
+	for i := 0; i < len(ranked) && i < k; i++ {
+		result = append(result, ranked[i].chunk)
+	}
+	return result, nil
+}
+
+// store replaces the chunks of source in the vector store.
+func (b *Base) store(source string, chunks []Chunk) error {
+	if err := b.Store.DeleteWhere(memory.Filter{"namespace": namespace, "source": source}); err != nil {
+		return fmt.Errorf("failed to remove old chunks of %s: %w", source, err)
+	}
+	records := make([]memory.Record, 0, len(chunks))
+	for i, c := range chunks {
+		records = append(records, memory.Record{
+			ID:       fmt.Sprintf("%s/%s#%d", namespace, source, i),
+			Vector:   c.Embedding,
+			Text:     c.Text,
+			Metadata: map[string]string{"namespace": namespace, "source": source, "kind": c.Kind, "heading": c.Heading},
+		})
+	}
+	if err := b.Store.Upsert(records...); err != nil {
+		return fmt.Errorf("failed to store chunks of %s: %w", source, err)
+	}
+	return nil
+}
+
+// query searches the vector store.
+func (b *Base) query(q []float64, k int) ([]Chunk, error) {
+	matches, err := b.Store.Query(q, k, memory.Filter{"namespace": namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query knowledge store: %w", err)
+	}
+	var result []Chunk
+	for _, m := range matches {
+		if m.Score <= 0 {
+			continue
+		}
+		result = append(result, Chunk{Source: m.Metadata["source"], Kind: m.Metadata["kind"], Heading: m.Metadata["heading"], Text: m.Text, Embedding: m.Vector})
+	}
+	return result, nil
+}
+
+// Context renders the chunks most relevant to the query for a prompt, or "" when nothing matches.
+func (b *Base) Context(query string, k int) (string, error) {
+	chunks, err := b.Search(query, k)
+	if err != nil || len(chunks) == 0 {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString("Project knowledge:\n")
+	for _, c := range chunks {
+		ref := c.Source
+		if c.Heading != "" {
+			ref += " > " + c.Heading
+		}
+		fmt.Fprintf(&sb, "\n[%s: %s]\n%s\n", c.Kind, ref, c.Text)
+	}
+	return sb.String(), nil
+}
+
+// kindOf classifies a document by its path; "" means it is not ingested.
+func kindOf(file string) string {
+	lower := strings.ToLower(file)
+	switch path.Ext(lower) {
+	case ".md", ".markdown":
+		base := path.Base(lower)
+		if strings.Contains(lower, "/adr/") || strings.Contains(lower, "/adrs/") || strings.HasPrefix(base, "adr-") {
+			return KindADR
+		}
+		return KindDoc
+	case ".yaml", ".yml", ".json":
+		return KindAPI
+	}
+	return ""
+}
+
+type section struct {
+	heading, text string
+}
+
+// split cuts a document into chunks of at most size characters. Markdown is split at headings
+// first and then at paragraph breaks; specs are split at line breaks.
+func split(text, kind string, size int) []section {
+	var sections []section
+	if kind == KindAPI {
+		return pack(section{}, strings.Split(text, "\n"), "\n", size)
+	}
+	cur := section{}
+	var body []string
+	flush := func() {
+		var paras []string
+		for _, p := range strings.Split(strings.Join(body, "\n"), "\n\n") {
+			if p = strings.TrimSpace(p); p != "" {
+				paras = append(paras, p)
+			}
+		}
+		sections = append(sections, pack(cur, paras, "\n\n", size)...)
+		body = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			flush()
+			cur = section{heading: strings.TrimSpace(strings.TrimLeft(line, "#"))}
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return sections
+}
+
+// pack joins parts into sections no longer than size, cutting oversized parts.
+func pack(head section, parts []string, sep string, size int) []section {
+	var out []section
+	var cur string
+	emit := func() {
+		if strings.TrimSpace(cur) != "" {
+			out = append(out, section{heading: head.heading, text: strings.TrimSpace(cur)})
+		}
+		cur = ""
+	}
+	for _, p := range parts {
+		for len(p) > size {
+			emit()
+			cur = p[:size]
+			emit()
+			p = p[size:]
+		}
+		if cur != "" && len(cur)+len(sep)+len(p) > size {
+			emit()
+		}
+		if cur != "" {
+			cur += sep
+		}
+		cur += p
+	}
+	emit()
+	return out
+}
+
+func embedText(c Chunk) string {
+	if c.Heading == "" {
+		return c.Text
+	}
+	return c.Heading + "\n" + c.Text
+}
+
+// keywordScorer scores a chunk by the fraction of query terms it contains.
+func keywordScorer(query string) func(Chunk) float64 {
+	terms := words(query)
+	return func(c Chunk) float64 {
+		if len(terms) == 0 {
+			return 0
+		}
+		have := make(map[string]bool)
+		for _, w := range words(c.Heading + " " + c.Text + " " + c.Source) {
+			have[w] = true
+		}
+		hits := 0
+		for _, t := range terms {
+			if have[t] {
+				hits++
+			}
+		}
+		return float64(hits) / float64(len(terms))
+	}
+}
+
+// words returns the distinct lower-cased words of at least three letters.
+func words(s string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	}) {
+		if len(w) >= 3 && !seen[w] {
+			seen[w] = true
+			out = append(out, w)
+		}
+	}
+	return out
+}
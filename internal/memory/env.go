@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+)
+
+// Environment variables read by FromEnv.
+const (
+	EnvBackend      = "AIAGENTS_VECTOR_STORE" // "memory" (default), "file", "qdrant", "pgvector" or "sqlite-vec".
+	EnvPath         = "AIAGENTS_VECTOR_PATH"  // File for "file"; database DSN for "pgvector" and "sqlite-vec".
+	EnvQdrantURL    = "QDRANT_URL"
+	EnvQdrantAPIKey = "QDRANT_API_KEY"
+	EnvCollection   = "AIAGENTS_VECTOR_COLLECTION" // Qdrant collection or SQL table; defaults to "aiagents".
+)
+
+// FromEnv opens the vector store selected by the environment for vectors of dimension dim.
+// The pgvector and sqlite-vec backends need their database/sql driver linked into the binary.
+func FromEnv(dim int) (VectorStore, error) {
+	collection := os.Getenv(EnvCollection)
+	if collection == "" {
+		collection = "aiagents"
+	}
+	path := os.Getenv(EnvPath)
+	switch backend := os.Getenv(EnvBackend); backend {
+	case "", "memory":
+		return NewInMemory(), nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("%s=file requires %s", EnvBackend, EnvPath)
+		}
+		return NewFileStore(path)
+	case "qdrant":
+		url := os.Getenv(EnvQdrantURL)
+		if url == "" {
+			url = "http://localhost:6333"
+		}
+		return NewQdrant(url, collection, os.Getenv(EnvQdrantAPIKey), dim)
+	case "pgvector", "sqlite-vec":
+		driver := "postgres"
+		if backend == "sqlite-vec" {
+			driver = "sqlite3"
+		}
+		db, err := sql.Open(driver, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s database: %w", backend, err)
+		}
+		if backend == "pgvector" {
+			return NewPGVector(db, collection, dim)
+		}
+		return NewSQLiteVec(db, collection, dim)
+	default:
+		return nil, fmt.Errorf("unknown %s %q", EnvBackend, backend)
+	}
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Learning is a summarized fact an agent keeps across runs, e.g. "we use chi for routing".
+type Learning struct {
+	ID       string    `json:"id"`
+	Agent    string    `json:"agent"`
+	Category string    `json:"category,omitempty"`
+	Text     string    `json:"text"`
+	Created  time.Time `json:"created"`
+	Expires  time.Time `json:"expires,omitempty"` // Zero never expires.
+}
+
+// Expired reports whether the learning has expired at now.
+func (l Learning) Expired(now time.Time) bool {
+	return !l.Expires.IsZero() && !now.Before(l.Expires)
+}
+
+// Journal is the long-term memory of the agents, saved to a JSON file after every change.
+type Journal struct {
+	mu        sync.Mutex
+	path      string
+	learnings []Learning
+}
+
+// NewJournal opens the journal at path, loading the learnings saved there earlier.
+// An empty path keeps the journal in memory only.
+func NewJournal(path string) (*Journal, error) {
+	j := &Journal{path: path}
+	if path == "" {
+		return j, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory journal %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &j.learnings); err != nil {
+		return nil, fmt.Errorf("failed to parse memory journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// Add records a learning for agent. A ttl of zero keeps it until it is forgotten. Adding text the
+// agent already knows refreshes the existing learning instead of duplicating it.
+func (j *Journal) Add(agent, category, text string, ttl time.Duration) (Learning, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Learning{}, fmt.Errorf("empty learning")
+	}
+	now := time.Now()
+	l := Learning{ID: uuid.New().String(), Agent: agent, Category: category, Text: text, Created: now}
+	if ttl > 0 {
+		l.Expires = now.Add(ttl)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i, old := range j.learnings {
+		if old.Agent == agent && strings.EqualFold(old.Text, text) {
+			l.ID = old.ID
+			j.learnings[i] = l
+			return l, j.save()
+		}
+	}
+	j.learnings = append(j.learnings, l)
+	return l, j.save()
+}
+
+// Forget removes a learning by ID.
+func (j *Journal) Forget(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i, l := range j.learnings {
+		if l.ID == id {
+			j.learnings = append(j.learnings[:i], j.learnings[i+1:]...)
+			return j.save()
+		}
+	}
+	return fmt.Errorf("learning %s not found", id)
+}
+
+// Expire removes the learnings expired at now and returns how many were removed.
+func (j *Journal) Expire(now time.Time) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	kept := j.learnings[:0]
+	for _, l := range j.learnings {
+		if !l.Expired(now) {
+			kept = append(kept, l)
+		}
+	}
+	removed := len(j.learnings) - len(kept)
+	j.learnings = kept
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, j.save()
+}
+
+// Query returns up to k unexpired learnings of agent containing any word of query, most matching
+// and then newest first. An empty query returns the newest learnings; k <= 0 returns all matches.
+func (j *Journal) Query(agent, query string, k int) []Learning {
+	terms := strings.Fields(strings.ToLower(query))
+	now := time.Now()
+	type scored struct {
+		l     Learning
+		score int
+	}
+	var found []scored
+	j.mu.Lock()
+	for _, l := range j.learnings {
+		if l.Agent != agent || l.Expired(now) {
+			continue
+		}
+		text := strings.ToLower(l.Category + " " + l.Text)
+		score := 0
+		for _, t := range terms {
+			if strings.Contains(text, t) {
+				score++
+			}
+		}
+		if len(terms) == 0 || score > 0 {
+			found = append(found, scored{l, score})
+		}
+	}
+	j.mu.Unlock()
+	sort.SliceStable(found, func(a, b int) bool {
+		if found[a].score != found[b].score {
+			return found[a].score > found[b].score
+		}
+		return found[a].l.Created.After(found[b].l.Created)
+	})
+	var result []Learning
+	for _, f := range found {
+		if k > 0 && len(result) == k {
+			break
+		}
+		result = append(result, f.l)
+	}
+	return result
+}
+
+// SystemMessage renders up to limit of the agent's newest learnings as a compact system prompt,
+// or "" when the agent has none.
+func (j *Journal) SystemMessage(agent string, limit int) string {
+	learnings := j.Query(agent, "", limit)
+	if len(learnings) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("What you learned in earlier runs:")
+	for _, l := range learnings {
+		sb.WriteString("\n- ")
+		if l.Category != "" {
+			sb.WriteString(l.Category + ": ")
+		}
+		sb.WriteString(l.Text)
+	}
+	return sb.String()
+}
+
+// save writes the journal atomically; it is a no-op for in-memory journals.
+func (j *Journal) save() error {
+	if j.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(j.learnings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode memory journal: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create memory journal directory: %w", err)
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write memory journal: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
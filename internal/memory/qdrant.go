@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idKey is the payload field holding a record's own ID; Qdrant point IDs must be UUIDs or integers.
+const idKey = "_id"
+
+// Qdrant is a VectorStore backed by a Qdrant collection, using its REST API.
+type Qdrant struct {
+	URL        string // e.g. "http://localhost:6333".
+	Collection string
+	APIKey     string // Optional.
+	Client     *http.Client
+}
+
+// NewQdrant returns a store for the collection, creating it with cosine distance when it does not exist.
+func NewQdrant(url, collection, apiKey string, dim int) (*Qdrant, error) {
+	q := &Qdrant{URL: strings.TrimRight(url, "/"), Collection: collection, APIKey: apiKey, Client: &http.Client{Timeout: 30 * time.Second}}
+	status, err := q.do(http.MethodGet, "", nil, nil)
+	if err != nil && status != http.StatusNotFound {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		body := map[string]interface{}{"vectors": map[string]interface{}{"size": dim, "distance": "Cosine"}}
+		if _, err := q.do(http.MethodPut, "", body, nil); err != nil {
+			return nil, fmt.Errorf("failed to create Qdrant collection %s: %w", collection, err)
+		}
+	}
+	return q, nil
+}
+
+type qdrantPoint struct {
+	ID      string                 `json:"id"`
+	Vector  []float64              `json:"vector,omitempty"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+	Score   float64                `json:"score,omitempty"`
+}
+
+func (q *Qdrant) Upsert(records ...Record) error {
+	points := make([]qdrantPoint, 0, len(records))
+	for _, r := range records {
+		payload := map[string]interface{}{idKey: r.ID, "text": r.Text}
+		for k, v := range r.Metadata {
+			payload[k] = v
+		}
+		points = append(points, qdrantPoint{ID: pointID(r.ID), Vector: r.Vector, Payload: payload})
+	}
+	_, err := q.do(http.MethodPut, "/points?wait=true", map[string]interface{}{"points": points}, nil)
+	return err
+}
+
+func (q *Qdrant) Query(vector []float64, k int, filter Filter) ([]Match, error) {
+	body := map[string]interface{}{"vector": vector, "limit": k, "with_payload": true, "with_vector": true}
+	if len(filter) > 0 {
+		body["filter"] = qdrantFilter(filter)
+	}
+	var resp struct {
+		Result []qdrantPoint `json:"result"`
+	}
+	if _, err := q.do(http.MethodPost, "/points/search", body, &resp); err != nil {
+		return nil, err
+	}
+	matches := make([]Match, 0, len(resp.Result))
+	for _, p := range resp.Result {
+		r := Record{Vector: p.Vector, Metadata: make(map[string]string)}
+		for k, v := range p.Payload {
+			s := fmt.Sprint(v)
+			switch k {
+			case idKey:
+				r.ID = s
+			case "text":
+				r.Text = s
+			default:
+				r.Metadata[k] = s
+			}
+		}
+		matches = append(matches, Match{Record: r, Score: p.Score})
+	}
+	return matches, nil
+}
+
+func (q *Qdrant) Delete(ids ...string) error {
+	points := make([]string, 0, len(ids))
+	for _, id := range ids {
+		points = append(points, pointID(id))
+	}
+	_, err := q.do(http.MethodPost, "/points/delete?wait=true", map[string]interface{}{"points": points}, nil)
+	return err
+}
+
+func (q *Qdrant) DeleteWhere(filter Filter) error {
+	_, err := q.do(http.MethodPost, "/points/delete?wait=true", map[string]interface{}{"filter": qdrantFilter(filter)}, nil)
+	return err
+}
+
+// pointID derives a stable UUID point ID from a record ID.
+func pointID(id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(id)).String()
+}
+
+func qdrantFilter(filter Filter) map[string]interface{} {
+	var must []map[string]interface{}
+	for k, v := range filter {
+		must = append(must, map[string]interface{}{"key": k, "match": map[string]interface{}{"value": v}})
+	}
+	return map[string]interface{}{"must": must}
+}
+
+// do sends a request to the collection endpoint and decodes the response into out when non-nil.
+func (q *Qdrant) do(method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode Qdrant request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, q.URL+"/collections/"+q.Collection+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Qdrant request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if q.APIKey != "" {
+		req.Header.Set("api-key", q.APIKey)
+	}
+	client := q.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Qdrant request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("Qdrant returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode Qdrant response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
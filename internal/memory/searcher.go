@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/context"
+)
+
+// Searcher adapts a VectorStore to similarity.SimilaritySearcher, so agent context storage can keep
+// its memories in any store. Namespace separates the memories of different agents sharing one store.
+type Searcher struct {
+	Store     VectorStore
+	Namespace string
+}
+
+// NewSearcher returns a searcher over store limited to namespace.
+func NewSearcher(store VectorStore, namespace string) *Searcher {
+	return &Searcher{Store: store, Namespace: namespace}
+}
+
+// IndexMemory stores the memory entry with its category, timestamp and importance as metadata.
+func (s *Searcher) IndexMemory(mem context.MemoryEntry) error {
+	return s.Store.Upsert(Record{
+		ID:     s.Namespace + "/" + mem.ID,
+		Vector: mem.Embedding,
+		Text:   mem.Content,
+		Metadata: map[string]string{
+			"namespace":  s.Namespace,
+			"memory_id":  mem.ID,
+			"category":   mem.Category,
+			"timestamp":  mem.Timestamp.Format(time.RFC3339Nano),
+			"importance": strconv.Itoa(mem.Importance),
+		},
+	})
+}
+
+// Search returns up to k memories whose cosine similarity to query is at least threshold.
+func (s *Searcher) Search(query []float64, k int, threshold float64) ([]context.MemoryEntry, error) {
+	matches, err := s.Store.Query(query, k, Filter{"namespace": s.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	var result []context.MemoryEntry
+	for _, m := range matches {
+		if m.Score < threshold {
+			continue
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, m.Metadata["timestamp"])
+		importance, _ := strconv.Atoi(m.Metadata["importance"])
+		result = append(result, context.MemoryEntry{
+			ID:         m.Metadata["memory_id"],
+			Category:   m.Metadata["category"],
+			Content:    m.Text,
+			Timestamp:  ts,
+			Importance: importance,
+			Embedding:  m.Vector,
+		})
+	}
+	return result, nil
+}
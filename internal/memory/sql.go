@@ -0,0 +1,254 @@
+package memory
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The SQL stores take an open *sql.DB so the binary chooses (and registers) the driver.
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// vectorLiteral formats a vector as "[1,2,3]", the text form both pgvector and sqlite-vec accept.
+func vectorLiteral(v []float64) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(x, 'g', -1, 64)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func parseVector(s string) ([]float64, error) {
+	var v []float64
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("failed to parse vector: %w", err)
+	}
+	return v, nil
+}
+
+func encodeMetadata(m map[string]string) string {
+	if m == nil {
+		m = map[string]string{}
+	}
+	data, _ := json.Marshal(m)
+	return string(data)
+}
+
+func decodeMetadata(s string) map[string]string {
+	m := make(map[string]string)
+	json.Unmarshal([]byte(s), &m)
+	return m
+}
+
+// PGVector is a VectorStore in a PostgreSQL table using the pgvector extension.
+type PGVector struct {
+	DB    *sql.DB
+	Table string
+	dim   int
+}
+
+// NewPGVector returns a store in table, creating the extension and table when missing.
+func NewPGVector(db *sql.DB, table string, dim int) (*PGVector, error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	schema := []string{
+		`CREATE EXTENSION IF NOT EXISTS vector`,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, embedding vector(%d) NOT NULL, text TEXT NOT NULL DEFAULT '', metadata JSONB NOT NULL DEFAULT '{}')`, table, dim),
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to prepare pgvector table %s: %w", table, err)
+		}
+	}
+	return &PGVector{DB: db, Table: table, dim: dim}, nil
+}
+
+func (p *PGVector) Upsert(records ...Record) error {
+	tx, err := p.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	stmt := fmt.Sprintf(`INSERT INTO %s (id, embedding, text, metadata) VALUES ($1, $2::vector, $3, $4::jsonb)
+ON CONFLICT (id) DO UPDATE SET embedding = EXCLUDED.embedding, text = EXCLUDED.text, metadata = EXCLUDED.metadata`, p.Table)
+	for _, r := range records {
+		if len(r.Vector) != p.dim {
+			return ErrDimension
+		}
+		if _, err := tx.Exec(stmt, r.ID, vectorLiteral(r.Vector), r.Text, encodeMetadata(r.Metadata)); err != nil {
+			return fmt.Errorf("failed to upsert %s: %w", r.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (p *PGVector) Query(vector []float64, k int, filter Filter) ([]Match, error) {
+	if len(vector) != p.dim {
+		return nil, ErrDimension
+	}
+	rows, err := p.DB.Query(fmt.Sprintf(`SELECT id, embedding::text, text, metadata::text, 1 - (embedding <=> $1::vector)
+FROM %s WHERE metadata @> $2::jsonb ORDER BY embedding <=> $1::vector LIMIT $3`, p.Table),
+		vectorLiteral(vector), encodeMetadata(filter), k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", p.Table, err)
+	}
+	defer rows.Close()
+	var matches []Match
+	for rows.Next() {
+		var m Match
+		var vec, meta string
+		if err := rows.Scan(&m.ID, &vec, &m.Text, &meta, &m.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		if m.Vector, err = parseVector(vec); err != nil {
+			return nil, err
+		}
+		m.Metadata = decodeMetadata(meta)
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+func (p *PGVector) Delete(ids ...string) error {
+	for _, id := range ids {
+		if _, err := p.DB.Exec(fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, p.Table), id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (p *PGVector) DeleteWhere(filter Filter) error {
+	if _, err := p.DB.Exec(fmt.Sprintf(`DELETE FROM %s WHERE metadata @> $1::jsonb`, p.Table), encodeMetadata(filter)); err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", p.Table, err)
+	}
+	return nil
+}
+
+// SQLiteVec is a VectorStore in SQLite using the sqlite-vec extension, which must be loaded
+// by the driver. Vectors live in a vec0 virtual table; IDs, text and metadata in a companion table.
+type SQLiteVec struct {
+	DB    *sql.DB
+	Table string
+	dim   int
+}
+
+// NewSQLiteVec returns a store in table, creating its tables when missing.
+func NewSQLiteVec(db *sql.DB, table string, dim int) (*SQLiteVec, error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	schema := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s_items (rowid INTEGER PRIMARY KEY, id TEXT NOT NULL UNIQUE, text TEXT NOT NULL DEFAULT '', metadata TEXT NOT NULL DEFAULT '{}')`, table),
+		fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s USING vec0(embedding float[%d] distance_metric=cosine)`, table, dim),
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to prepare sqlite-vec table %s: %w", table, err)
+		}
+	}
+	return &SQLiteVec{DB: db, Table: table, dim: dim}, nil
+}
+
+func (s *SQLiteVec) Upsert(records ...Record) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for _, r := range records {
+		if len(r.Vector) != s.dim {
+			return ErrDimension
+		}
+		var rowid int64
+		err := tx.QueryRow(fmt.Sprintf(`INSERT INTO %s_items (id, text, metadata) VALUES (?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET text = excluded.text, metadata = excluded.metadata RETURNING rowid`, s.Table),
+			r.ID, r.Text, encodeMetadata(r.Metadata)).Scan(&rowid)
+		if err != nil {
+			return fmt.Errorf("failed to upsert %s: %w", r.ID, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE rowid = ?`, s.Table), rowid); err != nil {
+			return fmt.Errorf("failed to replace vector for %s: %w", r.ID, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (rowid, embedding) VALUES (?, ?)`, s.Table), rowid, vectorLiteral(r.Vector)); err != nil {
+			return fmt.Errorf("failed to insert vector for %s: %w", r.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Query filters on metadata after the KNN search, so it fetches extra candidates when a filter is set.
+func (s *SQLiteVec) Query(vector []float64, k int, filter Filter) ([]Match, error) {
+	if len(vector) != s.dim {
+		return nil, ErrDimension
+	}
+	limit := k
+	if len(filter) > 0 {
+		limit = k * 10
+	}
+	rows, err := s.DB.Query(fmt.Sprintf(`SELECT i.id, vec_to_json(v.embedding), i.text, i.metadata, v.distance
+FROM %[1]s v JOIN %[1]s_items i ON i.rowid = v.rowid
+WHERE v.embedding MATCH ? AND k = ? ORDER BY v.distance`, s.Table), vectorLiteral(vector), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", s.Table, err)
+	}
+	defer rows.Close()
+	var matches []Match
+	for rows.Next() {
+		var m Match
+		var vec, meta string
+		var distance float64
+		if err := rows.Scan(&m.ID, &vec, &m.Text, &meta, &distance); err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		m.Metadata = decodeMetadata(meta)
+		if !filter.Matches(m.Metadata) {
+			continue
+		}
+		if m.Vector, err = parseVector(vec); err != nil {
+			return nil, err
+		}
+		m.Score = 1 - distance
+		if matches = append(matches, m); len(matches) == k {
+			break
+		}
+	}
+	return matches, rows.Err()
+}
+
+func (s *SQLiteVec) Delete(ids ...string) error {
+	for _, id := range ids {
+		if _, err := s.DB.Exec(fmt.Sprintf(`DELETE FROM %[1]s WHERE rowid IN (SELECT rowid FROM %[1]s_items WHERE id = ?)`, s.Table), id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", id, err)
+		}
+		if _, err := s.DB.Exec(fmt.Sprintf(`DELETE FROM %s_items WHERE id = ?`, s.Table), id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteVec) DeleteWhere(filter Filter) error {
+	rows, err := s.DB.Query(fmt.Sprintf(`SELECT id, metadata FROM %s_items`, s.Table))
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", s.Table, err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id, meta string
+		if err := rows.Scan(&id, &meta); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan %s: %w", s.Table, err)
+		}
+		if filter.Matches(decodeMetadata(meta)) {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+	return s.Delete(ids...)
+}
@@ -0,0 +1,193 @@
+// Package memory holds what agents retain: embeddings for retrieval behind a VectorStore interface,
+// so the knowledge base and agent context can move to a persistent vector database, and a Journal
+// of long-term learnings that survives restarts.
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Record is one stored vector with its text and metadata.
+type Record struct {
+	ID       string            `json:"id"`
+	Vector   []float64         `json:"vector"`
+	Text     string            `json:"text,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Match is a query result; Score is the cosine similarity to the query, higher is closer.
+type Match struct {
+	Record
+	Score float64
+}
+
+// Filter restricts queries and deletions to records whose metadata has every key set to the given value.
+type Filter map[string]string
+
+// Matches reports whether metadata satisfies the filter.
+func (f Filter) Matches(metadata map[string]string) bool {
+	for k, v := range f {
+		if metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrDimension is returned when a vector does not have the store's dimension.
+var ErrDimension = errors.New("vector dimension mismatch")
+
+// VectorStore persists embeddings and answers nearest-neighbour queries.
+type VectorStore interface {
+	// Upsert inserts the records, replacing any with the same ID.
+	Upsert(records ...Record) error
+	// Query returns up to k records closest to vector that satisfy filter, best first.
+	Query(vector []float64, k int, filter Filter) ([]Match, error)
+	// Delete removes the records with the given IDs.
+	Delete(ids ...string) error
+	// DeleteWhere removes every record satisfying filter.
+	DeleteWhere(filter Filter) error
+}
+
+// InMemory is a brute-force VectorStore. With a path it is saved to a JSON file after every change
+// and reloaded by NewFileStore, so embeddings survive restarts.
+type InMemory struct {
+	mu      sync.RWMutex
+	records map[string]Record
+	path    string
+}
+
+// NewInMemory creates an empty store that lives only as long as the process.
+func NewInMemory() *InMemory {
+	return &InMemory{records: make(map[string]Record)}
+}
+
+// NewFileStore creates a store persisted to path, loading the records saved there earlier.
+func NewFileStore(path string) (*InMemory, error) {
+	s := NewInMemory()
+	s.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vector store %s: %w", path, err)
+	}
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse vector store %s: %w", path, err)
+	}
+	for _, r := range records {
+		s.records[r.ID] = r
+	}
+	return s, nil
+}
+
+// Len returns the number of stored records.
+func (s *InMemory) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+func (s *InMemory) Upsert(records ...Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		s.records[r.ID] = r
+	}
+	return s.save()
+}
+
+func (s *InMemory) Query(vector []float64, k int, filter Filter) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var matches []Match
+	for _, r := range s.records {
+		if !filter.Matches(r.Metadata) {
+			continue
+		}
+		if len(r.Vector) != len(vector) {
+			return nil, ErrDimension
+		}
+		matches = append(matches, Match{Record: r, Score: Cosine(vector, r.Vector)})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	if k >= 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches, nil
+}
+
+func (s *InMemory) Delete(ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.records, id)
+	}
+	return s.save()
+}
+
+func (s *InMemory) DeleteWhere(filter Filter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, r := range s.records {
+		if filter.Matches(r.Metadata) {
+			delete(s.records, id)
+		}
+	}
+	return s.save()
+}
+
+// save writes the records to the store's file; it is a no-op for purely in-memory stores.
+func (s *InMemory) save() error {
+	if s.path == "" {
+		return nil
+	}
+	records := make([]Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode vector store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create vector store directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write vector store: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 when either is zero or their lengths differ.
+func Cosine(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
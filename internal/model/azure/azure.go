@@ -0,0 +1,210 @@
+// Package azure provides the Azure OpenAI model provider.
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+// DefaultAPIVersion is the Azure OpenAI API version used when none is configured.
+const DefaultAPIVersion = "2025-04-01-preview"
+
+// Environment variables holding credentials when the configuration does not name others.
+const (
+	EnvAPIKey       = "AZURE_OPENAI_API_KEY"
+	EnvClientSecret = "AZURE_CLIENT_SECRET"
+)
+
+// Client is a ModelClient for Azure OpenAI. It speaks the same Responses API as ChatGPTClient, but
+// sends requests to the resource endpoint and addresses models by their deployment name.
+type Client struct {
+	*chatgpt.ChatGPTClient
+	// Deployments maps model names, as chosen by roles and routing, to deployment names.
+	// Models without an entry are sent as their own deployment name.
+	Deployments map[string]string
+}
+
+// NewClient creates a client for the Azure OpenAI resource at endpoint, e.g.
+// "https://contoso.openai.azure.com". authorize sets the credentials of each request; see KeyAuth
+// and ClientCredentials. An empty apiVersion uses DefaultAPIVersion.
+func NewClient(endpoint, apiVersion string, authorize func(*http.Request) error, deployments map[string]string) *Client {
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+	base := strings.TrimSuffix(endpoint, "/") + "/openai"
+	inner := chatgpt.NewChatGPTClient("", "", nil)
+	inner.Endpoint = func(path string) string {
+		return base + path + "?api-version=" + url.QueryEscape(apiVersion)
+	}
+	inner.Authorize = authorize
+	return &Client{ChatGPTClient: inner, Deployments: deployments}
+}
+
+// FromConfig creates the client configured under "azure", reading credentials from the environment.
+func FromConfig() (*Client, error) {
+	s := config.GetAzureSettings()
+	if s == nil || s.Endpoint == "" {
+		return nil, fmt.Errorf("azure endpoint is not configured")
+	}
+	var authorize func(*http.Request) error
+	if s.TenantID != "" && s.ClientID != "" {
+		secret := os.Getenv(envOr(s.ClientSecretEnv, EnvClientSecret))
+		if secret == "" {
+			return nil, fmt.Errorf("azure client secret is not set")
+		}
+		authorize = (&ClientCredentials{TenantID: s.TenantID, ClientID: s.ClientID, ClientSecret: secret}).Authorize
+	} else {
+		key := os.Getenv(envOr(s.APIKeyEnv, EnvAPIKey))
+		if key == "" {
+			return nil, fmt.Errorf("azure API key is not set")
+		}
+		authorize = KeyAuth(key)
+	}
+	return NewClient(s.Endpoint, s.APIVersion, authorize, s.Deployments), nil
+}
+
+func envOr(name, def string) string {
+	if name != "" {
+		return name
+	}
+	return def
+}
+
+// KeyAuth authorizes requests with an Azure OpenAI API key.
+func KeyAuth(key string) func(*http.Request) error {
+	return func(req *http.Request) error {
+		req.Header.Set("api-key", key)
+		return nil
+	}
+}
+
+// Deployment returns the deployment serving modelName.
+func (c *Client) Deployment(modelName string) string {
+	if d, ok := c.Deployments[modelName]; ok {
+		return d
+	}
+	return modelName
+}
+
+// Chat sends a prompt and returns the response as a string.
+func (c *Client) Chat(prompt string) (string, error) {
+	return c.ChatAdvanced(model.ChatRequest{
+		Input:       []model.Message{{Role: "user", Content: prompt}},
+		Temperature: c.Temperature,
+	})
+}
+
+// ChatAdvanced sends request to the deployment of its model, or of the client's model when unset.
+func (c *Client) ChatAdvanced(request model.ChatRequest) (string, error) {
+	if request.Model == "" {
+		request.Model = c.Model
+	}
+	request.Model = c.Deployment(request.Model)
+	return c.ChatGPTClient.ChatAdvanced(request)
+}
+
+// ChatAdvancedParsed sends a ChatRequest and unmarshals the response into target.
+func (c *Client) ChatAdvancedParsed(request model.ChatRequest, target interface{}) error {
+	raw, err := c.ChatAdvanced(request)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(raw), target)
+}
+
+// ChatStructured sends prompt in JSON schema mode and unmarshals the validated answer into out.
+func (c *Client) ChatStructured(prompt string, schema interface{}, out interface{}) error {
+	request := model.ChatRequest{
+		Input:       []model.Message{{Role: "user", Content: prompt}},
+		Temperature: c.Temperature,
+		Text: &model.TextFormat{
+			Format: model.FormatOptions{Type: "json_schema", Name: "output_schema", Schema: schema, Strict: true},
+		},
+	}
+	return model.ChatStructured(c, request, out)
+}
+
+// ForRole returns a copy of the client using the model settings configured for role.
+func (c *Client) ForRole(role string) model.ModelClient {
+	return &Client{ChatGPTClient: c.ChatGPTClient.ForRole(role).(*chatgpt.ChatGPTClient), Deployments: c.Deployments}
+}
+
+// ClientCredentials authorizes requests with Azure AD (Entra ID) tokens obtained through the
+// client credentials flow. Tokens are cached until shortly before they expire.
+type ClientCredentials struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	Authority    string       // Optional; defaults to "https://login.microsoftonline.com".
+	HTTPClient   *http.Client // Optional.
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// Authorize sets a bearer token on req.
+func (c *ClientCredentials) Authorize(req *http.Request) error {
+	token, err := c.Token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token for Azure Cognitive Services.
+func (c *ClientCredentials) Token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.expires) {
+		return c.token, nil
+	}
+	authority := c.Authority
+	if authority == "" {
+		authority = "https://login.microsoftonline.com"
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"scope":         {"https://cognitiveservices.azure.com/.default"},
+	}
+	resp, err := client.PostForm(strings.TrimSuffix(authority, "/")+"/"+url.PathEscape(c.TenantID)+"/oauth2/v2.0/token", form)
+	if err != nil {
+		return "", fmt.Errorf("failed to request azure token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read azure token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request azure token: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil || tok.AccessToken == "" {
+		return "", fmt.Errorf("failed to decode azure token response: %v", err)
+	}
+	// Refresh a minute early so a request never starts with a token about to expire.
+	c.token, c.expires = tok.AccessToken, time.Now().Add(time.Duration(tok.ExpiresIn)*time.Second-time.Minute)
+	return c.token, nil
+}
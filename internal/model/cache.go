@@ -0,0 +1,164 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// DefaultCacheTTL is used when a cache is configured without a TTL.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Cache stores model responses by request key. Implementations may be backed by disk, Redis or memory.
+type Cache interface {
+	// Get returns the cached response for key, if present and not expired.
+	Get(key string) (string, bool)
+	// Set stores a response for key for the given time to live.
+	Set(key, value string, ttl time.Duration) error
+}
+
+// CacheKey returns the content address of a request: a SHA-256 over the request as sent plus its class.
+func CacheKey(req ChatRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request for cache key: %w", err)
+	}
+	sum := sha256.Sum256(append(data, []byte(req.Class)...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// diskEntry is the file format of DiskCache.
+type diskEntry struct {
+	Expires time.Time `json:"expires"`
+	Value   string    `json:"value"`
+}
+
+// DiskCache stores one JSON file per response in Dir.
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache creates a DiskCache, creating dir if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{Dir: dir}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+func (c *DiskCache) Get(key string) (string, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+	var e diskEntry
+	if err := json.Unmarshal(data, &e); err != nil || time.Now().After(e.Expires) {
+		os.Remove(c.path(key))
+		return "", false
+	}
+	return e.Value, true
+}
+
+func (c *DiskCache) Set(key, value string, ttl time.Duration) error {
+	data, err := json.Marshal(diskEntry{Expires: time.Now().Add(ttl), Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	// Write to a temporary file first so concurrent readers never see a partial entry.
+	tmp := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp, c.path(key)); err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+// CachedClient is a ModelClient that answers repeated identical requests from a cache.
+// Requests with NoCache set always go to the model and are not stored.
+type CachedClient struct {
+	ModelClient
+	Cache Cache
+	TTL   time.Duration // Zero uses DefaultCacheTTL.
+}
+
+// NewCachedClient wraps client with cache.
+func NewCachedClient(client ModelClient, cache Cache, ttl time.Duration) *CachedClient {
+	return &CachedClient{ModelClient: client, Cache: cache, TTL: ttl}
+}
+
+// CacheFromConfig wraps client with the disk cache configured under modelCache.
+// Without a configured cache directory the client is returned unchanged.
+func CacheFromConfig(client ModelClient) (ModelClient, error) {
+	settings := config.GetModelCache()
+	if settings == nil || settings.Dir == "" {
+		return client, nil
+	}
+	cache, err := NewDiskCache(settings.Dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewCachedClient(client, cache, settings.TTL), nil
+}
+
+func (c *CachedClient) ChatAdvanced(req ChatRequest) (string, error) {
+	if req.NoCache {
+		return c.ModelClient.ChatAdvanced(req)
+	}
+	key, err := CacheKey(req)
+	if err != nil {
+		return c.ModelClient.ChatAdvanced(req)
+	}
+	if cached, ok := c.Cache.Get(key); ok {
+		if req.Usage != nil {
+			*req.Usage = Usage{Model: req.Model, Cached: true}
+		}
+		return cached, nil
+	}
+	resp, err := c.ModelClient.ChatAdvanced(req)
+	if err != nil {
+		return "", err
+	}
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	if err := c.Cache.Set(key, resp, ttl); err != nil {
+		fmt.Printf("Warning: failed to cache model response: %v\n", err)
+	}
+	return resp, nil
+}
+
+func (c *CachedClient) ChatAdvancedParsed(req ChatRequest, target interface{}) error {
+	if req.NoCache {
+		return c.ModelClient.ChatAdvancedParsed(req, target)
+	}
+	raw, err := c.ChatAdvanced(req)
+	if err != nil {
+		return err
+	}
+	if raw == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(raw), target)
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps the cache.
+func (c *CachedClient) ForRole(role string) ModelClient {
+	scoped, ok := c.ModelClient.(RoleScoped)
+	if !ok {
+		return c
+	}
+	return &CachedClient{ModelClient: scoped.ForRole(role), Cache: c.Cache, TTL: c.TTL}
+}
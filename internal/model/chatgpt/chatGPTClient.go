@@ -11,8 +11,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/config"
 	"github.com/egobogo/aiagents/internal/model"
 	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
 )
@@ -22,7 +24,40 @@ type ChatGPTClient struct {
 	APIKey        string
 	Model         string
 	Temperature   float64
+	TopP          *float64              // optional nucleus sampling
+	MaxTokens     int                   // optional cap on output tokens
+	Reasoning     string                // optional reasoning effort for reasoning models
 	VectorStorage *vectorstorage.Client // optional vector storage client
+	HTTPClient    *http.Client          // optional; e.g. a recording or replaying transport
+	// Endpoint optionally builds API URLs from paths such as "/responses", e.g. for Azure OpenAI.
+	Endpoint func(path string) string
+	// Authorize optionally sets request credentials in place of the bearer API key.
+	Authorize func(req *http.Request) error
+}
+
+// httpClient returns the configured HTTP client or a default one.
+func (c *ChatGPTClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{}
+}
+
+// url returns the API URL for path.
+func (c *ChatGPTClient) url(path string) string {
+	if c.Endpoint != nil {
+		return c.Endpoint(path)
+	}
+	return "https://api.openai.com/v1" + path
+}
+
+// authorize sets the credentials of req.
+func (c *ChatGPTClient) authorize(req *http.Request) error {
+	if c.Authorize != nil {
+		return c.Authorize(req)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	return nil
 }
 
 // NewChatGPTClient creates a new ChatGPTClient.
@@ -38,6 +73,45 @@ func NewChatGPTClient(apiKey, model string, vsClient *vectorstorage.Client) *Cha
 	}
 }
 
+// ForRole returns a copy of the client using the model settings configured for role.
+// Settings the role leaves unset keep the client's values.
+func (c *ChatGPTClient) ForRole(role string) model.ModelClient {
+	scoped := *c
+	settings := config.GetRoleModelSettings(role)
+	if settings.Model != "" {
+		scoped.Model = settings.Model
+	}
+	if settings.Temperature != nil {
+		scoped.Temperature = *settings.Temperature
+	}
+	if settings.TopP != nil {
+		scoped.TopP = settings.TopP
+	}
+	if settings.MaxTokens > 0 {
+		scoped.MaxTokens = settings.MaxTokens
+	}
+	if settings.ReasoningEffort != "" {
+		scoped.Reasoning = settings.ReasoningEffort
+	}
+	return &scoped
+}
+
+// applyDefaults fills request parameters the caller left unset from the client settings.
+func (c *ChatGPTClient) applyDefaults(request *model.ChatRequest) {
+	if request.Model == "" {
+		request.Model = c.Model
+	}
+	if request.TopP == nil {
+		request.TopP = c.TopP
+	}
+	if request.MaxTokens == 0 {
+		request.MaxTokens = c.MaxTokens
+	}
+	if request.Reasoning == nil && c.Reasoning != "" {
+		request.Reasoning = &model.Reasoning{Effort: c.Reasoning}
+	}
+}
+
 // PollUploadedFile polls the file endpoint until the file is available.
 func (c *ChatGPTClient) pollUploadedFile(fileID string) (model.File, error) {
 	timeout := time.Now().Add(60 * time.Second)
@@ -83,24 +157,27 @@ func (c *ChatGPTClient) Chat(prompt string) (string, error) {
 }
 
 func (c *ChatGPTClient) ChatAdvanced(request model.ChatRequest) (string, error) {
+	c.applyDefaults(&request)
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal ChatRequest: %w", err)
 	}
 
-	url := "https://api.openai.com/v1/responses"
+	url := c.url("/responses")
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return "", fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	if err := c.authorize(req); err != nil {
+		return "", fmt.Errorf("failed to authorize request: %w", err)
+	}
 
 	writeDebugLog(fmt.Sprintf("API Request:\ncurl %s \\\n  -H \"Content-Type: application/json\" \\\n  -H \"Authorization: Bearer %s\" \\\n  -d '%s'",
 		url, c.APIKey, string(bodyBytes)))
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to send HTTP request: %w", err)
@@ -111,6 +188,13 @@ func (c *ChatGPTClient) ChatAdvanced(request model.ChatRequest) (string, error)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
+	if model.IsRateLimitStatus(resp.StatusCode) {
+		return "", &model.RateLimitError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: model.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Message:    strings.TrimSpace(string(respBytes)),
+		}
+	}
 
 	// Pretty-print the raw JSON response for debugging.
 	var prettyJSON bytes.Buffer
@@ -122,17 +206,28 @@ func (c *ChatGPTClient) ChatAdvanced(request model.ChatRequest) (string, error)
 
 	// Define a temporary structure that includes the "type" field for each output.
 	var respData struct {
+		Model  string `json:"model"`
 		Output []struct {
 			Type    string `json:"type"`
 			Content []struct {
 				Text string `json:"text"`
 			} `json:"content"`
 		} `json:"output"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
 	}
 
 	if err := json.Unmarshal(respBytes, &respData); err != nil {
 		return "", fmt.Errorf("failed to decode response: %w", err)
 	}
+	if request.Usage != nil {
+		*request.Usage = model.Usage{Model: request.Model, PromptTokens: respData.Usage.InputTokens, CompletionTokens: respData.Usage.OutputTokens}
+		if respData.Model != "" {
+			request.Usage.Model = respData.Model
+		}
+	}
 
 	// Iterate over the output blocks and return the text from the first block of type "message".
 	for _, out := range respData.Output {
@@ -153,6 +248,25 @@ func (c *ChatGPTClient) ChatAdvancedParsed(request model.ChatRequest, target int
 	return json.Unmarshal([]byte(raw), target)
 }
 
+// ChatStructured sends prompt in JSON schema mode, validates the answer against schema and unmarshals it
+// into out, asking the model to repair invalid answers.
+func (c *ChatGPTClient) ChatStructured(prompt string, schema interface{}, out interface{}) error {
+	request := model.ChatRequest{
+		Model:       c.Model,
+		Input:       []model.Message{{Role: "user", Content: prompt}},
+		Temperature: c.Temperature,
+		Text: &model.TextFormat{
+			Format: model.FormatOptions{
+				Type:   "json_schema",
+				Name:   "output_schema",
+				Schema: schema,
+				Strict: true,
+			},
+		},
+	}
+	return model.ChatStructured(c, request, out)
+}
+
 // SetModel sets the model.
 func (c *ChatGPTClient) SetModel(model string) {
 	c.Model = model
@@ -195,16 +309,17 @@ func (c *ChatGPTClient) UploadFile(filePath string, purpose string) (model.File,
 	}
 	writer.Close()
 
-	url := "https://api.openai.com/v1/files"
+	url := c.url("/files")
 	req, err := http.NewRequest("POST", url, body)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	if err := c.authorize(req); err != nil {
+		return model.File{}, fmt.Errorf("failed to authorize request: %w", err)
+	}
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -228,14 +343,16 @@ func (c *ChatGPTClient) UploadFile(filePath string, purpose string) (model.File,
 
 // GetFile retrieves metadata for a file given its ID.
 func (c *ChatGPTClient) GetFile(fileID string) (model.File, error) {
-	url := fmt.Sprintf("https://api.openai.com/v1/files/%s", fileID)
+	url := c.url("/files/" + fileID)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to create GET request: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	if err := c.authorize(req); err != nil {
+		return model.File{}, fmt.Errorf("failed to authorize request: %w", err)
+	}
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send GET request: %w", err)
@@ -256,14 +373,16 @@ func (c *ChatGPTClient) GetFile(fileID string) (model.File, error) {
 
 // DeleteAllFiles deletes all files uploaded via the files API. This is useful for cleanup during tests.
 func (c *ChatGPTClient) DeleteAllFiles() error {
-	url := "https://api.openai.com/v1/files"
+	url := c.url("/files")
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create list files request: %w", err)
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	if err := c.authorize(req); err != nil {
+		return fmt.Errorf("failed to authorize request: %w", err)
+	}
 
-	client := &http.Client{}
+	client := c.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to list files: %w", err)
@@ -282,12 +401,14 @@ func (c *ChatGPTClient) DeleteAllFiles() error {
 	}
 
 	for _, file := range listResponse.Data {
-		delURL := fmt.Sprintf("https://api.openai.com/v1/files/%s", file.ID)
+		delURL := c.url("/files/" + file.ID)
 		delReq, err := http.NewRequest("DELETE", delURL, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create delete request for file %s: %w", file.ID, err)
 		}
-		delReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+		if err := c.authorize(delReq); err != nil {
+			return fmt.Errorf("failed to authorize request: %w", err)
+		}
 		delResp, err := client.Do(delReq)
 		if err != nil {
 			return fmt.Errorf("failed to delete file %s: %w", file.ID, err)
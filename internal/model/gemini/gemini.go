@@ -0,0 +1,375 @@
+// Package gemini provides the Google Gemini model provider.
+package gemini
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// Defaults for Client.
+const (
+	DefaultModel    = "gemini-2.5-flash"
+	DefaultEndpoint = "https://generativelanguage.googleapis.com/v1beta"
+	EnvAPIKey       = "GEMINI_API_KEY"
+)
+
+// ErrNoFiles is returned by the file operations, which Gemini does not offer through this client.
+var ErrNoFiles = errors.New("gemini client does not support files")
+
+// Client implements the ModelClient interface using the Gemini generateContent API. System and
+// developer messages become the system instruction and assistant turns become "model" turns.
+type Client struct {
+	APIKey      string
+	Model       string
+	Temperature float64
+	TopP        *float64 // optional nucleus sampling
+	MaxTokens   int      // optional cap on output tokens
+	// Models maps model names chosen by roles and routing to Gemini models. Other names that are
+	// not Gemini models, such as "gpt-4o", use Model.
+	Models map[string]string
+	// SafetySettings maps harm categories to block thresholds sent with every request.
+	SafetySettings map[string]string
+	Endpoint       string       // optional; defaults to DefaultEndpoint
+	HTTPClient     *http.Client // optional; e.g. a recording or replaying transport
+}
+
+// NewClient creates a client using model, or DefaultModel when empty.
+func NewClient(apiKey, modelName string) *Client {
+	if modelName == "" {
+		modelName = DefaultModel
+	}
+	return &Client{APIKey: apiKey, Model: modelName, Temperature: 0.7}
+}
+
+// FromConfig creates the client configured under "gemini", reading the API key from the environment.
+func FromConfig() (*Client, error) {
+	s := config.GetGeminiSettings()
+	if s == nil {
+		s = &config.GeminiSettings{}
+	}
+	env := s.APIKeyEnv
+	if env == "" {
+		env = EnvAPIKey
+	}
+	key := os.Getenv(env)
+	if key == "" {
+		return nil, fmt.Errorf("gemini API key is not set in %s", env)
+	}
+	c := NewClient(key, s.Model)
+	c.Models, c.SafetySettings = s.Models, s.SafetySettings
+	return c, nil
+}
+
+// ForRole returns a copy of the client using the model settings configured for role.
+// Settings the role leaves unset keep the client's values.
+func (c *Client) ForRole(role string) model.ModelClient {
+	scoped := *c
+	settings := config.GetRoleModelSettings(role)
+	if settings.Model != "" {
+		scoped.Model = c.modelFor(settings.Model)
+	}
+	if settings.Temperature != nil {
+		scoped.Temperature = *settings.Temperature
+	}
+	if settings.TopP != nil {
+		scoped.TopP = settings.TopP
+	}
+	if settings.MaxTokens > 0 {
+		scoped.MaxTokens = settings.MaxTokens
+	}
+	return &scoped
+}
+
+// modelFor returns the Gemini model serving name.
+func (c *Client) modelFor(name string) string {
+	if m, ok := c.Models[name]; ok {
+		return m
+	}
+	if strings.HasPrefix(name, "gemini") {
+		return name
+	}
+	return c.Model
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{}
+}
+
+type part struct {
+	Text       string    `json:"text,omitempty"`
+	InlineData *blobData `json:"inline_data,omitempty"`
+	FileData   *fileData `json:"file_data,omitempty"`
+}
+
+type blobData struct {
+	MimeType string `json:"mime_type"`
+	Data     string `json:"data"`
+}
+
+type fileData struct {
+	MimeType string `json:"mime_type,omitempty"`
+	FileURI  string `json:"file_uri"`
+}
+
+// userParts maps the content of a user message onto parts. Data URL images are sent inline and
+// other image URLs as file data.
+func userParts(m model.Message) []part {
+	if _, ok := m.Content.([]model.ContentPart); !ok {
+		return []part{{Text: model.MessageText(m)}}
+	}
+	var parts []part
+	for _, p := range model.MessageParts(m) {
+		if p.Type != model.PartImage {
+			parts = append(parts, part{Text: p.Text})
+			continue
+		}
+		if mimeType, data, ok := model.ParseDataURL(p.ImageURL); ok {
+			parts = append(parts, part{InlineData: &blobData{MimeType: mimeType, Data: data}})
+		} else {
+			parts = append(parts, part{FileData: &fileData{MimeType: model.ImageType(p.ImageURL), FileURI: p.ImageURL}})
+		}
+	}
+	return parts
+}
+
+type content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []part `json:"parts"`
+}
+
+type safetySetting struct {
+	Category  string `json:"category"`
+	Threshold string `json:"threshold"`
+}
+
+type generationConfig struct {
+	Temperature        *float64    `json:"temperature,omitempty"`
+	TopP               *float64    `json:"topP,omitempty"`
+	MaxOutputTokens    int         `json:"maxOutputTokens,omitempty"`
+	ResponseMimeType   string      `json:"responseMimeType,omitempty"`
+	ResponseJSONSchema interface{} `json:"responseJsonSchema,omitempty"`
+}
+
+type generateRequest struct {
+	SystemInstruction *content                 `json:"systemInstruction,omitempty"`
+	Contents          []content                `json:"contents"`
+	GenerationConfig  generationConfig         `json:"generationConfig"`
+	SafetySettings    []safetySetting          `json:"safetySettings,omitempty"`
+	Tools             []map[string]interface{} `json:"tools,omitempty"`
+}
+
+// buildRequest maps a ChatRequest onto a generateContent request.
+func (c *Client) buildRequest(request model.ChatRequest) (generateRequest, error) {
+	var g generateRequest
+	var system []part
+	for _, m := range request.Input {
+		text := model.MessageText(m)
+		switch m.Role {
+		case "system", "developer":
+			system = append(system, part{Text: text})
+		case "assistant":
+			g.Contents = append(g.Contents, content{Role: "model", Parts: []part{{Text: text}}})
+		default:
+			g.Contents = append(g.Contents, content{Role: "user", Parts: userParts(m)})
+		}
+	}
+	if len(system) > 0 {
+		g.SystemInstruction = &content{Parts: system}
+	}
+
+	temperature := request.Temperature
+	if temperature == 0 {
+		temperature = c.Temperature
+	}
+	g.GenerationConfig.Temperature = &temperature
+	g.GenerationConfig.TopP = request.TopP
+	if g.GenerationConfig.TopP == nil {
+		g.GenerationConfig.TopP = c.TopP
+	}
+	g.GenerationConfig.MaxOutputTokens = request.MaxTokens
+	if g.GenerationConfig.MaxOutputTokens == 0 {
+		g.GenerationConfig.MaxOutputTokens = c.MaxTokens
+	}
+	if request.Text != nil && request.Text.Format.Type == "json_schema" {
+		g.GenerationConfig.ResponseMimeType = "application/json"
+		g.GenerationConfig.ResponseJSONSchema = request.Text.Format.Schema
+	}
+
+	categories := make([]string, 0, len(c.SafetySettings))
+	for category := range c.SafetySettings {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		g.SafetySettings = append(g.SafetySettings, safetySetting{Category: category, Threshold: c.SafetySettings[category]})
+	}
+
+	for _, tool := range request.Tools {
+		switch tool.(type) {
+		case model.WebSearch, *model.WebSearch:
+			g.Tools = append(g.Tools, map[string]interface{}{"google_search": map[string]interface{}{}})
+		default:
+			return g, fmt.Errorf("gemini does not support tool %T", tool)
+		}
+	}
+	return g, nil
+}
+
+// Chat sends a prompt and returns the response as a string.
+func (c *Client) Chat(prompt string) (string, error) {
+	return c.ChatAdvanced(model.ChatRequest{Input: []model.Message{{Role: "user", Content: prompt}}})
+}
+
+// ChatAdvanced sends request to the Gemini model serving its model, or to Model when unset.
+func (c *Client) ChatAdvanced(request model.ChatRequest) (string, error) {
+	modelName := c.Model
+	if request.Model != "" {
+		modelName = c.modelFor(request.Model)
+	}
+	g, err := c.buildRequest(request)
+	if err != nil {
+		return "", err
+	}
+	bodyBytes, err := json.Marshal(g)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal generate request: %w", err)
+	}
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	req, err := http.NewRequest("POST", strings.TrimSuffix(endpoint, "/")+"/models/"+modelName+":generateContent", bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.APIKey)
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if model.IsRateLimitStatus(resp.StatusCode) {
+		return "", &model.RateLimitError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: model.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Message:    strings.TrimSpace(string(respBytes)),
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gemini returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBytes)))
+	}
+
+	var respData struct {
+		Candidates []struct {
+			Content      content `json:"content"`
+			FinishReason string  `json:"finishReason"`
+		} `json:"candidates"`
+		PromptFeedback struct {
+			BlockReason string `json:"blockReason"`
+		} `json:"promptFeedback"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+		ModelVersion string `json:"modelVersion"`
+	}
+	if err := json.Unmarshal(respBytes, &respData); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if request.Usage != nil {
+		*request.Usage = model.Usage{Model: modelName, PromptTokens: respData.UsageMetadata.PromptTokenCount, CompletionTokens: respData.UsageMetadata.CandidatesTokenCount}
+		if respData.ModelVersion != "" {
+			request.Usage.Model = respData.ModelVersion
+		}
+	}
+	if reason := respData.PromptFeedback.BlockReason; reason != "" {
+		return "", fmt.Errorf("gemini blocked the prompt: %s", reason)
+	}
+	if len(respData.Candidates) == 0 {
+		return "", fmt.Errorf("no candidates returned in response")
+	}
+	candidate := respData.Candidates[0]
+	var text strings.Builder
+	for _, p := range candidate.Content.Parts {
+		text.WriteString(p.Text)
+	}
+	if text.Len() == 0 && candidate.FinishReason != "" && candidate.FinishReason != "STOP" {
+		return "", fmt.Errorf("gemini returned no text: finish reason %s", candidate.FinishReason)
+	}
+	return text.String(), nil
+}
+
+// ChatAdvancedParsed sends a ChatRequest and unmarshals the response into target.
+func (c *Client) ChatAdvancedParsed(request model.ChatRequest, target interface{}) error {
+	raw, err := c.ChatAdvanced(request)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(raw), target)
+}
+
+// ChatStructured sends prompt in JSON schema mode and unmarshals the validated answer into out.
+func (c *Client) ChatStructured(prompt string, schema interface{}, out interface{}) error {
+	request := model.ChatRequest{
+		Input: []model.Message{{Role: "user", Content: prompt}},
+		Text: &model.TextFormat{
+			Format: model.FormatOptions{Type: "json_schema", Name: "output_schema", Schema: schema, Strict: true},
+		},
+	}
+	return model.ChatStructured(c, request, out)
+}
+
+// SetModel sets the model.
+func (c *Client) SetModel(modelName string) {
+	c.Model = modelName
+}
+
+// SetTemperature sets the temperature.
+func (c *Client) SetTemperature(temp float64) {
+	c.Temperature = temp
+}
+
+// GetModel returns the model.
+func (c *Client) GetModel() string {
+	return c.Model
+}
+
+// GetTemperature returns the temperature.
+func (c *Client) GetTemperature() float64 {
+	return c.Temperature
+}
+
+// UploadFile is not supported.
+func (c *Client) UploadFile(filePath string, purpose string) (model.File, error) {
+	return model.File{}, ErrNoFiles
+}
+
+// GetFile is not supported.
+func (c *Client) GetFile(fileID string) (model.File, error) {
+	return model.File{}, ErrNoFiles
+}
+
+// DeleteAllFiles does nothing, as no files are ever uploaded.
+func (c *Client) DeleteAllFiles() error {
+	return nil
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"encoding/base64"
+	"path"
+	"strings"
+)
+
+// Content part types of multimodal messages.
+const (
+	PartText  = "input_text"
+	PartImage = "input_image"
+)
+
+// tokensPerImage estimates the prompt tokens of one image, a typical screenshot at high detail.
+const tokensPerImage = 765
+
+// ContentPart is one typed part of a message's content. A Message whose Content is a []ContentPart
+// can mix text and images, e.g. a question about a screenshot.
+type ContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"` // An http(s) URL or a data URL.
+	Detail   string `json:"detail,omitempty"`    // Optional; "low", "high" or "auto".
+}
+
+// TextPart returns a text content part.
+func TextPart(text string) ContentPart {
+	return ContentPart{Type: PartText, Text: text}
+}
+
+// ImageURLPart returns an image content part the provider fetches from url.
+func ImageURLPart(url string) ContentPart {
+	return ContentPart{Type: PartImage, ImageURL: url}
+}
+
+// ImageDataPart returns an image content part carrying data inline as a data URL.
+func ImageDataPart(mimeType string, data []byte) ContentPart {
+	return ContentPart{Type: PartImage, ImageURL: "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)}
+}
+
+// imageTypes maps image file extensions to the MIME types vision models accept.
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// ImageType returns the MIME type of an image file name or URL, or "" when it is not an image
+// vision models accept.
+func ImageType(name string) string {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	return imageTypes[strings.ToLower(path.Ext(name))]
+}
+
+// ParseDataURL splits a base64 data URL into its MIME type and data. ok is false for other URLs.
+func ParseDataURL(url string) (mimeType string, data string, ok bool) {
+	rest, found := strings.CutPrefix(url, "data:")
+	if !found {
+		return "", "", false
+	}
+	header, data, found := strings.Cut(rest, ",")
+	if !found {
+		return "", "", false
+	}
+	mimeType, found = strings.CutSuffix(header, ";base64")
+	return mimeType, data, found
+}
+
+// MessageParts returns the content of m as typed parts. Plain text becomes a single text part.
+func MessageParts(m Message) []ContentPart {
+	switch c := m.Content.(type) {
+	case []ContentPart:
+		return c
+	case []map[string]string:
+		parts := make([]ContentPart, 0, len(c))
+		for _, p := range c {
+			parts = append(parts, ContentPart{Type: p["type"], Text: p["text"], ImageURL: p["image_url"], Detail: p["detail"]})
+		}
+		return parts
+	default:
+		return []ContentPart{TextPart(MessageText(m))}
+	}
+}
+
+// AttachImages appends images to the last user message of req, turning its content into parts.
+func AttachImages(req *ChatRequest, images ...ContentPart) {
+	if len(images) == 0 {
+		return
+	}
+	for i := len(req.Input) - 1; i >= 0; i-- {
+		if req.Input[i].Role == "user" {
+			parts := MessageParts(req.Input[i])
+			req.Input[i].Content = append(append([]ContentPart(nil), parts...), images...)
+			return
+		}
+	}
+	req.Input = append(req.Input, Message{Role: "user", Content: images})
+}
+
+// countImages returns the number of image parts in m.
+func countImages(m Message) int {
+	parts, ok := m.Content.([]ContentPart)
+	if !ok {
+		return 0
+	}
+	n := 0
+	for _, p := range parts {
+		if p.Type == PartImage {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Priority orders requests waiting for a free slot of a Limiter.
+type Priority int
+
+const (
+	PriorityBackground  Priority = iota // Context refreshes, memories and other work nobody waits on.
+	PriorityInteractive                 // Everything else: tickets, answers, reviews.
+)
+
+// RequestPriority returns the priority of a request: summarization work runs in the background.
+func RequestPriority(req ChatRequest) Priority {
+	if req.Class == ClassSummarization {
+		return PriorityBackground
+	}
+	return PriorityInteractive
+}
+
+// LimiterStats is a snapshot of a Limiter's queue.
+type LimiterStats struct {
+	Max         int           `json:"max"`
+	InFlight    int           `json:"inFlight"`
+	Interactive int           `json:"interactive"` // Interactive requests waiting for a slot.
+	Background  int           `json:"background"`  // Background requests waiting for a slot.
+	Requests    int           `json:"requests"`    // Requests admitted so far.
+	Waited      time.Duration `json:"waited"`      // Total time admitted requests spent queued.
+}
+
+// Limiter caps the model requests in flight across every client sharing it, so several agents do
+// not trip the provider's rate limits together. Waiting interactive requests are admitted before
+// waiting background ones; within a priority, requests are admitted in arrival order.
+type Limiter struct {
+	mu       sync.Mutex
+	max      int
+	inFlight int
+	queues   [2][]chan struct{} // Indexed by Priority.
+	requests int
+	waited   time.Duration
+}
+
+// NewLimiter creates a Limiter allowing max requests in flight; max below one allows one.
+func NewLimiter(max int) *Limiter {
+	if max < 1 {
+		max = 1
+	}
+	return &Limiter{max: max}
+}
+
+// LimiterFromConfig creates the Limiter configured by modelConcurrency, or returns nil when
+// concurrency is not limited.
+func LimiterFromConfig() *Limiter {
+	if n := config.GetModelConcurrency(); n > 0 {
+		return NewLimiter(n)
+	}
+	return nil
+}
+
+// Acquire blocks until a slot is free and returns the function releasing it.
+func (l *Limiter) Acquire(p Priority) (release func()) {
+	start := time.Now()
+	l.mu.Lock()
+	if l.inFlight < l.max && len(l.queues[PriorityInteractive])+len(l.queues[PriorityBackground]) == 0 {
+		l.inFlight++
+		l.requests++
+		l.mu.Unlock()
+		return l.release
+	}
+	ready := make(chan struct{})
+	l.queues[p] = append(l.queues[p], ready)
+	l.mu.Unlock()
+
+	<-ready // The releasing request hands its slot over.
+	l.mu.Lock()
+	l.requests++
+	l.waited += time.Since(start)
+	l.mu.Unlock()
+	return l.release
+}
+
+// release hands the slot to the next waiting request or frees it.
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, p := range []Priority{PriorityInteractive, PriorityBackground} {
+		if q := l.queues[p]; len(q) > 0 {
+			l.queues[p] = q[1:]
+			close(q[0])
+			return
+		}
+	}
+	l.inFlight--
+}
+
+// Stats returns the current queue depth and totals.
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return LimiterStats{
+		Max:         l.max,
+		InFlight:    l.inFlight,
+		Interactive: len(l.queues[PriorityInteractive]),
+		Background:  len(l.queues[PriorityBackground]),
+		Requests:    l.requests,
+		Waited:      l.waited,
+	}
+}
+
+// LimitedClient is a ModelClient whose requests take a slot of a shared Limiter.
+type LimitedClient struct {
+	ModelClient
+	Limiter *Limiter
+}
+
+// NewLimitedClient wraps client so its requests share limiter's slots.
+func NewLimitedClient(client ModelClient, limiter *Limiter) *LimitedClient {
+	return &LimitedClient{ModelClient: client, Limiter: limiter}
+}
+
+func (c *LimitedClient) Chat(prompt string) (string, error) {
+	defer c.Limiter.Acquire(PriorityInteractive)()
+	return c.ModelClient.Chat(prompt)
+}
+
+func (c *LimitedClient) ChatAdvanced(req ChatRequest) (string, error) {
+	defer c.Limiter.Acquire(RequestPriority(req))()
+	return c.ModelClient.ChatAdvanced(req)
+}
+
+func (c *LimitedClient) ChatAdvancedParsed(req ChatRequest, target interface{}) error {
+	defer c.Limiter.Acquire(RequestPriority(req))()
+	return c.ModelClient.ChatAdvancedParsed(req, target)
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps the shared limiter.
+func (c *LimitedClient) ForRole(role string) ModelClient {
+	scoped, ok := c.ModelClient.(RoleScoped)
+	if !ok {
+		return c
+	}
+	return &LimitedClient{ModelClient: scoped.ForRole(role), Limiter: c.Limiter}
+}
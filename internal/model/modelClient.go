@@ -76,8 +76,27 @@ type ChatRequest struct {
 	Model       string        `json:"model"`
 	Input       []Message     `json:"input"`
 	Temperature float64       `json:"temperature,omitempty"`
+	TopP        *float64      `json:"top_p,omitempty"`
+	MaxTokens   int           `json:"max_output_tokens,omitempty"`
+	Reasoning   *Reasoning    `json:"reasoning,omitempty"`
 	Text        *TextFormat   `json:"text,omitempty"`
 	Tools       []interface{} `json:"tools,omitempty"`
+	Class       RequestClass  `json:"-"` // Used by Router to pick a model; not sent to the API.
+	NoCache     bool          `json:"-"` // Bypasses CachedClient for non-deterministic calls.
+	Usage       *Usage        `json:"-"` // Optional; filled by clients that report what the call consumed.
+}
+
+// Usage is what a single model call consumed, as reported by the provider.
+type Usage struct {
+	Model            string // Model that answered, after routing and defaults.
+	PromptTokens     int
+	CompletionTokens int
+	Cached           bool // Answered from a cache without calling the provider.
+}
+
+// Reasoning configures reasoning models.
+type Reasoning struct {
+	Effort string `json:"effort,omitempty"` // "low", "medium" or "high".
 }
 
 // ModelClient is an abstract, model-agnostic interface for interacting with a language model.
@@ -93,3 +112,9 @@ type ModelClient interface {
 	GetFile(fileID string) (File, error)
 	DeleteAllFiles() error
 }
+
+// RoleScoped is implemented by model clients that can apply per-role settings.
+type RoleScoped interface {
+	// ForRole returns a client using the model settings configured for role.
+	ForRole(role string) ModelClient
+}
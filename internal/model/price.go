@@ -0,0 +1,48 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Price is what a model charges, in USD per million tokens.
+type Price struct {
+	Prompt     float64
+	Completion float64
+}
+
+// Cost returns the USD cost of the given token counts.
+func (p Price) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.Prompt + float64(completionTokens)*p.Completion) / 1e6
+}
+
+// DefaultPrices are the list prices of the models agents use, keyed by model name prefix.
+var DefaultPrices = map[string]Price{
+	"gpt-4o-mini":  {Prompt: 0.15, Completion: 0.60},
+	"gpt-4o":       {Prompt: 2.50, Completion: 10.00},
+	"gpt-4.1-nano": {Prompt: 0.10, Completion: 0.40},
+	"gpt-4.1-mini": {Prompt: 0.40, Completion: 1.60},
+	"gpt-4.1":      {Prompt: 2.00, Completion: 8.00},
+	"o3-mini":      {Prompt: 1.10, Completion: 4.40},
+	"o4-mini":      {Prompt: 1.10, Completion: 4.40},
+	"o3":           {Prompt: 2.00, Completion: 8.00},
+}
+
+// PriceFor returns the price of a model: the configured modelPrices entry or default price whose
+// name is the longest prefix of name, so dated versions such as "gpt-4o-2024-08-06" are priced too.
+func PriceFor(name string) (Price, bool) {
+	best, length := Price{}, -1
+	for prefix, p := range DefaultPrices {
+		if strings.HasPrefix(name, prefix) && len(prefix) > length {
+			best, length = p, len(prefix)
+		}
+	}
+	for prefix, p := range config.GetModelPrices() {
+		// Configured prices win over default ones for the same prefix.
+		if strings.HasPrefix(name, prefix) && len(prefix) >= length {
+			best, length = Price{Prompt: p.Prompt, Completion: p.Completion}, len(prefix)
+		}
+	}
+	return best, length >= 0
+}
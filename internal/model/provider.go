@@ -0,0 +1,23 @@
+package model
+
+import "github.com/egobogo/aiagents/internal/config"
+
+// ProviderClient lets roles use different model providers, e.g. Azure OpenAI for some roles and
+// OpenAI for others. The embedded client is the default provider; ForRole switches to the client
+// of the provider configured for the role.
+type ProviderClient struct {
+	ModelClient
+	Providers map[string]ModelClient // By provider name, e.g. "openai" or "azure".
+}
+
+// ForRole returns the client of the role's provider with the role's model settings applied.
+func (p *ProviderClient) ForRole(role string) ModelClient {
+	client := p.ModelClient
+	if c, ok := p.Providers[config.GetModelProvider(role)]; ok {
+		client = c
+	}
+	if scoped, ok := client.(RoleScoped); ok {
+		return scoped.ForRole(role)
+	}
+	return client
+}
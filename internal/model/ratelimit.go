@@ -0,0 +1,177 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for RetryingClient.
+const (
+	DefaultMaxRetries   = 5
+	DefaultInitialDelay = 2 * time.Second
+	DefaultMaxDelay     = 2 * time.Minute
+)
+
+// RateLimitError is returned by model clients when the provider rejects a request because of rate
+// limits or quota (HTTP 429) or is temporarily overloaded (HTTP 503).
+type RateLimitError struct {
+	StatusCode int
+	RetryAfter time.Duration // Optional; how long the provider asked to wait.
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("model provider returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// IsRateLimitStatus reports whether an HTTP status means the request should be retried later.
+func IsRateLimitStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+// ParseRetryAfter parses a Retry-After header value, either delay seconds or an HTTP date, relative
+// to now. It returns zero for empty, invalid or past values.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+var (
+	degradedMu     sync.Mutex
+	degradedUntil  time.Time
+	degradedReason string
+)
+
+// Degrade marks the model provider degraded until the given time, e.g. while a rate limit lasts.
+// A later time extends the state; an earlier one leaves it unchanged.
+func Degrade(until time.Time, reason string) {
+	degradedMu.Lock()
+	defer degradedMu.Unlock()
+	if until.After(degradedUntil) {
+		degradedUntil, degradedReason = until, reason
+	}
+}
+
+// Degraded reports whether the model provider is degraded, until when and why.
+func Degraded() (bool, time.Time, string) {
+	degradedMu.Lock()
+	defer degradedMu.Unlock()
+	if time.Now().Before(degradedUntil) {
+		return true, degradedUntil, degradedReason
+	}
+	return false, time.Time{}, ""
+}
+
+// RetryingClient is a ModelClient that retries requests rejected with a RateLimitError. It waits
+// as long as the provider's Retry-After asks, or backs off exponentially when it gives no delay,
+// and marks the provider degraded while waiting so schedulers can slow down.
+type RetryingClient struct {
+	ModelClient
+	MaxRetries   int                 // Zero uses DefaultMaxRetries.
+	InitialDelay time.Duration       // First backoff without Retry-After; zero uses DefaultInitialDelay.
+	MaxDelay     time.Duration       // Cap on any single wait; zero uses DefaultMaxDelay.
+	Sleep        func(time.Duration) // Optional; replaces time.Sleep, e.g. in tests.
+}
+
+// NewRetryingClient wraps client with the default retry settings.
+func NewRetryingClient(client ModelClient) *RetryingClient {
+	return &RetryingClient{ModelClient: client}
+}
+
+// retry runs call until it succeeds, fails with another error or the retries are used up.
+func (c *RetryingClient) retry(call func() error) error {
+	retries, delay, maxDelay := c.MaxRetries, c.InitialDelay, c.MaxDelay
+	if retries <= 0 {
+		retries = DefaultMaxRetries
+	}
+	if delay <= 0 {
+		delay = DefaultInitialDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultMaxDelay
+	}
+	sleep := c.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for attempt := 0; ; attempt++ {
+		err := call()
+		var limited *RateLimitError
+		if !errors.As(err, &limited) {
+			return err
+		}
+		if attempt >= retries {
+			return fmt.Errorf("giving up after %d retries: %w", retries, err)
+		}
+		wait := limited.RetryAfter
+		if wait <= 0 {
+			wait = delay
+			delay *= 2
+		}
+		if wait > maxDelay {
+			wait = maxDelay
+		}
+		Degrade(time.Now().Add(wait), limited.Error())
+		fmt.Printf("Warning: %v; retrying in %s\n", limited, wait)
+		sleep(wait)
+	}
+}
+
+func (c *RetryingClient) Chat(prompt string) (string, error) {
+	var resp string
+	err := c.retry(func() (err error) {
+		resp, err = c.ModelClient.Chat(prompt)
+		return err
+	})
+	return resp, err
+}
+
+func (c *RetryingClient) ChatAdvanced(req ChatRequest) (string, error) {
+	var resp string
+	err := c.retry(func() (err error) {
+		resp, err = c.ModelClient.ChatAdvanced(req)
+		return err
+	})
+	return resp, err
+}
+
+func (c *RetryingClient) ChatAdvancedParsed(req ChatRequest, target interface{}) error {
+	return c.retry(func() error {
+		return c.ModelClient.ChatAdvancedParsed(req, target)
+	})
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps the retry settings.
+func (c *RetryingClient) ForRole(role string) ModelClient {
+	scoped, ok := c.ModelClient.(RoleScoped)
+	if !ok {
+		return c
+	}
+	copy := *c
+	copy.ModelClient = scoped.ForRole(role)
+	return &copy
+}
@@ -0,0 +1,119 @@
+package model
+
+import "github.com/egobogo/aiagents/internal/config"
+
+// RequestClass groups requests by the kind of work they ask of the model.
+type RequestClass string
+
+const (
+	ClassDefault        RequestClass = ""
+	ClassClassification RequestClass = "classification" // Short yes/no or labelling answers.
+	ClassGeneration     RequestClass = "generation"     // Code, tickets and other long structured output.
+	ClassSummarization  RequestClass = "summarization"  // Memories, reports and commit messages.
+)
+
+// ModeClasses maps prompt modes to request classes. Modes not listed use ClassDefault.
+var ModeClasses = map[string]RequestClass{
+	"Classify":           ClassClassification,
+	"ReviewDependencies": ClassClassification,
+	"Implement":          ClassGeneration,
+	"RepairBuild":        ClassGeneration,
+	"RepairPatch":        ClassGeneration,
+	"ResolveConflict":    ClassGeneration,
+	"Decompose":          ClassGeneration,
+	"DraftTickets":       ClassGeneration,
+	"UpdateDocs":         ClassGeneration,
+	"SyncAPISpec":        ClassGeneration,
+	"PlanAssets":         ClassGeneration,
+	"DesignReview":       ClassGeneration,
+	"PlanSprint":         ClassGeneration,
+	"PrioritizeBacklog":  ClassGeneration,
+	"SecurityReview":     ClassGeneration,
+	"SelfReview":         ClassGeneration,
+	"AddTests":           ClassGeneration,
+	"RepairLint":         ClassGeneration,
+	"RepairTests":        ClassGeneration,
+	"RepairChecks":       ClassGeneration,
+	"RepairMigration":    ClassGeneration,
+	"TriageErrors":       ClassGeneration,
+	"AddressReview":      ClassGeneration,
+	"Summarize":          ClassSummarization,
+	"ActualizeContext":   ClassSummarization,
+	"RefreshMemories":    ClassSummarization,
+	"Standup":            ClassSummarization,
+	"Retrospective":      ClassSummarization,
+	"ReleaseNotes":       ClassSummarization,
+	"CommitMessage":      ClassSummarization,
+}
+
+// ClassifyMode returns the request class of a prompt mode.
+func ClassifyMode(mode string) RequestClass {
+	return ModeClasses[mode]
+}
+
+// Router is a ModelClient that picks the model for each request from its class,
+// so routine requests can go to a cheaper model than code generation.
+//
+// Routes take precedence over per-role model settings: a request whose class has a route uses the
+// routed model whichever role sent it, and the role's model (see ForRole) only applies to classes
+// without a route. The role's other settings, such as temperature and token limits, apply either way.
+type Router struct {
+	ModelClient
+	Models map[RequestClass]string // Model per class; classes without an entry keep the request's model.
+}
+
+// NewRouter wraps client with per-class model selection.
+func NewRouter(client ModelClient, models map[RequestClass]string) *Router {
+	return &Router{ModelClient: client, Models: models}
+}
+
+// RouterFromConfig wraps client with the routing configured under modelRouting.
+// Without configured routes the client is returned unchanged.
+func RouterFromConfig(client ModelClient) ModelClient {
+	routes := config.GetModelRouting()
+	if len(routes) == 0 {
+		return client
+	}
+	models := make(map[RequestClass]string, len(routes))
+	for class, name := range routes {
+		models[RequestClass(class)] = name
+	}
+	return NewRouter(client, models)
+}
+
+// route sets the request's model from its class.
+func (r *Router) route(req ChatRequest) ChatRequest {
+	if name := r.Models[req.Class]; name != "" {
+		req.Model = name
+	}
+	return req
+}
+
+func (r *Router) Chat(prompt string) (string, error) {
+	if name := r.Models[ClassDefault]; name != "" {
+		return r.ModelClient.ChatAdvanced(ChatRequest{
+			Model:       name,
+			Input:       []Message{{Role: "user", Content: prompt}},
+			Temperature: r.GetTemperature(),
+		})
+	}
+	return r.ModelClient.Chat(prompt)
+}
+
+func (r *Router) ChatAdvanced(req ChatRequest) (string, error) {
+	return r.ModelClient.ChatAdvanced(r.route(req))
+}
+
+func (r *Router) ChatAdvancedParsed(req ChatRequest, target interface{}) error {
+	return r.ModelClient.ChatAdvancedParsed(r.route(req), target)
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps the routing, which still
+// overrides the role's model for routed classes.
+func (r *Router) ForRole(role string) ModelClient {
+	scoped, ok := r.ModelClient.(RoleScoped)
+	if !ok {
+		return r
+	}
+	return &Router{ModelClient: scoped.ForRole(role), Models: r.Models}
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultStructuredRetries is how often ChatStructured asks the model to repair an invalid answer.
+const DefaultStructuredRetries = 2
+
+// ErrInvalidOutput is returned when the model's answer still does not match the schema after all retries.
+var ErrInvalidOutput = errors.New("model output does not match schema")
+
+// ChatStructured sends a request whose Text format carries a JSON schema, validates the answer against
+// that schema and unmarshals it into out. Invalid answers are sent back to the model together with the
+// validation error, up to DefaultStructuredRetries times. Requests without a schema are only unmarshalled.
+func ChatStructured(c ModelClient, req ChatRequest, out interface{}) error {
+	var schema interface{}
+	if req.Text != nil {
+		schema = req.Text.Format.Schema
+	}
+	var lastErr error
+	for attempt := 0; attempt <= DefaultStructuredRetries; attempt++ {
+		raw, err := c.ChatAdvanced(req)
+		if err != nil {
+			return err
+		}
+		if raw == "" && schema == nil {
+			return nil
+		}
+		lastErr = ValidateJSON(schema, []byte(raw))
+		if lastErr == nil {
+			if err := json.Unmarshal([]byte(raw), out); err != nil {
+				lastErr = err
+			} else {
+				return nil
+			}
+		}
+		req.Input = append(req.Input,
+			Message{Role: "assistant", Content: raw},
+			Message{Role: "user", Content: fmt.Sprintf("Your previous answer was invalid: %v\nReply again with JSON that matches the schema exactly.", lastErr)},
+		)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidOutput, lastErr)
+}
+
+// ValidateJSON checks data against a JSON schema. It supports the subset produced for structured outputs:
+// type, properties, required, additionalProperties, items, enum, minimum and maximum. A nil schema only
+// checks that data is valid JSON.
+func ValidateJSON(schema interface{}, data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if schema == nil {
+		return nil
+	}
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		// Normalise typed schemas (e.g. structs) to their JSON form.
+		encoded, err := json.Marshal(schema)
+		if err != nil {
+			return fmt.Errorf("failed to encode schema: %w", err)
+		}
+		if err := json.Unmarshal(encoded, &s); err != nil {
+			return fmt.Errorf("schema is not an object: %w", err)
+		}
+	}
+	return validateValue(s, value, "$")
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string) error {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		matched := false
+		for _, t := range types {
+			if t == actual || (t == "number" && actual == "integer") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), actual)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			return fmt.Errorf("%s: %v is less than %v", path, v, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			return fmt.Errorf("%s: %v is greater than %v", path, v, max)
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateValue(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		props, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				name := fmt.Sprint(r)
+				if _, present := v[name]; !present {
+					return fmt.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			propSchema, known := props[k].(map[string]interface{})
+			if !known {
+				if extra, ok := schema["additionalProperties"].(bool); ok && !extra {
+					return fmt.Errorf("%s: unexpected property %q", path, k)
+				}
+				continue
+			}
+			if err := validateValue(propSchema, v[k], path+"."+k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the allowed types of a schema's "type" keyword.
+func schemaTypes(t interface{}) []string {
+	switch t := t.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var types []string
+		for _, x := range t {
+			types = append(types, fmt.Sprint(x))
+		}
+		return types
+	}
+	return nil
+}
+
+// jsonType returns the JSON schema type name of a decoded value.
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
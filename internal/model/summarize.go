@@ -0,0 +1,277 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// Defaults for SummarizingClient.
+const (
+	DefaultSummarizeThreshold = 0.8 // Fraction of the context window that triggers summarization.
+	DefaultKeepRecent         = 2   // Latest non-system messages always sent verbatim.
+)
+
+// summaryInstruction asks for a summary that can replace the original turns.
+const summaryInstruction = "Summarize the following conversation so it can replace the original in a model's context. " +
+	"Keep every decision, requirement, question and answer, file name, identifier and number exactly; drop pleasantries and repetition. " +
+	"Write plain text, oldest first."
+
+// ContextWindow returns the prompt window, in tokens, of a model.
+func ContextWindow(model string) int {
+	m := strings.ToLower(model)
+	switch {
+	case strings.HasPrefix(m, "gpt-4.1"):
+		return 1047576
+	case strings.HasPrefix(m, "gpt-5"):
+		return 400000
+	case strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return 200000
+	case strings.HasPrefix(m, "gpt-3.5"):
+		return 16385
+	case strings.HasPrefix(m, "gpt-4o"), strings.HasPrefix(m, "gpt-4-turbo"):
+		return 128000
+	case strings.HasPrefix(m, "gpt-4"):
+		return 8192
+	default:
+		return 128000
+	}
+}
+
+// MessageText returns the text of a message, whether its content is a string or a list of typed parts.
+func MessageText(m Message) string {
+	switch c := m.Content.(type) {
+	case string:
+		return c
+	case []ContentPart:
+		var parts []string
+		for _, p := range c {
+			if p.Type != PartImage {
+				parts = append(parts, p.Text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	case []map[string]string:
+		var parts []string
+		for _, p := range c {
+			parts = append(parts, p["text"])
+		}
+		return strings.Join(parts, "\n")
+	case []interface{}:
+		var parts []string
+		for _, p := range c {
+			if part, ok := p.(map[string]interface{}); ok {
+				if s, ok := part["text"].(string); ok {
+					parts = append(parts, s)
+				}
+			}
+		}
+		return strings.Join(parts, "\n")
+	default:
+		return fmt.Sprint(c)
+	}
+}
+
+// withText returns m with its text replaced, keeping the content shape of the original.
+// Images in typed parts are kept after the new text.
+func withText(m Message, text string) Message {
+	if parts, ok := m.Content.([]ContentPart); ok {
+		kept := []ContentPart{TextPart(text)}
+		for _, p := range parts {
+			if p.Type == PartImage {
+				kept = append(kept, p)
+			}
+		}
+		m.Content = kept
+		return m
+	}
+	if parts, ok := m.Content.([]map[string]string); ok && len(parts) > 0 {
+		part := make(map[string]string, len(parts[0]))
+		for k, v := range parts[0] {
+			part[k] = v
+		}
+		part["text"] = text
+		m.Content = []map[string]string{part}
+		return m
+	}
+	m.Content = text
+	return m
+}
+
+// EstimateRequestTokens estimates the prompt tokens of a request, including typed content parts and images.
+func EstimateRequestTokens(req ChatRequest) int {
+	total := tokensPerReply
+	for _, m := range req.Input {
+		total += tokensPerMessage + EstimateTokens(m.Role, req.Model) + EstimateTokens(MessageText(m), req.Model) + countImages(m)*tokensPerImage
+	}
+	return total
+}
+
+// SummarizingClient is a ModelClient that keeps requests inside the model's context window. When a
+// request approaches the window, the turns before the latest KeepRecent messages are replaced with
+// a summary written by the model; if the latest message alone is still too long, its older part is
+// summarized the same way. System messages are never summarized.
+type SummarizingClient struct {
+	ModelClient
+	Threshold  float64 // Fraction of the window; zero uses DefaultSummarizeThreshold.
+	KeepRecent int     // Zero uses DefaultKeepRecent.
+	Window     int     // Prompt window in tokens; zero uses ContextWindow of the request's model.
+}
+
+// NewSummarizingClient wraps client with the default thresholds.
+func NewSummarizingClient(client ModelClient) *SummarizingClient {
+	return &SummarizingClient{ModelClient: client}
+}
+
+// SummarizeFromConfig wraps client with a SummarizingClient when contextLimit is configured.
+func SummarizeFromConfig(client ModelClient) ModelClient {
+	settings := config.GetContextLimit()
+	if settings == nil {
+		return client
+	}
+	return &SummarizingClient{ModelClient: client, Threshold: settings.Threshold, KeepRecent: settings.KeepRecent, Window: settings.Window}
+}
+
+func (c *SummarizingClient) ChatAdvanced(req ChatRequest) (string, error) {
+	req, err := c.Compact(req)
+	if err != nil {
+		return "", err
+	}
+	return c.ModelClient.ChatAdvanced(req)
+}
+
+func (c *SummarizingClient) ChatAdvancedParsed(req ChatRequest, target interface{}) error {
+	req, err := c.Compact(req)
+	if err != nil {
+		return err
+	}
+	return c.ModelClient.ChatAdvancedParsed(req, target)
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps the limits.
+func (c *SummarizingClient) ForRole(role string) ModelClient {
+	scoped, ok := c.ModelClient.(RoleScoped)
+	if !ok {
+		return c
+	}
+	return &SummarizingClient{ModelClient: scoped.ForRole(role), Threshold: c.Threshold, KeepRecent: c.KeepRecent, Window: c.Window}
+}
+
+// budget returns the prompt tokens allowed for req before summarization kicks in.
+func (c *SummarizingClient) budget(req ChatRequest) int {
+	window := c.Window
+	if window <= 0 {
+		window = ContextWindow(c.modelOf(req))
+	}
+	threshold := c.Threshold
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultSummarizeThreshold
+	}
+	return int(float64(window)*threshold) - req.MaxTokens
+}
+
+func (c *SummarizingClient) modelOf(req ChatRequest) string {
+	if req.Model != "" {
+		return req.Model
+	}
+	return c.GetModel()
+}
+
+// Compact returns req unchanged when it fits the budget and otherwise with its older turns summarized.
+func (c *SummarizingClient) Compact(req ChatRequest) (ChatRequest, error) {
+	limit := c.budget(req)
+	if EstimateRequestTokens(req) <= limit {
+		return req, nil
+	}
+	keep := c.KeepRecent
+	if keep <= 0 {
+		keep = DefaultKeepRecent
+	}
+
+	var system, turns []Message
+	for _, m := range req.Input {
+		if m.Role == "system" && len(turns) == 0 {
+			system = append(system, m)
+		} else {
+			turns = append(turns, m)
+		}
+	}
+	if len(turns) > keep {
+		older, recent := turns[:len(turns)-keep], turns[len(turns)-keep:]
+		var sb strings.Builder
+		for _, m := range older {
+			fmt.Fprintf(&sb, "%s:\n%s\n\n", m.Role, MessageText(m))
+		}
+		summary, err := c.summarize(req, sb.String())
+		if err != nil {
+			return req, err
+		}
+		turns = append([]Message{{Role: "assistant", Content: "Summary of the earlier conversation:\n" + summary}}, recent...)
+	}
+	req.Input = append(append([]Message(nil), system...), turns...)
+
+	over := EstimateRequestTokens(req) - limit
+	if over <= 0 || len(turns) == 0 {
+		return req, nil
+	}
+	// The latest message alone is too long: summarize its older part and keep the rest verbatim.
+	last := req.Input[len(req.Input)-1]
+	text := MessageText(last)
+	keepTokens := EstimateTokens(text, c.modelOf(req)) - over - limit/10
+	head, tail := splitTail(text, c.modelOf(req), keepTokens)
+	if head == "" {
+		return req, nil
+	}
+	summary, err := c.summarize(req, head)
+	if err != nil {
+		return req, err
+	}
+	req.Input[len(req.Input)-1] = withText(last, "Summary of the earlier part:\n"+summary+"\n\n"+tail)
+	return req, nil
+}
+
+// summarize asks the wrapped client for a summary of text, in window-sized pieces when needed.
+func (c *SummarizingClient) summarize(req ChatRequest, text string) (string, error) {
+	model := c.modelOf(req)
+	piece := c.budget(ChatRequest{Model: model}) / 2
+	var summaries []string
+	for text != "" {
+		part := TrimToTokens(text, model, piece)
+		if part == "" {
+			part = text
+		}
+		text = text[len(part):]
+		resp, err := c.ModelClient.ChatAdvanced(ChatRequest{
+			Model: req.Model,
+			Input: []Message{
+				{Role: "system", Content: summaryInstruction},
+				{Role: "user", Content: part},
+			},
+			Class: ClassSummarization,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to summarize conversation: %w", err)
+		}
+		summaries = append(summaries, strings.TrimSpace(resp))
+	}
+	return strings.Join(summaries, "\n"), nil
+}
+
+// splitTail splits text at a line boundary so that tail holds at most tailTokens tokens.
+func splitTail(text, model string, tailTokens int) (head, tail string) {
+	if tailTokens <= 0 {
+		return text, ""
+	}
+	lines := strings.SplitAfter(text, "\n")
+	used, cut := 0, len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		n := EstimateTokens(lines[i], model)
+		if used+n > tailTokens {
+			break
+		}
+		used += n
+		cut = i
+	}
+	return strings.Join(lines[:cut], ""), strings.Join(lines[cut:], "")
+}
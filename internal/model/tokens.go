@@ -0,0 +1,227 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// Encodings used by OpenAI models.
+const (
+	EncodingO200k  = "o200k_base"
+	EncodingCL100k = "cl100k_base"
+)
+
+// Per-message overhead of the chat format: every message costs a few tokens for its role and
+// delimiters, and every reply is primed with a few more.
+const (
+	tokensPerMessage = 3
+	tokensPerReply   = 3
+)
+
+// pretokenize splits text roughly like tiktoken's cl100k/o200k patterns do before applying BPE:
+// contractions, words with an optional leading symbol, numbers in groups of up to three digits,
+// punctuation runs and whitespace.
+var pretokenize = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`)
+
+// EncodingForModel returns the tiktoken encoding name used by a model.
+func EncodingForModel(model string) string {
+	m := strings.ToLower(model)
+	switch {
+	case strings.HasPrefix(m, "gpt-4o"), strings.HasPrefix(m, "gpt-4.1"), strings.HasPrefix(m, "gpt-5"),
+		strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return EncodingO200k
+	default:
+		return EncodingCL100k
+	}
+}
+
+// EstimateTokens guesses how many tokens text occupies for model from a characters-per-token
+// heuristic over pre-tokenized pieces. It is not a BPE tokenizer: it has no merge tables and can be
+// off by a wide margin, especially for non-English text. Use it to budget and trim prompts only;
+// token counts for usage and cost must come from the provider or from CountTokens.
+func EstimateTokens(text, model string) int {
+	if text == "" {
+		return 0
+	}
+	// The larger o200k vocabulary merges long words more often.
+	charsPerToken := 4
+	if EncodingForModel(model) == EncodingO200k {
+		charsPerToken = 5
+	}
+	count := 0
+	for _, piece := range pretokenize.FindAllString(text, -1) {
+		count += pieceTokens(piece, charsPerToken)
+	}
+	return count
+}
+
+// pieceTokens estimates the BPE tokens of one pre-tokenized piece.
+func pieceTokens(piece string, charsPerToken int) int {
+	runes := utf8.RuneCountInString(piece)
+	if runes == len(piece) {
+		// ASCII: common words and short symbols are a single token.
+		n := (len(piece) + charsPerToken - 1) / charsPerToken
+		if len(piece) <= charsPerToken+2 || n < 1 {
+			return 1
+		}
+		return n
+	}
+	// Non-ASCII text rarely merges beyond one or two characters per token.
+	n := (runes + 1) / 2
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// EstimateMessageTokens estimates the prompt tokens of a chat request's messages, including the chat
+// format overhead, with EstimateTokens.
+func EstimateMessageTokens(messages []Message, model string) int {
+	total := tokensPerReply
+	for _, m := range messages {
+		total += tokensPerMessage + EstimateTokens(m.Role, model)
+		if s, ok := m.Content.(string); ok {
+			total += EstimateTokens(s, model)
+		}
+	}
+	return total
+}
+
+// TrimToTokens returns the longest prefix of text, cut at a line boundary where possible,
+// that EstimateTokens puts within maxTokens for model.
+func TrimToTokens(text, model string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	if EstimateTokens(text, model) <= maxTokens {
+		return text
+	}
+	used := 0
+	end := 0
+	for _, loc := range pretokenize.FindAllStringIndex(text, -1) {
+		n := EstimateTokens(text[loc[0]:loc[1]], model)
+		if used+n > maxTokens {
+			break
+		}
+		used += n
+		end = loc[1]
+	}
+	if i := strings.LastIndex(text[:end], "\n"); i > 0 {
+		end = i + 1
+	}
+	return text[:end]
+}
+
+// ranksURL is where OpenAI publishes the BPE rank files of its encodings.
+const ranksURL = "https://openaipublic.blob.core.windows.net/encodings/"
+
+// encodingPatterns are the pre-tokenization patterns of the encodings, as in tiktoken.
+var encodingPatterns = map[string]string{
+	EncodingCL100k: `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`,
+	EncodingO200k: strings.Join([]string{
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?`,
+		`\p{N}{1,3}`,
+		` ?[^\s\p{L}\p{N}]+[\r\n/]*`,
+		`\s*[\r\n]+`,
+		`\s+(?!\S)`,
+		`\s+`,
+	}, "|"),
+}
+
+// Loaded encoders by encoding name. Failures are kept too, so an unreachable download is tried once.
+var (
+	encodersMu    sync.Mutex
+	encoders      = make(map[string]*tiktoken.Tiktoken)
+	encoderErrs   = make(map[string]error)
+	tokenRanksDir string
+)
+
+// SetTokenRanksDir makes CountTokens read the rank files (o200k_base.tiktoken, cl100k_base.tiktoken)
+// from dir only, for machines without internet access. With an empty dir they are downloaded once and
+// cached in TIKTOKEN_CACHE_DIR. Encoders loaded earlier are dropped.
+func SetTokenRanksDir(dir string) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	tokenRanksDir = dir
+	encoders = make(map[string]*tiktoken.Tiktoken)
+	encoderErrs = make(map[string]error)
+}
+
+// encoder returns the BPE encoder of an encoding, loading its ranks on first use.
+func encoder(encoding string) (*tiktoken.Tiktoken, error) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	if enc, ok := encoders[encoding]; ok {
+		return enc, nil
+	}
+	if err, ok := encoderErrs[encoding]; ok {
+		return nil, err
+	}
+	enc, err := loadEncoder(encoding)
+	if err != nil {
+		err = fmt.Errorf("failed to load %s ranks: %w", encoding, err)
+		encoderErrs[encoding] = err
+		return nil, err
+	}
+	encoders[encoding] = enc
+	return enc, nil
+}
+
+func loadEncoder(encoding string) (*tiktoken.Tiktoken, error) {
+	source := ranksURL + encoding + ".tiktoken"
+	if tokenRanksDir != "" {
+		source = filepath.Join(tokenRanksDir, encoding+".tiktoken")
+		if _, err := os.Stat(source); err != nil {
+			return nil, err
+		}
+	}
+	ranks, err := tiktoken.NewDefaultBpeLoader().LoadTiktokenBpe(source)
+	if err != nil {
+		return nil, err
+	}
+	bpe, err := tiktoken.NewCoreBPE(ranks, nil, encodingPatterns[encoding])
+	if err != nil {
+		return nil, err
+	}
+	return tiktoken.NewTiktoken(bpe, &tiktoken.Encoding{Name: encoding, PatStr: encodingPatterns[encoding], MergeableRanks: ranks}, nil), nil
+}
+
+// CountTokens counts the tokens text occupies for model with the model's BPE encoding, exactly as
+// tiktoken does; special tokens in text are counted as plain text. It fails when the encoding's
+// ranks cannot be loaded, in which case callers fall back to EstimateTokens.
+func CountTokens(text, model string) (int, error) {
+	enc, err := encoder(EncodingForModel(model))
+	if err != nil {
+		return 0, err
+	}
+	if text == "" {
+		return 0, nil
+	}
+	return len(enc.EncodeOrdinary(text)), nil
+}
+
+// CountMessageTokens counts the prompt tokens of a chat request's messages with CountTokens,
+// including the chat format overhead. Only text content is counted.
+func CountMessageTokens(messages []Message, model string) (int, error) {
+	total := tokensPerReply
+	for _, m := range messages {
+		role, err := CountTokens(m.Role, model)
+		if err != nil {
+			return 0, err
+		}
+		content, err := CountTokens(MessageText(m), model)
+		if err != nil {
+			return 0, err
+		}
+		total += tokensPerMessage + role + content
+	}
+	return total, nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// translateInstruction asks for a faithful translation that leaves machine-readable parts alone.
+const translateInstruction = "Translate the user's text into %s. If it already is in %s, return it unchanged. " +
+	"Keep the meaning, tone and formatting; leave code, URLs, file names, identifiers, @mentions and text in square brackets as they are. " +
+	"Reply with the translation only."
+
+// Translator translates board text with a model; it implements board.Translator.
+type Translator struct {
+	Client ModelClient
+	Model  string // Empty uses the client's model.
+}
+
+// NewTranslator returns a Translator using client's model.
+func NewTranslator(client ModelClient) *Translator {
+	return &Translator{Client: client}
+}
+
+// Translate returns text in language, a language name in English such as "German".
+func (t *Translator) Translate(text, language string) (string, error) {
+	model := t.Model
+	if model == "" {
+		model = t.Client.GetModel()
+	}
+	resp, err := t.Client.ChatAdvanced(ChatRequest{
+		Model: model,
+		Input: []Message{
+			{Role: "system", Content: fmt.Sprintf(translateInstruction, language, language)},
+			{Role: "user", Content: text},
+		},
+		Class: ClassSummarization,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to translate into %s: %w", language, err)
+	}
+	translated := strings.TrimSpace(resp)
+	if translated == "" {
+		return "", fmt.Errorf("failed to translate into %s: empty response", language)
+	}
+	return translated, nil
+}
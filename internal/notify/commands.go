@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CommandPrefix starts every chat command, e.g. "!decompose <card-url>".
+const CommandPrefix = "!"
+
+// Command is a parsed chat command.
+type Command struct {
+	Name    string
+	Args    []string
+	Author  string
+	Channel string
+}
+
+// ParseCommand parses a chat message into a Command. It reports false for ordinary messages.
+func ParseCommand(text string) (Command, bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, CommandPrefix) {
+		return Command{}, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(text, CommandPrefix))
+	if len(fields) == 0 {
+		return Command{}, false
+	}
+	return Command{Name: strings.ToLower(fields[0]), Args: fields[1:]}, true
+}
+
+// CommandHandler executes a command and returns the reply posted back to the channel.
+type CommandHandler func(cmd Command) (string, error)
+
+// Dispatcher routes commands to their handlers.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]CommandHandler
+}
+
+// NewDispatcher creates a Dispatcher with a built-in "help" command.
+func NewDispatcher() *Dispatcher {
+	d := &Dispatcher{handlers: make(map[string]CommandHandler)}
+	d.Register("help", func(Command) (string, error) {
+		return "Available commands: " + CommandPrefix + strings.Join(d.Names(), ", "+CommandPrefix), nil
+	})
+	return d
+}
+
+// Register adds or replaces the handler for a command name.
+func (d *Dispatcher) Register(name string, handler CommandHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[strings.ToLower(name)] = handler
+}
+
+// Names returns the registered command names in sorted order.
+func (d *Dispatcher) Names() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	names := make([]string, 0, len(d.handlers))
+	for name := range d.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dispatch runs the handler for cmd and returns its reply. Handler errors are turned into a reply as well.
+func (d *Dispatcher) Dispatch(cmd Command) string {
+	d.mu.RLock()
+	handler, ok := d.handlers[cmd.Name]
+	d.mu.RUnlock()
+	if !ok {
+		return fmt.Sprintf("Unknown command %s%s. Try %shelp.", CommandPrefix, cmd.Name, CommandPrefix)
+	}
+	reply, err := handler(cmd)
+	if err != nil {
+		return fmt.Sprintf("%s%s failed: %v", CommandPrefix, cmd.Name, err)
+	}
+	return reply
+}
@@ -0,0 +1,169 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/notify"
+)
+
+// DiscordClient implements notify.Notifier with a Discord bot token or an incoming webhook,
+// and can poll a channel for "!" commands.
+type DiscordClient struct {
+	Token          string // Bot token; required for reading commands and posting to arbitrary channels.
+	WebhookURL     string // Incoming webhook; used for posting when Token is empty.
+	DefaultChannel string // Channel ID.
+	BaseURL        string // e.g., "https://discord.com/api/v10"
+	HTTPClient     *http.Client
+}
+
+// NewDiscordClient creates a DiscordClient for a bot posting to defaultChannel unless told otherwise.
+func NewDiscordClient(token, defaultChannel string) *DiscordClient {
+	return &DiscordClient{
+		Token:          token,
+		DefaultChannel: defaultChannel,
+		BaseURL:        "https://discord.com/api/v10",
+		HTTPClient:     &http.Client{},
+	}
+}
+
+// NewDiscordWebhookClient creates a post-only DiscordClient using an incoming webhook.
+func NewDiscordWebhookClient(webhookURL string) *DiscordClient {
+	return &DiscordClient{
+		WebhookURL: webhookURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// message mirrors the fields of a Discord message we care about.
+type message struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Author  struct {
+		Username string `json:"username"`
+		Bot      bool   `json:"bot"`
+	} `json:"author"`
+}
+
+// do performs an API request and decodes the JSON response into out (if non-nil).
+func (c *DiscordClient) do(method, endpoint string, payload interface{}, out interface{}) error {
+	body := &bytes.Buffer{}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewBuffer(data)
+	}
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bot "+c.Token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("discord returned %s: %s", resp.Status, string(respBody))
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// Notify posts message to channel.
+func (c *DiscordClient) Notify(channel, text string) error {
+	if channel == "" {
+		channel = c.DefaultChannel
+	}
+	payload := map[string]string{"content": text}
+	if c.Token == "" {
+		if c.WebhookURL == "" {
+			return fmt.Errorf("discord client has neither a token nor a webhook URL")
+		}
+		return c.do("POST", c.WebhookURL, payload, nil)
+	}
+	if channel == "" {
+		return fmt.Errorf("no discord channel given")
+	}
+	return c.do("POST", c.BaseURL+"/channels/"+url.PathEscape(channel)+"/messages", payload, nil)
+}
+
+// ReadCommands returns the commands posted to channel after the message with ID after,
+// together with the ID of the newest message seen. Messages from bots are ignored.
+func (c *DiscordClient) ReadCommands(channel, after string) ([]notify.Command, string, error) {
+	if c.Token == "" {
+		return nil, after, fmt.Errorf("reading commands requires a bot token")
+	}
+	q := url.Values{"limit": {"100"}}
+	if after != "" {
+		q.Set("after", after)
+	}
+	var msgs []message
+	if err := c.do("GET", c.BaseURL+"/channels/"+url.PathEscape(channel)+"/messages?"+q.Encode(), nil, &msgs); err != nil {
+		return nil, after, fmt.Errorf("failed to read messages: %w", err)
+	}
+	// Discord returns newest first.
+	var cmds []notify.Command
+	last := after
+	for i := len(msgs) - 1; i >= 0; i-- {
+		m := msgs[i]
+		last = m.ID
+		if m.Author.Bot {
+			continue
+		}
+		if cmd, ok := notify.ParseCommand(m.Content); ok {
+			cmd.Author = m.Author.Username
+			cmd.Channel = channel
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds, last, nil
+}
+
+// Listen polls channel for commands every interval, dispatches them and posts the replies,
+// until stop is closed. Only messages posted after Listen starts are handled.
+func (c *DiscordClient) Listen(channel string, d *notify.Dispatcher, interval time.Duration, stop <-chan struct{}) error {
+	if channel == "" {
+		channel = c.DefaultChannel
+	}
+	// Skip the backlog: remember the newest existing message.
+	_, last, err := c.ReadCommands(channel, "")
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+		cmds, newest, err := c.ReadCommands(channel, last)
+		if err != nil {
+			fmt.Printf("Warning: failed to poll discord commands: %v\n", err)
+			continue
+		}
+		last = newest
+		for _, cmd := range cmds {
+			if err := c.Notify(channel, d.Dispatch(cmd)); err != nil {
+				fmt.Printf("Warning: failed to reply to %s%s: %v\n", notify.CommandPrefix, cmd.Name, err)
+			}
+		}
+	}
+}
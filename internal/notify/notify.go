@@ -0,0 +1,7 @@
+package notify
+
+// Notifier posts short messages to a team chat.
+type Notifier interface {
+	// Notify posts message to channel. An empty channel uses the notifier's default channel.
+	Notify(channel, message string) error
+}
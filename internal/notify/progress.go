@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TicketUpdates posts a chat message whenever an agent moves a ticket into a new phase.
+// It satisfies agent.ProgressTracker; polls are not reported to keep channels quiet.
+type TicketUpdates struct {
+	Notifier Notifier
+	Channel  string
+
+	mu     sync.Mutex
+	phases map[string]string
+}
+
+// NewTicketUpdates creates a TicketUpdates posting to channel.
+func NewTicketUpdates(n Notifier, channel string) *TicketUpdates {
+	return &TicketUpdates{Notifier: n, Channel: channel, phases: make(map[string]string)}
+}
+
+// Track posts an update when the ticket enters a phase it was not already in.
+func (t *TicketUpdates) Track(ticketID, agent, phase string) {
+	t.mu.Lock()
+	changed := t.phases[ticketID] != phase
+	t.phases[ticketID] = phase
+	t.mu.Unlock()
+	if changed {
+		t.post(fmt.Sprintf("[%s] %s: %s", ticketID, agent, phase))
+	}
+}
+
+// Poll is ignored.
+func (t *TicketUpdates) Poll(ticketID string) {}
+
+// Done posts a completion message.
+func (t *TicketUpdates) Done(ticketID string) {
+	t.mu.Lock()
+	delete(t.phases, ticketID)
+	t.mu.Unlock()
+	t.post(fmt.Sprintf("[%s] done", ticketID))
+}
+
+// Alert posts an error alert for a ticket.
+func (t *TicketUpdates) Alert(ticketID string, err error) {
+	t.post(fmt.Sprintf(":warning: [%s] %v", ticketID, err))
+}
+
+func (t *TicketUpdates) post(message string) {
+	if err := t.Notifier.Notify(t.Channel, message); err != nil {
+		fmt.Printf("Warning: failed to post ticket update: %v\n", err)
+	}
+}
@@ -0,0 +1,88 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// SlackClient implements notify.Notifier using either an incoming webhook or a bot token.
+type SlackClient struct {
+	WebhookURL     string // Incoming webhook; used when Token is empty.
+	Token          string // Bot token for chat.postMessage; allows posting to any channel.
+	DefaultChannel string
+	BaseURL        string // e.g., "https://slack.com/api"
+	HTTPClient     *http.Client
+}
+
+// NewSlackWebhookClient creates a SlackClient posting through an incoming webhook.
+func NewSlackWebhookClient(webhookURL string) *SlackClient {
+	return &SlackClient{
+		WebhookURL: webhookURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// NewSlackClient creates a SlackClient posting with a bot token to defaultChannel unless told otherwise.
+func NewSlackClient(token, defaultChannel string) *SlackClient {
+	return &SlackClient{
+		Token:          token,
+		DefaultChannel: defaultChannel,
+		BaseURL:        "https://slack.com/api",
+		HTTPClient:     &http.Client{},
+	}
+}
+
+// Notify posts message to channel.
+func (c *SlackClient) Notify(channel, message string) error {
+	if channel == "" {
+		channel = c.DefaultChannel
+	}
+	payload := map[string]string{"text": message}
+	if channel != "" {
+		payload["channel"] = channel
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := c.WebhookURL
+	if c.Token != "" {
+		url = c.BaseURL + "/chat.postMessage"
+	}
+	if url == "" {
+		return fmt.Errorf("slack client has neither a token nor a webhook URL")
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack returned %s: %s", resp.Status, string(body))
+	}
+	// The Web API reports errors in the body with a 200 status.
+	if c.Token != "" {
+		var result struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &result); err == nil && !result.OK {
+			return fmt.Errorf("slack API error: %s", result.Error)
+		}
+	}
+	return nil
+}
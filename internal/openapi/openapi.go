@@ -0,0 +1,258 @@
+// Package openapi finds the HTTP routes registered in Go code and validates OpenAPI 3 documents
+// against them.
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the repository path of the spec.
+const DefaultPath = "api/openapi.yaml"
+
+// methods are the operation keys of a path item.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Route is an HTTP route registered in code.
+type Route struct {
+	Method string // Upper case; empty when the route accepts any method.
+	Path   string // In OpenAPI template form, e.g. "/users/{id}".
+}
+
+func (r Route) String() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return r.Method + " " + r.Path
+}
+
+var (
+	// muxPattern matches net/http registrations, e.g. `HandleFunc("GET /users/{id}", ...)`.
+	muxPattern = regexp.MustCompile(`\.Handle(?:Func)?\(\s*"([^"]+)"`)
+	// routerPattern matches gin, echo and chi style registrations, e.g. `r.GET("/users/:id", ...)`.
+	routerPattern = regexp.MustCompile(`\.(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|Get|Post|Put|Patch|Delete|Head|Options)\(\s*"(/[^"]*)"`)
+	// colonParam matches gin and echo path parameters, e.g. ":id".
+	colonParam = regexp.MustCompile(`:([A-Za-z_][A-Za-z0-9_]*)`)
+	// templateParam matches OpenAPI and net/http path parameters, e.g. "{id}" or "{path...}".
+	templateParam = regexp.MustCompile(`\{([^}]+)\}`)
+	// handlerPattern matches code that defines or registers HTTP handlers.
+	handlerPattern = regexp.MustCompile(`http\.ResponseWriter|http\.Handler|\.Handle(?:Func)?\(|\*gin\.Context|echo\.Context|\.(?:GET|POST|PUT|PATCH|DELETE)\(\s*"/`)
+)
+
+// IsHandlerCode reports whether Go source or a diff of it defines or registers HTTP handlers.
+func IsHandlerCode(src string) bool {
+	return handlerPattern.MatchString(src)
+}
+
+// FindRoutes returns the routes registered in Go source, in order of appearance.
+func FindRoutes(src string) []Route {
+	var routes []Route
+	for _, m := range muxPattern.FindAllStringSubmatch(src, -1) {
+		method, path, ok := strings.Cut(m[1], " ")
+		if !ok {
+			method, path = "", m[1]
+		}
+		if !strings.HasPrefix(path, "/") {
+			continue // Host patterns are not part of the spec.
+		}
+		routes = append(routes, Route{Method: strings.ToUpper(method), Path: normalize(path)})
+	}
+	for _, m := range routerPattern.FindAllStringSubmatch(src, -1) {
+		routes = append(routes, Route{Method: strings.ToUpper(m[1]), Path: normalize(m[2])})
+	}
+	return routes
+}
+
+// normalize converts a router path to OpenAPI template form.
+func normalize(path string) string {
+	path = colonParam.ReplaceAllString(path, "{$1}")
+	path = strings.ReplaceAll(path, "...}", "}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// Validate checks that data is an OpenAPI 3 document: it needs a version, a title, paths with
+// responses for every operation, declared path parameters, unique operation IDs and local
+// references that resolve. All problems are returned together.
+func Validate(data []byte) error {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if doc == nil {
+		return errors.New("the document is empty")
+	}
+	var problems []error
+	if v, _ := doc["openapi"].(string); !strings.HasPrefix(v, "3.") {
+		problems = append(problems, fmt.Errorf("openapi must be a 3.x version, got %v", doc["openapi"]))
+	}
+	info, _ := doc["info"].(map[string]interface{})
+	if s, _ := info["title"].(string); s == "" {
+		problems = append(problems, errors.New("info.title is missing"))
+	}
+	if info["version"] == nil {
+		problems = append(problems, errors.New("info.version is missing"))
+	}
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		problems = append(problems, errors.New("paths is missing"))
+	}
+
+	operationIDs := make(map[string]string)
+	for _, path := range sortedKeys(paths) {
+		item, ok := paths[path].(map[string]interface{})
+		if !strings.HasPrefix(path, "/") {
+			problems = append(problems, fmt.Errorf("path %q must start with /", path))
+		}
+		if !ok {
+			problems = append(problems, fmt.Errorf("path %s is not an object", path))
+			continue
+		}
+		shared := parameterNames(doc, item["parameters"])
+		for _, method := range methods {
+			raw, present := item[method]
+			if !present {
+				continue
+			}
+			op, ok := raw.(map[string]interface{})
+			if !ok {
+				problems = append(problems, fmt.Errorf("%s %s is not an object", strings.ToUpper(method), path))
+				continue
+			}
+			name := strings.ToUpper(method) + " " + path
+			if responses, _ := op["responses"].(map[string]interface{}); len(responses) == 0 {
+				problems = append(problems, fmt.Errorf("%s has no responses", name))
+			}
+			if id, _ := op["operationId"].(string); id != "" {
+				if other, dup := operationIDs[id]; dup {
+					problems = append(problems, fmt.Errorf("operationId %q is used by %s and %s", id, other, name))
+				}
+				operationIDs[id] = name
+			}
+			declared := parameterNames(doc, op["parameters"])
+			for _, m := range templateParam.FindAllStringSubmatch(path, -1) {
+				if !shared[m[1]] && !declared[m[1]] {
+					problems = append(problems, fmt.Errorf("%s does not declare path parameter %q", name, m[1]))
+				}
+			}
+		}
+	}
+
+	for _, ref := range refs(doc, nil) {
+		if resolve(doc, ref) == nil {
+			problems = append(problems, fmt.Errorf("reference %s does not resolve", ref))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// Missing returns the routes that have no matching operation in the spec. Routes accepting any
+// method only need their path.
+func Missing(data []byte, routes []Route) ([]Route, error) {
+	var doc struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	// Parameter names may differ between code and spec; compare the path shapes.
+	items := make(map[string]map[string]interface{})
+	for path, item := range doc.Paths {
+		items[shape(path)] = item
+	}
+	var missing []Route
+	seen := make(map[Route]bool)
+	for _, r := range routes {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		item, ok := items[shape(r.Path)]
+		if !ok {
+			missing = append(missing, r)
+			continue
+		}
+		if _, ok := item[strings.ToLower(r.Method)]; r.Method != "" && !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing, nil
+}
+
+// shape replaces path parameter names so paths can be compared.
+func shape(path string) string {
+	return templateParam.ReplaceAllString(path, "{}")
+}
+
+// parameterNames returns the names of the path parameters in a parameters list.
+func parameterNames(doc map[string]interface{}, list interface{}) map[string]bool {
+	names := make(map[string]bool)
+	params, _ := list.([]interface{})
+	for _, p := range params {
+		param, _ := p.(map[string]interface{})
+		if ref, ok := param["$ref"].(string); ok {
+			param, _ = resolve(doc, ref).(map[string]interface{})
+		}
+		if in, _ := param["in"].(string); in == "path" {
+			if name, _ := param["name"].(string); name != "" {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// refs returns every "$ref" in node, in document order.
+func refs(node interface{}, found []string) []string {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(n) {
+			if ref, ok := n[k].(string); ok && k == "$ref" {
+				found = append(found, ref)
+				continue
+			}
+			found = refs(n[k], found)
+		}
+	case []interface{}:
+		for _, v := range n {
+			found = refs(v, found)
+		}
+	}
+	return found
+}
+
+// resolve follows a local reference such as "#/components/schemas/User". References to other
+// documents are not checked and resolve to themselves.
+func resolve(doc map[string]interface{}, ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return ref
+	}
+	var node interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if node, ok = m[part]; !ok {
+			return nil
+		}
+	}
+	return node
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
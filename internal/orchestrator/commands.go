@@ -0,0 +1,235 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// CardCommandPrefix starts a command in a card comment, e.g. "/assign @backend".
+const CardCommandPrefix = "/"
+
+// commandMarker starts the acknowledgement CommandRule posts for every command it handled.
+const commandMarker = "[command]"
+
+// CardCommand is a command a human wrote in a card comment.
+type CardCommand struct {
+	Name   string
+	Args   []string
+	Author *board.Member // Nil when the board does not report comment authors.
+	Card   board.Card
+}
+
+// ParseCardCommand parses the first line of a comment into a command. It reports false for
+// ordinary comments.
+func ParseCardCommand(text string) (CardCommand, bool) {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if !strings.HasPrefix(line, CardCommandPrefix) {
+		return CardCommand{}, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, CardCommandPrefix))
+	if len(fields) == 0 {
+		return CardCommand{}, false
+	}
+	return CardCommand{Name: strings.ToLower(fields[0]), Args: fields[1:]}, true
+}
+
+// CardCommandHandler executes a command and returns the acknowledgement posted on the card.
+type CardCommandHandler func(cmd CardCommand) (string, error)
+
+// CommandRule is a Rule that runs the commands humans write in card comments and acknowledges each
+// with a comment. Acknowledgements mark commands as done, so every command runs once, across
+// restarts too. It knows /assign, /retry and /abort; agents register the commands they serve, e.g.
+// /decompose.
+type CommandRule struct {
+	Trusted     []string         // Optional; member IDs allowed to issue commands. Empty allows everyone.
+	Checkpoints checkpoint.Store // Optional; /retry clears the assigned agents' saved progress on the card.
+
+	mu       sync.RWMutex
+	handlers map[string]CardCommandHandler
+}
+
+// NewCommandRule creates a CommandRule with the built-in commands.
+func NewCommandRule() *CommandRule {
+	r := &CommandRule{handlers: make(map[string]CardCommandHandler)}
+	r.Register("assign", r.assign)
+	r.Register("retry", r.retry)
+	r.Register("abort", r.abort)
+	return r
+}
+
+// Register adds or replaces the handler for a command name.
+func (r *CommandRule) Register(name string, handler CardCommandHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[strings.ToLower(name)] = handler
+}
+
+// Names returns the registered command names in sorted order.
+func (r *CommandRule) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.handlers))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the rule name.
+func (r *CommandRule) Name() string {
+	return "card commands"
+}
+
+// Apply runs the commands on every card that were not acknowledged yet.
+func (r *CommandRule) Apply(b board.BoardClient) error {
+	cards, err := b.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, c := range cards {
+		if err := r.applyToCard(c); err != nil {
+			fmt.Printf("Warning: commands on %q failed: %v\n", c.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// applyToCard runs the card's pending commands. Every command gets one acknowledgement naming it;
+// counting both works whatever order the board returns comments in.
+func (r *CommandRule) applyToCard(c board.Card) error {
+	comments, err := c.ReadComments()
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	pending := make(map[string]int)
+	for _, cm := range comments {
+		if ack, ok := strings.CutPrefix(cm.Text, commandMarker+" "); ok {
+			line, _, _ := strings.Cut(ack, "\n")
+			pending[line]--
+		}
+	}
+	for _, cm := range comments {
+		cmd, ok := ParseCardCommand(cm.Text)
+		if !ok {
+			continue
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(cm.Text), "\n")
+		if pending[line]++; pending[line] <= 0 {
+			continue
+		}
+		cmd.Author, cmd.Card = cm.Member, c
+		if err := c.WriteComment(commandMarker + " " + line + "\n" + r.run(cmd)); err != nil {
+			return fmt.Errorf("failed to acknowledge %s: %w", line, err)
+		}
+	}
+	return nil
+}
+
+// run executes cmd and returns its acknowledgement; refusals and failures are acknowledged too.
+func (r *CommandRule) run(cmd CardCommand) string {
+	if len(r.Trusted) > 0 && (cmd.Author == nil || !slices.Contains(r.Trusted, cmd.Author.ID)) {
+		return "Ignored: only trusted members may issue commands."
+	}
+	r.mu.RLock()
+	handler, ok := r.handlers[cmd.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Sprintf("Unknown command %s%s. Available: %s%s.", CardCommandPrefix, cmd.Name, CardCommandPrefix, strings.Join(r.Names(), ", "+CardCommandPrefix))
+	}
+	reply, err := handler(cmd)
+	if err != nil {
+		return fmt.Sprintf("%s%s failed: %v", CardCommandPrefix, cmd.Name, err)
+	}
+	return reply
+}
+
+// assign handles "/assign @member...", assigning the card to each member.
+func (r *CommandRule) assign(cmd CardCommand) (string, error) {
+	if len(cmd.Args) == 0 {
+		return "", fmt.Errorf("usage: %sassign @member", CardCommandPrefix)
+	}
+	var names []string
+	for _, arg := range cmd.Args {
+		name := strings.TrimPrefix(arg, "@")
+		if err := cmd.Card.AssignTo(name); err != nil {
+			return "", fmt.Errorf("failed to assign %s: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	return "Assigned to " + strings.Join(names, ", ") + ".", nil
+}
+
+// retry handles "/retry": the assigned agents start the card over from Doing.
+func (r *CommandRule) retry(cmd CardCommand) (string, error) {
+	members, err := cmd.Card.GetAssignedMembers()
+	if err != nil {
+		return "", fmt.Errorf("failed to get assigned members: %w", err)
+	}
+	if r.Checkpoints != nil {
+		for _, m := range members {
+			for _, name := range agentNames(m) {
+				if err := r.Checkpoints.Clear(name, cmd.Card.GetID()); err != nil {
+					return "", fmt.Errorf("failed to clear progress of %s: %w", name, err)
+				}
+			}
+		}
+	}
+	if err := board.TransitionTicket(cmd.Card, board.ListDoing, commandActor(cmd), "retry requested"); err != nil {
+		return "", err
+	}
+	return "Moved back to " + board.ListDoing + "; the assigned agents start over.", nil
+}
+
+// abort handles "/abort": the card is parked in Blocked and unassigned, so no agent picks it up
+// again until someone assigns it.
+func (r *CommandRule) abort(cmd CardCommand) (string, error) {
+	members, err := cmd.Card.GetAssignedMembers()
+	if err != nil {
+		return "", fmt.Errorf("failed to get assigned members: %w", err)
+	}
+	var names []string
+	for _, m := range members {
+		if err := cmd.Card.UnassignFrom(m.Name); err != nil {
+			return "", fmt.Errorf("failed to unassign %s: %w", m.Name, err)
+		}
+		names = append(names, m.Name)
+	}
+	if err := board.TransitionTicket(cmd.Card, board.ListBlocked, commandActor(cmd), "aborted"); err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "Moved to " + board.ListBlocked + ".", nil
+	}
+	return fmt.Sprintf("Moved to %s and unassigned %s. Use %sassign to resume.", board.ListBlocked, strings.Join(names, ", "), CardCommandPrefix), nil
+}
+
+// commandActor names who issued cmd in recorded transitions.
+func commandActor(cmd CardCommand) string {
+	if cmd.Author != nil && cmd.Author.Name != "" {
+		return cmd.Author.Name
+	}
+	return CardCommandPrefix + cmd.Name
+}
+
+// agentNames returns the names an agent assigned as m may keep checkpoints under: the member's
+// names and the configured aliases pointing at them.
+func agentNames(m board.Member) []string {
+	names := []string{m.Name}
+	if m.Username != "" && m.Username != m.Name {
+		names = append(names, m.Username)
+	}
+	for alias, member := range config.GetMemberAliases() {
+		if member == m.Name || member == m.Username {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+)
+
+// costReportMarker starts the summary comment posted by CostReportRule.
+const costReportMarker = "[cost report]"
+
+// EventSource provides the event log a CostReportRule reports from; *events.JSONLLog implements it.
+type EventSource interface {
+	Read(filter events.Filter) ([]events.Event, error)
+}
+
+// CostReportRule posts a summary on every ticket that reaches the done list: model calls, tokens and
+// estimated dollars spent, commits produced, wall-clock time and time spent in each list. Figures come
+// from the events agents recorded for the ticket, so moves made by hand on the board are not timed.
+type CostReportRule struct {
+	Events   EventSource
+	DoneList string
+}
+
+// NewCostReportRule creates a CostReportRule reading log and using the standard done list.
+func NewCostReportRule(log EventSource) *CostReportRule {
+	return &CostReportRule{Events: log, DoneList: board.ListDone}
+}
+
+// Name returns the rule name.
+func (r *CostReportRule) Name() string {
+	return "cost report"
+}
+
+// Apply reports on done tickets that have recorded events and no report yet.
+func (r *CostReportRule) Apply(b board.BoardClient) error {
+	cards, err := b.GetCardsFromList(r.DoneList)
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, c := range cards {
+		if err := r.report(c); err != nil {
+			fmt.Printf("Warning: cost report for %q failed: %v\n", c.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func (r *CostReportRule) report(card board.Card) error {
+	comments, err := card.ReadComments()
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, costReportMarker) {
+			return nil
+		}
+	}
+	evs, err := r.Events.Read(events.Filter{TicketID: card.GetID()})
+	if err != nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+	if len(evs) == 0 {
+		return nil // Nothing was recorded for tickets finished before the event log existed.
+	}
+	if err := card.WriteComment(CostReport(evs, r.DoneList, time.Now())); err != nil {
+		return fmt.Errorf("failed to post report: %w", err)
+	}
+	return nil
+}
+
+// CostReport formats the summary of a ticket's events, in recording order. The ticket counts as
+// finished at its last move to doneList, or at now when no such move was recorded.
+func CostReport(evs []events.Event, doneList string, now time.Time) string {
+	usage := events.AggregateUsage(evs)
+	usd, unpriced := usage.Cost()
+	commits := 0
+	for _, e := range evs {
+		if e.Type == events.Commit && e.Error == "" {
+			commits++
+		}
+	}
+	lists, order, finished := listTimes(evs, doneList, now)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s Ticket completed.\n", costReportMarker)
+	fmt.Fprintf(&sb, "- Model calls: %d", usage.Total.Calls)
+	if usage.Total.Cached > 0 || usage.Total.Errors > 0 {
+		fmt.Fprintf(&sb, " (%d from cache, %d failed)", usage.Total.Cached, usage.Total.Errors)
+	}
+	fmt.Fprintf(&sb, "\n- Tokens: %d prompt, %d completion\n", usage.Total.PromptTokens, usage.Total.CompletionTokens)
+	fmt.Fprintf(&sb, "- Estimated cost: $%.2f", usd)
+	if len(unpriced) > 0 {
+		fmt.Fprintf(&sb, " (no price for %s)", strings.Join(unpriced, ", "))
+	}
+	if usage.Total.Unreported > 0 {
+		fmt.Fprintf(&sb, " (%d calls without reported usage left out)", usage.Total.Unreported)
+	}
+	fmt.Fprintf(&sb, "\n- Commits: %d\n", commits)
+	fmt.Fprintf(&sb, "- Elapsed: %s", roundDuration(finished.Sub(evs[0].Time)))
+	if len(order) > 0 {
+		parts := make([]string, len(order))
+		for i, name := range order {
+			parts[i] = fmt.Sprintf("%s %s", name, roundDuration(lists[name]))
+		}
+		fmt.Fprintf(&sb, "\n- Time per list: %s", strings.Join(parts, ", "))
+	}
+	return sb.String()
+}
+
+// listTimes sums the time the ticket spent in each list before reaching doneList, from the recorded
+// moves. It returns the lists in the order the ticket entered them and when the ticket finished.
+func listTimes(evs []events.Event, doneList string, now time.Time) (map[string]time.Duration, []string, time.Time) {
+	times := make(map[string]time.Duration)
+	var order []string
+	current, since := "", evs[0].Time
+	finished := now
+	for _, e := range evs {
+		if e.Type != events.CardMoved || e.Error != "" {
+			continue
+		}
+		if current == "" {
+			current = e.Details["from"] // The list the ticket was in before its first recorded move.
+		}
+		if d := e.Time.Sub(since); d > 0 && current != "" && !strings.EqualFold(current, doneList) {
+			if _, seen := times[current]; !seen {
+				order = append(order, current)
+			}
+			times[current] += d
+		}
+		current, since = e.Details["to"], e.Time
+		if strings.EqualFold(current, doneList) {
+			finished = e.Time
+		}
+	}
+	return times, order, finished
+}
+
+// roundDuration shortens d for display: whole seconds under a minute, whole minutes above.
+func roundDuration(d time.Duration) time.Duration {
+	if d < time.Minute {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Minute)
+}
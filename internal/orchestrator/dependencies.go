@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// Comment markers posted by DependencyRule.
+const (
+	waitingMarker = "[dependencies] Waiting for:"
+	readyMarker   = "[dependencies] Ready:"
+)
+
+// DependencyRule keeps tickets from being worked on before the tickets they depend on are done.
+// A ticket with unfinished dependencies in one of the active lists is moved to the hold list;
+// once its dependencies are done, a ticket the rule held is moved back to the ready list.
+type DependencyRule struct {
+	DoneList    string   // List that marks a dependency as done.
+	HoldList    string   // List waiting tickets are parked in.
+	ReadyList   string   // List released tickets return to.
+	ActiveLists []string // Lists whose tickets may be picked up.
+}
+
+// NewDependencyRule creates a DependencyRule using the standard board lists.
+func NewDependencyRule() *DependencyRule {
+	return &DependencyRule{
+		DoneList:    board.ListDone,
+		HoldList:    board.ListBlocked,
+		ReadyList:   board.ListSprint,
+		ActiveLists: []string{board.ListSprint, board.ListDoing},
+	}
+}
+
+// Name returns the rule name.
+func (r *DependencyRule) Name() string {
+	return "dependencies"
+}
+
+// Apply holds and releases every ticket that has dependencies.
+func (r *DependencyRule) Apply(b board.BoardClient) error {
+	cards, err := b.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, c := range cards {
+		if len(board.Dependencies(c.GetDescription())) == 0 {
+			continue
+		}
+		list, err := c.GetList()
+		if err != nil {
+			continue
+		}
+		unmet := board.UnmetDependencies(cards, c, r.DoneList)
+		switch {
+		case len(unmet) > 0 && r.active(list.GetName()):
+			err = r.hold(c, unmet)
+		case len(unmet) == 0 && strings.EqualFold(list.GetName(), r.HoldList):
+			err = r.release(c)
+		}
+		if err != nil {
+			fmt.Printf("Warning: dependency check for %q failed: %v\n", c.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func (r *DependencyRule) active(list string) bool {
+	for _, l := range r.ActiveLists {
+		if strings.EqualFold(l, list) {
+			return true
+		}
+	}
+	return false
+}
+
+// hold parks the card in the hold list with a comment naming what it waits for.
+func (r *DependencyRule) hold(c board.Card, unmet []board.Card) error {
+	var names []string
+	for _, d := range unmet {
+		names = append(names, d.GetName())
+	}
+	if err := c.WriteComment(fmt.Sprintf("%s\n- %s", waitingMarker, strings.Join(names, "\n- "))); err != nil {
+		return fmt.Errorf("failed to post waiting comment: %w", err)
+	}
+	if err := board.TransitionTicket(c, r.HoldList, "dependencies", "waiting for its dependencies"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// release moves a card back to the ready list if it was held by this rule rather than blocked for another reason.
+func (r *DependencyRule) release(c board.Card) error {
+	comments, err := c.ReadComments()
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	// Every hold posts one waiting comment and every release one ready comment; counting them
+	// works whatever order the board returns comments in.
+	held := 0
+	for _, cm := range comments {
+		switch {
+		case strings.HasPrefix(cm.Text, waitingMarker):
+			held++
+		case strings.HasPrefix(cm.Text, readyMarker):
+			held--
+		}
+	}
+	if held <= 0 {
+		return nil
+	}
+	if err := c.WriteComment(readyMarker + " all dependencies are done."); err != nil {
+		return fmt.Errorf("failed to post ready comment: %w", err)
+	}
+	if err := board.TransitionTicket(c, r.ReadyList, "dependencies", "all dependencies are done"); err != nil {
+		return err
+	}
+	return nil
+}
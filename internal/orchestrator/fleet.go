@@ -0,0 +1,147 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUnknownAgent is returned when a fleet operation names an agent that was never added.
+var ErrUnknownAgent = errors.New("unknown agent")
+
+// Actor is the part of an agent the Fleet drives.
+type Actor interface {
+	Act() error
+}
+
+// AgentStatus is a snapshot of one fleet member.
+type AgentStatus struct {
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Paused    bool      `json:"paused"`
+	Running   bool      `json:"running"`
+	Runs      int       `json:"runs"`
+	LastRun   time.Time `json:"lastRun"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+type fleetMember struct {
+	status AgentStatus
+	actor  Actor
+}
+
+// Fleet runs a set of agents in turns and lets operators pause and resume them individually.
+type Fleet struct {
+	mu      sync.Mutex
+	members map[string]*fleetMember
+	order   []string
+
+	// Degraded, when set, reports whether the model provider is rate limiting or overloaded. While
+	// it is, RunOnce starts no agents so tickets wait for the provider instead of failing.
+	Degraded func() (degraded bool, until time.Time, reason string)
+	// OnError, when set, is called with every error an agent's turn returns, e.g. to alert the team chat.
+	OnError func(name string, err error)
+}
+
+// NewFleet creates an empty Fleet.
+func NewFleet() *Fleet {
+	return &Fleet{members: make(map[string]*fleetMember)}
+}
+
+// Add registers an agent under name. Adding a name twice replaces the agent.
+func (f *Fleet) Add(name, role string, a Actor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.members[name]; !ok {
+		f.order = append(f.order, name)
+	}
+	f.members[name] = &fleetMember{status: AgentStatus{Name: name, Role: role}, actor: a}
+}
+
+func (f *Fleet) setPaused(name string, paused bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.members[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAgent, name)
+	}
+	m.status.Paused = paused
+	return nil
+}
+
+// Pause stops the agent from acting on the next turns. A run in progress is not interrupted.
+func (f *Fleet) Pause(name string) error {
+	return f.setPaused(name, true)
+}
+
+// Resume lets a paused agent act again.
+func (f *Fleet) Resume(name string) error {
+	return f.setPaused(name, false)
+}
+
+// Status returns a snapshot of every agent in the order they were added.
+func (f *Fleet) Status() []AgentStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([]AgentStatus, 0, len(f.order))
+	for _, name := range f.order {
+		result = append(result, f.members[name].status)
+	}
+	return result
+}
+
+// RunOnce lets every agent that is not paused act once, in order. Errors are recorded on the
+// agent's status and do not stop the other agents. Nothing runs while the provider is degraded.
+func (f *Fleet) RunOnce() {
+	if f.Degraded != nil {
+		if degraded, until, reason := f.Degraded(); degraded {
+			fmt.Printf("Warning: model provider degraded until %s, skipping this round: %s\n", until.Format(time.TimeOnly), reason)
+			return
+		}
+	}
+	f.mu.Lock()
+	names := append([]string(nil), f.order...)
+	f.mu.Unlock()
+
+	for _, name := range names {
+		f.mu.Lock()
+		m := f.members[name]
+		if m.status.Paused || m.status.Running {
+			f.mu.Unlock()
+			continue
+		}
+		m.status.Running = true
+		f.mu.Unlock()
+
+		err := m.actor.Act()
+
+		f.mu.Lock()
+		m.status.Running = false
+		m.status.Runs++
+		m.status.LastRun = time.Now()
+		m.status.LastError = ""
+		if err != nil {
+			m.status.LastError = err.Error()
+			fmt.Printf("Warning: agent %s failed: %v\n", name, err)
+		}
+		f.mu.Unlock()
+		if err != nil && f.OnError != nil {
+			f.OnError(name, err)
+		}
+	}
+}
+
+// Start runs the fleet every interval until stop is closed.
+func (f *Fleet) Start(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		f.RunOnce()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
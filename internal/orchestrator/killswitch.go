@@ -0,0 +1,53 @@
+package orchestrator
+
+import (
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/killswitch"
+)
+
+// DefaultKillSwitchTitle is the card title that pauses all agents while the card is open.
+const DefaultKillSwitchTitle = "KILL SWITCH"
+
+// KillSwitchCard is a Rule that engages the kill switch while a card with Title exists outside the
+// done list, and releases its hold once the card is archived or moved to done. Holds taken elsewhere,
+// e.g. on the dashboard, are left alone.
+type KillSwitchCard struct {
+	Title string
+}
+
+// NewKillSwitchCard creates the rule with DefaultKillSwitchTitle.
+func NewKillSwitchCard() *KillSwitchCard {
+	return &KillSwitchCard{Title: DefaultKillSwitchTitle}
+}
+
+// Name returns the rule name.
+func (k *KillSwitchCard) Name() string {
+	return "kill switch card"
+}
+
+// Apply checks the board for the kill switch card.
+func (k *KillSwitchCard) Apply(b board.BoardClient) error {
+	cards, err := b.GetCards()
+	if err != nil {
+		return err
+	}
+	for _, c := range cards {
+		if !strings.EqualFold(strings.TrimSpace(c.GetName()), k.Title) {
+			continue
+		}
+		if list, err := c.GetList(); err == nil && list.GetName() == board.ListDone {
+			continue
+		}
+		killswitch.EngageFor(k.source(), "card "+c.GetURL())
+		return nil
+	}
+	killswitch.ReleaseFor(k.source())
+	return nil
+}
+
+// source identifies the rule's hold on the kill switch.
+func (k *KillSwitchCard) source() string {
+	return "card " + k.Title
+}
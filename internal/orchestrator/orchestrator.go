@@ -0,0 +1,49 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// Rule is a board-wide policy evaluated by the Orchestrator on every tick.
+type Rule interface {
+	// Name returns a short human-readable name of the rule.
+	Name() string
+	// Apply evaluates the rule against the board and performs any resulting actions.
+	Apply(b board.BoardClient) error
+}
+
+// Orchestrator periodically evaluates a set of rules against a board.
+type Orchestrator struct {
+	Board board.BoardClient
+	Rules []Rule
+}
+
+// New creates a new Orchestrator for the given board with the provided rules.
+func New(b board.BoardClient, rules ...Rule) *Orchestrator {
+	return &Orchestrator{
+		Board: b,
+		Rules: rules,
+	}
+}
+
+// AddRule registers an additional rule.
+func (o *Orchestrator) AddRule(r Rule) {
+	o.Rules = append(o.Rules, r)
+}
+
+// Tick applies every rule once. A failing rule does not prevent the others from running;
+// the first error encountered is returned.
+func (o *Orchestrator) Tick() error {
+	var firstErr error
+	for _, r := range o.Rules {
+		if err := r.Apply(o.Board); err != nil {
+			fmt.Printf("Warning: rule %s failed: %v\n", r.Name(), err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("rule %s failed: %w", r.Name(), err)
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// flagMarker prefixes the comment posted on a parent card when one of its children failed.
+const flagMarker = "[rollup] Attention:"
+
+// ParentRollupRule completes high-level tickets once all of their technical tickets are done,
+// and flags them when any technical ticket has failed.
+type ParentRollupRule struct {
+	DoneList   string // List that marks a ticket as completed.
+	FailedList string // List that marks a ticket as failed.
+}
+
+// NewParentRollupRule creates a ParentRollupRule using the standard board lists.
+func NewParentRollupRule() *ParentRollupRule {
+	return &ParentRollupRule{
+		DoneList:   board.ListDone,
+		FailedList: board.ListBlocked,
+	}
+}
+
+// Name returns the rule name.
+func (r *ParentRollupRule) Name() string {
+	return "parent-rollup"
+}
+
+// Apply groups cards by their parent link and updates each parent accordingly.
+func (r *ParentRollupRule) Apply(b board.BoardClient) error {
+	cards, err := b.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	byURL := make(map[string]board.Card)
+	children := make(map[string][]board.Card)
+	for _, c := range cards {
+		byURL[c.GetURL()] = c
+		if url, ok := board.ParentURL(c.GetDescription()); ok {
+			children[url] = append(children[url], c)
+		}
+	}
+
+	for url, kids := range children {
+		parent, ok := byURL[url]
+		if !ok {
+			continue
+		}
+		if err := r.applyToParent(parent, kids); err != nil {
+			fmt.Printf("Warning: rollup for %q failed: %v\n", parent.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// applyToParent moves the parent to done or flags it based on the lists of its children.
+func (r *ParentRollupRule) applyToParent(parent board.Card, kids []board.Card) error {
+	if list, err := parent.GetList(); err == nil && strings.EqualFold(list.GetName(), r.DoneList) {
+		return nil
+	}
+
+	var done, failed []string
+	for _, k := range kids {
+		list, err := k.GetList()
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.EqualFold(list.GetName(), r.DoneList):
+			done = append(done, k.GetName())
+		case strings.EqualFold(list.GetName(), r.FailedList):
+			failed = append(failed, k.GetName())
+		}
+	}
+
+	if len(failed) > 0 {
+		return r.flag(parent, failed)
+	}
+	if len(done) < len(kids) {
+		return nil
+	}
+
+	summary := fmt.Sprintf("All %d technical tickets are done:\n- %s", len(done), strings.Join(done, "\n- "))
+	if err := parent.WriteComment(summary); err != nil {
+		return fmt.Errorf("failed to post summary: %w", err)
+	}
+	// Parents wait in the backlog while their technical tickets go through the flow, so completing
+	// one skips the states the ticket flow requires.
+	if err := board.ForceTransition(parent, r.DoneList, "rollup", "all technical tickets are done"); err != nil {
+		return fmt.Errorf("failed to move parent to %s: %w", r.DoneList, err)
+	}
+	return nil
+}
+
+// flag posts a single attention comment on the parent listing the failed children.
+func (r *ParentRollupRule) flag(parent board.Card, failed []string) error {
+	text := fmt.Sprintf("%s %d technical ticket(s) failed:\n- %s", flagMarker, len(failed), strings.Join(failed, "\n- "))
+	comments, err := parent.ReadComments()
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	for _, c := range comments {
+		if c.Text == text {
+			return nil
+		}
+	}
+	if err := parent.WriteComment(text); err != nil {
+		return fmt.Errorf("failed to flag parent: %w", err)
+	}
+	return nil
+}
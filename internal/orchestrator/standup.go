@@ -0,0 +1,104 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/scheduler"
+)
+
+// StandupReporter is implemented by agents able to summarize their recent work.
+type StandupReporter interface {
+	Standup(since time.Time) (string, error)
+}
+
+// StandupCoordinator collects standup updates from agents and publishes a combined report.
+type StandupCoordinator struct {
+	Agents   map[string]StandupReporter // Keyed by agent name.
+	Board    board.BoardClient          // Board holding the standup card.
+	CardName string                     // Card the report is posted on; created in ListSprint when missing.
+	Notifier notify.Notifier            // Optional; the report is also posted to Channel.
+	Channel  string
+	Period   time.Duration // Time window covered by one report.
+}
+
+// NewStandupCoordinator creates a coordinator reporting the last 24 hours on the "Daily standup" card.
+func NewStandupCoordinator(b board.BoardClient, agents map[string]StandupReporter) *StandupCoordinator {
+	return &StandupCoordinator{
+		Agents:   agents,
+		Board:    b,
+		CardName: "Daily standup",
+		Period:   24 * time.Hour,
+	}
+}
+
+// Report compiles the standup report for the configured period.
+func (c *StandupCoordinator) Report() string {
+	since := time.Now().Add(-c.Period)
+	names := make([]string, 0, len(c.Agents))
+	for name := range c.Agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Standup %s\n", time.Now().Format("2006-01-02")))
+	for _, name := range names {
+		update, err := c.Agents[name].Standup(since)
+		if err != nil {
+			update = fmt.Sprintf("(no update: %v)", err)
+		}
+		sb.WriteString(fmt.Sprintf("\n### %s\n%s\n", name, strings.TrimSpace(update)))
+	}
+	return sb.String()
+}
+
+// Publish compiles the report and posts it on the standup card and, if configured, to chat.
+func (c *StandupCoordinator) Publish() error {
+	report := c.Report()
+	if c.Notifier != nil {
+		if err := c.Notifier.Notify(c.Channel, report); err != nil {
+			fmt.Printf("Warning: failed to post standup to chat: %v\n", err)
+		}
+	}
+	if c.Board == nil || c.CardName == "" {
+		return nil
+	}
+	card, err := c.standupCard()
+	if err != nil {
+		return err
+	}
+	return card.WriteComment(report)
+}
+
+// standupCard finds the standup card, creating it when needed.
+func (c *StandupCoordinator) standupCard() (board.Card, error) {
+	cards, err := c.Board.GetCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	for _, card := range cards {
+		if card.GetName() == c.CardName {
+			return card, nil
+		}
+	}
+	card, err := c.Board.CreateCard(c.CardName, "Daily standup reports compiled from all agents.", board.ListSprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create standup card: %w", err)
+	}
+	return card, nil
+}
+
+// Schedule registers the report on the scheduler: on spec, a cron expression, or once per Period
+// when spec is empty.
+func (c *StandupCoordinator) Schedule(s *scheduler.Scheduler, spec string) error {
+	if spec == "" {
+		s.Every(c.Period, "standup", c.Publish)
+		return nil
+	}
+	return s.Add("standup", spec, c.Publish)
+}
@@ -0,0 +1,205 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// SupervisorAction is one step of the escalation ladder applied to a stuck ticket.
+type SupervisorAction string
+
+const (
+	ActionNudge    SupervisorAction = "nudge"    // Post a reminder comment on the card.
+	ActionReassign SupervisorAction = "reassign" // Assign the card to the supervising human.
+	ActionRestart  SupervisorAction = "restart"  // Restart the current phase via Supervisor.Restart.
+	ActionAlert    SupervisorAction = "alert"    // Flag the card for a human and move it to the blocked list.
+)
+
+// Supervisor defaults.
+const (
+	DefaultStuckAfter = 30 * time.Minute
+	DefaultMaxPolls   = 100
+)
+
+// Progress is the last known state of a supervised ticket.
+type Progress struct {
+	TicketID     string
+	Agent        string
+	Phase        string
+	PhaseStarted time.Time
+	LastProgress time.Time // Last phase change or escalation.
+	Polls        int       // Unsuccessful polls in the current phase.
+	Escalations  int       // Actions already taken for the current phase.
+}
+
+// Supervisor tracks per-ticket progress reported by agents and escalates tickets that stop moving.
+// It implements agent.ProgressTracker for agents and Rule for the Orchestrator.
+type Supervisor struct {
+	StuckAfter time.Duration      // Time without progress after which a ticket is stuck.
+	MaxPolls   int                // Polls in one phase after which a ticket is stuck.
+	Actions    []SupervisorAction // Escalation ladder, one step per stuck interval.
+	Human      string             // Board member used for reassignment and alerts.
+	Blocked    string             // List cards are moved to on alert.
+	// Restart re-runs the given phase of a ticket. ActionRestart is skipped when nil.
+	Restart func(ticketID, phase string) error
+
+	mu       sync.Mutex
+	progress map[string]*Progress
+	now      func() time.Time
+}
+
+// NewSupervisor creates a Supervisor with the default thresholds and the full escalation ladder.
+func NewSupervisor(human string) *Supervisor {
+	return &Supervisor{
+		StuckAfter: DefaultStuckAfter,
+		MaxPolls:   DefaultMaxPolls,
+		Actions:    []SupervisorAction{ActionNudge, ActionReassign, ActionRestart, ActionAlert},
+		Human:      human,
+		Blocked:    board.ListBlocked,
+		progress:   make(map[string]*Progress),
+		now:        time.Now,
+	}
+}
+
+// Track records that agent entered phase on the ticket. Entering a new phase resets the escalation ladder.
+func (s *Supervisor) Track(ticketID, agent, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	p, ok := s.progress[ticketID]
+	if !ok || p.Phase != phase || p.Agent != agent {
+		s.progress[ticketID] = &Progress{TicketID: ticketID, Agent: agent, Phase: phase, PhaseStarted: now, LastProgress: now}
+		return
+	}
+	p.LastProgress = now
+}
+
+// Poll records one unsuccessful poll on the ticket.
+func (s *Supervisor) Poll(ticketID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.progress[ticketID]; ok {
+		p.Polls++
+	}
+}
+
+// Done stops supervising the ticket.
+func (s *Supervisor) Done(ticketID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.progress, ticketID)
+}
+
+// Snapshot returns the progress of every supervised ticket, oldest phase first.
+func (s *Supervisor) Snapshot() []Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]Progress, 0, len(s.progress))
+	for _, p := range s.progress {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PhaseStarted.Before(result[j].PhaseStarted) })
+	return result
+}
+
+// Stuck returns the tickets that exceeded StuckAfter or MaxPolls, oldest first.
+func (s *Supervisor) Stuck() []Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var stuck []Progress
+	for _, p := range s.progress {
+		if now.Sub(p.LastProgress) >= s.StuckAfter || (s.MaxPolls > 0 && p.Polls >= s.MaxPolls) {
+			stuck = append(stuck, *p)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].PhaseStarted.Before(stuck[j].PhaseStarted) })
+	return stuck
+}
+
+// Name returns the rule name.
+func (s *Supervisor) Name() string {
+	return "supervisor"
+}
+
+// Apply takes the next escalation action for every stuck ticket.
+func (s *Supervisor) Apply(b board.BoardClient) error {
+	stuck := s.Stuck()
+	if len(stuck) == 0 {
+		return nil
+	}
+	cards, err := b.GetCards()
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+	byID := make(map[string]board.Card)
+	for _, c := range cards {
+		byID[c.GetID()] = c
+	}
+
+	for _, p := range stuck {
+		card, ok := byID[p.TicketID]
+		if !ok {
+			s.Done(p.TicketID)
+			continue
+		}
+		if p.Escalations >= len(s.Actions) {
+			continue // Ladder exhausted; a human has already been alerted.
+		}
+		action := s.Actions[p.Escalations]
+		if err := s.escalate(card, p, action); err != nil {
+			fmt.Printf("Warning: supervisor action %s on %q failed: %v\n", action, card.GetName(), err)
+		}
+		s.mu.Lock()
+		if cur, ok := s.progress[p.TicketID]; ok && cur.Phase == p.Phase {
+			cur.Escalations++
+			cur.LastProgress = s.now()
+			cur.Polls = 0
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// escalate performs a single escalation action on a stuck card.
+func (s *Supervisor) escalate(card board.Card, p Progress, action SupervisorAction) error {
+	stalled := s.now().Sub(p.PhaseStarted).Round(time.Minute)
+	switch action {
+	case ActionNudge:
+		return card.WriteComment(fmt.Sprintf("Reminder: %s has been waiting in %s for %s. Any update?", p.Agent, p.Phase, stalled))
+	case ActionReassign:
+		if s.Human == "" {
+			return nil
+		}
+		if err := card.AssignTo(s.Human); err != nil {
+			return err
+		}
+		return card.WriteComment(fmt.Sprintf("Reassigned to %s: no progress in %s for %s.", s.Human, p.Phase, stalled))
+	case ActionRestart:
+		if s.Restart == nil {
+			return nil
+		}
+		if err := s.Restart(p.TicketID, p.Phase); err != nil {
+			return err
+		}
+		return card.WriteComment(fmt.Sprintf("Restarted phase %s after %s without progress.", p.Phase, stalled))
+	case ActionAlert:
+		comment := fmt.Sprintf("Attention needed: this ticket is stuck in %s (%s) for %s.", p.Phase, p.Agent, stalled)
+		if s.Human != "" {
+			comment = "@" + s.Human + " " + comment
+		}
+		if err := card.WriteComment(comment); err != nil {
+			return err
+		}
+		if s.Blocked != "" {
+			return board.TransitionTicket(card, s.Blocked, "supervisor", fmt.Sprintf("stuck in %s for %s", p.Phase, stalled))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown supervisor action %q", action)
+	}
+}
@@ -0,0 +1,171 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// guardedBoard refuses board writes the policy forbids.
+type guardedBoard struct {
+	board.BoardClient
+	engine *Engine
+	actor  string
+}
+
+// WrapBoard returns a BoardClient whose writes are checked against the engine's forbidden operations.
+func WrapBoard(b board.BoardClient, e *Engine, actor string) board.BoardClient {
+	if e == nil {
+		return b
+	}
+	return &guardedBoard{BoardClient: b, engine: e, actor: actor}
+}
+
+func (b *guardedBoard) wrapAll(cards []board.Card, err error) ([]board.Card, error) {
+	if err != nil {
+		return cards, err
+	}
+	wrapped := make([]board.Card, len(cards))
+	for i, c := range cards {
+		wrapped[i] = &guardedCard{Card: c, board: b}
+	}
+	return wrapped, nil
+}
+
+func (b *guardedBoard) GetCards() ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *guardedBoard) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *guardedBoard) GetCardsFromList(listName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *guardedBoard) CreateCard(name, description, listName string) (board.Card, error) {
+	if err := b.engine.CheckOperation(b.actor, OpCreateCard, name); err != nil {
+		return nil, err
+	}
+	c, err := b.BoardClient.CreateCard(name, description, listName)
+	if c == nil {
+		return nil, err
+	}
+	return &guardedCard{Card: c, board: b}, err
+}
+
+// guardedCard refuses card writes the policy forbids.
+type guardedCard struct {
+	board.Card
+	board *guardedBoard
+}
+
+func (c *guardedCard) check(op Operation) error {
+	return c.board.engine.CheckOperation(c.board.actor, op, c.GetName())
+}
+
+func (c *guardedCard) ChangeName(newName string) error {
+	if err := c.check(OpEditCard); err != nil {
+		return err
+	}
+	return c.Card.ChangeName(newName)
+}
+
+func (c *guardedCard) ChangeDescription(newDescription string) error {
+	if err := c.check(OpEditCard); err != nil {
+		return err
+	}
+	return c.Card.ChangeDescription(newDescription)
+}
+
+func (c *guardedCard) Move(newListName string) error {
+	if err := c.check(OpMoveCard); err != nil {
+		return err
+	}
+	return c.Card.Move(newListName)
+}
+
+func (c *guardedCard) AssignTo(userName string) error {
+	if err := c.check(OpAssign); err != nil {
+		return err
+	}
+	return c.Card.AssignTo(userName)
+}
+
+func (c *guardedCard) UnassignFrom(userName string) error {
+	if err := c.check(OpAssign); err != nil {
+		return err
+	}
+	return c.Card.UnassignFrom(userName)
+}
+
+func (c *guardedCard) WriteComment(comment string) error {
+	if err := c.check(OpComment); err != nil {
+		return err
+	}
+	return c.Card.WriteComment(comment)
+}
+
+// WriteQuestion forwards to the wrapped card's question handling; questions are comments to the policy.
+func (c *guardedCard) WriteQuestion(question string) error {
+	if err := c.check(OpComment); err != nil {
+		return err
+	}
+	return board.WriteQuestion(c.Card, question)
+}
+
+func (c *guardedCard) AddAttachment(attachment board.Attachment) error {
+	if err := c.check(OpEditCard); err != nil {
+		return err
+	}
+	return c.Card.AddAttachment(attachment)
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *guardedCard) GetMoves() ([]board.Move, error) {
+	if h, ok := c.Card.(board.CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *guardedCard) ReadAttachment(a board.Attachment) ([]byte, error) {
+	return board.ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *guardedCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(board.CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *guardedCard) SetField(name, value string) error {
+	f, ok := c.Card.(board.CardFields)
+	if !ok {
+		return board.ErrNoField
+	}
+	if err := c.check(OpEditCard); err != nil {
+		return err
+	}
+	return f.SetField(name, value)
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *guardedCard) ReadCommentsSince(since time.Time) ([]board.Comment, error) {
+	return board.ReadCommentsSince(c.Card, since)
+}
+
+func (c *guardedCard) MoveToBottom() error {
+	o, ok := c.Card.(board.CardOrdering)
+	if !ok {
+		return board.ErrNoOrdering
+	}
+	if err := c.check(OpMoveCard); err != nil {
+		return err
+	}
+	return o.MoveToBottom()
+}
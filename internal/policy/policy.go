@@ -0,0 +1,292 @@
+// Package policy enforces declarative limits on what agents may change: protected paths,
+// commit size, forbidden operations and approval for deletions and sensitive files.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/notify"
+)
+
+// Operation names an action that can be forbidden outright.
+type Operation string
+
+const (
+	OpCommit     Operation = "commit"
+	OpPush       Operation = "push"
+	OpForcePush  Operation = "force-push"
+	OpCreateCard Operation = "create-card"
+	OpMoveCard   Operation = "move-card"
+	OpEditCard   Operation = "edit-card"
+	OpAssign     Operation = "assign"
+	OpComment    Operation = "comment"
+)
+
+// Rule names reported in violations.
+const (
+	RuleProtectedPath = "protected-path"
+	RuleMaxFiles      = "max-files-per-commit"
+	RuleForbidden     = "forbidden-operation"
+	RuleDeletion      = "deletion-requires-approval"
+	RuleApproval      = "write-requires-approval"
+)
+
+// DefaultProtectedPaths are the paths agents may not write when the configuration does not list any.
+var DefaultProtectedPaths = []string{".github/workflows/**", "LICENSE", "LICENSE.*"}
+
+// DefaultApprovalPaths are the paths agents may commit only after approval when the configuration does not list any.
+var DefaultApprovalPaths = []string{"go.mod"}
+
+// ErrViolation is wrapped by every error returned for a blocked action.
+var ErrViolation = errors.New("policy violation")
+
+// PolicyViolation is recorded in the event log for every blocked action.
+const PolicyViolation events.Type = "policy_violation"
+
+// Violation describes a blocked action.
+type Violation struct {
+	Rule      string
+	Operation Operation
+	Actor     string
+	Target    string // Path, branch or card.
+	Detail    string
+}
+
+// ViolationError is returned for a blocked action; it wraps ErrViolation.
+type ViolationError struct {
+	Violation
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrViolation, e.Violation)
+}
+
+func (e *ViolationError) Unwrap() error {
+	return ErrViolation
+}
+
+func (v Violation) String() string {
+	s := fmt.Sprintf("%s blocked by %s", v.Target, v.Rule)
+	if v.Actor != "" {
+		s = v.Actor + ": " + s
+	}
+	if v.Detail != "" {
+		s += " (" + v.Detail + ")"
+	}
+	return s
+}
+
+// Rules is the declarative policy.
+type Rules struct {
+	ProtectedPaths    []string    // Glob patterns agents may not write, e.g. ".github/**".
+	MaxFilesPerCommit int         // Zero means unlimited.
+	Forbidden         []Operation // Operations refused for every agent.
+	ApproveDeletions  bool        // Deleting files needs an explicit approval.
+	ApprovalPaths     []string    // Glob patterns agents may commit only after ApproveWrite, e.g. "go.mod".
+}
+
+// Engine checks actions against the rules, logs violations and escalates them.
+// A nil Engine allows everything.
+type Engine struct {
+	Rules Rules
+	// Events, when set, receives a policy_violation event for every blocked action.
+	Events events.Recorder
+	// Notifier, when set, is told about every violation on Channel.
+	Notifier notify.Notifier
+	Channel  string
+
+	mu       sync.Mutex
+	approved map[string]bool // Deletions approved by path.
+	writes   map[string]bool // Writes to approval paths approved by path.
+}
+
+// New returns an engine enforcing rules.
+func New(rules Rules) *Engine {
+	return &Engine{Rules: rules, approved: make(map[string]bool), writes: make(map[string]bool)}
+}
+
+// FromConfig returns an engine for the configured policy. Without a policy, or when it lists no
+// protected or approval paths, DefaultProtectedPaths and DefaultApprovalPaths apply.
+func FromConfig() *Engine {
+	p := config.GetPolicy()
+	if p == nil {
+		p = &config.PolicySettings{}
+	}
+	rules := Rules{
+		ProtectedPaths:    p.ProtectedPaths,
+		MaxFilesPerCommit: p.MaxFilesPerCommit,
+		ApproveDeletions:  p.ApproveDeletions,
+		ApprovalPaths:     p.ApprovalPaths,
+	}
+	if rules.ProtectedPaths == nil {
+		rules.ProtectedPaths = DefaultProtectedPaths
+	}
+	if rules.ApprovalPaths == nil {
+		rules.ApprovalPaths = DefaultApprovalPaths
+	}
+	for _, op := range p.Forbidden {
+		rules.Forbidden = append(rules.Forbidden, Operation(strings.ToLower(strings.TrimSpace(op))))
+	}
+	return New(rules)
+}
+
+// ApproveDeletion allows the next commits to delete path, e.g. after a human confirmed it.
+func (e *Engine) ApproveDeletion(path string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.approved == nil {
+		e.approved = make(map[string]bool)
+	}
+	e.approved[path] = true
+}
+
+func (e *Engine) deletionApproved(path string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.approved[path]
+}
+
+// ApproveWrite allows agents to commit path although it matches an approval path, e.g. after a
+// human reviewed the change.
+func (e *Engine) ApproveWrite(path string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.writes == nil {
+		e.writes = make(map[string]bool)
+	}
+	e.writes[path] = true
+}
+
+// awaitingApproval returns the approval path pattern matching path when its write is not approved yet.
+func (e *Engine) awaitingApproval(path string) (string, bool) {
+	for _, pattern := range e.Rules.ApprovalPaths {
+		if gitrepo.MatchGlob(pattern, path) {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			return pattern, !e.writes[path]
+		}
+	}
+	return "", false
+}
+
+// violate logs, records and escalates a violation and returns the error for the caller.
+func (e *Engine) violate(v Violation) error {
+	fmt.Printf("Warning: policy violation: %s\n", v)
+	if e.Events != nil {
+		e.Events.Record(events.Event{
+			Agent:   v.Actor,
+			Type:    PolicyViolation,
+			Target:  v.Target,
+			Details: map[string]string{"rule": v.Rule, "operation": string(v.Operation), "detail": v.Detail},
+		})
+	}
+	if e.Notifier != nil {
+		if err := e.Notifier.Notify(e.Channel, "Policy violation: "+v.String()); err != nil {
+			fmt.Printf("Warning: failed to escalate policy violation: %v\n", err)
+		}
+	}
+	return &ViolationError{Violation: v}
+}
+
+// CheckOperation refuses operations listed as forbidden.
+func (e *Engine) CheckOperation(actor string, op Operation, target string) error {
+	if e == nil {
+		return nil
+	}
+	for _, f := range e.Rules.Forbidden {
+		if f == op {
+			return e.violate(Violation{Rule: RuleForbidden, Operation: op, Actor: actor, Target: target})
+		}
+	}
+	return nil
+}
+
+// protected returns the pattern protecting path, if any.
+func (e *Engine) protected(path string) (string, bool) {
+	for _, pattern := range e.Rules.ProtectedPaths {
+		if gitrepo.MatchGlob(pattern, path) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// CheckWrite refuses writes to protected paths. Approval paths may be written; committing them
+// needs the approval. It implements gitrepo.Guard.
+func (e *Engine) CheckWrite(path string) error {
+	if e == nil {
+		return nil
+	}
+	if pattern, ok := e.protected(path); ok {
+		return e.violate(Violation{Rule: RuleProtectedPath, Operation: OpCommit, Target: path, Detail: "matches " + pattern})
+	}
+	return nil
+}
+
+// CheckCommit validates the files a commit would change. It implements gitrepo.Guard.
+func (e *Engine) CheckCommit(author string, changed, deleted []string) error {
+	if e == nil {
+		return nil
+	}
+	if err := e.CheckOperation(author, OpCommit, "commit"); err != nil {
+		return err
+	}
+	if max := e.Rules.MaxFilesPerCommit; max > 0 && len(changed) > max {
+		return e.violate(Violation{Rule: RuleMaxFiles, Operation: OpCommit, Actor: author, Target: "commit",
+			Detail: fmt.Sprintf("%d files changed, at most %d allowed", len(changed), max)})
+	}
+	for _, path := range changed {
+		if pattern, ok := e.protected(path); ok {
+			return e.violate(Violation{Rule: RuleProtectedPath, Operation: OpCommit, Actor: author, Target: path, Detail: "matches " + pattern})
+		}
+		if pattern, ok := e.awaitingApproval(path); ok {
+			return e.violate(Violation{Rule: RuleApproval, Operation: OpCommit, Actor: author, Target: path, Detail: "matches " + pattern + ", awaiting approval"})
+		}
+	}
+	if e.Rules.ApproveDeletions {
+		for _, path := range deleted {
+			if !e.deletionApproved(path) {
+				return e.violate(Violation{Rule: RuleDeletion, Operation: OpCommit, Actor: author, Target: path, Detail: "awaiting approval"})
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPush refuses forbidden pushes. It implements gitrepo.Guard.
+func (e *Engine) CheckPush(branch string, force bool) error {
+	if e == nil {
+		return nil
+	}
+	if force {
+		if err := e.CheckOperation("", OpForcePush, branch); err != nil {
+			return err
+		}
+	}
+	return e.CheckOperation("", OpPush, branch)
+}
+
+// RegisterCommands adds the policy's chat commands to d: "!approve <path>" lets agents write a file
+// that needs approval and "!approve-deletion <path>" lets them delete one.
+func (e *Engine) RegisterCommands(d *notify.Dispatcher) {
+	d.Register("approve", func(cmd notify.Command) (string, error) {
+		if len(cmd.Args) != 1 {
+			return "", fmt.Errorf("usage: %sapprove <path>", notify.CommandPrefix)
+		}
+		e.ApproveWrite(cmd.Args[0])
+		return fmt.Sprintf("Agents may now change %s.", cmd.Args[0]), nil
+	})
+	d.Register("approve-deletion", func(cmd notify.Command) (string, error) {
+		if len(cmd.Args) != 1 {
+			return "", fmt.Errorf("usage: %sapprove-deletion <path>", notify.CommandPrefix)
+		}
+		e.ApproveDeletion(cmd.Args[0])
+		return fmt.Sprintf("Agents may now delete %s.", cmd.Args[0]), nil
+	})
+}
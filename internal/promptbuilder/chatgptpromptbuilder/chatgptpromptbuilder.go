@@ -115,7 +115,8 @@ func (b *ChatGPTPromptBuilder) Build(role, mode, state, userInput string, desire
 	chatReq := model.ChatRequest{
 		Model:       modelName,
 		Input:       []model.Message{systemMsg, developerMsg, userMsg},
-		Temperature: 0.8,
+		Temperature: temperature,
+		Class:       model.ClassifyMode(mode),
 	}
 
 	if desiredOutput != nil {
@@ -0,0 +1,207 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode selects how the Recorder treats requests.
+type Mode string
+
+const (
+	ModeOff    Mode = ""       // Requests go to the network untouched.
+	ModeRecord Mode = "record" // Requests go to the network and are saved to the cassette.
+	ModeReplay Mode = "replay" // Requests are answered from the cassette; the network is never used.
+)
+
+// secretParams are query parameters stripped before requests are stored or matched.
+var secretParams = []string{"key", "token", "api_key", "access_token"}
+
+// Interaction is one recorded HTTP request and its response.
+type Interaction struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"` // With secret query parameters removed.
+	RequestBody  string      `json:"requestBody,omitempty"`
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header,omitempty"`
+	ResponseBody string      `json:"responseBody"`
+}
+
+// key identifies requests that are interchangeable for replay.
+func (i Interaction) key() string {
+	return i.Method + " " + i.URL + "\n" + i.RequestBody
+}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette file or replays them.
+// Identical requests are replayed in the order they were recorded.
+type Recorder struct {
+	Mode      Mode
+	Path      string            // Cassette file (JSON).
+	Transport http.RoundTripper // Used in record mode; defaults to http.DefaultTransport.
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         map[string]int
+}
+
+// New creates a Recorder. In replay mode the cassette at path is loaded immediately.
+func New(mode Mode, path string) (*Recorder, error) {
+	r := &Recorder{Mode: mode, Path: path, used: make(map[string]int)}
+	if mode != ModeReplay {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// FromEnv creates a Recorder for the cassette at path using the mode in AIAGENTS_RECORD
+// ("record", "replay" or empty for off).
+func FromEnv(path string) (*Recorder, error) {
+	return New(Mode(strings.ToLower(os.Getenv("AIAGENTS_RECORD"))), path)
+}
+
+// Client returns an http.Client using the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// sanitizeURL removes secret query parameters and sorts the rest so URLs compare reliably.
+func sanitizeURL(u *url.URL) string {
+	clean := *u
+	q := clean.Query()
+	for _, p := range secretParams {
+		q.Del(p)
+	}
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	clean.RawQuery = strings.Join(parts, "&")
+	return clean.String()
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	in := Interaction{Method: req.Method, URL: sanitizeURL(req.URL), RequestBody: string(body)}
+
+	switch r.Mode {
+	case ModeReplay:
+		return r.replay(req, in)
+	case ModeRecord:
+		return r.record(req, in)
+	default:
+		return r.transport().RoundTrip(req)
+	}
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport != nil {
+		return r.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (r *Recorder) record(req *http.Request, in Interaction) (*http.Response, error) {
+	resp, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	in.Status = resp.StatusCode
+	in.Header = http.Header{}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		in.Header.Set("Content-Type", ct)
+	}
+	in.ResponseBody = string(data)
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, in Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := in.key()
+	skip := r.used[k]
+	for _, rec := range r.interactions {
+		if rec.key() != k {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		r.used[k]++
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+			StatusCode: rec.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     rec.Header.Clone(),
+			Body:       io.NopCloser(strings.NewReader(rec.ResponseBody)),
+			Request:    req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s", in.Method, in.URL)
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op outside record mode.
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
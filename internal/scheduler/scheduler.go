@@ -0,0 +1,102 @@
+// Package scheduler runs recurring jobs, such as polling boards, standup reports, branch maintenance
+// and backlog grooming, on cron schedules or fixed intervals.
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Job is a unit of recurring work run by the Scheduler.
+type Job struct {
+	Name      string
+	Schedule  Schedule
+	Run       func() error
+	Immediate bool // Also run once as soon as the scheduler starts.
+}
+
+// Scheduler runs jobs on their schedules. A job never overlaps with itself: a run that overshoots
+// its next time delays the following run rather than starting a second one.
+type Scheduler struct {
+	mu   sync.Mutex
+	jobs []Job
+}
+
+// New creates an empty Scheduler.
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// AddJob registers a job.
+func (s *Scheduler) AddJob(j Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, j)
+}
+
+// Add registers a job that runs on spec, a cron expression as understood by Parse.
+func (s *Scheduler) Add(name, spec string, run func() error) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	s.AddJob(Job{Name: name, Schedule: schedule, Run: run})
+	return nil
+}
+
+// Every registers a job that runs every interval.
+func (s *Scheduler) Every(interval time.Duration, name string, run func() error) {
+	s.AddJob(Job{Name: name, Schedule: Every(interval), Run: run})
+}
+
+// Jobs returns the registered job names in registration order.
+func (s *Scheduler) Jobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, len(s.jobs))
+	for i, j := range s.jobs {
+		names[i] = j.Name
+	}
+	return names
+}
+
+// Start runs every registered job on its own schedule until stop is closed, then returns once the
+// runs in progress have finished. Job errors are logged and do not stop the schedule.
+func (s *Scheduler) Start(stop <-chan struct{}) {
+	s.mu.Lock()
+	jobs := append([]Job(nil), s.jobs...)
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j Job) {
+			defer wg.Done()
+			if j.Immediate {
+				run(j)
+			}
+			for {
+				next := j.Schedule.Next(time.Now())
+				if next.IsZero() {
+					return
+				}
+				timer := time.NewTimer(time.Until(next))
+				select {
+				case <-stop:
+					timer.Stop()
+					return
+				case <-timer.C:
+					run(j)
+				}
+			}
+		}(j)
+	}
+	wg.Wait()
+}
+
+func run(j Job) {
+	if err := j.Run(); err != nil {
+		fmt.Printf("Warning: job %s failed: %v\n", j.Name, err)
+	}
+}
@@ -0,0 +1,346 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// List is an in-memory board list.
+type List struct {
+	name string
+}
+
+func (l *List) GetName() string { return l.name }
+func (l *List) GetID() string   { return l.name }
+
+// Card is an in-memory board card. It records its list changes so history-based agents work too.
+type Card struct {
+	board       *Board
+	id          string
+	name        string
+	description string
+	list        string
+	members     []string
+	comments    []board.Comment
+	attachments []board.Attachment
+	files       map[string][]byte // Uploaded attachment content keyed by URL.
+	moves       []board.Move
+	fields      map[string]string
+}
+
+func (c *Card) GetID() string          { return c.id }
+func (c *Card) GetName() string        { return c.name }
+func (c *Card) GetDescription() string { return c.description }
+func (c *Card) GetURL() string         { return c.board.GetURL() + "/c/" + c.id }
+
+func (c *Card) ChangeName(newName string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	c.name = newName
+	return nil
+}
+
+func (c *Card) ChangeDescription(newDescription string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	c.description = newDescription
+	return nil
+}
+
+func (c *Card) GetList() (board.List, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	return &List{name: c.list}, nil
+}
+
+func (c *Card) Move(newListName string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	if !c.board.hasList(newListName) {
+		return fmt.Errorf("list %q not found", newListName)
+	}
+	c.moves = append(c.moves, board.Move{From: c.list, To: newListName, When: time.Now()})
+	c.list = newListName
+	return nil
+}
+
+// MoveToBottom moves the card to the end of its list.
+func (c *Card) MoveToBottom() error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	cards := c.board.cards
+	for i, other := range cards {
+		if other == c {
+			c.board.cards = append(append(cards[:i:i], cards[i+1:]...), c)
+			break
+		}
+	}
+	return nil
+}
+
+func (c *Card) GetMoves() ([]board.Move, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	return append([]board.Move(nil), c.moves...), nil
+}
+
+// GetFields returns the card's custom fields.
+func (c *Card) GetFields() (map[string]string, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	fields := make(map[string]string, len(c.fields))
+	for k, v := range c.fields {
+		fields[k] = v
+	}
+	return fields, nil
+}
+
+// SetField sets one of the board's Fields; other names return board.ErrNoField.
+func (c *Card) SetField(name, value string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	name = strings.ToLower(name)
+	defined := false
+	for _, f := range c.board.Fields {
+		defined = defined || strings.EqualFold(f, name)
+	}
+	if !defined {
+		return fmt.Errorf("%w: %s", board.ErrNoField, name)
+	}
+	if c.fields == nil {
+		c.fields = make(map[string]string)
+	}
+	if value == "" {
+		delete(c.fields, name)
+	} else {
+		c.fields[name] = value
+	}
+	return nil
+}
+
+func (c *Card) GetAssignedMembers() ([]board.Member, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	var members []board.Member
+	for _, m := range c.members {
+		members = append(members, board.Member{ID: m, Name: m})
+	}
+	return members, nil
+}
+
+func (c *Card) AssignTo(userName string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	for _, m := range c.members {
+		if strings.EqualFold(m, userName) {
+			return nil
+		}
+	}
+	c.members = append(c.members, userName)
+	return nil
+}
+
+func (c *Card) UnassignFrom(userName string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	var kept []string
+	for _, m := range c.members {
+		if !strings.EqualFold(m, userName) {
+			kept = append(kept, m)
+		}
+	}
+	c.members = kept
+	return nil
+}
+
+func (c *Card) ReadComments() ([]board.Comment, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	return append([]board.Comment(nil), c.comments...), nil
+}
+
+func (c *Card) WriteComment(comment string) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	c.comments = append(c.comments, board.Comment{Text: comment, Created: time.Now()})
+	return nil
+}
+
+// Reply adds a comment from a named member, e.g. a scripted human answering an agent's question.
+func (c *Card) Reply(member, comment string) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	c.comments = append(c.comments, board.Comment{Text: comment, Member: &board.Member{ID: member, Name: member}, Created: time.Now()})
+}
+
+func (c *Card) GetAttachments() ([]board.Attachment, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	return append([]board.Attachment(nil), c.attachments...), nil
+}
+
+func (c *Card) AddAttachment(attachment board.Attachment) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	c.attachments = append(c.attachments, attachment)
+	return nil
+}
+
+// Upload attaches a file hosted on the board, which only the card itself can download.
+func (c *Card) Upload(name string, data []byte) error {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	url := fmt.Sprintf("%s/c/%s/attachments/%s", c.board.GetURL(), c.id, name)
+	if c.files == nil {
+		c.files = make(map[string][]byte)
+	}
+	c.files[url] = append([]byte(nil), data...)
+	c.attachments = append(c.attachments, board.Attachment{ID: name, Name: name, URL: url})
+	return nil
+}
+
+// ReadAttachment returns the content of an uploaded file; links to elsewhere are not downloadable.
+func (c *Card) ReadAttachment(a board.Attachment) ([]byte, error) {
+	c.board.mu.Lock()
+	defer c.board.mu.Unlock()
+	data, ok := c.files[a.URL]
+	if !ok {
+		return nil, board.ErrNotDownloadable
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Board is an in-memory BoardClient. It is safe for concurrent use by several agents.
+type Board struct {
+	Name    string
+	Members []string
+	Fields  []string // Custom fields the board defines; none by default.
+
+	mu    sync.Mutex
+	lists []string
+	cards []*Card
+}
+
+// NewBoard creates a board with the given lists, or the standard workflow lists when none are given.
+func NewBoard(lists ...string) *Board {
+	if len(lists) == 0 {
+		lists = []string{
+			board.ListInbox, board.ListBacklog, board.ListSprint, board.ListDoing,
+			board.ListReview, board.ListQA, board.ListDone, board.ListBlocked, board.ListRetro,
+			board.ListRelease, board.ListBugs,
+		}
+	}
+	return &Board{Name: "sim", lists: lists}
+}
+
+func (b *Board) GetName() string { return b.Name }
+func (b *Board) GetURL() string  { return "https://sim.local/b/" + b.Name }
+
+func (b *Board) hasList(name string) bool {
+	for _, l := range b.lists {
+		if strings.EqualFold(l, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Board) GetMembers() ([]board.Member, error) {
+	var members []board.Member
+	for _, m := range b.Members {
+		members = append(members, board.Member{ID: m, Name: m})
+	}
+	return members, nil
+}
+
+func (b *Board) GetCards() ([]board.Card, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []board.Card
+	for _, c := range b.cards {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (b *Board) CreateCard(name, description, listName string) (board.Card, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.hasList(listName) {
+		return nil, fmt.Errorf("list %q not found", listName)
+	}
+	c := &Card{
+		board:       b,
+		id:          fmt.Sprintf("card%d", len(b.cards)+1),
+		name:        name,
+		description: description,
+		list:        listName,
+		moves:       []board.Move{{To: listName, When: time.Now()}},
+	}
+	b.cards = append(b.cards, c)
+	return c, nil
+}
+
+func (b *Board) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []board.Card
+	for _, c := range b.cards {
+		for _, m := range c.members {
+			if strings.EqualFold(m, userName) {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (b *Board) GetCardsFromList(listName string) ([]board.Card, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []board.Card
+	for _, c := range b.cards {
+		if strings.EqualFold(c.list, listName) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (b *Board) GetLists() ([]board.List, error) {
+	var result []board.List
+	for _, l := range b.lists {
+		result = append(result, &List{name: l})
+	}
+	return result, nil
+}
+
+// Card returns the first card with the given name, or nil.
+func (b *Board) Card(name string) *Card {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, c := range b.cards {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// CardNames returns the names of the cards in a list, for assertions.
+func (b *Board) CardNames(listName string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var names []string
+	for _, c := range b.cards {
+		if strings.EqualFold(c.list, listName) {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
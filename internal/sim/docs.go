@@ -0,0 +1,118 @@
+package sim
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// Docs is an in-memory DocumentationClient.
+type Docs struct {
+	mu    sync.Mutex
+	pages map[string]*docs.Page
+	next  int
+}
+
+// NewDocs creates an empty documentation space.
+func NewDocs() *Docs {
+	return &Docs{pages: make(map[string]*docs.Page)}
+}
+
+func (d *Docs) CreatePage(title string, content string, parentPageID string) (docs.Page, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if parentPageID != "" && d.pages[parentPageID] == nil {
+		return docs.Page{}, fmt.Errorf("parent page %s not found", parentPageID)
+	}
+	d.next++
+	p := &docs.Page{
+		ID:       fmt.Sprintf("page%d", d.next),
+		Title:    title,
+		Content:  content,
+		ParentID: parentPageID,
+	}
+	p.URL = "https://sim.local/docs/" + p.ID
+	d.pages[p.ID] = p
+	return *p, nil
+}
+
+func (d *Docs) UpdatePage(pageID string, content string, replace bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := d.pages[pageID]
+	if p == nil {
+		return fmt.Errorf("page %s not found", pageID)
+	}
+	if replace || p.Content == "" {
+		p.Content = content
+	} else {
+		p.Content += "\n" + content
+	}
+	return nil
+}
+
+func (d *Docs) ReadPage(pageID string) (docs.Page, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := d.pages[pageID]
+	if p == nil {
+		return docs.Page{}, fmt.Errorf("page %s not found", pageID)
+	}
+	return *p, nil
+}
+
+// sorted returns the pages matching keep, ordered by ID.
+func (d *Docs) sorted(keep func(*docs.Page) bool) []docs.Page {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var result []docs.Page
+	for _, p := range d.pages {
+		if keep(p) {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (d *Docs) SearchPages(query string) ([]docs.Page, error) {
+	q := strings.ToLower(query)
+	return d.sorted(func(p *docs.Page) bool {
+		return strings.Contains(strings.ToLower(p.Title), q) || strings.Contains(strings.ToLower(p.Content), q)
+	}), nil
+}
+
+func (d *Docs) ListPages() ([]docs.Page, error) {
+	return d.sorted(func(*docs.Page) bool { return true }), nil
+}
+
+func (d *Docs) ListSubPages(parentPageID string) ([]docs.Page, error) {
+	return d.sorted(func(p *docs.Page) bool { return p.ParentID == parentPageID }), nil
+}
+
+func (d *Docs) DeletePage(pageID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pages[pageID] == nil {
+		return fmt.Errorf("page %s not found", pageID)
+	}
+	delete(d.pages, pageID)
+	return nil
+}
+
+func (d *Docs) PrintTree() (string, error) {
+	var sb strings.Builder
+	var walk func(parent, indent string)
+	walk = func(parent, indent string) {
+		children, _ := d.ListSubPages(parent)
+		for _, p := range children {
+			sb.WriteString(indent + p.Title + "\n")
+			walk(p.ID, indent+"  ")
+		}
+	}
+	walk("", "")
+	return sb.String(), nil
+}
@@ -0,0 +1,129 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// Rule answers model requests whose text contains every string in Match.
+type Rule struct {
+	Match    []string
+	Response string // JSON for parsed calls, plain text for Chat.
+	Times    int    // Number of uses before the rule is exhausted; 0 means unlimited.
+
+	used int
+}
+
+// ScriptedModel is a ModelClient that answers from a list of rules instead of calling an API.
+// Rules are tried in order; requests nothing matches get an empty response, which leaves
+// parsed targets at their zero value.
+type ScriptedModel struct {
+	mu          sync.Mutex
+	rules       []*Rule
+	calls       []model.ChatRequest
+	modelName   string
+	temperature float64
+}
+
+// NewScriptedModel creates a ScriptedModel without rules.
+func NewScriptedModel() *ScriptedModel {
+	return &ScriptedModel{modelName: "scripted"}
+}
+
+// On adds a rule answering requests that contain all of match with response.
+func (m *ScriptedModel) On(response string, match ...string) *Rule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := &Rule{Match: match, Response: response}
+	m.rules = append(m.rules, r)
+	return r
+}
+
+// OnMode adds a rule for requests built with the given prompt mode (see PromptBuilder).
+// The response is marshalled to JSON.
+func (m *ScriptedModel) OnMode(mode string, response interface{}, match ...string) (*Rule, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scripted response: %w", err)
+	}
+	return m.On(string(data), append([]string{modeMarker(mode)}, match...)...), nil
+}
+
+// Calls returns the requests received so far.
+func (m *ScriptedModel) Calls() []model.ChatRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]model.ChatRequest(nil), m.calls...)
+}
+
+// requestText flattens the request messages for matching.
+func requestText(req model.ChatRequest) string {
+	var sb strings.Builder
+	for _, msg := range req.Input {
+		sb.WriteString(fmt.Sprint(msg.Content))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func (m *ScriptedModel) respond(req model.ChatRequest) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, req)
+	text := requestText(req)
+	for _, r := range m.rules {
+		if r.Times > 0 && r.used >= r.Times {
+			continue
+		}
+		matched := true
+		for _, s := range r.Match {
+			if !strings.Contains(text, s) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			r.used++
+			return r.Response
+		}
+	}
+	return ""
+}
+
+func (m *ScriptedModel) Chat(prompt string) (string, error) {
+	return m.respond(model.ChatRequest{Input: []model.Message{{Role: "user", Content: prompt}}}), nil
+}
+
+func (m *ScriptedModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	return m.respond(req), nil
+}
+
+func (m *ScriptedModel) ChatAdvancedParsed(req model.ChatRequest, target interface{}) error {
+	resp := m.respond(req)
+	if resp == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(resp), target); err != nil {
+		return fmt.Errorf("failed to parse scripted response: %w", err)
+	}
+	return nil
+}
+
+func (m *ScriptedModel) SetModel(name string)        { m.modelName = name }
+func (m *ScriptedModel) SetTemperature(temp float64) { m.temperature = temp }
+func (m *ScriptedModel) GetModel() string            { return m.modelName }
+func (m *ScriptedModel) GetTemperature() float64     { return m.temperature }
+
+func (m *ScriptedModel) UploadFile(filePath string, purpose string) (model.File, error) {
+	return model.File{}, fmt.Errorf("file uploads are not supported in simulation")
+}
+
+func (m *ScriptedModel) GetFile(fileID string) (model.File, error) {
+	return model.File{}, fmt.Errorf("file %s not found", fileID)
+}
+
+func (m *ScriptedModel) DeleteAllFiles() error { return nil }
@@ -0,0 +1,52 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// modeMarker is the line PromptBuilder writes for a prompt mode; ScriptedModel.OnMode matches on it.
+func modeMarker(mode string) string {
+	return "Mode: " + mode + "\n"
+}
+
+// PromptBuilder builds plain requests that name the role and mode without reading the prompt configuration,
+// so scenarios run without a config file. Use the ChatGPT prompt builder instead when driving a real model.
+type PromptBuilder struct{}
+
+func (PromptBuilder) Build(role, mode, state, userInput string, desiredOutput interface{}, temperature float64, modelName string) (model.ChatRequest, error) {
+	system := fmt.Sprintf("Role: %s\n%sContext:\n%s", role, modeMarker(mode), state)
+	return model.ChatRequest{
+		Model:       modelName,
+		Temperature: temperature,
+		Class:       model.ClassifyMode(mode),
+		Input: []model.Message{
+			{Role: "system", Content: system},
+			{Role: "user", Content: userInput},
+		},
+	}, nil
+}
+
+func (PromptBuilder) AddFile(chatReq *model.ChatRequest, vectorStoreIDs []string) error { return nil }
+
+func (PromptBuilder) AddWeb(chatReq *model.ChatRequest, webTool model.WebSearch) error { return nil }
+
+// hashEmbedding is an offline EmbeddingProvider that hashes words into a fixed-size vector.
+type hashEmbedding struct{ dim int }
+
+func (h hashEmbedding) ComputeEmbedding(text string) ([]float64, error) {
+	vec := make([]float64, h.dim)
+	word := uint32(2166136261)
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || text[i] == ' ' || text[i] == '\n' {
+			if word != 2166136261 {
+				vec[word%uint32(h.dim)]++
+			}
+			word = 2166136261
+			continue
+		}
+		word = (word ^ uint32(text[i]|0x20)) * 16777619
+	}
+	return vec, nil
+}
@@ -0,0 +1,237 @@
+package sim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/memory"
+	"github.com/egobogo/aiagents/internal/model"
+	pb "github.com/egobogo/aiagents/internal/promptbuilder"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// embeddingDim is the vector size used by the offline context storage.
+const embeddingDim = 64
+
+// Step is one stage of a scenario, e.g. a single agent acting.
+type Step struct {
+	Name string
+	Run  func(s *Scenario) error
+}
+
+// Scenario wires agents to an in-memory board and documentation space, a temporary git repository and a model,
+// then runs them in a fixed order so tests can assert on the final board and repository state.
+type Scenario struct {
+	Board         *Board
+	Docs          *Docs
+	Repo          *gitrepo.GitClient
+	Model         model.ModelClient
+	PromptBuilder pb.PromptBuilder
+	Checkpoints   checkpoint.Store // Optional; shared by every agent created afterwards.
+	Knowledge     *knowledge.Base  // Optional; shared by every agent created afterwards.
+	LongTerm      *memory.Journal  // Optional; shared by every agent created afterwards.
+	Steps         []Step
+
+	dir string
+}
+
+// New creates a scenario with a fresh board and repository. A nil model uses a ScriptedModel without rules;
+// pass a real client (together with a real PromptBuilder) to run against an API.
+func New(m model.ModelClient) (*Scenario, error) {
+	if m == nil {
+		m = NewScriptedModel()
+	}
+	dir, err := os.MkdirTemp("", "aiagents-sim-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scenario directory: %w", err)
+	}
+	repoPath := filepath.Join(dir, "repo")
+	if _, err := git.PlainInit(repoPath, false); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to init repository: %w", err)
+	}
+	repo, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := repo.WriteFile("README.md", []byte("# Simulation\n")); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := repo.CommitChanges("chore: initial commit", "sim", "sim@aiagents.local"); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Scenario{
+		Board:         NewBoard(),
+		Docs:          NewDocs(),
+		Repo:          repo,
+		Model:         m,
+		PromptBuilder: PromptBuilder{},
+		dir:           dir,
+	}, nil
+}
+
+// Close removes the scenario's repository and worktrees.
+func (s *Scenario) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// Agent creates an agent for role from the default registry, sharing the scenario's board, repository and model.
+func (s *Scenario) Agent(role, name string) (agent.Agent, error) {
+	searcher, err := hnsw.New(embeddingDim)
+	if err != nil {
+		return nil, err
+	}
+	s.Board.Members = append(s.Board.Members, name)
+	return agent.DefaultRegistry.New(role, &agent.BaseAgent{
+		Name:          name,
+		Role:          role,
+		ModelClient:   s.Model,
+		BoardClient:   s.Board,
+		DocsClient:    s.Docs,
+		GitClient:     s.Repo,
+		Context:       inmemory.NewInMemoryContextStorage(hashEmbedding{dim: embeddingDim}, searcher),
+		PromptBuilder: s.PromptBuilder,
+		Checkpoints:   s.Checkpoints,
+		Knowledge:     s.Knowledge,
+		LongTerm:      s.LongTerm,
+	})
+}
+
+// Add appends a step to the scenario.
+func (s *Scenario) Add(name string, run func(s *Scenario) error) {
+	s.Steps = append(s.Steps, Step{Name: name, Run: run})
+}
+
+// AddAgent creates an agent for role and appends a step running its Act.
+func (s *Scenario) AddAgent(role, name string) (agent.Agent, error) {
+	a, err := s.Agent(role, name)
+	if err != nil {
+		return nil, err
+	}
+	s.Add(name, func(*Scenario) error { return a.Act() })
+	return a, nil
+}
+
+// AssignList appends a step assigning every unassigned card in list to member, standing in for a human handing work over.
+func (s *Scenario) AssignList(list, member string) {
+	s.Add("assign "+list+" to "+member, func(s *Scenario) error {
+		cards, err := s.Board.GetCardsFromList(list)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			if members, err := c.GetAssignedMembers(); err != nil || len(members) > 0 {
+				continue
+			}
+			if err := c.AssignTo(member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MoveList appends a step moving every card in from to to, standing in for a human planning the sprint.
+func (s *Scenario) MoveList(from, to string) {
+	s.Add("move "+from+" to "+to, func(s *Scenario) error {
+		cards, err := s.Board.GetCardsFromList(from)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			if err := c.Move(to); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddQA appends a step standing in for QA: each card assigned to member that is not done is checked on its
+// ticket branch. Cards with new commits that build and vet cleanly move to Done; failing ones go back to Doing.
+func (s *Scenario) AddQA(member string) {
+	s.Add("QA", func(s *Scenario) error {
+		base, err := s.Repo.Log("", 1)
+		if err != nil {
+			return err
+		}
+		cards, err := s.Board.GetCardsAssignedTo(member)
+		if err != nil {
+			return err
+		}
+		for _, c := range cards {
+			if list, err := c.GetList(); err != nil || list.GetName() == board.ListDone {
+				continue
+			}
+			wt, err := s.Repo.WorktreeFor(c.GetID())
+			if err != nil {
+				return err
+			}
+			head, err := wt.Log("", 1)
+			if err != nil {
+				return err
+			}
+			if len(head) == 0 || (len(base) > 0 && head[0].Hash == base[0].Hash) {
+				c.WriteComment("QA: nothing has been committed for this ticket yet.")
+				continue
+			}
+			if err := codegen.VerifyGo(wt.RepoPath); err != nil {
+				c.WriteComment("QA failed:\n" + err.Error())
+				if err := c.Move(board.ListDoing); err != nil {
+					return err
+				}
+				continue
+			}
+			c.WriteComment("QA passed.")
+			if err := c.Move(board.ListDone); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Run executes the steps in order for the given number of rounds, stopping at the first error.
+func (s *Scenario) Run(rounds int) error {
+	if rounds <= 0 {
+		rounds = 1
+	}
+	for r := 1; r <= rounds; r++ {
+		for _, step := range s.Steps {
+			if err := step.Run(s); err != nil {
+				return fmt.Errorf("round %d, step %q: %w", r, step.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RepoFile returns the content of a file on a ticket's branch, or on the main checkout when ticketID is empty.
+func (s *Scenario) RepoFile(ticketID, path string) (string, error) {
+	repo := s.Repo
+	if ticketID != "" {
+		wt, err := s.Repo.WorktreeFor(ticketID)
+		if err != nil {
+			return "", err
+		}
+		repo = wt
+	}
+	data, err := repo.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
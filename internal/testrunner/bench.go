@@ -0,0 +1,263 @@
+package testrunner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultBenchCount is how often each benchmark runs; enough samples for a meaningful significance test.
+const DefaultBenchCount = 6
+
+// benchLine matches a result line, e.g. "BenchmarkPut/small-8   1000   1234 ns/op   56 B/op".
+var benchLine = regexp.MustCompile(`^(Benchmark\S*?)(?:-\d+)?\s+\d+\s+(.+)$`)
+
+// BenchOptions configures a benchmark run.
+type BenchOptions struct {
+	Count     int    // Runs per benchmark; zero uses DefaultBenchCount.
+	BenchTime string // Passed as -benchtime, e.g. "2s" or "100x"; empty uses the go test default.
+}
+
+// BenchResults holds the samples of each benchmark by name ("import/path.BenchmarkName") and unit.
+type BenchResults map[string]map[string][]float64
+
+// ParseBench reads "go test -bench" output.
+func ParseBench(r io.Reader) (BenchResults, error) {
+	results := make(BenchResults)
+	pkg := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if p, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = p
+			continue
+		}
+		m := benchLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := m[1]
+		if pkg != "" {
+			name = pkg + "." + name
+		}
+		fields := strings.Fields(m[2])
+		for i := 0; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+			if results[name] == nil {
+				results[name] = make(map[string][]float64)
+			}
+			results[name][fields[i+1]] = append(results[name][fields[i+1]], v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read benchmark output: %w", err)
+	}
+	return results, nil
+}
+
+// Bench runs the benchmarks of packages (patterns such as "./store") in dir with -benchmem and no
+// tests. Patterns whose directory does not exist are skipped; no packages, or a directory without a
+// go.mod, yield no results. A run that fails, e.g. because the code does not build, is an error.
+func Bench(dir string, packages []string, opts BenchOptions) (BenchResults, error) {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return BenchResults{}, nil
+	}
+	var existing []string
+	for _, p := range packages {
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p))); err == nil && info.IsDir() {
+			existing = append(existing, p)
+		}
+	}
+	if len(existing) == 0 {
+		return BenchResults{}, nil
+	}
+	count := opts.Count
+	if count <= 0 {
+		count = DefaultBenchCount
+	}
+	args := []string{"test", "-run", "^$", "-bench", ".", "-benchmem", "-count", strconv.Itoa(count)}
+	if opts.BenchTime != "" {
+		args = append(args, "-benchtime", opts.BenchTime)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", append(args, existing...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run benchmarks: %w: %s", err, tail(strings.TrimSpace(string(out)), maxOutputLines))
+	}
+	return ParseBench(strings.NewReader(string(out)))
+}
+
+// BenchSummary condenses the samples of one benchmark unit.
+type BenchSummary struct {
+	Median float64
+	Spread float64 // Largest deviation of a sample from the median, in percent.
+	N      int
+}
+
+func summarize(samples []float64) BenchSummary {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	spread := 0.0
+	if median != 0 {
+		spread = math.Max(median-sorted[0], sorted[n-1]-median) / median * 100
+	}
+	return BenchSummary{Median: median, Spread: spread, N: n}
+}
+
+// BenchDelta compares one unit of a benchmark before and after a change.
+type BenchDelta struct {
+	Name          string
+	Unit          string // e.g. "ns/op", "B/op" or "allocs/op".
+	Before, After BenchSummary
+	Change        float64 // Change of the median in percent; positive means slower or bigger.
+	P             float64 // p-value of a Mann-Whitney U test; small values mean the change is not noise.
+}
+
+// Significant reports whether the change is unlikely to be noise at significance level alpha.
+func (d BenchDelta) Significant(alpha float64) bool {
+	return d.P < alpha
+}
+
+// unitOrder sorts the usual units first.
+var unitOrder = map[string]int{"ns/op": 0, "B/op": 1, "allocs/op": 2}
+
+// CompareBench compares the benchmarks present in both runs, like benchstat: by median, with a
+// Mann-Whitney U test telling real changes from noise. Deltas are ordered by unit, then name.
+func CompareBench(before, after BenchResults) []BenchDelta {
+	var deltas []BenchDelta
+	for name, units := range after {
+		for unit, samples := range units {
+			old := before[name][unit]
+			if len(old) == 0 || len(samples) == 0 {
+				continue
+			}
+			d := BenchDelta{Name: name, Unit: unit, Before: summarize(old), After: summarize(samples), P: mannWhitney(old, samples)}
+			if d.Before.Median != 0 {
+				d.Change = (d.After.Median - d.Before.Median) / d.Before.Median * 100
+			}
+			deltas = append(deltas, d)
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		oi, iok := unitOrder[deltas[i].Unit]
+		oj, jok := unitOrder[deltas[j].Unit]
+		if !iok {
+			oi = len(unitOrder)
+		}
+		if !jok {
+			oj = len(unitOrder)
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		if deltas[i].Unit != deltas[j].Unit {
+			return deltas[i].Unit < deltas[j].Unit
+		}
+		return deltas[i].Name < deltas[j].Name
+	})
+	return deltas
+}
+
+// mannWhitney returns the two-sided p-value of the Mann-Whitney U test for x and y, using the exact
+// distribution of U; ties count half.
+func mannWhitney(x, y []float64) float64 {
+	u := 0.0
+	for _, a := range x {
+		for _, b := range y {
+			switch {
+			case a > b:
+				u++
+			case a == b:
+				u += 0.5
+			}
+		}
+	}
+	counts := uDistribution(len(x), len(y))
+	total, lo, hi := 0.0, 0.0, 0.0
+	for k, c := range counts {
+		total += c
+		if float64(k) <= u {
+			lo += c
+		}
+		if float64(k) >= u {
+			hi += c
+		}
+	}
+	return math.Min(1, 2*math.Min(lo, hi)/total)
+}
+
+// uDistribution returns, for each value k of the U statistic of samples of size n1 and n2, the
+// number of orderings of the samples yielding k.
+func uDistribution(n1, n2 int) []float64 {
+	// prev[j] and cur[j] hold the distribution for (i-1, j) and (i, j).
+	prev := make([][]float64, n2+1)
+	for j := range prev {
+		prev[j] = []float64{1}
+	}
+	for i := 1; i <= n1; i++ {
+		cur := make([][]float64, n2+1)
+		cur[0] = []float64{1}
+		for j := 1; j <= n2; j++ {
+			dist := make([]float64, i*j+1)
+			// The largest value comes from x, adding j to U, or from y, adding nothing.
+			for k, c := range prev[j] {
+				dist[k+j] += c
+			}
+			for k, c := range cur[j-1] {
+				dist[k] += c
+			}
+			cur[j] = dist
+		}
+		prev = cur
+	}
+	return prev[n2]
+}
+
+// FormatBench renders deltas as a benchstat-style table, one section per unit. Changes that are
+// not significant at alpha are shown as "~".
+func FormatBench(deltas []BenchDelta, alpha float64) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	unit := ""
+	for _, d := range deltas {
+		if d.Unit != unit {
+			if unit != "" {
+				fmt.Fprintln(w)
+			}
+			unit = d.Unit
+			fmt.Fprintf(w, "name\told %s\tnew %s\tdelta\n", unit, unit)
+		}
+		delta := "~"
+		if d.Significant(alpha) {
+			delta = fmt.Sprintf("%+.2f%%", d.Change)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s (p=%.3f n=%d+%d)\n", d.Name, formatSummary(d.Before), formatSummary(d.After), delta, d.P, d.Before.N, d.After.N)
+	}
+	w.Flush()
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func formatSummary(s BenchSummary) string {
+	return fmt.Sprintf("%.4g ± %.0f%%", s.Median, s.Spread)
+}
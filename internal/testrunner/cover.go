@@ -0,0 +1,105 @@
+package testrunner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FuncCoverage is the statement coverage of a single function.
+type FuncCoverage struct {
+	File     string // Module-relative path.
+	Line     int
+	Function string
+	Percent  float64
+}
+
+// CoverageReport is the outcome of a coverage run.
+type CoverageReport struct {
+	Packages  []string // Measured package patterns, e.g. "./store".
+	Total     float64  // Statement coverage of all measured packages, in percent.
+	Functions []FuncCoverage
+}
+
+// Below returns the functions covered less than min percent, least covered first.
+func (r *CoverageReport) Below(min float64) []FuncCoverage {
+	var funcs []FuncCoverage
+	for _, f := range r.Functions {
+		if f.Percent < min {
+			funcs = append(funcs, f)
+		}
+	}
+	sort.SliceStable(funcs, func(i, j int) bool { return funcs[i].Percent < funcs[j].Percent })
+	return funcs
+}
+
+// Cover runs "go test -cover" for packages in dir and reports per-function coverage. Patterns
+// whose directory no longer exists are skipped; no packages, or a directory without a go.mod,
+// yield an empty report with full coverage.
+func Cover(dir string, packages ...string) (*CoverageReport, error) {
+	report := &CoverageReport{Total: 100}
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return report, nil
+	}
+	for _, p := range packages {
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(p))); err == nil && info.IsDir() {
+			report.Packages = append(report.Packages, p)
+		}
+	}
+	if len(report.Packages) == 0 {
+		return report, nil
+	}
+	module, err := modulePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := os.CreateTemp("", "cover-*.out")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coverage profile: %w", err)
+	}
+	profile.Close()
+	defer os.Remove(profile.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test", "-cover", "-coverprofile=" + profile.Name()}, report.Packages...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to run go test -cover: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	cmd = exec.CommandContext(ctx, "go", "tool", "cover", "-func="+profile.Name())
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coverage profile: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		// "example.com/m/store/db.go:12:	Open		75.0%" or "total:	(statements)	80.0%".
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "%"), 64)
+		if err != nil {
+			continue
+		}
+		if fields[0] == "total:" {
+			report.Total = percent
+			continue
+		}
+		file, lineNo, ok := strings.Cut(strings.TrimSuffix(fields[0], ":"), ":")
+		if !ok {
+			continue
+		}
+		n, _ := strconv.Atoi(lineNo)
+		rel, _ := strings.CutPrefix(file, module+"/")
+		report.Functions = append(report.Functions, FuncCoverage{File: path.Clean(rel), Line: n, Function: fields[1], Percent: percent})
+	}
+	return report, nil
+}
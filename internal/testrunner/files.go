@@ -0,0 +1,96 @@
+package testrunner
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// fileRef matches "name.go:12" references in test output.
+var fileRef = regexp.MustCompile(`([A-Za-z0-9_.-]+\.go):\d+`)
+
+// modulePath returns the module path declared in dir's go.mod.
+func modulePath(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("go.mod in %s declares no module", dir)
+}
+
+// RelevantFiles returns the module-relative paths of the files behind the failures: the test file
+// declaring each failing test, the files named in the output, and the source file paired with each
+// of those test files ("store.go" for "store_test.go"). Packages failing without a test contribute
+// the files named in their output, or all their non-test files when none are named.
+func RelevantFiles(dir string, failures []Failure) ([]string, error) {
+	module, err := modulePath(dir)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool)
+	for _, f := range failures {
+		rel, ok := strings.CutPrefix(f.Package, module)
+		if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+			continue // Outside the module.
+		}
+		rel = strings.TrimPrefix(rel, "/")
+		pkgDir := filepath.Join(dir, filepath.FromSlash(rel))
+		entries, err := os.ReadDir(pkgDir)
+		if err != nil {
+			continue
+		}
+		var goFiles []string
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), ".go") {
+				goFiles = append(goFiles, e.Name())
+			}
+		}
+		found := make(map[string]bool)
+		for _, m := range fileRef.FindAllStringSubmatch(f.Output, -1) {
+			found[m[1]] = true
+		}
+		if f.Test != "" {
+			declaration := "func " + f.Test + "("
+			for _, name := range goFiles {
+				if !strings.HasSuffix(name, "_test.go") {
+					continue
+				}
+				if data, err := os.ReadFile(filepath.Join(pkgDir, name)); err == nil && strings.Contains(string(data), declaration) {
+					found[name] = true
+				}
+			}
+		}
+		for name := range found {
+			if stem, ok := strings.CutSuffix(name, "_test.go"); ok {
+				found[stem+".go"] = true
+			}
+		}
+		if f.Test == "" && len(found) == 0 {
+			for _, name := range goFiles {
+				if !strings.HasSuffix(name, "_test.go") {
+					found[name] = true
+				}
+			}
+		}
+		for _, name := range goFiles {
+			if found[name] {
+				set[path.Join(rel, name)] = true
+			}
+		}
+	}
+	files := make([]string, 0, len(set))
+	for f := range set {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,189 @@
+// Package testrunner runs Go tests and turns their failures into feedback for the model.
+package testrunner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a single "go test" run.
+const DefaultTimeout = 10 * time.Minute
+
+// maxOutputLines bounds the output kept per failure.
+const maxOutputLines = 60
+
+// event is one line of "go test -json" output.
+type event struct {
+	Action     string `json:"Action"`
+	Package    string `json:"Package"`
+	ImportPath string `json:"ImportPath"` // Set on "build-output" and "build-fail" events.
+	Test       string `json:"Test"`
+	Output     string `json:"Output"`
+}
+
+// Failure is a failed test, or a package that failed without a failing test, e.g. because it does not compile.
+type Failure struct {
+	Package string // Import path.
+	Test    string // Empty for package-level failures.
+	Output  string // Output of the test or package, trimmed to its last lines.
+}
+
+// Name returns "pkg.TestName", or the package alone for package-level failures.
+func (f Failure) Name() string {
+	if f.Test == "" {
+		return f.Package
+	}
+	return f.Package + "." + f.Test
+}
+
+// Report is the outcome of a test run.
+type Report struct {
+	Passed   int
+	Failures []Failure
+}
+
+// Failed reports whether any test or package failed.
+func (r *Report) Failed() bool {
+	return len(r.Failures) > 0
+}
+
+// FailedPackages returns the import paths of the failed packages in order.
+func (r *Report) FailedPackages() []string {
+	seen := make(map[string]bool)
+	var pkgs []string
+	for _, f := range r.Failures {
+		if !seen[f.Package] {
+			seen[f.Package] = true
+			pkgs = append(pkgs, f.Package)
+		}
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// String lists the failures with their output.
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, f := range r.Failures {
+		sb.WriteString(fmt.Sprintf("--- FAIL: %s\n%s\n\n", f.Name(), f.Output))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Parse reads "go test -json" output. Subtests are folded into their top-level test, and a
+// package only counts as a failure of its own when none of its tests failed.
+func Parse(r io.Reader) (*Report, error) {
+	type key struct{ pkg, test string }
+	output := make(map[key]*strings.Builder)
+	write := func(k key, s string) {
+		b, ok := output[k]
+		if !ok {
+			b = &strings.Builder{}
+			output[k] = b
+		}
+		b.WriteString(s)
+	}
+	report := &Report{}
+	failedTests := make(map[string]bool)
+	var failed []key
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var e event
+		if err := json.Unmarshal(line, &e); err != nil {
+			// Build errors of older toolchains are printed as plain text.
+			write(key{}, string(line)+"\n")
+			continue
+		}
+		pkg := e.Package
+		if pkg == "" {
+			// e.g. "example.com/m/store [example.com/m/store.test]".
+			pkg, _, _ = strings.Cut(e.ImportPath, " ")
+		}
+		test, _, _ := strings.Cut(e.Test, "/")
+		switch e.Action {
+		case "output", "build-output":
+			write(key{pkg, test}, e.Output)
+		case "pass":
+			if e.Test != "" && test == e.Test {
+				report.Passed++
+			}
+		case "fail":
+			switch {
+			case e.Test == "":
+				failed = append(failed, key{pkg, ""})
+			case test == e.Test:
+				failedTests[pkg] = true
+				failed = append(failed, key{pkg, test})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read test output: %w", err)
+	}
+	for _, k := range failed {
+		if k.test == "" && failedTests[k.pkg] {
+			continue
+		}
+		var out string
+		if b, ok := output[k]; ok {
+			out = b.String()
+		}
+		if k.test == "" {
+			if b, ok := output[key{}]; ok {
+				out = b.String() + out
+			}
+		}
+		report.Failures = append(report.Failures, Failure{Package: k.pkg, Test: k.test, Output: tail(out, maxOutputLines)})
+	}
+	return report, nil
+}
+
+// tail returns the last n lines of s.
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Run runs "go test -json" for packages (import paths or patterns; "./..." when none) in dir and
+// parses the result. Failing tests are reported in the Report, not as an error. Directories
+// without a go.mod are not Go modules and yield an empty report.
+func Run(dir string, packages ...string) (*Report, error) {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return &Report{}, nil
+	}
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test", "-json"}, packages...)...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	report, parseErr := Parse(io.MultiReader(strings.NewReader(string(out)), strings.NewReader(stderr.String())))
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if err != nil && !report.Failed() {
+		return nil, fmt.Errorf("failed to run go test: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return report, nil
+}
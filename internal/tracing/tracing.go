@@ -0,0 +1,185 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Common span attribute keys.
+const (
+	AttrAgent    = "agent.name"
+	AttrTicketID = "ticket.id"
+	AttrModel    = "model.name"
+)
+
+// defaultBatchSize is the number of finished spans buffered before they are exported.
+const defaultBatchSize = 64
+
+// Exporter ships finished spans to a tracing backend.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+// Span is a single timed operation. A nil *Span is valid and does nothing, so callers
+// never need to check whether tracing is enabled.
+type Span struct {
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Err        string
+
+	tracer *Tracer
+	agent  string
+}
+
+// SetAttribute adds an attribute to the span.
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.tracer.mu.Lock()
+	s.Attributes[key] = value
+	s.tracer.mu.Unlock()
+}
+
+// Finish ends the span, recording err as its status when non-nil.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.tracer.finish(s, err)
+}
+
+// Tracer creates spans and hands finished ones to an Exporter in batches.
+// Spans started for the same agent nest automatically: a span started while another one of
+// that agent is open becomes its child. A nil *Tracer disables tracing.
+type Tracer struct {
+	Service  string
+	Exporter Exporter
+
+	mu      sync.Mutex
+	active  map[string][]*Span // Open spans per agent, innermost last.
+	pending []*Span
+}
+
+// New creates a Tracer for service.
+func New(service string, exporter Exporter) *Tracer {
+	return &Tracer{Service: service, Exporter: exporter, active: make(map[string][]*Span)}
+}
+
+// FromEnv creates a Tracer exporting over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT is set,
+// using OTEL_SERVICE_NAME (default "aiagents") and OTEL_EXPORTER_OTLP_HEADERS ("k=v,k2=v2").
+// It returns nil when tracing is not configured.
+func FromEnv() *Tracer {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return nil
+	}
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if service == "" {
+		service = "aiagents"
+	}
+	exporter := NewOTLPExporter(endpoint, service)
+	for _, kv := range strings.Split(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			exporter.Headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return New(service, exporter)
+}
+
+func randomID(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Start opens a span for agent. attrs are key/value pairs.
+func (t *Tracer) Start(agent, name string, attrs ...string) *Span {
+	if t == nil {
+		return nil
+	}
+	s := &Span{
+		SpanID:     randomID(8),
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: make(map[string]string),
+		tracer:     t,
+		agent:      agent,
+	}
+	if agent != "" {
+		s.Attributes[AttrAgent] = agent
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		s.Attributes[attrs[i]] = attrs[i+1]
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active == nil {
+		t.active = make(map[string][]*Span)
+	}
+	if stack := t.active[agent]; len(stack) > 0 {
+		parent := stack[len(stack)-1]
+		s.TraceID = parent.TraceID
+		s.ParentID = parent.SpanID
+		// Children inherit the ticket so every span of a run can be filtered by it.
+		if id, ok := parent.Attributes[AttrTicketID]; ok {
+			if _, set := s.Attributes[AttrTicketID]; !set {
+				s.Attributes[AttrTicketID] = id
+			}
+		}
+	} else {
+		s.TraceID = randomID(16)
+	}
+	t.active[agent] = append(t.active[agent], s)
+	return s
+}
+
+func (t *Tracer) finish(s *Span, err error) {
+	t.mu.Lock()
+	s.End = time.Now()
+	if err != nil {
+		s.Err = err.Error()
+	}
+	stack := t.active[s.agent]
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == s {
+			t.active[s.agent] = append(stack[:i], stack[i+1:]...)
+			break
+		}
+	}
+	t.pending = append(t.pending, s)
+	// Export when a root span completes so a finished run shows up without waiting for a full batch.
+	flush := len(t.pending) >= defaultBatchSize || s.ParentID == ""
+	t.mu.Unlock()
+	if flush {
+		if err := t.Flush(); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+}
+
+// Flush exports all finished spans.
+func (t *Tracer) Flush() error {
+	if t == nil || t.Exporter == nil {
+		return nil
+	}
+	t.mu.Lock()
+	batch := t.pending
+	t.pending = nil
+	t.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	return t.Exporter.Export(batch)
+}
@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// tracedModel opens a span for every chat call.
+type tracedModel struct {
+	model.ModelClient
+	tracer *Tracer
+	agent  string
+}
+
+// WrapModel returns a ModelClient that traces chat calls as children of the agent's open span.
+func WrapModel(m model.ModelClient, t *Tracer, agent string) model.ModelClient {
+	if t == nil {
+		return m
+	}
+	return &tracedModel{ModelClient: m, tracer: t, agent: agent}
+}
+
+func (m *tracedModel) Chat(prompt string) (string, error) {
+	span := m.tracer.Start(m.agent, "Chat", AttrModel, m.GetModel())
+	resp, err := m.ModelClient.Chat(prompt)
+	span.Finish(err)
+	return resp, err
+}
+
+func (m *tracedModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	span := m.tracer.Start(m.agent, "Chat", AttrModel, req.Model, "request.class", string(req.Class))
+	resp, err := m.ModelClient.ChatAdvanced(req)
+	span.Finish(err)
+	return resp, err
+}
+
+func (m *tracedModel) ChatAdvancedParsed(req model.ChatRequest, target interface{}) error {
+	span := m.tracer.Start(m.agent, "Chat", AttrModel, req.Model, "request.class", string(req.Class))
+	err := m.ModelClient.ChatAdvancedParsed(req, target)
+	span.Finish(err)
+	return err
+}
+
+// tracedBoard opens a span for card creation and card writes.
+type tracedBoard struct {
+	board.BoardClient
+	tracer *Tracer
+	agent  string
+}
+
+// WrapBoard returns a BoardClient that traces card creation, moves and comments.
+func WrapBoard(b board.BoardClient, t *Tracer, agent string) board.BoardClient {
+	if t == nil {
+		return b
+	}
+	return &tracedBoard{BoardClient: b, tracer: t, agent: agent}
+}
+
+func (b *tracedBoard) wrapAll(cards []board.Card, err error) ([]board.Card, error) {
+	for i, c := range cards {
+		cards[i] = &tracedCard{Card: c, board: b}
+	}
+	return cards, err
+}
+
+func (b *tracedBoard) GetCards() ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCards())
+}
+
+func (b *tracedBoard) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsAssignedTo(userName))
+}
+
+func (b *tracedBoard) GetCardsFromList(listName string) ([]board.Card, error) {
+	return b.wrapAll(b.BoardClient.GetCardsFromList(listName))
+}
+
+func (b *tracedBoard) CreateCard(name, description, listName string) (board.Card, error) {
+	span := b.tracer.Start(b.agent, "CreateCard", "board.list", listName)
+	c, err := b.BoardClient.CreateCard(name, description, listName)
+	span.Finish(err)
+	if c == nil {
+		return nil, err
+	}
+	return &tracedCard{Card: c, board: b}, err
+}
+
+// tracedCard traces the writes of a card.
+type tracedCard struct {
+	board.Card
+	board *tracedBoard
+}
+
+func (c *tracedCard) Move(newListName string) error {
+	span := c.board.tracer.Start(c.board.agent, "MoveCard", AttrTicketID, c.GetID(), "board.list", newListName)
+	err := c.Card.Move(newListName)
+	span.Finish(err)
+	return err
+}
+
+func (c *tracedCard) WriteComment(comment string) error {
+	span := c.board.tracer.Start(c.board.agent, "WriteComment", AttrTicketID, c.GetID())
+	err := c.Card.WriteComment(comment)
+	span.Finish(err)
+	return err
+}
+
+// WriteQuestion forwards to the wrapped card's question handling, traced like a comment.
+func (c *tracedCard) WriteQuestion(question string) error {
+	span := c.board.tracer.Start(c.board.agent, "WriteComment", AttrTicketID, c.GetID())
+	err := board.WriteQuestion(c.Card, question)
+	span.Finish(err)
+	return err
+}
+
+// GetMoves forwards to the wrapped card's history when it has one.
+func (c *tracedCard) GetMoves() ([]board.Move, error) {
+	if h, ok := c.Card.(board.CardHistory); ok {
+		return h.GetMoves()
+	}
+	return nil, nil
+}
+
+// ReadAttachment forwards to the wrapped card's downloads when it has them.
+func (c *tracedCard) ReadAttachment(a board.Attachment) ([]byte, error) {
+	return board.ReadAttachment(c.Card, a)
+}
+
+// GetFields forwards to the wrapped card's custom fields when it has them.
+func (c *tracedCard) GetFields() (map[string]string, error) {
+	if f, ok := c.Card.(board.CardFields); ok {
+		return f.GetFields()
+	}
+	return nil, nil
+}
+
+func (c *tracedCard) SetField(name, value string) error {
+	f, ok := c.Card.(board.CardFields)
+	if !ok {
+		return board.ErrNoField
+	}
+	span := c.board.tracer.Start(c.board.agent, "SetField", AttrTicketID, c.GetID(), "board.field", name)
+	err := f.SetField(name, value)
+	span.Finish(err)
+	return err
+}
+
+// ReadCommentsSince forwards to the wrapped card's comment feed when it has one.
+func (c *tracedCard) ReadCommentsSince(since time.Time) ([]board.Comment, error) {
+	return board.ReadCommentsSince(c.Card, since)
+}
+
+func (c *tracedCard) MoveToBottom() error {
+	o, ok := c.Card.(board.CardOrdering)
+	if !ok {
+		return board.ErrNoOrdering
+	}
+	span := c.board.tracer.Start(c.board.agent, "MoveToBottom", AttrTicketID, c.GetID())
+	err := o.MoveToBottom()
+	span.Finish(err)
+	return err
+}
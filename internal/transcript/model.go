@@ -0,0 +1,83 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// ModelExchange is the event type holding a full prompt and response.
+const ModelExchange events.Type = "model_exchange"
+
+// exchangeModel records the full text of every model call together with the ticket being worked on.
+type exchangeModel struct {
+	model.ModelClient
+	rec    events.Recorder
+	agent  string
+	ticket func() string
+}
+
+// WrapModel returns a ModelClient that records each prompt and response as a model_exchange event.
+// ticket reports the ticket the agent is working on, e.g. func() string { return base.CurrentTicketID }.
+// Unlike events.WrapModel this stores prompt text, so point rec at a log kept for debugging.
+func WrapModel(m model.ModelClient, rec events.Recorder, agent string, ticket func() string) model.ModelClient {
+	return &exchangeModel{ModelClient: m, rec: rec, agent: agent, ticket: ticket}
+}
+
+func (m *exchangeModel) record(modelName string, messages []model.Message, response string, err error) {
+	var prompt strings.Builder
+	for _, msg := range messages {
+		fmt.Fprintf(&prompt, "[%s]\n%v\n\n", msg.Role, msg.Content)
+	}
+	var ticketID string
+	if m.ticket != nil {
+		ticketID = m.ticket()
+	}
+	e := events.Event{
+		Agent:    m.agent,
+		TicketID: ticketID,
+		Type:     ModelExchange,
+		Target:   modelName,
+		Details:  map[string]string{"prompt": strings.TrimSpace(prompt.String()), "response": response},
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	m.rec.Record(e)
+}
+
+func (m *exchangeModel) Chat(prompt string) (string, error) {
+	resp, err := m.ModelClient.Chat(prompt)
+	m.record(m.GetModel(), []model.Message{{Role: "user", Content: prompt}}, resp, err)
+	return resp, err
+}
+
+func (m *exchangeModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	resp, err := m.ModelClient.ChatAdvanced(req)
+	m.record(req.Model, req.Input, resp, err)
+	return resp, err
+}
+
+func (m *exchangeModel) ChatAdvancedParsed(req model.ChatRequest, target interface{}) error {
+	err := m.ModelClient.ChatAdvancedParsed(req, target)
+	var resp string
+	if err == nil {
+		if data, mErr := json.MarshalIndent(target, "", "  "); mErr == nil {
+			resp = string(data)
+		}
+	}
+	m.record(req.Model, req.Input, resp, err)
+	return err
+}
+
+// ForRole applies per-role settings of the wrapped client and keeps recording.
+func (m *exchangeModel) ForRole(role string) model.ModelClient {
+	scoped, ok := m.ModelClient.(model.RoleScoped)
+	if !ok {
+		return m
+	}
+	return &exchangeModel{ModelClient: scoped.ForRole(role), rec: m.rec, agent: m.agent, ticket: m.ticket}
+}
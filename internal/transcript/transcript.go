@@ -0,0 +1,143 @@
+// Package transcript assembles everything that happened on a ticket — model prompts and responses,
+// board actions, file writes, commits and card comments — into one exportable history.
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+)
+
+// Formats accepted by Render.
+const (
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+)
+
+// EventSource is a log that can be read back; *events.JSONLLog implements it.
+type EventSource interface {
+	Read(filter events.Filter) ([]events.Event, error)
+}
+
+// Comment is a card comment in a transcript.
+type Comment struct {
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+}
+
+// Transcript is the interaction history of one ticket.
+type Transcript struct {
+	TicketID  string         `json:"ticketId"`
+	Title     string         `json:"title,omitempty"`
+	Generated time.Time      `json:"generated"`
+	Events    []events.Event `json:"events"` // Oldest first.
+	Comments  []Comment      `json:"comments,omitempty"`
+}
+
+// Exporter builds transcripts from event logs and, optionally, the board.
+type Exporter struct {
+	Sources []EventSource     // E.g. the audit log and the model exchange log.
+	Board   board.BoardClient // Optional; adds the card title and comments.
+}
+
+// Export collects the history of ticketID.
+func (x *Exporter) Export(ticketID string) (*Transcript, error) {
+	t := &Transcript{TicketID: ticketID, Generated: time.Now()}
+	for _, src := range x.Sources {
+		evs, err := src.Read(events.Filter{TicketID: ticketID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read events: %w", err)
+		}
+		t.Events = append(t.Events, evs...)
+	}
+	sort.SliceStable(t.Events, func(i, j int) bool { return t.Events[i].Time.Before(t.Events[j].Time) })
+
+	if x.Board != nil {
+		cards, err := x.Board.GetCards()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read board: %w", err)
+		}
+		for _, c := range cards {
+			if c.GetID() != ticketID {
+				continue
+			}
+			t.Title = c.GetName()
+			comments, err := c.ReadComments()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read comments: %w", err)
+			}
+			for _, cm := range comments {
+				var author string
+				if cm.Member != nil {
+					author = cm.Member.Name
+				}
+				t.Comments = append(t.Comments, Comment{Author: author, Text: cm.Text})
+			}
+			break
+		}
+	}
+	return t, nil
+}
+
+// Render encodes the transcript as FormatMarkdown or FormatJSON.
+func (t *Transcript) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(t, "", "  ")
+	case FormatMarkdown, "":
+		return []byte(t.Markdown()), nil
+	default:
+		return nil, fmt.Errorf("unknown transcript format %q (use %s or %s)", format, FormatMarkdown, FormatJSON)
+	}
+}
+
+// Markdown renders the transcript for reading.
+func (t *Transcript) Markdown() string {
+	var sb strings.Builder
+	title := t.TicketID
+	if t.Title != "" {
+		title = fmt.Sprintf("%s (%s)", t.Title, t.TicketID)
+	}
+	fmt.Fprintf(&sb, "# Transcript: %s\n\nGenerated %s.\n\n## Timeline\n", title, t.Generated.Format(time.RFC3339))
+	if len(t.Events) == 0 {
+		sb.WriteString("\n(no recorded activity)\n")
+	}
+	for _, e := range t.Events {
+		fmt.Fprintf(&sb, "\n### %s %s — %s", e.Time.Format("2006-01-02 15:04:05"), e.Agent, e.Type)
+		if e.Target != "" {
+			fmt.Fprintf(&sb, " `%s`", e.Target)
+		}
+		sb.WriteString("\n")
+		if e.Error != "" {
+			fmt.Fprintf(&sb, "\n**Error:** %s\n", e.Error)
+		}
+		if e.Type == ModelExchange {
+			fmt.Fprintf(&sb, "\n**Prompt**\n\n```\n%s\n```\n\n**Response**\n\n```\n%s\n```\n", e.Details["prompt"], e.Details["response"])
+			continue
+		}
+		keys := make([]string, 0, len(e.Details))
+		for k := range e.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&sb, "- %s: %s\n", k, e.Details[k])
+		}
+	}
+	if len(t.Comments) > 0 {
+		sb.WriteString("\n## Card comments\n")
+		for _, c := range t.Comments {
+			author := c.Author
+			if author == "" {
+				author = "unknown"
+			}
+			fmt.Fprintf(&sb, "\n**%s:**\n\n%s\n", author, c.Text)
+		}
+	}
+	return sb.String()
+}
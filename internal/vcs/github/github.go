@@ -0,0 +1,315 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// DefaultLogLines is how many trailing lines of a failed job's log FailedJobLogs returns.
+const DefaultLogLines = 200
+
+// GitHubClient implements vcs.VCSProvider, vcs.CILogs, vcs.ReviewThreads and vcs.ReleasePublisher using the
+// GitHub REST and GraphQL APIs.
+// Pipeline statuses are derived from the check runs of a commit, so GitHub Actions and other
+// check providers are covered alike; logs are only available for GitHub Actions jobs.
+type GitHubClient struct {
+	Token      string // Personal access or installation token.
+	Owner      string // e.g. "egobogo".
+	Repo       string // e.g. "aiagents".
+	BaseURL    string // e.g., "https://api.github.com"
+	LogLines   int    // Zero uses DefaultLogLines.
+	HTTPClient *http.Client
+	// DryRun makes pull requests, comments, thread replies and releases print what they would do instead.
+	DryRun bool
+}
+
+// NewGitHubClient creates a new GitHubClient for the "owner/repo" repository. An empty baseURL defaults to github.com.
+func NewGitHubClient(token, repository, baseURL string) (*GitHubClient, error) {
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("invalid repository %q; expected owner/repo", repository)
+	}
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &GitHubClient{
+		Token:      token,
+		Owner:      owner,
+		Repo:       repo,
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// pullRequest mirrors the fields of a GitHub pull request we care about.
+type pullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (pr pullRequest) toPullRequest() vcs.PullRequest {
+	return vcs.PullRequest{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Description:  pr.Body,
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		State:        pr.State,
+		URL:          pr.HTMLURL,
+	}
+}
+
+// repoPath returns the API path prefix for the configured repository.
+func (c *GitHubClient) repoPath() string {
+	return "/repos/" + url.PathEscape(c.Owner) + "/" + url.PathEscape(c.Repo)
+}
+
+// request performs an authenticated API request and returns the response body.
+func (c *GitHubClient) request(method, path string, payload interface{}) ([]byte, error) {
+	body := &bytes.Buffer{}
+	if payload != nil {
+		if err := json.NewEncoder(body).Encode(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+	contentType := ""
+	if payload != nil {
+		contentType = "application/json"
+	}
+	return c.send(method, c.BaseURL+path, contentType, body)
+}
+
+// send performs an authenticated request against an absolute URL and returns the response body.
+func (c *GitHubClient) send(method, rawURL, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("github API returned status %d: %s", resp.StatusCode, string(respBytes))
+	}
+	return respBytes, nil
+}
+
+// do performs an API request and decodes the JSON response into out (if non-nil).
+func (c *GitHubClient) do(method, path string, payload interface{}, out interface{}) error {
+	data, err := c.request(method, path, payload)
+	if err != nil || out == nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// CreatePullRequest opens a pull request from sourceBranch into targetBranch.
+func (c *GitHubClient) CreatePullRequest(title, description, sourceBranch, targetBranch string) (vcs.PullRequest, error) {
+	payload := map[string]string{
+		"title": title,
+		"body":  description,
+		"head":  sourceBranch,
+		"base":  targetBranch,
+	}
+	if c.DryRun {
+		vcs.DryRunPrint("open pull request %q from %s into %s:\n%s", title, sourceBranch, targetBranch, description)
+		return vcs.PullRequest{Title: title, Description: description, SourceBranch: sourceBranch, TargetBranch: targetBranch, State: "open"}, nil
+	}
+	var pr pullRequest
+	if err := c.do("POST", c.repoPath()+"/pulls", payload, &pr); err != nil {
+		return vcs.PullRequest{}, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	return pr.toPullRequest(), nil
+}
+
+// GetPullRequest retrieves a pull request by its number.
+func (c *GitHubClient) GetPullRequest(number int) (vcs.PullRequest, error) {
+	var pr pullRequest
+	if err := c.do("GET", fmt.Sprintf("%s/pulls/%d", c.repoPath(), number), nil, &pr); err != nil {
+		return vcs.PullRequest{}, fmt.Errorf("failed to get pull request: %w", err)
+	}
+	return pr.toPullRequest(), nil
+}
+
+// comment mirrors the fields of GitHub issue and review comments we care about.
+type comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	User struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"user"`
+}
+
+// ReadPullRequestComments retrieves the conversation and review comments of a pull request,
+// skipping those left by bots.
+func (c *GitHubClient) ReadPullRequestComments(number int) ([]vcs.ReviewComment, error) {
+	var comments []vcs.ReviewComment
+	for _, kind := range []string{"issues", "pulls"} {
+		var page []comment
+		path := fmt.Sprintf("%s/%s/%d/comments?per_page=100", c.repoPath(), kind, number)
+		if err := c.do("GET", path, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to get pull request comments: %w", err)
+		}
+		for _, cm := range page {
+			if cm.User.Type == "Bot" {
+				continue
+			}
+			comments = append(comments, vcs.ReviewComment{
+				ID:     strconv.FormatInt(cm.ID, 10),
+				Author: cm.User.Login,
+				Body:   cm.Body,
+				Path:   cm.Path,
+				Line:   cm.Line,
+			})
+		}
+	}
+	return comments, nil
+}
+
+// CommentOnPullRequest posts a conversation comment on a pull request.
+func (c *GitHubClient) CommentOnPullRequest(number int, body string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("comment on pull request #%d:\n%s", number, body)
+		return nil
+	}
+	path := fmt.Sprintf("%s/issues/%d/comments", c.repoPath(), number)
+	if err := c.do("POST", path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to comment on pull request: %w", err)
+	}
+	return nil
+}
+
+// checkRun mirrors the fields of a GitHub check run we care about.
+type checkRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // "queued", "in_progress", "completed", ...
+	Conclusion string `json:"conclusion"` // Set once completed, e.g. "success" or "failure".
+	HTMLURL    string `json:"html_url"`
+	App        struct {
+		Slug string `json:"slug"`
+	} `json:"app"`
+}
+
+// failed reports whether a completed check run counts as a failure.
+func (r checkRun) failed() bool {
+	switch r.Conclusion {
+	case "failure", "timed_out", "action_required", "startup_failure":
+		return true
+	}
+	return false
+}
+
+// checkRuns returns the check runs of the commit ref points to.
+func (c *GitHubClient) checkRuns(ref string) ([]checkRun, error) {
+	var result struct {
+		CheckRuns []checkRun `json:"check_runs"`
+	}
+	path := fmt.Sprintf("%s/commits/%s/check-runs?per_page=100", c.repoPath(), url.PathEscape(ref))
+	if err := c.do("GET", path, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to get check runs: %w", err)
+	}
+	return result.CheckRuns, nil
+}
+
+// GetPipelineStatus combines the check runs of ref into one status. A failed run fails the
+// pipeline even while others are still running.
+func (c *GitHubClient) GetPipelineStatus(ref string) (vcs.PipelineStatus, error) {
+	runs, err := c.checkRuns(ref)
+	if err != nil {
+		return vcs.PipelineUnknown, err
+	}
+	if len(runs) == 0 {
+		return vcs.PipelineUnknown, nil
+	}
+	var running, queued, canceled bool
+	for _, r := range runs {
+		switch {
+		case r.Status == "in_progress":
+			running = true
+		case r.Status != "completed":
+			queued = true
+		case r.failed():
+			return vcs.PipelineFailed, nil
+		case r.Conclusion == "cancelled":
+			canceled = true
+		}
+	}
+	switch {
+	case running:
+		return vcs.PipelineRunning, nil
+	case queued:
+		return vcs.PipelinePending, nil
+	case canceled:
+		return vcs.PipelineCanceled, nil
+	default:
+		return vcs.PipelineSuccess, nil
+	}
+}
+
+// FailedJobLogs returns the log tails of the failed GitHub Actions jobs of ref. Failed checks
+// from other apps are listed without a log.
+func (c *GitHubClient) FailedJobLogs(ref string) ([]vcs.JobLog, error) {
+	runs, err := c.checkRuns(ref)
+	if err != nil {
+		return nil, err
+	}
+	lines := c.LogLines
+	if lines <= 0 {
+		lines = DefaultLogLines
+	}
+	var logs []vcs.JobLog
+	for _, r := range runs {
+		if r.Status != "completed" || !r.failed() {
+			continue
+		}
+		job := vcs.JobLog{Name: r.Name, URL: r.HTMLURL}
+		if r.App.Slug == "github-actions" {
+			// For GitHub Actions the check run ID is the job ID.
+			data, err := c.request("GET", fmt.Sprintf("%s/actions/jobs/%d/logs", c.repoPath(), r.ID), nil)
+			if err != nil {
+				fmt.Printf("Warning: failed to get log of %s: %v\n", r.Name, err)
+			} else {
+				job.Log = vcs.TailLog(string(data), lines)
+			}
+		}
+		logs = append(logs, job)
+	}
+	return logs, nil
+}
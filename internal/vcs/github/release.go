@@ -0,0 +1,53 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// release mirrors the fields of a GitHub release we care about.
+type release struct {
+	TagName   string `json:"tag_name"`
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	HTMLURL   string `json:"html_url"`
+	UploadURL string `json:"upload_url"` // URI template, e.g. ".../assets{?name,label}".
+}
+
+// CreateRelease publishes a release of an existing tag and uploads assets to it.
+func (c *GitHubClient) CreateRelease(tag, name, notes string, assets []vcs.ReleaseAsset) (vcs.Release, error) {
+	if c.DryRun {
+		vcs.DryRunPrint("publish release %s (%s) with %d assets:\n%s", tag, name, len(assets), notes)
+		return vcs.Release{Tag: tag, Name: name, Notes: notes}, nil
+	}
+	payload := map[string]string{"tag_name": tag, "name": name, "body": notes}
+	var r release
+	if err := c.do("POST", c.repoPath()+"/releases", payload, &r); err != nil {
+		return vcs.Release{}, fmt.Errorf("failed to create release: %w", err)
+	}
+	published := vcs.Release{Tag: r.TagName, Name: r.Name, Notes: r.Body, URL: r.HTMLURL}
+	uploadURL, _, _ := strings.Cut(r.UploadURL, "{")
+	for _, a := range assets {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		data, err := c.send("POST", uploadURL+"?name="+url.QueryEscape(a.Name), contentType, bytes.NewReader(a.Data))
+		if err != nil {
+			return published, fmt.Errorf("failed to upload %s: %w", a.Name, err)
+		}
+		var uploaded struct {
+			BrowserDownloadURL string `json:"browser_download_url"`
+		}
+		if err := json.Unmarshal(data, &uploaded); err != nil {
+			return published, fmt.Errorf("failed to decode upload of %s: %w", a.Name, err)
+		}
+		published.Assets = append(published.Assets, uploaded.BrowserDownloadURL)
+	}
+	return published, nil
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// graphqlURL returns the GraphQL endpoint belonging to BaseURL; GitHub Enterprise serves REST
+// under "/api/v3" and GraphQL under "/api/graphql".
+func (c *GitHubClient) graphqlURL() string {
+	if base, ok := strings.CutSuffix(c.BaseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return c.BaseURL + "/graphql"
+}
+
+// graphql runs a GraphQL query or mutation and decodes its data into out (if non-nil).
+func (c *GitHubClient) graphql(query string, variables map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to marshal query: %w", err)
+	}
+	data, err := c.send("POST", c.graphqlURL(), "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("github GraphQL error: %s", result.Errors[0].Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes {
+          id isResolved path line
+          comments(first: 100) { nodes { databaseId body path line author { login __typename } } }
+        }
+      }
+    }
+  }
+}`
+
+// ReadReviewThreads returns the review threads of a pull request, without comments left by bots.
+func (c *GitHubClient) ReadReviewThreads(number int) ([]vcs.ReviewThread, error) {
+	var data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         string `json:"id"`
+						IsResolved bool   `json:"isResolved"`
+						Path       string `json:"path"`
+						Line       int    `json:"line"`
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID int64  `json:"databaseId"`
+								Body       string `json:"body"`
+								Path       string `json:"path"`
+								Line       int    `json:"line"`
+								Author     struct {
+									Login    string `json:"login"`
+									Typename string `json:"__typename"`
+								} `json:"author"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	vars := map[string]interface{}{"owner": c.Owner, "repo": c.Repo, "number": number}
+	if err := c.graphql(reviewThreadsQuery, vars, &data); err != nil {
+		return nil, fmt.Errorf("failed to get review threads: %w", err)
+	}
+	var threads []vcs.ReviewThread
+	for _, t := range data.Repository.PullRequest.ReviewThreads.Nodes {
+		thread := vcs.ReviewThread{ID: t.ID, Path: t.Path, Line: t.Line, Resolved: t.IsResolved}
+		for _, cm := range t.Comments.Nodes {
+			if cm.Author.Typename == "Bot" {
+				continue
+			}
+			thread.Comments = append(thread.Comments, vcs.ReviewComment{
+				ID:       strconv.FormatInt(cm.DatabaseID, 10),
+				Author:   cm.Author.Login,
+				Body:     cm.Body,
+				Path:     cm.Path,
+				Line:     cm.Line,
+				Resolved: t.IsResolved,
+			})
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// ReplyToThread adds a reply to a review thread.
+func (c *GitHubClient) ReplyToThread(number int, threadID, body string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("reply to review thread %s on pull request #%d:\n%s", threadID, number, body)
+		return nil
+	}
+	const mutation = `mutation($thread: ID!, $body: String!) {
+  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $thread, body: $body}) { comment { id } }
+}`
+	if err := c.graphql(mutation, map[string]interface{}{"thread": threadID, "body": body}, nil); err != nil {
+		return fmt.Errorf("failed to reply to review thread: %w", err)
+	}
+	return nil
+}
+
+// ResolveThread marks a review thread as resolved.
+func (c *GitHubClient) ResolveThread(number int, threadID string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("resolve review thread %s on pull request #%d", threadID, number)
+		return nil
+	}
+	const mutation = `mutation($thread: ID!) {
+  resolveReviewThread(input: {threadId: $thread}) { thread { isResolved } }
+}`
+	if err := c.graphql(mutation, map[string]interface{}{"thread": threadID}, nil); err != nil {
+		return fmt.Errorf("failed to resolve review thread: %w", err)
+	}
+	return nil
+}
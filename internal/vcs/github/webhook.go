@@ -0,0 +1,75 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// maxWebhookBody bounds the size of accepted webhook deliveries.
+const maxWebhookBody = 5 << 20
+
+// WebhookHandler receives GitHub check_run, check_suite and workflow_run deliveries and wakes
+// pipeline waits on hub for the commit and branch they concern. With a non-empty secret,
+// deliveries must carry a valid X-Hub-Signature-256 header.
+func WebhookHandler(secret string, hub *vcs.StatusHub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if secret != "" && !validSignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+		var head struct {
+			HeadSHA    string `json:"head_sha"`
+			HeadBranch string `json:"head_branch"`
+			CheckSuite struct {
+				HeadBranch string `json:"head_branch"`
+			} `json:"check_suite"`
+		}
+		switch event := r.Header.Get("X-GitHub-Event"); event {
+		case "check_run", "check_suite", "workflow_run":
+			if err := json.Unmarshal(payload[event], &head); err != nil {
+				http.Error(w, "invalid payload", http.StatusBadRequest)
+				return
+			}
+		}
+		var refs []string
+		for _, ref := range []string{head.HeadSHA, head.HeadBranch, head.CheckSuite.HeadBranch} {
+			if ref != "" {
+				refs = append(refs, ref)
+			}
+		}
+		hub.Notify(refs...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// validSignature checks a "sha256=<hex>" signature of body.
+func validSignature(secret string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,197 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/gitlabapi"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// GitLabClient implements vcs.VCSProvider and vcs.ReviewThreads using the GitLab REST API.
+type GitLabClient struct {
+	Token      string // Personal, project or group access token.
+	ProjectID  string // Numeric project ID or URL path such as "group/project".
+	BaseURL    string // e.g., "https://gitlab.com/api/v4"
+	HTTPClient *http.Client
+	// DryRun makes merge requests, notes and discussion replies print what they would do instead.
+	DryRun bool
+}
+
+// NewGitLabClient creates a new GitLabClient. An empty baseURL defaults to gitlab.com.
+func NewGitLabClient(token, projectID, baseURL string) *GitLabClient {
+	if baseURL == "" {
+		baseURL = gitlabapi.DefaultBaseURL
+	}
+	return &GitLabClient{
+		Token:      token,
+		ProjectID:  projectID,
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{},
+	}
+}
+
+// mergeRequest mirrors the fields of a GitLab merge request we care about.
+type mergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	State        string `json:"state"`
+	WebURL       string `json:"web_url"`
+}
+
+func (mr mergeRequest) toPullRequest() vcs.PullRequest {
+	return vcs.PullRequest{
+		Number:       mr.IID,
+		Title:        mr.Title,
+		Description:  mr.Description,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		State:        mr.State,
+		URL:          mr.WebURL,
+	}
+}
+
+// projectPath returns the API path prefix for the configured project.
+func (c *GitLabClient) projectPath() string {
+	return "/projects/" + url.PathEscape(c.ProjectID)
+}
+
+// do performs an authenticated API request and decodes the JSON response into out (if non-nil).
+func (c *GitLabClient) do(method, path string, payload interface{}, out interface{}) error {
+	return gitlabapi.Do(c.HTTPClient, c.BaseURL, c.Token, method, path, payload, out)
+}
+
+// CreatePullRequest opens a merge request from sourceBranch into targetBranch.
+func (c *GitLabClient) CreatePullRequest(title, description, sourceBranch, targetBranch string) (vcs.PullRequest, error) {
+	payload := map[string]string{
+		"title":         title,
+		"description":   description,
+		"source_branch": sourceBranch,
+		"target_branch": targetBranch,
+	}
+	if c.DryRun {
+		vcs.DryRunPrint("open merge request %q from %s into %s:\n%s", title, sourceBranch, targetBranch, description)
+		return vcs.PullRequest{Title: title, Description: description, SourceBranch: sourceBranch, TargetBranch: targetBranch, State: "opened"}, nil
+	}
+	var mr mergeRequest
+	if err := c.do("POST", c.projectPath()+"/merge_requests", payload, &mr); err != nil {
+		return vcs.PullRequest{}, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return mr.toPullRequest(), nil
+}
+
+// GetPullRequest retrieves a merge request by its project-scoped IID.
+func (c *GitLabClient) GetPullRequest(number int) (vcs.PullRequest, error) {
+	var mr mergeRequest
+	if err := c.do("GET", fmt.Sprintf("%s/merge_requests/%d", c.projectPath(), number), nil, &mr); err != nil {
+		return vcs.PullRequest{}, fmt.Errorf("failed to get merge request: %w", err)
+	}
+	return mr.toPullRequest(), nil
+}
+
+// ReadPullRequestComments retrieves all non-system notes on a merge request.
+func (c *GitLabClient) ReadPullRequestComments(number int) ([]vcs.ReviewComment, error) {
+	type note struct {
+		ID     int    `json:"id"`
+		Body   string `json:"body"`
+		System bool   `json:"system"`
+		Author struct {
+			Username string `json:"username"`
+		} `json:"author"`
+		Resolved bool `json:"resolved"`
+		Position *struct {
+			NewPath string `json:"new_path"`
+			NewLine int    `json:"new_line"`
+		} `json:"position"`
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/notes?sort=asc", c.projectPath(), number)
+	notes, err := gitlabapi.GetAll[note](c.HTTPClient, c.BaseURL, c.Token, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request notes: %w", err)
+	}
+	var comments []vcs.ReviewComment
+	for _, n := range notes {
+		if n.System {
+			continue
+		}
+		comment := vcs.ReviewComment{
+			ID:       strconv.Itoa(n.ID),
+			Author:   n.Author.Username,
+			Body:     n.Body,
+			Resolved: n.Resolved,
+		}
+		if n.Position != nil {
+			comment.Path = n.Position.NewPath
+			comment.Line = n.Position.NewLine
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+// CommentOnPullRequest posts a note on a merge request.
+func (c *GitLabClient) CommentOnPullRequest(number int, body string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("comment on merge request !%d:\n%s", number, body)
+		return nil
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/notes", c.projectPath(), number)
+	if err := c.do("POST", path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to comment on merge request: %w", err)
+	}
+	return nil
+}
+
+// GetPipelineStatus returns the status of the most recent pipeline for the given ref (branch, tag or commit SHA).
+func (c *GitLabClient) GetPipelineStatus(ref string) (vcs.PipelineStatus, error) {
+	var pipelines []struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}
+	param := "ref"
+	if isCommitSHA(ref) {
+		param = "sha"
+	}
+	path := fmt.Sprintf("%s/pipelines?%s=%s&per_page=1&order_by=id&sort=desc", c.projectPath(), param, url.QueryEscape(ref))
+	if err := c.do("GET", path, nil, &pipelines); err != nil {
+		return vcs.PipelineUnknown, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+	if len(pipelines) == 0 {
+		return vcs.PipelineUnknown, nil
+	}
+	switch pipelines[0].Status {
+	case "created", "waiting_for_resource", "preparing", "pending", "scheduled":
+		return vcs.PipelinePending, nil
+	case "manual":
+		return vcs.PipelineManual, nil
+	case "running":
+		return vcs.PipelineRunning, nil
+	case "success":
+		return vcs.PipelineSuccess, nil
+	case "failed":
+		return vcs.PipelineFailed, nil
+	case "canceled", "skipped":
+		return vcs.PipelineCanceled, nil
+	default:
+		return vcs.PipelineUnknown, nil
+	}
+}
+
+// isCommitSHA reports whether ref is a full commit SHA rather than a branch or tag name.
+func isCommitSHA(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/egobogo/aiagents/internal/gitlabapi"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+// ReadReviewThreads returns the resolvable discussions of a merge request, without system notes.
+func (c *GitLabClient) ReadReviewThreads(number int) ([]vcs.ReviewThread, error) {
+	type discussion struct {
+		ID    string `json:"id"`
+		Notes []struct {
+			ID         int    `json:"id"`
+			Body       string `json:"body"`
+			System     bool   `json:"system"`
+			Resolvable bool   `json:"resolvable"`
+			Resolved   bool   `json:"resolved"`
+			Author     struct {
+				Username string `json:"username"`
+			} `json:"author"`
+			Position *struct {
+				NewPath string `json:"new_path"`
+				NewLine int    `json:"new_line"`
+			} `json:"position"`
+		} `json:"notes"`
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions", c.projectPath(), number)
+	discussions, err := gitlabapi.GetAll[discussion](c.HTTPClient, c.BaseURL, c.Token, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request discussions: %w", err)
+	}
+	var threads []vcs.ReviewThread
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || !d.Notes[0].Resolvable {
+			continue
+		}
+		thread := vcs.ReviewThread{ID: d.ID, Resolved: d.Notes[0].Resolved}
+		if p := d.Notes[0].Position; p != nil {
+			thread.Path, thread.Line = p.NewPath, p.NewLine
+		}
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			thread.Comments = append(thread.Comments, vcs.ReviewComment{
+				ID:       strconv.Itoa(n.ID),
+				Author:   n.Author.Username,
+				Body:     n.Body,
+				Path:     thread.Path,
+				Line:     thread.Line,
+				Resolved: n.Resolved,
+			})
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// ReplyToThread adds a note to a merge request discussion.
+func (c *GitLabClient) ReplyToThread(number int, threadID, body string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("reply to discussion %s on merge request !%d:\n%s", threadID, number, body)
+		return nil
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s/notes", c.projectPath(), number, url.PathEscape(threadID))
+	if err := c.do("POST", path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to reply to discussion: %w", err)
+	}
+	return nil
+}
+
+// ResolveThread resolves a merge request discussion.
+func (c *GitLabClient) ResolveThread(number int, threadID string) error {
+	if c.DryRun {
+		vcs.DryRunPrint("resolve discussion %s on merge request !%d", threadID, number)
+		return nil
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s?resolved=true", c.projectPath(), number, url.PathEscape(threadID))
+	if err := c.do("PUT", path, nil, nil); err != nil {
+		return fmt.Errorf("failed to resolve discussion: %w", err)
+	}
+	return nil
+}
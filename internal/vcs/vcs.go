@@ -0,0 +1,116 @@
+package vcs
+
+import "fmt"
+
+// PipelineStatus is the normalized state of a CI pipeline.
+type PipelineStatus string
+
+const (
+	PipelinePending  PipelineStatus = "pending"
+	PipelineRunning  PipelineStatus = "running"
+	PipelineSuccess  PipelineStatus = "success"
+	PipelineFailed   PipelineStatus = "failed"
+	PipelineCanceled PipelineStatus = "canceled"
+	PipelineManual   PipelineStatus = "manual" // Blocked on a job someone has to start by hand.
+	PipelineUnknown  PipelineStatus = "unknown"
+)
+
+// PullRequest represents a pull (or merge) request on the hosting provider.
+type PullRequest struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	SourceBranch string `json:"sourceBranch"`
+	TargetBranch string `json:"targetBranch"`
+	State        string `json:"state"`
+	URL          string `json:"url"`
+}
+
+// ReviewComment represents a comment left on a pull request.
+type ReviewComment struct {
+	ID       string `json:"id"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	Path     string `json:"path,omitempty"` // File the comment refers to, if any.
+	Line     int    `json:"line,omitempty"` // Line the comment refers to, if any.
+	Resolved bool   `json:"resolved"`
+}
+
+// ReviewThread is a review discussion on a pull request, usually anchored to a line of code.
+type ReviewThread struct {
+	ID       string          `json:"id"`
+	Path     string          `json:"path,omitempty"` // File the thread refers to, if any.
+	Line     int             `json:"line,omitempty"` // Line the thread refers to, if any.
+	Resolved bool            `json:"resolved"`
+	Comments []ReviewComment `json:"comments"` // Oldest first; bot comments are left out.
+}
+
+// ReviewThreads is implemented by providers whose pull requests have resolvable review threads.
+type ReviewThreads interface {
+	// ReadReviewThreads returns the review threads of a pull request.
+	ReadReviewThreads(number int) ([]ReviewThread, error)
+	// ReplyToThread adds a reply to a review thread.
+	ReplyToThread(number int, threadID, body string) error
+	// ResolveThread marks a review thread as resolved.
+	ResolveThread(number int, threadID string) error
+}
+
+// VCSProvider defines operations on a code hosting provider (GitHub, GitLab, ...).
+type VCSProvider interface {
+	// CreatePullRequest opens a pull request from sourceBranch into targetBranch.
+	CreatePullRequest(title, description, sourceBranch, targetBranch string) (PullRequest, error)
+	// GetPullRequest retrieves a pull request by its number.
+	GetPullRequest(number int) (PullRequest, error)
+	// ReadPullRequestComments retrieves all human comments on a pull request.
+	ReadPullRequestComments(number int) ([]ReviewComment, error)
+	// CommentOnPullRequest posts a general comment on a pull request.
+	CommentOnPullRequest(number int, body string) error
+	// GetPipelineStatus returns the status of the latest pipeline for the given ref (branch or SHA).
+	GetPipelineStatus(ref string) (PipelineStatus, error)
+}
+
+// Finished reports whether the pipeline has reached a final state. A pipeline waiting for a manual job
+// counts as finished, since waiting does not move it on.
+func (s PipelineStatus) Finished() bool {
+	return s == PipelineSuccess || s == PipelineFailed || s == PipelineCanceled || s == PipelineManual
+}
+
+// JobLog is the output of one failed CI job.
+type JobLog struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+	Log  string `json:"log"` // Tail of the job output.
+}
+
+// CILogs is implemented by providers that can return the output of failed CI jobs.
+type CILogs interface {
+	// FailedJobLogs returns the logs of the failed jobs of the latest pipeline for ref (branch or SHA).
+	FailedJobLogs(ref string) ([]JobLog, error)
+}
+
+// Release is a release published on the hosting provider.
+type Release struct {
+	Tag    string   `json:"tag"`
+	Name   string   `json:"name"`
+	Notes  string   `json:"notes"`
+	URL    string   `json:"url"`
+	Assets []string `json:"assets,omitempty"` // Download URLs of the attached files.
+}
+
+// ReleaseAsset is a file attached to a release.
+type ReleaseAsset struct {
+	Name        string // File name shown on the release.
+	ContentType string // Empty uses "application/octet-stream".
+	Data        []byte
+}
+
+// ReleasePublisher is implemented by providers that can publish releases.
+type ReleasePublisher interface {
+	// CreateRelease publishes a release of an existing tag with notes and uploads assets to it.
+	CreateRelease(tag, name, notes string, assets []ReleaseAsset) (Release, error)
+}
+
+// DryRunPrint prints a write a provider skipped because it runs in dry-run mode.
+func DryRunPrint(format string, args ...interface{}) {
+	fmt.Printf("[dry-run] "+format+"\n", args...)
+}
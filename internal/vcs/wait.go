@@ -0,0 +1,117 @@
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for WaitForPipeline.
+const (
+	DefaultPollInterval    = 30 * time.Second
+	DefaultPipelineTimeout = 30 * time.Minute
+)
+
+// ErrPipelineTimeout is returned by WaitForPipeline when the pipeline does not finish in time.
+var ErrPipelineTimeout = errors.New("pipeline did not finish in time")
+
+// WaitOptions controls WaitForPipeline.
+type WaitOptions struct {
+	Interval time.Duration // Between status checks; zero uses DefaultPollInterval.
+	Timeout  time.Duration // Zero uses DefaultPipelineTimeout.
+	Hub      *StatusHub    // Optional; webhook deliveries trigger an immediate check.
+}
+
+// WaitForPipeline checks the pipeline of ref until it finishes and returns its final status.
+// Unknown statuses, e.g. while the provider has not picked up a push yet, keep it waiting.
+func WaitForPipeline(p VCSProvider, ref string, opts WaitOptions) (PipelineStatus, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultPollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultPipelineTimeout
+	}
+	deadline := time.After(opts.Timeout)
+	status := PipelineUnknown
+	for {
+		wake, cancel := opts.Hub.subscribe(ref)
+		var err error
+		status, err = p.GetPipelineStatus(ref)
+		if err != nil {
+			fmt.Printf("Warning: failed to get pipeline status of %s: %v\n", ref, err)
+		} else if status.Finished() {
+			cancel()
+			return status, nil
+		}
+		select {
+		case <-time.After(opts.Interval):
+		case <-wake:
+		case <-deadline:
+			cancel()
+			return status, fmt.Errorf("%w after %s (last status %s)", ErrPipelineTimeout, opts.Timeout, status)
+		}
+		cancel()
+	}
+}
+
+// StatusHub lets webhook handlers wake WaitForPipeline as soon as a provider reports activity for a ref.
+type StatusHub struct {
+	mu      sync.Mutex
+	waiters map[string][]chan struct{}
+}
+
+// NewStatusHub creates an empty StatusHub.
+func NewStatusHub() *StatusHub {
+	return &StatusHub{waiters: make(map[string][]chan struct{})}
+}
+
+// Notify wakes every wait on one of refs (branch names or commit SHAs).
+func (h *StatusHub) Notify(refs ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ref := range refs {
+		key := strings.ToLower(ref)
+		for _, ch := range h.waiters[key] {
+			close(ch)
+		}
+		delete(h.waiters, key)
+	}
+}
+
+// subscribe returns a channel closed on the next Notify for ref and a function that drops it.
+// A nil hub returns a channel that is never closed.
+func (h *StatusHub) subscribe(ref string) (<-chan struct{}, func()) {
+	if h == nil {
+		return nil, func() {}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan struct{})
+	key := strings.ToLower(ref)
+	h.waiters[key] = append(h.waiters[key], ch)
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		waiters := h.waiters[key]
+		for i, w := range waiters {
+			if w == ch {
+				h.waiters[key] = append(waiters[:i:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(h.waiters[key]) == 0 {
+			delete(h.waiters, key)
+		}
+	}
+}
+
+// TailLog returns the last n lines of log.
+func TailLog(log string, n int) string {
+	lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	if n <= 0 || len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[len(lines)-n:], "\n")
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/adr"
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestADRRenderAndList(t *testing.T) {
+	if got := adr.Slug("Use Redis for session storage!"); got != "use-redis-for-session-storage" {
+		t.Fatalf("unexpected slug %q", got)
+	}
+	if n := adr.Next([]adr.Record{{Number: 1}, {Number: 4}}); n != 5 {
+		t.Fatalf("expected 5 as the next number, got %d", n)
+	}
+	text := adr.Render(adr.Record{Number: 3, Title: "Use Redis", Decision: "Sessions live in Redis.", Alternatives: []string{"Postgres"}})
+	for _, want := range []string{"# 3. Use Redis\n", "## Status\n\nAccepted\n", "## Decision\n\nSessions live in Redis.\n", "- Postgres\n"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestManagerRecordsArchitectureDecisions(t *testing.T) {
+	redis := &agent.ArchitectureDecision{
+		Title:        "Use Redis for session storage",
+		Context:      "Sessions must survive restarts.",
+		Decision:     "Store sessions in Redis.",
+		Consequences: "Redis becomes a runtime dependency.",
+		Alternatives: []string{"Postgres table"},
+	}
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add session store", Description: "Persist sessions.", StoryPoints: 3, Decision: redis},
+		{Title: "Expire sessions", Description: "Drop old sessions.", StoryPoints: 2, Decision: redis},
+		{Title: "Add logout button", Description: "Clear the session.", StoryPoints: 1},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Knowledge = knowledge.New(nil)
+	if err := s.Repo.WriteFile("docs/adr/0001-use-postgres.md", []byte(adr.Render(adr.Record{Number: 1, Title: "Use Postgres"}))); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := s.Repo.CommitChanges("docs: first decision", "test", "test@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	parent, _ := s.Board.CreateCard("Sessions", "Keep users logged in.", board.ListBacklog)
+
+	created, err := em.HandleTicket(parent)
+	if err != nil || len(created) != 3 {
+		t.Fatalf("HandleTicket failed: %d tickets, %v", len(created), err)
+	}
+	listed := false
+	for _, c := range m.Calls() {
+		listed = listed || strings.Contains(requestContent(c.Input[len(c.Input)-1].Content), "- ADR 1: Use Postgres (Accepted)")
+	}
+	if !listed {
+		t.Fatal("expected the recorded decisions in the decomposition prompt")
+	}
+	path := "docs/adr/0002-use-redis-for-session-storage.md"
+	text, err := s.RepoFile("", path)
+	if err != nil {
+		t.Fatalf("expected the decision committed to %s: %v", path, err)
+	}
+	for _, want := range []string{"# 2. Use Redis for session storage", "Redis becomes a runtime dependency.", "- Add session store\n", "- Expire sessions\n"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in the record:\n%s", want, text)
+		}
+	}
+	line := "Architecture decision: ADR 2, Use Redis for session storage (" + path + ")"
+	for i, c := range created {
+		if got := strings.Contains(c.GetDescription(), line); got != (i < 2) {
+			t.Fatalf("unexpected decision link in %q:\n%s", c.GetName(), c.GetDescription())
+		}
+	}
+	chunks, err := s.Knowledge.Search("redis session storage", 1)
+	if err != nil || len(chunks) != 1 || chunks[0].Source != path || chunks[0].Kind != knowledge.KindADR {
+		t.Fatalf("expected the decision indexed, got %+v, %v", chunks, err)
+	}
+
+	again, _ := s.Board.CreateCard("Sessions v2", "Keep users logged in longer.", board.ListBacklog)
+	if _, err := em.HandleTicket(again); err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	records, err := adr.List(s.Repo, "")
+	if err != nil || len(records) != 2 {
+		t.Fatalf("expected the recorded decision reused, got %+v, %v", records, err)
+	}
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+var developers = []config.DeveloperSettings{
+	{Name: "backend", Labels: []string{"api", "gateway"}},
+	{Name: "frontend", Labels: []string{"web"}},
+}
+
+func TestRoundRobinAssignment(t *testing.T) {
+	rr := &agent.RoundRobin{}
+	var got []string
+	for i := 0; i < 3; i++ {
+		name, err := rr.Pick(nil, developers)
+		if err != nil {
+			t.Fatalf("Pick failed: %v", err)
+		}
+		got = append(got, name)
+	}
+	if strings.Join(got, ",") != "backend,frontend,backend" {
+		t.Fatalf("unexpected rotation: %v", got)
+	}
+	if _, err := rr.Pick(nil, nil); err == nil {
+		t.Fatal("expected an error without developers")
+	}
+}
+
+func TestLeastLoadedAssignment(t *testing.T) {
+	b := sim.NewBoard()
+	for i, list := range []string{board.ListDoing, board.ListDoing, board.ListDone} {
+		c, _ := b.CreateCard("busy", "", list)
+		_ = c.AssignTo("backend")
+		if i == 0 {
+			done, _ := b.CreateCard("finished", "", board.ListDone)
+			_ = done.AssignTo("frontend")
+		}
+	}
+	strategy, err := agent.NewAssignStrategy(agent.AssignLeastLoaded, b)
+	if err != nil {
+		t.Fatalf("NewAssignStrategy failed: %v", err)
+	}
+	name, err := strategy.Pick(nil, developers)
+	if err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if name != "frontend" {
+		t.Fatalf("expected frontend (no open cards), got %s", name)
+	}
+	if _, err := agent.NewAssignStrategy("random", b); err == nil {
+		t.Fatal("expected unknown strategy to fail")
+	}
+}
+
+func TestCapabilityAssignment(t *testing.T) {
+	b := sim.NewBoard()
+	b.Fields = []string{board.FieldComponent}
+	card, _ := b.CreateCard("Style the page", "", board.ListBacklog)
+	if _, err := board.SetField(card, board.FieldComponent, "Web"); err != nil {
+		t.Fatalf("SetField failed: %v", err)
+	}
+	m := &agent.CapabilityMatch{}
+	if name, _ := m.Pick(card, developers); name != "frontend" {
+		t.Fatalf("expected frontend for the web component, got %s", name)
+	}
+	labelled, _ := b.CreateCard("[gateway] Rate limits", "", board.ListBacklog)
+	if name, _ := m.Pick(labelled, developers); name != "backend" {
+		t.Fatalf("expected backend for the gateway label, got %s", name)
+	}
+	other, _ := b.CreateCard("Write docs", "", board.ListBacklog)
+	if name, _ := m.Pick(other, developers); name != "backend" {
+		t.Fatalf("expected the first developer when nothing matches, got %s", name)
+	}
+}
+
+func TestEngineeringManagerAssignsTickets(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 3},
+		{Title: "Add page", Description: "Show health.", StoryPoints: 2},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	em.Developers = developers
+	created, err := em.HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	for i, want := range []string{"backend", "frontend"} {
+		members, _ := created[i].GetAssignedMembers()
+		if len(members) != 1 || members[0].Name != want {
+			t.Fatalf("ticket %d: expected %s, got %v", i, want, members)
+		}
+	}
+	comments, _ := parent.ReadComments()
+	if len(comments) == 0 || !strings.Contains(comments[len(comments)-1].Text, "assigned to frontend") {
+		t.Fatalf("expected assignees in the summary, got %v", comments)
+	}
+}
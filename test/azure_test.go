@@ -0,0 +1,92 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/azure"
+)
+
+// capturingTransport answers every request with a fixed model response and keeps the requests.
+type capturingTransport struct {
+	requests []*http.Request
+	bodies   []string
+}
+
+func (c *capturingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, string(body))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"output": [{"type": "message", "content": [{"text": "Friday"}]}]}`)),
+	}, nil
+}
+
+func TestAzureClientSendsToDeployment(t *testing.T) {
+	t.Chdir(t.TempDir()) // The client writes a debug log to the working directory.
+	transport := &capturingTransport{}
+	c := azure.NewClient("https://contoso.openai.azure.com/", "", azure.KeyAuth("secret"), map[string]string{"gpt-4o": "prod-4o"})
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	if resp, err := c.ChatAdvanced(model.ChatRequest{Model: "gpt-4o", Input: []model.Message{{Role: "user", Content: "Which day?"}}}); err != nil || resp != "Friday" {
+		t.Fatalf("ChatAdvanced failed: %q, %v", resp, err)
+	}
+	if _, err := c.Chat("Which day?"); err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+
+	req := transport.requests[0]
+	if got := req.URL.String(); got != "https://contoso.openai.azure.com/openai/responses?api-version="+azure.DefaultAPIVersion {
+		t.Fatalf("unexpected URL %s", got)
+	}
+	if req.Header.Get("api-key") != "secret" || req.Header.Get("Authorization") != "" {
+		t.Fatalf("expected key authentication, got %v", req.Header)
+	}
+	for i, want := range []string{"prod-4o", "gpt-4o-mini"} {
+		var body struct{ Model string }
+		json.Unmarshal([]byte(transport.bodies[i]), &body)
+		if body.Model != want {
+			t.Fatalf("request %d: expected deployment %s, got %s", i, want, body.Model)
+		}
+	}
+}
+
+func TestAzureClientCredentialsCachesToken(t *testing.T) {
+	issued := 0
+	login := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.Form.Get("client_secret") != "s3cret" || r.Form.Get("grant_type") != "client_credentials" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		issued++
+		w.Write([]byte(`{"access_token": "token-1", "expires_in": 3600}`))
+	}))
+	defer login.Close()
+
+	creds := &azure.ClientCredentials{TenantID: "tenant", ClientID: "app", ClientSecret: "s3cret", Authority: login.URL}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("POST", "https://contoso.openai.azure.com/openai/responses", nil)
+		if err := creds.Authorize(req); err != nil {
+			t.Fatalf("Authorize failed: %v", err)
+		}
+		if req.Header.Get("Authorization") != "Bearer token-1" {
+			t.Fatalf("expected the bearer token, got %q", req.Header.Get("Authorization"))
+		}
+	}
+	if issued != 1 {
+		t.Fatalf("expected the token to be cached, issued %d", issued)
+	}
+
+	creds = &azure.ClientCredentials{TenantID: "tenant", ClientID: "app", ClientSecret: "wrong", Authority: login.URL}
+	if _, err := creds.Token(); err == nil {
+		t.Fatal("expected a rejected secret to fail")
+	}
+}
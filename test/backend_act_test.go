@@ -0,0 +1,78 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// modeCalls counts the requests built for the given prompt mode.
+func modeCalls(m *sim.ScriptedModel, mode string) int {
+	n := 0
+	for _, call := range m.Calls() {
+		if strings.Contains(requestContent(call.Input[0].Content), "Mode: "+mode+"\n") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBackendActImplementsTicketOnce(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "hello.txt", Content: "hello\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	card, _ := s.Board.CreateCard("Add greeting file", "Write hello.txt.", board.ListSprint)
+	card.AssignTo("backend")
+	qa, _ := s.Board.CreateCard("Add farewell file", "Write bye.txt.", board.ListQA)
+	qa.AssignTo("backend")
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+
+	for round := 0; round < 3; round++ {
+		if err := a.Act(); err != nil {
+			t.Fatalf("Act failed: %v", err)
+		}
+	}
+	if n := modeCalls(m, "Implement"); n != 1 {
+		t.Fatalf("expected one implementation, got %d", n)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListReview {
+		t.Fatalf("expected the ticket in Review, got %s", list.GetName())
+	}
+	if list, _ := qa.GetList(); list.GetName() != board.ListQA {
+		t.Fatalf("ticket in QA moved to %s", list.GetName())
+	}
+}
+
+func TestAgentsBuiltOnBackendExposeIt(t *testing.T) {
+	s, err := sim.New(nil)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	for _, role := range []string{agent.RoleBackend, agent.RoleDevOps, agent.RoleFrontend, agent.RoleDocs, agent.RoleAPISpec} {
+		a, err := s.Agent(role, role)
+		if err != nil {
+			t.Fatalf("Agent(%s) failed: %v", role, err)
+		}
+		coder, ok := a.(interface{ Backend() *agent.BackendAgent })
+		if !ok {
+			t.Fatalf("%s does not expose its Backend agent", role)
+		}
+		coder.Backend().TestRepairs = 2
+		if coder.Backend().Name != role || coder.Backend().TestRepairs != 2 {
+			t.Fatalf("%s returned another Backend agent", role)
+		}
+	}
+}
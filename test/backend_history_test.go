@@ -0,0 +1,57 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestImplementPromptDescribesFileHistory(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "greet.md", Content: "Hello!\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	for _, f := range []struct{ path, message string }{
+		{"greet.md", "docs: keep the greeting short for the kiosk"},
+		{"other.md", "docs: add other notes"},
+	} {
+		if err := s.Repo.WriteFile(f.path, []byte("Hi\n")); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := s.Repo.CommitChanges(f.message, "alice", "alice@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	card, _ := s.Board.CreateCard("Friendlier greeting", "Make greet.md friendlier.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if err := a.(*agent.BackendAgent).ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "Friendlier greeting") {
+			prompt = text
+			break
+		}
+	}
+	for _, want := range []string{"Recent commits for greet.md:", "docs: keep the greeting short for the kiosk (alice,", "alice: 1 line(s)"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("expected %q in the implementation prompt:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "other.md") {
+		t.Fatalf("expected only the mentioned files' history:\n%s", prompt)
+	}
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestImplementTicketAppliesPatches(t *testing.T) {
+	m := sim.NewScriptedModel()
+	stale := "--- a/README.md\n+++ b/README.md\n@@ -1,2 +1,2 @@\n # Greet\n-Says hi.\n+Says hello.\n"
+	fixed := "--- a/README.md\n+++ b/README.md\n@@ -1,2 +1,2 @@\n # Greet\n-Says hello to everyone.\n+Says hello to everyone, politely.\n"
+	if _, err := m.OnMode("Implement", agent.Implementation{Patches: []string{stale}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("RepairPatch", agent.PatchRepair{Patch: fixed}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	if err := s.Repo.WriteFile("README.md", []byte("# Greet\nSays hello to everyone.\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := s.Repo.CommitChanges("docs: add readme", "human", "human@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	card, _ := s.Board.CreateCard("Be polite", "Greet politely.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if err := a.(*agent.BackendAgent).ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+
+	if content, _ := s.RepoFile(card.GetID(), "README.md"); content != "# Greet\nSays hello to everyone, politely.\n" {
+		t.Fatalf("expected the repaired patch to be applied, got %q", content)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "does not apply") {
+			prompt = text
+		}
+	}
+	for _, want := range []string{"README.md hunk #1 at line 1", "-Says hi.", "Current README.md:", "Says hello to everyone."} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("expected %q in the repair prompt:\n%s", want, prompt)
+		}
+	}
+	if n := modeCalls(m, "RepairPatch"); n != 1 {
+		t.Fatalf("expected one repair request, got %d", n)
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/testrunner"
+)
+
+func TestCompareBench(t *testing.T) {
+	output := func(ns ...string) string {
+		out := "goos: linux\npkg: example.com/calc\n"
+		for _, v := range ns {
+			out += "BenchmarkSum-8   \t 1000\t " + v + " ns/op\t 16 B/op\t 1 allocs/op\n"
+		}
+		return out + "PASS\nok  \texample.com/calc\t1.2s\n"
+	}
+	before, err := testrunner.ParseBench(strings.NewReader(output("100", "102", "98", "101", "99", "100")))
+	if err != nil || len(before["example.com/calc.BenchmarkSum"]["ns/op"]) != 6 {
+		t.Fatalf("unexpected results %v, %v", before, err)
+	}
+	slower, _ := testrunner.ParseBench(strings.NewReader(output("150", "152", "149", "151", "150", "148")))
+	noisy, _ := testrunner.ParseBench(strings.NewReader(output("99", "103", "97", "101", "100", "102")))
+
+	deltas := testrunner.CompareBench(before, slower)
+	if len(deltas) != 3 || deltas[0].Unit != "ns/op" || deltas[0].Change != 50 || !deltas[0].Significant(0.05) {
+		t.Fatalf("expected a significant 50%% slowdown first, got %+v", deltas)
+	}
+	if deltas[1].Unit != "B/op" || deltas[1].Significant(0.05) {
+		t.Fatalf("expected unchanged allocations, got %+v", deltas[1])
+	}
+	if d := testrunner.CompareBench(before, noisy)[0]; d.Significant(0.05) {
+		t.Fatalf("expected noise not to be significant, got %+v", d)
+	}
+	table := testrunner.FormatBench(deltas, 0.05)
+	for _, want := range []string{"old ns/op  new ns/op  delta", "100 ± 2%", "150 ± 1%", "+50.00% (p=0.002 n=6+6)", "~ (p=1.000 n=6+6)"} {
+		if !strings.Contains(table, want) {
+			t.Fatalf("expected %q in:\n%s", want, table)
+		}
+	}
+}
+
+func TestBenchmarkAgentBlocksRegression(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"go.mod":              "module example.com/calc\n\ngo 1.24\n",
+		"calc/sum.go":         "package calc\n\n// Sum adds the numbers up to n.\nfunc Sum(n int) int {\n\ttotal := 0\n\tfor i := 0; i < n; i++ {\n\t\ttotal += i\n\t}\n\treturn total\n}\n",
+		"calc/sum_test.go":    "package calc\n\nimport \"testing\"\n\nfunc BenchmarkSum(b *testing.B) {\n\tfor i := 0; i < b.N; i++ {\n\t\tSum(100)\n\t}\n}\n",
+		"other/other.go":      "package other\n",
+		"other/other_test.go": "package other\n",
+	})
+	if err := s.Repo.CommitChanges("feat: add sum", "human", "human@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	parent, _ := s.Board.CreateCard("Faster maths", "", board.ListBacklog)
+	card, _ := s.Board.CreateCard("Rewrite sum", board.WithParentLink("Make Sum clearer.", parent), board.ListDoing)
+	repo, err := s.Repo.WorktreeFor(card.GetID())
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	slow := "package calc\n\nimport \"time\"\n\n// Sum adds the numbers up to n.\nfunc Sum(n int) int {\n\ttime.Sleep(200 * time.Microsecond)\n\treturn n * (n - 1) / 2\n}\n"
+	if err := repo.WriteFile("calc/sum.go", []byte(slow)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := repo.CommitChanges("refactor: compute sum directly", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	a, err := s.Agent(agent.RoleBenchmark, agent.RoleBenchmark)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	bench := a.(*agent.BenchmarkAgent)
+	bench.BaseBranch = "master"
+	bench.Bench = testrunner.BenchOptions{Count: 5, BenchTime: "20x"}
+
+	deltas, err := bench.Benchmark(card)
+	if !errors.Is(err, agent.ErrBenchmarkRegression) || len(deltas) == 0 || deltas[0].Name != "example.com/calc/calc.BenchmarkSum" {
+		t.Fatalf("expected a regression in BenchmarkSum, got %+v, %v", deltas, err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListBlocked {
+		t.Fatalf("expected the ticket blocked, got %s", list.GetName())
+	}
+	comments, _ := card.ReadComments()
+	var report string
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, "[benchmarks]") {
+			report = c.Text
+		}
+	}
+	if !strings.Contains(report, "Regressions above 20%: example.com/calc/calc.BenchmarkSum +") || !strings.Contains(report, "old ns/op") {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+
+	if deltas, err := bench.Benchmark(card); err != nil || deltas != nil {
+		t.Fatalf("expected the commit benchmarked once, got %v, %v", deltas, err)
+	}
+}
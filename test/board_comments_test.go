@@ -0,0 +1,89 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestReadCommentsSinceFallsBackToFiltering(t *testing.T) {
+	b := sim.NewBoard()
+	card, _ := b.CreateCard("Ticket", "", board.ListBacklog)
+	card.WriteComment("first")
+	all, _ := card.ReadComments()
+	since := all[0].Created
+	time.Sleep(time.Millisecond)
+	card.(*sim.Card).Reply("human", "second")
+
+	comments, err := board.ReadCommentsSince(card, since)
+	if err != nil {
+		t.Fatalf("ReadCommentsSince failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != "second" {
+		t.Fatalf("expected only the new comment, got %v", comments)
+	}
+
+	wrapped, _ := board.Pausable(b).GetCardsFromList(board.ListBacklog)
+	if comments, _ := board.ReadCommentsSince(wrapped[0], since); len(comments) != 1 {
+		t.Fatalf("expected the wrapped card to filter too, got %v", comments)
+	}
+}
+
+func TestTrelloReadCommentsPages(t *testing.T) {
+	const total = 1500
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/c1/actions" {
+			http.NotFound(w, r)
+			return
+		}
+		requests = append(requests, r.URL.RawQuery)
+		// Newest first, like Trello: action i was posted i minutes after base.
+		start := total - 1
+		if before := r.URL.Query().Get("before"); before != "" {
+			fmt.Sscanf(before, "a%d", &start)
+			start--
+		}
+		var actions []map[string]interface{}
+		for i := start; i >= 0 && len(actions) < 1000; i-- {
+			actions = append(actions, map[string]interface{}{
+				"id":   fmt.Sprintf("a%d", i),
+				"date": base.Add(time.Duration(i) * time.Minute),
+				"data": map[string]string{"text": fmt.Sprintf("comment %d", i)},
+			})
+		}
+		json.NewEncoder(w).Encode(actions)
+	}))
+	defer srv.Close()
+
+	tc := trelloClient.NewTrelloClient("key", "token", "b1")
+	tc.Client.BaseURL = srv.URL
+	card := &trelloClient.TrelloCard{ID: "c1", BoardClient: tc, Client: tc.Client}
+
+	comments, err := card.ReadComments()
+	if err != nil {
+		t.Fatalf("ReadComments failed: %v", err)
+	}
+	if len(comments) != total || len(requests) != 2 {
+		t.Fatalf("expected %d comments in 2 pages, got %d in %d", total, len(comments), len(requests))
+	}
+	if comments[0].Text != "comment 1499" || !comments[0].Created.Equal(base.Add(1499*time.Minute)) {
+		t.Fatalf("unexpected newest comment: %+v", comments[0])
+	}
+
+	recent, err := board.ReadCommentsSince(card, base.Add(1497*time.Minute))
+	if err != nil {
+		t.Fatalf("ReadCommentsSince failed: %v", err)
+	}
+	if len(recent) != 2 || recent[1].Text != "comment 1498" {
+		t.Fatalf("expected the two newest comments, got %v", recent)
+	}
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func decomposeWithFields(t *testing.T, fields ...string) (*sim.Scenario, *sim.Card) {
+	t.Helper()
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 3, Repo: "api", Component: "gateway", Priority: "high"},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	s.Board.Fields = fields
+
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if _, err := a.(*agent.EngineeringManagerAgent).HandleTicket(parent); err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	return s, s.Board.Card("Add endpoint")
+}
+
+func TestDecompositionUsesCustomFields(t *testing.T) {
+	_, child := decomposeWithFields(t, "Points", "Repo", "Component", "Priority")
+
+	if strings.Contains(child.GetDescription(), "Estimate:") || strings.Contains(child.GetDescription(), "Repo:") {
+		t.Fatalf("metadata should live in fields, not the description:\n%s", child.GetDescription())
+	}
+	if v, ok := board.CardEstimate(child); !ok || v != 3 {
+		t.Fatalf("expected 3 points, got %v, %v", v, ok)
+	}
+	if board.RepoHint(child) != "api" || board.Field(child, board.FieldComponent) != "gateway" || board.Field(child, board.FieldPriority) != "high" {
+		t.Fatalf("unexpected fields: %v", child)
+	}
+}
+
+func TestDecompositionFallsBackToDescription(t *testing.T) {
+	_, child := decomposeWithFields(t)
+
+	if v, ok := board.Estimate(child.GetDescription()); !ok || v != 3 {
+		t.Fatalf("expected the estimate line, got:\n%s", child.GetDescription())
+	}
+	if board.RepoHint(child) != "api" {
+		t.Fatalf("expected the repo line, got:\n%s", child.GetDescription())
+	}
+	if board.Field(child, board.FieldPriority) != "" {
+		t.Fatalf("undefined fields must stay empty")
+	}
+}
+
+func TestTrelloCardSetsCustomFields(t *testing.T) {
+	var puts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/customFields"):
+			w.Write([]byte(`[{"id":"f1","name":"Points","type":"number"},
+				{"id":"f2","name":"Priority","type":"list","options":[{"id":"o1","idCustomField":"f2","value":{"text":"High"}}]}]`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/boards/"):
+			w.Write([]byte(`{"id":"b1","name":"Board"}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/cards/"):
+			w.Write([]byte(`{"id":"c1","customFieldItems":[{"idCustomField":"f1","value":{"number":"5"}},{"idCustomField":"f2","idValue":"o1"}]}`))
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			puts = append(puts, r.URL.Path+" "+string(body))
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tc := trelloClient.NewTrelloClient("key", "token", "b1")
+	tc.Client.BaseURL = srv.URL
+	card := &trelloClient.TrelloCard{ID: "c1", BoardClient: tc, Client: tc.Client}
+
+	fields, err := card.GetFields()
+	if err != nil || fields["points"] != "5" || fields["priority"] != "High" {
+		t.Fatalf("unexpected fields: %v, %v", fields, err)
+	}
+	if err := card.SetField("points", "8"); err != nil {
+		t.Fatalf("SetField failed: %v", err)
+	}
+	if err := card.SetField("priority", "high"); err != nil {
+		t.Fatalf("SetField failed: %v", err)
+	}
+	if err := card.SetField("component", "api"); !errors.Is(err, board.ErrNoField) {
+		t.Fatalf("expected ErrNoField, got %v", err)
+	}
+	if len(puts) != 2 {
+		t.Fatalf("expected two updates, got %v", puts)
+	}
+	var number, option map[string]interface{}
+	json.Unmarshal([]byte(strings.SplitN(puts[0], " ", 2)[1]), &number)
+	json.Unmarshal([]byte(strings.SplitN(puts[1], " ", 2)[1]), &option)
+	if !strings.HasPrefix(puts[0], "/cards/c1/customField/f1/item") || number["value"].(map[string]interface{})["number"] != "8" {
+		t.Fatalf("unexpected number update: %s", puts[0])
+	}
+	if option["idValue"] != "o1" {
+		t.Fatalf("unexpected option update: %s", puts[1])
+	}
+}
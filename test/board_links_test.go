@@ -0,0 +1,66 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+func TestParentChildLinking(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListDone)
+
+	parent, _ := b.CreateCard("Checkout flow", "High-level ticket", board.ListBacklog)
+	child1, _ := b.CreateCard("Add cart endpoint", board.WithParentLink("Implement POST /cart", parent), board.ListBacklog)
+	child2, _ := b.CreateCard("Add payment client", "", board.ListBacklog)
+	if _, err := b.CreateCard("Unrelated", "No parent", board.ListBacklog); err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+
+	if err := board.LinkToParent(child2, parent); err != nil {
+		t.Fatalf("LinkToParent failed: %v", err)
+	}
+	if url, ok := board.ParentURL(child2.GetDescription()); !ok || url != parent.GetURL() {
+		t.Fatalf("expected parent URL %q, got %q (found=%v)", parent.GetURL(), url, ok)
+	}
+
+	children, err := board.GetChildren(b, parent)
+	if err != nil {
+		t.Fatalf("GetChildren failed: %v", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+
+	if err := child1.Move(board.ListDone); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	rollup, err := board.RollupStatus(b, parent, board.ListDone)
+	if err != nil {
+		t.Fatalf("RollupStatus failed: %v", err)
+	}
+	if rollup.Total != 2 || rollup.Done != 1 || rollup.Complete() {
+		t.Fatalf("unexpected rollup: %+v", rollup)
+	}
+
+	if err := child2.Move(board.ListDone); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	rollup, _ = board.RollupStatus(b, parent, board.ListDone)
+	if !rollup.Complete() {
+		t.Fatalf("expected rollup to be complete: %+v", rollup)
+	}
+}
+
+func TestEstimateSection(t *testing.T) {
+	desc := board.WithEstimate("Add the login endpoint.", 5)
+	if v, ok := board.Estimate(desc); !ok || v != 5 {
+		t.Fatalf("expected estimate 5, got %v (found=%v)", v, ok)
+	}
+	desc = board.WithEstimate(desc, 2.5)
+	if v, _ := board.Estimate(desc); v != 2.5 {
+		t.Fatalf("estimate not replaced: %q", desc)
+	}
+	if _, ok := board.Estimate("no estimate here"); ok {
+		t.Fatalf("unexpected estimate")
+	}
+}
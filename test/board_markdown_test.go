@@ -0,0 +1,101 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestCodeBlockAndCloseFences(t *testing.T) {
+	block := board.CodeBlock("fmt.Println(\"```\")\n", "go")
+	if !strings.HasPrefix(block, "````go\n") || !strings.HasSuffix(block, "\n````") {
+		t.Fatalf("expected a four-backtick fence, got %q", block)
+	}
+	if got := board.CloseFences("Log:\n```\npanic: boom\n"); got != "Log:\n```\npanic: boom\n```" {
+		t.Fatalf("unexpected closed text: %q", got)
+	}
+	if got := board.CloseFences("no code"); got != "no code" {
+		t.Fatalf("text without code changed: %q", got)
+	}
+}
+
+func TestSplitCommentKeepsCodeBlocksBalanced(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("Here is the diff:\n```diff\n")
+	for i := 0; i < 200; i++ {
+		sb.WriteString("+ added a fairly long line of code number to the file\n")
+	}
+	sb.WriteString("```\nDone.")
+	parts := board.SplitComment(sb.String(), 1000)
+	if len(parts) < 10 {
+		t.Fatalf("expected many parts, got %d", len(parts))
+	}
+	for i, p := range parts {
+		if len(p) > 1000 {
+			t.Fatalf("part %d is %d bytes", i+1, len(p))
+		}
+		if !strings.HasPrefix(p, "(") || !strings.Contains(p, "/") {
+			t.Fatalf("part %d lacks a header: %q", i+1, p[:20])
+		}
+		if strings.Count(p, "```")%2 != 0 {
+			t.Fatalf("part %d has an unbalanced fence:\n%s", i+1, p)
+		}
+		if i > 0 && i < len(parts)-1 && !strings.Contains(p, "```diff\n") {
+			t.Fatalf("part %d does not reopen the diff block", i+1)
+		}
+	}
+	if !strings.HasSuffix(parts[len(parts)-1], "Done.") {
+		t.Fatalf("last part lost the tail: %q", parts[len(parts)-1])
+	}
+	if got := board.SplitComment("short", 1000); len(got) != 1 || got[0] != "short" {
+		t.Fatalf("short text was split: %v", got)
+	}
+}
+
+func TestTrelloWriteCommentSplitsAndAttaches(t *testing.T) {
+	var comments []string
+	var uploads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/c1/actions/comments":
+			r.ParseForm()
+			comments = append(comments, r.PostForm.Get("text"))
+		case "/cards/c1/attachments":
+			if _, _, err := r.FormFile("file"); err == nil {
+				uploads++
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	tc := trelloClient.NewTrelloClient("key", "token", "b1")
+	tc.Client.BaseURL = srv.URL
+	card := &trelloClient.TrelloCard{ID: "c1", BoardClient: tc, Client: tc.Client}
+
+	line := strings.Repeat("x", 99) + "\n"
+	if err := card.WriteComment(strings.Repeat(line, 300)); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+	if len(comments) != 2 || !strings.HasPrefix(comments[0], "(1/2)") || uploads != 0 {
+		t.Fatalf("expected two numbered comments, got %d (uploads %d)", len(comments), uploads)
+	}
+
+	comments = nil
+	if err := card.WriteComment(strings.Repeat(line, 1000)); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+	if uploads != 1 || len(comments) != 1 || !strings.Contains(comments[0], "attached as comment-") {
+		t.Fatalf("expected an attachment and one comment, got %d upload(s), %d comment(s)", uploads, len(comments))
+	}
+	if len(comments[0]) > trelloClient.MaxCommentLength {
+		t.Fatalf("truncated comment is %d bytes", len(comments[0]))
+	}
+}
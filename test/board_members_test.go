@@ -0,0 +1,73 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+var boardMembers = []board.Member{
+	{ID: "1", Name: "Backend Agent", Username: "egobogobackendagent"},
+	{ID: "2", Name: "Frontend Agent", Username: "egobogofrontendagent"},
+	{ID: "3", Name: "Engineering Manager", Username: "egobogo_em"},
+}
+
+func TestMatchMember(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"egobogobackendagent", "1", true},
+		{"BACKEND AGENT", "1", true},
+		{"@egobogo_em", "3", true},
+		{"engineering-manager", "3", true},
+		{"frontend", "2", true},
+		{"egobogobakendagent", "1", true}, // Typo.
+		{"agent", "", false},              // Matches both agents.
+		{"designer", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		m, ok := board.MatchMember(boardMembers, c.name)
+		if ok != c.ok || m.ID != c.want {
+			t.Errorf("MatchMember(%q) = %q, %v; want %q, %v", c.name, m.ID, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMemberDirectoryCachesAndRefetches(t *testing.T) {
+	members := boardMembers[:2]
+	fetches := 0
+	d := board.NewMemberDirectory(func() ([]board.Member, error) {
+		fetches++
+		return members, nil
+	}, map[string]string{"manager": "egobogo_em"})
+
+	for i := 0; i < 3; i++ {
+		if _, err := d.Lookup("backend agent"); err != nil {
+			t.Fatalf("Lookup failed: %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("expected one fetch, got %d", fetches)
+	}
+
+	// The manager joins after the cache was filled; a miss refetches once.
+	members = boardMembers
+	m, err := d.Lookup("Manager")
+	if err != nil {
+		t.Fatalf("Lookup via alias failed: %v", err)
+	}
+	if m.ID != "3" || fetches != 2 {
+		t.Fatalf("expected member 3 after one refetch, got %q after %d fetches", m.ID, fetches)
+	}
+
+	if _, err := d.Lookup("designer"); err == nil {
+		t.Fatal("expected unknown member to fail")
+	}
+	d.Invalidate()
+	if _, err := d.Members(); err != nil || fetches != 4 {
+		t.Fatalf("expected refetch after Invalidate, got %d fetches (err %v)", fetches, err)
+	}
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+func TestTransitionTicket(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListDoing, board.ListReview, board.ListQA, board.ListDone, board.ListBlocked)
+	card, _ := b.CreateCard("Add login", "", board.ListBacklog)
+
+	if err := board.TransitionTicket(card, board.ListDone, "Backend", "skipping ahead"); !errors.Is(err, board.ErrIllegalTransition) {
+		t.Fatalf("expected ErrIllegalTransition, got %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListBacklog {
+		t.Fatalf("rejected move must leave the card in place, got %s", list.GetName())
+	}
+
+	for _, to := range []string{board.ListDoing, board.ListReview, board.ListBlocked, board.ListQA, board.ListDone} {
+		if err := board.TransitionTicket(card, to, "Backend", "progress"); err != nil {
+			t.Fatalf("transition to %s failed: %v", to, err)
+		}
+	}
+	if err := board.TransitionTicket(card, board.ListBlocked, "Backend", ""); err == nil {
+		t.Fatalf("done tickets must not be blocked")
+	}
+
+	comments, _ := card.ReadComments()
+	if len(comments) != 5 || !strings.Contains(comments[0].Text, "Backlog → Doing by Backend: progress") {
+		t.Fatalf("unexpected transition log: %+v", comments)
+	}
+}
+
+func TestForceTransitionRecordsUnvalidatedMove(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListDone)
+	card, _ := b.CreateCard("Greet users", "", board.ListBacklog)
+
+	if err := board.ForceTransition(card, board.ListDone, "rollup", "all technical tickets are done"); err != nil {
+		t.Fatalf("ForceTransition failed: %v", err)
+	}
+	if err := board.ForceTransition(card, board.ListDone, "rollup", ""); err != nil {
+		t.Fatalf("ForceTransition to the current list failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDone {
+		t.Fatalf("expected the card in Done, got %s", list.GetName())
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) != 1 || comments[0].Text != "[transition] Backlog → Done by rollup: all technical tickets are done" {
+		t.Fatalf("unexpected transition log: %+v", comments)
+	}
+}
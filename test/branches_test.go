@@ -0,0 +1,72 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/vcs"
+)
+
+func TestBackendUpdatesBranchFromTarget(t *testing.T) {
+	ci := &fakeCI{statuses: []vcs.PipelineStatus{vcs.PipelineSuccess}}
+	m, s, card, err := implementWithCI(t, ci, 0)
+	if err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if _, err := m.OnMode("ResolveConflict", agent.ConflictResolution{Resolution: "hi\n", Confidence: 0.2}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.CI = &agent.CIGate{Provider: ci, TargetBranch: "master"}
+
+	if updated, err := backend.UpdateBranch(card); err != nil || updated {
+		t.Fatalf("expected an up-to-date branch to be left alone, got %v (%v)", updated, err)
+	}
+
+	commit := func(path, content, message string) {
+		t.Helper()
+		if err := s.Repo.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := s.Repo.CommitChanges(message, "human", "human@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	commit("README.md", "# Greetings\n", "docs: add readme")
+	if updated, err := backend.UpdateBranch(card); err != nil || !updated {
+		t.Fatalf("expected the branch to be updated, got %v (%v)", updated, err)
+	}
+	if content, _ := s.RepoFile(card.GetID(), "README.md"); content != "# Greetings\n" {
+		t.Fatalf("expected the target's changes pushed on the ticket branch, got %q", content)
+	}
+	if content, _ := s.RepoFile(card.GetID(), "hello.txt"); content != "helo\n" {
+		t.Fatalf("expected the ticket's work kept, got %q", content)
+	}
+	comments, _ := card.ReadComments()
+	if last := comments[len(comments)-1].Text; !strings.Contains(last, "Merged 1 new commit(s) from master") {
+		t.Fatalf("expected the update reported, got %q", last)
+	}
+
+	// A conflict the model is not confident about is left to a human.
+	commit("hello.txt", "hi\n", "feat: greet briefly")
+	_, err = backend.UpdateBranch(card)
+	if !errors.Is(err, agent.ErrBranchConflicts) {
+		t.Fatalf("expected ErrBranchConflicts, got %v", err)
+	}
+	if content, _ := s.RepoFile(card.GetID(), "hello.txt"); content != "helo\n" {
+		t.Fatalf("expected the ticket branch untouched, got %q", content)
+	}
+	comments, _ = card.ReadComments()
+	if last := comments[len(comments)-1].Text; !strings.Contains(last, "conflicts in hello.txt") {
+		t.Fatalf("expected an escalation on the card, got %q", last)
+	}
+	if err := backend.MaintainBranches(); err != nil {
+		t.Fatalf("expected conflicts not to stop maintenance, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestVerifyGoReportsFailingStep(t *testing.T) {
+	if err := codegen.VerifyGo(t.TempDir()); err != nil {
+		t.Fatalf("expected directories without go.mod to be skipped, got %v", err)
+	}
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"go.mod":  "module example.com/calc\n\ngo 1.24\n",
+		"calc.go": "package calc\n\nimport \"fmt\"\n\nfunc Show(n int) string { return fmt.Sprintf(\"%s\", n) }\n",
+	})
+	var buildErr *codegen.BuildError
+	if err := codegen.VerifyGo(dir); !errors.As(err, &buildErr) || buildErr.Step != "go vet ./..." || !strings.Contains(buildErr.Output, "Sprintf") {
+		t.Fatalf("expected vet to fail on the format verb, got %v", err)
+	}
+	writeModule(t, dir, map[string]string{"calc.go": "package calc\n\nfunc Show(n int) string { return n }\n"})
+	if err := codegen.VerifyGo(dir); !errors.As(err, &buildErr) || buildErr.Step != "go build ./..." {
+		t.Fatalf("expected the build to fail, got %v", err)
+	}
+}
+
+func TestCommitWorkRepairsBuildErrors(t *testing.T) {
+	m := sim.NewScriptedModel()
+	fixed := "package calc\n\nfunc Add(a, b int) int { return a + b }\n"
+	rule, err := m.OnMode("RepairBuild", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc.go", Content: fixed}}})
+	if err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	rule.Times = 1
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"go.mod":  "module example.com/calc\n\ngo 1.24\n",
+		"calc.go": "package calc\n\nfunc Add(a, b int) int { return a + c }\n",
+	})
+	card, _ := s.Board.CreateCard("Add", "", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.MaxRepairAttempts = 2
+	if err := backend.CommitWork(s.Repo, card, "feat: add addition", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitWork failed: %v", err)
+	}
+	if content, _ := s.RepoFile("", "calc.go"); content != fixed {
+		t.Fatalf("expected the repaired file to be committed, got %q", content)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "does not compile") {
+			prompt = text
+		}
+	}
+	if n := modeCalls(m, "RepairBuild"); n != 1 || !strings.Contains(prompt, "undefined: c") {
+		t.Fatalf("expected one repair request with the compiler error, got %d:\n%s", n, prompt)
+	}
+
+	// Without a working fix the errors go to the card and nothing is committed.
+	broken := "package calc\n\nfunc Add(a, b int) int { return a + d }\n"
+	writeModule(t, s.Repo.RepoPath, map[string]string{"calc.go": broken})
+	if _, err := m.OnMode("RepairBuild", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc.go", Content: broken}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	err = backend.CommitWork(s.Repo, card, "fix: add the right operand", "backend", "backend@aiagents.local")
+	if !errors.Is(err, agent.ErrBuildFailed) {
+		t.Fatalf("expected ErrBuildFailed, got %v", err)
+	}
+	if n := modeCalls(m, "RepairBuild"); n != 3 {
+		t.Fatalf("expected two more repair attempts, got %d in total", n)
+	}
+	if comment := lastComment(t, card.(*sim.Card)); !strings.Contains(comment, "still failing after 2 repair attempt(s)") || !strings.Contains(comment, "undefined: d") {
+		t.Fatalf("expected the build errors on the card, got %q", comment)
+	}
+	if changed, _ := s.Repo.ChangedFiles(); strings.Join(changed, ",") != "calc.go" {
+		t.Fatalf("expected the broken code to stay uncommitted, got changes %v", changed)
+	}
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestParseCardCommand(t *testing.T) {
+	cmd, ok := orchestrator.ParseCardCommand("  /Assign @backend @frontend\nplease")
+	if !ok || cmd.Name != "assign" || len(cmd.Args) != 2 || cmd.Args[0] != "@backend" {
+		t.Fatalf("unexpected parse: %+v, %v", cmd, ok)
+	}
+	for _, text := range []string{"looks good", "/", "see /retry"} {
+		if _, ok := orchestrator.ParseCardCommand(text); ok {
+			t.Errorf("expected %q not to be a command", text)
+		}
+	}
+}
+
+// lastComment returns the text of the card's latest comment.
+func lastComment(t *testing.T, card *sim.Card) string {
+	t.Helper()
+	comments, _ := card.ReadComments()
+	if len(comments) == 0 {
+		t.Fatal("expected a comment")
+	}
+	return comments[len(comments)-1].Text
+}
+
+func TestCommandRuleRunsEachCommandOnce(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	store, err := checkpoint.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	c, _ := s.Board.CreateCard("Add login", "", board.ListDoing)
+	card := c.(*sim.Card)
+	card.AssignTo("backend")
+	store.Save(checkpoint.New("backend", card.GetID()))
+
+	rule := orchestrator.NewCommandRule()
+	rule.Checkpoints = store
+	card.Reply("alice", "/abort")
+	if err := rule.Apply(s.Board); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListBlocked {
+		t.Fatalf("expected the card in %s, got %s", board.ListBlocked, list.GetName())
+	}
+	if members, _ := card.GetAssignedMembers(); len(members) != 0 {
+		t.Fatalf("expected the card unassigned, got %v", members)
+	}
+	if text := lastComment(t, card); !strings.HasPrefix(text, "[command] /abort\n") {
+		t.Fatalf("expected an acknowledgement, got %q", text)
+	}
+
+	card.Reply("alice", "/assign @backend")
+	card.Reply("alice", "/retry")
+	card.Reply("alice", "/frobnicate")
+	rule.Apply(s.Board)
+	rule.Apply(s.Board) // Acknowledged commands do not run again.
+	comments, _ := card.ReadComments()
+	// Four commands, their acknowledgements and the recorded moves of /abort and /retry.
+	if len(comments) != 10 {
+		t.Fatalf("expected one acknowledgement per command, got %d comments", len(comments))
+	}
+	if text := lastComment(t, card); !strings.Contains(text, "Unknown command /frobnicate") {
+		t.Fatalf("expected the unknown command reported, got %q", text)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDoing {
+		t.Fatalf("expected the card back in %s, got %s", board.ListDoing, list.GetName())
+	}
+	if members, _ := card.GetAssignedMembers(); len(members) != 1 || members[0].Name != "backend" {
+		t.Fatalf("expected the card assigned to backend, got %v", members)
+	}
+	if cp, _ := store.Load("backend", card.GetID()); cp != nil {
+		t.Fatal("expected /retry to clear the checkpoint")
+	}
+
+	rule.Trusted = []string{"alice"}
+	card.Reply("mallory", "/abort")
+	rule.Apply(s.Board)
+	if text := lastComment(t, card); !strings.Contains(text, "Ignored") {
+		t.Fatalf("expected the untrusted command ignored, got %q", text)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDoing {
+		t.Fatalf("expected the card to stay in %s, got %s", board.ListDoing, list.GetName())
+	}
+}
+
+func TestCommandRuleRefusesIllegalMoves(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Add login", "", board.ListDone)
+	card := c.(*sim.Card)
+
+	card.Reply("alice", "/retry")
+	if err := orchestrator.NewCommandRule().Apply(s.Board); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDone {
+		t.Fatalf("done ticket moved to %s", list.GetName())
+	}
+	if text := lastComment(t, card); !strings.Contains(text, "illegal ticket transition") {
+		t.Fatalf("expected the refusal reported, got %q", text)
+	}
+}
+
+func TestManagerEstimatesOnCommand(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Estimate", agent.TicketEstimate{StoryPoints: 5, Reason: "New endpoint and migration."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	a, err := s.Agent(agent.RoleEngineeringManager, "manager")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	rule := orchestrator.NewCommandRule()
+	a.(*agent.EngineeringManagerAgent).RegisterCardCommands(rule)
+	c, _ := s.Board.CreateCard("Add login", "Users sign in with email.", board.ListBacklog)
+	card := c.(*sim.Card)
+	card.Reply("alice", "/estimate")
+	if err := rule.Apply(s.Board); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if points, ok := board.CardEstimate(card); !ok || points != 5 {
+		t.Fatalf("expected an estimate of 5, got %v, %v", points, ok)
+	}
+	if text := lastComment(t, card); !strings.Contains(text, "Estimated at 5 story points") {
+		t.Fatalf("expected the estimate acknowledged, got %q", text)
+	}
+}
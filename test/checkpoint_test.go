@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/checkpoint"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	store, err := checkpoint.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	if cp, err := store.Load("backend", "card/1"); err != nil || cp != nil {
+		t.Fatalf("expected no checkpoint, got %+v, %v", cp, err)
+	}
+
+	cp := checkpoint.New("backend", "card/1")
+	cp.Phase, cp.Pending = checkpoint.PhaseQuestionPosted, "Which database?"
+	cp.MarkCreated("Add table", "c42")
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := store.Load("backend", "card/1")
+	if err != nil || loaded == nil {
+		t.Fatalf("Load failed: %+v, %v", loaded, err)
+	}
+	if loaded.Pending != "Which database?" || loaded.Created["Add table"] != "c42" || loaded.Phase != checkpoint.PhaseTicketsCreated {
+		t.Fatalf("unexpected checkpoint: %+v", loaded)
+	}
+
+	if err := store.Clear("backend", "card/1"); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if cp, _ := store.Load("backend", "card/1"); cp != nil {
+		t.Fatalf("checkpoint should be gone, got %+v", cp)
+	}
+}
+
+func TestEngineeringManagerResumesDecomposition(t *testing.T) {
+	m := sim.NewScriptedModel()
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	store, err := checkpoint.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	s.Checkpoints = store
+
+	parent, err := s.Board.CreateCard("Greet users", "Users should be greeted.", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	first, err := s.Board.CreateCard("Add greeting file", "Write hello.txt.", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+
+	// The previous run decomposed the ticket and created the first child before it died.
+	plan, _ := json.Marshal([]agent.TechnicalTicket{
+		{Title: "Add greeting file", Description: "Write hello.txt.", StoryPoints: 1},
+		{Title: "Show greeting", Description: "Print hello.txt.", StoryPoints: 2},
+	})
+	cp := checkpoint.New(agent.RoleEngineeringManager, parent.GetID())
+	cp.Plan, cp.Phase = plan, checkpoint.PhasePlanned
+	cp.MarkCreated("Add greeting file", first.GetID())
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	created, err := a.(*agent.EngineeringManagerAgent).HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	if len(created) != 2 || created[0].GetID() != first.GetID() || created[1].GetName() != "Show greeting" {
+		t.Fatalf("unexpected created cards: %v", created)
+	}
+	if names := s.Board.CardNames(board.ListBacklog); len(names) != 3 {
+		t.Fatalf("expected no duplicate cards, backlog is %v", names)
+	}
+	for _, call := range m.Calls() {
+		if strings.Contains(fmt.Sprint(call.Input[0].Content), "Mode: Decompose") {
+			t.Fatalf("resumed decomposition must not ask the model again")
+		}
+	}
+	if cp, _ := store.Load(agent.RoleEngineeringManager, parent.GetID()); cp != nil {
+		t.Fatalf("checkpoint should be cleared once the ticket is handled, got %+v", cp)
+	}
+}
@@ -0,0 +1,228 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/vcs"
+	"github.com/egobogo/aiagents/internal/vcs/github"
+)
+
+// fakeCI reports the scripted statuses in turn, repeating the last one.
+type fakeCI struct {
+	mu       sync.Mutex
+	statuses []vcs.PipelineStatus
+	logs     []vcs.JobLog
+	prs      []vcs.PullRequest
+}
+
+func (f *fakeCI) CreatePullRequest(title, description, source, target string) (vcs.PullRequest, error) {
+	pr := vcs.PullRequest{Number: len(f.prs) + 1, Title: title, SourceBranch: source, TargetBranch: target, URL: "https://ci.example/pr/1"}
+	f.prs = append(f.prs, pr)
+	return pr, nil
+}
+func (f *fakeCI) GetPullRequest(int) (vcs.PullRequest, error)              { return vcs.PullRequest{}, nil }
+func (f *fakeCI) ReadPullRequestComments(int) ([]vcs.ReviewComment, error) { return nil, nil }
+func (f *fakeCI) CommentOnPullRequest(int, string) error                   { return nil }
+func (f *fakeCI) FailedJobLogs(string) ([]vcs.JobLog, error)               { return f.logs, nil }
+
+func (f *fakeCI) GetPipelineStatus(string) (vcs.PipelineStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	return status, nil
+}
+
+func implementWithCI(t *testing.T, ci *fakeCI, attempts int) (*sim.ScriptedModel, *sim.Scenario, board.Card, error) {
+	t.Helper()
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "hello.txt", Content: "helo\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("RepairChecks", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "hello.txt", Content: "hello\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	card, _ := s.Board.CreateCard("Add greeting file", "Write hello.txt.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.CI = &agent.CIGate{Provider: ci, FixAttempts: attempts, PollInterval: time.Millisecond, Timeout: time.Second}
+	return m, s, card, backend.ImplementTicket(card)
+}
+
+func TestBackendWaitsForGreenChecks(t *testing.T) {
+	ci := &fakeCI{
+		statuses: []vcs.PipelineStatus{vcs.PipelineUnknown, vcs.PipelineRunning, vcs.PipelineFailed, vcs.PipelinePending, vcs.PipelineSuccess},
+		logs:     []vcs.JobLog{{Name: "test", Log: "greeting_test.go:9: want hello, got helo"}},
+	}
+	m, s, card, err := implementWithCI(t, ci, 0)
+	if err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListReview {
+		t.Fatalf("expected the ticket in Review, got %s", list.GetName())
+	}
+	if content, _ := s.RepoFile(card.GetID(), "hello.txt"); content != "hello\n" {
+		t.Fatalf("expected the fix on the ticket branch, got %q", content)
+	}
+	if _, err := s.Repo.Repo.Reference(plumbing.NewBranchReferenceName("ticket/"+card.GetID()), true); err != nil {
+		t.Fatalf("expected the ticket branch to be pushed: %v", err)
+	}
+	if len(ci.prs) != 1 || ci.prs[0].SourceBranch != "ticket/"+card.GetID() || ci.prs[0].TargetBranch != "main" {
+		t.Fatalf("expected one pull request from the ticket branch, got %+v", ci.prs)
+	}
+	var fedBack bool
+	for _, c := range m.Calls() {
+		fedBack = fedBack || strings.Contains(requestContent(c.Input[len(c.Input)-1].Content), "want hello, got helo")
+	}
+	if !fedBack {
+		t.Fatal("expected the failing log in the repair prompt")
+	}
+}
+
+func TestBackendEscalatesFailingChecks(t *testing.T) {
+	ci := &fakeCI{
+		statuses: []vcs.PipelineStatus{vcs.PipelineFailed},
+		logs:     []vcs.JobLog{{Name: "test", URL: "https://ci.example/job/7", Log: "FAIL"}},
+	}
+	_, _, card, err := implementWithCI(t, ci, 1)
+	if !errors.Is(err, agent.ErrChecksFailed) {
+		t.Fatalf("expected ErrChecksFailed, got %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDoing {
+		t.Fatalf("expected the ticket to stay in Doing, got %s", list.GetName())
+	}
+	comments, _ := card.ReadComments()
+	last := comments[len(comments)-1].Text
+	if !strings.Contains(last, "still failing after 1 fix attempt(s)") || !strings.Contains(last, "https://ci.example/job/7") {
+		t.Fatalf("unexpected escalation: %s", last)
+	}
+}
+
+func TestGitHubCheckRuns(t *testing.T) {
+	var runs string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/shop/commits/{ref}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"check_runs": [%s]}`, runs)
+	})
+	mux.HandleFunc("GET /repos/acme/shop/actions/jobs/7/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/raw/7", http.StatusFound)
+	})
+	mux.HandleFunc("GET /raw/7", func(w http.ResponseWriter, r *http.Request) {
+		for i := 1; i <= 300; i++ {
+			fmt.Fprintf(w, "line %d\n", i)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	gh, err := github.NewGitHubClient("secret", "acme/shop", server.URL)
+	if err != nil {
+		t.Fatalf("NewGitHubClient failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		runs string
+		want vcs.PipelineStatus
+	}{
+		{``, vcs.PipelineUnknown},
+		{`{"status": "completed", "conclusion": "success"}, {"status": "queued"}`, vcs.PipelinePending},
+		{`{"status": "in_progress"}, {"status": "completed", "conclusion": "skipped"}`, vcs.PipelineRunning},
+		{`{"status": "in_progress"}, {"status": "completed", "conclusion": "failure"}`, vcs.PipelineFailed},
+		{`{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "neutral"}`, vcs.PipelineSuccess},
+	} {
+		runs = tc.runs
+		if got, err := gh.GetPipelineStatus("abc"); err != nil || got != tc.want {
+			t.Fatalf("runs %s: expected %s, got %s (%v)", tc.runs, tc.want, got, err)
+		}
+	}
+
+	runs = `{"id": 7, "name": "test", "status": "completed", "conclusion": "failure", "app": {"slug": "github-actions"}},
+		{"id": 8, "name": "lint", "status": "completed", "conclusion": "timed_out", "app": {"slug": "other"}},
+		{"id": 9, "name": "build", "status": "completed", "conclusion": "success", "app": {"slug": "github-actions"}}`
+	logs, err := gh.FailedJobLogs("abc")
+	if err != nil {
+		t.Fatalf("FailedJobLogs failed: %v", err)
+	}
+	if len(logs) != 2 || logs[1].Log != "" {
+		t.Fatalf("expected the test and lint jobs, got %+v", logs)
+	}
+	if lines := strings.Split(logs[0].Log, "\n"); len(lines) != github.DefaultLogLines || lines[len(lines)-1] != "line 300" {
+		t.Fatalf("expected the last %d lines, got %d ending in %q", github.DefaultLogLines, len(lines), lines[len(lines)-1])
+	}
+}
+
+func TestGitHubWebhookWakesWait(t *testing.T) {
+	hub := vcs.NewStatusHub()
+	handler := github.WebhookHandler("s3cret", hub)
+	body := `{"action": "completed", "check_suite": {"head_sha": "abc", "head_branch": "ticket/1"}}`
+	deliver := func(signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "check_suite")
+		req.Header.Set("X-Hub-Signature-256", signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := deliver("sha256=00"); code != http.StatusUnauthorized {
+		t.Fatalf("expected a bad signature to be rejected, got %d", code)
+	}
+
+	ci := &fakeCI{statuses: []vcs.PipelineStatus{vcs.PipelineRunning, vcs.PipelineSuccess}}
+	done := make(chan vcs.PipelineStatus)
+	go func() {
+		status, _ := vcs.WaitForPipeline(ci, "abc", vcs.WaitOptions{Interval: time.Hour, Timeout: 5 * time.Second, Hub: hub})
+		done <- status
+	}()
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	for {
+		if code := deliver(signature); code != http.StatusNoContent {
+			t.Fatalf("expected the delivery to be accepted, got %d", code)
+		}
+		select {
+		case status := <-done:
+			if status != vcs.PipelineSuccess {
+				t.Fatalf("expected success, got %s", status)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestWaitForPipelineTimeout(t *testing.T) {
+	ci := &fakeCI{statuses: []vcs.PipelineStatus{vcs.PipelineRunning}}
+	status, err := vcs.WaitForPipeline(ci, "abc", vcs.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, vcs.ErrPipelineTimeout) || status != vcs.PipelineRunning {
+		t.Fatalf("expected a timeout while running, got %s, %v", status, err)
+	}
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/codecontext"
+)
+
+const outlineSource = `// Package pay moves money.
+package pay
+
+import "errors"
+
+// ErrDeclined is returned for declined cards.
+var ErrDeclined = errors.New("declined")
+
+var retries = 3
+
+// Charge is a single payment.
+type Charge struct {
+	Amount int // In cents.
+	card   string
+}
+
+// Process charges the card.
+func (c *Charge) Process() error {
+	return helper(c)
+}
+
+func helper(c *Charge) error { return nil }
+`
+
+func TestOutlineKeepsAPISurfaceOnly(t *testing.T) {
+	out, err := codecontext.Outline("pay.go", []byte(outlineSource))
+	if err != nil {
+		t.Fatalf("Outline failed: %v", err)
+	}
+	for _, want := range []string{"// Package pay moves money.", "ErrDeclined", "type Charge struct", "Amount int // In cents.", "// Process charges the card.", "func (c *Charge) Process() error"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("outline is missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"return helper(c)", "func helper", "retries"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("outline should not contain %q:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestForTicketExpandsTouchedFiles(t *testing.T) {
+	g := newTempGitClient(t)
+	if err := g.WriteFile("pay/pay.go", []byte(outlineSource)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := g.WriteFile("users/users.go", []byte("package users\n\n// Find looks a user up.\nfunc Find(id int) string {\n\treturn \"secret body\"\n}\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	ctx, err := codecontext.ForTicket(g, codecontext.ModeOutline, "Retry declined charges in pay/pay.go")
+	if err != nil {
+		t.Fatalf("ForTicket failed: %v", err)
+	}
+	if !strings.Contains(ctx, "### pay/pay.go (full)") || !strings.Contains(ctx, "return helper(c)") {
+		t.Fatalf("touched file should be included in full:\n%s", ctx)
+	}
+	if !strings.Contains(ctx, "### users/users.go (outline)") || strings.Contains(ctx, "secret body") {
+		t.Fatalf("other files should be outlined:\n%s", ctx)
+	}
+	if ctx, _ := codecontext.ForTicket(g, codecontext.ModeNone, "anything"); ctx != "" {
+		t.Fatalf("ModeNone must not add context")
+	}
+}
+
+func TestRelatedModeFollowsImportGraph(t *testing.T) {
+	g := newTempGitClient(t)
+	files := map[string]string{
+		"go.mod":             "module example.com/shop\n\ngo 1.24\n",
+		"pay/pay.go":         "package pay\n\nimport \"example.com/shop/money\"\n\nfunc Charge(a money.Amount) {}\n",
+		"money/money.go":     "package money\n\ntype Amount int\n",
+		"checkout/order.go":  "package checkout\n\nimport (\n\t\"fmt\"\n\t\"example.com/shop/pay\"\n)\n\nfunc Order() { fmt.Println(pay.Charge) }\n",
+		"users/users.go":     "package users\n\nfunc Find() {}\n",
+		"money/money_ext.go": "package money\n\nfunc Zero() Amount { return 0 }\n",
+	}
+	for name, src := range files {
+		if err := g.WriteFile(name, []byte(src)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	graph, err := codecontext.BuildGraph(g)
+	if err != nil {
+		t.Fatalf("BuildGraph failed: %v", err)
+	}
+	related := strings.Join(graph.Related([]string{"pay/pay.go"}), ",")
+	if related != "checkout/order.go,money/money.go,money/money_ext.go,pay/pay.go" {
+		t.Fatalf("unexpected related files: %s", related)
+	}
+
+	ctx, err := codecontext.ForTicket(g, codecontext.ModeRelated, "Fix rounding in pay.go")
+	if err != nil {
+		t.Fatalf("ForTicket failed: %v", err)
+	}
+	if !strings.Contains(ctx, "### pay/pay.go (full)") || !strings.Contains(ctx, "### checkout/order.go (outline)") {
+		t.Fatalf("related files missing:\n%s", ctx)
+	}
+	if strings.Contains(ctx, "users/users.go") {
+		t.Fatalf("unrelated package should be left out:\n%s", ctx)
+	}
+}
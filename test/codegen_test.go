@@ -0,0 +1,52 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/codegen"
+)
+
+func TestFormatGoFixesImports(t *testing.T) {
+	src := "package demo\nimport \"os\"\nfunc Shout(s string)  string { fmt.Println(s)\nreturn strings.ToUpper(s) }\n"
+	out, err := codegen.FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo failed: %v", err)
+	}
+	got := string(out)
+	if strings.Contains(got, `"os"`) {
+		t.Errorf("unused import not removed:\n%s", got)
+	}
+	if !strings.Contains(got, `"fmt"`) || !strings.Contains(got, `"strings"`) {
+		t.Errorf("missing imports not added:\n%s", got)
+	}
+	if !strings.Contains(got, "func Shout(s string) string {\n\tfmt.Println(s)") {
+		t.Errorf("source not formatted:\n%s", got)
+	}
+}
+
+func TestFormatGoReportsSyntaxErrors(t *testing.T) {
+	src := "package demo\nfunc broken( {\n"
+	out, err := codegen.FormatGo([]byte(src))
+	if err == nil {
+		t.Fatalf("expected a parse error")
+	}
+	if string(out) != src {
+		t.Errorf("source must be returned unchanged on error")
+	}
+}
+
+func TestFormatGoKeepsImportsWithUnknownNames(t *testing.T) {
+	src := "package demo\n\nimport (\n\t\"os\"\n\n\t\"github.com/egobogo/aiagents/internal/board/trello\"\n)\n\nvar client = trelloClient.NewTrelloClient\n"
+	out, err := codegen.FormatGo([]byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo failed: %v", err)
+	}
+	got := string(out)
+	if !strings.Contains(got, `"github.com/egobogo/aiagents/internal/board/trello"`) {
+		t.Errorf("import with a package name differing from its path was removed:\n%s", got)
+	}
+	if strings.Contains(got, `"os"`) {
+		t.Errorf("unused standard library import not removed:\n%s", got)
+	}
+}
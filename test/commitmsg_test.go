@@ -0,0 +1,65 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestValidateCommitMessage(t *testing.T) {
+	valid := []string{
+		"feat(board): add GitLab issue board",
+		"fix: handle empty ticket IDs\n\nRefs: 42",
+		"refactor(agent)!: split BaseAgent",
+	}
+	for _, msg := range valid {
+		if err := gitrepo.ValidateCommitMessage(msg); err != nil {
+			t.Errorf("%q rejected: %v", msg, err)
+		}
+	}
+	invalid := []string{
+		"Initial commit",
+		"feature: add board",
+		"fix: trailing period.",
+		"fix: no blank line\nbody",
+	}
+	for _, msg := range invalid {
+		if err := gitrepo.ValidateCommitMessage(msg); err == nil {
+			t.Errorf("%q accepted", msg)
+		}
+	}
+}
+
+func TestNewCommitMessage(t *testing.T) {
+	msg := gitrepo.NewCommitMessage([]string{"internal/board/links.go", "internal/board/trello/trelloClient.go"}, "T-7", "Link child tickets.")
+	want := "feat(board): link child tickets\n\nRefs: T-7"
+	if msg.String() != want {
+		t.Errorf("got %q, want %q", msg.String(), want)
+	}
+	if err := gitrepo.ValidateCommitMessage(msg.String()); err != nil {
+		t.Errorf("generated message invalid: %v", err)
+	}
+	if got := gitrepo.NewCommitMessage([]string{"test/board_test.go"}, "", "Cover links").Type; got != "test" {
+		t.Errorf("expected test type, got %q", got)
+	}
+}
+
+func TestNewCommitMessageTruncatesLongTitles(t *testing.T) {
+	title := strings.Repeat("Größenänderung ", 14) // 210 characters, most of them multi-byte.
+	for _, changed := range [][]string{nil, {"internal/board/links.go"}, {"README.md", "internal/board/links.go"}} {
+		msg := gitrepo.NewCommitMessage(changed, "T-7", title)
+		if err := gitrepo.ValidateCommitMessage(msg.String()); err != nil {
+			t.Errorf("message for %v invalid: %v", changed, err)
+		}
+		if !utf8.ValidString(msg.Summary) || !strings.HasPrefix(msg.Summary, "größenänderung") {
+			t.Errorf("summary for %v cut badly: %q", changed, msg.Summary)
+		}
+	}
+	word := strings.Repeat("ä", 100) // A single word longer than the subject.
+	msg := gitrepo.NewCommitMessage(nil, "", word)
+	if err := gitrepo.ValidateCommitMessage(msg.String()); err != nil || !utf8.ValidString(msg.Summary) {
+		t.Errorf("long word cut badly: %q, %v", msg.Summary, err)
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestPriceForMatchesLongestPrefix(t *testing.T) {
+	if p, ok := model.PriceFor("gpt-4o-mini-2024-07-18"); !ok || p != model.DefaultPrices["gpt-4o-mini"] {
+		t.Fatalf("expected the gpt-4o-mini price, got %+v, %v", p, ok)
+	}
+	if p, ok := model.PriceFor("gpt-4o-2024-08-06"); !ok || p != model.DefaultPrices["gpt-4o"] {
+		t.Fatalf("expected the gpt-4o price, got %+v, %v", p, ok)
+	}
+	if _, ok := model.PriceFor("claude-3"); ok {
+		t.Fatal("expected no price for an unknown model")
+	}
+	if cost := (model.Price{Prompt: 2, Completion: 8}).Cost(500_000, 100_000); cost != 1.8 {
+		t.Fatalf("expected $1.80, got %v", cost)
+	}
+}
+
+func TestCostReportPostedOnceWhenTicketIsDone(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Add login", "", board.ListDone)
+	card := c.(*sim.Card)
+	idle, _ := s.Board.CreateCard("Old ticket", "", board.ListDone)
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	start := time.Now().Add(-3 * time.Hour)
+	call := func(at time.Duration, modelName, prompt, completion string) {
+		log.Record(events.Event{Time: start.Add(at), Agent: "backend", TicketID: card.GetID(), Type: events.ModelCall, Target: modelName,
+			Details: map[string]string{"tokens": prompt, "completionTokens": completion, "durationMs": "1500"}})
+	}
+	move := func(at time.Duration, from, to string) {
+		log.Record(events.Event{Time: start.Add(at), Agent: "backend", TicketID: card.GetID(), Type: events.CardMoved,
+			Details: map[string]string{"from": from, "to": to}})
+	}
+	move(0, board.ListSprint, board.ListDoing)
+	call(10*time.Minute, "gpt-4o", "1000000", "100000")
+	call(20*time.Minute, "gpt-4o-mini", "0", "0")
+	log.Record(events.Event{Time: start.Add(90 * time.Minute), TicketID: card.GetID(), Type: events.Commit, Target: "abc123"})
+	move(2*time.Hour, board.ListDoing, board.ListReview)
+	move(2*time.Hour+30*time.Minute, board.ListReview, board.ListDone)
+
+	rule := orchestrator.NewCostReportRule(log)
+	if err := rule.Apply(s.Board); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	text := lastComment(t, card)
+	for _, want := range []string{
+		"[cost report]",
+		"Model calls: 2",
+		"Tokens: 1000000 prompt, 100000 completion",
+		"Estimated cost: $3.50",
+		"Commits: 1",
+		"Elapsed: 2h30m0s",
+		"Time per list: Doing 2h0m0s, Review 30m0s",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the report, got:\n%s", want, text)
+		}
+	}
+
+	rule.Apply(s.Board)
+	if comments, _ := card.ReadComments(); len(comments) != 1 {
+		t.Fatalf("expected a single report, got %d comments", len(comments))
+	}
+	if comments, _ := idle.ReadComments(); len(comments) != 0 {
+		t.Fatal("expected no report for a ticket without recorded events")
+	}
+}
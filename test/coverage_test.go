@@ -0,0 +1,81 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/testrunner"
+)
+
+const calcSource = "package calc\n\nfunc Add(a, b int) int { return a + b }\n\nfunc Sub(a, b int) int { return a - b }\n"
+
+func TestCover(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"go.mod":       "module example.com/calc\n\ngo 1.24\n",
+		"calc.go":      calcSource,
+		"calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"want 3\")\n\t}\n}\n",
+	})
+	report, err := testrunner.Cover(dir, ".", "./gone")
+	if err != nil {
+		t.Fatalf("Cover failed: %v", err)
+	}
+	if report.Total != 50 || len(report.Packages) != 1 {
+		t.Fatalf("expected 50%% of one package, got %+v", report)
+	}
+	below := report.Below(80)
+	if len(below) != 1 || below[0].Function != "Sub" || below[0].File != "calc.go" || below[0].Line != 5 {
+		t.Fatalf("expected Sub to be uncovered, got %+v", below)
+	}
+}
+
+func TestCommitWorkAddsTestsBelowCoverage(t *testing.T) {
+	m := sim.NewScriptedModel()
+	tests := "package calc\n\nimport \"testing\"\n\nfunc TestCalc(t *testing.T) {\n\tif Add(1, 2) != 3 || Sub(3, 1) != 2 {\n\t\tt.Fatal(\"wrong result\")\n\t}\n}\n"
+	if _, err := m.OnMode("AddTests", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc_test.go", Content: tests}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{"go.mod": "module example.com/calc\n\ngo 1.24\n", "calc.go": calcSource})
+	card, _ := s.Board.CreateCard("Calculator", "", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.MinCoverage = 80
+	if err := backend.CommitWork(s.Repo, card, "feat: add calculator", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitWork failed: %v", err)
+	}
+	if content, _ := s.RepoFile("", "calc_test.go"); content != tests {
+		t.Fatalf("expected the added tests to be committed, got %q", content)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "Add tests") {
+			prompt = text
+		}
+	}
+	if !strings.Contains(prompt, "calc.go:3: Add 0.0%") || !strings.Contains(prompt, "File: calc.go") {
+		t.Fatalf("expected the uncovered functions in the prompt, got:\n%s", prompt)
+	}
+
+	// Coverage that stays too low is escalated.
+	writeModule(t, s.Repo.RepoPath, map[string]string{"mul.go": "package calc\n\nfunc Mul(a, b int) int { return a * b }\n\nfunc Div(a, b int) int { return a / b }\n"})
+	err = backend.CommitWork(s.Repo, card, "feat: add multiplication", "backend", "backend@aiagents.local")
+	if !errors.Is(err, agent.ErrCoverageTooLow) {
+		t.Fatalf("expected ErrCoverageTooLow, got %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) == 0 || !strings.Contains(comments[len(comments)-1].Text, "mul.go:3: Mul 0.0%") {
+		t.Fatalf("expected the uncovered functions on the card, got %v", comments)
+	}
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/dashboard"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/killswitch"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+)
+
+type countingActor struct {
+	runs int
+	err  error
+}
+
+func (a *countingActor) Act() error {
+	a.runs++
+	return a.err
+}
+
+func TestDashboardPauseAndStatus(t *testing.T) {
+	manager := &countingActor{}
+	backend := &countingActor{err: errors.New("build failed")}
+	fleet := orchestrator.NewFleet()
+	fleet.Add("manager", "EngineeringManager", manager)
+	fleet.Add("backend", "Backend", backend)
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	log.Record(events.Event{Agent: "manager", Type: events.ModelCall, Details: map[string]string{"tokens": "120"}})
+	log.Record(events.Event{Agent: "backend", Type: events.Commit, Error: "nothing to commit"})
+
+	sup := orchestrator.NewSupervisor("human")
+	sup.Track("card1", "manager", "Decompose")
+
+	dash := dashboard.NewServer(fleet, sup, log)
+	dash.Token = "s3cret"
+	srv := httptest.NewServer(dash.Handler())
+	defer srv.Close()
+
+	if resp, _ := http.Post(srv.URL+"/agents/backend/pause", "", nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the token, got %d", resp.StatusCode)
+	}
+	resp, err := http.PostForm(srv.URL+"/agents/backend/pause", url.Values{"token": {"s3cret"}})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("pause failed: %v %v", resp, err)
+	}
+	if resp, _ := http.PostForm(srv.URL+"/agents/nobody/pause", url.Values{"token": {"s3cret"}}); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown agent, got %d", resp.StatusCode)
+	}
+	fleet.RunOnce()
+	if manager.runs != 1 || backend.runs != 0 {
+		t.Fatalf("paused agent ran: manager=%d backend=%d", manager.runs, backend.runs)
+	}
+
+	resp, err = http.Get(srv.URL + "/api/status")
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	defer resp.Body.Close()
+	var st dashboard.Status
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if st.TotalTokens != 120 || len(st.Agents) != 2 || len(st.Failures) != 1 {
+		t.Fatalf("unexpected status: %+v", st)
+	}
+	m, b := st.Agents[0], st.Agents[1]
+	if m.TicketID != "card1" || m.Phase != "Decompose" || m.Tokens != 120 || m.Runs != 1 {
+		t.Fatalf("unexpected manager view: %+v", m)
+	}
+	if !b.Paused || b.Errors != 1 {
+		t.Fatalf("unexpected backend view: %+v", b)
+	}
+
+	page, err := http.Get(srv.URL + "/")
+	if err != nil || page.StatusCode != http.StatusOK {
+		t.Fatalf("page failed: %v %v", page, err)
+	}
+	body, _ := io.ReadAll(page.Body)
+	page.Body.Close()
+	if strings.Contains(string(body), "<form") {
+		t.Fatalf("expected no controls without the token:\n%s", body)
+	}
+	page, err = http.Get(srv.URL + "/?token=s3cret")
+	if err != nil {
+		t.Fatalf("page failed: %v", err)
+	}
+	body, _ = io.ReadAll(page.Body)
+	page.Body.Close()
+	if !strings.Contains(string(body), `action="/agents/backend/resume"`) {
+		t.Fatalf("expected controls with the token:\n%s", body)
+	}
+}
+
+func TestDashboardRefusesControlsWithoutToken(t *testing.T) {
+	fleet := orchestrator.NewFleet()
+	fleet.Add("backend", "Backend", &countingActor{})
+	srv := httptest.NewServer(dashboard.NewServer(fleet, nil, nil).Handler())
+	defer srv.Close()
+
+	for _, path := range []string{"/agents/backend/pause", "/killswitch/engage"} {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer anything")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected %s refused without a configured token, got %v %v", path, resp, err)
+		}
+	}
+	if resp, _ := http.Get(srv.URL + "/api/tickets/card1/transcript"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected transcripts refused without a configured token, got %d", resp.StatusCode)
+	}
+	if killed, _ := killswitch.Engaged(); killed {
+		t.Fatalf("kill switch engaged without a token")
+	}
+}
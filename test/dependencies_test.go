@@ -0,0 +1,102 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestDecomposeLinksDependencies(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 2},
+		{Title: "Add page", Description: "Show health.", StoryPoints: 1, DependsOn: []string{"add endpoint"}},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	created, err := a.(*agent.EngineeringManagerAgent).HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	deps := board.Dependencies(created[1].GetDescription())
+	if len(deps) != 1 || deps[0] != created[0].GetURL() {
+		t.Fatalf("expected the page to depend on the endpoint, got %v", deps)
+	}
+	if url, ok := board.ParentURL(created[1].GetDescription()); !ok || url != parent.GetURL() {
+		t.Fatalf("expected the parent link to survive, got %q", url)
+	}
+	if len(board.Dependencies(created[0].GetDescription())) != 0 {
+		t.Fatal("expected the endpoint to have no dependencies")
+	}
+}
+
+func TestCheckTicketsDependencies(t *testing.T) {
+	tickets := []agent.TechnicalTicket{
+		{Title: "A", DependsOn: []string{"B"}},
+		{Title: "B", DependsOn: []string{"A"}},
+		{Title: "C", DependsOn: []string{"D"}},
+	}
+	problems := strings.Join(agent.CheckTickets(tickets, config.TicketRules{}), "\n")
+	if !strings.Contains(problems, "unknown ticket \"D\"") || !strings.Contains(problems, "form a cycle") {
+		t.Fatalf("expected unknown and cyclic dependencies to fail, got %s", problems)
+	}
+}
+
+func TestDependenciesGateWork(t *testing.T) {
+	b := sim.NewBoard()
+	api, _ := b.CreateCard("Add endpoint", "", board.ListSprint)
+	page, _ := b.CreateCard("Add page", "", board.ListSprint)
+	blocked, _ := b.CreateCard("Add docs", "", board.ListBlocked)
+	for _, c := range []board.Card{page, blocked} {
+		if err := board.AddDependency(c, api); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+		c.AssignTo("frontend")
+	}
+	board.AddDependency(page, api) // Idempotent.
+	if n := len(board.Dependencies(page.GetDescription())); n != 1 {
+		t.Fatalf("expected one dependency, got %d", n)
+	}
+
+	dev := &agent.BaseAgent{Name: "frontend", BoardClient: b}
+	if ready, _ := dev.FindReadyTickets(); len(ready) != 0 {
+		t.Fatalf("expected no ready tickets while the endpoint is open, got %d", len(ready))
+	}
+
+	rule := orchestrator.NewDependencyRule()
+	if err := rule.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := page.GetList(); list.GetName() != board.ListBlocked {
+		t.Fatalf("expected the page to be held in Blocked, got %s", list.GetName())
+	}
+
+	api.Move(board.ListDone)
+	if err := rule.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := page.GetList(); list.GetName() != board.ListSprint {
+		t.Fatalf("expected the page to return to the sprint, got %s", list.GetName())
+	}
+	if list, _ := blocked.GetList(); list.GetName() != board.ListBlocked {
+		t.Fatalf("expected a ticket blocked for other reasons to stay, got %s", list.GetName())
+	}
+	if ready, _ := dev.FindReadyTickets(); len(ready) != 2 {
+		t.Fatalf("expected both tickets to be ready, got %d", len(ready))
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/policy"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestDiffRequirements(t *testing.T) {
+	before := "module example.com/app\n\ngo 1.24\n\nrequire github.com/a/a v1.0.0\n\nrequire (\n\tgithub.com/b/b v1.2.0 // indirect\n\tgithub.com/c/c v0.3.0\n)\n"
+	after := "module example.com/app\n\ngo 1.24\n\nrequire (\n\tgithub.com/a/a v1.1.0\n\tgithub.com/b/b v1.2.0 // indirect\n\tgithub.com/d/d v2.0.0\n)\n"
+	changes := codegen.DiffRequirements(before, after)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{"change github.com/a/a v1.0.0 => v1.1.0", "remove github.com/c/c v0.3.0", "add github.com/d/d v2.0.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// dependencyScenario has the backend implement a ticket that adds a module requirement.
+func dependencyScenario(t *testing.T) (*sim.ScriptedModel, *sim.Scenario, *sim.Card, *agent.BackendAgent) {
+	t.Helper()
+	m := sim.NewScriptedModel()
+	files := []agent.GeneratedFile{
+		{Path: "go.mod", Content: "module example.com/greet\n\ngo 1.24\n\nrequire example.com/words v0.0.0\n\nreplace example.com/words => ./words\n"},
+		{Path: "greet.go", Content: "package greet\n\nimport \"example.com/words\"\n\n// Hello greets.\nfunc Hello() string { return words.Hello }\n"},
+	}
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: files}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"go.mod":         "module example.com/greet\n\ngo 1.24\n",
+		"words/go.mod":   "module example.com/words\n\ngo 1.24\n",
+		"words/words.go": "package words\n\n// Hello is a greeting.\nconst Hello = \"hello\"\n",
+	})
+	if err := s.Repo.CommitChanges("feat: add words module", "human", "human@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	s.Repo.Guard = policy.New(policy.Rules{ApprovalPaths: policy.DefaultApprovalPaths})
+	card, _ := s.Board.CreateCard("Make a module", "Add a greet package.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.ReplyInterval = time.Millisecond
+	return m, s, card.(*sim.Card), backend
+}
+
+// whenRequested runs answer once the card asks for a dependency change.
+func whenRequested(t *testing.T, card *sim.Card, answer func()) {
+	t.Helper()
+	go func() {
+		for i := 0; i < 1000; i++ {
+			comments, _ := card.ReadComments()
+			if len(comments) > 0 && strings.HasPrefix(comments[len(comments)-1].Text, "Dependency change requested") {
+				answer()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+}
+
+func TestBackendDependencyChangeRejected(t *testing.T) {
+	_, s, card, backend := dependencyScenario(t)
+	whenRequested(t, card, func() { card.Reply("human", "reject: keep it a plain repository") })
+	if err := backend.ImplementTicket(card); !errors.Is(err, agent.ErrDependenciesRejected) {
+		t.Fatalf("expected ErrDependenciesRejected, got %v", err)
+	}
+	wt, err := s.Repo.WorktreeFor(card.GetID())
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	if content, _ := wt.ReadFileAt("HEAD", "go.mod"); strings.Contains(string(content), "require") {
+		t.Fatal("expected the requirement not to be committed")
+	}
+}
+
+func TestManagerApprovesDependencyChange(t *testing.T) {
+	m, s, card, backend := dependencyScenario(t)
+	if _, err := m.OnMode("ReviewDependencies", agent.DependencyReview{Approve: true, Reason: "the ticket asks for a module"}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	a, err := s.Agent(agent.RoleEngineeringManager, "manager")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	manager := a.(*agent.EngineeringManagerAgent)
+	reviewed := make(chan error, 1)
+	whenRequested(t, card, func() { reviewed <- manager.ReviewDependencyRequests() })
+
+	if err := backend.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if err := <-reviewed; err != nil {
+		t.Fatalf("ReviewDependencyRequests failed: %v", err)
+	}
+	var request string
+	for _, c := range m.Calls() {
+		if content := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(content, "Go dependencies") {
+			request = content
+		}
+	}
+	if !strings.Contains(request, "add example.com/words v0.0.0 (go.mod)") {
+		t.Fatalf("expected the change in the review prompt, got %q", request)
+	}
+	wt, err := s.Repo.WorktreeFor(card.GetID())
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	if content, err := wt.ReadFileAt("HEAD", "go.mod"); err != nil || !strings.Contains(string(content), "require example.com/words") {
+		t.Fatalf("expected the requirement committed, got %q (%v)", content, err)
+	}
+}
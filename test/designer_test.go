@@ -0,0 +1,229 @@
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestAttachImagesKeepsText(t *testing.T) {
+	req := model.ChatRequest{Input: []model.Message{
+		{Role: "system", Content: "You review designs."},
+		{Role: "user", Content: []map[string]string{{"type": "input_text", "text": "Review the login screen."}}},
+	}}
+	model.AttachImages(&req, model.ImageDataPart("image/png", []byte("png")), model.ImageURLPart("https://example.com/a.jpg"))
+
+	parts, ok := req.Input[1].Content.([]model.ContentPart)
+	if !ok || len(parts) != 3 || parts[1].ImageURL != "data:image/png;base64,cG5n" || parts[2].Type != model.PartImage {
+		t.Fatalf("unexpected parts %+v", req.Input[1].Content)
+	}
+	if text := model.MessageText(req.Input[1]); text != "Review the login screen." {
+		t.Fatalf("expected only the text, got %q", text)
+	}
+	if with, without := model.EstimateRequestTokens(req), model.EstimateRequestTokens(model.ChatRequest{Input: req.Input[:1]}); with-without < 1000 {
+		t.Fatalf("expected the images counted, got %d more tokens", with-without)
+	}
+	if mimeType, data, ok := model.ParseDataURL(parts[1].ImageURL); !ok || mimeType != "image/png" || data != "cG5n" {
+		t.Fatalf("unexpected data URL %q %q %v", mimeType, data, ok)
+	}
+	if model.ImageType("mock.PNG?download=1") != "image/png" || model.ImageType("spec.pdf") != "" {
+		t.Fatal("unexpected image types")
+	}
+}
+
+func TestDesignerReviewsAttachedScreens(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("DesignReview", agent.DesignReview{
+		Summary: "Close to the brand, but the button color is off.",
+		Issues:  []agent.DesignIssue{{Screen: "login.png", Severity: "medium", Description: "The sign-in button is green.", Recommendation: "Use primary blue #0055FF."}},
+		Spec:    "Centered card, 400px wide; email and password fields; primary button full width.",
+	}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Repo.WriteFile("docs/brandbook.md", []byte("Primary color: #0055FF\n"))
+	s.Repo.CommitChanges("docs: add brandbook", "sim", "sim@aiagents.local")
+
+	a, err := s.Agent(agent.RoleDesigner, agent.RoleDesigner)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	card, _ := s.Board.CreateCard("Login page", "Build the login page.", board.ListDoing)
+	card.AssignTo(agent.RoleDesigner)
+	card.AddAttachment(board.Attachment{ID: "a1", Name: "login.png", URL: "https://example.com/login.png"})
+	card.AddAttachment(board.Attachment{ID: "a2", Name: "notes.pdf", URL: "https://example.com/notes.pdf"})
+
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	calls := m.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one review, got %d calls", len(calls))
+	}
+	last := calls[0].Input[len(calls[0].Input)-1]
+	parts := model.MessageParts(last)
+	if len(parts) != 2 || parts[1].ImageURL != "https://example.com/login.png" {
+		t.Fatalf("expected only the screenshot attached, got %+v", parts)
+	}
+	if !strings.Contains(model.MessageText(last), "#0055FF") {
+		t.Fatalf("expected the brandbook in the prompt, got:\n%s", model.MessageText(last))
+	}
+	comment := lastComment(t, card.(*sim.Card))
+	for _, want := range []string{"[design review] login.png", "[medium] login.png: The sign-in button is green.", "UI spec:\nCentered card"} {
+		if !strings.Contains(comment, want) {
+			t.Fatalf("expected %q in the review, got:\n%s", want, comment)
+		}
+	}
+
+	// Reviewed screens are not sent again; a new export is.
+	a.Act()
+	card.AddAttachment(board.Attachment{ID: "a3", Name: "login-v2.png", URL: "https://example.com/login-v2.png"})
+	a.Act()
+	if calls = m.Calls(); len(calls) != 2 || len(model.MessageParts(calls[1].Input[len(calls[1].Input)-1])) != 2 {
+		t.Fatalf("expected one more review of the new screen, got %d calls", len(calls))
+	}
+}
+
+func TestDesignerProposesAssetsInPullRequest(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("DesignReview", agent.DesignReview{
+		Spec: "Use the search icon left of the field.",
+		Assets: []agent.DesignAsset{
+			{Path: "icons/search.svg", Content: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><circle cx="11" cy="11" r="7"/></svg>`, Description: "Search icon"},
+			{Path: "tokens.css", Content: ":root { --color-primary: #0055FF; }\n", Description: "Brand colors"},
+			{Path: "../../cmd/evil.svg", Content: "<svg/>"},
+			{Path: "icons/broken.svg", Content: "<html></html>"},
+			{Path: "logo.png", Content: "PNG"},
+		},
+	}, "search field"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	a, err := s.Agent(agent.RoleDesigner, agent.RoleDesigner)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	ci := &fakeCI{}
+	designer := a.(*agent.DesignerAgent)
+	designer.VCS = ci
+	card, _ := s.Board.CreateCard("Search page", "Add a search field.", board.ListDoing)
+	card.AssignTo(agent.RoleDesigner)
+
+	// Nothing to do until the Designer is asked.
+	if err := designer.Act(); err != nil || len(m.Calls()) != 0 {
+		t.Fatalf("expected no review without screens or a request, got %d calls, %v", len(m.Calls()), err)
+	}
+	card.WriteComment("@Designer I need the following assets for this ticket:\n- search: icon for the search field")
+	if err := designer.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+
+	if len(ci.prs) != 1 || ci.prs[0].SourceBranch != "ticket/design-"+card.GetID() || ci.prs[0].TargetBranch != "main" {
+		t.Fatalf("expected a design pull request, got %+v", ci.prs)
+	}
+	if icon, err := s.RepoFile("design-"+card.GetID(), "assets/design/icons/search.svg"); err != nil || !strings.Contains(icon, "<circle") {
+		t.Fatalf("expected the icon on the design branch, got %q, %v", icon, err)
+	}
+	if _, err := s.RepoFile("design-"+card.GetID(), "assets/design/tokens.css"); err != nil {
+		t.Fatalf("expected the tokens on the design branch: %v", err)
+	}
+	for _, dropped := range []string{"cmd/evil.svg", "assets/design/icons/broken.svg", "assets/design/logo.png"} {
+		if _, err := s.RepoFile("design-"+card.GetID(), dropped); err == nil {
+			t.Fatalf("expected %s to be rejected", dropped)
+		}
+	}
+	if _, err := s.RepoFile("", "assets/design/tokens.css"); err == nil {
+		t.Fatal("expected the main checkout untouched until the design is approved")
+	}
+	comment := lastComment(t, card.(*sim.Card))
+	for _, want := range []string{"- assets/design/icons/search.svg: Search icon", "pull request #1: https://ci.example/pr/1"} {
+		if !strings.Contains(comment, want) {
+			t.Fatalf("expected %q in the reply, got:\n%s", want, comment)
+		}
+	}
+	if attachments, _ := card.GetAttachments(); len(attachments) != 1 || attachments[0].Name != "Design pull request #1" {
+		t.Fatalf("expected the pull request linked on the card, got %+v", attachments)
+	}
+
+	// The request is answered, so the next round does nothing.
+	designer.Act()
+	if len(m.Calls()) != 1 {
+		t.Fatalf("expected the request answered once, got %d calls", len(m.Calls()))
+	}
+}
+
+func TestDesignerDownloadsBoardHostedScreens(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("DesignReview", agent.DesignReview{Summary: "Looks right."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	// The registry wraps the board, so the download has to pass through every card wrapper.
+	a, err := s.Agent(agent.RoleDesigner, agent.RoleDesigner)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	card, _ := s.Board.CreateCard("Login page", "Build the login page.", board.ListDoing)
+	card.AssignTo(agent.RoleDesigner)
+	card.(*sim.Card).Upload("login.png", []byte("png"))
+	card.AddAttachment(board.Attachment{ID: "a2", Name: "signup.png", URL: "https://example.com/signup.png"})
+
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	calls := m.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one review, got %d calls", len(calls))
+	}
+	parts := model.MessageParts(calls[0].Input[len(calls[0].Input)-1])
+	if len(parts) != 3 || parts[1].ImageURL != "data:image/png;base64,cG5n" || parts[2].ImageURL != "https://example.com/signup.png" {
+		t.Fatalf("expected the uploaded screen inline and the link as is, got %+v", parts)
+	}
+}
+
+// echoTranslator returns text unchanged, so the translated board only adds its card wrapper.
+type echoTranslator struct{}
+
+func (echoTranslator) Translate(text, language string) (string, error) { return text, nil }
+
+func TestCardWrappersForwardDownloads(t *testing.T) {
+	raw := sim.NewBoard(board.ListBacklog, "In progress")
+	multi := board.NewMulti()
+	multi.Add("web", board.WithColumns(raw, map[string]string{board.ListDoing: "In progress"}))
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	wrapped := board.DryRun(board.WithTranslation(events.WrapBoard(multi, log, "designer"), echoTranslator{}, map[string]string{"": "German"}))
+
+	created, _ := raw.CreateCard("Login page", "", "In progress")
+	created.(*sim.Card).Upload("login.png", []byte("png"))
+	created.AddAttachment(board.Attachment{Name: "signup.png", URL: "https://example.com/signup.png"})
+	cards, err := wrapped.GetCardsFromList(board.ListDoing)
+	if err != nil || len(cards) != 1 {
+		t.Fatalf("unexpected cards %v, %v", cards, err)
+	}
+	attachments, _ := cards[0].GetAttachments()
+	if data, err := board.ReadAttachment(cards[0], attachments[0]); err != nil || string(data) != "png" {
+		t.Fatalf("expected the upload through every wrapper, got %q, %v", data, err)
+	}
+	if _, err := board.ReadAttachment(cards[0], attachments[1]); !errors.Is(err, board.ErrNotDownloadable) {
+		t.Fatalf("expected links to stay links, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestDevOpsActImplementsInfraTickets(t *testing.T) {
+	m := sim.NewScriptedModel()
+	questions, err := m.OnMode("Implement", agent.Implementation{Questions: []string{"Which base image?"}})
+	if err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	questions.Times = 1
+	dockerfile := "FROM golang:1.24-alpine\nCOPY . /src\n"
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "Dockerfile", Content: dockerfile}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	infra, _ := s.Board.CreateCard("[infra] Containerize the service", "Build an image for the API.", board.ListSprint)
+	infra.AssignTo("devops")
+	feature, _ := s.Board.CreateCard("Add greeting", "Write hello.txt.", board.ListSprint)
+	feature.AssignTo("devops")
+	a, err := s.Agent(agent.RoleDevOps, "devops")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.(*agent.DevOpsAgent).ReplyInterval = time.Millisecond
+	replyWhenAsked(infra.(*sim.Card), "I have some questions", "alice", "Use the Alpine image.")
+
+	for round := 0; round < 2; round++ {
+		if err := a.Act(); err != nil {
+			t.Fatalf("Act failed: %v", err)
+		}
+	}
+	if n := modeCalls(m, "Implement"); n != 2 {
+		t.Fatalf("expected a question round and one implementation, got %d requests", n)
+	}
+	if list, _ := infra.GetList(); list.GetName() != board.ListReview {
+		t.Fatalf("expected the infra ticket in Review, got %s", list.GetName())
+	}
+	if content, err := s.RepoFile(infra.GetID(), "Dockerfile"); err != nil || content != dockerfile {
+		t.Fatalf("unexpected Dockerfile on the ticket branch: %q, %v", content, err)
+	}
+	if list, _ := feature.GetList(); list.GetName() != board.ListSprint {
+		t.Fatalf("DevOps picked up an unlabelled ticket; it is in %s", list.GetName())
+	}
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[1].Content), "Add greeting") {
+			t.Fatal("DevOps asked the model about an unlabelled ticket")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestDocsActUpdatesDocsOfDoneTickets(t *testing.T) {
+	m := sim.NewScriptedModel()
+	readme := "# Simulation\n\nRun `./serve.sh --port 8080` to start the server.\n"
+	if _, err := m.OnMode("UpdateDocs", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "README.md", Content: readme}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	parent, _ := s.Board.CreateCard("Configurable server", "", board.ListDoing)
+	ticket, _ := s.Board.CreateCard("Add a port flag", board.WithParentLink("Let the port be chosen.", parent), board.ListDone)
+	commitOnTicket(t, s, ticket, "serve.sh", "#!/bin/sh\nexec server --port \"${1:-8080}\"\n")
+	unlinked, _ := s.Board.CreateCard("Tidy the board", "", board.ListDone)
+	a, err := s.Agent(agent.RoleDocs, agent.RoleDocs)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.(*agent.DocsAgent).BaseBranch = "master"
+
+	for round := 0; round < 2; round++ {
+		if err := a.Act(); err != nil {
+			t.Fatalf("Act failed: %v", err)
+		}
+	}
+	if n := modeCalls(m, "UpdateDocs"); n != 1 {
+		t.Fatalf("expected the ticket documented once, got %d requests", n)
+	}
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[0].Content), "Mode: UpdateDocs\n") && !strings.Contains(requestContent(c.Input[1].Content), "serve.sh") {
+			t.Fatal("the ticket diff did not reach the docs request")
+		}
+	}
+	if content, err := s.RepoFile(ticket.GetID(), "README.md"); err != nil || content != readme {
+		t.Fatalf("unexpected README on the ticket branch: %q, %v", content, err)
+	}
+	if text := lastComment(t, ticket.(*sim.Card)); text != "[docs] Updated documentation: README.md" {
+		t.Fatalf("unexpected note %q", text)
+	}
+	if comments, _ := unlinked.ReadComments(); len(comments) != 0 {
+		t.Fatalf("ticket without a parent was documented: %+v", comments)
+	}
+}
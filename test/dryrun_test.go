@@ -0,0 +1,128 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/vcs/github"
+	"github.com/egobogo/aiagents/internal/vcs/gitlab"
+)
+
+func TestDryRunGitClientSkipsCommits(t *testing.T) {
+	client := newTempGitClient(t)
+	if err := client.WriteFile("main.go", []byte("package main\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.CommitChanges("feat: first", "backend", "backend@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	head, _ := client.Repo.Head()
+
+	client.DryRun = true
+	if err := client.WriteFile("main.go", []byte("package main\n\nfunc main() {}\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.CommitChanges("feat: second", "backend", "backend@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if err := client.CreateTag("v1.0.0", "", "backend", "backend@example.com"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+	// No remote is configured, so a real push would fail.
+	if err := client.PushChanges("", ""); err != nil {
+		t.Fatalf("PushChanges failed: %v", err)
+	}
+
+	after, _ := client.Repo.Head()
+	if after.Hash() != head.Hash() {
+		t.Fatalf("dry-run commit moved HEAD from %s to %s", head.Hash(), after.Hash())
+	}
+	if tags, _ := client.Tags(); len(tags) != 0 {
+		t.Fatalf("dry-run created tags: %+v", tags)
+	}
+}
+
+// failOnRequest fails the test on any request but reads.
+func failOnRequest(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry-run client sent %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDryRunGitHubClientSkipsWrites(t *testing.T) {
+	server := failOnRequest(t)
+	gh, err := github.NewGitHubClient("token", "egobogo/aiagents", server.URL)
+	if err != nil {
+		t.Fatalf("NewGitHubClient failed: %v", err)
+	}
+	gh.DryRun = true
+
+	pr, err := gh.CreatePullRequest("Add login", "Closes the ticket", "feature/login", "main")
+	if err != nil {
+		t.Fatalf("CreatePullRequest failed: %v", err)
+	}
+	if pr.SourceBranch != "feature/login" || pr.TargetBranch != "main" || pr.Title != "Add login" {
+		t.Fatalf("unexpected pull request %+v", pr)
+	}
+	if err := gh.CommentOnPullRequest(1, "Looks good"); err != nil {
+		t.Fatalf("CommentOnPullRequest failed: %v", err)
+	}
+	if err := gh.ReplyToThread(1, "T1", "Fixed"); err != nil {
+		t.Fatalf("ReplyToThread failed: %v", err)
+	}
+	if err := gh.ResolveThread(1, "T1"); err != nil {
+		t.Fatalf("ResolveThread failed: %v", err)
+	}
+	release, err := gh.CreateRelease("v1.0.0", "v1.0.0", "First release", nil)
+	if err != nil {
+		t.Fatalf("CreateRelease failed: %v", err)
+	}
+	if release.Tag != "v1.0.0" {
+		t.Fatalf("unexpected release %+v", release)
+	}
+}
+
+func TestDryRunGitLabClientSkipsWrites(t *testing.T) {
+	server := failOnRequest(t)
+	gl := gitlab.NewGitLabClient("token", "group/project", server.URL)
+	gl.DryRun = true
+
+	if _, err := gl.CreatePullRequest("Add login", "", "feature/login", "main"); err != nil {
+		t.Fatalf("CreatePullRequest failed: %v", err)
+	}
+	if err := gl.CommentOnPullRequest(1, "Looks good"); err != nil {
+		t.Fatalf("CommentOnPullRequest failed: %v", err)
+	}
+	if err := gl.ReplyToThread(1, "D1", "Fixed"); err != nil {
+		t.Fatalf("ReplyToThread failed: %v", err)
+	}
+	if err := gl.ResolveThread(1, "D1"); err != nil {
+		t.Fatalf("ResolveThread failed: %v", err)
+	}
+}
+
+func TestDryRunBoardKeepsOrchestratorFromMovingCards(t *testing.T) {
+	b := sim.NewBoard()
+	api, _ := b.CreateCard("Add endpoint", "", board.ListSprint)
+	page, _ := b.CreateCard("Add page", "", board.ListSprint)
+	if err := board.AddDependency(page, api); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	orch := orchestrator.New(board.DryRun(b), orchestrator.NewDependencyRule())
+	if err := orch.Tick(); err != nil {
+		t.Fatalf("Tick failed: %v", err)
+	}
+	if list, _ := page.GetList(); list.GetName() != board.ListSprint {
+		t.Fatalf("dry-run orchestrator moved the card to %s", list.GetName())
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestEngineeringManagerActDecomposesEachTicketOnce(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 3},
+	}}, "Health checks"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add metrics", Description: "Serve /metrics.", StoryPoints: 2},
+	}}, "Metrics"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	a, err := s.Agent(agent.RoleEngineeringManager, "em")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	health, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	health.AssignTo("em")
+
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	metrics, _ := s.Board.CreateCard("Metrics", "Expose metrics.", board.ListBacklog)
+	metrics.AssignTo("em")
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+
+	if got := strings.Join(s.Board.CardNames(board.ListBacklog), ","); got != "Health checks,Add endpoint,Metrics,Add metrics" {
+		t.Fatalf("expected one set of tickets per parent, got %s", got)
+	}
+	if n := modeCalls(m, "Decompose"); n != 2 {
+		t.Fatalf("expected one decomposition per parent, got %d", n)
+	}
+}
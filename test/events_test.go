@@ -0,0 +1,38 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+)
+
+func TestEventLogRecordsBoardActions(t *testing.T) {
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	b := events.WrapBoard(newFakeBoard(board.ListBacklog, board.ListDoing), log, "Backend")
+
+	card, err := b.CreateCard("Add login", "", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	if err := card.Move(board.ListDoing); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if err := card.WriteComment("Started"); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+
+	all, err := log.Read(events.Filter{TicketID: card.GetID()})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(all) != 3 || all[0].Type != events.CardCreated || all[1].Details["to"] != board.ListDoing || all[2].Agent != "Backend" {
+		t.Fatalf("unexpected events: %+v", all)
+	}
+
+	moves, _ := log.Read(events.Filter{Types: []events.Type{events.CardMoved}})
+	if len(moves) != 1 || moves[0].Details["from"] != board.ListBacklog {
+		t.Fatalf("unexpected move events: %+v", moves)
+	}
+}
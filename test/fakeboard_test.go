@@ -0,0 +1,154 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// fakeList is a minimal in-memory board list.
+type fakeList struct {
+	name string
+}
+
+func (l *fakeList) GetName() string { return l.name }
+func (l *fakeList) GetID() string   { return l.name }
+
+// fakeCard is a minimal in-memory board card.
+type fakeCard struct {
+	id          string
+	name        string
+	description string
+	list        string
+	members     []string
+	comments    []board.Comment
+	attachments []board.Attachment
+}
+
+func (c *fakeCard) GetID() string          { return c.id }
+func (c *fakeCard) GetName() string        { return c.name }
+func (c *fakeCard) GetDescription() string { return c.description }
+func (c *fakeCard) GetURL() string         { return "https://board.test/c/" + c.id }
+
+func (c *fakeCard) ChangeName(newName string) error {
+	c.name = newName
+	return nil
+}
+
+func (c *fakeCard) ChangeDescription(newDescription string) error {
+	c.description = newDescription
+	return nil
+}
+
+func (c *fakeCard) GetList() (board.List, error) {
+	return &fakeList{name: c.list}, nil
+}
+
+func (c *fakeCard) Move(newListName string) error {
+	c.list = newListName
+	return nil
+}
+
+func (c *fakeCard) GetAssignedMembers() ([]board.Member, error) {
+	var members []board.Member
+	for _, m := range c.members {
+		members = append(members, board.Member{ID: m, Name: m})
+	}
+	return members, nil
+}
+
+func (c *fakeCard) AssignTo(userName string) error {
+	c.members = append(c.members, userName)
+	return nil
+}
+
+func (c *fakeCard) UnassignFrom(userName string) error {
+	var kept []string
+	for _, m := range c.members {
+		if !strings.EqualFold(m, userName) {
+			kept = append(kept, m)
+		}
+	}
+	c.members = kept
+	return nil
+}
+
+func (c *fakeCard) ReadComments() ([]board.Comment, error) { return c.comments, nil }
+
+func (c *fakeCard) WriteComment(comment string) error {
+	c.comments = append(c.comments, board.Comment{Text: comment})
+	return nil
+}
+
+func (c *fakeCard) GetAttachments() ([]board.Attachment, error) { return c.attachments, nil }
+
+func (c *fakeCard) AddAttachment(attachment board.Attachment) error {
+	c.attachments = append(c.attachments, attachment)
+	return nil
+}
+
+// fakeBoard is a minimal in-memory board used by offline tests.
+type fakeBoard struct {
+	lists []string
+	cards []*fakeCard
+}
+
+func newFakeBoard(lists ...string) *fakeBoard {
+	return &fakeBoard{lists: lists}
+}
+
+func (b *fakeBoard) GetName() string { return "fake" }
+func (b *fakeBoard) GetURL() string  { return "https://board.test" }
+
+func (b *fakeBoard) GetMembers() ([]board.Member, error) { return nil, nil }
+
+func (b *fakeBoard) GetCards() ([]board.Card, error) {
+	var result []board.Card
+	for _, c := range b.cards {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (b *fakeBoard) CreateCard(name, description, listName string) (board.Card, error) {
+	c := &fakeCard{
+		id:          fmt.Sprintf("card%d", len(b.cards)+1),
+		name:        name,
+		description: description,
+		list:        listName,
+	}
+	b.cards = append(b.cards, c)
+	return c, nil
+}
+
+func (b *fakeBoard) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	var result []board.Card
+	for _, c := range b.cards {
+		for _, m := range c.members {
+			if strings.EqualFold(m, userName) {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (b *fakeBoard) GetCardsFromList(listName string) ([]board.Card, error) {
+	var result []board.Card
+	for _, c := range b.cards {
+		if strings.EqualFold(c.list, listName) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (b *fakeBoard) GetLists() ([]board.List, error) {
+	var result []board.List
+	for _, l := range b.lists {
+		result = append(result, &fakeList{name: l})
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/figma"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// figmaServer serves a file with one page holding a login frame, a loose rectangle and a button component.
+func figmaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/png" && r.Header.Get("X-Figma-Token") != "token" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.URL.Path == "/v1/files/AbC123/nodes" && r.URL.Query().Get("ids") == "1:2":
+			fmt.Fprint(w, `{"nodes": {"1:2": {"document": {"id": "1:2", "name": "Login / Default", "type": "FRAME"}}}}`)
+		case r.URL.Path == "/v1/files/AbC123":
+			fmt.Fprint(w, `{"document": {"id": "0:0", "type": "DOCUMENT", "children": [{"id": "0:1", "name": "Page 1", "type": "CANVAS", "children": [
+				{"id": "1:2", "name": "Login / Default", "type": "FRAME"},
+				{"id": "1:3", "name": "Rectangle", "type": "RECTANGLE"},
+				{"id": "1:4", "name": "Button", "type": "COMPONENT"}]}]}}`)
+		case r.URL.Path == "/v1/images/AbC123":
+			if r.URL.Query().Get("format") != "png" {
+				http.Error(w, "bad format", http.StatusBadRequest)
+				return
+			}
+			var images []string
+			for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+				images = append(images, fmt.Sprintf("%q: %q", id, server.URL+"/png?id="+id))
+			}
+			fmt.Fprintf(w, `{"err": null, "images": {%s}}`, strings.Join(images, ", "))
+		case r.URL.Path == "/png":
+			fmt.Fprint(w, "PNG "+r.URL.Query().Get("id"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestFigmaFindLinks(t *testing.T) {
+	links := figma.FindLinks("Mock-ups: https://www.figma.com/design/AbC123/Shop?node-id=1-2&t=x and " +
+		"(https://figma.com/file/AbC123/Shop) plus https://www.figma.com/design/AbC123/Shop?node-id=1-2 again.")
+	if len(links) != 2 || links[0] != (figma.Link{FileKey: "AbC123", NodeID: "1:2"}) || links[1] != (figma.Link{FileKey: "AbC123"}) {
+		t.Fatalf("unexpected links %+v", links)
+	}
+	if got := figma.NodeURL("AbC123", "1:2"); got != "https://www.figma.com/design/AbC123?node-id=1-2" {
+		t.Fatalf("unexpected node URL %s", got)
+	}
+}
+
+func TestFigmaClientExportsScreens(t *testing.T) {
+	server := figmaServer(t)
+	defer server.Close()
+	c := figma.NewClient("token", server.URL)
+
+	screens, err := c.Screens(figma.Link{FileKey: "AbC123"})
+	if err != nil || len(screens) != 2 || screens[0].Name != "Login / Default" || screens[1].Type != figma.TypeComponent {
+		t.Fatalf("expected the frame and the component of the page, got %+v, %v", screens, err)
+	}
+	images, err := c.ExportImages("AbC123", "png", 2, "1:2", "1:4")
+	if err != nil || len(images) != 2 {
+		t.Fatalf("ExportImages failed: %v, %v", images, err)
+	}
+	if data, err := c.Download(images["1:4"]); err != nil || string(data) != "PNG 1:4" {
+		t.Fatalf("Download failed: %q, %v", data, err)
+	}
+	if _, err := figma.NewClient("wrong", server.URL).Nodes("AbC123", "1:2"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected the rejected token reported, got %v", err)
+	}
+}
+
+func TestDesignerExportsFigmaFrames(t *testing.T) {
+	server := figmaServer(t)
+	defer server.Close()
+	m := sim.NewScriptedModel()
+	m.OnMode("DesignReview", agent.DesignReview{
+		Issues: []agent.DesignIssue{{Screen: "Login / Default", Severity: "low", Description: "The title is not in the brand font."}},
+		Spec:   "Title in Inter 24/32.",
+	})
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	a, err := s.Agent(agent.RoleDesigner, agent.RoleDesigner)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	designer := a.(*agent.DesignerAgent)
+	designer.Figma = figma.NewClient("token", server.URL)
+	card, _ := s.Board.CreateCard("Login page", "Design: https://www.figma.com/design/AbC123/Shop?node-id=1-2", board.ListDoing)
+	card.AssignTo(agent.RoleDesigner)
+
+	if err := designer.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	calls := m.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one review, got %d calls", len(calls))
+	}
+	if parts := model.MessageParts(calls[0].Input[len(calls[0].Input)-1]); len(parts) != 2 || parts[1].ImageURL != "data:image/png;base64,UE5HIDE6Mg==" {
+		t.Fatalf("expected the exported frame attached, got %+v", parts)
+	}
+	if asset, err := s.RepoFile("design-"+card.GetID(), "assets/design/figma/login-default.png"); err != nil || asset != "PNG 1:2" {
+		t.Fatalf("expected the export committed to the design branch, got %q, %v", asset, err)
+	}
+	comment := lastComment(t, card.(*sim.Card))
+	link := "https://www.figma.com/design/AbC123?node-id=1-2"
+	for _, want := range []string{
+		"[design review] Login / Default\n",
+		"- Login / Default: " + link + " (exported to assets/design/figma/login-default.png)",
+		"- [low] Login / Default: The title is not in the brand font. (" + link + ")",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Fatalf("expected %q in the review, got:\n%s", want, comment)
+		}
+	}
+
+	designer.Act()
+	if len(m.Calls()) != 1 {
+		t.Fatal("expected the reviewed frame not to be exported again")
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestFrontendActRequestsAssetsBeforeImplementing(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("PlanAssets", map[string]interface{}{"result": []agent.AssetRequest{{Name: "logo", Description: "Company logo for the header."}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	page := "export const Login = () => <img src=\"/assets/logo.svg\" />;\n"
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "web/Login.tsx", Content: page}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	ui, _ := s.Board.CreateCard("[ui] Login page", "Show the logo above the form.", board.ListSprint)
+	ui.AssignTo("frontend")
+	api, _ := s.Board.CreateCard("Login endpoint", "Check the password.", board.ListSprint)
+	api.AssignTo("frontend")
+	a, err := s.Agent(agent.RoleFrontend, "frontend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.(*agent.FrontendAgent).ReplyInterval = time.Millisecond
+	replyWhenAsked(ui.(*sim.Card), "@"+agent.RoleDesigner+" I need the following assets", agent.RoleDesigner, "The logo is at web/assets/logo.svg.")
+
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	if !strings.Contains(ui.GetDescription(), "Assets from the Designer:\nThe logo is at web/assets/logo.svg.") {
+		t.Fatalf("the Designer's answer is missing from %q", ui.GetDescription())
+	}
+	if members, _ := ui.GetAssignedMembers(); !slices.ContainsFunc(members, func(m board.Member) bool { return m.Name == agent.RoleDesigner }) {
+		t.Fatalf("expected the Designer assigned, got %+v", members)
+	}
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[0].Content), "Mode: Implement\n") && !strings.Contains(requestContent(c.Input[1].Content), "web/assets/logo.svg") {
+			t.Fatal("the implementation request does not mention the delivered assets")
+		}
+	}
+	if content, err := s.RepoFile(ui.GetID(), "web/Login.tsx"); err != nil || content != page {
+		t.Fatalf("unexpected page on the ticket branch: %q, %v", content, err)
+	}
+	if list, _ := ui.GetList(); list.GetName() != board.ListReview {
+		t.Fatalf("expected the UI ticket in Review, got %s", list.GetName())
+	}
+	if list, _ := api.GetList(); list.GetName() != board.ListSprint || modeCalls(m, "PlanAssets") != 1 {
+		t.Fatalf("Frontend picked up the unlabelled ticket; it is in %s", list.GetName())
+	}
+}
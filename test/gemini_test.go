@@ -0,0 +1,106 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/gemini"
+)
+
+// geminiTransport answers generateContent calls with body and keeps the last request.
+type geminiTransport struct {
+	status int
+	body   string
+	req    *http.Request
+	sent   map[string]interface{}
+}
+
+func (g *geminiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	g.req = req
+	data, _ := io.ReadAll(req.Body)
+	json.Unmarshal(data, &g.sent)
+	return &http.Response{StatusCode: g.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(g.body))}, nil
+}
+
+func TestGeminiClientMapsRequest(t *testing.T) {
+	transport := &geminiTransport{status: http.StatusOK, body: `{
+		"candidates": [{"content": {"role": "model", "parts": [{"text": "{\"points\": "}, {"text": "3}"}]}, "finishReason": "STOP"}],
+		"usageMetadata": {"promptTokenCount": 30, "candidatesTokenCount": 4},
+		"modelVersion": "gemini-2.5-pro"}`}
+	c := gemini.NewClient("key", "")
+	c.Models = map[string]string{"gpt-4o": "gemini-2.5-pro"}
+	c.SafetySettings = map[string]string{"HARM_CATEGORY_HARASSMENT": "BLOCK_ONLY_HIGH", "HARM_CATEGORY_DANGEROUS_CONTENT": "BLOCK_NONE"}
+	c.HTTPClient = &http.Client{Transport: transport}
+
+	var usage model.Usage
+	var out struct{ Points int }
+	err := c.ChatAdvancedParsed(model.ChatRequest{
+		Model: "gpt-4o",
+		Input: []model.Message{
+			{Role: "system", Content: "You estimate tickets."},
+			{Role: "user", Content: "Estimate login."},
+			{Role: "assistant", Content: "Which provider?"},
+			{Role: "user", Content: "Email only."},
+		},
+		Text:  &model.TextFormat{Format: model.FormatOptions{Type: "json_schema", Schema: map[string]interface{}{"type": "object"}}},
+		Usage: &usage,
+	}, &out)
+	if err != nil || out.Points != 3 {
+		t.Fatalf("ChatAdvancedParsed failed: %+v, %v", out, err)
+	}
+	if !strings.HasSuffix(transport.req.URL.Path, "/models/gemini-2.5-pro:generateContent") || transport.req.Header.Get("x-goog-api-key") != "key" {
+		t.Fatalf("unexpected request %s %v", transport.req.URL, transport.req.Header)
+	}
+
+	sent, _ := json.Marshal(transport.sent)
+	for _, want := range []string{
+		`"systemInstruction":{"parts":[{"text":"You estimate tickets."}]}`,
+		`{"parts":[{"text":"Which provider?"}],"role":"model"}`,
+		`"responseMimeType":"application/json"`,
+		`"safetySettings":[{"category":"HARM_CATEGORY_DANGEROUS_CONTENT","threshold":"BLOCK_NONE"},{"category":"HARM_CATEGORY_HARASSMENT","threshold":"BLOCK_ONLY_HIGH"}]`,
+	} {
+		if !strings.Contains(string(sent), want) {
+			t.Errorf("expected %s in the request, got %s", want, sent)
+		}
+	}
+	if contents := transport.sent["contents"].([]interface{}); len(contents) != 3 {
+		t.Fatalf("expected the system message left out of the turns, got %d turns", len(contents))
+	}
+	if usage.Model != "gemini-2.5-pro" || usage.PromptTokens != 30 || usage.CompletionTokens != 4 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestGeminiClientReportsBlockedPrompts(t *testing.T) {
+	c := gemini.NewClient("key", "")
+	c.HTTPClient = &http.Client{Transport: &geminiTransport{status: http.StatusOK, body: `{"promptFeedback": {"blockReason": "SAFETY"}}`}}
+	if _, err := c.Chat("hello"); err == nil || !strings.Contains(err.Error(), "SAFETY") {
+		t.Fatalf("expected the block reason reported, got %v", err)
+	}
+	c.HTTPClient = &http.Client{Transport: &geminiTransport{status: http.StatusTooManyRequests, body: `{"error": {"code": 429}}`}}
+	var limited *model.RateLimitError
+	if _, err := c.Chat("hello"); !errors.As(err, &limited) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+}
+
+func TestGeminiClientSendsImages(t *testing.T) {
+	transport := &geminiTransport{status: http.StatusOK, body: `{"candidates": [{"content": {"parts": [{"text": "Looks fine."}]}}]}`}
+	c := gemini.NewClient("key", "")
+	c.HTTPClient = &http.Client{Transport: transport}
+	req := model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Critique these."}}}
+	model.AttachImages(&req, model.ImageDataPart("image/png", []byte("png")), model.ImageURLPart("gs://designs/login.jpg"))
+	if _, err := c.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	sent, _ := json.Marshal(transport.sent["contents"])
+	want := `[{"parts":[{"text":"Critique these."},{"inline_data":{"data":"cG5n","mime_type":"image/png"}},{"file_data":{"file_uri":"gs://designs/login.jpg","mime_type":"image/jpeg"}}],"role":"user"}]`
+	if string(sent) != want {
+		t.Fatalf("unexpected contents %s", sent)
+	}
+}
@@ -0,0 +1,170 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	gitlabboard "github.com/egobogo/aiagents/internal/board/gitlab"
+	"github.com/egobogo/aiagents/internal/vcs"
+	"github.com/egobogo/aiagents/internal/vcs/gitlab"
+)
+
+// gitlabServer serves a fake GitLab API from routes keyed by method and path with query, and
+// records the JSON payloads it received under the same keys.
+func gitlabServer(t *testing.T, routes map[string]string) (*httptest.Server, map[string]map[string]string) {
+	t.Helper()
+	payloads := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			t.Errorf("missing token on %s %s", r.Method, r.URL)
+		}
+		key := r.Method + " " + r.URL.RequestURI()
+		if r.Method != http.MethodGet {
+			payload := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			payloads[key] = payload
+		}
+		body, ok := routes[key]
+		if !ok {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"403 Forbidden"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, payloads
+}
+
+func TestGitLabBoardUsesLabelsAsColumns(t *testing.T) {
+	server, payloads := gitlabServer(t, map[string]string{
+		"POST /projects/42/issues":  `{"iid":7,"title":"Add login","labels":["Doing","backend"]}`,
+		"PUT /projects/42/issues/7": `{"iid":7,"title":"Add login","labels":["Review","backend"]}`,
+		"GET /projects/42/issues?state=opened&labels=Review&per_page=100&page=1": `[{"iid":7,"title":"Add login","labels":["Review","backend"]}]`,
+	})
+	b := gitlabboard.NewGitLabClient("token", "42", server.URL, []string{board.ListDoing, board.ListReview})
+
+	card, err := b.CreateCard("Add login", "Email and password.", "doing")
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	if got := payloads["POST /projects/42/issues"]; got["labels"] != board.ListDoing || got["title"] != "Add login" {
+		t.Fatalf("unexpected issue payload %v", got)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListDoing {
+		t.Fatalf("expected the card in %s, got %s", board.ListDoing, list.GetName())
+	}
+
+	if err := card.Move(board.ListReview); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if got := payloads["PUT /projects/42/issues/7"]; got["add_labels"] != board.ListReview || got["remove_labels"] != board.ListDoing {
+		t.Fatalf("expected only the column label to change, got %v", got)
+	}
+	cards, err := b.GetCardsFromList(board.ListReview)
+	if err != nil || len(cards) != 1 || cards[0].GetID() != card.GetID() {
+		t.Fatalf("expected the moved card in %s, got %d (%v)", board.ListReview, len(cards), err)
+	}
+
+	if _, err := b.CreateCard("Add logout", "", "Nowhere"); err == nil {
+		t.Fatal("expected an error for a list without a label")
+	}
+	if _, err := b.GetCards(); err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("expected the API error to be reported, got %v", err)
+	}
+}
+
+func TestGitLabMergeRequests(t *testing.T) {
+	server, payloads := gitlabServer(t, map[string]string{
+		"POST /projects/42/merge_requests": `{"iid":3,"title":"Add login","source_branch":"feature/login","target_branch":"main","state":"opened"}`,
+		"GET /projects/42/merge_requests/3/notes?sort=asc&per_page=100&page=1": `[` +
+			`{"id":1,"body":"added 1 commit","system":true},` +
+			`{"id":2,"body":"Handle the empty password","author":{"username":"alice"},"position":{"new_path":"login.go","new_line":12}}]`,
+		"GET /projects/42/pipelines?ref=feature%2Flogin&per_page=1&order_by=id&sort=desc": `[{"id":9,"status":"running"}]`,
+	})
+	gl := gitlab.NewGitLabClient("token", "42", server.URL)
+
+	pr, err := gl.CreatePullRequest("Add login", "Closes #7", "feature/login", "main")
+	if err != nil {
+		t.Fatalf("CreatePullRequest failed: %v", err)
+	}
+	if pr.Number != 3 || pr.SourceBranch != "feature/login" {
+		t.Fatalf("unexpected merge request %+v", pr)
+	}
+	if got := payloads["POST /projects/42/merge_requests"]; got["target_branch"] != "main" || got["description"] != "Closes #7" {
+		t.Fatalf("unexpected merge request payload %v", got)
+	}
+
+	comments, err := gl.ReadPullRequestComments(3)
+	if err != nil {
+		t.Fatalf("ReadPullRequestComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "alice" || comments[0].Path != "login.go" || comments[0].Line != 12 {
+		t.Fatalf("expected the review note without system notes, got %+v", comments)
+	}
+
+	if status, err := gl.GetPipelineStatus("feature/login"); err != nil || status != vcs.PipelineRunning {
+		t.Fatalf("expected a running pipeline, got %s (%v)", status, err)
+	}
+	if _, err := gl.GetPullRequest(4); err == nil || !strings.Contains(err.Error(), "status 403") {
+		t.Fatalf("expected the API error to be reported, got %v", err)
+	}
+}
+
+// gitlabItems returns a JSON array of the n objects item builds for the indexes from, from+1 and so on.
+func gitlabItems(from, n int, item func(i int) string) string {
+	items := make([]string, 0, n)
+	for i := from; i < from+n; i++ {
+		items = append(items, item(i))
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func TestGitLabReadsEveryPage(t *testing.T) {
+	issue := func(i int) string { return fmt.Sprintf(`{"iid":%d,"title":"Ticket %d","labels":["Doing"]}`, i, i) }
+	note := func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"body":"Note %d","author":{"username":"alice"}}`, i, i)
+	}
+	server, _ := gitlabServer(t, map[string]string{
+		"GET /projects/42/issues?state=opened&per_page=100&page=1":                         gitlabItems(1, 100, issue),
+		"GET /projects/42/issues?state=opened&per_page=100&page=2":                         gitlabItems(101, 20, issue),
+		"GET /projects/42/issues?state=opened&assignee_username=alice&per_page=100&page=1": gitlabItems(1, 100, issue),
+		"GET /projects/42/issues?state=opened&assignee_username=alice&per_page=100&page=2": gitlabItems(101, 1, issue),
+		"GET /projects/42/issues?state=opened&labels=Doing&per_page=100&page=1":            gitlabItems(1, 100, issue),
+		"GET /projects/42/issues?state=opened&labels=Doing&per_page=100&page=2":            `[]`,
+		"GET /projects/42/merge_requests/3/notes?sort=asc&per_page=100&page=1":             gitlabItems(1, 100, note),
+		"GET /projects/42/merge_requests/3/notes?sort=asc&per_page=100&page=2":             gitlabItems(101, 5, note),
+	})
+	b := gitlabboard.NewGitLabClient("token", "42", server.URL, []string{board.ListDoing})
+
+	if cards, err := b.GetCards(); err != nil || len(cards) != 120 || cards[119].GetName() != "Ticket 120" {
+		t.Fatalf("expected all 120 issues, got %d (%v)", len(cards), err)
+	}
+	if cards, err := b.GetCardsAssignedTo("alice"); err != nil || len(cards) != 101 {
+		t.Fatalf("expected all 101 assigned issues, got %d (%v)", len(cards), err)
+	}
+	if cards, err := b.GetCardsFromList(board.ListDoing); err != nil || len(cards) != 100 {
+		t.Fatalf("expected all 100 issues in %s, got %d (%v)", board.ListDoing, len(cards), err)
+	}
+	gl := gitlab.NewGitLabClient("token", "42", server.URL)
+	if comments, err := gl.ReadPullRequestComments(3); err != nil || len(comments) != 105 || comments[104].Body != "Note 105" {
+		t.Fatalf("expected all 105 notes, got %d (%v)", len(comments), err)
+	}
+}
+
+func TestGitLabManualPipelineIsFinal(t *testing.T) {
+	server, _ := gitlabServer(t, map[string]string{
+		"GET /projects/42/pipelines?ref=main&per_page=1&order_by=id&sort=desc": `[{"id":9,"status":"manual"}]`,
+	})
+	gl := gitlab.NewGitLabClient("token", "42", server.URL)
+	status, err := vcs.WaitForPipeline(gl, "main", vcs.WaitOptions{Interval: time.Millisecond, Timeout: time.Second})
+	if err != nil || status != vcs.PipelineManual {
+		t.Fatalf("expected the wait to end on the manual job, got %s (%v)", status, err)
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// pushAuthorization pushes a commit to an HTTP remote that refuses every request and returns the
+// Authorization header the client sent, or the push error when it failed before any request.
+func pushAuthorization(t *testing.T, auth gitrepo.AuthConfig, username, token string) (string, error) {
+	t.Helper()
+	var mu sync.Mutex
+	header := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		header = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTempGitClient(t)
+	client.Auth = auth
+	if err := client.WriteFile("a.txt", []byte("a\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.CommitChanges("feat: add a", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if err := client.SetRemote("origin", server.URL+"/repo.git"); err != nil {
+		t.Fatalf("SetRemote failed: %v", err)
+	}
+	err := client.PushChanges(username, token)
+	if err == nil {
+		t.Fatal("expected the refusing remote to fail the push")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if header == "" && strings.Contains(err.Error(), "failed to configure auth") {
+		return "", err
+	}
+	return header, nil
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestPushUsesConfiguredAuth(t *testing.T) {
+	t.Setenv("TEST_GIT_TOKEN", "s3cret")
+	tokenAuth := gitrepo.AuthConfig{Method: gitrepo.AuthTokenEnv, Username: "bot", TokenEnv: "TEST_GIT_TOKEN"}
+
+	if got, err := pushAuthorization(t, tokenAuth, "", ""); err != nil || got != basicAuth("bot", "s3cret") {
+		t.Fatalf("expected the configured token, got %q (%v)", got, err)
+	}
+	// Explicit credentials take precedence over the configured ones.
+	if got, err := pushAuthorization(t, tokenAuth, "alice", "pw"); err != nil || got != basicAuth("alice", "pw") {
+		t.Fatalf("expected the explicit credentials, got %q (%v)", got, err)
+	}
+	if got, err := pushAuthorization(t, gitrepo.AuthConfig{}, "", ""); err != nil || got != "" {
+		t.Fatalf("expected an anonymous push, got %q (%v)", got, err)
+	}
+	missing := gitrepo.AuthConfig{Method: gitrepo.AuthTokenEnv, TokenEnv: "TEST_GIT_TOKEN_UNSET"}
+	if _, err := pushAuthorization(t, missing, "", ""); err == nil || !strings.Contains(err.Error(), "TEST_GIT_TOKEN_UNSET") {
+		t.Fatalf("expected the empty token variable to be reported, got %v", err)
+	}
+}
+
+func TestAuthConfigFromSettings(t *testing.T) {
+	if auth := gitrepo.AuthConfigFrom(nil); auth.Method != gitrepo.AuthNone {
+		t.Fatalf("expected anonymous access without settings, got %+v", auth)
+	}
+	t.Setenv("TEST_KEY_PASSPHRASE", "open sesame")
+	auth := gitrepo.AuthConfigFrom(&config.GitAuthSettings{Method: "ssh", SSHKeyPath: "/keys/deploy", SSHKeyPassphraseEnv: "TEST_KEY_PASSPHRASE"})
+	if auth.Method != gitrepo.AuthSSH || auth.SSHKeyPath != "/keys/deploy" || auth.SSHKeyPassphrase != "open sesame" {
+		t.Fatalf("unexpected auth %+v", auth)
+	}
+}
+
+func TestAuthFromEnv(t *testing.T) {
+	t.Setenv("GIT_SSH_KEY_PATH", "")
+	t.Setenv("GIT_TOKEN", "")
+	if auth := gitrepo.AuthFromEnv(); auth.Method != gitrepo.AuthNone {
+		t.Fatalf("expected anonymous access, got %+v", auth)
+	}
+	t.Setenv("GIT_TOKEN", "s3cret")
+	t.Setenv("GIT_USERNAME", "bot")
+	if auth := gitrepo.AuthFromEnv(); auth.Method != gitrepo.AuthTokenEnv || auth.TokenEnv != "GIT_TOKEN" || auth.Username != "bot" {
+		t.Fatalf("expected token auth, got %+v", auth)
+	}
+	t.Setenv("GIT_SSH_KEY_PATH", "/keys/deploy")
+	if auth := gitrepo.AuthFromEnv(); auth.Method != gitrepo.AuthSSH || auth.SSHKeyPath != "/keys/deploy" {
+		t.Fatalf("expected an SSH key to win over the token, got %+v", auth)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestShallowSparseClone(t *testing.T) {
+	origin := newTempGitClient(t)
+	for i, f := range []string{"services/payments/pay.go", "services/search/find.go", "README.md"} {
+		if err := origin.WriteFile(f, []byte("// file\n")); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := origin.CommitChanges("feat: add file "+string(rune('a'+i)), "tester", "tester@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+
+	opts := gitrepo.CloneOptions{Depth: 1, SingleBranch: true, SparsePaths: []string{"services/payments"}}
+	clone, err := origin.CloneFor(filepath.Join(t.TempDir(), "payments"), opts)
+	if err != nil {
+		t.Fatalf("CloneFor failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(clone.RepoPath, "services/payments/pay.go")); err != nil {
+		t.Fatalf("expected the sparse path checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(clone.RepoPath, "services/search/find.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected paths outside the sparse set left out, got %v", err)
+	}
+	if commits, err := clone.Log("", 10); err != nil || len(commits) != 1 {
+		t.Fatalf("expected a single commit of history, got %d (%v)", len(commits), err)
+	}
+
+	// Ticket worktrees are sparse too, and only the agent's changes show up as pending.
+	wt, err := clone.WorktreeFor("T-1")
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.RepoPath, "README.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected the worktree to be sparse, got %v", err)
+	}
+	if err := wt.WriteFile("services/payments/refund.go", []byte("// refund\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	changed, err := wt.ChangedFiles()
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+	if want := []string{"services/payments/refund.go"}; !reflect.DeepEqual(changed, want) {
+		t.Fatalf("expected %v pending, got %v", want, changed)
+	}
+	if err := wt.CommitChanges("feat: add refunds", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if content, err := wt.ReadFileAt("HEAD", "services/search/find.go"); err != nil || string(content) != "// file\n" {
+		t.Fatalf("expected files outside the sparse set kept in the commit, got %q (%v)", content, err)
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/policy"
+)
+
+func TestGitClientFileOperations(t *testing.T) {
+	client := newTempGitClient(t)
+	for path, content := range map[string]string{"pkg/old/a.go": "package old\n", "pkg/old/b.go": "package old\n", "tmp.txt": "scratch\n"} {
+		if err := client.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := client.CommitChanges("feat: add old package", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	if err := client.RenameFile("pkg/old", "pkg/new"); err != nil {
+		t.Fatalf("RenameFile failed: %v", err)
+	}
+	if err := client.DeleteFile("tmp.txt"); err != nil {
+		t.Fatalf("DeleteFile failed: %v", err)
+	}
+	if err := client.MkdirAll("docs/adr"); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := client.WriteFile("draft.md", []byte("# Draft\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.RenameFile("draft.md", "docs/adr/0001.md"); err != nil {
+		t.Fatalf("RenameFile of an uncommitted file failed: %v", err)
+	}
+	if err := client.RenameFile("pkg/new/a.go", "pkg/new/b.go"); err == nil {
+		t.Fatal("expected renaming onto an existing file to fail")
+	}
+
+	changed, err := client.ChangedFiles()
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+	sort.Strings(changed)
+	want := []string{"docs/adr/.gitkeep", "docs/adr/0001.md", "pkg/new/a.go", "pkg/new/b.go", "pkg/old/a.go", "pkg/old/b.go", "tmp.txt"}
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("expected %v pending, got %v", want, changed)
+	}
+	if err := client.CommitChanges("refactor: move the old package", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	for _, p := range []string{"pkg/new/a.go", "pkg/new/b.go", "docs/adr/.gitkeep", "docs/adr/0001.md"} {
+		if _, err := client.ReadFileAt("HEAD", p); err != nil {
+			t.Fatalf("expected %s committed: %v", p, err)
+		}
+	}
+	for _, p := range []string{"pkg/old/a.go", "tmp.txt", "draft.md"} {
+		if _, err := client.ReadFileAt("HEAD", p); err == nil {
+			t.Fatalf("expected %s gone", p)
+		}
+	}
+
+	// Moves and deletions are vetted like writes.
+	client.Guard = policy.New(policy.Rules{ProtectedPaths: []string{"pkg/new/**"}})
+	if err := client.RenameFile("pkg/new", "pkg/newer"); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	if err := client.DeleteFile("pkg/new/a.go"); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	if _, err := client.ReadFile("pkg/new/a.go"); err != nil {
+		t.Fatalf("expected refused changes to leave the files alone: %v", err)
+	}
+}
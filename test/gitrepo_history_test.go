@@ -0,0 +1,70 @@
+package test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogAndBlameAttributeChanges(t *testing.T) {
+	client := newTempGitClient(t)
+	commit := func(path, content, message, author string) {
+		t.Helper()
+		if err := client.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := client.CommitChanges(message, author, author+"@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	commit("main.go", "package main\n\nfunc main() {}\n", "feat: add main", "alice")
+	commit("README.md", "# Demo\n", "docs: add readme", "carol")
+	commit("main.go", "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n", "fix: greet", "bob")
+
+	commits, err := client.Log("main.go", 0)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	var messages []string
+	for _, c := range commits {
+		messages = append(messages, c.Message)
+	}
+	if len(commits) != 2 || strings.Contains(strings.Join(messages, "|"), "readme") {
+		t.Fatalf("expected the two commits touching main.go, got %q", messages)
+	}
+	if all, err := client.Log("", 0); err != nil || len(all) != 3 {
+		t.Fatalf("expected the full history, got %d commits (%v)", len(all), err)
+	}
+	if last, err := client.Log("", 1); err != nil || len(last) != 1 {
+		t.Fatalf("expected the log to be capped at one commit, got %d (%v)", len(last), err)
+	}
+
+	blame, err := client.Blame("main.go")
+	if err != nil {
+		t.Fatalf("Blame failed: %v", err)
+	}
+	if len(blame) != 5 || blame[0].Line != 1 || blame[0].AuthorName != "alice" {
+		t.Fatalf("unexpected blame %+v", blame)
+	}
+	for _, l := range blame {
+		want := "bob"
+		if l.Line <= 2 {
+			want = "alice" // The package clause and blank line are unchanged.
+		}
+		if l.AuthorName != want {
+			t.Fatalf("line %d %q attributed to %s, want %s", l.Line, l.Text, l.AuthorName, want)
+		}
+	}
+
+	history, err := client.DescribeHistory("main.go", 5)
+	if err != nil {
+		t.Fatalf("DescribeHistory failed: %v", err)
+	}
+	for _, want := range []string{"fix: greet (bob", "feat: add main (alice", "Line ownership:", "bob: 3 line(s)", "alice: 2 line(s)"} {
+		if !strings.Contains(history, want) {
+			t.Fatalf("expected %q in the history:\n%s", want, history)
+		}
+	}
+	if history, err := client.DescribeHistory("new.go", 5); err != nil || !strings.Contains(history, "(no history)") {
+		t.Fatalf("expected an empty history for an untracked file, got %q (%v)", history, err)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestReadsSkipLargeAndBinaryFiles(t *testing.T) {
+	client := newTempGitClient(t)
+	client.Limits = gitrepo.ReadOptions{MaxFileSize: 64}
+	files := map[string][]byte{
+		"main.go":  []byte("package main\n"),
+		"big.go":   []byte("package main\n\n// " + strings.Repeat("x", 100) + "\n"),
+		"logo.png": {0x89, 'P', 'N', 'G', 0x00, 0x1a},
+		"héllo.md": []byte("# héllo\n"),
+	}
+	for path, content := range files {
+		if err := client.WriteFile(path, content); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	if text, err := client.ReadText("héllo.md"); err != nil || text != "# héllo\n" {
+		t.Fatalf("expected UTF-8 text to be read, got %q (%v)", text, err)
+	}
+	if _, err := client.ReadText("big.go"); !errors.Is(err, gitrepo.ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+	if _, err := client.ReadFile("big.go"); !errors.Is(err, gitrepo.ErrFileTooLarge) {
+		t.Fatalf("expected raw reads to be capped too, got %v", err)
+	}
+	if _, err := client.ReadText("logo.png"); !errors.Is(err, gitrepo.ErrBinaryFile) {
+		t.Fatalf("expected ErrBinaryFile, got %v", err)
+	}
+	if data, err := client.ReadFile("logo.png"); err != nil || len(data) != len(files["logo.png"]) {
+		t.Fatalf("expected the raw bytes of a small binary file, got %d bytes (%v)", len(data), err)
+	}
+
+	listed, err := client.ListCodeFiles()
+	if err != nil {
+		t.Fatalf("ListCodeFiles failed: %v", err)
+	}
+	var names []string
+	for _, path := range listed {
+		names = append(names, filepath.Base(path))
+	}
+	if got := strings.Join(names, ","); strings.Contains(got, "big.go") || !strings.Contains(got, "main.go") {
+		t.Fatalf("expected the oversized file to be skipped, got %s", got)
+	}
+
+	client.Limits.Placeholders = true
+	if text, err := client.ReadText("big.go"); err != nil || text != "[file omitted: 118B exceeds 64B limit]" {
+		t.Fatalf("unexpected placeholder %q (%v)", text, err)
+	}
+	if text, err := client.ReadText("logo.png"); err != nil || text != "[binary, 6B]" {
+		t.Fatalf("unexpected placeholder %q (%v)", text, err)
+	}
+}
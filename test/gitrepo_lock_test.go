@@ -0,0 +1,69 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestGitClientConcurrentUse(t *testing.T) {
+	client := newTempGitClient(t)
+	if err := client.WriteFile("README.md", []byte("# demo\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.CommitChanges("docs: add readme", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	// Writes and status reads from many goroutines leave a consistent index.
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := client.WriteFile(fmt.Sprintf("files/%02d.txt", i), []byte("x\n")); err != nil {
+				errs <- err
+			}
+			if _, err := client.ChangedFiles(); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := client.CommitChanges("feat: add files", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	for i := 0; i < 16; i++ {
+		if _, err := client.ReadFileAt("HEAD", fmt.Sprintf("files/%02d.txt", i)); err != nil {
+			t.Fatalf("expected every file committed: %v", err)
+		}
+	}
+
+	// Agents picking up the same ticket at once share one checkout.
+	paths := make(chan string, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wt, err := client.WorktreeFor("T-1")
+			if err != nil {
+				errs <- err
+				return
+			}
+			paths <- wt.RepoPath
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	close(paths)
+	for err := range errs {
+		t.Fatalf("concurrent use failed: %v", err)
+	}
+	first := <-paths
+	for p := range paths {
+		if p != first {
+			t.Fatalf("expected one checkout, got %s and %s", first, p)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/policy"
+)
+
+func TestMergeRemoteConflicts(t *testing.T) {
+	origin := newTempGitClient(t)
+	if err := origin.WriteFile("config.txt", []byte("name=demo\nport=8080\nmode=dev\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := origin.CommitChanges("Initial commit", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	branch, err := origin.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch failed: %v", err)
+	}
+
+	local, err := gitrepo.NewGitClient(origin.RepoPath, filepath.Join(t.TempDir(), "clone"))
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	// Diverge: both sides change the same line.
+	if err := origin.WriteFile("config.txt", []byte("name=demo\nport=9090\nmode=dev\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := origin.CommitChanges("Remote change", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if err := local.WriteFile("config.txt", []byte("name=demo\nport=7070\nmode=dev\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := local.CommitChanges("Local change", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	conflicted, err := local.MergeRemote("", "", branch, "tester", "tester@example.com")
+	if !errors.Is(err, gitrepo.ErrConflicts) {
+		t.Fatalf("expected ErrConflicts, got %v", err)
+	}
+	if len(conflicted) != 1 || conflicted[0] != "config.txt" {
+		t.Fatalf("unexpected conflicted paths: %v", conflicted)
+	}
+
+	hunks, err := local.FindConflicts()
+	if err != nil {
+		t.Fatalf("FindConflicts failed: %v", err)
+	}
+	if len(hunks) != 1 || hunks[0].Ours != "port=7070" || hunks[0].Theirs != "port=9090" {
+		t.Fatalf("unexpected hunks: %+v", hunks)
+	}
+	if err := local.CompleteMerge("Merge", "tester", "tester@example.com"); err == nil {
+		t.Fatalf("CompleteMerge must refuse while conflicts remain")
+	}
+
+	if err := local.ResolveConflict("config.txt", 0, "port=9090"); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	if err := local.CompleteMerge("Merge", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CompleteMerge failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(local.RepoPath, "config.txt"))
+	if !strings.Contains(string(data), "port=9090") || strings.Contains(string(data), "<<<<<<<") {
+		t.Fatalf("unexpected merged content:\n%s", data)
+	}
+	if local.MergeInProgress() {
+		t.Fatalf("merge should be completed")
+	}
+}
+
+// divergedClones commits base to a new origin, then commits theirs there and ours in a clone.
+func divergedClones(t *testing.T, path, base, ours, theirs string) (local *gitrepo.GitClient, branch string) {
+	t.Helper()
+	origin := newTempGitClient(t)
+	commit := func(g *gitrepo.GitClient, content, message string) {
+		if err := g.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := g.CommitChanges(message, "tester", "tester@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	commit(origin, base, "Initial commit")
+	branch, err := origin.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch failed: %v", err)
+	}
+	local, err = gitrepo.NewGitClient(origin.RepoPath, filepath.Join(t.TempDir(), "clone"))
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	commit(origin, theirs, "Remote change")
+	commit(local, ours, "Local change")
+	return local, branch
+}
+
+func TestMergeRemoteMergesSeparateEditsToOneFile(t *testing.T) {
+	base := "package main\n\nimport \"fmt\"\n\nfunc a() {\n\tfmt.Println(\"a\")\n}\n\nfunc b() {\n\tfmt.Println(\"b\")\n}\n"
+	ours := strings.Replace(base, `"a"`, `"A"`, 1)
+	theirs := strings.Replace(base, `"b"`, `"B"`, 1) + "\nfunc c() {}\n"
+	local, branch := divergedClones(t, "main.go", base, ours, theirs)
+
+	conflicted, err := local.MergeRemote("", "", branch, "tester", "tester@example.com")
+	if err != nil {
+		t.Fatalf("expected a clean merge, got %v (%v)", err, conflicted)
+	}
+	data, _ := os.ReadFile(filepath.Join(local.RepoPath, "main.go"))
+	want := strings.Replace(theirs, `"a"`, `"A"`, 1)
+	if string(data) != want {
+		t.Fatalf("unexpected merged content:\n%s", data)
+	}
+	if local.MergeInProgress() {
+		t.Fatalf("clean merge should be committed")
+	}
+}
+
+func TestMergeRemoteConflictsOnlyOnOverlappingLines(t *testing.T) {
+	base := "one\ntwo\nthree\nfour\nfive\nsix\nseven\n"
+	ours := "one\nTWO\nthree\nfour\nfive\nSIX\nseven\neight\n"
+	theirs := "ONE\n2\nthree\nfour\nfive\n6\nseven\n"
+	local, branch := divergedClones(t, "numbers.txt", base, ours, theirs)
+
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); !errors.Is(err, gitrepo.ErrConflicts) {
+		t.Fatalf("expected ErrConflicts, got %v", err)
+	}
+	hunks, err := local.FindConflicts("numbers.txt")
+	if err != nil {
+		t.Fatalf("FindConflicts failed: %v", err)
+	}
+	if len(hunks) != 2 || hunks[0].Ours != "one\nTWO" || hunks[0].Theirs != "ONE\n2" || hunks[1].Ours != "SIX" || hunks[1].Theirs != "6" {
+		t.Fatalf("unexpected hunks: %+v", hunks)
+	}
+	data, _ := os.ReadFile(filepath.Join(local.RepoPath, "numbers.txt"))
+	if !strings.Contains(string(data), "\nthree\nfour\nfive\n") || !strings.HasSuffix(string(data), "seven\neight\n") {
+		t.Fatalf("unchanged and one-sided lines must be kept outside the conflicts:\n%s", data)
+	}
+}
+
+// behindClone commits v1 to a new origin, clones it and commits v2 there, so the clone can fast-forward.
+func behindClone(t *testing.T) (local *gitrepo.GitClient, branch string) {
+	t.Helper()
+	origin := newTempGitClient(t)
+	commit := func(content string) {
+		if err := origin.WriteFile("version.txt", []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := origin.CommitChanges("Set "+strings.TrimSpace(content), "tester", "tester@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	commit("v1\n")
+	branch, err := origin.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch failed: %v", err)
+	}
+	local, err = gitrepo.NewGitClient(origin.RepoPath, filepath.Join(t.TempDir(), "clone"))
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	commit("v2\n")
+	return local, branch
+}
+
+func TestMergeRemoteKeepsUncommittedChanges(t *testing.T) {
+	local, branch := behindClone(t)
+	if err := local.WriteFile("version.txt", []byte("work in progress\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); !errors.Is(err, gitrepo.ErrUncommittedChanges) {
+		t.Fatalf("expected ErrUncommittedChanges, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(local.RepoPath, "version.txt")); string(data) != "work in progress\n" {
+		t.Fatalf("the fast-forward discarded local changes: %q", data)
+	}
+}
+
+func TestMergesGoThroughCommitChecks(t *testing.T) {
+	base := "one\ntwo\nthree\n"
+	local, branch := divergedClones(t, "numbers.txt", base, "ONE\ntwo\nthree\n", "one\ntwo\nTHREE\n")
+	head, _ := local.Repo.Head()
+
+	local.Guard = policy.New(policy.Rules{Forbidden: []policy.Operation{policy.OpCommit}})
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err == nil {
+		t.Fatal("expected the policy to refuse the merge commit")
+	}
+	if after, _ := local.Repo.Head(); after.Hash() != head.Hash() {
+		t.Fatal("a refused merge moved HEAD")
+	}
+	if err := local.AbortMerge(); err != nil {
+		t.Fatalf("AbortMerge failed: %v", err)
+	}
+
+	local.Guard, local.DryRun = nil, true
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err != nil {
+		t.Fatalf("MergeRemote failed: %v", err)
+	}
+	if after, _ := local.Repo.Head(); after.Hash() != head.Hash() || local.MergeInProgress() {
+		t.Fatal("a dry-run merge changed the checkout")
+	}
+	if changed, _ := local.ChangedFiles(); len(changed) != 0 {
+		t.Fatalf("a dry-run merge wrote files: %v", changed)
+	}
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	local.DryRun, local.Events = false, log
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err != nil {
+		t.Fatalf("MergeRemote failed: %v", err)
+	}
+	evs, _ := log.Read(events.Filter{Types: []events.Type{events.Commit}})
+	if len(evs) != 1 || evs[0].Details["merge"] != "true" || evs[0].Details["author"] != "tester" {
+		t.Fatalf("expected the merge commit to be recorded, got %+v", evs)
+	}
+}
+
+func TestFastForwardGoesThroughCommitChecks(t *testing.T) {
+	local, branch := behindClone(t)
+	head, _ := local.Repo.Head()
+
+	local.Guard = policy.New(policy.Rules{Forbidden: []policy.Operation{policy.OpCommit}})
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err == nil {
+		t.Fatal("expected the policy to refuse the fast-forward")
+	}
+	local.Guard, local.DryRun = nil, true
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err != nil {
+		t.Fatalf("MergeRemote failed: %v", err)
+	}
+	if after, _ := local.Repo.Head(); after.Hash() != head.Hash() {
+		t.Fatal("a refused or dry-run fast-forward moved HEAD")
+	}
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	local.DryRun, local.Events = false, log
+	if _, err := local.MergeRemote("", "", branch, "tester", "tester@example.com"); err != nil {
+		t.Fatalf("MergeRemote failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(local.RepoPath, "version.txt")); string(data) != "v2\n" {
+		t.Fatalf("expected the fast-forward, got %q", data)
+	}
+	if evs, _ := log.Read(events.Filter{Types: []events.Type{events.Commit}}); len(evs) != 1 || evs[0].Details["fastForward"] != "true" {
+		t.Fatalf("expected the fast-forward to be recorded, got %+v", evs)
+	}
+}
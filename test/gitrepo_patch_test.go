@@ -0,0 +1,109 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// newTempGitClient initializes an empty repository in a temp dir and opens it with a GitClient.
+func newTempGitClient(t *testing.T) *gitrepo.GitClient {
+	t.Helper()
+	dir := t.TempDir()
+	if _, err := git.PlainInit(dir, false); err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	client, err := gitrepo.NewGitClient("", dir)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	return client
+}
+
+func TestApplyPatch(t *testing.T) {
+	client := newTempGitClient(t)
+	original := "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"
+	if err := client.WriteFile("main.go", []byte(original)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	diff := `--- a/main.go
++++ b/main.go
+@@ -3,3 +3,4 @@
+ func main() {
+-	println("hello")
++	println("hello, world")
++	println("bye")
+ }
+--- /dev/null
++++ b/README.md
+@@ -0,0 +1,1 @@
++# Demo
+`
+	changed, err := client.ApplyPatch(diff)
+	if err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("expected 2 changed files, got %v", changed)
+	}
+	got, _ := os.ReadFile(filepath.Join(client.RepoPath, "main.go"))
+	want := "package main\n\nfunc main() {\n\tprintln(\"hello, world\")\n\tprintln(\"bye\")\n}\n"
+	if string(got) != want {
+		t.Fatalf("unexpected content:\n%s", got)
+	}
+	readme, _ := os.ReadFile(filepath.Join(client.RepoPath, "README.md"))
+	if string(readme) != "# Demo\n" {
+		t.Fatalf("unexpected README content: %q", readme)
+	}
+
+	conflicting := `--- a/main.go
++++ b/main.go
+@@ -3,3 +3,3 @@
+ func main() {
+-	println("hello")
++	println("hi")
+ }
+`
+	_, err = client.ApplyPatch(conflicting)
+	var patchErr *gitrepo.PatchError
+	if !errors.As(err, &patchErr) {
+		t.Fatalf("expected PatchError, got %v", err)
+	}
+	if len(patchErr.Conflicts) != 1 || patchErr.Conflicts[0].Path != "main.go" {
+		t.Fatalf("unexpected conflicts: %+v", patchErr.Conflicts)
+	}
+	after, _ := os.ReadFile(filepath.Join(client.RepoPath, "main.go"))
+	if string(after) != want {
+		t.Fatalf("conflicting patch must not modify the file")
+	}
+}
+
+func TestApplyPatchRemovesLineStartingWithDashes(t *testing.T) {
+	client := newTempGitClient(t)
+	original := "-- users table\nCREATE TABLE users (id INTEGER);\n-- end\n"
+	if err := client.WriteFile("schema.sql", []byte(original)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	diff := `--- a/schema.sql
++++ b/schema.sql
+@@ -1,3 +1,2 @@
+--- users table
+ CREATE TABLE users (id INTEGER);
+--- end
++++ end of schema
+`
+	if _, err := client.ApplyPatch(diff); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(client.RepoPath, "schema.sql"))
+	if want := "CREATE TABLE users (id INTEGER);\n++ end of schema\n"; string(got) != want {
+		t.Fatalf("unexpected content:\n%s", got)
+	}
+}
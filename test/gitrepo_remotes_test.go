@@ -0,0 +1,129 @@
+package test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/policy"
+)
+
+func TestGitClientRemotes(t *testing.T) {
+	origin := newTempGitClient(t)
+	if err := origin.WriteFile("a.txt", []byte("a\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := origin.CommitChanges("feat: add a", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	local, err := gitrepo.NewGitClient(origin.RepoPath, filepath.Join(t.TempDir(), "local"))
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	mirrorPath := t.TempDir()
+	mirror, err := git.PlainInit(mirrorPath, true)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	if err := local.SetRemote("mirror", mirrorPath); err != nil {
+		t.Fatalf("SetRemote failed: %v", err)
+	}
+	remotes, err := local.Remotes()
+	if err != nil || len(remotes) != 2 || remotes[0].Name != "mirror" || remotes[1].Name != "origin" {
+		t.Fatalf("expected mirror and origin, got %+v (%v)", remotes, err)
+	}
+	branch, _ := local.CurrentBranch()
+	remoteHead := func(name string) string {
+		t.Helper()
+		ref, err := mirror.Reference(plumbing.NewBranchReferenceName(name), true)
+		if err != nil {
+			return ""
+		}
+		return ref.Hash().String()
+	}
+
+	// The first push sets the upstream; later pushes and pulls follow it.
+	if err := local.PushTo("", "", gitrepo.PushOptions{Remote: "mirror", Branch: "feature", SetUpstream: true}); err != nil {
+		t.Fatalf("PushTo failed: %v", err)
+	}
+	if remote, name, ok := local.Upstream(branch); !ok || remote != "mirror" || name != "feature" {
+		t.Fatalf("expected mirror/feature upstream, got %s/%s (%v)", remote, name, ok)
+	}
+	if err := local.WriteFile("b.txt", []byte("b\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := local.CommitChanges("feat: add b", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if err := local.PushChanges("", ""); err != nil {
+		t.Fatalf("PushChanges failed: %v", err)
+	}
+	head, _ := local.HeadCommit()
+	if remoteHead("feature") != head {
+		t.Fatal("expected the push to go to the upstream")
+	}
+
+	// Rewritten history needs force-with-lease, which the policy may forbid.
+	worktree, _ := local.Repo.Worktree()
+	parent, _ := local.Repo.ResolveRevision("HEAD~1")
+	if err := worktree.Reset(&git.ResetOptions{Commit: *parent, Mode: git.HardReset}); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if err := local.PushChanges("", ""); err == nil {
+		t.Fatal("expected a plain push of rewritten history to fail")
+	}
+	local.Guard = policy.New(policy.Rules{Forbidden: []policy.Operation{policy.OpForcePush}})
+	if err := local.PushTo("", "", gitrepo.PushOptions{ForceWithLease: true}); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	local.Guard = nil
+	if err := local.PushTo("", "", gitrepo.PushOptions{ForceWithLease: true}); err != nil {
+		t.Fatalf("PushTo with lease failed: %v", err)
+	}
+	if remoteHead("feature") != parent.String() {
+		t.Fatal("expected the remote branch rewritten")
+	}
+
+	// Someone else pushing in between breaks the lease.
+	other, err := gitrepo.NewGitClientWithOptions(mirrorPath, filepath.Join(t.TempDir(), "other"), gitrepo.AuthConfig{}, gitrepo.CloneOptions{Branch: "feature"})
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if err := other.WriteFile("c.txt", []byte("c\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := other.CommitChanges("feat: add c", "human", "human@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if err := other.PushChanges("", ""); err != nil {
+		t.Fatalf("PushChanges failed: %v", err)
+	}
+	if err := local.PushTo("", "", gitrepo.PushOptions{ForceWithLease: true}); err == nil {
+		t.Fatal("expected a stale lease to be refused")
+	}
+
+	// Pruning drops remote-tracking branches deleted upstream.
+	if err := local.PushTo("", "", gitrepo.PushOptions{Remote: "mirror", Branch: "old"}); err != nil {
+		t.Fatalf("PushTo failed: %v", err)
+	}
+	if err := local.Fetch("", "", "mirror", false); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	tracking := plumbing.NewRemoteReferenceName("mirror", "old")
+	if _, err := local.Repo.Reference(tracking, true); err != nil {
+		t.Fatalf("expected mirror/old to be tracked: %v", err)
+	}
+	if err := mirror.Storer.RemoveReference(plumbing.NewBranchReferenceName("old")); err != nil {
+		t.Fatalf("RemoveReference failed: %v", err)
+	}
+	if err := local.Fetch("", "", "mirror", true); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if _, err := local.Repo.Reference(tracking, true); err == nil {
+		t.Fatal("expected mirror/old to be pruned")
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestScopedClientRestrictsReadsAndWrites(t *testing.T) {
+	g := newTempGitClient(t)
+	for _, f := range []string{"services/payments/api.go", "services/users/api.go", "README.md"} {
+		if err := g.WriteFile(f, []byte("package x\n")); err != nil {
+			t.Fatalf("WriteFile %s failed: %v", f, err)
+		}
+	}
+
+	scoped := g.Scoped("services/payments/**")
+	files, err := scoped.ListFiles("**")
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(files) != 1 || files[0] != "services/payments/api.go" {
+		t.Fatalf("unexpected scoped listing: %v", files)
+	}
+	if _, err := scoped.ReadFile("services/users/api.go"); !errors.Is(err, gitrepo.ErrOutOfScope) {
+		t.Fatalf("expected ErrOutOfScope reading another service, got %v", err)
+	}
+	if err := scoped.WriteFile("services/users/api.go", []byte("hacked")); !errors.Is(err, gitrepo.ErrOutOfScope) {
+		t.Fatalf("expected ErrOutOfScope writing another service, got %v", err)
+	}
+	if err := scoped.WriteFile("services/payments/refund.go", []byte("package payments\n")); err != nil {
+		t.Fatalf("in-scope write failed: %v", err)
+	}
+
+	snapshot, _, err := scoped.GatherRepoInfo()
+	if err != nil {
+		t.Fatalf("GatherRepoInfo failed: %v", err)
+	}
+	if strings.Contains(snapshot, "users") || !strings.Contains(snapshot, "refund.go") {
+		t.Fatalf("context snapshot must only include the scope:\n%s", snapshot)
+	}
+	entries, err := scoped.ReadDir("services")
+	if err != nil || len(entries) != 1 || entries[0].Name != "payments" {
+		t.Fatalf("unexpected scoped ReadDir: %+v, %v", entries, err)
+	}
+
+	if err := g.CommitChanges("feat: add services", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if _, err := scoped.Log("services/users/api.go", 1); !errors.Is(err, gitrepo.ErrOutOfScope) {
+		t.Fatalf("expected ErrOutOfScope reading another service's log, got %v", err)
+	}
+	if _, err := scoped.Blame("services/users/api.go"); !errors.Is(err, gitrepo.ErrOutOfScope) {
+		t.Fatalf("expected ErrOutOfScope blaming another service, got %v", err)
+	}
+	if log, err := scoped.Log("services/payments/api.go", 1); err != nil || len(log) != 1 {
+		t.Fatalf("expected the in-scope log, got %v (%v)", log, err)
+	}
+
+	// Scopes stack: a ticket scope cannot widen the role scope.
+	narrower := scoped.Scoped("services/**/refund.go")
+	if narrower.InScope("services/payments/api.go") || !narrower.InScope("services/payments/refund.go") {
+		t.Fatalf("stacked scopes must intersect")
+	}
+	if !g.Scoped().InScope("services/users/api.go") {
+		t.Fatalf("an unscoped client must allow every path")
+	}
+}
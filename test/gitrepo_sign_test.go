@@ -0,0 +1,141 @@
+package test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// headCommit returns the commit HEAD points at.
+func headCommit(t *testing.T, client *gitrepo.GitClient) *object.Commit {
+	t.Helper()
+	head, err := client.Repo.Head()
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	commit, err := client.Repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	return commit
+}
+
+// signedPayload returns the commit as it was signed, i.e. without its signature.
+func signedPayload(t *testing.T, commit *object.Commit) []byte {
+	t.Helper()
+	unsigned := *commit
+	unsigned.PGPSignature = ""
+	encoded := &plumbing.MemoryObject{}
+	if err := unsigned.Encode(encoded); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	r, _ := encoded.Reader()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.Bytes()
+}
+
+func TestCommitSigning(t *testing.T) {
+	client := newTempGitClient(t)
+	keyring := t.TempDir()
+
+	// The backend agent signs with its own OpenPGP key.
+	entity, err := openpgp.NewEntity("backend", "", "backend@aiagents.local", nil)
+	if err != nil {
+		t.Fatalf("NewEntity failed: %v", err)
+	}
+	var private, public bytes.Buffer
+	w, _ := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	entity.SerializePrivate(w, nil)
+	w.Close()
+	w, _ = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	entity.Serialize(w)
+	w.Close()
+	if err := os.WriteFile(filepath.Join(keyring, "backend.asc"), private.Bytes(), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// Everyone else signs with the shared SSH key.
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey failed: %v", err)
+	}
+	shared, err := gitrepo.NewSigner("", pem.EncodeToMemory(block), "")
+	if err != nil {
+		t.Fatalf("NewSigner failed: %v", err)
+	}
+	client.Signing = &gitrepo.Signing{Default: shared, Dir: keyring}
+
+	client.WriteFile("a.txt", []byte("a\n"))
+	if err := client.CommitChanges("feat: add a", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	if _, err := headCommit(t, client).Verify(public.String()); err != nil {
+		t.Fatalf("expected a valid OpenPGP signature: %v", err)
+	}
+
+	client.WriteFile("b.txt", []byte("b\n"))
+	if err := client.CommitChanges("feat: add b", "release", "release@aiagents.local"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	commit := headCommit(t, client)
+	armored := strings.TrimSpace(commit.PGPSignature)
+	body, ok := strings.CutPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n")
+	body, ok2 := strings.CutSuffix(body, "\n-----END SSH SIGNATURE-----")
+	if !ok || !ok2 {
+		t.Fatalf("expected an SSH signature, got %q", commit.PGPSignature)
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\n", ""))
+	if err != nil || !bytes.HasPrefix(blob, []byte("SSHSIG")) {
+		t.Fatalf("malformed SSH signature: %v", err)
+	}
+	var sig struct {
+		Version                            uint32
+		PublicKey                          []byte
+		Namespace, Reserved, HashAlgorithm string
+		Signature                          []byte
+	}
+	if err := ssh.Unmarshal(blob[6:], &sig); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	signer, _ := ssh.NewPublicKey(pub)
+	if sig.Namespace != "git" || !bytes.Equal(sig.PublicKey, signer.Marshal()) {
+		t.Fatalf("unexpected signature header: %+v", sig)
+	}
+	hash := sha512.Sum512(signedPayload(t, commit))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace, Reserved, HashAlgorithm string
+		Hash                               []byte
+	}{"git", "", "sha512", hash[:]})...)
+	var s ssh.Signature
+	if err := ssh.Unmarshal(sig.Signature, &s); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if err := signer.Verify(signed, &s); err != nil {
+		t.Fatalf("expected a valid SSH signature: %v", err)
+	}
+
+	// Without a default key, authors need their own.
+	client.Signing = &gitrepo.Signing{Dir: keyring}
+	client.WriteFile("c.txt", []byte("c\n"))
+	if err := client.CommitChanges("feat: add c", "release", "release@aiagents.local"); !errors.Is(err, gitrepo.ErrNoSigningKey) {
+		t.Fatalf("expected ErrNoSigningKey, got %v", err)
+	}
+}
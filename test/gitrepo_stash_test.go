@@ -0,0 +1,120 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestGitClientStash(t *testing.T) {
+	client := newTempGitClient(t)
+	for path, content := range map[string]string{"a.txt": "a\n", "b.txt": "b\n"} {
+		if err := client.WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := client.CommitChanges("feat: add files", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	head, _ := client.Repo.Head()
+	if clean, err := client.StatusIsClean(); err != nil || !clean {
+		t.Fatalf("expected a clean tree, got %v, %v", clean, err)
+	}
+	if hash, err := client.Stash("nothing"); err != nil || hash != "" {
+		t.Fatalf("expected nothing to stash, got %q, %v", hash, err)
+	}
+
+	client.WriteFile("a.txt", []byte("a changed\n"))
+	os.Remove(filepath.Join(client.RepoPath, "b.txt"))
+	client.WriteFile("c.txt", []byte("new\n"))
+	if clean, _ := client.StatusIsClean(); clean {
+		t.Fatal("expected a dirty tree")
+	}
+	if hash, err := client.Stash("first"); err != nil || hash == "" {
+		t.Fatalf("Stash failed: %q, %v", hash, err)
+	}
+	if clean, _ := client.StatusIsClean(); !clean {
+		t.Fatal("expected Stash to leave a clean tree")
+	}
+	if now, _ := client.Repo.Head(); now.Hash() != head.Hash() {
+		t.Fatalf("expected HEAD to stay at %s, got %s", head.Hash(), now.Hash())
+	}
+
+	client.WriteFile("a.txt", []byte("a again\n"))
+	if _, err := client.Stash("second"); err != nil {
+		t.Fatalf("Stash failed: %v", err)
+	}
+
+	// Stashes pop newest first.
+	if err := client.StashPop(); err != nil {
+		t.Fatalf("StashPop failed: %v", err)
+	}
+	if content, _ := client.ReadFile("a.txt"); string(content) != "a again\n" {
+		t.Fatalf("expected the second stash back, got %q", content)
+	}
+	if err := client.ResetHard(); err != nil {
+		t.Fatalf("ResetHard failed: %v", err)
+	}
+	if content, _ := client.ReadFile("a.txt"); string(content) != "a\n" {
+		t.Fatalf("expected ResetHard to restore a.txt, got %q", content)
+	}
+	if err := client.StashPop(); err != nil {
+		t.Fatalf("StashPop failed: %v", err)
+	}
+	if content, _ := client.ReadFile("a.txt"); string(content) != "a changed\n" {
+		t.Fatalf("expected the first stash back, got %q", content)
+	}
+	if content, _ := client.ReadFile("c.txt"); string(content) != "new\n" {
+		t.Fatalf("expected the untracked file back, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(client.RepoPath, "b.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected the deletion back, got %v", err)
+	}
+	if err := client.StashPop(); !errors.Is(err, gitrepo.ErrNoStash) {
+		t.Fatalf("expected ErrNoStash, got %v", err)
+	}
+}
+
+func TestBackendStashesLeftoversBeforeImplementing(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "README.md", Content: "# Greet\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	card, _ := s.Board.CreateCard("Document", "Add a README.", board.ListDoing)
+	wt, err := s.Repo.WorktreeFor(card.GetID())
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	// An aborted run left a half-written file behind.
+	if err := wt.WriteFile("half.go", []byte("package gre")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if err := a.(*agent.BackendAgent).ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if _, err := wt.ReadFileAt("HEAD", "README.md"); err != nil {
+		t.Fatalf("expected the README committed: %v", err)
+	}
+	if _, err := wt.ReadFileAt("HEAD", "half.go"); err == nil {
+		t.Fatal("expected the leftover not to be committed")
+	}
+	if err := wt.StashPop(); err != nil {
+		t.Fatalf("expected the leftover stashed: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktreeFor(t *testing.T) {
+	client := newTempGitClient(t)
+	if err := client.WriteFile("README.md", []byte("# Demo\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := client.CommitChanges("Initial commit", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(client.RepoPath + "-worktrees") })
+
+	wt, err := client.WorktreeFor("PROJ-42")
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	branch, err := wt.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch failed: %v", err)
+	}
+	if branch != "ticket/PROJ-42" {
+		t.Fatalf("expected branch ticket/PROJ-42, got %s", branch)
+	}
+
+	if err := wt.WriteFile("feature.txt", []byte("work in progress")); err != nil {
+		t.Fatalf("WriteFile in worktree failed: %v", err)
+	}
+	if err := wt.CommitChanges("Add feature", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges in worktree failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(client.RepoPath, "feature.txt")); !os.IsNotExist(err) {
+		t.Fatalf("worktree changes leaked into the main checkout")
+	}
+
+	again, err := client.WorktreeFor("PROJ-42")
+	if err != nil {
+		t.Fatalf("reopening worktree failed: %v", err)
+	}
+	if again.RepoPath != wt.RepoPath {
+		t.Fatalf("expected the same worktree path, got %s and %s", wt.RepoPath, again.RepoPath)
+	}
+	if err := client.RemoveWorktree("PROJ-42"); err != nil {
+		t.Fatalf("RemoveWorktree failed: %v", err)
+	}
+}
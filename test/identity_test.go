@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestAgentAuthorDefaultsToName(t *testing.T) {
+	a := &agent.BaseAgent{Name: "backend"}
+	if name, email := a.Author(); name != "backend" || email != "backend@aiagents.local" {
+		t.Fatalf("expected the default identity, got %s <%s>", name, email)
+	}
+}
+
+func TestBackendCommitsUnderItsOwnIdentity(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "README.md", Content: "# Greet\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	card, _ := s.Board.CreateCard("Document", "Add a README.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.AuthorName, backend.AuthorEmail = "Backend Bot", "backend-bot@example.com"
+	if err := backend.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	wt, err := s.Repo.WorktreeFor(card.GetID())
+	if err != nil {
+		t.Fatalf("WorktreeFor failed: %v", err)
+	}
+	log, err := wt.Log("", 1)
+	if err != nil || len(log) == 0 {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if log[0].AuthorName != "Backend Bot" || log[0].AuthorEmail != "backend-bot@example.com" {
+		t.Fatalf("expected the agent's identity on the commit, got %s <%s>", log[0].AuthorName, log[0].AuthorEmail)
+	}
+}
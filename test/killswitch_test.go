@@ -0,0 +1,90 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/killswitch"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+)
+
+func TestKillSwitchHoldsBoardWrites(t *testing.T) {
+	killswitch.SetPollInterval(5 * time.Millisecond)
+	defer killswitch.SetPollInterval(killswitch.DefaultPollInterval)
+
+	fb := newFakeBoard(board.ListBacklog, board.ListDone)
+	b := board.Pausable(fb)
+
+	killswitch.Engage("test")
+	done := make(chan struct{})
+	go func() {
+		b.CreateCard("held", "", board.ListBacklog)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatalf("card created while the kill switch was engaged")
+	case <-time.After(30 * time.Millisecond):
+	}
+	killswitch.Release()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("write did not resume after release")
+	}
+	if len(fb.cards) != 1 {
+		t.Fatalf("expected the held card to be created once, got %d", len(fb.cards))
+	}
+}
+
+func TestKillSwitchFlagFileAndCard(t *testing.T) {
+	flag := filepath.Join(t.TempDir(), "STOP")
+	t.Setenv(killswitch.EnvFlagFile, flag)
+	if on, _ := killswitch.Engaged(); on {
+		t.Fatalf("engaged without a flag file")
+	}
+	os.WriteFile(flag, []byte("bad prompt in production\n"), 0644)
+	if on, why := killswitch.Engaged(); !on || why != "bad prompt in production" {
+		t.Fatalf("flag file not honoured: %v %q", on, why)
+	}
+	os.Remove(flag)
+
+	fb := newFakeBoard(board.ListBacklog, board.ListDone)
+	rule := orchestrator.NewKillSwitchCard()
+	card, _ := fb.CreateCard("Kill switch", "", board.ListBacklog)
+	rule.Apply(fb)
+	if on, _ := killswitch.Engaged(); !on {
+		t.Fatalf("kill switch card did not engage the switch")
+	}
+	card.Move(board.ListDone)
+	rule.Apply(fb)
+	if on, _ := killswitch.Engaged(); on {
+		t.Fatalf("closing the card did not release the switch")
+	}
+}
+
+func TestKillSwitchCardKeepsOtherHolds(t *testing.T) {
+	fb := newFakeBoard(board.ListBacklog, board.ListDone)
+	rule := orchestrator.NewKillSwitchCard()
+	card, _ := fb.CreateCard("KILL SWITCH", "", board.ListBacklog)
+	rule.Apply(fb)
+	killswitch.Engage("engaged from the dashboard")
+	defer killswitch.Release()
+	if on, why := killswitch.Engaged(); !on || !strings.Contains(why, "card ") || !strings.Contains(why, "dashboard") {
+		t.Fatalf("expected both holds, got %v %q", on, why)
+	}
+
+	card.Move(board.ListDone)
+	rule.Apply(fb)
+	if on, why := killswitch.Engaged(); !on || why != "engaged from the dashboard" {
+		t.Fatalf("closing the card released the dashboard's hold: %v %q", on, why)
+	}
+	killswitch.Release()
+	if on, _ := killswitch.Engaged(); on {
+		t.Fatalf("releasing the last hold did not release the switch")
+	}
+}
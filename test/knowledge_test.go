@@ -0,0 +1,82 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestKnowledgeIngestsDocsDirectory(t *testing.T) {
+	g := newTempGitClient(t)
+	docs := map[string]string{
+		"docs/guide.md":                 "# Style\n\nHandlers return wrapped errors.\n\n# Logging\n\nUse structured logging everywhere.\n",
+		"docs/adr/0001-use-postgres.md": "# Use PostgreSQL\n\nAll services store their data in PostgreSQL; no other database is allowed.\n",
+		"docs/api/openapi.yaml":         "paths:\n  /orders:\n    get:\n      summary: List orders\n",
+		"docs/tool.go":                  "package docs\n",
+	}
+	for name, src := range docs {
+		if err := g.WriteFile(name, []byte(src)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	kb := knowledge.New(nil)
+	n, err := kb.IngestRepo(g, "docs")
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 documents, got %d, %v", n, err)
+	}
+	if kb.Len() != 4 {
+		t.Fatalf("expected a chunk per heading and spec, got %d", kb.Len())
+	}
+
+	hits, err := kb.Search("Which database should the order service use?", 1)
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("Search failed: %+v, %v", hits, err)
+	}
+	if hits[0].Kind != knowledge.KindADR || hits[0].Heading != "Use PostgreSQL" {
+		t.Fatalf("expected the ADR, got %+v", hits[0])
+	}
+	if hits, _ := kb.Search("list orders endpoint", 1); len(hits) != 1 || hits[0].Kind != knowledge.KindAPI {
+		t.Fatalf("expected the API spec, got %+v", hits)
+	}
+
+	// Re-adding a document replaces its chunks.
+	if err := kb.Add("docs/guide.md", "Nothing here."); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if kb.Len() != 3 {
+		t.Fatalf("expected 3 chunks after replacing the guide, got %d", kb.Len())
+	}
+}
+
+func TestAgentPromptsIncludeKnowledge(t *testing.T) {
+	m := sim.NewScriptedModel()
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Knowledge = knowledge.New(nil)
+	if err := s.Knowledge.Add("docs/adr/0002-money.md", "# Money\n\nAmounts are stored as integer cents."); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleEngineeringManager, "manager")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.Think("", "How should we store amounts of money?", "Answer", nil)
+
+	for _, req := range m.Calls() {
+		for _, msg := range req.Input {
+			if strings.Contains(fmt.Sprint(msg.Content), "Amounts are stored as integer cents.") {
+				return
+			}
+		}
+	}
+	t.Fatalf("no prompt included the knowledge base entry")
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// fakeLinter installs a golangci-lint on PATH that flags every ignored error in calc.go.
+func fakeLinter(t *testing.T) {
+	t.Helper()
+	bin := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"if grep -q '_ = ' calc.go 2>/dev/null; then\n" +
+		"  echo 'calc.go:3:2: Error return value is not checked (errcheck)'\n" +
+		"  echo '1 issues:'\n" +
+		"  exit 1\n" +
+		"fi\n"
+	if err := os.WriteFile(filepath.Join(bin, codegen.LintCommand), []byte(script), 0755); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestChangedPackages(t *testing.T) {
+	got := codegen.ChangedPackages([]string{"main.go", "store/db.go", "store/db_test.go", "README.md", "api/v1/h.go"})
+	if strings.Join(got, ",") != ".,./api/v1,./store" {
+		t.Fatalf("unexpected packages: %v", got)
+	}
+}
+
+func TestCommitWorkFixesLintFindings(t *testing.T) {
+	fakeLinter(t)
+	m := sim.NewScriptedModel()
+	fixed := "package calc\n\nfunc Run() error { return nil }\n"
+	if _, err := m.OnMode("RepairLint", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc.go", Content: "package calc\n\nfunc Run() error { _ = 2; return nil }\n"}}}, "_ = 1"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("RepairLint", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc.go", Content: fixed}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"go.mod":  "module example.com/calc\n\ngo 1.24\n",
+		"calc.go": "package calc\n\nfunc Run() error { _ = do(); return nil }\n\nfunc do() error { return nil }\n",
+	})
+	card, _ := s.Board.CreateCard("Run", "", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.LintRounds = 1
+	if err := backend.CommitWork(s.Repo, card, "feat: add run", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitWork failed: %v", err)
+	}
+	if content, _ := s.RepoFile("", "calc.go"); content != fixed {
+		t.Fatalf("expected the lint fix to be committed, got %q", content)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "golangci-lint reports") {
+			prompt = text
+		}
+	}
+	if !strings.Contains(prompt, "calc.go:3:2: Error return value is not checked (errcheck)") || !strings.Contains(prompt, "File: calc.go") {
+		t.Fatalf("expected the findings and file in the prompt, got:\n%s", prompt)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) == 0 || !strings.Contains(comments[len(comments)-1].Text, "No lint findings.") {
+		t.Fatalf("expected the final lint report on the card, got %v", comments)
+	}
+
+	// Findings left after the last round are committed and reported.
+	writeModule(t, s.Repo.RepoPath, map[string]string{"calc.go": "package calc\n\nfunc Run() error { _ = 1; return nil }\n"})
+	if err := backend.CommitWork(s.Repo, card, "refactor: simplify run", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitWork failed: %v", err)
+	}
+	comments, _ = card.ReadComments()
+	if last := comments[len(comments)-1].Text; !strings.Contains(last, "(errcheck)") {
+		t.Fatalf("expected the remaining finding in the report, got %s", last)
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/memory"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestJournalPersistsQueriesAndExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.json")
+	j, err := memory.NewJournal(path)
+	if err != nil {
+		t.Fatalf("NewJournal failed: %v", err)
+	}
+	chi, err := j.Add("backend", "Architecture", "We use chi for routing", 0)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if again, _ := j.Add("backend", "Architecture", "we use chi for routing", 0); again.ID != chi.ID {
+		t.Fatalf("duplicate learning should refresh the original")
+	}
+	j.Add("backend", "Layout", "Auth lives in internal/auth", 0)
+	j.Add("backend", "", "Release freeze this week", 50*time.Millisecond)
+	j.Add("frontend", "", "Use React", 0)
+
+	reopened, err := memory.NewJournal(path)
+	if err != nil {
+		t.Fatalf("NewJournal failed: %v", err)
+	}
+	if got := reopened.Query("backend", "", 0); len(got) != 3 {
+		t.Fatalf("expected 3 backend learnings after reload, got %+v", got)
+	}
+	if got := reopened.Query("backend", "auth", 5); len(got) != 1 || got[0].Category != "Layout" {
+		t.Fatalf("unexpected query result: %+v", got)
+	}
+
+	time.Sleep(5 * 50 * time.Millisecond)
+	if n, err := reopened.Expire(time.Now()); err != nil || n != 1 {
+		t.Fatalf("expected one expired learning, got %d, %v", n, err)
+	}
+	if err := reopened.Forget(chi.ID); err != nil {
+		t.Fatalf("Forget failed: %v", err)
+	}
+	msg := reopened.SystemMessage("backend", 10)
+	if !strings.Contains(msg, "- Layout: Auth lives in internal/auth") || strings.Contains(msg, "chi") || strings.Contains(msg, "React") {
+		t.Fatalf("unexpected system message:\n%s", msg)
+	}
+}
+
+func TestAgentKeepsImportantThoughtsAcrossRuns(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.OnMode("Summarize", map[string]interface{}{"result": []map[string]interface{}{
+		{"category": "Architecture", "content": "We use chi for routing", "importance": 9},
+		{"category": "Chatter", "content": "The user said hello", "importance": 2},
+	}}, "Which router")
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	if s.LongTerm, err = memory.NewJournal(filepath.Join(t.TempDir(), "memory.json")); err != nil {
+		t.Fatalf("NewJournal failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleEngineeringManager, "manager")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.Think("", "Which router do we use? chi.", "Answer", nil)
+
+	learned := s.LongTerm.Query("manager", "", 0)
+	if len(learned) != 1 || learned[0].Text != "We use chi for routing" {
+		t.Fatalf("expected only the important thought to be kept, got %+v", learned)
+	}
+
+	// A restarted agent gets the learning as a system message.
+	restarted, err := s.Agent(agent.RoleEngineeringManager, "manager")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	before := len(m.Calls())
+	restarted.Think("", "Add a health endpoint", "Answer", nil)
+	for _, req := range m.Calls()[before:] {
+		for _, msg := range req.Input {
+			if msg.Role == "system" && strings.Contains(fmt.Sprint(msg.Content), "Architecture: We use chi for routing") {
+				return
+			}
+		}
+	}
+	t.Fatalf("no request carried the long-term memory")
+}
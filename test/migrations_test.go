@@ -0,0 +1,126 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// fakeDatabase appends the SQL to the scratch database file and fails on statements marked BROKEN.
+var fakeDatabase = []string{"sh", "-c", `cat >> "$0" && ! grep -q BROKEN "$0"`, "{db}"}
+
+func TestNextMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"db/001_init.up.sql":        "CREATE TABLE users (id INTEGER);",
+		"db/001_init.down.sql":      "DROP TABLE users;",
+		"db/002_add_name.up.sql":    "ALTER TABLE users ADD COLUMN name TEXT;",
+		"db/002_add_name.down.sql":  "ALTER TABLE users DROP COLUMN name;",
+		"db/README.md":              "Migrations.",
+		"db/003_seed_only.down.sql": "DELETE FROM users;",
+	})
+	existing, err := codegen.ListMigrations(dir, "db")
+	if err != nil || len(existing) != 2 || existing[1].Down != "db/002_add_name.down.sql" {
+		t.Fatalf("unexpected migrations %+v, %v", existing, err)
+	}
+	next := codegen.NextMigration(existing, "db", codegen.MigrationName("Add users.email"))
+	if next.Up != "db/003_add_users_email.up.sql" || next.Down != "db/003_add_users_email.down.sql" || next.ID() != "003_add_users_email" {
+		t.Fatalf("unexpected next migration %+v", next)
+	}
+	if first := codegen.NextMigration(nil, "db", "init"); first.Up != "db/0001_init.up.sql" {
+		t.Fatalf("unexpected first migration %+v", first)
+	}
+
+	writeModule(t, dir, map[string]string{next.Up: "ALTER TABLE users ADD COLUMN email TEXT;", next.Down: "BROKEN"})
+	err = codegen.VerifyMigration(dir, append(existing, next), fakeDatabase)
+	var migErr *codegen.MigrationError
+	if !errors.As(err, &migErr) || migErr.Step != "roll back 003_add_users_email.down.sql" {
+		t.Fatalf("expected the rollback to fail, got %v", err)
+	}
+	if err := codegen.VerifyMigration(dir, existing, []string{"no-such-database"}); !errors.Is(err, codegen.ErrNoDatabase) {
+		t.Fatalf("expected ErrNoDatabase, got %v", err)
+	}
+}
+
+func TestBackendAuthorsMigration(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{
+		Files: []agent.GeneratedFile{{Path: "users.sql", Content: "SELECT email FROM users;"}},
+		Migration: &agent.MigrationDraft{
+			Name:     "Add users email",
+			Up:       "ALTER TABLE users ADD COLUMN email TEXT BROKEN;",
+			Down:     "ALTER TABLE users DROP COLUMN email;",
+			Rollback: "Back up users.email before rolling back.",
+		},
+	}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	fixed := "ALTER TABLE users ADD COLUMN email TEXT;"
+	if _, err := m.OnMode("RepairMigration", agent.MigrationDraft{Up: fixed, Down: "ALTER TABLE users DROP COLUMN email;"}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"migrations/0001_init.up.sql":   "CREATE TABLE users (id INTEGER);",
+		"migrations/0001_init.down.sql": "DROP TABLE users;",
+	})
+	if err := s.Repo.CommitChanges("feat: add users table", "human", "human@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+	card, _ := s.Board.CreateCard("Store emails", "Users need an email address.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.MigrationsDir = "migrations"
+	backend.MigrationCommand = fakeDatabase
+
+	if err := backend.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	var implement, repair string
+	for _, c := range m.Calls() {
+		text := requestContent(c.Input[len(c.Input)-1].Content)
+		switch {
+		case strings.Contains(text, "Database migrations live in migrations"):
+			implement = text
+		case strings.Contains(text, "does not apply to a scratch database"):
+			repair = text
+		}
+	}
+	if !strings.Contains(implement, "CREATE TABLE users (id INTEGER);") {
+		t.Fatalf("expected the existing schema in the prompt, got:\n%s", implement)
+	}
+	if !strings.Contains(repair, "apply 0002_add_users_email.up.sql failed") {
+		t.Fatalf("expected the failure fed back, got:\n%s", repair)
+	}
+	if up, err := s.RepoFile(card.GetID(), "migrations/0002_add_users_email.up.sql"); err != nil || up != fixed {
+		t.Fatalf("expected the repaired migration committed, got %q, %v", up, err)
+	}
+	comments, _ := card.ReadComments()
+	var notes string
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, "Database migration") {
+			notes = c.Text
+		}
+	}
+	for _, want := range []string{
+		"Database migration 0002_add_users_email:\n- Apply: migrations/0002_add_users_email.up.sql\n- Roll back: migrations/0002_add_users_email.down.sql",
+		"Rollback steps:\nBack up users.email before rolling back.",
+		"Checked against a scratch database",
+	} {
+		if !strings.Contains(notes, want) {
+			t.Fatalf("expected %q in the migration notes, got:\n%s", want, notes)
+		}
+	}
+}
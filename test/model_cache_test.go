@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestCachedClient(t *testing.T) {
+	scripted := sim.NewScriptedModel()
+	scripted.On(`{"answer":"yes"}`, "question")
+	cache, err := model.NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+	client := model.NewCachedClient(scripted, cache, time.Hour)
+
+	req := model.ChatRequest{Model: "m", Input: []model.Message{{Role: "user", Content: "question"}}}
+	var out struct {
+		Answer string `json:"answer"`
+	}
+	for i := 0; i < 2; i++ {
+		if err := client.ChatAdvancedParsed(req, &out); err != nil || out.Answer != "yes" {
+			t.Fatalf("unexpected response %+v, %v", out, err)
+		}
+	}
+	if n := len(scripted.Calls()); n != 1 {
+		t.Fatalf("expected one model call, got %d", n)
+	}
+
+	req.NoCache = true
+	if _, err := client.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if n := len(scripted.Calls()); n != 2 {
+		t.Fatalf("bypass should reach the model, got %d calls", n)
+	}
+
+	expired := model.NewCachedClient(scripted, cache, time.Nanosecond)
+	other := model.ChatRequest{Model: "m", Input: []model.Message{{Role: "user", Content: "other question"}}}
+	expired.ChatAdvanced(other)
+	time.Sleep(time.Millisecond)
+	expired.ChatAdvanced(other)
+	if n := len(scripted.Calls()); n != 4 {
+		t.Fatalf("expired entries should be refetched, got %d calls", n)
+	}
+}
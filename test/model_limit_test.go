@@ -0,0 +1,85 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// waitForQueue waits until l has the given number of interactive and background requests queued.
+func waitForQueue(t *testing.T, l *model.Limiter, interactive, background int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		st := l.Stats()
+		if st.Interactive == interactive && st.Background == background {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d interactive and %d background requests queued, got %+v", interactive, background, st)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiterServesInteractiveRequestsFirst(t *testing.T) {
+	l := model.NewLimiter(1)
+	release := l.Acquire(model.PriorityInteractive)
+
+	order := make(chan string, 3)
+	queue := func(name string, p model.Priority) {
+		go func() {
+			done := l.Acquire(p)
+			order <- name
+			done()
+		}()
+	}
+	queue("refresh", model.PriorityBackground)
+	waitForQueue(t, l, 0, 1)
+	queue("ticket", model.PriorityInteractive)
+	waitForQueue(t, l, 1, 1)
+	queue("answer", model.PriorityInteractive)
+	waitForQueue(t, l, 2, 1)
+	if st := l.Stats(); st.InFlight != 1 || st.Max != 1 {
+		t.Fatalf("expected one request in flight, got %+v", st)
+	}
+
+	release()
+	for _, want := range []string{"ticket", "answer", "refresh"} {
+		select {
+		case got := <-order:
+			if got != want {
+				t.Fatalf("expected %s to run next, got %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected %s to be admitted", want)
+		}
+	}
+	waitForQueue(t, l, 0, 0)
+	if st := l.Stats(); st.InFlight != 0 || st.Requests != 4 {
+		t.Fatalf("expected every slot released after 4 requests, got %+v", st)
+	}
+}
+
+func TestLimitedClientPrioritizesByRequestClass(t *testing.T) {
+	if model.RequestPriority(model.ChatRequest{Class: model.ClassSummarization}) != model.PriorityBackground {
+		t.Fatal("expected summarization to run in the background")
+	}
+	if model.RequestPriority(model.ChatRequest{Class: model.ClassGeneration}) != model.PriorityInteractive {
+		t.Fatal("expected generation to be interactive")
+	}
+
+	m := sim.NewScriptedModel()
+	m.On("done", "Which day?")
+	l := model.NewLimiter(2)
+	c := model.NewLimitedClient(m, l)
+	resp, err := c.ChatAdvanced(model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Which day?"}}})
+	if err != nil || resp != "done" {
+		t.Fatalf("ChatAdvanced failed: %q, %v", resp, err)
+	}
+	if st := l.Stats(); st.InFlight != 0 || st.Requests != 1 {
+		t.Fatalf("expected the request to take and release a slot, got %+v", st)
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// throttledModel rejects its first requests with a rate limit before answering.
+type throttledModel struct {
+	*sim.ScriptedModel
+	rejections int
+	retryAfter time.Duration
+}
+
+func (m *throttledModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	if m.rejections > 0 {
+		m.rejections--
+		return "", &model.RateLimitError{StatusCode: http.StatusTooManyRequests, RetryAfter: m.retryAfter}
+	}
+	return m.ScriptedModel.ChatAdvanced(req)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 10, 10, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"-5":                            0,
+		"soon":                          0,
+		"Wed, 10 Jan 2024 10:01:00 GMT": time.Minute,
+		"Wed, 10 Jan 2024 09:00:00 GMT": 0,
+	}
+	for value, want := range cases {
+		if got := model.ParseRetryAfter(value, now); got != want {
+			t.Errorf("ParseRetryAfter(%q): expected %s, got %s", value, want, got)
+		}
+	}
+}
+
+func TestRetryingClientWaitsOutRateLimits(t *testing.T) {
+	m := &throttledModel{ScriptedModel: sim.NewScriptedModel(), rejections: 2}
+	m.On("done", "Which day?")
+	var waits []time.Duration
+	c := &model.RetryingClient{ModelClient: m, InitialDelay: 10 * time.Millisecond, Sleep: func(d time.Duration) { waits = append(waits, d) }}
+	req := model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Which day?"}}}
+	resp, err := c.ChatAdvanced(req)
+	if err != nil || resp != "done" {
+		t.Fatalf("ChatAdvanced failed: %q, %v", resp, err)
+	}
+	if len(waits) != 2 || waits[0] != 10*time.Millisecond || waits[1] != 20*time.Millisecond {
+		t.Fatalf("expected exponential backoff without Retry-After, got %v", waits)
+	}
+
+	m.rejections, m.retryAfter = 1, 50*time.Millisecond
+	waits = nil
+	if _, err := c.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if len(waits) != 1 || waits[0] != 50*time.Millisecond {
+		t.Fatalf("expected the Retry-After delay honoured, got %v", waits)
+	}
+	if degraded, _, _ := model.Degraded(); !degraded {
+		t.Fatal("expected the provider marked degraded while rate limited")
+	}
+
+	m.rejections, m.retryAfter = 10, time.Millisecond
+	c.MaxRetries = 3
+	_, err = c.ChatAdvanced(req)
+	var limited *model.RateLimitError
+	if !errors.As(err, &limited) {
+		t.Fatalf("expected the rate limit error after the retries, got %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if degraded, _, _ := model.Degraded(); degraded {
+		t.Fatal("expected the degraded state to end once Retry-After passed")
+	}
+}
+
+// statusTransport answers every request with a fixed response.
+type statusTransport struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func (s statusTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: s.status, Header: s.header, Body: io.NopCloser(strings.NewReader(s.body))}, nil
+}
+
+func TestChatGPTClientReportsRateLimits(t *testing.T) {
+	t.Chdir(t.TempDir()) // The client writes a debug log to the working directory.
+	c := chatgpt.NewChatGPTClient("key", "", nil)
+	c.HTTPClient = &http.Client{Transport: statusTransport{
+		status: http.StatusTooManyRequests,
+		header: http.Header{"Retry-After": []string{"7"}},
+		body:   `{"error": {"message": "Rate limit reached"}}`,
+	}}
+	_, err := c.Chat("hello")
+	var limited *model.RateLimitError
+	if !errors.As(err, &limited) || limited.StatusCode != http.StatusTooManyRequests || limited.RetryAfter != 7*time.Second {
+		t.Fatalf("expected a rate limit error with Retry-After, got %v", err)
+	}
+}
+
+func TestFleetSkipsRoundsWhileDegraded(t *testing.T) {
+	actor := &countingActor{}
+	fleet := orchestrator.NewFleet()
+	fleet.Add("backend", "Backend", actor)
+	degraded := true
+	fleet.Degraded = func() (bool, time.Time, string) {
+		return degraded, time.Now().Add(time.Minute), "429 Too Many Requests"
+	}
+	fleet.RunOnce()
+	if actor.runs != 0 {
+		t.Fatal("expected no agent to run while the provider is degraded")
+	}
+	degraded = false
+	fleet.RunOnce()
+	if actor.runs != 1 {
+		t.Fatalf("expected the agent to run once the provider recovered, ran %d times", actor.runs)
+	}
+}
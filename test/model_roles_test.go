@@ -0,0 +1,57 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+)
+
+func TestRoleModelSettingsReachRequests(t *testing.T) {
+	temperature, topP := 0.1, 0.9
+	useConfig(t, &config.Config{
+		GlobalModes: map[string]string{"Implement": "Write the code."},
+		Roles: map[string]config.RoleConfig{
+			agent.RoleBackend: {Prompt: "You are the backend developer.", ModelSettings: config.ModelSettings{
+				Model: "gpt-4.1", Temperature: &temperature, TopP: &topP, MaxTokens: 4000, ReasoningEffort: "high",
+			}},
+		},
+	})
+	client, sent := sentRequests(t)
+	// Agents get the shared client stack; the registry scopes it to each role.
+	shared := model.NewRetryingClient(model.NewLimitedClient(model.NewSummarizingClient(client), model.NewLimiter(2)))
+	builder := chatgptpromptbuilder.New()
+	for _, role := range []string{agent.RoleBackend, agent.RoleDevOps} {
+		deps := &agent.BaseAgent{Name: role, ModelClient: shared, PromptBuilder: builder}
+		if _, err := agent.DefaultRegistry.New(role, deps); err != nil {
+			t.Fatalf("New(%s) failed: %v", role, err)
+		}
+		req, err := builder.Build(role, "Implement", "", "Add login", nil, deps.ModelClient.GetTemperature(), deps.ModelClient.GetModel())
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		if _, err := deps.ModelClient.ChatAdvanced(req); err != nil {
+			t.Fatalf("ChatAdvanced failed: %v", err)
+		}
+	}
+
+	got := sent()
+	if len(got) != 2 {
+		t.Fatalf("expected two requests, got %d", len(got))
+	}
+	backend := got[0]
+	if backend.Model != "gpt-4.1" || backend.Temperature != temperature || backend.TopP == nil || *backend.TopP != topP ||
+		backend.MaxTokens != 4000 || backend.Reasoning == nil || backend.Reasoning.Effort != "high" {
+		t.Fatalf("backend request does not use the role's settings: %+v", backend)
+	}
+	// Roles without settings keep the client's.
+	devops := got[1]
+	if devops.Model != "gpt-4o" || devops.Temperature != client.Temperature || devops.TopP != nil || devops.MaxTokens != 0 || devops.Reasoning != nil {
+		t.Fatalf("DevOps request does not use the client's settings: %+v", devops)
+	}
+	if client.Model != "gpt-4o" || client.MaxTokens != 0 {
+		t.Fatal("scoping a role changed the shared client")
+	}
+}
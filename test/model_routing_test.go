@@ -0,0 +1,177 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// staticConfig is a ConfigProvider returning a fixed configuration.
+type staticConfig struct{ cfg *config.Config }
+
+func (p staticConfig) LoadConfig(string) (*config.Config, error) { return p.cfg, nil }
+
+// useConfig loads cfg for the rest of the test and restores the previous configuration afterwards.
+func useConfig(t *testing.T, cfg *config.Config) {
+	t.Helper()
+	previous := config.GetLoadedConfig()
+	config.SetProvider(staticConfig{cfg})
+	if err := config.Load(""); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	t.Cleanup(func() {
+		config.SetProvider(staticConfig{previous})
+		config.Load("")
+	})
+}
+
+// sentRequests returns a ChatGPT client answering every request with "ok" and the requests it sent.
+func sentRequests(t *testing.T) (*chatgpt.ChatGPTClient, func() []model.ChatRequest) {
+	t.Helper()
+	t.Chdir(t.TempDir()) // The client writes a debug log to the working directory.
+	var sent []model.ChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req model.ChatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		sent = append(sent, req)
+		w.Write([]byte(`{"output":[{"type":"message","content":[{"text":"ok"}]}]}`))
+	}))
+	t.Cleanup(server.Close)
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o", nil)
+	client.Endpoint = func(path string) string { return server.URL + path }
+	return client, func() []model.ChatRequest { return sent }
+}
+
+func TestRoutesTakePrecedenceOverRoleModels(t *testing.T) {
+	cfg := &config.Config{
+		Roles: map[string]config.RoleConfig{
+			"backend": {ModelSettings: config.ModelSettings{Model: "gpt-4.1", MaxTokens: 2000}},
+		},
+		ModelRouting: map[string]string{"classification": "gpt-4o-mini"},
+	}
+	useConfig(t, cfg)
+	client, sent := sentRequests(t)
+	router := model.RouterFromConfig(client)
+	backend := router.(model.RoleScoped).ForRole("backend")
+	qa := router.(model.RoleScoped).ForRole("qa")
+
+	// PromptBuilder fills in the scoped client's model, as agents do.
+	requests := []struct {
+		client model.ModelClient
+		class  model.RequestClass
+		want   string
+	}{
+		{backend, model.ClassClassification, "gpt-4o-mini"},
+		{backend, model.ClassGeneration, "gpt-4.1"},
+		{qa, model.ClassClassification, "gpt-4o-mini"},
+		{qa, model.ClassGeneration, "gpt-4o"},
+	}
+	for _, r := range requests {
+		if _, err := r.client.ChatAdvanced(model.ChatRequest{Model: r.client.GetModel(), Class: r.class, Input: []model.Message{{Role: "user", Content: "hi"}}}); err != nil {
+			t.Fatalf("ChatAdvanced failed: %v", err)
+		}
+	}
+	got := sent()
+	if len(got) != len(requests) {
+		t.Fatalf("expected %d requests, got %d", len(requests), len(got))
+	}
+	for i, r := range requests {
+		if got[i].Model != r.want {
+			t.Errorf("request %d (%s): expected %s, got %s", i, r.class, r.want, got[i].Model)
+		}
+	}
+	// The role's other settings still apply to routed requests.
+	if got[0].MaxTokens != 2000 || got[2].MaxTokens != 0 {
+		t.Fatalf("unexpected token limits %d and %d", got[0].MaxTokens, got[2].MaxTokens)
+	}
+}
+
+func TestRouterWithoutRoutesKeepsClient(t *testing.T) {
+	useConfig(t, &config.Config{})
+	client := sim.NewScriptedModel()
+	if model.RouterFromConfig(client) != model.ModelClient(client) {
+		t.Fatal("expected the client unchanged without routes")
+	}
+}
+
+// routedManager returns an engineering manager whose requests go through a router sending
+// classification requests to "cheap".
+func routedManager(t *testing.T, m *sim.ScriptedModel) *agent.EngineeringManagerAgent {
+	t.Helper()
+	s, err := sim.New(model.NewRouter(m, map[model.RequestClass]string{model.ClassClassification: "cheap"}))
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	return a.(*agent.EngineeringManagerAgent)
+}
+
+func TestJudgedRepliesAreClassified(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode(agent.ModeClassify, agent.Classification{Answer: true}, "ship it"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	em := routedManager(t, m)
+	approves := em.Judged("approve the release")
+
+	if !approves(board.Comment{Text: "Looks fine, ship it"}) {
+		t.Fatal("expected the approval to match")
+	}
+	if approves(board.Comment{Text: "Wait until Monday"}) {
+		t.Fatal("expected the objection not to match")
+	}
+	if n := modeCalls(m, agent.ModeClassify); n != 2 {
+		t.Fatalf("expected both comments to be classified, got %d requests", n)
+	}
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[0].Content), "Mode: Classify\n") && (c.Class != model.ClassClassification || c.Model != "cheap") {
+			t.Fatalf("expected a routed classification request, got %s to %s", c.Class, c.Model)
+		}
+	}
+}
+
+func TestSelfReviewChecksAreClassified(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 3},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode(agent.ModeClassify, agent.DraftCheck{}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	em := routedManager(t, m)
+	em.SelfReview = 1
+	parent, _ := em.BoardClient.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	if _, err := em.HandleTicket(parent); err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+
+	checks := 0
+	for _, c := range m.Calls() {
+		if c.Class == model.ClassClassification {
+			checks++
+			if c.Model != "cheap" {
+				t.Fatalf("expected the check to be routed, got %s", c.Model)
+			}
+		} else if c.Model == "cheap" {
+			t.Fatalf("%s request routed to the classification model", c.Class)
+		}
+	}
+	if checks != 1 {
+		t.Fatalf("expected one classified check, got %d", checks)
+	}
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+var pointsSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"title":       map[string]interface{}{"type": "string"},
+		"storyPoints": map[string]interface{}{"type": "integer", "enum": []interface{}{1, 2, 3, 5, 8, 13}},
+	},
+	"required":             []interface{}{"title", "storyPoints"},
+	"additionalProperties": false,
+}
+
+func TestChatStructuredRepairsInvalidOutput(t *testing.T) {
+	scripted := sim.NewScriptedModel()
+	scripted.On(`{"title":"Add login","storyPoints":4}`, "estimate").Times = 1
+	scripted.On(`{"title":"Add login","storyPoints":5}`, "estimate", "not one of")
+
+	req := model.ChatRequest{
+		Input: []model.Message{{Role: "user", Content: "estimate the ticket"}},
+		Text:  &model.TextFormat{Format: model.FormatOptions{Type: "json_schema", Schema: pointsSchema}},
+	}
+	var out struct {
+		Title       string `json:"title"`
+		StoryPoints int    `json:"storyPoints"`
+	}
+	if err := model.ChatStructured(scripted, req, &out); err != nil {
+		t.Fatalf("ChatStructured failed: %v", err)
+	}
+	if out.StoryPoints != 5 || len(scripted.Calls()) != 2 {
+		t.Fatalf("expected repaired answer after one retry, got %+v with %d calls", out, len(scripted.Calls()))
+	}
+
+	broken := sim.NewScriptedModel()
+	broken.On(`{"title":"Add login"}`, "estimate")
+	err := model.ChatStructured(broken, req, &out)
+	if !errors.Is(err, model.ErrInvalidOutput) {
+		t.Fatalf("expected ErrInvalidOutput, got %v", err)
+	}
+	if n := len(broken.Calls()); n != model.DefaultStructuredRetries+1 {
+		t.Fatalf("expected %d attempts, got %d", model.DefaultStructuredRetries+1, n)
+	}
+}
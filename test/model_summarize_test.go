@@ -0,0 +1,84 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func longTurn(topic string) string {
+	return strings.Repeat("We discussed "+topic+" at length and agreed on the details.\n", 10)
+}
+
+func TestSummarizingClientReplacesOlderTurns(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.On("SUMMARY: use postgres, ship friday", "Summarize the following conversation")
+	m.On("done", "Which day?")
+	c := &model.SummarizingClient{ModelClient: m, Window: 400}
+
+	req := model.ChatRequest{Model: "gpt-4o-mini", Input: []model.Message{
+		{Role: "system", Content: "You are a backend engineer."},
+		{Role: "user", Content: longTurn("the database")},
+		{Role: "assistant", Content: longTurn("postgres")},
+		{Role: "user", Content: longTurn("the release")},
+		{Role: "assistant", Content: "Friday works."},
+		{Role: "user", Content: "Which day?"},
+	}}
+	resp, err := c.ChatAdvanced(req)
+	if err != nil || resp != "done" {
+		t.Fatalf("ChatAdvanced failed: %q, %v", resp, err)
+	}
+
+	// Older turns larger than half the budget are summarized in several pieces.
+	calls := m.Calls()
+	if len(calls) < 2 {
+		t.Fatalf("expected summarization calls followed by the request, got %d calls", len(calls))
+	}
+	for _, call := range calls[:len(calls)-1] {
+		if call.Class != model.ClassSummarization {
+			t.Fatalf("expected a summarization call, got %+v", call)
+		}
+	}
+	final := calls[len(calls)-1]
+	sent := final.Input
+	if len(sent) != 4 || sent[0].Role != "system" || sent[2].Content != "Friday works." || sent[3].Content != "Which day?" {
+		t.Fatalf("unexpected compacted request: %+v", sent)
+	}
+	if !strings.Contains(model.MessageText(sent[1]), "SUMMARY: use postgres, ship friday") {
+		t.Fatalf("older turns were not replaced by the summary: %+v", sent[1])
+	}
+	if model.EstimateRequestTokens(final) > 320 {
+		t.Fatalf("compacted request is still over the threshold: %d tokens", model.EstimateRequestTokens(final))
+	}
+}
+
+func TestSummarizingClientShortensLongLastMessage(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.On("SUMMARY", "Summarize the following conversation")
+	c := &model.SummarizingClient{ModelClient: m, Window: 400}
+
+	text := longTurn("old questions") + longTurn("more old questions") + "Latest answer: use UTC.\n"
+	req := model.ChatRequest{Model: "gpt-4o-mini", Input: []model.Message{
+		{Role: "system", Content: "sys"},
+		{Role: "user", Content: []map[string]string{{"type": "input_text", "text": text}}},
+	}}
+	out, err := c.Compact(req)
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	last := out.Input[len(out.Input)-1]
+	got := model.MessageText(last)
+	if !strings.HasPrefix(got, "Summary of the earlier part:\nSUMMARY") || !strings.HasSuffix(got, "Latest answer: use UTC.\n") {
+		t.Fatalf("unexpected last message:\n%s", got)
+	}
+	if _, ok := last.Content.([]map[string]string); !ok {
+		t.Fatalf("content shape should be preserved, got %T", last.Content)
+	}
+
+	small := model.ChatRequest{Model: "gpt-4o-mini", Input: []model.Message{{Role: "user", Content: "hi"}}}
+	if out, _ := c.Compact(small); len(out.Input) != 1 || out.Input[0].Content != "hi" {
+		t.Fatalf("requests within the window must be left alone")
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	if n := model.EstimateTokens("", "gpt-4o"); n != 0 {
+		t.Fatalf("expected no tokens for empty text, got %d", n)
+	}
+	short := model.EstimateTokens("Add a login form.", "gpt-4o")
+	long := model.EstimateTokens(strings.Repeat("Add a login form. ", 20), "gpt-4o")
+	if short < 4 || long <= short*10 {
+		t.Fatalf("expected estimates to grow with the text, got %d and %d", short, long)
+	}
+	word := strings.Repeat("internationalization", 3)
+	if model.EstimateTokens(word, "gpt-4o") > model.EstimateTokens(word, "gpt-4") {
+		t.Fatal("expected the larger o200k vocabulary to need no more tokens than cl100k")
+	}
+
+	messages := []model.Message{{Role: "system", Content: "Be brief."}, {Role: "user", Content: "Add a login form."}}
+	sum := 0
+	for _, m := range messages {
+		sum += model.EstimateTokens(m.Role, "gpt-4o") + model.EstimateTokens(m.Content.(string), "gpt-4o")
+	}
+	if n := model.EstimateMessageTokens(messages, "gpt-4o"); n <= sum {
+		t.Fatalf("expected the chat format overhead on top of %d tokens, got %d", sum, n)
+	}
+}
+
+func TestTrimToTokens(t *testing.T) {
+	text := strings.Repeat("func handler() error { return nil }\n", 50)
+	trimmed := model.TrimToTokens(text, "gpt-4o", 40)
+	if n := model.EstimateTokens(trimmed, "gpt-4o"); n == 0 || n > 40 {
+		t.Fatalf("expected at most 40 tokens, got %d", n)
+	}
+	if !strings.HasSuffix(trimmed, "\n") || !strings.HasPrefix(text, trimmed) {
+		t.Fatalf("expected a prefix cut at a line boundary, got %q", trimmed)
+	}
+	if model.TrimToTokens("short", "gpt-4o", 40) != "short" || model.TrimToTokens(text, "gpt-4o", 0) != "" {
+		t.Fatal("unexpected trimming of short text or a zero budget")
+	}
+}
+
+func TestUnreportedUsageIsLeftOutOfCost(t *testing.T) {
+	// Without rank files the tokens cannot be counted either.
+	model.SetTokenRanksDir(t.TempDir())
+	defer model.SetTokenRanksDir("")
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	m := sim.NewScriptedModel()
+	m.On("Which day?", "Friday")
+	client := events.WrapModel(m, log, "backend", func() string { return "card1" })
+	client.ChatAdvanced(model.ChatRequest{Model: "gpt-4o", Input: []model.Message{{Role: "user", Content: "Which day?"}}})
+	// Logs written before estimates were kept apart stored them as token counts.
+	log.Record(events.Event{Agent: "backend", TicketID: "card1", Type: events.ModelCall, Target: "gpt-4o",
+		Details: map[string]string{"tokens": "1000000", "completionTokens": "1000", "estimated": "true"}})
+
+	evs, err := log.Read(events.Filter{Types: []events.Type{events.ModelCall}})
+	if err != nil || len(evs) != 2 {
+		t.Fatalf("Read failed: %v (%d events)", err, len(evs))
+	}
+	if n, _ := strconv.Atoi(evs[0].Details["estimatedTokens"]); n == 0 {
+		t.Fatalf("expected the estimate to be kept, got %+v", evs[0].Details)
+	}
+	if _, ok := evs[0].Details["tokens"]; ok {
+		t.Fatalf("estimates must not be stored as token counts: %+v", evs[0].Details)
+	}
+
+	report := events.AggregateUsage(evs)
+	if report.Total.Calls != 2 || report.Total.Unreported != 2 || report.Total.Tokens() != 0 {
+		t.Fatalf("unexpected totals: %+v", report.Total)
+	}
+	if usd, _ := report.Cost(); usd != 0 {
+		t.Fatalf("expected no cost from estimates, got $%.2f", usd)
+	}
+	text := orchestrator.CostReport(evs, "Done", time.Now())
+	if !strings.Contains(text, "2 calls without reported usage left out") {
+		t.Fatalf("expected the report to say usage is missing, got:\n%s", text)
+	}
+}
+
+// writeRanks writes a cl100k_base rank file with every single byte and a few merges of "hello".
+func writeRanks(t *testing.T, dir string) {
+	t.Helper()
+	var b strings.Builder
+	for i := 0; i < 256; i++ {
+		b.WriteString(base64.StdEncoding.EncodeToString([]byte{byte(i)}) + " " + strconv.Itoa(i) + "\n")
+	}
+	for i, merge := range []string{"he", "ll", "llo", "hello"} {
+		b.WriteString(base64.StdEncoding.EncodeToString([]byte(merge)) + " " + strconv.Itoa(256+i) + "\n")
+	}
+	if err := os.WriteFile(filepath.Join(dir, model.EncodingCL100k+".tiktoken"), []byte(b.String()), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestCountTokens(t *testing.T) {
+	dir := t.TempDir()
+	writeRanks(t, dir)
+	model.SetTokenRanksDir(dir)
+	defer model.SetTokenRanksDir("")
+
+	// "hello" merges into one token; " world" has no merges and stays six bytes.
+	if n, err := model.CountTokens("hello world", "gpt-4"); err != nil || n != 7 {
+		t.Fatalf("expected 7 tokens, got %d (%v)", n, err)
+	}
+	if n, err := model.CountTokens("", "gpt-4"); err != nil || n != 0 {
+		t.Fatalf("expected no tokens for empty text, got %d (%v)", n, err)
+	}
+	if _, err := model.CountTokens("hello", "gpt-4o"); err == nil || !strings.Contains(err.Error(), model.EncodingO200k) {
+		t.Fatalf("expected missing o200k ranks to fail, got %v", err)
+	}
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	m := sim.NewScriptedModel()
+	m.On("hello", "hello")
+	client := events.WrapModel(m, log, "backend", nil)
+	client.ChatAdvanced(model.ChatRequest{Model: "gpt-4", Input: []model.Message{{Role: "user", Content: "hello"}}})
+	evs, err := log.Read(events.Filter{Types: []events.Type{events.ModelCall}})
+	if err != nil || len(evs) != 1 {
+		t.Fatalf("Read failed: %v (%d events)", err, len(evs))
+	}
+	// Prompt: 3 tokens for the reply, 3 per message, 4 for "user" and 1 for "hello".
+	if d := evs[0].Details; d["counted"] != "true" || d["tokens"] != "11" || d["completionTokens"] != "1" {
+		t.Fatalf("expected counted tokens, got %+v", d)
+	}
+	if report := events.AggregateUsage(evs); report.Total.Unreported != 0 || report.Total.Tokens() != 12 {
+		t.Fatalf("expected counted tokens in the usage, got %+v", report.Total)
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// meteredModel reports fixed token counts for every call, like a provider does.
+type meteredModel struct {
+	*sim.ScriptedModel
+	err error
+}
+
+func (m *meteredModel) ChatAdvanced(req model.ChatRequest) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	if req.Usage != nil {
+		*req.Usage = model.Usage{Model: "gpt-4o", PromptTokens: 100, CompletionTokens: 20}
+	}
+	return m.ScriptedModel.ChatAdvanced(req)
+}
+
+func TestModelUsageIsAttributedToTickets(t *testing.T) {
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	m := &meteredModel{ScriptedModel: sim.NewScriptedModel()}
+	m.On("done", "Which day?")
+	ticket := "card1"
+	client := events.WrapModel(m, log, "backend", func() string { return ticket })
+
+	req := model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Which day?"}}}
+	client.ChatAdvanced(req)
+	client.ChatAdvanced(req)
+	ticket = "card2"
+	m.err = errors.New("timeout")
+	client.ChatAdvanced(req)
+
+	cache, err := model.NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+	cached := events.WrapModel(model.NewCachedClient(m, cache, 0), log, "manager", nil)
+	m.err = nil
+	cached.ChatAdvanced(req)
+	cached.ChatAdvanced(req) // Answered from the cache.
+
+	report, err := events.ReadUsage(log, events.Filter{})
+	if err != nil {
+		t.Fatalf("ReadUsage failed: %v", err)
+	}
+	if report.Total.Calls != 5 || report.Total.Errors != 1 || report.Total.Cached != 1 {
+		t.Fatalf("unexpected totals: %+v", report.Total)
+	}
+	if u := report.ByTicket["card1"]; u.Calls != 2 || u.PromptTokens != 200 || u.CompletionTokens != 40 {
+		t.Fatalf("unexpected usage of card1: %+v", u)
+	}
+	if u := report.ByTicket["card2"]; u.Calls != 1 || u.Errors != 1 {
+		t.Fatalf("unexpected usage of card2: %+v", u)
+	}
+	if u := report.ByAgent["manager"]; u.Calls != 2 || u.Tokens() != 120 {
+		t.Fatalf("expected the cache hit to cost no tokens, got %+v", u)
+	}
+	if u := report.ByModel["gpt-4o"]; u.Calls != 3 {
+		t.Fatalf("expected calls attributed to the answering model, got %+v", report.ByModel)
+	}
+
+	card1, _ := events.ReadUsage(log, events.Filter{TicketID: "card1"})
+	if card1.Total.Calls != 2 {
+		t.Fatalf("expected the ticket filter applied, got %+v", card1.Total)
+	}
+}
+
+func TestChatGPTClientReportsUsage(t *testing.T) {
+	t.Chdir(t.TempDir()) // The client writes a debug log to the working directory.
+	c := chatgpt.NewChatGPTClient("key", "", nil)
+	c.HTTPClient = &http.Client{Transport: statusTransport{
+		status: http.StatusOK,
+		body: `{"model": "gpt-4o-mini-2024-07-18",
+			"output": [{"type": "message", "content": [{"text": "Friday"}]}],
+			"usage": {"input_tokens": 42, "output_tokens": 3}}`,
+	}}
+	var usage model.Usage
+	resp, err := c.ChatAdvanced(model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Which day?"}}, Usage: &usage})
+	if err != nil || resp != "Friday" {
+		t.Fatalf("ChatAdvanced failed: %q, %v", resp, err)
+	}
+	if usage.Model != "gpt-4o-mini-2024-07-18" || usage.PromptTokens != 42 || usage.CompletionTokens != 3 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestMultiBoardScopesTicketsAndColumns(t *testing.T) {
+	api := sim.NewBoard()
+	web := sim.NewBoard("To Do", "In Progress", "Done")
+	multi := board.NewMulti()
+	multi.Add("api", api)
+	multi.Add("web", board.WithColumns(web, map[string]string{
+		board.ListBacklog: "To Do",
+		board.ListDoing:   "In Progress",
+	}))
+
+	apiCard, err := multi.CreateCard("Add endpoint", "", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	webCard, err := multi.CreateCard("Add page", "", "web:"+board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard on web failed: %v", err)
+	}
+	if apiCard.GetID() != "api:card1" || webCard.GetID() != "web:card1" {
+		t.Fatalf("ticket IDs must carry the board: %s, %s", apiCard.GetID(), webCard.GetID())
+	}
+	if names := web.CardNames("To Do"); len(names) != 1 || names[0] != "Add page" {
+		t.Fatalf("web card should land in its mapped column, got %v", names)
+	}
+
+	backlog, err := multi.GetCardsFromList(board.ListBacklog)
+	if err != nil || len(backlog) != 2 {
+		t.Fatalf("expected the backlog of both boards, got %d cards, %v", len(backlog), err)
+	}
+
+	if err := webCard.Move(board.ListDoing); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if names := web.CardNames("In Progress"); len(names) != 1 {
+		t.Fatalf("Doing should map to In Progress, got %v", names)
+	}
+	list, err := webCard.GetList()
+	if err != nil || list.GetName() != board.ListDoing {
+		t.Fatalf("GetList should report the standard name, got %v, %v", list, err)
+	}
+
+	if got := board.ListOn(webCard, board.ListBacklog); got != "web:Backlog" {
+		t.Fatalf("unexpected ListOn: %q", got)
+	}
+	child, err := multi.CreateCard("Style page", "", board.ListOn(webCard, board.ListBacklog))
+	if err != nil || child.GetID() != "web:card2" {
+		t.Fatalf("child ticket should be created on the parent's board, got %v, %v", child, err)
+	}
+	if _, err := multi.CreateCard("Lost", "", "mobile:Backlog"); err == nil {
+		t.Fatalf("expected an error for an unknown board")
+	}
+}
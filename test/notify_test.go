@@ -0,0 +1,184 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/notify/discord"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+)
+
+// recordingNotifier keeps every message posted through it.
+type recordingNotifier struct {
+	mu       sync.Mutex
+	channels []string
+	messages []string
+}
+
+func (n *recordingNotifier) Notify(channel, message string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.channels = append(n.channels, channel)
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func (n *recordingNotifier) posted() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.messages...)
+}
+
+func TestEscalationNotifyStepPostsToChannel(t *testing.T) {
+	b := newFakeBoard(board.ListDoing)
+	card, _ := b.CreateCard("Add a dependency", "", board.ListDoing)
+	n := &recordingNotifier{}
+	a := &agent.BaseAgent{
+		Name:       "backend",
+		Notifier:   n,
+		Escalation: []config.EscalationStep{{Action: agent.EscalateNotify, Channel: "#team"}},
+	}
+
+	if err := a.Escalate(card); err != nil {
+		t.Fatalf("Escalate failed: %v", err)
+	}
+	if len(n.messages) != 1 || n.channels[0] != "#team" {
+		t.Fatalf("expected one message to #team, got %v %v", n.channels, n.messages)
+	}
+	if !strings.Contains(n.messages[0], "Add a dependency") || !strings.Contains(n.messages[0], card.GetURL()) {
+		t.Fatalf("message does not name the card: %q", n.messages[0])
+	}
+
+	a.Notifier = nil
+	if err := a.Escalate(card); err == nil {
+		t.Fatalf("expected an error without a notifier")
+	}
+}
+
+func TestTicketUpdatesFollowSupervisedTickets(t *testing.T) {
+	n := &recordingNotifier{}
+	supervisor := orchestrator.NewSupervisor("alice")
+	tracker := agent.Trackers{supervisor, notify.NewTicketUpdates(n, "")}
+
+	tracker.Track("T1", "backend", "implementing")
+	tracker.Track("T1", "backend", "implementing")
+	tracker.Poll("T1")
+	tracker.Track("T1", "backend", "WaitForReply")
+	if len(supervisor.Snapshot()) != 1 {
+		t.Fatalf("supervisor did not see the ticket: %+v", supervisor.Snapshot())
+	}
+	tracker.Done("T1")
+
+	want := []string{"[T1] backend: implementing", "[T1] backend: WaitForReply", "[T1] done"}
+	if got := n.posted(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected updates %q, got %q", want, got)
+	}
+	if len(supervisor.Snapshot()) != 0 {
+		t.Fatalf("done ticket still supervised: %+v", supervisor.Snapshot())
+	}
+}
+
+func TestFleetReportsAgentErrors(t *testing.T) {
+	fleet := orchestrator.NewFleet()
+	fleet.Add("backend", "Backend", actorFunc(func() error { return errors.New("model unavailable") }))
+	fleet.Add("qa", "QA", actorFunc(func() error { return nil }))
+	var reported []string
+	fleet.OnError = func(name string, err error) {
+		reported = append(reported, name+": "+err.Error())
+	}
+
+	fleet.RunOnce()
+	if len(reported) != 1 || reported[0] != "backend: model unavailable" {
+		t.Fatalf("unexpected error reports: %q", reported)
+	}
+}
+
+// actorFunc adapts a function to orchestrator.Actor.
+type actorFunc func() error
+
+func (f actorFunc) Act() error { return f() }
+
+func TestDiscordListenDispatchesNewCommands(t *testing.T) {
+	var mu sync.Mutex
+	reads := 0
+	replies := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot secret" {
+			t.Errorf("missing bot token: %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/channels/42/messages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method == http.MethodPost {
+			var payload map[string]string
+			json.NewDecoder(r.Body).Decode(&payload)
+			replies <- payload["content"]
+			w.Write([]byte(`{}`))
+			return
+		}
+		mu.Lock()
+		reads++
+		n := reads
+		mu.Unlock()
+		switch {
+		case n == 1:
+			// Existing messages are skipped.
+			w.Write([]byte(`[{"id":"1","content":"!decompose old","author":{"username":"alice"}}]`))
+		case n == 2 && r.URL.Query().Get("after") == "1":
+			// Newest first; bot messages are ignored.
+			w.Write([]byte(`[{"id":"3","content":"!ping now","author":{"username":"alice"}},` +
+				`{"id":"2","content":"!ping bot","author":{"username":"agents","bot":true}}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	bot := discord.NewDiscordClient("secret", "42")
+	bot.BaseURL = server.URL
+	d := notify.NewDispatcher()
+	var handledMu sync.Mutex
+	var handled []notify.Command
+	d.Register("ping", func(cmd notify.Command) (string, error) {
+		handledMu.Lock()
+		handled = append(handled, cmd)
+		handledMu.Unlock()
+		return "pong " + strings.Join(cmd.Args, " "), nil
+	})
+	d.Register("decompose", func(cmd notify.Command) (string, error) {
+		t.Errorf("old command dispatched: %+v", cmd)
+		return "", nil
+	})
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- bot.Listen("", d, time.Millisecond, stop) }()
+
+	select {
+	case reply := <-replies:
+		if reply != "pong now" {
+			t.Fatalf("unexpected reply %q", reply)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no reply posted")
+	}
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	handledMu.Lock()
+	defer handledMu.Unlock()
+	if len(handled) != 1 || handled[0].Author != "alice" || handled[0].Channel != "42" {
+		t.Fatalf("unexpected commands: %+v", handled)
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/openapi"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+const usersHandler = `package api
+
+import "net/http"
+
+// Routes registers the user handlers.
+func Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /users/{id}", getUser)
+	mux.HandleFunc("DELETE /users/{id}", deleteUser)
+}
+
+func getUser(w http.ResponseWriter, r *http.Request)    {}
+func deleteUser(w http.ResponseWriter, r *http.Request) {}
+`
+
+const usersSpec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    parameters:
+      - $ref: '#/components/parameters/UserID'
+    get:
+      operationId: getUser
+      responses:
+        "200":
+          description: The user.
+    delete:
+      operationId: deleteUser
+      responses:
+        "204":
+          description: Deleted.
+components:
+  parameters:
+    UserID:
+      name: userId
+      in: path
+      required: true
+      schema:
+        type: string
+`
+
+func TestOpenAPIFindRoutes(t *testing.T) {
+	src := usersHandler + `
+func gin(r *gin.Engine) { r.POST("/orders/:id/items/", addItem) }
+func files() { http.Handle("/static/{path...}", nil) }
+`
+	want := []openapi.Route{
+		{Method: "GET", Path: "/users/{id}"},
+		{Method: "DELETE", Path: "/users/{id}"},
+		{Path: "/static/{path}"},
+		{Method: "POST", Path: "/orders/{id}/items"},
+	}
+	if got := openapi.FindRoutes(src); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestOpenAPIValidate(t *testing.T) {
+	if err := openapi.Validate([]byte(usersSpec)); err != nil {
+		t.Fatalf("expected a valid spec, got %v", err)
+	}
+	broken := strings.NewReplacer("openapi: 3.0.3", "openapi: 2.0", "#/components/parameters/UserID", "#/components/parameters/Missing",
+		"operationId: deleteUser", "operationId: getUser").Replace(usersSpec)
+	err := openapi.Validate([]byte(broken))
+	for _, want := range []string{"3.x version", `operationId "getUser" is used by`, `GET /users/{userId} does not declare path parameter "userId"`,
+		"reference #/components/parameters/Missing does not resolve"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q reported, got %v", want, err)
+		}
+	}
+	missing, err := openapi.Missing([]byte(usersSpec), []openapi.Route{{Method: "GET", Path: "/users/{id}"}, {Method: "PUT", Path: "/users/{id}"}, {Path: "/health"}})
+	if err != nil || !reflect.DeepEqual(missing, []openapi.Route{{Method: "PUT", Path: "/users/{id}"}, {Path: "/health"}}) {
+		t.Fatalf("unexpected missing routes %v, %v", missing, err)
+	}
+}
+
+func TestAPISpecAgentSyncsSpecOnTicketBranch(t *testing.T) {
+	m := sim.NewScriptedModel()
+	incomplete := strings.Split(usersSpec, "    delete:")[0] + strings.SplitN(usersSpec, "Deleted.\n", 2)[1]
+	if rule, err := m.OnMode("SyncAPISpec", agent.APISpecUpdate{Spec: incomplete}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	} else {
+		rule.Times = 1
+	}
+	if _, err := m.OnMode("SyncAPISpec", agent.APISpecUpdate{Spec: usersSpec, Summary: "Documented the user endpoints."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	parent, _ := s.Board.CreateCard("User admin", "Manage users.", board.ListBacklog)
+	card, _ := s.Board.CreateCard("Add user endpoints", board.WithParentLink("Serve users.", parent), board.ListDone)
+	other, _ := s.Board.CreateCard("Fix typo", board.WithParentLink("Fix the README.", parent), board.ListDone)
+	for _, c := range []struct {
+		card       board.Card
+		path, text string
+	}{{card, "api/users.go", usersHandler}, {other, "README.md", "# Users\n"}} {
+		repo, err := s.Repo.WorktreeFor(c.card.GetID())
+		if err != nil {
+			t.Fatalf("WorktreeFor failed: %v", err)
+		}
+		if err := repo.WriteFile(c.path, []byte(c.text)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := repo.CommitChanges("feat: "+c.card.GetName(), "dev", "dev@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	a, err := s.Agent(agent.RoleAPISpec, agent.RoleAPISpec)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.(*agent.APISpecAgent).BaseBranch = "master"
+
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	calls := m.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected the incomplete spec to be redone once, got %d calls", len(calls))
+	}
+	if feedback := requestContent(calls[1].Input[len(calls[1].Input)-1].Content); !strings.Contains(feedback, "route DELETE /users/{id} is not documented") {
+		t.Fatalf("expected the missing route fed back, got:\n%s", feedback)
+	}
+	if spec, err := s.RepoFile(card.GetID(), openapi.DefaultPath); err != nil || spec != usersSpec {
+		t.Fatalf("expected the spec committed on the ticket branch, got %q, %v", spec, err)
+	}
+	if comment := lastComment(t, card.(*sim.Card)); comment != "[api spec] Updated api/openapi.yaml: Documented the user endpoints." {
+		t.Fatalf("unexpected comment %q", comment)
+	}
+	if comments, _ := other.ReadComments(); len(comments) != 0 {
+		t.Fatalf("expected tickets without handler changes left alone, got %v", comments)
+	}
+
+	if err := a.Act(); err != nil || len(m.Calls()) != 2 {
+		t.Fatalf("expected the synced ticket skipped, got %d calls, %v", len(m.Calls()), err)
+	}
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/policy"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestPolicyGuardsRepository(t *testing.T) {
+	g := newTempGitClient(t)
+	if err := g.WriteFile("old.txt", []byte("legacy\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := g.CommitChanges("Initial commit", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	log := events.NewJSONLLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	engine := policy.New(policy.Rules{
+		ProtectedPaths:    []string{".github/**"},
+		MaxFilesPerCommit: 2,
+		Forbidden:         []policy.Operation{policy.OpPush},
+		ApproveDeletions:  true,
+	})
+	engine.Events = log
+	g.Guard = engine
+
+	if err := g.WriteFile(".github/workflows/ci.yml", []byte("on: push\n")); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a violation writing a protected path, got %v", err)
+	}
+
+	for _, f := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := g.WriteFile(f, []byte(f)); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := g.CommitChanges("Too big", "dev", "dev@example.com"); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a violation for too many files, got %v", err)
+	}
+	os.Remove(filepath.Join(g.RepoPath, "c.txt"))
+	if err := g.CommitChanges("Two files", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("CommitChanges within the limit failed: %v", err)
+	}
+
+	os.Remove(filepath.Join(g.RepoPath, "old.txt"))
+	if err := g.CommitChanges("Remove legacy", "dev", "dev@example.com"); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected deletions to need approval, got %v", err)
+	}
+	engine.ApproveDeletion("old.txt")
+	if err := g.CommitChanges("Remove legacy", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("approved deletion failed: %v", err)
+	}
+
+	if err := g.PushChanges("", ""); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected push to be forbidden, got %v", err)
+	}
+
+	violations, err := log.Read(events.Filter{Types: []events.Type{policy.PolicyViolation}})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(violations) != 4 {
+		t.Fatalf("expected 4 recorded violations, got %d: %+v", len(violations), violations)
+	}
+}
+
+func TestPolicyGuardsBoard(t *testing.T) {
+	engine := policy.New(policy.Rules{Forbidden: []policy.Operation{policy.OpMoveCard}})
+	b := policy.WrapBoard(sim.NewBoard(), engine, "dev")
+	card, err := b.CreateCard("Fix bug", "", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	if err := card.Move(board.ListDone); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected moving cards to be forbidden, got %v", err)
+	}
+	if err := card.WriteComment("Looking into it"); err != nil {
+		t.Fatalf("comments are allowed, got %v", err)
+	}
+}
+
+func TestPolicyDefaultsNeedApproval(t *testing.T) {
+	g := newTempGitClient(t)
+	engine := policy.New(policy.Rules{ProtectedPaths: policy.DefaultProtectedPaths, ApprovalPaths: policy.DefaultApprovalPaths})
+	g.Guard = engine
+
+	if err := g.WriteFile("LICENSE", []byte("MIT\n")); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected the license to be protected, got %v", err)
+	}
+	patch := "--- /dev/null\n+++ b/.github/workflows/ci.yml\n@@ -0,0 +1 @@\n+on: push\n"
+	if _, err := g.ApplyPatch(patch); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected workflows to be protected, got %v", err)
+	}
+	if err := g.WriteFile("go.mod", []byte("module example.com/demo\n")); err != nil {
+		t.Fatalf("expected go.mod to be writable, got %v", err)
+	}
+	err := g.CommitChanges("build: add module", "dev", "dev@example.com")
+	var v *policy.ViolationError
+	if !errors.As(err, &v) || v.Rule != policy.RuleApproval || v.Target != "go.mod" {
+		t.Fatalf("expected committing go.mod to need approval, got %v", err)
+	}
+
+	d := notify.NewDispatcher()
+	engine.RegisterCommands(d)
+	cmd, _ := notify.ParseCommand("!approve go.mod")
+	if reply := d.Dispatch(cmd); !strings.Contains(reply, "go.mod") {
+		t.Fatalf("unexpected reply: %s", reply)
+	}
+	if err := g.CommitChanges("build: add module", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("expected the approved commit to succeed, got %v", err)
+	}
+}
+
+func TestBackendReportsBlockedChanges(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "db/schema.sql", Content: "DROP TABLE users;\n"}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Repo.Guard = policy.New(policy.Rules{ApprovalPaths: []string{"db/**"}})
+	card, _ := s.Board.CreateCard("Reset users", "Drop the users table.", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if err := a.(*agent.BackendAgent).ImplementTicket(card); !errors.Is(err, policy.ErrViolation) {
+		t.Fatalf("expected a policy violation, got %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) == 0 || !strings.Contains(comments[len(comments)-1].Text, "!approve db/schema.sql") {
+		t.Fatalf("expected the blocked change on the card, got %v", comments)
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestPriorityScore(t *testing.T) {
+	if got := (agent.PriorityScore{Value: 9, Effort: 2, Risk: 1}).Score(); got != 3 {
+		t.Fatalf("expected 3, got %v", got)
+	}
+	// Out-of-range dimensions are clamped to the 1–10 scale.
+	if got := (agent.PriorityScore{Value: 20, Effort: 0, Risk: -3}).Score(); got != 5 {
+		t.Fatalf("expected 5, got %v", got)
+	}
+}
+
+func TestPrioritizeBacklog(t *testing.T) {
+	m := sim.NewScriptedModel()
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Board.Fields = []string{board.FieldScore}
+	docs, _ := s.Board.CreateCard("Write docs", "", board.ListBacklog)
+	login, _ := s.Board.CreateCard("Fix login", "", board.ListBacklog)
+	theme, _ := s.Board.CreateCard("Dark theme", "", board.ListBacklog)
+	s.Board.CreateCard("Ship release", "", board.ListDoing)
+	if _, err := m.OnMode("PrioritizeBacklog", agent.BacklogPriorities{Scores: []agent.PriorityScore{
+		{ID: docs.GetID(), Value: 3, Effort: 2, Risk: 1},
+		{ID: login.GetID(), Value: 9, Effort: 2, Risk: 1},
+		{ID: "unknown", Value: 10, Effort: 1, Risk: 1},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	if _, err := a.(*agent.EngineeringManagerAgent).PrioritizeBacklog(); err != nil {
+		t.Fatalf("PrioritizeBacklog failed: %v", err)
+	}
+	cards, _ := s.Board.GetCardsFromList(board.ListBacklog)
+	want := []string{"Fix login", "Write docs", "Dark theme"}
+	for i, c := range cards {
+		if c.GetName() != want[i] {
+			t.Fatalf("position %d: expected %q, got %q", i, want[i], c.GetName())
+		}
+	}
+	if score, ok := board.CardScore(login); !ok || score != 3 || board.Field(login, board.FieldScore) != "3" {
+		t.Fatalf("expected the score field to hold 3, got %v", score)
+	}
+	if _, ok := board.CardScore(theme); ok {
+		t.Fatal("expected the skipped ticket to stay unscored")
+	}
+}
+
+func TestScoreFallsBackToDescription(t *testing.T) {
+	b := sim.NewBoard()
+	c, _ := b.CreateCard("Fix login", "Users cannot sign in.", board.ListBacklog)
+	if err := board.SetScore(c, 1.5); err != nil {
+		t.Fatalf("SetScore failed: %v", err)
+	}
+	board.SetScore(c, 2.25)
+	if c.GetDescription() != "Users cannot sign in.\n\nPriority score: 2.25" {
+		t.Fatalf("unexpected description: %q", c.GetDescription())
+	}
+	if score, ok := board.CardScore(c); !ok || score != 2.25 {
+		t.Fatalf("expected 2.25, got %v", score)
+	}
+}
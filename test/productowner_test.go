@@ -0,0 +1,83 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// replyWhenAsked answers on the card, as member, once its last comment starts with prefix.
+func replyWhenAsked(card *sim.Card, prefix, member, answer string) {
+	go func() {
+		for i := 0; i < 5000; i++ {
+			comments, _ := card.ReadComments()
+			if len(comments) > 0 && strings.HasPrefix(comments[len(comments)-1].Text, prefix) {
+				card.Reply(member, answer)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+}
+
+func TestProductOwnerActInterviewsAndDraftsTickets(t *testing.T) {
+	m := sim.NewScriptedModel()
+	questions, err := m.OnMode("DraftTickets", agent.TicketDraft{Questions: []string{"Who signs in?"}})
+	if err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	questions.Times = 1
+	if _, err := m.OnMode("DraftTickets", agent.TicketDraft{Tickets: []agent.HighLevelTicket{{
+		Title:              "Customer sign-in",
+		Description:        "Customers sign in with email and password.",
+		AcceptanceCriteria: []string{"Wrong passwords are rejected"},
+	}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	idea, _ := s.Board.CreateCard("Sign-in", "Let people sign in.", board.ListInbox)
+	a, err := s.Agent(agent.RoleProductOwner, "po")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	a.(*agent.ProductOwnerAgent).ReplyInterval = time.Millisecond
+	replyWhenAsked(idea.(*sim.Card), "Before I write the tickets", "alice", "Only customers, with their email.")
+
+	for round := 0; round < 2; round++ {
+		if err := a.Act(); err != nil {
+			t.Fatalf("Act failed: %v", err)
+		}
+	}
+	if n := modeCalls(m, "DraftTickets"); n != 2 {
+		t.Fatalf("expected one question round and one draft, got %d requests", n)
+	}
+	if last := m.Calls()[len(m.Calls())-1]; !strings.Contains(requestContent(last.Input[1].Content), "Only customers, with their email.") {
+		t.Fatal("the answer did not reach the draft request")
+	}
+
+	backlog := s.Board.CardNames(board.ListBacklog)
+	if len(backlog) != 1 || backlog[0] != "Customer sign-in" {
+		t.Fatalf("unexpected backlog %v", backlog)
+	}
+	ticket := s.Board.Card("Customer sign-in")
+	if !strings.Contains(ticket.GetDescription(), "- [ ] Wrong passwords are rejected") {
+		t.Fatalf("acceptance criteria missing from %q", ticket.GetDescription())
+	}
+	if members, _ := ticket.GetAssignedMembers(); len(members) != 1 || members[0].Name != agent.RoleEngineeringManager {
+		t.Fatalf("expected the ticket assigned to the Engineering Manager, got %+v", members)
+	}
+	if list, _ := idea.GetList(); list.GetName() != board.ListDone {
+		t.Fatalf("expected the idea in Done, got %s", list.GetName())
+	}
+	if text := lastComment(t, idea.(*sim.Card)); !strings.HasPrefix(text, "Drafted 1 ticket(s):") || !strings.Contains(text, ticket.GetURL()) {
+		t.Fatalf("unexpected summary comment %q", text)
+	}
+}
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/vcs"
+	"github.com/egobogo/aiagents/internal/vcs/github"
+)
+
+// fakeReview adds review threads to fakeCI and records replies and resolutions.
+type fakeReview struct {
+	*fakeCI
+	threads  []vcs.ReviewThread
+	replies  map[string]string
+	resolved []string
+}
+
+func (f *fakeReview) ReadReviewThreads(int) ([]vcs.ReviewThread, error) { return f.threads, nil }
+
+func (f *fakeReview) ReplyToThread(_ int, threadID, body string) error {
+	f.replies[threadID] = body
+	return nil
+}
+
+func (f *fakeReview) ResolveThread(_ int, threadID string) error {
+	f.resolved = append(f.resolved, threadID)
+	return nil
+}
+
+func TestBackendAddressesReviewThreads(t *testing.T) {
+	ci := &fakeCI{statuses: []vcs.PipelineStatus{vcs.PipelineSuccess}}
+	m, s, card, err := implementWithCI(t, ci, 0)
+	if err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if _, err := m.OnMode("AddressReview", agent.ReviewReply{
+		Files: []agent.GeneratedFile{{Path: "hello.txt", Content: "Hello!\n"}},
+		Reply: "Capitalized the greeting.",
+	}, "Capitalize"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("AddressReview", agent.ReviewReply{Reply: "The ticket asks for a text file."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	review := &fakeReview{
+		fakeCI:  ci,
+		replies: make(map[string]string),
+		threads: []vcs.ReviewThread{
+			{ID: "T1", Path: "hello.txt", Line: 1, Comments: []vcs.ReviewComment{{Author: "alice", Body: "Capitalize the greeting."}}},
+			{ID: "T2", Comments: []vcs.ReviewComment{{Author: "bob", Body: "Why a text file?"}}},
+			{ID: "T3", Resolved: true, Comments: []vcs.ReviewComment{{Author: "alice", Body: "Typo."}}},
+		},
+	}
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.CI = &agent.CIGate{Provider: review}
+
+	n, err := backend.AddressReviewThreads(card)
+	if err != nil || n != 2 {
+		t.Fatalf("expected two threads addressed, got %d (%v)", n, err)
+	}
+	if content, _ := s.RepoFile(card.GetID(), "hello.txt"); content != "Hello!\n" {
+		t.Fatalf("expected the change pushed on the ticket branch, got %q", content)
+	}
+	if review.replies["T1"] != "Capitalized the greeting." || review.replies["T2"] != "The ticket asks for a text file." {
+		t.Fatalf("unexpected replies: %v", review.replies)
+	}
+	if strings.Join(review.resolved, ",") != "T1,T2" {
+		t.Fatalf("expected the open threads resolved, got %v", review.resolved)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "Capitalize the greeting") {
+			prompt = text
+		}
+	}
+	if !strings.Contains(prompt, "at hello.txt:1") || !strings.Contains(prompt, "File: hello.txt") {
+		t.Fatalf("expected the thread's file in the prompt, got:\n%s", prompt)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListReview {
+		t.Fatalf("expected the ticket to stay in Review, got %s", list.GetName())
+	}
+}
+
+func TestGitHubReviewThreads(t *testing.T) {
+	var mutations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.HasPrefix(req.Query, "mutation") {
+			mutations = append(mutations, fmt.Sprintf("%v", req.Variables["thread"]))
+			fmt.Fprint(w, `{"data": {}}`)
+			return
+		}
+		if req.Variables["owner"] != "acme" || req.Variables["number"] != float64(4) {
+			fmt.Fprint(w, `{"errors": [{"message": "not found"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"repository": {"pullRequest": {"reviewThreads": {"nodes": [
+			{"id": "T1", "isResolved": false, "path": "main.go", "line": 3, "comments": {"nodes": [
+				{"databaseId": 11, "body": "Rename this.", "author": {"login": "alice", "__typename": "User"}},
+				{"databaseId": 12, "body": "Coverage dropped.", "author": {"login": "codecov", "__typename": "Bot"}}
+			]}}
+		]}}}}}`)
+	}))
+	defer server.Close()
+	gh, err := github.NewGitHubClient("secret", "acme/shop", server.URL)
+	if err != nil {
+		t.Fatalf("NewGitHubClient failed: %v", err)
+	}
+	threads, err := gh.ReadReviewThreads(4)
+	if err != nil {
+		t.Fatalf("ReadReviewThreads failed: %v", err)
+	}
+	if len(threads) != 1 || threads[0].Path != "main.go" || len(threads[0].Comments) != 1 || threads[0].Comments[0].Author != "alice" {
+		t.Fatalf("unexpected threads: %+v", threads)
+	}
+	if err := gh.ReplyToThread(4, "T1", "Renamed."); err != nil {
+		t.Fatalf("ReplyToThread failed: %v", err)
+	}
+	if err := gh.ResolveThread(4, "T1"); err != nil {
+		t.Fatalf("ResolveThread failed: %v", err)
+	}
+	if strings.Join(mutations, ",") != "T1,T1" {
+		t.Fatalf("expected a reply and a resolution, got %v", mutations)
+	}
+	if _, err := gh.ReadReviewThreads(5); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected the GraphQL error, got %v", err)
+	}
+}
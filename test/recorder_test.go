@@ -0,0 +1,65 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/recorder"
+)
+
+func TestRecorderRecordThenReplay(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"n":`+string(rune('0'+calls))+`}`)
+	}))
+	defer srv.Close()
+
+	cassette := filepath.Join(t.TempDir(), "fixtures", "cards.json")
+	rec, err := recorder.New(recorder.ModeRecord, cassette)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	client := rec.Client()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL + "/1/cards?key=secret&token=secret")
+		if err != nil {
+			t.Fatalf("record request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(cassette)
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("cassette leaks credentials:\n%s", data)
+	}
+
+	srv.Close()
+	replay, err := recorder.New(recorder.ModeReplay, cassette)
+	if err != nil {
+		t.Fatalf("New replay failed: %v", err)
+	}
+	client = replay.Client()
+	for _, want := range []string{`{"n":1}`, `{"n":2}`} {
+		resp, err := client.Get(srv.URL + "/1/cards?token=other&key=other")
+		if err != nil {
+			t.Fatalf("replay request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != want {
+			t.Fatalf("replayed %q, want %q", body, want)
+		}
+	}
+	if _, err := client.Get(srv.URL + "/1/cards"); err == nil {
+		t.Fatalf("expected an error once recordings are exhausted")
+	}
+}
@@ -0,0 +1,160 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/vcs/github"
+)
+
+func TestReleaseAgent(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("ReleaseNotes", agent.ReleaseNotes{Changelog: "### Added\n- Greeting endpoint", Notes: "Say hello."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	if err := s.Repo.CreateTag("v0.1.0", "Release v0.1.0", "sim", "sim@aiagents.local"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+	commit := func(file, message string) {
+		t.Helper()
+		if err := s.Repo.WriteFile(file, []byte(message+"\n")); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := s.Repo.CommitChanges(message, "dev", "dev@example.com"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	commit("hello.go", "feat: add greeting endpoint")
+	s.Board.CreateCard("Greeting endpoint", "", board.ListDone)
+	a, err := s.Agent(agent.RoleRelease, "release")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	rel := a.(*agent.ReleaseAgent)
+
+	card, err := rel.Release("v0.2.0")
+	if err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListRelease || card.GetName() != "Release v0.2.0" || card.GetDescription() != "Say hello." {
+		t.Fatalf("unexpected release card %q in %s: %q", card.GetName(), list.GetName(), card.GetDescription())
+	}
+	prompt := requestContent(m.Calls()[0].Input[len(m.Calls()[0].Input)-1].Content)
+	if !strings.Contains(prompt, "feat: add greeting endpoint") || !strings.Contains(prompt, "Greeting endpoint (") || strings.Contains(prompt, "initial commit") {
+		t.Fatalf("expected only the work since v0.1.0 in the prompt, got:\n%s", prompt)
+	}
+	tags, _ := s.Repo.Tags()
+	if len(tags) != 2 || tags[1].Name != "v0.2.0" {
+		t.Fatalf("expected v0.2.0 to be tagged, got %+v", tags)
+	}
+	if err := rel.Act(); err != nil || len(m.Calls()) != 1 {
+		t.Fatalf("expected a released tag to be skipped, got %d call(s), %v", len(m.Calls()), err)
+	}
+	if _, err := rel.Release("v0.2.0"); err == nil {
+		t.Fatal("expected a second release of v0.2.0 to fail")
+	}
+
+	// A tag created by hand gets its release on the next run.
+	commit("bye.go", "fix: say goodbye")
+	if err := s.Repo.CreateTag("v0.3.0", "", "dev", "dev@example.com"); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+	if err := rel.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	prompt = requestContent(m.Calls()[1].Input[len(m.Calls()[1].Input)-1].Content)
+	if !strings.Contains(prompt, "fix: say goodbye") || strings.Contains(prompt, "feat: add greeting") || strings.Contains(prompt, "docs(release)") {
+		t.Fatalf("expected only the work since v0.2.0 in the prompt, got:\n%s", prompt)
+	}
+	changelog, _ := s.Repo.ReadText("CHANGELOG.md")
+	if !strings.HasPrefix(changelog, "# Changelog\n\n## v0.3.0 - ") || !strings.Contains(changelog, "\n## v0.2.0 - ") || strings.Count(changelog, "- Greeting endpoint") != 2 {
+		t.Fatalf("unexpected changelog:\n%s", changelog)
+	}
+}
+
+func TestPublishRelease(t *testing.T) {
+	var created map[string]string
+	uploads := make(map[string]string)
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("POST /repos/acme/shop/releases", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		fmt.Fprintf(w, `{"tag_name": %q, "name": %q, "body": %q, "html_url": "https://github.example/releases/1", "upload_url": "%s/uploads/1/assets{?name,label}"}`,
+			created["tag_name"], created["name"], created["body"], server.URL)
+	})
+	mux.HandleFunc("POST /uploads/1/assets", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		name := r.URL.Query().Get("name")
+		uploads[name] = r.Header.Get("Content-Type") + ":" + string(data)
+		fmt.Fprintf(w, `{"browser_download_url": "https://github.example/download/%s"}`, name)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+	gh, err := github.NewGitHubClient("secret", "acme/shop", server.URL)
+	if err != nil {
+		t.Fatalf("NewGitHubClient failed: %v", err)
+	}
+
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("ReleaseNotes", agent.ReleaseNotes{Changelog: "### Added\n- Everything", Notes: "First release."}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	remoteDir := t.TempDir()
+	remote, err := git.PlainInit(remoteDir, true)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	if _, err := s.Repo.Repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{remoteDir}}); err != nil {
+		t.Fatalf("CreateRemote failed: %v", err)
+	}
+	if err := s.Repo.WriteFile("dist/app.txt", []byte("binary")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	a, err := s.Agent(agent.RoleRelease, "release")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	rel := a.(*agent.ReleaseAgent)
+	rel.Publisher = gh
+	rel.Assets = []string{"dist/app.txt"}
+
+	card, err := rel.Release("v1.0.0")
+	if err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if created["tag_name"] != "v1.0.0" || created["body"] != "First release." {
+		t.Fatalf("unexpected release request: %v", created)
+	}
+	if uploads["app.txt"] != "text/plain; charset=utf-8:binary" {
+		t.Fatalf("unexpected uploads: %v", uploads)
+	}
+	if _, err := remote.Reference(plumbing.NewTagReferenceName("v1.0.0"), true); err != nil {
+		t.Fatalf("expected the tag on the remote: %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if last := comments[len(comments)-1].Text; last != "Published: https://github.example/releases/1" {
+		t.Fatalf("expected the release linked on the card, got %q", last)
+	}
+}
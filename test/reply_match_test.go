@@ -0,0 +1,60 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestReplyMatchers(t *testing.T) {
+	now := time.Now()
+	alice := &board.Member{ID: "m1", Name: "Alice", Username: "alice"}
+	approval := board.Comment{Text: "LGTM, ship it", Member: alice, Created: now}
+	chatter := board.Comment{Text: "@backend how is it going?", Member: &board.Member{ID: "m2", Name: "Bob"}, Created: now.Add(-time.Hour)}
+
+	cases := []struct {
+		name  string
+		match agent.ReplyMatcher
+		want  [2]bool // approval, chatter
+	}{
+		{"tags", agent.Tagged("lgtm", "/approve"), [2]bool{true, false}},
+		{"regexp", agent.Matching(regexp.MustCompile(`^@backend\b`)), [2]bool{false, true}},
+		{"newer", agent.NewerThan(now.Add(-time.Minute)), [2]bool{true, false}},
+		{"member", agent.FromMember("alice"), [2]bool{true, false}},
+		{"all", agent.AllOf(agent.FromMember("m2"), agent.Tagged("@backend")), [2]bool{false, true}},
+		{"any", agent.AnyOf(agent.FromMember("m1"), agent.Tagged("@backend")), [2]bool{true, true}},
+	}
+	for _, c := range cases {
+		if got := [2]bool{c.match(approval), c.match(chatter)}; got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestWaitForReplyMatchingSkipsOtherComments(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Deploy", "", board.ListDoing)
+	card := c.(*sim.Card)
+	a := &agent.BaseAgent{Name: "devops", ReplyInterval: time.Millisecond}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		card.Reply("bob", "what is the status?")
+		card.Reply("alice", "/deploy staging")
+	}()
+	reply, err := a.WaitForReplyMatching(card, agent.Tagged("/deploy"))
+	if err != nil {
+		t.Fatalf("WaitForReplyMatching failed: %v", err)
+	}
+	if reply.Text != "/deploy staging" {
+		t.Fatalf("expected the command, got %q", reply.Text)
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestAskIgnoresUntrustedReplies(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Add a dependency", "", board.ListDoing)
+	card := c.(*sim.Card)
+	a := &agent.BaseAgent{Name: "backend", ReplyInterval: time.Millisecond, TrustedMembers: []string{"alice"}}
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			if comments, _ := card.ReadComments(); len(comments) > 0 {
+				card.Reply("mallory", "approve")
+				card.Reply("alice", "reject: not needed")
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	answer, err := a.Ask(card, "May I add example.com/words?")
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if answer != "reject: not needed" {
+		t.Fatalf("expected the trusted member's answer, got %q", answer)
+	}
+}
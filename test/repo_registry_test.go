@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestRepoForResolvesTicketHint(t *testing.T) {
+	backend, frontend := newTempGitClient(t), newTempGitClient(t)
+	repos := gitrepo.NewRegistry()
+	repos.Add("backend", backend, true)
+	repos.Add("frontend", frontend, false)
+	a := &agent.BaseAgent{Name: "dev", Repos: repos}
+
+	b := sim.NewBoard()
+	byLabel, _ := b.CreateCard("[repo:frontend] Add login page", "", board.ListBacklog)
+	byLine, _ := b.CreateCard("Add login form", board.WithRepo("Render the form.", "Frontend"), board.ListBacklog)
+	noHint, _ := b.CreateCard("Add login endpoint", "", board.ListBacklog)
+	unknown, _ := b.CreateCard("Add app screen", "Repo: mobile", board.ListBacklog)
+
+	for _, tc := range []struct {
+		card board.Card
+		want *gitrepo.GitClient
+	}{
+		{byLabel, frontend},
+		{byLine, frontend},
+		{noHint, backend},
+	} {
+		got, err := a.RepoFor(tc.card)
+		if err != nil {
+			t.Fatalf("RepoFor(%q) failed: %v", tc.card.GetName(), err)
+		}
+		if got != tc.want {
+			t.Fatalf("RepoFor(%q) picked %s, want %s", tc.card.GetName(), got.RepoPath, tc.want.RepoPath)
+		}
+	}
+	if _, err := a.RepoFor(unknown); err == nil {
+		t.Fatalf("expected an error for an unknown repository")
+	}
+
+	single := &agent.BaseAgent{Name: "dev", GitClient: backend}
+	if got, err := single.RepoFor(byLabel); err != nil || got != backend {
+		t.Fatalf("without a registry GitClient must be used, got %v, %v", got, err)
+	}
+}
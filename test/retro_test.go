@@ -0,0 +1,73 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestRetroActReportsDoneTicketsOncePerCadence(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Retrospective", agent.Retrospective{
+		Summary:     "One ticket went back to Doing after review.",
+		Problems:    []string{"Review found a missing test."},
+		Suggestions: []string{"Write the tests before asking for review."},
+	}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	// The ticket goes through Doing and Review twice.
+	card, _ := s.Board.CreateCard("Add login", "", board.ListDoing)
+	for _, list := range []string{board.ListReview, board.ListDoing, board.ListReview, board.ListDone} {
+		card.Move(list)
+	}
+	card.WriteComment("Please add a test for the wrong password.")
+	a, err := s.Agent(agent.RoleRetro, agent.RoleRetro)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+
+	for round := 0; round < 2; round++ {
+		if err := a.Act(); err != nil {
+			t.Fatalf("Act failed: %v", err)
+		}
+	}
+	if n := modeCalls(m, "Retrospective"); n != 1 {
+		t.Fatalf("expected one retrospective within the cadence, got %d", n)
+	}
+	prompt := ""
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[0].Content), "Mode: Retrospective\n") {
+			prompt = requestContent(c.Input[1].Content)
+		}
+	}
+	if !strings.Contains(prompt, "Rework loops: 2") || !strings.Contains(prompt, "Please add a test for the wrong password.") {
+		t.Fatalf("the ticket's history is missing from the prompt:\n%s", prompt)
+	}
+	retros := s.Board.CardNames(board.ListRetro)
+	if len(retros) != 1 || !strings.HasPrefix(retros[0], "Retrospective ") {
+		t.Fatalf("unexpected Retro list %v", retros)
+	}
+	report := s.Board.Card(retros[0]).GetDescription()
+	if !strings.Contains(report, "Tickets reviewed: 1") || !strings.Contains(report, "Suggestions:\n- Write the tests before asking for review.") {
+		t.Fatalf("unexpected report:\n%s", report)
+	}
+
+	// Once the cadence has passed, tickets already covered are not reported again.
+	retro := a.(*agent.RetroAgent)
+	retro.Cadence = time.Nanosecond
+	if err := a.Act(); err != nil {
+		t.Fatalf("Act failed: %v", err)
+	}
+	if n := modeCalls(m, "Retrospective"); n != 1 || len(s.Board.CardNames(board.ListRetro)) != 1 {
+		t.Fatalf("expected no retrospective without new done tickets, got %d", n)
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+)
+
+func TestParentRollupCompletesParent(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListDoing, board.ListDone, board.ListBlocked)
+	parent, _ := b.CreateCard("Greet users", "", board.ListBacklog)
+	first, _ := b.CreateCard("Add greeting file", board.WithParentLink("Write hello.txt.", parent), board.ListDone)
+	second, _ := b.CreateCard("Serve greeting", board.WithParentLink("Serve hello.txt.", parent), board.ListDoing)
+	rule := orchestrator.NewParentRollupRule()
+
+	if err := rule.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := parent.GetList(); list.GetName() != board.ListBacklog {
+		t.Fatalf("parent moved before all children are done: %s", list.GetName())
+	}
+
+	second.Move(board.ListDone)
+	if err := rule.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if list, _ := parent.GetList(); list.GetName() != board.ListDone {
+		t.Fatalf("expected the parent in Done, got %s", list.GetName())
+	}
+	comments, _ := parent.ReadComments()
+	if len(comments) != 2 || !strings.Contains(comments[0].Text, "- "+first.GetName()) {
+		t.Fatalf("expected a summary naming the children, got %+v", comments)
+	}
+	if !strings.HasPrefix(comments[1].Text, "[transition] "+board.ListBacklog+" → "+board.ListDone) {
+		t.Fatalf("expected the move to be recorded, got %q", comments[1].Text)
+	}
+}
+
+func TestParentRollupFlagsFailedChildOnce(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListDone, board.ListBlocked)
+	parent, _ := b.CreateCard("Greet users", "", board.ListBacklog)
+	b.CreateCard("Add greeting file", board.WithParentLink("Write hello.txt.", parent), board.ListDone)
+	b.CreateCard("Serve greeting", board.WithParentLink("Serve hello.txt.", parent), board.ListBlocked)
+	rule := orchestrator.NewParentRollupRule()
+
+	for i := 0; i < 2; i++ {
+		if err := rule.Apply(b); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+	}
+	comments, _ := parent.ReadComments()
+	if len(comments) != 1 || !strings.Contains(comments[0].Text, "1 technical ticket(s) failed:\n- Serve greeting") {
+		t.Fatalf("expected one attention comment, got %+v", comments)
+	}
+	if list, _ := parent.GetList(); list.GetName() != board.ListBacklog {
+		t.Fatalf("flagged parent moved to %s", list.GetName())
+	}
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/scheduler"
+)
+
+func TestCronNext(t *testing.T) {
+	// Wednesday, 10 January 2024, 10:07:30.
+	from := time.Date(2024, time.January, 10, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * sat,sun", time.Date(2024, 1, 13, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 feb *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)}, // Day of month or weekday.
+		{"5-20/5 10 * * *", time.Date(2024, 1, 10, 10, 10, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from.Add(90 * time.Second)},
+	}
+	for _, c := range cases {
+		s, err := scheduler.Parse(c.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", c.spec, err)
+			continue
+		}
+		if got := s.Next(from); !got.Equal(c.want) {
+			t.Errorf("%q: expected %s, got %s", c.spec, c.want, got)
+		}
+	}
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * * * funday", "10-5 * * * *", "*/0 * * * *", "@every soon"} {
+		if _, err := scheduler.Parse(spec); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+	if next := mustParse(t, "0 0 30 2 *").Next(from); !next.IsZero() {
+		t.Errorf("expected a date that never comes to yield no run, got %s", next)
+	}
+}
+
+func mustParse(t *testing.T, spec string) scheduler.Schedule {
+	t.Helper()
+	s, err := scheduler.Parse(spec)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", spec, err)
+	}
+	return s
+}
+
+func TestSchedulerRunsJobsUntilStopped(t *testing.T) {
+	var ticks, immediate atomic.Int32
+	jobs := scheduler.New()
+	jobs.Every(5*time.Millisecond, "tick", func() error {
+		ticks.Add(1)
+		return nil
+	})
+	jobs.AddJob(scheduler.Job{Name: "once", Schedule: scheduler.Every(time.Hour), Immediate: true, Run: func() error {
+		immediate.Add(1)
+		return nil
+	}})
+	if err := jobs.Add("broken", "every minute", func() error { return nil }); err == nil {
+		t.Fatal("expected an invalid schedule to be rejected")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		jobs.Start(stop)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Start to return once stopped")
+	}
+	if ticks.Load() < 2 {
+		t.Fatalf("expected the interval job to run repeatedly, ran %d times", ticks.Load())
+	}
+	if immediate.Load() != 1 {
+		t.Fatalf("expected the immediate job to run once, ran %d times", immediate.Load())
+	}
+}
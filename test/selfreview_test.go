@@ -0,0 +1,93 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// decomposeWithReview decomposes a ticket with rounds of self-review whose checks answer check and
+// whose revisions answer revision.
+func decomposeWithReview(t *testing.T, rounds int, check agent.DraftCheck, revision interface{}) (*sim.ScriptedModel, []board.Card) {
+	t.Helper()
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "Serve /health.", StoryPoints: 3},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode(agent.ModeClassify, check); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if revision != nil {
+		if _, err := m.OnMode("SelfReview", revision); err != nil {
+			t.Fatalf("OnMode failed: %v", err)
+		}
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	em.SelfReview = rounds
+	created, err := em.HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	return m, created
+}
+
+func requestContent(content interface{}) string {
+	s, _ := content.(string)
+	return s
+}
+
+func TestSelfReviewRevisesTickets(t *testing.T) {
+	check := agent.DraftCheck{Issues: []string{"Nobody updates the load balancer probe."}}
+	revised := agent.SelfReview[[]agent.TechnicalTicket]{
+		Revised: []agent.TechnicalTicket{
+			{Title: "Add endpoint", Description: "Serve /health returning 200.", StoryPoints: 3},
+			{Title: "Point the probe at /health", Description: "Update the load balancer.", StoryPoints: 1},
+		},
+	}
+	m, created := decomposeWithReview(t, 2, check, revised)
+	if len(created) != 2 || created[1].GetName() != "Point the probe at /health" {
+		t.Fatalf("expected the revised tickets, got %d", len(created))
+	}
+	if checks, revisions := modeCalls(m, agent.ModeClassify), modeCalls(m, "SelfReview"); checks != 2 || revisions != 2 {
+		t.Fatalf("expected two checks and revisions, got %d and %d", checks, revisions)
+	}
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[1].Content); strings.Contains(text, "Revise the draft") && !strings.Contains(text, "load balancer probe") {
+			t.Fatalf("revision does not name the issues found:\n%s", text)
+		}
+	}
+}
+
+func TestSelfReviewKeepsDraft(t *testing.T) {
+	// A check without issues ends the loop before any revision.
+	m, created := decomposeWithReview(t, 3, agent.DraftCheck{}, nil)
+	if len(created) != 1 || modeCalls(m, agent.ModeClassify) != 1 || modeCalls(m, "SelfReview") != 0 {
+		t.Fatalf("expected the draft after one check, got %d ticket(s) after %d check(s)", len(created), modeCalls(m, agent.ModeClassify))
+	}
+	// An empty revision is ignored.
+	_, created = decomposeWithReview(t, 1, agent.DraftCheck{Issues: []string{"Too vague."}}, agent.SelfReview[[]agent.TechnicalTicket]{})
+	if len(created) != 1 || created[0].GetName() != "Add endpoint" {
+		t.Fatalf("expected the original draft, got %d ticket(s)", len(created))
+	}
+	// Disabled by default.
+	m, _ = decomposeWithReview(t, 0, agent.DraftCheck{}, nil)
+	if modeCalls(m, agent.ModeClassify) != 0 {
+		t.Fatal("expected no review without SelfReview")
+	}
+}
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/intake/sentry"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+const sentryFrames = `[
+	{"filename": "runtime/panic.go", "lineno": 770, "function": "panic", "in_app": false},
+	{"filename": "/app/shop/api.go", "lineno": 12, "function": "Handle", "in_app": true},
+	{"filename": "/app/shop/cart.go", "lineno": 42, "function": "Total", "in_app": true}
+]`
+
+func TestSentryWebhookFilesBugTickets(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{"shop/cart.go": "package shop\n", "shop/api.go": "package shop\n"})
+	in := sentry.NewIntake(s.Board)
+	in.Repo = s.Repo
+	handler := sentry.WebhookHandler("s3cret", in)
+	body := `{"action": "triggered", "data": {"event": {"issue_id": "1", "title": "index out of range", "culprit": "shop.Total", "level": "error",
+		"web_url": "https://sentry.example/issues/1/", "exception": {"values": [{"type": "runtime.Error", "value": "index out of range [3]",
+		"stacktrace": {"frames": ` + sentryFrames + `}}]}}}}`
+	deliver := func(signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/sentry", strings.NewReader(body))
+		req.Header.Set("Sentry-Hook-Resource", "event_alert")
+		req.Header.Set("Sentry-Hook-Signature", signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := deliver("00"); code != http.StatusUnauthorized {
+		t.Fatalf("expected a bad signature to be rejected, got %d", code)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	for i := 0; i < 2; i++ {
+		if code := deliver(signature); code != http.StatusNoContent {
+			t.Fatalf("expected the delivery to be accepted, got %d", code)
+		}
+	}
+	cards, _ := s.Board.GetCardsFromList(board.ListBugs)
+	if len(cards) != 1 {
+		t.Fatalf("expected one bug ticket for repeated deliveries, got %d", len(cards))
+	}
+	card := cards[0]
+	desc := card.GetDescription()
+	if card.GetName() != "[bug] index out of range" || !board.HasLabel(card, "bug") {
+		t.Fatalf("unexpected ticket name %q", card.GetName())
+	}
+	if !strings.Contains(desc, "runtime.Error: index out of range [3]\n  at Total (/app/shop/cart.go:42)\n  at Handle (/app/shop/api.go:12)") {
+		t.Fatalf("expected the stack trace innermost first, got:\n%s", desc)
+	}
+	if !strings.Contains(desc, "Suspected files:\n- shop/cart.go\n- shop/api.go\n") || strings.Contains(desc, "- runtime/panic.go") {
+		t.Fatalf("expected the in-app repository files as suspects, got:\n%s", desc)
+	}
+	if id, ok := sentry.IssueID(card); !ok || id != "1" {
+		t.Fatalf("expected the issue ID on the ticket, got %q", id)
+	}
+}
+
+func TestSentrySync(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/0/projects/acme/shop/issues/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Query().Get("query") != "is:unresolved" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"id": "2", "shortId": "SHOP-2", "title": "nil map write", "culprit": "shop.Add", "level": "fatal", "count": "17", "permalink": "https://sentry.example/issues/2/"}]`)
+	})
+	mux.HandleFunc("GET /api/0/issues/2/events/latest/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"entries": [{"type": "exception", "data": {"values": [{"type": "panic", "value": "assignment to entry in nil map", "stacktrace": {"frames": %s}}]}}]}`, sentryFrames)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	b := sim.NewBoard()
+	in := sentry.NewIntake(b)
+	in.Client = sentry.NewClient("token", "acme", "shop", server.URL)
+	for want := 1; want >= 0; want-- {
+		created, err := in.Sync()
+		if err != nil || created != want {
+			t.Fatalf("expected %d ticket(s) created, got %d (%v)", want, created, err)
+		}
+	}
+	cards, _ := b.GetCardsFromList(board.ListBugs)
+	desc := cards[0].GetDescription()
+	if !strings.Contains(desc, "Events: 17") || !strings.Contains(desc, "panic: assignment to entry in nil map") || !strings.Contains(desc, "- /app/shop/cart.go") {
+		t.Fatalf("unexpected ticket:\n%s", desc)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestSimulatedIdeaToDone(t *testing.T) {
+	m := sim.NewScriptedModel()
+	rules := []struct {
+		mode     string
+		response interface{}
+	}{
+		{"DraftTickets", agent.TicketDraft{Tickets: []agent.HighLevelTicket{{Title: "Greeting", Description: "Say hello."}}}},
+		{"Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{{Title: "Add greeting file", Description: "Write hello.txt.", StoryPoints: 1}}}},
+		{"Implement", agent.Implementation{Files: []agent.GeneratedFile{{Path: "hello.txt", Content: "hello\n"}}, Summary: "Added hello.txt."}},
+	}
+	for _, r := range rules {
+		if _, err := m.OnMode(r.mode, r.response); err != nil {
+			t.Fatalf("OnMode failed: %v", err)
+		}
+	}
+
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := s.Board.CreateCard("Greet users", "Users should be greeted.", board.ListInbox); err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	if _, err := s.AddAgent(agent.RoleProductOwner, "po"); err != nil {
+		t.Fatalf("AddAgent failed: %v", err)
+	}
+	if _, err := s.AddAgent(agent.RoleEngineeringManager, agent.RoleEngineeringManager); err != nil {
+		t.Fatalf("AddAgent failed: %v", err)
+	}
+	s.MoveList(board.ListBacklog, board.ListSprint)
+	s.AssignList(board.ListSprint, "backend")
+	if _, err := s.AddAgent(agent.RoleBackend, "backend"); err != nil {
+		t.Fatalf("AddAgent failed: %v", err)
+	}
+	s.AddQA("backend")
+
+	if err := s.Run(1); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	done := s.Board.CardNames(board.ListDone)
+	if len(done) != 2 || done[0] != "Greet users" || done[1] != "Add greeting file" {
+		t.Fatalf("unexpected Done list: %v", done)
+	}
+	card := s.Board.Card("Add greeting file")
+	content, err := s.RepoFile(card.GetID(), "hello.txt")
+	if err != nil || content != "hello\n" {
+		t.Fatalf("unexpected hello.txt on ticket branch: %q, %v", content, err)
+	}
+}
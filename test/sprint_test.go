@@ -0,0 +1,83 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestPlanSprintFillsCapacityInPriorityOrder(t *testing.T) {
+	m := sim.NewScriptedModel()
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	s.Board.Fields = []string{board.FieldPriority}
+	search, _ := s.Board.CreateCard("Add search", "", board.ListBacklog)
+	login, _ := s.Board.CreateCard("Fix login", "", board.ListBacklog)
+	typo, _ := s.Board.CreateCard("Fix typo", "", board.ListBacklog)
+	s.Board.CreateCard("Dark theme", "", board.ListBacklog)
+	board.SetEstimate(search, 3)
+	board.SetEstimate(login, 3)
+	board.SetField(login, board.FieldPriority, "high")
+	if _, err := m.OnMode("PlanSprint", agent.SprintPlan{Summary: "Stabilize login first.", Items: []agent.SprintItem{
+		{ID: login.GetID(), Rationale: "users are locked out"},
+		{ID: search.GetID(), Rationale: "most requested"},
+		{ID: "unknown", Rationale: "not on the board"},
+		{ID: typo.GetID(), Rationale: "quick win"},
+		{ID: login.GetID(), Rationale: "listed twice"},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	selected, err := a.(*agent.EngineeringManagerAgent).PlanSprint(5)
+	if err != nil {
+		t.Fatalf("PlanSprint failed: %v", err)
+	}
+	var names []string
+	for _, c := range selected {
+		names = append(names, c.GetName())
+	}
+	if got := strings.Join(names, ","); got != "Fix login,Fix typo" {
+		t.Fatalf("expected the tickets that fit in priority order, got %s", got)
+	}
+	if got := strings.Join(s.Board.CardNames(board.ListBacklog), ","); got != "Add search,Dark theme" {
+		t.Fatalf("expected the rest to stay in the backlog, got %s", got)
+	}
+
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "Plan the next sprint") {
+			prompt = text
+		}
+	}
+	for _, want := range []string{"capacity of 5 story points", "points=3 priority=high title=Fix login", "points=1 title=Fix typo"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("expected %q in the prompt:\n%s", want, prompt)
+		}
+	}
+
+	var plan *sim.Card
+	for _, name := range s.Board.CardNames(board.ListSprint) {
+		if strings.HasPrefix(name, "Sprint plan ") {
+			plan = s.Board.Card(name)
+		}
+	}
+	if plan == nil {
+		t.Fatal("expected a sprint plan card in the sprint list")
+	}
+	text := lastComment(t, plan)
+	for _, want := range []string{"Stabilize login first.", "Planned 4 of 5 story points", "1. Fix login (3) — users are locked out", "2. Fix typo (1) — quick win"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in the plan:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+// standupFunc adapts a function to orchestrator.StandupReporter.
+type standupFunc func(since time.Time) (string, error)
+
+func (f standupFunc) Standup(since time.Time) (string, error) { return f(since) }
+
+func TestStandupSummarizesOwnActivity(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.On("Shipped login, search is next; waiting on the provider.", "Write your standup update")
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	for _, c := range []struct{ path, message, author string }{
+		{"login.go", "feat: add login", "backend"},
+		{"login_test.go", "test: cover login", "qa"},
+	} {
+		if err := s.Repo.WriteFile(c.path, []byte("package main\n")); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := s.Repo.CommitChanges(c.message, c.author, c.author+"@aiagents.local"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+	}
+	login, _ := s.Board.CreateCard("Add login", "", board.ListDoing)
+	login.AssignTo("backend")
+	login.WriteComment("Which OAuth provider should we use?")
+	search, _ := s.Board.CreateCard("Add search", "", board.ListBacklog)
+	search.AssignTo("backend")
+	other, _ := s.Board.CreateCard("Regression suite", "", board.ListDoing)
+	other.AssignTo("qa")
+
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	coordinator := orchestrator.NewStandupCoordinator(s.Board, map[string]orchestrator.StandupReporter{
+		"backend": a.(orchestrator.StandupReporter),
+		"qa":      standupFunc(func(time.Time) (string, error) { return "", errors.New("model unavailable") }),
+	})
+	n := &recordingNotifier{}
+	coordinator.Notifier, coordinator.Channel = n, "#standup"
+	if err := coordinator.Publish(); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "Write your standup update") {
+			prompt = text
+		}
+	}
+	for _, want := range []string{"- feat: add login\n", "- Add login [Doing]\n", "- Add search [Backlog]\n", "Open questions on: Add login\n"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("expected %q in the prompt:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "cover login") || strings.Contains(prompt, "Regression suite") {
+		t.Fatalf("expected only the agent's own work in the prompt:\n%s", prompt)
+	}
+
+	card := s.Board.Card("Daily standup")
+	if card == nil {
+		t.Fatal("expected the standup card to be created")
+	}
+	if list, _ := card.GetList(); list.GetName() != board.ListSprint {
+		t.Fatalf("expected the standup card in %s, got %s", board.ListSprint, list.GetName())
+	}
+	report := lastComment(t, card)
+	for _, want := range []string{"### backend\nShipped login, search is next", "### qa\n(no update: model unavailable)"} {
+		if !strings.Contains(report, want) {
+			t.Fatalf("expected %q in the report:\n%s", want, report)
+		}
+	}
+	if strings.Index(report, "### backend") > strings.Index(report, "### qa") {
+		t.Fatalf("expected agents in name order:\n%s", report)
+	}
+	if posted := n.posted(); len(posted) != 1 || posted[0] != report || n.channels[0] != "#standup" {
+		t.Fatalf("expected the report in #standup, got %v %q", n.channels, posted)
+	}
+
+	// The next report goes on the same card.
+	if err := coordinator.Publish(); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if names := strings.Join(s.Board.CardNames(board.ListSprint), ","); names != "Daily standup" {
+		t.Fatalf("expected a single standup card, got %s", names)
+	}
+	if comments, _ := card.ReadComments(); len(comments) != 2 {
+		t.Fatalf("expected two reports on the card, got %d", len(comments))
+	}
+}
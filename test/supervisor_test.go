@@ -0,0 +1,93 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/orchestrator"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestSupervisorEscalatesStuckTicket(t *testing.T) {
+	b := newFakeBoard(board.ListBacklog, board.ListBlocked)
+	card, _ := b.CreateCard("Implement login", "", board.ListBacklog)
+
+	s := orchestrator.NewSupervisor("alice")
+	s.MaxPolls = 3
+	s.Actions = []orchestrator.SupervisorAction{orchestrator.ActionNudge, orchestrator.ActionAlert}
+
+	s.Track(card.GetID(), "Backend", "WaitForReply")
+	s.Poll(card.GetID())
+	if len(s.Stuck()) != 0 {
+		t.Fatalf("ticket should not be stuck yet")
+	}
+	s.Poll(card.GetID())
+	s.Poll(card.GetID())
+
+	if err := s.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) != 1 || !strings.HasPrefix(comments[0].Text, "Reminder:") {
+		t.Fatalf("expected a nudge, got %+v", comments)
+	}
+
+	// Escalating resets the poll counter; the next stuck interval triggers the alert.
+	for i := 0; i < 3; i++ {
+		s.Poll(card.GetID())
+	}
+	if err := s.Apply(b); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	list, _ := card.GetList()
+	if list.GetName() != board.ListBlocked {
+		t.Fatalf("expected card in %s, got %s", board.ListBlocked, list.GetName())
+	}
+
+	s.Done(card.GetID())
+	if len(s.Stuck()) != 0 {
+		t.Fatalf("done tickets must not be reported")
+	}
+}
+
+func TestSupervisorLeavesAnsweredTicketAlone(t *testing.T) {
+	s, err := sim.New(sim.NewScriptedModel())
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Add a dependency", "", board.ListDoing)
+	card := c.(*sim.Card)
+
+	supervisor := orchestrator.NewSupervisor("alice")
+	supervisor.StuckAfter = 0 // Every supervised ticket counts as stuck.
+	supervisor.Actions = []orchestrator.SupervisorAction{orchestrator.ActionAlert}
+	a := &agent.BaseAgent{Name: "backend", ReplyInterval: time.Millisecond, Tracker: supervisor}
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			if comments, _ := card.ReadComments(); len(comments) > 0 {
+				card.Reply("alice", "yes")
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	if _, err := a.Ask(card, "May I add example.com/words?"); err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+
+	if err := supervisor.Apply(s.Board); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	list, _ := card.GetList()
+	if list.GetName() != board.ListDoing {
+		t.Fatalf("answered ticket moved to %s", list.GetName())
+	}
+	if len(supervisor.Snapshot()) != 0 {
+		t.Fatalf("answered ticket still supervised: %+v", supervisor.Snapshot())
+	}
+}
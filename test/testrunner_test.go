@@ -0,0 +1,136 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/testrunner"
+)
+
+const testJSON = `{"Action":"run","Package":"example.com/calc","Test":"TestAdd"}
+{"Action":"output","Package":"example.com/calc","Test":"TestAdd","Output":"=== RUN   TestAdd\n"}
+{"Action":"pass","Package":"example.com/calc","Test":"TestAdd"}
+{"Action":"output","Package":"example.com/calc","Test":"TestSub/negative","Output":"    calc_test.go:14: Sub(1, 2) = 3, want -1\n"}
+{"Action":"fail","Package":"example.com/calc","Test":"TestSub/negative"}
+{"Action":"fail","Package":"example.com/calc","Test":"TestSub"}
+{"Action":"output","Package":"example.com/calc","Output":"FAIL\n"}
+{"Action":"fail","Package":"example.com/calc"}
+{"ImportPath":"example.com/calc/parse [example.com/calc/parse.test]","Action":"build-output","Output":"parse/parse.go:7:2: undefined: strconv\n"}
+{"ImportPath":"example.com/calc/parse [example.com/calc/parse.test]","Action":"build-fail"}
+{"Action":"output","Package":"example.com/calc/parse","Output":"FAIL\texample.com/calc/parse [build failed]\n"}
+{"Action":"fail","Package":"example.com/calc/parse","FailedBuild":"example.com/calc/parse [example.com/calc/parse.test]"}
+`
+
+func TestParseTestOutput(t *testing.T) {
+	report, err := testrunner.Parse(strings.NewReader(testJSON))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if report.Passed != 1 || len(report.Failures) != 2 {
+		t.Fatalf("expected one pass and two failures, got %d and %+v", report.Passed, report.Failures)
+	}
+	sub, build := report.Failures[0], report.Failures[1]
+	if sub.Name() != "example.com/calc.TestSub" || !strings.Contains(sub.Output, "want -1") {
+		t.Fatalf("unexpected test failure: %+v", sub)
+	}
+	if build.Test != "" || !strings.Contains(build.Output, "undefined: strconv") {
+		t.Fatalf("unexpected build failure: %+v", build)
+	}
+	if got := strings.Join(report.FailedPackages(), ","); got != "example.com/calc,example.com/calc/parse" {
+		t.Fatalf("unexpected failed packages: %s", got)
+	}
+}
+
+// writeModule writes files into dir, creating directories as needed.
+func writeModule(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+}
+
+func TestRelevantFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeModule(t, dir, map[string]string{
+		"go.mod":              "module example.com/calc\n\ngo 1.24\n",
+		"calc.go":             "package calc\n",
+		"calc_test.go":        "package calc\n\nfunc TestSub(t *testing.T) {}\n",
+		"util.go":             "package calc\n",
+		"parse/parse.go":      "package parse\n",
+		"parse/lexer.go":      "package parse\n",
+		"parse/lexer_test.go": "package parse\n",
+	})
+	report, _ := testrunner.Parse(strings.NewReader(testJSON))
+	files, err := testrunner.RelevantFiles(dir, report.Failures)
+	if err != nil {
+		t.Fatalf("RelevantFiles failed: %v", err)
+	}
+	if got := strings.Join(files, ","); got != "calc.go,calc_test.go,parse/parse.go" {
+		t.Fatalf("unexpected files: %s", got)
+	}
+}
+
+func TestCommitWorkRepairsFailingTests(t *testing.T) {
+	m := sim.NewScriptedModel()
+	fixed := "package calc\n\nfunc Sub(a, b int) int { return a - b }\n"
+	if _, err := m.OnMode("RepairTests", map[string]interface{}{"result": []agent.GeneratedFile{{Path: "calc.go", Content: fixed}}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	writeModule(t, s.Repo.RepoPath, map[string]string{
+		"go.mod":              "module example.com/calc\n\ngo 1.24\n",
+		"calc.go":             "package calc\n\nfunc Sub(a, b int) int { return b - a }\n",
+		"calc_test.go":        "package calc\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tif got := Sub(3, 1); got != 2 {\n\t\tt.Fatalf(\"Sub(3, 1) = %d, want 2\", got)\n\t}\n}\n",
+		"other/other.go":      "package other\n\nfunc One() int { return 1 }\n",
+		"other/other_test.go": "package other\n\nimport \"testing\"\n\nfunc TestOne(t *testing.T) {\n\tif One() != 1 {\n\t\tt.Fatal(\"want 1\")\n\t}\n}\n",
+	})
+	card, _ := s.Board.CreateCard("Subtract", "", board.ListDoing)
+	a, err := s.Agent(agent.RoleBackend, "backend")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	backend := a.(*agent.BackendAgent)
+	backend.TestRepairs = 2
+	if err := backend.CommitWork(s.Repo, card, "feat: add subtraction", "backend", "backend@aiagents.local"); err != nil {
+		t.Fatalf("CommitWork failed: %v", err)
+	}
+	if content, _ := s.RepoFile("", "calc.go"); content != fixed {
+		t.Fatalf("expected the fix to be committed, got %q", content)
+	}
+	var prompt string
+	for _, c := range m.Calls() {
+		if text := requestContent(c.Input[len(c.Input)-1].Content); strings.Contains(text, "The tests fail") {
+			prompt = text
+		}
+	}
+	if !strings.Contains(prompt, "Sub(3, 1) = -2, want 2") || !strings.Contains(prompt, "File: calc_test.go") || strings.Contains(prompt, "other.go") {
+		t.Fatalf("expected only the failure and its files in the prompt, got:\n%s", prompt)
+	}
+
+	// A fix that does not help is escalated once the rounds are used up.
+	writeModule(t, s.Repo.RepoPath, map[string]string{"calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestSub(t *testing.T) {\n\tt.Fatal(\"always\")\n}\n"})
+	err = backend.CommitWork(s.Repo, card, "test: tighten subtraction", "backend", "backend@aiagents.local")
+	if !errors.Is(err, agent.ErrTestsFailed) {
+		t.Fatalf("expected ErrTestsFailed, got %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) == 0 || !strings.Contains(comments[len(comments)-1].Text, "still failing after 2 repair attempt(s)") {
+		t.Fatalf("expected an escalation on the card, got %v", comments)
+	}
+}
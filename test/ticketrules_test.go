@@ -0,0 +1,118 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+var strictRules = config.TicketRules{
+	MinTickets:       1,
+	MaxTickets:       3,
+	MinTitleLength:   5,
+	MaxTitleLength:   40,
+	RequiredSections: []string{"Goal", "Acceptance Criteria"},
+	Attempts:         2,
+}
+
+const goodDescription = "## Goal\nServe /health.\n\n**Acceptance Criteria:**\n- Returns 200."
+
+func TestCheckTickets(t *testing.T) {
+	good := []agent.TechnicalTicket{{Title: "Add health endpoint", Description: goodDescription}}
+	if problems := agent.CheckTickets(good, strictRules); problems != nil {
+		t.Fatalf("expected good tickets to pass, got %v", problems)
+	}
+	bad := []agent.TechnicalTicket{
+		{Title: "Fix", Description: "Goal: tidy up."},
+		{Title: "Add health endpoint", Description: goodDescription},
+		{Title: "add health endpoint", Description: goodDescription},
+		{Title: "An overly long title that keeps going and going", Description: goodDescription},
+	}
+	problems := agent.CheckTickets(bad, strictRules)
+	for _, want := range []string{"at most 3 tickets", "shorter than 5", "lacks a \"Acceptance Criteria\"", "used more than once", "longer than 40"} {
+		if !strings.Contains(strings.Join(problems, "\n"), want) {
+			t.Errorf("expected a problem containing %q in %v", want, problems)
+		}
+	}
+	if problems := agent.CheckTickets([]agent.TechnicalTicket{{Title: " "}}, config.TicketRules{}); len(problems) != 1 {
+		t.Fatalf("expected an empty title to fail even without rules, got %v", problems)
+	}
+}
+
+func decomposeWithRules(t *testing.T, m *sim.ScriptedModel) (*sim.Scenario, []board.Card, error) {
+	t.Helper()
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	rules := strictRules
+	em.Rules = &rules
+	created, err := em.HandleTicket(parent)
+	return s, created, err
+}
+
+func TestDecomposeRegeneratesRejectedTickets(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add health endpoint", Description: goodDescription, StoryPoints: 2},
+	}}, "were rejected"); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Health", Description: "Do it."},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	_, created, err := decomposeWithRules(t, m)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	if len(created) != 1 || created[0].GetName() != "Add health endpoint" {
+		t.Fatalf("expected the regenerated ticket, got %d card(s)", len(created))
+	}
+	var feedback bool
+	for _, c := range m.Calls() {
+		feedback = feedback || strings.Contains(requestContent(c.Input[len(c.Input)-1].Content), "lacks a \"Goal\" section")
+	}
+	if !feedback {
+		t.Fatal("expected the problems to be sent as feedback")
+	}
+}
+
+func TestDecomposeGivesUpWithoutCreatingCards(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Health", Description: "Do it."},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, created, err := decomposeWithRules(t, m)
+	if !errors.Is(err, agent.ErrTicketRules) {
+		t.Fatalf("expected ErrTicketRules, got %v", err)
+	}
+	cards, _ := s.Board.GetCardsFromList(board.ListBacklog)
+	if len(created) != 0 || len(cards) != 1 {
+		t.Fatalf("expected no new cards, got %d created and %d in the backlog", len(created), len(cards))
+	}
+	attempts := 0
+	for _, c := range m.Calls() {
+		if strings.Contains(requestContent(c.Input[0].Content), "Mode: Decompose") {
+			attempts++
+		}
+	}
+	if attempts != strictRules.Attempts {
+		t.Fatalf("expected %d attempts, got %d", strictRules.Attempts, attempts)
+	}
+}
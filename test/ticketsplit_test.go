@@ -0,0 +1,95 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func decomposeWithSplits(t *testing.T, rules config.TicketRules) []agent.TechnicalTicket {
+	t.Helper()
+	m := sim.NewScriptedModel()
+	responses := []struct {
+		match   string
+		tickets []agent.TechnicalTicket
+	}{
+		{"Ticket: Build handlers", []agent.TechnicalTicket{
+			{Title: "Add routes", StoryPoints: 5},
+			{Title: "Add validation", StoryPoints: 3, DependsOn: []string{"Add routes"}},
+		}},
+		{"Ticket: Build API\n", []agent.TechnicalTicket{
+			{Title: "Build handlers", StoryPoints: 8},
+			{Title: "Write schema", StoryPoints: 3},
+		}},
+		{"Ticket: Health checks", []agent.TechnicalTicket{
+			{Title: "Build API", StoryPoints: 13, Component: "api"},
+			{Title: "Add page", StoryPoints: 2, DependsOn: []string{"Build API"}},
+		}},
+	}
+	for _, r := range responses {
+		if _, err := m.OnMode("Decompose", map[string]interface{}{"result": r.tickets}, r.match); err != nil {
+			t.Fatalf("OnMode failed: %v", err)
+		}
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	em.Rules = &rules
+	created, err := em.HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	var tickets []agent.TechnicalTicket
+	for _, c := range created {
+		points, _ := board.CardEstimate(c)
+		tickets = append(tickets, agent.TechnicalTicket{Title: c.GetName(), StoryPoints: points, Description: c.GetDescription()})
+	}
+	return tickets
+}
+
+func ticketTitles(tickets []agent.TechnicalTicket) string {
+	var titles []string
+	for _, t := range tickets {
+		titles = append(titles, t.Title)
+	}
+	return strings.Join(titles, ", ")
+}
+
+func TestDecomposeSplitsOversizedTickets(t *testing.T) {
+	tickets := decomposeWithSplits(t, config.TicketRules{MaxStoryPoints: 5})
+	if got := ticketTitles(tickets); got != "Add routes, Add validation, Write schema, Add page" {
+		t.Fatalf("unexpected tickets: %s", got)
+	}
+	for _, ticket := range tickets {
+		if ticket.StoryPoints > 5 {
+			t.Fatalf("%q is still estimated at %v points", ticket.Title, ticket.StoryPoints)
+		}
+	}
+	// The page now waits for every part of the API.
+	if deps := board.Dependencies(tickets[3].Description); len(deps) != 3 {
+		t.Fatalf("expected the page to depend on all three parts, got %v", deps)
+	}
+}
+
+func TestDecomposeSplitDepth(t *testing.T) {
+	tickets := decomposeWithSplits(t, config.TicketRules{MaxStoryPoints: 5, SplitDepth: 1})
+	if got := ticketTitles(tickets); got != "Build handlers, Write schema, Add page" {
+		t.Fatalf("expected one level of splitting, got %s", got)
+	}
+	tickets = decomposeWithSplits(t, config.TicketRules{})
+	if got := ticketTitles(tickets); got != "Build API, Add page" {
+		t.Fatalf("expected no splitting without a limit, got %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+var teamTemplate = config.TicketTemplate{
+	Sections: []string{"Goal", "Spec", "Acceptance Criteria"},
+	Footer:   "Sized at {{.Ticket.StoryPoints}} points. Generated by {{.Agent}} from {{.Parent.Name}}.",
+}
+
+func TestRenderTicketOrdersSections(t *testing.T) {
+	data := agent.TicketData{Agent: "manager", Ticket: agent.TechnicalTicket{
+		StoryPoints: 3,
+		Description: "Context first.\n\n**Acceptance Criteria:**\n- Returns 200.\n\nGoal: Serve /health.",
+	}}
+	data.Parent.Name = "Health checks"
+	body, err := agent.RenderTicket(teamTemplate, data)
+	if err != nil {
+		t.Fatalf("RenderTicket failed: %v", err)
+	}
+	want := "## Goal\nServe /health.\n\n## Spec\n_Not specified._\n\n## Acceptance Criteria\n- Returns 200.\n\nContext first.\n\n" +
+		"Sized at 3 points. Generated by manager from Health checks."
+	if body != want {
+		t.Fatalf("unexpected body:\n%s\nwant:\n%s", body, want)
+	}
+	if _, err := agent.RenderTicket(config.TicketTemplate{Footer: "{{.Missing}}"}, data); err == nil {
+		t.Fatal("expected an unknown footer field to fail")
+	}
+}
+
+func TestEngineeringManagerUsesTicketTemplate(t *testing.T) {
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("Decompose", map[string]interface{}{"result": []agent.TechnicalTicket{
+		{Title: "Add endpoint", Description: "## Spec\nGET /health.\n## Goal\nServe health.", StoryPoints: 2},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	parent, _ := s.Board.CreateCard("Health checks", "Expose health.", board.ListBacklog)
+	a, err := s.Agent(agent.RoleEngineeringManager, agent.RoleEngineeringManager)
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	em := a.(*agent.EngineeringManagerAgent)
+	tmpl := teamTemplate
+	em.Template = &tmpl
+	created, err := em.HandleTicket(parent)
+	if err != nil {
+		t.Fatalf("HandleTicket failed: %v", err)
+	}
+	desc := created[0].GetDescription()
+	if !strings.HasPrefix(desc, "## Goal\nServe health.\n\n## Spec\nGET /health.") || !strings.Contains(desc, "Generated by EngineeringManager") {
+		t.Fatalf("unexpected description:\n%s", desc)
+	}
+	if url, ok := board.ParentURL(desc); !ok || url != parent.GetURL() {
+		t.Fatalf("expected the parent link to survive, got %q", url)
+	}
+	var prompted bool
+	for _, c := range m.Calls() {
+		prompted = prompted || strings.Contains(requestContent(c.Input[len(c.Input)-1].Content), "Goal, Spec, Acceptance Criteria")
+	}
+	if !prompted {
+		t.Fatal("expected the template sections in the decomposition prompt")
+	}
+}
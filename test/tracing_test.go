@@ -0,0 +1,51 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/tracing"
+)
+
+func TestTracerNestsSpansAndExportsOTLP(t *testing.T) {
+	var body map[string]interface{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &body)
+	}))
+	defer collector.Close()
+
+	tracer := tracing.New("test", tracing.NewOTLPExporter(collector.URL, "test"))
+	root := tracer.Start("manager", "HandleTicket", tracing.AttrTicketID, "card1")
+	child := tracer.Start("manager", "Chat")
+	child.Finish(errors.New("rate limited"))
+	if child.ParentID != root.SpanID || child.TraceID != root.TraceID {
+		t.Fatalf("child span not nested under root: %+v", child)
+	}
+	if child.Attributes[tracing.AttrTicketID] != "card1" {
+		t.Fatalf("child did not inherit the ticket ID: %v", child.Attributes)
+	}
+	if body != nil {
+		t.Fatalf("spans exported before the root span finished")
+	}
+	root.Finish(nil)
+
+	spans := body["resourceSpans"].([]interface{})[0].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(spans))
+	}
+	chat := spans[0].(map[string]interface{})
+	if chat["name"] != "Chat" || chat["status"].(map[string]interface{})["code"].(float64) != 2 {
+		t.Fatalf("unexpected exported span: %v", chat)
+	}
+
+	var disabled *tracing.Tracer
+	disabled.Start("x", "noop").Finish(nil)
+}
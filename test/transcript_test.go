@@ -0,0 +1,64 @@
+package test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/events"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+	"github.com/egobogo/aiagents/internal/transcript"
+)
+
+func TestTranscriptExport(t *testing.T) {
+	dir := t.TempDir()
+	auditLog := events.NewJSONLLog(filepath.Join(dir, "events.jsonl"))
+	exchangeLog := events.NewJSONLLog(filepath.Join(dir, "transcripts.jsonl"))
+
+	b := sim.NewBoard()
+	card, err := events.WrapBoard(b, auditLog, "backend").CreateCard("Add greeting", "Say hello.", board.ListBacklog)
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	b.Card("Add greeting").Reply("alice", "Use a friendly tone.")
+
+	scripted := sim.NewScriptedModel()
+	scripted.On("Hello there!", "greeting")
+	m := transcript.WrapModel(scripted, exchangeLog, "backend", func() string { return card.GetID() })
+	if _, err := m.ChatAdvanced(model.ChatRequest{Input: []model.Message{{Role: "user", Content: "Write a greeting"}}}); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	// Activity on other tickets stays out of the transcript.
+	auditLog.Record(events.Event{Agent: "backend", TicketID: "other", Type: events.CommentPosted})
+
+	x := &transcript.Exporter{Sources: []transcript.EventSource{auditLog, exchangeLog}, Board: b}
+	tr, err := x.Export(card.GetID())
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(tr.Events) != 2 || tr.Events[0].Type != events.CardCreated || tr.Events[1].Type != transcript.ModelExchange {
+		t.Fatalf("unexpected events: %+v", tr.Events)
+	}
+
+	md := tr.Markdown()
+	for _, want := range []string{"Add greeting (" + card.GetID() + ")", "Write a greeting", "Hello there!", "**alice:**", "Use a friendly tone."} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("markdown transcript is missing %q:\n%s", want, md)
+		}
+	}
+
+	data, err := tr.Render(transcript.FormatJSON)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	var decoded transcript.Transcript
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.TicketID != card.GetID() || len(decoded.Comments) != 1 {
+		t.Fatalf("unexpected JSON transcript: %+v, %v", decoded, err)
+	}
+	if _, err := tr.Render("pdf"); err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+}
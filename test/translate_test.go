@@ -0,0 +1,116 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestTranslatedBoard(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.On("Add payments", "into English", "Zahlungen hinzufügen")
+	m.On("Customers pay by card.", "into English", "Kunden zahlen mit Karte.")
+	m.On("Welchen Zahlungsanbieter sollen wir verwenden?", "into German", "Which payment provider should we use?")
+	m.On("Stripe, please.", "into English", "Stripe, bitte.")
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	c, _ := s.Board.CreateCard("Zahlungen hinzufügen", "Kunden zahlen mit Karte.", board.ListBacklog)
+	raw := c.(*sim.Card)
+
+	translator := model.NewTranslator(m)
+	if b := board.WithTranslation(s.Board, translator, map[string]string{"": "English"}); b != board.BoardClient(s.Board) {
+		t.Fatalf("expected English boards left alone")
+	}
+	translated := board.WithTranslation(s.Board, translator, map[string]string{"": "German"})
+	cards, err := translated.GetCardsFromList(board.ListBacklog)
+	if err != nil || len(cards) != 1 {
+		t.Fatalf("unexpected cards %v, %v", cards, err)
+	}
+	card := cards[0]
+	if card.GetName() != "Add payments" || card.GetDescription() != "Customers pay by card." {
+		t.Fatalf("expected the ticket in English, got %q: %q", card.GetName(), card.GetDescription())
+	}
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			if comments, _ := raw.ReadComments(); len(comments) > 0 {
+				if comments[0].Text == "Welchen Zahlungsanbieter sollen wir verwenden?" {
+					raw.Reply("po", "Stripe, bitte.")
+				}
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	a := &agent.BaseAgent{Name: "backend", ReplyInterval: time.Millisecond}
+	answer, err := a.Ask(card, "Which payment provider should we use?")
+	if err != nil || answer != "Stripe, please." {
+		t.Fatalf("expected the answer in English, got %q, %v", answer, err)
+	}
+	if err := card.WriteComment("[plan] Use Stripe checkout."); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+	comments, _ := card.ReadComments()
+	if len(comments) != 3 || comments[0].Text != "Which payment provider should we use?" || comments[2].Text != "[plan] Use Stripe checkout." {
+		t.Fatalf("expected the discussion in English, got %+v", comments)
+	}
+	if onBoard, _ := raw.ReadComments(); onBoard[2].Text != "[plan] Use Stripe checkout." {
+		t.Fatalf("expected other agent comments posted as written, got %q", onBoard[2].Text)
+	}
+
+	card.GetName()
+	card.GetDescription()
+	if calls := len(m.Calls()); calls != 4 {
+		t.Fatalf("expected every text translated once, got %d model calls", calls)
+	}
+}
+
+func TestTranslatedBoardKeepsOriginalDescription(t *testing.T) {
+	m := sim.NewScriptedModel()
+	m.On("Add payments", "into English", "Zahlungen hinzufügen")
+	m.On("Customers pay by card.\nThe shop lists prices in euros.", "into English", "Kunden zahlen mit Karte.\nDer Shop zeigt Preise in Euro.")
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	parent, _ := s.Board.CreateCard("Checkout", "", board.ListBacklog)
+	c, _ := s.Board.CreateCard("Zahlungen hinzufügen", "Kunden zahlen mit Karte.\nDer Shop zeigt Preise in Euro.", board.ListBacklog)
+	raw := c.(*sim.Card)
+
+	translated := board.WithTranslation(s.Board, model.NewTranslator(m), map[string]string{"": "German"})
+	cards, _ := translated.GetCardsFromList(board.ListBacklog)
+	card := cards[1]
+	if err := board.SetScore(card, 3); err != nil {
+		t.Fatalf("SetScore failed: %v", err)
+	}
+	if want := "Kunden zahlen mit Karte.\nDer Shop zeigt Preise in Euro.\n\nPriority score: 3"; raw.GetDescription() != want {
+		t.Fatalf("expected the score added to the German text, got %q", raw.GetDescription())
+	}
+	if err := board.SetScore(card, 5); err != nil {
+		t.Fatalf("SetScore failed: %v", err)
+	}
+	if err := board.LinkToParent(card, parent); err != nil {
+		t.Fatalf("LinkToParent failed: %v", err)
+	}
+	want := "Kunden zahlen mit Karte.\nDer Shop zeigt Preise in Euro.\n\nPriority score: 5\n\n---\nParent ticket: " + parent.GetURL()
+	if raw.GetDescription() != want {
+		t.Fatalf("expected the agents' edits applied to the German text, got %q", raw.GetDescription())
+	}
+	if score, ok := board.CardScore(card); !ok || score != 5 {
+		t.Fatalf("expected the agent to read its score back, got %v, %v", score, ok)
+	}
+	if url, ok := board.ParentURL(card.GetDescription()); !ok || url != parent.GetURL() {
+		t.Fatalf("expected the agent to read its parent link back, got %q", card.GetDescription())
+	}
+	if calls := len(m.Calls()); calls != 1 {
+		t.Fatalf("expected only the original description translated, got %d model calls", calls)
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/intake/logs"
+	"github.com/egobogo/aiagents/internal/sim"
+)
+
+func TestParseLogLines(t *testing.T) {
+	for _, tc := range []struct{ line, level, message string }{
+		{`{"level":"ERROR","msg":"checkout failed","error":"timeout","time":"2026-01-02T03:04:05Z"}`, "error", "checkout failed: timeout"},
+		{`2026-01-02 03:04:05 WARN disk almost full`, "warn", "2026-01-02 03:04:05 WARN disk almost full"},
+		{`ts=1 level=error msg="db down"`, "error", `ts=1 level=error msg="db down"`},
+		{`panic: runtime error: index out of range [3] with length 3`, "panic", "panic: runtime error: index out of range [3] with length 3"},
+		{`request served in 3ms`, "", "request served in 3ms"},
+	} {
+		e := logs.Parse(tc.line, "app.log")
+		if e.Level != tc.level || e.Message != tc.message {
+			t.Fatalf("%s: expected %q %q, got %q %q", tc.line, tc.level, tc.message, e.Level, e.Message)
+		}
+	}
+	a := logs.Signature(`order 1234 failed for user "ann" at 2026-01-02T03:04:05Z (id 0xdeadbeef)`)
+	b := logs.Signature(`order 99 failed for user "bob" at 2026-02-03T04:05:06Z (id 0xfeedface)`)
+	if a != b || a == logs.Signature("payment failed") {
+		t.Fatalf("expected variable parts to be ignored: %s, %s", a, b)
+	}
+}
+
+func TestFileSourceTails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("ERROR old\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	src := logs.NewFileSource(path)
+	if entries, err := src.Read(); err != nil || len(entries) != 0 {
+		t.Fatalf("expected the existing content to be skipped, got %v, %v", entries, err)
+	}
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString("ERROR first\nERROR sec")
+	entries, _ := src.Read()
+	f.WriteString("ond\n")
+	f.Close()
+	more, _ := src.Read()
+	if len(entries) != 1 || len(more) != 1 || more[0].Message != "ERROR second" {
+		t.Fatalf("expected complete lines only, got %v then %v", entries, more)
+	}
+}
+
+func TestLokiSource(t *testing.T) {
+	var starts []string
+	ns := time.Now().UnixNano()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		starts = append(starts, r.URL.Query().Get("start"))
+		fmt.Fprintf(w, `{"data": {"result": [{"stream": {"level": "error"}, "values": [["%d", "db down"], ["%d", "db down"]]}]}}`, ns+2, ns+1)
+	}))
+	defer server.Close()
+	src := logs.NewLokiSource(server.URL, `{app="shop"}`, time.Minute)
+	entries, err := src.Read()
+	if err != nil || len(entries) != 2 || entries[0].Level != "error" || entries[0].Time.UnixNano() != ns+1 {
+		t.Fatalf("unexpected entries %v, %v", entries, err)
+	}
+	src.Read()
+	if starts[1] != strconv.FormatInt(ns+3, 10) {
+		t.Fatalf("expected the next read to start after the last line, got %s", starts[1])
+	}
+}
+
+func TestTriageFilesPrioritizedBugs(t *testing.T) {
+	var lines []logs.Entry
+	for i := 0; i < 4; i++ {
+		lines = append(lines, logs.Parse(fmt.Sprintf(`{"level":"error","msg":"checkout failed for order %d"}`, 100+i), "app.log"))
+	}
+	for i := 0; i < 3; i++ {
+		lines = append(lines, logs.Parse(fmt.Sprintf("ERROR cache miss storm on shard %d", i), "app.log"))
+	}
+	lines = append(lines, logs.Parse("ERROR rare failure", "app.log"), logs.Parse("INFO checkout failed for order 1", "app.log"))
+	checkout, cache := logs.Signature("checkout failed for order 1"), logs.Signature("ERROR cache miss storm on shard 1")
+
+	m := sim.NewScriptedModel()
+	if _, err := m.OnMode("TriageErrors", agent.BugTriage{Bugs: []agent.BugReport{
+		{Signature: cache, Title: "Cache miss storms", Priority: "low", Summary: "Shards miss together."},
+		{Signature: checkout, Title: "Checkout fails", Priority: "high", Summary: "Orders cannot be paid.", SuspectedCommits: []string{"abc1234"}, Reproduction: "Pay any order."},
+		{Signature: "unknown", Title: "Made up", Priority: "high"},
+	}}); err != nil {
+		t.Fatalf("OnMode failed: %v", err)
+	}
+	s, err := sim.New(m)
+	if err != nil {
+		t.Fatalf("sim.New failed: %v", err)
+	}
+	defer s.Close()
+	a, err := s.Agent(agent.RoleTriage, "triage")
+	if err != nil {
+		t.Fatalf("Agent failed: %v", err)
+	}
+	triage := a.(*agent.TriageAgent)
+	triage.Ingest(lines)
+	created, err := triage.Triage()
+	if err != nil {
+		t.Fatalf("Triage failed: %v", err)
+	}
+	if len(created) != 2 || created[0].GetName() != "[bug] Checkout fails" || created[1].GetName() != "[bug] Cache miss storms" {
+		t.Fatalf("expected two tickets, highest priority first, got %v", created)
+	}
+	desc := created[0].GetDescription()
+	for _, want := range []string{"Priority: high", "Occurrences: 4", "checkout failed for order 100", "Suspected commits: abc1234", "Pay any order.", "Error signature: " + checkout} {
+		if !strings.Contains(desc, want) {
+			t.Fatalf("expected %q in the ticket, got:\n%s", want, desc)
+		}
+	}
+	prompt := requestContent(m.Calls()[0].Input[len(m.Calls()[0].Input)-1].Content)
+	if strings.Contains(prompt, "rare failure") || !strings.Contains(prompt, "chore: initial commit") {
+		t.Fatalf("expected only recurring errors and recent commits in the prompt, got:\n%s", prompt)
+	}
+
+	// Filed errors are not triaged again, even by a fresh agent.
+	a, _ = s.Agent(agent.RoleTriage, "triage2")
+	again := a.(*agent.TriageAgent)
+	again.Ingest(lines)
+	if created, err := again.Triage(); err != nil || len(created) != 0 || len(m.Calls()) != 1 {
+		t.Fatalf("expected no new triage, got %v, %v", created, err)
+	}
+	if cards, _ := s.Board.GetCardsFromList(board.ListBugs); len(cards) != 2 {
+		t.Fatalf("expected two bug tickets, got %d", len(cards))
+	}
+}
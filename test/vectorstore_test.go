@@ -0,0 +1,153 @@
+package test
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/knowledge"
+	"github.com/egobogo/aiagents/internal/memory"
+)
+
+// wordEmbedder hashes words into a small bag-of-words vector.
+type wordEmbedder struct{}
+
+func (wordEmbedder) ComputeEmbedding(text string) ([]float64, error) {
+	v := make([]float64, 32)
+	for _, w := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(strings.Trim(w, ".,?!")))
+		v[h.Sum32()%32]++
+	}
+	return v, nil
+}
+
+func TestFileStorePersistsAndFilters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vectors.json")
+	store, err := memory.NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	err = store.Upsert(
+		memory.Record{ID: "a", Vector: []float64{1, 0}, Text: "east", Metadata: map[string]string{"team": "x"}},
+		memory.Record{ID: "b", Vector: []float64{0, 1}, Text: "north", Metadata: map[string]string{"team": "x"}},
+		memory.Record{ID: "c", Vector: []float64{1, 0.1}, Text: "east-ish", Metadata: map[string]string{"team": "y"}},
+	)
+	if err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	reopened, err := memory.NewFileStore(path)
+	if err != nil || reopened.Len() != 3 {
+		t.Fatalf("expected 3 persisted records, got %d, %v", reopened.Len(), err)
+	}
+	matches, err := reopened.Query([]float64{1, 0}, 1, memory.Filter{"team": "x"})
+	if err != nil || len(matches) != 1 || matches[0].ID != "a" || matches[0].Score < 0.99 {
+		t.Fatalf("unexpected matches: %+v, %v", matches, err)
+	}
+	if _, err := reopened.Query([]float64{1, 0, 0}, 1, nil); err != memory.ErrDimension {
+		t.Fatalf("expected ErrDimension, got %v", err)
+	}
+
+	if err := reopened.DeleteWhere(memory.Filter{"team": "x"}); err != nil {
+		t.Fatalf("DeleteWhere failed: %v", err)
+	}
+	if err := reopened.Delete("c"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if again, _ := memory.NewFileStore(path); again.Len() != 0 {
+		t.Fatalf("deletions were not persisted: %d records left", again.Len())
+	}
+}
+
+func TestSearcherKeepsAgentMemoriesApart(t *testing.T) {
+	store := memory.NewInMemory()
+	alice, bob := memory.NewSearcher(store, "alice"), memory.NewSearcher(store, "bob")
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := alice.IndexMemory(context.MemoryEntry{ID: "1", Category: "Architecture", Content: "We use chi", Timestamp: now, Importance: 3, Embedding: []float64{1, 0}}); err != nil {
+		t.Fatalf("IndexMemory failed: %v", err)
+	}
+	if err := bob.IndexMemory(context.MemoryEntry{ID: "1", Content: "Bob's note", Embedding: []float64{1, 0}}); err != nil {
+		t.Fatalf("IndexMemory failed: %v", err)
+	}
+
+	found, err := alice.Search([]float64{1, 0}, 5, 0.5)
+	if err != nil || len(found) != 1 {
+		t.Fatalf("expected one memory, got %+v, %v", found, err)
+	}
+	if m := found[0]; m.ID != "1" || m.Content != "We use chi" || m.Category != "Architecture" || m.Importance != 3 || !m.Timestamp.Equal(now) {
+		t.Fatalf("memory did not round-trip: %+v", m)
+	}
+	if found, _ := alice.Search([]float64{0, 1}, 5, 0.5); len(found) != 0 {
+		t.Fatalf("threshold should exclude dissimilar memories, got %+v", found)
+	}
+}
+
+func TestQdrantStoreSpeaksRESTAPI(t *testing.T) {
+	var paths []string
+	var upserted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/points") && r.Method == http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&upserted)
+			w.Write([]byte(`{"result":{}}`))
+		case strings.HasSuffix(r.URL.Path, "/points/search"):
+			w.Write([]byte(`{"result":[{"id":"x","score":0.9,"vector":[1,0],"payload":{"_id":"doc#1","text":"hello","kind":"adr"}}]}`))
+		default:
+			w.Write([]byte(`{"result":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	q, err := memory.NewQdrant(srv.URL, "kb", "", 2)
+	if err != nil {
+		t.Fatalf("NewQdrant failed: %v", err)
+	}
+	if err := q.Upsert(memory.Record{ID: "doc#1", Vector: []float64{1, 0}, Text: "hello"}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	points, _ := upserted["points"].([]interface{})
+	if len(points) != 1 {
+		t.Fatalf("expected one point, got %+v", upserted)
+	}
+	matches, err := q.Query([]float64{1, 0}, 3, memory.Filter{"kind": "adr"})
+	if err != nil || len(matches) != 1 || matches[0].ID != "doc#1" || matches[0].Metadata["kind"] != "adr" || matches[0].Score != 0.9 {
+		t.Fatalf("unexpected matches: %+v, %v", matches, err)
+	}
+	want := []string{"GET /collections/kb", "PUT /collections/kb", "PUT /collections/kb/points", "POST /collections/kb/points/search"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected requests: %v", paths)
+	}
+}
+
+func TestKnowledgeSearchesVectorStore(t *testing.T) {
+	store := memory.NewInMemory()
+	kb := knowledge.New(wordEmbedder{})
+	kb.Store = store
+	if err := kb.Add("docs/adr/0001-db.md", "# Database\n\nWe store orders in postgres."); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := kb.Add("docs/guide.md", "# Logging\n\nLog with slog."); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if store.Len() != 2 {
+		t.Fatalf("expected chunks in the store, got %d", store.Len())
+	}
+
+	// A fresh base over the same store finds the chunks without re-ingesting.
+	restarted := knowledge.New(wordEmbedder{})
+	restarted.Store = store
+	hits, err := restarted.Search("where do we store orders", 1)
+	if err != nil || len(hits) != 1 || hits[0].Source != "docs/adr/0001-db.md" || hits[0].Kind != knowledge.KindADR {
+		t.Fatalf("unexpected hits: %+v, %v", hits, err)
+	}
+}